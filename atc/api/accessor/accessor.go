@@ -15,13 +15,15 @@ type Access interface {
 	IsAdmin() bool
 	IsSystem() bool
 	TeamNames() []string
+	TeamRoles() map[string][]string
 	CSRFToken() string
 	UserName() string
 }
 
 type access struct {
 	*jwt.Token
-	action string
+	action      string
+	permissions map[string]map[string]bool
 }
 
 func (a *access) HasToken() bool {
@@ -59,18 +61,7 @@ func (a *access) IsAuthorized(team string) bool {
 }
 
 func (a *access) hasPermission(role string) bool {
-	switch requiredRoles[a.action] {
-	case "owner":
-		return role == "owner"
-	case "member":
-		return role == "owner" || role == "member"
-	case "pipeline-operator":
-		return role == "owner" || role == "member" || role == "pipeline-operator"
-	case "viewer":
-		return role == "owner" || role == "member" || role == "pipeline-operator" || role == "viewer"
-	default:
-		return false
-	}
+	return a.permissions[role][a.action]
 }
 
 func (a *access) IsAdmin() bool {
@@ -141,6 +132,8 @@ func (a *access) UserName() string {
 	return ""
 }
 
+// requiredRoles maps each action to the least privileged built-in role
+// permitted to perform it by default.
 var requiredRoles = map[string]string{
 	atc.SaveConfig:                    "member",
 	atc.GetConfig:                     "viewer",
@@ -156,6 +149,7 @@ var requiredRoles = map[string]string{
 	atc.GetBuildPreparation:           "viewer",
 	atc.GetJob:                        "viewer",
 	atc.CreateJobBuild:                "pipeline-operator",
+	atc.RerunJobBuild:                 "pipeline-operator",
 	atc.ListAllJobs:                   "viewer",
 	atc.ListJobs:                      "viewer",
 	atc.ListJobBuilds:                 "viewer",
@@ -209,6 +203,7 @@ var requiredRoles = map[string]string{
 	atc.DownloadCLI:                   "viewer",
 	atc.GetInfo:                       "viewer",
 	atc.GetInfoCreds:                  "viewer",
+	atc.GetInfoRoles:                  "viewer",
 	atc.ListContainers:                "viewer",
 	atc.GetContainer:                  "viewer",
 	atc.HijackContainer:               "member",
@@ -223,7 +218,17 @@ var requiredRoles = map[string]string{
 	atc.RenameTeam:                    "owner",
 	atc.DestroyTeam:                   "owner",
 	atc.ListTeamBuilds:                "viewer",
+	atc.SearchBuildLogs:               "viewer",
+	atc.ListSemaphores:                "viewer",
 	atc.CreateArtifact:                "member",
 	atc.GetArtifact:                   "member",
 	atc.ListBuildArtifacts:            "viewer",
+	atc.ListArchivedArtifacts:         "viewer",
+	atc.ListBuildTestResults:          "viewer",
+	atc.ListBuildApprovals:            "viewer",
+	atc.ApproveBuild:                  "pipeline-operator",
+	atc.RejectBuild:                   "pipeline-operator",
+	atc.ListJobTestHistory:            "viewer",
+	atc.GetJobStats:                   "viewer",
+	atc.GetArchivedArtifact:           "viewer",
 }
@@ -35,7 +35,7 @@ var _ = Describe("Accessor", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		publicKey := &key.PublicKey
-		accessorFactory = accessor.NewAccessFactory(publicKey)
+		accessorFactory = accessor.NewAccessFactory(publicKey, accessor.DefaultRoleActionMap())
 
 	})
 
@@ -517,6 +517,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.CreateJobBuild, atc.CreateJobBuild, "pipeline-operator", true),
 		Entry("viewer :: "+atc.CreateJobBuild, atc.CreateJobBuild, "viewer", false),
 
+		Entry("owner :: "+atc.RerunJobBuild, atc.RerunJobBuild, "owner", true),
+		Entry("member :: "+atc.RerunJobBuild, atc.RerunJobBuild, "member", true),
+		Entry("pipeline-operator :: "+atc.RerunJobBuild, atc.RerunJobBuild, "pipeline-operator", true),
+		Entry("viewer :: "+atc.RerunJobBuild, atc.RerunJobBuild, "viewer", false),
+
 		Entry("owner :: "+atc.ListAllJobs, atc.ListAllJobs, "owner", true),
 		Entry("member :: "+atc.ListAllJobs, atc.ListAllJobs, "member", true),
 		Entry("pipeline-operator :: "+atc.ListAllJobs, atc.ListAllJobs, "pipeline-operator", true),
@@ -767,6 +772,11 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.GetInfoCreds, atc.GetInfoCreds, "pipeline-operator", true),
 		Entry("viewer :: "+atc.GetInfoCreds, atc.GetInfoCreds, "viewer", true),
 
+		Entry("owner :: "+atc.GetInfoRoles, atc.GetInfoRoles, "owner", true),
+		Entry("member :: "+atc.GetInfoRoles, atc.GetInfoRoles, "member", true),
+		Entry("pipeline-operator :: "+atc.GetInfoRoles, atc.GetInfoRoles, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetInfoRoles, atc.GetInfoRoles, "viewer", true),
+
 		Entry("owner :: "+atc.ListContainers, atc.ListContainers, "owner", true),
 		Entry("member :: "+atc.ListContainers, atc.ListContainers, "member", true),
 		Entry("pipeline-operator :: "+atc.ListContainers, atc.ListContainers, "pipeline-operator", true),
@@ -836,6 +846,16 @@ var _ = Describe("Accessor", func() {
 		Entry("pipeline-operator :: "+atc.ListTeamBuilds, atc.ListTeamBuilds, "pipeline-operator", true),
 		Entry("viewer :: "+atc.ListTeamBuilds, atc.ListTeamBuilds, "viewer", true),
 
+		Entry("owner :: "+atc.SearchBuildLogs, atc.SearchBuildLogs, "owner", true),
+		Entry("member :: "+atc.SearchBuildLogs, atc.SearchBuildLogs, "member", true),
+		Entry("pipeline-operator :: "+atc.SearchBuildLogs, atc.SearchBuildLogs, "pipeline-operator", true),
+		Entry("viewer :: "+atc.SearchBuildLogs, atc.SearchBuildLogs, "viewer", true),
+
+		Entry("owner :: "+atc.ListSemaphores, atc.ListSemaphores, "owner", true),
+		Entry("member :: "+atc.ListSemaphores, atc.ListSemaphores, "member", true),
+		Entry("pipeline-operator :: "+atc.ListSemaphores, atc.ListSemaphores, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ListSemaphores, atc.ListSemaphores, "viewer", true),
+
 		Entry("owner :: "+atc.CreateArtifact, atc.CreateArtifact, "owner", true),
 		Entry("member :: "+atc.CreateArtifact, atc.CreateArtifact, "member", true),
 		Entry("pipeline-operator :: "+atc.CreateArtifact, atc.CreateArtifact, "pipeline-operator", false),
@@ -850,5 +870,45 @@ var _ = Describe("Accessor", func() {
 		Entry("member :: "+atc.ListBuildArtifacts, atc.ListBuildArtifacts, "member", true),
 		Entry("pipeline-operator :: "+atc.ListBuildArtifacts, atc.ListBuildArtifacts, "pipeline-operator", true),
 		Entry("viewer :: "+atc.ListBuildArtifacts, atc.ListBuildArtifacts, "viewer", true),
+
+		Entry("owner :: "+atc.ListArchivedArtifacts, atc.ListArchivedArtifacts, "owner", true),
+		Entry("member :: "+atc.ListArchivedArtifacts, atc.ListArchivedArtifacts, "member", true),
+		Entry("pipeline-operator :: "+atc.ListArchivedArtifacts, atc.ListArchivedArtifacts, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ListArchivedArtifacts, atc.ListArchivedArtifacts, "viewer", true),
+
+		Entry("owner :: "+atc.ListBuildTestResults, atc.ListBuildTestResults, "owner", true),
+		Entry("member :: "+atc.ListBuildTestResults, atc.ListBuildTestResults, "member", true),
+		Entry("pipeline-operator :: "+atc.ListBuildTestResults, atc.ListBuildTestResults, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ListBuildTestResults, atc.ListBuildTestResults, "viewer", true),
+
+		Entry("owner :: "+atc.ListBuildApprovals, atc.ListBuildApprovals, "owner", true),
+		Entry("member :: "+atc.ListBuildApprovals, atc.ListBuildApprovals, "member", true),
+		Entry("pipeline-operator :: "+atc.ListBuildApprovals, atc.ListBuildApprovals, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ListBuildApprovals, atc.ListBuildApprovals, "viewer", true),
+
+		Entry("owner :: "+atc.ApproveBuild, atc.ApproveBuild, "owner", true),
+		Entry("member :: "+atc.ApproveBuild, atc.ApproveBuild, "member", true),
+		Entry("pipeline-operator :: "+atc.ApproveBuild, atc.ApproveBuild, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ApproveBuild, atc.ApproveBuild, "viewer", false),
+
+		Entry("owner :: "+atc.RejectBuild, atc.RejectBuild, "owner", true),
+		Entry("member :: "+atc.RejectBuild, atc.RejectBuild, "member", true),
+		Entry("pipeline-operator :: "+atc.RejectBuild, atc.RejectBuild, "pipeline-operator", true),
+		Entry("viewer :: "+atc.RejectBuild, atc.RejectBuild, "viewer", false),
+
+		Entry("owner :: "+atc.ListJobTestHistory, atc.ListJobTestHistory, "owner", true),
+		Entry("member :: "+atc.ListJobTestHistory, atc.ListJobTestHistory, "member", true),
+		Entry("pipeline-operator :: "+atc.ListJobTestHistory, atc.ListJobTestHistory, "pipeline-operator", true),
+		Entry("viewer :: "+atc.ListJobTestHistory, atc.ListJobTestHistory, "viewer", true),
+
+		Entry("owner :: "+atc.GetJobStats, atc.GetJobStats, "owner", true),
+		Entry("member :: "+atc.GetJobStats, atc.GetJobStats, "member", true),
+		Entry("pipeline-operator :: "+atc.GetJobStats, atc.GetJobStats, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetJobStats, atc.GetJobStats, "viewer", true),
+
+		Entry("owner :: "+atc.GetArchivedArtifact, atc.GetArchivedArtifact, "owner", true),
+		Entry("member :: "+atc.GetArchivedArtifact, atc.GetArchivedArtifact, "member", true),
+		Entry("pipeline-operator :: "+atc.GetArchivedArtifact, atc.GetArchivedArtifact, "pipeline-operator", true),
+		Entry("viewer :: "+atc.GetArchivedArtifact, atc.GetArchivedArtifact, "viewer", true),
 	)
 })
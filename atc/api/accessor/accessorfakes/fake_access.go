@@ -79,6 +79,16 @@ type FakeAccess struct {
 	teamNamesReturnsOnCall map[int]struct {
 		result1 []string
 	}
+	TeamRolesStub        func() map[string][]string
+	teamRolesMutex       sync.RWMutex
+	teamRolesArgsForCall []struct {
+	}
+	teamRolesReturns struct {
+		result1 map[string][]string
+	}
+	teamRolesReturnsOnCall map[int]struct {
+		result1 map[string][]string
+	}
 	UserNameStub        func() string
 	userNameMutex       sync.RWMutex
 	userNameArgsForCall []struct {
@@ -465,6 +475,58 @@ func (fake *FakeAccess) TeamNamesReturnsOnCall(i int, result1 []string) {
 	}{result1}
 }
 
+func (fake *FakeAccess) TeamRoles() map[string][]string {
+	fake.teamRolesMutex.Lock()
+	ret, specificReturn := fake.teamRolesReturnsOnCall[len(fake.teamRolesArgsForCall)]
+	fake.teamRolesArgsForCall = append(fake.teamRolesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("TeamRoles", []interface{}{})
+	fake.teamRolesMutex.Unlock()
+	if fake.TeamRolesStub != nil {
+		return fake.TeamRolesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.teamRolesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeAccess) TeamRolesCallCount() int {
+	fake.teamRolesMutex.RLock()
+	defer fake.teamRolesMutex.RUnlock()
+	return len(fake.teamRolesArgsForCall)
+}
+
+func (fake *FakeAccess) TeamRolesCalls(stub func() map[string][]string) {
+	fake.teamRolesMutex.Lock()
+	defer fake.teamRolesMutex.Unlock()
+	fake.TeamRolesStub = stub
+}
+
+func (fake *FakeAccess) TeamRolesReturns(result1 map[string][]string) {
+	fake.teamRolesMutex.Lock()
+	defer fake.teamRolesMutex.Unlock()
+	fake.TeamRolesStub = nil
+	fake.teamRolesReturns = struct {
+		result1 map[string][]string
+	}{result1}
+}
+
+func (fake *FakeAccess) TeamRolesReturnsOnCall(i int, result1 map[string][]string) {
+	fake.teamRolesMutex.Lock()
+	defer fake.teamRolesMutex.Unlock()
+	fake.TeamRolesStub = nil
+	if fake.teamRolesReturnsOnCall == nil {
+		fake.teamRolesReturnsOnCall = make(map[int]struct {
+			result1 map[string][]string
+		})
+	}
+	fake.teamRolesReturnsOnCall[i] = struct {
+		result1 map[string][]string
+	}{result1}
+}
+
 func (fake *FakeAccess) UserName() string {
 	fake.userNameMutex.Lock()
 	ret, specificReturn := fake.userNameReturnsOnCall[len(fake.userNameArgsForCall)]
@@ -534,6 +596,8 @@ func (fake *FakeAccess) Invocations() map[string][][]interface{} {
 	defer fake.isSystemMutex.RUnlock()
 	fake.teamNamesMutex.RLock()
 	defer fake.teamNamesMutex.RUnlock()
+	fake.teamRolesMutex.RLock()
+	defer fake.teamRolesMutex.RUnlock()
 	fake.userNameMutex.RLock()
 	defer fake.userNameMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,148 @@
+package accessor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/concourse/concourse/atc"
+)
+
+const (
+	OwnerRole            = "owner"
+	MemberRole           = "member"
+	PipelineOperatorRole = "pipeline-operator"
+	ViewerRole           = "viewer"
+)
+
+// builtInRoles are ordered from most to least privileged. Each role is
+// permitted to perform the actions of every role that follows it.
+var builtInRoles = []string{
+	OwnerRole,
+	MemberRole,
+	PipelineOperatorRole,
+	ViewerRole,
+}
+
+// RoleActionMap maps role names to the actions they are permitted to perform.
+// Actions are named after the routes in atc/routes.go.
+type RoleActionMap map[string][]string
+
+// DefaultRoleActionMap returns the actions permitted to each built-in role.
+func DefaultRoleActionMap() RoleActionMap {
+	return roleActionMap(requiredRoles, nil)
+}
+
+// CustomizeRoleActionMap returns the role action map resulting from applying
+// the given customizations to the defaults.
+//
+// Listing an action under a built-in role makes that role the least
+// privileged role permitted to perform it, e.g. moving an action from member
+// to pipeline-operator also permits owners and members to perform it. Any
+// other role name defines a custom role which is permitted to perform exactly
+// the actions listed.
+//
+// Every action must be the name of a route in atc/routes.go.
+func CustomizeRoleActionMap(customizations RoleActionMap) (RoleActionMap, error) {
+	knownActions := map[string]bool{}
+	for _, route := range atc.Routes {
+		knownActions[route.Name] = true
+	}
+
+	required := map[string]string{}
+	for action, role := range requiredRoles {
+		required[action] = role
+	}
+
+	customized := map[string]string{}
+	customRoles := RoleActionMap{}
+
+	for _, role := range sortedRoles(customizations) {
+		if role == "" {
+			return nil, fmt.Errorf("role name must not be empty")
+		}
+
+		for _, action := range customizations[role] {
+			if !knownActions[action] {
+				return nil, fmt.Errorf("unknown action '%s' for role '%s'", action, role)
+			}
+		}
+
+		if !isBuiltInRole(role) {
+			customRoles[role] = customizations[role]
+			continue
+		}
+
+		for _, action := range customizations[role] {
+			if other, found := customized[action]; found && other != role {
+				return nil, fmt.Errorf("action '%s' is assigned to both role '%s' and role '%s'", action, other, role)
+			}
+
+			customized[action] = role
+			required[action] = role
+		}
+	}
+
+	return roleActionMap(required, customRoles), nil
+}
+
+func roleActionMap(required map[string]string, customRoles RoleActionMap) RoleActionMap {
+	roleActions := RoleActionMap{}
+
+	for i, role := range builtInRoles {
+		actions := []string{}
+		for action, requiredRole := range required {
+			if rank(requiredRole) >= i {
+				actions = append(actions, action)
+			}
+		}
+
+		sort.Strings(actions)
+		roleActions[role] = actions
+	}
+
+	for role, customActions := range customRoles {
+		actions := append([]string{}, customActions...)
+		sort.Strings(actions)
+		roleActions[role] = actions
+	}
+
+	return roleActions
+}
+
+func (roleActions RoleActionMap) permissions() map[string]map[string]bool {
+	permissions := map[string]map[string]bool{}
+
+	for role, actions := range roleActions {
+		permissions[role] = map[string]bool{}
+		for _, action := range actions {
+			permissions[role][action] = true
+		}
+	}
+
+	return permissions
+}
+
+func rank(role string) int {
+	for i, builtInRole := range builtInRoles {
+		if builtInRole == role {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isBuiltInRole(role string) bool {
+	return rank(role) != -1
+}
+
+func sortedRoles(roleActions RoleActionMap) []string {
+	roles := []string{}
+	for role := range roleActions {
+		roles = append(roles, role)
+	}
+
+	sort.Strings(roles)
+
+	return roles
+}
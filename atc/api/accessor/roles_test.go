@@ -0,0 +1,204 @@
+package accessor_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	jwt "github.com/dgrijalva/jwt-go"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Roles", func() {
+	Describe("DefaultRoleActionMap", func() {
+		var roleActions accessor.RoleActionMap
+
+		BeforeEach(func() {
+			roleActions = accessor.DefaultRoleActionMap()
+		})
+
+		It("includes only the built-in roles", func() {
+			Expect(roleActions).To(HaveLen(4))
+			Expect(roleActions).To(HaveKey(accessor.OwnerRole))
+			Expect(roleActions).To(HaveKey(accessor.MemberRole))
+			Expect(roleActions).To(HaveKey(accessor.PipelineOperatorRole))
+			Expect(roleActions).To(HaveKey(accessor.ViewerRole))
+		})
+
+		It("permits more privileged roles to perform the actions of less privileged roles", func() {
+			Expect(roleActions[accessor.OwnerRole]).To(ContainElement(atc.SetTeam))
+			Expect(roleActions[accessor.OwnerRole]).To(ContainElement(atc.SaveConfig))
+			Expect(roleActions[accessor.OwnerRole]).To(ContainElement(atc.GetPipeline))
+
+			Expect(roleActions[accessor.MemberRole]).ToNot(ContainElement(atc.SetTeam))
+			Expect(roleActions[accessor.MemberRole]).To(ContainElement(atc.SaveConfig))
+			Expect(roleActions[accessor.MemberRole]).To(ContainElement(atc.CreateJobBuild))
+
+			Expect(roleActions[accessor.PipelineOperatorRole]).ToNot(ContainElement(atc.SaveConfig))
+			Expect(roleActions[accessor.PipelineOperatorRole]).To(ContainElement(atc.CreateJobBuild))
+			Expect(roleActions[accessor.PipelineOperatorRole]).To(ContainElement(atc.GetPipeline))
+
+			Expect(roleActions[accessor.ViewerRole]).ToNot(ContainElement(atc.CreateJobBuild))
+			Expect(roleActions[accessor.ViewerRole]).To(ContainElement(atc.GetPipeline))
+		})
+	})
+
+	Describe("CustomizeRoleActionMap", func() {
+		var (
+			customizations accessor.RoleActionMap
+			roleActions    accessor.RoleActionMap
+			customizeErr   error
+		)
+
+		BeforeEach(func() {
+			customizations = accessor.RoleActionMap{}
+		})
+
+		JustBeforeEach(func() {
+			roleActions, customizeErr = accessor.CustomizeRoleActionMap(customizations)
+		})
+
+		Context("with no customizations", func() {
+			It("returns the defaults", func() {
+				Expect(customizeErr).ToNot(HaveOccurred())
+				Expect(roleActions).To(Equal(accessor.DefaultRoleActionMap()))
+			})
+		})
+
+		Context("when an action is moved to a less privileged built-in role", func() {
+			BeforeEach(func() {
+				customizations = accessor.RoleActionMap{
+					accessor.ViewerRole: {atc.CreateJobBuild},
+				}
+			})
+
+			It("permits the role and every more privileged role to perform it", func() {
+				Expect(customizeErr).ToNot(HaveOccurred())
+				Expect(roleActions[accessor.OwnerRole]).To(ContainElement(atc.CreateJobBuild))
+				Expect(roleActions[accessor.MemberRole]).To(ContainElement(atc.CreateJobBuild))
+				Expect(roleActions[accessor.PipelineOperatorRole]).To(ContainElement(atc.CreateJobBuild))
+				Expect(roleActions[accessor.ViewerRole]).To(ContainElement(atc.CreateJobBuild))
+			})
+		})
+
+		Context("when an action is moved to a more privileged built-in role", func() {
+			BeforeEach(func() {
+				customizations = accessor.RoleActionMap{
+					accessor.OwnerRole: {atc.HijackContainer},
+				}
+			})
+
+			It("no longer permits less privileged roles to perform it", func() {
+				Expect(customizeErr).ToNot(HaveOccurred())
+				Expect(roleActions[accessor.OwnerRole]).To(ContainElement(atc.HijackContainer))
+				Expect(roleActions[accessor.MemberRole]).ToNot(ContainElement(atc.HijackContainer))
+			})
+		})
+
+		Context("when a custom role is defined", func() {
+			BeforeEach(func() {
+				customizations = accessor.RoleActionMap{
+					"pipeline-pinner": {atc.PinResourceVersion, atc.CreateJobBuild},
+				}
+			})
+
+			It("permits the role to perform exactly the given actions", func() {
+				Expect(customizeErr).ToNot(HaveOccurred())
+				Expect(roleActions["pipeline-pinner"]).To(Equal([]string{atc.CreateJobBuild, atc.PinResourceVersion}))
+			})
+
+			It("keeps the built-in roles", func() {
+				Expect(roleActions).To(HaveLen(5))
+				Expect(roleActions[accessor.MemberRole]).To(Equal(accessor.DefaultRoleActionMap()[accessor.MemberRole]))
+			})
+		})
+
+		Context("when an action is unknown", func() {
+			BeforeEach(func() {
+				customizations = accessor.RoleActionMap{
+					"pipeline-pinner": {"SomeAction"},
+				}
+			})
+
+			It("errors", func() {
+				Expect(customizeErr).To(MatchError("unknown action 'SomeAction' for role 'pipeline-pinner'"))
+			})
+		})
+
+		Context("when a role name is empty", func() {
+			BeforeEach(func() {
+				customizations = accessor.RoleActionMap{
+					"": {atc.GetPipeline},
+				}
+			})
+
+			It("errors", func() {
+				Expect(customizeErr).To(MatchError("role name must not be empty"))
+			})
+		})
+
+		Context("when an action is assigned to multiple built-in roles", func() {
+			BeforeEach(func() {
+				customizations = accessor.RoleActionMap{
+					accessor.MemberRole: {atc.HijackContainer},
+					accessor.OwnerRole:  {atc.HijackContainer},
+				}
+			})
+
+			It("errors", func() {
+				Expect(customizeErr).To(MatchError("action 'HijackContainer' is assigned to both role 'member' and role 'owner'"))
+			})
+		})
+	})
+
+	Describe("authorizing a custom role", func() {
+		var (
+			key             *rsa.PrivateKey
+			accessorFactory accessor.AccessFactory
+		)
+
+		BeforeEach(func() {
+			var err error
+			key, err = rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+
+			roleActions, err := accessor.CustomizeRoleActionMap(accessor.RoleActionMap{
+				"pipeline-pinner": {atc.PinResourceVersion, atc.CreateJobBuild},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			accessorFactory = accessor.NewAccessFactory(&key.PublicKey, roleActions)
+		})
+
+		accessFor := func(action string) accessor.Access {
+			claims := &jwt.MapClaims{"teams": map[string][]string{"some-team": {"pipeline-pinner"}}}
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+			tokenString, err := token.SignedString(key)
+			Expect(err).NotTo(HaveOccurred())
+
+			req, err := http.NewRequest("GET", "localhost:8080", nil)
+			Expect(err).NotTo(HaveOccurred())
+			req.Header.Add("Authorization", fmt.Sprintf("BEARER %s", tokenString))
+
+			return accessorFactory.Create(req, action)
+		}
+
+		It("is authorized to perform the role's actions", func() {
+			Expect(accessFor(atc.CreateJobBuild).IsAuthorized("some-team")).To(BeTrue())
+			Expect(accessFor(atc.PinResourceVersion).IsAuthorized("some-team")).To(BeTrue())
+		})
+
+		It("is not authorized to perform any other actions", func() {
+			Expect(accessFor(atc.HijackContainer).IsAuthorized("some-team")).To(BeFalse())
+			Expect(accessFor(atc.GetPipeline).IsAuthorized("some-team")).To(BeFalse())
+		})
+
+		It("is not authorized for other teams", func() {
+			Expect(accessFor(atc.CreateJobBuild).IsAuthorized("other-team")).To(BeFalse())
+		})
+	})
+})
@@ -16,6 +16,7 @@ import (
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/api/containerserver/containerserverfakes"
 	"github.com/concourse/concourse/atc/auditor/auditorfakes"
+	"github.com/concourse/concourse/atc/blobstore/blobstorefakes"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
@@ -50,10 +51,14 @@ var (
 	build                   *dbfakes.FakeBuild
 	dbBuildFactory          *dbfakes.FakeBuildFactory
 	dbUserFactory           *dbfakes.FakeUserFactory
+	dbSecretAccessFactory   *dbfakes.FakeSecretAccessFactory
+	dbEncryptionKeyRotation *dbfakes.FakeEncryptionKeyRotation
 	dbCheckFactory          *dbfakes.FakeCheckFactory
 	dbTeam                  *dbfakes.FakeTeam
 	fakeSecretManager       *credsfakes.FakeSecrets
+	fakeVarSourcePool       *credsfakes.FakeVarSourcePool
 	credsManagers           creds.Managers
+	fakeArtifactStore       *blobstorefakes.FakeStore
 	interceptTimeoutFactory *containerserverfakes.FakeInterceptTimeoutFactory
 	interceptTimeout        *containerserverfakes.FakeInterceptTimeout
 	expire                  time.Duration
@@ -95,6 +100,8 @@ var _ = BeforeEach(func() {
 	dbResourceConfigFactory = new(dbfakes.FakeResourceConfigFactory)
 	dbBuildFactory = new(dbfakes.FakeBuildFactory)
 	dbUserFactory = new(dbfakes.FakeUserFactory)
+	dbSecretAccessFactory = new(dbfakes.FakeSecretAccessFactory)
+	dbEncryptionKeyRotation = new(dbfakes.FakeEncryptionKeyRotation)
 	dbCheckFactory = new(dbfakes.FakeCheckFactory)
 
 	interceptTimeoutFactory = new(containerserverfakes.FakeInterceptTimeoutFactory)
@@ -123,6 +130,8 @@ var _ = BeforeEach(func() {
 	fakeDestroyer = new(gcfakes.FakeDestroyer)
 
 	fakeSecretManager = new(credsfakes.FakeSecrets)
+	fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
+	fakeArtifactStore = new(blobstorefakes.FakeStore)
 	credsManagers = make(creds.Managers)
 	var err error
 
@@ -174,6 +183,8 @@ var _ = BeforeEach(func() {
 		dbCheckFactory,
 		dbResourceConfigFactory,
 		dbUserFactory,
+		dbSecretAccessFactory,
+		dbEncryptionKeyRotation,
 
 		constructedEventHandler.Construct,
 
@@ -187,7 +198,10 @@ var _ = BeforeEach(func() {
 		"1.2.3",
 		"4.5.6",
 		fakeSecretManager,
+		fakeVarSourcePool,
 		credsManagers,
+		accessor.DefaultRoleActionMap(),
+		fakeArtifactStore,
 		interceptTimeoutFactory,
 	)
 
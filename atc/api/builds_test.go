@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/blobstore"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/onsi/ginkgo"
@@ -79,6 +80,7 @@ var _ = Describe("Builds API", func() {
 			Context("when authorized", func() {
 				BeforeEach(func() {
 					fakeAccess.IsAuthorizedReturns(true)
+					fakeAccess.UserNameReturns("some-user")
 				})
 
 				Context("when creating a started build fails", func() {
@@ -117,7 +119,12 @@ var _ = Describe("Builds API", func() {
 
 					It("creates a started build", func() {
 						Expect(dbTeam.CreateStartedBuildCallCount()).To(Equal(1))
-						Expect(dbTeam.CreateStartedBuildArgsForCall(0)).To(Equal(plan))
+						actualPlan, createdBy := dbTeam.CreateStartedBuildArgsForCall(0)
+						Expect(actualPlan).To(Equal(plan))
+						Expect(createdBy).To(Equal(atc.BuildCreator{
+							Type: atc.BuildCreatorAPI,
+							User: "some-user",
+						}))
 					})
 
 					It("returns the created build", func() {
@@ -585,6 +592,20 @@ var _ = Describe("Builds API", func() {
 						"reap_time": 200
 					}`))
 						})
+
+						Context("when the build belongs to a pipeline instance", func() {
+							BeforeEach(func() {
+								build.PipelineInstanceVarsReturns(atc.InstanceVars{"branch": "feature"})
+							})
+
+							It("returns the instance vars of the pipeline", func() {
+								var presented atc.Build
+								err := json.NewDecoder(response.Body).Decode(&presented)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(presented.PipelineInstanceVars).To(Equal(atc.InstanceVars{"branch": "feature"}))
+							})
+						})
 					})
 				})
 			})
@@ -1460,4 +1481,517 @@ var _ = Describe("Builds API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/builds/:build_id/archived-artifacts", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/archived-artifacts")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated and authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				build.IDReturns(42)
+				build.TeamNameReturns("some-team")
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when the build has archived artifacts", func() {
+				BeforeEach(func() {
+					build.ArchivedArtifactsReturns([]db.ArchivedArtifact{
+						{PlanID: "some-plan", Name: "some-artifact", Size: 1024, CreatedAt: time.Unix(1, 0)},
+						{PlanID: "some-other-plan", Name: "some-artifact", Size: 2048, CreatedAt: time.Unix(2, 0)},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the archived artifacts", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{"plan_id": "some-plan", "name": "some-artifact", "size": 1024, "created_at": 1},
+						{"plan_id": "some-other-plan", "name": "some-artifact", "size": 2048, "created_at": 2}
+					]`))
+				})
+			})
+
+			Context("when fetching the archived artifacts fails", func() {
+				BeforeEach(func() {
+					build.ArchivedArtifactsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated and the pipeline is private", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+
+				dbBuildFactory.BuildReturns(build, true, nil)
+				build.PipelineReturns(fakePipeline, true, nil)
+				fakePipeline.PublicReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/tests", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/tests")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated and authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				build.IDReturns(42)
+				build.TeamNameReturns("some-team")
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when the build has test results", func() {
+				BeforeEach(func() {
+					build.TestResultsReturns([]atc.TestResult{
+						{Suite: "some-suite", Name: "some-test", Status: "passed", Duration: 1.5},
+						{Suite: "some-suite", Name: "some-other-test", Status: "failed", Duration: 2, Message: "expected true"},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the test results along with a summary", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"summary": {"total": 2, "passed": 1, "failed": 1, "errored": 0, "skipped": 0},
+						"tests": [
+							{"suite": "some-suite", "name": "some-test", "status": "passed", "duration": 1.5},
+							{"suite": "some-suite", "name": "some-other-test", "status": "failed", "duration": 2, "message": "expected true"}
+						]
+					}`))
+				})
+			})
+
+			Context("when fetching the test results fails", func() {
+				BeforeEach(func() {
+					build.TestResultsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated and the pipeline is private", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+
+				dbBuildFactory.BuildReturns(build, true, nil)
+				build.PipelineReturns(fakePipeline, true, nil)
+				fakePipeline.PublicReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/approvals", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/approvals")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated and authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				build.IDReturns(42)
+				build.TeamNameReturns("some-team")
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when the build has approvals", func() {
+				BeforeEach(func() {
+					build.ApprovalsReturns([]atc.BuildApproval{
+						{
+							BuildID:      42,
+							PlanID:       "some-plan",
+							Name:         "deploy",
+							Status:       atc.ApprovalStatusPending,
+							AllowedRoles: []string{"owner"},
+							RequestTime:  1000,
+							Deadline:     2000,
+						},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the approvals", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[{
+						"build_id": 42,
+						"plan_id": "some-plan",
+						"name": "deploy",
+						"status": "pending",
+						"allowed_roles": ["owner"],
+						"request_time": 1000,
+						"deadline": 2000
+					}]`))
+				})
+			})
+
+			Context("when fetching the approvals fails", func() {
+				BeforeEach(func() {
+					build.ApprovalsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated and the pipeline is private", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+
+				dbBuildFactory.BuildReturns(build, true, nil)
+				build.PipelineReturns(fakePipeline, true, nil)
+				fakePipeline.PublicReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/builds/:build_id/approve", func() {
+		var (
+			decision atc.ApprovalDecision
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			decision = atc.ApprovalDecision{Comment: "ship it"}
+		})
+
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(decision)
+			Expect(err).NotTo(HaveOccurred())
+
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/42/approve", bytes.NewBuffer(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated and authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakeAccess.UserNameReturns("some-user")
+
+				build.IDReturns(42)
+				build.TeamNameReturns("some-team")
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when the build is waiting for approval", func() {
+				BeforeEach(func() {
+					build.ApprovalsReturns([]atc.BuildApproval{
+						{Name: "deploy", Status: atc.ApprovalStatusPending},
+					}, nil)
+					build.DecideApprovalReturns(true, nil)
+				})
+
+				It("returns 204", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+				})
+
+				It("approves it as the user", func() {
+					Expect(build.DecideApprovalCallCount()).To(Equal(1))
+					name, status, decidedBy, comment := build.DecideApprovalArgsForCall(0)
+					Expect(name).To(BeEmpty())
+					Expect(status).To(Equal(atc.ApprovalStatusApproved))
+					Expect(decidedBy).To(Equal("some-user"))
+					Expect(comment).To(Equal("ship it"))
+				})
+
+				Context("when the build is waiting for more than one approval", func() {
+					BeforeEach(func() {
+						build.DecideApprovalReturns(false, db.ErrMultipleApprovalsPending)
+					})
+
+					It("returns 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+				})
+
+				Context("when deciding fails", func() {
+					BeforeEach(func() {
+						build.DecideApprovalReturns(false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the approval is restricted to roles", func() {
+				BeforeEach(func() {
+					build.ApprovalsReturns([]atc.BuildApproval{
+						{Name: "deploy", Status: atc.ApprovalStatusPending, AllowedRoles: []string{"owner"}},
+					}, nil)
+					build.DecideApprovalReturns(true, nil)
+				})
+
+				Context("when the user has one of the roles", func() {
+					BeforeEach(func() {
+						fakeAccess.TeamRolesReturns(map[string][]string{"some-team": {"owner"}})
+					})
+
+					It("returns 204", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					})
+				})
+
+				Context("when the user only has the role in another team", func() {
+					BeforeEach(func() {
+						fakeAccess.TeamRolesReturns(map[string][]string{
+							"some-team":       {"pipeline-operator"},
+							"some-other-team": {"owner"},
+						})
+					})
+
+					It("returns 403 without deciding", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						Expect(build.DecideApprovalCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the user is an admin", func() {
+					BeforeEach(func() {
+						fakeAccess.IsAdminReturns(true)
+					})
+
+					It("returns 204", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+					})
+				})
+			})
+
+			Context("when the build is waiting for a different approval", func() {
+				BeforeEach(func() {
+					decision.Name = "smoke-test"
+
+					build.ApprovalsReturns([]atc.BuildApproval{
+						{Name: "deploy", Status: atc.ApprovalStatusPending},
+					}, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(build.DecideApprovalCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the build is not waiting for approval", func() {
+				BeforeEach(func() {
+					build.ApprovalsReturns([]atc.BuildApproval{
+						{Name: "deploy", Status: atc.ApprovalStatusApproved},
+					}, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/builds/:build_id/reject", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/42/reject", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated and authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				fakeAccess.UserNameReturns("some-user")
+
+				build.IDReturns(42)
+				build.TeamNameReturns("some-team")
+				dbBuildFactory.BuildReturns(build, true, nil)
+
+				build.ApprovalsReturns([]atc.BuildApproval{
+					{Name: "deploy", Status: atc.ApprovalStatusPending},
+				}, nil)
+				build.DecideApprovalReturns(true, nil)
+			})
+
+			It("rejects the approval", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+				Expect(build.DecideApprovalCallCount()).To(Equal(1))
+				_, status, decidedBy, comment := build.DecideApprovalArgsForCall(0)
+				Expect(status).To(Equal(atc.ApprovalStatusRejected))
+				Expect(decidedBy).To(Equal("some-user"))
+				Expect(comment).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/archived-artifacts/:plan_id/:artifact_name", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/archived-artifacts/some-plan/some-artifact")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated and authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				build.IDReturns(42)
+				build.TeamNameReturns("some-team")
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when the build has archived the artifact", func() {
+				BeforeEach(func() {
+					build.ArchivedArtifactsReturns([]db.ArchivedArtifact{
+						{PlanID: "some-other-plan", Name: "some-artifact", Size: 2048},
+						{PlanID: "some-plan", Name: "some-artifact", Size: 1024},
+					}, nil)
+				})
+
+				Context("when the artifact is in the store", func() {
+					BeforeEach(func() {
+						fakeArtifactStore.GetReturns(ioutil.NopCloser(bytes.NewBufferString("some-archive")), nil)
+					})
+
+					It("returns 200", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+
+					It("returns Content-Type 'application/octet-stream'", func() {
+						Expect(response.Header.Get("Content-Type")).To(Equal("application/octet-stream"))
+					})
+
+					It("streams the artifact from the store", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(Equal("some-archive"))
+
+						Expect(fakeArtifactStore.GetCallCount()).To(Equal(1))
+						_, key := fakeArtifactStore.GetArgsForCall(0)
+						Expect(key).To(Equal("builds/42/artifacts/some-plan/some-artifact.tar.zst"))
+					})
+				})
+
+				Context("when the artifact is missing from the store", func() {
+					BeforeEach(func() {
+						fakeArtifactStore.GetReturns(nil, blobstore.ErrBlobNotFound)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when getting the artifact from the store fails", func() {
+					BeforeEach(func() {
+						fakeArtifactStore.GetReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the build has not archived the artifact", func() {
+				BeforeEach(func() {
+					build.ArchivedArtifactsReturns([]db.ArchivedArtifact{
+						{PlanID: "some-plan", Name: "some-other-artifact", Size: 1024},
+						{PlanID: "some-other-plan", Name: "some-artifact", Size: 1024},
+					}, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+
+				It("does not look in the store", func() {
+					Expect(fakeArtifactStore.GetCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,123 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListBuildApprovals(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-build-approvals")
+
+		approvals, err := build.Approvals()
+		if err != nil {
+			logger.Error("failed-to-fetch-approvals", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(approvals)
+		if err != nil {
+			logger.Error("failed-to-encode-approvals", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+// DecideApproval returns a handler deciding an approval the build is waiting
+// for with the given status. Approvals restricted to certain roles may only
+// be decided by admins and users with one of the roles in the build's team.
+func (s *Server) DecideApproval(status atc.BuildApprovalStatus) func(db.Build) http.Handler {
+	return func(build db.Build) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logger := s.logger.Session("decide-approval", lager.Data{
+				"build":  build.ID(),
+				"status": status,
+			})
+
+			var decision atc.ApprovalDecision
+			err := json.NewDecoder(r.Body).Decode(&decision)
+			if err != nil && err != io.EOF {
+				logger.Info("malformed-request", lager.Data{"error": err.Error()})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			approvals, err := build.Approvals()
+			if err != nil {
+				logger.Error("failed-to-fetch-approvals", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			acc := accessor.GetAccessor(r)
+
+			waiting := false
+			for _, approval := range approvals {
+				if !approval.IsPending() {
+					continue
+				}
+
+				if decision.Name != "" && approval.Name != decision.Name {
+					continue
+				}
+
+				if !mayDecide(acc, build.TeamName(), approval.AllowedRoles) {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+
+				waiting = true
+			}
+
+			if !waiting {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			decided, err := build.DecideApproval(decision.Name, status, acc.UserName(), decision.Comment)
+			if err != nil {
+				if err == db.ErrMultipleApprovalsPending {
+					w.WriteHeader(http.StatusConflict)
+					return
+				}
+
+				logger.Error("failed-to-decide-approval", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if !decided {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+func mayDecide(acc accessor.Access, teamName string, allowedRoles []string) bool {
+	if len(allowedRoles) == 0 || acc.IsAdmin() {
+		return true
+	}
+
+	for _, role := range acc.TeamRoles()[teamName] {
+		for _, allowed := range allowedRoles {
+			if role == allowed {
+				return true
+			}
+		}
+	}
+
+	return false
+}
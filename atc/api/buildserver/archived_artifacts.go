@@ -0,0 +1,97 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/blobstore"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListArchivedArtifacts(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-archived-artifacts")
+
+		artifacts, err := build.ArchivedArtifacts()
+		if err != nil {
+			logger.Error("failed-to-fetch-archived-artifacts", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(present.ArchivedArtifacts(artifacts))
+		if err != nil {
+			logger.Error("failed-to-encode-archived-artifacts", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) GetArchivedArtifact(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		planID := atc.PlanID(r.FormValue(":plan_id"))
+		name := r.FormValue(":artifact_name")
+
+		logger := s.logger.Session("get-archived-artifact", lager.Data{
+			"build":    build.ID(),
+			"plan":     planID,
+			"artifact": name,
+		})
+
+		if s.artifactStore == nil {
+			logger.Info("no-artifact-store-configured")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		artifacts, err := build.ArchivedArtifacts()
+		if err != nil {
+			logger.Error("failed-to-fetch-archived-artifacts", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		found := false
+		for _, artifact := range artifacts {
+			if artifact.PlanID == planID && artifact.Name == name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		reader, err := s.artifactStore.Get(r.Context(), blobstore.ArtifactKey(build.ID(), planID, name))
+		if err == blobstore.ErrBlobNotFound {
+			logger.Info("archived-artifact-missing-from-store")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			logger.Error("failed-to-get-archived-artifact", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		defer reader.Close()
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+
+		_, err = io.Copy(w, reader)
+		if err != nil {
+			logger.Error("failed-to-stream-archived-artifact", err)
+		}
+	})
+}
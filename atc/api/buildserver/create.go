@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -22,7 +23,10 @@ func (s *Server) CreateBuild(team db.Team) http.Handler {
 			return
 		}
 
-		build, err := team.CreateStartedBuild(plan)
+		build, err := team.CreateStartedBuild(plan, atc.BuildCreator{
+			Type: atc.BuildCreatorAPI,
+			User: accessor.GetAccessor(r).UserName(),
+		})
 		if err != nil {
 			hLog.Error("failed-to-create-one-off-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
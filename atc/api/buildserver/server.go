@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/api/auth"
+	"github.com/concourse/concourse/atc/blobstore"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -18,6 +19,7 @@ type Server struct {
 	teamFactory         db.TeamFactory
 	buildFactory        db.BuildFactory
 	eventHandlerFactory EventHandlerFactory
+	artifactStore       blobstore.Store
 	rejector            auth.Rejector
 }
 
@@ -27,6 +29,7 @@ func NewServer(
 	teamFactory db.TeamFactory,
 	buildFactory db.BuildFactory,
 	eventHandlerFactory EventHandlerFactory,
+	artifactStore blobstore.Store,
 ) *Server {
 	return &Server{
 		logger: logger,
@@ -36,6 +39,7 @@ func NewServer(
 		teamFactory:         teamFactory,
 		buildFactory:        buildFactory,
 		eventHandlerFactory: eventHandlerFactory,
+		artifactStore:       artifactStore,
 
 		rejector: auth.UnauthorizedRejector{},
 	}
@@ -0,0 +1,34 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListBuildTestResults(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-build-test-results")
+
+		results, err := build.TestResults()
+		if err != nil {
+			logger.Error("failed-to-fetch-test-results", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(atc.BuildTestResults{
+			Summary: atc.SummarizeTests(results),
+			Tests:   results,
+		})
+		if err != nil {
+			logger.Error("failed-to-encode-test-results", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
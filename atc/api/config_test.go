@@ -430,8 +430,8 @@ var _ = Describe("Config API", func() {
 						It("saves it initially paused", func() {
 							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-							name, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
-							Expect(name).To(Equal("a-pipeline"))
+							pipelineRef, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+							Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(initiallyPaused).To(BeTrue())
@@ -495,6 +495,54 @@ var _ = Describe("Config API", func() {
 								Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
 							})
 						})
+
+						Context("when the config declares var sources", func() {
+							BeforeEach(func() {
+								pipelineConfig.VarSources = atc.VarSourceConfigs{
+									{
+										Name:   "some-var-source",
+										Type:   "vault",
+										Config: map[string]interface{}{"url": "https://vault.example.com"},
+									},
+								}
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+								request.Body = gbytes.BufferWithBytes(payload)
+							})
+
+							It("saves them", func() {
+								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+
+								_, savedConfig, _, _ := dbTeam.SavePipelineArgsForCall(0)
+								Expect(savedConfig.VarSources).To(Equal(pipelineConfig.VarSources))
+							})
+
+							Context("when a var source has an unknown type", func() {
+								BeforeEach(func() {
+									pipelineConfig.VarSources[0].Type = "bogus"
+									payload, err := json.Marshal(pipelineConfig)
+									Expect(err).NotTo(HaveOccurred())
+									request.Body = gbytes.BufferWithBytes(payload)
+								})
+
+								It("returns 400", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+								})
+
+								It("returns error JSON", func() {
+									Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`
+									{
+										"errors": [
+											"var_sources.some-var-source has unknown type 'bogus'"
+										]
+									}`))
+								})
+
+								It("does not save it", func() {
+									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								})
+							})
+						})
 					})
 
 					Context("YAML", func() {
@@ -522,8 +570,8 @@ var _ = Describe("Config API", func() {
 						It("saves it initially paused", func() {
 							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-							name, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
-							Expect(name).To(Equal("a-pipeline"))
+							pipelineRef, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+							Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(initiallyPaused).To(BeTrue())
@@ -566,8 +614,8 @@ jobs:
 							It("saves it", func() {
 								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-								name, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
-								Expect(name).To(Equal("a-pipeline"))
+								pipelineRef, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+								Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
 										{
@@ -836,8 +884,8 @@ jobs:
 									It("passes validation and saves it un-interpolated", func() {
 										Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-										name, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
-										Expect(name).To(Equal("a-pipeline"))
+										pipelineRef, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+										Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 										Expect(savedConfig).To(Equal(payloadAsConfig))
 										Expect(id).To(Equal(db.ConfigVersion(42)))
 										Expect(initiallyPaused).To(BeTrue())
@@ -996,8 +1044,8 @@ jobs:
 					It("saves it", func() {
 						Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
 
-						name, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
-						Expect(name).To(Equal("a-pipeline"))
+						pipelineRef, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+						Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 						Expect(savedConfig).To(Equal(atc.Config{
 							Jobs: atc.JobConfigs{
 								{
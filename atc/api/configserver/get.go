@@ -29,7 +29,17 @@ func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pipeline, found, err := team.Pipeline(pipelineName)
+	instanceVars, err := atc.InstanceVarsFromQueryParams(r.URL.Query())
+	if err != nil {
+		logger.Error("malformed-instance-vars", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	pipeline, found, err := team.Pipeline(atc.PipelineRef{
+		Name:         pipelineName,
+		InstanceVars: instanceVars,
+	})
 	if err != nil {
 		logger.Error("failed-to-find-pipeline", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -68,6 +78,7 @@ func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
 		Resources:     resources.Configs(),
 		ResourceTypes: resourceTypes.Configs(),
 		Jobs:          jobs.Configs(),
+		VarSources:    pipeline.VarSources(),
 	}
 
 	w.Header().Set(atc.ConfigVersionHeader, fmt.Sprintf("%d", pipeline.ConfigVersion()))
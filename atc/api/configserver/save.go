@@ -58,7 +58,7 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 		for k := range ignoredUnknownToplevels {
 			switch k {
-			case "groups", "jobs", "resources", "resource_types":
+			case "groups", "jobs", "resources", "resource_types", "var_sources":
 			default:
 				delete(ignoredUnknownToplevels, k)
 			}
@@ -84,6 +84,13 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	instanceVars, err := atc.InstanceVarsFromQueryParams(query)
+	if err != nil {
+		session.Error("malformed-instance-vars", err)
+		s.handleBadRequest(w, err.Error())
+		return
+	}
+
 	warnings, errorMessages := config.Validate()
 	if len(errorMessages) > 0 {
 		session.Info("ignoring-invalid-config")
@@ -91,11 +98,30 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	for _, varSource := range config.VarSources {
+		if _, found := creds.ManagerFactories()[varSource.Type]; !found {
+			session.Info("ignoring-invalid-config")
+			s.handleBadRequest(w, fmt.Sprintf("var_sources.%s has unknown type '%s'", varSource.Name, varSource.Type))
+			return
+		}
+	}
+
 	pipelineName := rata.Param(r, "pipeline_name")
 	teamName := rata.Param(r, "team_name")
 
 	if checkCredentials {
-		variables := creds.NewVariables(s.secretManager, teamName, pipelineName)
+		access := atc.SecretAccess{
+			TeamName:     teamName,
+			PipelineName: pipelineName,
+		}
+
+		variables := creds.NewPipelineVariables(
+			creds.WithSecretAccess(s.secretManager, access),
+			creds.WithVarSourceAccess(s.varSourcePool, access),
+			teamName,
+			pipelineName,
+			config.VarSources,
+		)
 
 		errs := validateCredParams(variables, config, session)
 		if errs != nil {
@@ -119,7 +145,12 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, created, err := team.SavePipeline(pipelineName, config, version, true)
+	pipelineRef := atc.PipelineRef{
+		Name:         pipelineName,
+		InstanceVars: instanceVars,
+	}
+
+	_, created, err := team.SavePipeline(pipelineRef, config, version, true)
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
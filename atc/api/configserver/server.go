@@ -10,16 +10,19 @@ type Server struct {
 	logger        lager.Logger
 	teamFactory   db.TeamFactory
 	secretManager creds.Secrets
+	varSourcePool creds.VarSourcePool
 }
 
 func NewServer(
 	logger lager.Logger,
 	teamFactory db.TeamFactory,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 ) *Server {
 	return &Server{
 		logger:        logger,
 		teamFactory:   teamFactory,
 		secretManager: secretManager,
+		varSourcePool: varSourcePool,
 	}
 }
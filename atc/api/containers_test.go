@@ -271,6 +271,37 @@ var _ = Describe("Containers API", func() {
 				})
 			})
 
+			Describe("querying with a pipeline instance", func() {
+				BeforeEach(func() {
+					fakePipeline := new(dbfakes.FakePipeline)
+					fakePipeline.IDReturns(42)
+					dbTeam.PipelineReturns(fakePipeline, true, nil)
+
+					req.URL.RawQuery = url.Values{
+						"pipeline_name": []string{"some-pipeline"},
+						"instance_vars": []string{`{"branch":"master"}`},
+						"job_name":      []string{"some-job"},
+					}.Encode()
+				})
+
+				It("queries with the id of the instance in the metadata", func() {
+					_, err := client.Do(req)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(dbTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{
+						Name:         "some-pipeline",
+						InstanceVars: atc.InstanceVars{"branch": "master"},
+					}))
+
+					meta := dbTeam.FindContainersByMetadataArgsForCall(0)
+					Expect(meta).To(Equal(db.ContainerMetadata{
+						PipelineID:   42,
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+					}))
+				})
+			})
+
 			Describe("querying with step name", func() {
 				BeforeEach(func() {
 					req.URL.RawQuery = url.Values{
@@ -362,10 +393,33 @@ var _ = Describe("Containers API", func() {
 					_, err := client.Do(req)
 					Expect(err).NotTo(HaveOccurred())
 
-					pipelineName, resourceName, secretManager := dbTeam.FindCheckContainersArgsForCall(0)
-					Expect(pipelineName).To(Equal("some-pipeline"))
+					pipelineRef, resourceName, secretManager, varSourcePool := dbTeam.FindCheckContainersArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
 					Expect(resourceName).To(Equal("some-resource"))
 					Expect(secretManager).To(Equal(fakeSecretManager))
+					Expect(varSourcePool).To(Equal(fakeVarSourcePool))
+				})
+
+				Context("when instance vars are given", func() {
+					BeforeEach(func() {
+						req.URL.RawQuery = url.Values{
+							"type":          []string{"check"},
+							"resource_name": []string{"some-resource"},
+							"pipeline_name": []string{"some-pipeline"},
+							"instance_vars": []string{`{"branch":"master"}`},
+						}.Encode()
+					})
+
+					It("queries the check containers of the pipeline instance", func() {
+						_, err := client.Do(req)
+						Expect(err).NotTo(HaveOccurred())
+
+						pipelineRef, _, _, _ := dbTeam.FindCheckContainersArgsForCall(0)
+						Expect(pipelineRef).To(Equal(atc.PipelineRef{
+							Name:         "some-pipeline",
+							InstanceVars: atc.InstanceVars{"branch": "master"},
+						}))
+					})
 				})
 			})
 		})
@@ -21,7 +21,7 @@ func (s *Server) ListContainers(team db.Team) http.Handler {
 			"params": params,
 		})
 
-		containerLocator, err := createContainerLocatorFromRequest(team, r, s.secretManager)
+		containerLocator, err := createContainerLocatorFromRequest(team, r, s.secretManager, s.varSourcePool)
 		if err != nil {
 			hLog.Error("failed-to-parse-request", err)
 			http.Error(w, err.Error(), http.StatusBadRequest)
@@ -59,7 +59,7 @@ type containerLocator interface {
 	Locate() ([]db.Container, map[int]time.Time, error)
 }
 
-func createContainerLocatorFromRequest(team db.Team, r *http.Request, secretManager creds.Secrets) (containerLocator, error) {
+func createContainerLocatorFromRequest(team db.Team, r *http.Request, secretManager creds.Secrets, varSourcePool creds.VarSourcePool) (containerLocator, error) {
 	query := r.URL.Query()
 	delete(query, ":team_name")
 
@@ -69,16 +69,24 @@ func createContainerLocatorFromRequest(team db.Team, r *http.Request, secretMana
 		}, nil
 	}
 
+	instanceVars, err := atc.InstanceVarsFromQueryParams(query)
+	if err != nil {
+		return nil, err
+	}
+
 	if query.Get("type") == "check" {
 		return &checkContainerLocator{
-			team:          team,
-			pipelineName:  query.Get("pipeline_name"),
+			team: team,
+			pipelineRef: atc.PipelineRef{
+				Name:         query.Get("pipeline_name"),
+				InstanceVars: instanceVars,
+			},
 			resourceName:  query.Get("resource_name"),
 			secretManager: secretManager,
+			varSourcePool: varSourcePool,
 		}, nil
 	}
 
-	var err error
 	var containerType db.ContainerType
 	if query.Get("type") != "" {
 		containerType, err = db.ContainerTypeFromString(query.Get("type"))
@@ -103,7 +111,8 @@ func createContainerLocatorFromRequest(team db.Team, r *http.Request, secretMana
 	}
 
 	return &stepContainerLocator{
-		team: team,
+		team:         team,
+		instanceVars: instanceVars,
 
 		metadata: db.ContainerMetadata{
 			Type: containerType,
@@ -133,21 +142,41 @@ func (l *allContainersLocator) Locate() ([]db.Container, map[int]time.Time, erro
 
 type checkContainerLocator struct {
 	team          db.Team
-	pipelineName  string
+	pipelineRef   atc.PipelineRef
 	resourceName  string
 	secretManager creds.Secrets
+	varSourcePool creds.VarSourcePool
 }
 
 func (l *checkContainerLocator) Locate() ([]db.Container, map[int]time.Time, error) {
-	return l.team.FindCheckContainers(l.pipelineName, l.resourceName, l.secretManager)
+	return l.team.FindCheckContainers(l.pipelineRef, l.resourceName, l.secretManager, l.varSourcePool)
 }
 
 type stepContainerLocator struct {
-	team     db.Team
-	metadata db.ContainerMetadata
+	team         db.Team
+	instanceVars atc.InstanceVars
+	metadata     db.ContainerMetadata
 }
 
 func (l *stepContainerLocator) Locate() ([]db.Container, map[int]time.Time, error) {
+	// containers only record the name of their pipeline, so narrow down to
+	// the instance by its id
+	if l.instanceVars != nil {
+		pipeline, found, err := l.team.Pipeline(atc.PipelineRef{
+			Name:         l.metadata.PipelineName,
+			InstanceVars: l.instanceVars,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !found {
+			return nil, nil, nil
+		}
+
+		l.metadata.PipelineID = pipeline.ID()
+	}
+
 	containers, err := l.team.FindContainersByMetadata(l.metadata)
 	return containers, nil, err
 }
@@ -0,0 +1,114 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Encryption API", func() {
+	var (
+		response   *http.Response
+		fakeaccess *accessorfakes.FakeAccess
+	)
+
+	BeforeEach(func() {
+		fakeaccess = new(accessorfakes.FakeAccess)
+	})
+
+	Context("GET /api/v1/encryption", func() {
+		JustBeforeEach(func() {
+			fakeAccessor.CreateReturns(fakeaccess)
+
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/encryption", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("not an admin", func() {
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("being an admin", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAdminReturns(true)
+
+					dbEncryptionKeyRotation.StatusReturns(atc.EncryptionStatus{
+						KeyID:     "new-key",
+						OldKeyIDs: []string{"old-key"},
+						Tables: []atc.EncryptedTableStatus{
+							{
+								Table:     "jobs",
+								Column:    "config",
+								Legacy:    1,
+								Keys:      map[string]int{"new-key": 2, "old-key": 3},
+								Remaining: 4,
+							},
+						},
+					}, nil)
+				})
+
+				It("succeeds", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the progress of re-encrypting with the current key", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"key_id": "new-key",
+						"old_key_ids": ["old-key"],
+						"tables": [{
+							"table": "jobs",
+							"column": "config",
+							"plaintext": 0,
+							"legacy": 1,
+							"keys": {"new-key": 2, "old-key": 3},
+							"remaining": 4
+						}]
+					}`))
+				})
+
+				Context("failing to get the status", func() {
+					BeforeEach(func() {
+						dbEncryptionKeyRotation.StatusReturns(atc.EncryptionStatus{}, errors.New("no db connection"))
+					})
+
+					It("fails", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
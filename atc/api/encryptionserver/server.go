@@ -0,0 +1,21 @@
+package encryptionserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger   lager.Logger
+	rotation db.EncryptionKeyRotation
+}
+
+func NewServer(
+	logger lager.Logger,
+	rotation db.EncryptionKeyRotation,
+) *Server {
+	return &Server{
+		logger:   logger,
+		rotation: rotation,
+	}
+}
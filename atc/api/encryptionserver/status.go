@@ -0,0 +1,25 @@
+package encryptionserver
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func (s *Server) GetEncryptionStatus(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("get-encryption-status")
+
+	status, err := s.rotation.Status()
+	if err != nil {
+		logger.Error("failed-to-get-encryption-status", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(status)
+	if err != nil {
+		logger.Error("failed-to-encode-encryption-status", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
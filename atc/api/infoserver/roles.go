@@ -0,0 +1,20 @@
+package infoserver
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Roles returns the actions each role is permitted to perform, taking into
+// account any customizations configured for this instance of concourse.
+func (s *Server) Roles(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("roles")
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(w).Encode(s.roleActions)
+	if err != nil {
+		logger.Error("failed-to-encode-roles", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -2,6 +2,7 @@ package infoserver
 
 import (
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/creds"
 )
 
@@ -12,6 +13,7 @@ type Server struct {
 	externalURL   string
 	clusterName   string
 	credsManagers creds.Managers
+	roleActions   accessor.RoleActionMap
 }
 
 func NewServer(
@@ -21,6 +23,7 @@ func NewServer(
 	externalURL string,
 	clusterName string,
 	credsManagers creds.Managers,
+	roleActions accessor.RoleActionMap,
 ) *Server {
 	return &Server{
 		logger:        logger,
@@ -29,5 +32,6 @@ func NewServer(
 		externalURL:   externalURL,
 		clusterName:   clusterName,
 		credsManagers: credsManagers,
+		roleActions:   roleActions,
 	}
 }
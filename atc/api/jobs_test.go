@@ -493,6 +493,40 @@ var _ = Describe("Jobs API", func() {
 						})
 					})
 
+					Context("when the job belongs to a pipeline instance", func() {
+						BeforeEach(func() {
+							fakeJob.PipelineInstanceVarsReturns(atc.InstanceVars{"branch": "feature"})
+							build1.PipelineInstanceVarsReturns(atc.InstanceVars{"branch": "feature"})
+						})
+
+						It("returns the instance vars of the pipeline", func() {
+							var job atc.Job
+							err := json.NewDecoder(response.Body).Decode(&job)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(job.PipelineInstanceVars).To(Equal(atc.InstanceVars{"branch": "feature"}))
+							Expect(job.FinishedBuild.PipelineInstanceVars).To(Equal(atc.InstanceVars{"branch": "feature"}))
+						})
+					})
+
+					Context("when the job has a schedule", func() {
+						BeforeEach(func() {
+							fakeJob.ConfigReturns(atc.JobConfig{
+								Name:     "some-job",
+								Schedule: &atc.ScheduleConfig{Cron: "0 2 * * *"},
+							})
+							fakeJob.ScheduleTimeReturns(time.Date(2019, 12, 3, 12, 0, 0, 0, time.UTC))
+						})
+
+						It("returns the time of the next scheduled build", func() {
+							var job atc.Job
+							err := json.NewDecoder(response.Body).Decode(&job)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(job.NextScheduledBuild).To(Equal(time.Date(2019, 12, 4, 2, 0, 0, 0, time.UTC).Unix()))
+						})
+					})
+
 					Context("when getting the job's builds fails", func() {
 						BeforeEach(func() {
 							fakeJob.FinishedAndNextBuildReturns(nil, nil, errors.New("oh no!"))
@@ -1545,8 +1579,10 @@ var _ = Describe("Jobs API", func() {
 					})
 
 					Context("when triggering the build succeeds", func() {
+						var build *dbfakes.FakeBuild
+
 						BeforeEach(func() {
-							build := new(dbfakes.FakeBuild)
+							build = new(dbfakes.FakeBuild)
 							build.IDReturns(42)
 							build.NameReturns("1")
 							build.JobNameReturns("some-job")
@@ -1563,6 +1599,59 @@ var _ = Describe("Jobs API", func() {
 							Expect(fakeJob.CreateBuildCallCount()).To(Equal(1))
 						})
 
+						Context("when the user is known", func() {
+							BeforeEach(func() {
+								fakeaccess.UserNameReturns("some-user")
+							})
+
+							It("creates the build as triggered by the user", func() {
+								Expect(fakeJob.CreateBuildArgsForCall(0)).To(Equal(atc.BuildCreator{
+									Type: atc.BuildCreatorManual,
+									User: "some-user",
+								}))
+								Expect(build.SetCreatedByCallCount()).To(BeZero())
+							})
+						})
+
+						It("keeps the priority from the job config", func() {
+							Expect(build.SetPriorityCallCount()).To(BeZero())
+						})
+
+						Context("when a priority is given", func() {
+							BeforeEach(func() {
+								request.URL.RawQuery = "priority=10"
+							})
+
+							It("overrides the priority of the build", func() {
+								Expect(build.SetPriorityCallCount()).To(Equal(1))
+								Expect(build.SetPriorityArgsForCall(0)).To(Equal(10))
+							})
+
+							Context("when setting the priority fails", func() {
+								BeforeEach(func() {
+									build.SetPriorityReturns(errors.New("nope"))
+								})
+
+								It("returns a 500", func() {
+									Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+								})
+							})
+						})
+
+						Context("when the priority is malformed", func() {
+							BeforeEach(func() {
+								request.URL.RawQuery = "priority=high"
+							})
+
+							It("returns a 400", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							})
+
+							It("does not trigger the build", func() {
+								Expect(fakeJob.CreateBuildCallCount()).To(BeZero())
+							})
+						})
+
 						Context("when finding the pipeline resources fails", func() {
 							BeforeEach(func() {
 								fakePipeline.ResourcesReturns(nil, errors.New("nope"))
@@ -1649,6 +1738,540 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
+		var request *http.Request
+		var response *http.Response
+
+		BeforeEach(func() {
+			var err error
+
+			request, err = http.NewRequest("POST", server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/3", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized and authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(true)
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.UserNameReturns("some-user")
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns a 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when getting the job succeeds", func() {
+				var buildToRerun *dbfakes.FakeBuild
+
+				BeforeEach(func() {
+					fakeJob.NameReturns("some-job")
+					fakeJob.ConfigReturns(atc.JobConfig{Name: "some-job"})
+					fakePipeline.JobReturns(fakeJob, true, nil)
+
+					buildToRerun = new(dbfakes.FakeBuild)
+					buildToRerun.IDReturns(3)
+					buildToRerun.NameReturns("3")
+					buildToRerun.IsScheduledReturns(true)
+					fakeJob.BuildReturns(buildToRerun, true, nil)
+
+					fakeJob.RerunBuildReturns(new(dbfakes.FakeBuild), nil)
+				})
+
+				It("looks up the build to rerun", func() {
+					Expect(fakeJob.BuildCallCount()).To(Equal(1))
+					Expect(fakeJob.BuildArgsForCall(0)).To(Equal("3"))
+				})
+
+				Context("when manual triggering is disabled", func() {
+					BeforeEach(func() {
+						fakeJob.ConfigReturns(atc.JobConfig{
+							Name:                 "some-job",
+							DisableManualTrigger: true,
+						})
+					})
+
+					It("returns a 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+
+					It("does not rerun the build", func() {
+						Expect(fakeJob.RerunBuildCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the build is not found", func() {
+					BeforeEach(func() {
+						fakeJob.BuildReturns(nil, false, nil)
+					})
+
+					It("returns a 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when the build was never scheduled", func() {
+					BeforeEach(func() {
+						buildToRerun.IsScheduledReturns(false)
+					})
+
+					It("returns a 409", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusConflict))
+					})
+
+					It("does not rerun the build", func() {
+						Expect(fakeJob.RerunBuildCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when rerunning the build fails", func() {
+					BeforeEach(func() {
+						fakeJob.RerunBuildReturns(nil, errors.New("nope"))
+					})
+
+					It("returns a 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when rerunning the build succeeds", func() {
+					var rerunBuild *dbfakes.FakeBuild
+
+					BeforeEach(func() {
+						rerunBuild = new(dbfakes.FakeBuild)
+						rerunBuild.IDReturns(42)
+						rerunBuild.NameReturns("3.1")
+						rerunBuild.JobNameReturns("some-job")
+						rerunBuild.PipelineNameReturns("some-pipeline")
+						rerunBuild.TeamNameReturns("some-team")
+						rerunBuild.StatusReturns(db.BuildStatusPending)
+						rerunBuild.CreatedByReturns(&atc.BuildCreator{
+							Type:      atc.BuildCreatorRerun,
+							BuildID:   3,
+							BuildName: "3",
+						})
+
+						fakeJob.RerunBuildReturns(rerunBuild, nil)
+					})
+
+					It("reruns the build as the user", func() {
+						Expect(fakeJob.RerunBuildCallCount()).To(Equal(1))
+
+						build, rerunBy := fakeJob.RerunBuildArgsForCall(0)
+						Expect(build).To(Equal(buildToRerun))
+						Expect(rerunBy).To(Equal(atc.BuildCreator{
+							Type: atc.BuildCreatorRerun,
+							User: "some-user",
+						}))
+						Expect(rerunBuild.SetCreatedByCallCount()).To(BeZero())
+					})
+
+					It("returns the new build", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+						var build atc.Build
+						err := json.NewDecoder(response.Body).Decode(&build)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(build.ID).To(Equal(42))
+						Expect(build.Name).To(Equal("3.1"))
+						Expect(build.Status).To(Equal("pending"))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/stats", func() {
+		var response *http.Response
+		var queryParams string
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/stats" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated and not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(false)
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("and the pipeline is public", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(true)
+					fakePipeline.JobReturns(fakeJob, true, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when getting the job fails", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, errors.New("some-error"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the job is found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(fakeJob, true, nil)
+					fakeJob.StatsReturns(atc.JobStats{
+						Since:       1,
+						Until:       2,
+						Builds:      3,
+						Succeeded:   2,
+						Failed:      1,
+						SuccessRate: 0.5,
+						Duration:    atc.DurationStats{Count: 3, Mean: 20, P50: 20, P95: 30, Max: 30},
+						QueueTime:   atc.DurationStats{Count: 3, Mean: 2, P50: 2, P95: 3, Max: 3},
+						Steps: []atc.StepStats{
+							{
+								Type:           "task",
+								Name:           "unit",
+								Initialization: atc.DurationStats{Count: 3, Mean: 1, P50: 1, P95: 1, Max: 1},
+								Duration:       atc.DurationStats{Count: 3, Mean: 10, P50: 10, P95: 15, Max: 15},
+							},
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("covers the last 30 days by default", func() {
+					Expect(fakeJob.StatsCallCount()).To(Equal(1))
+
+					since, until := fakeJob.StatsArgsForCall(0)
+					Expect(until).To(BeTemporally("~", time.Now(), time.Minute))
+					Expect(until.Sub(since)).To(Equal(30 * 24 * time.Hour))
+				})
+
+				It("returns the job's stats", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"since": 1,
+						"until": 2,
+						"builds": 3,
+						"succeeded": 2,
+						"failed": 1,
+						"errored": 0,
+						"aborted": 0,
+						"success_rate": 0.5,
+						"duration": {"count": 3, "mean": 20, "p50": 20, "p95": 30, "max": 30},
+						"queue_time": {"count": 3, "mean": 2, "p50": 2, "p95": 3, "max": 3},
+						"steps": [
+							{
+								"type": "task",
+								"name": "unit",
+								"initialization": {"count": 3, "mean": 1, "p50": 1, "p95": 1, "max": 1},
+								"duration": {"count": 3, "mean": 10, "p50": 10, "p95": 15, "max": 15}
+							}
+						]
+					}`))
+				})
+
+				Context("when a time range is given", func() {
+					BeforeEach(func() {
+						queryParams = "?since=100&until=200"
+					})
+
+					It("covers that time range", func() {
+						since, until := fakeJob.StatsArgsForCall(0)
+						Expect(since).To(Equal(time.Unix(100, 0)))
+						Expect(until).To(Equal(time.Unix(200, 0)))
+					})
+				})
+
+				Context("when the time range is malformed", func() {
+					BeforeEach(func() {
+						queryParams = "?since=yesterday"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when the time range ends before it starts", func() {
+					BeforeEach(func() {
+						queryParams = "?since=200&until=100"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when getting the stats fails", func() {
+					BeforeEach(func() {
+						fakeJob.StatsReturns(atc.JobStats{}, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when build events are not stored in postgres", func() {
+					BeforeEach(func() {
+						fakeJob.StatsReturns(atc.JobStats{}, db.ErrBuildEventsNotInPostgres)
+					})
+
+					It("returns 501 with an explanation", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotImplemented))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(body)).To(ContainSubstring("only be computed when build events are stored in postgres"))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/tests", func() {
+		var response *http.Response
+		var queryParams string
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/tests" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated and not authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthorizedReturns(false)
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("and the pipeline is private", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("and the pipeline is public", func() {
+				BeforeEach(func() {
+					fakePipeline.PublicReturns(true)
+					fakePipeline.JobReturns(fakeJob, true, nil)
+				})
+
+				Context("and the job is public", func() {
+					BeforeEach(func() {
+						fakeJob.PublicReturns(true)
+					})
+
+					It("returns 200 OK", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+					})
+				})
+
+				Context("and the job is private", func() {
+					BeforeEach(func() {
+						fakeJob.PublicReturns(false)
+					})
+
+					It("returns 403", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+					})
+
+					It("does not look up the test history", func() {
+						Expect(fakeJob.TestHistoryCallCount()).To(BeZero())
+					})
+				})
+			})
+		})
+
+		Context("when not authenticated and the pipeline is public", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+				fakePipeline.PublicReturns(true)
+				fakePipeline.JobReturns(fakeJob, true, nil)
+				fakeJob.PublicReturns(false)
+			})
+
+			It("returns 401 for a private job", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when getting the job fails", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, errors.New("some-error"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the job is found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(fakeJob, true, nil)
+					fakeJob.TestHistoryReturns([]atc.TestHistory{
+						{
+							Suite:     "some-suite",
+							Name:      "some-test",
+							Runs:      2,
+							Failures:  1,
+							FlakeRate: 1,
+							Builds: []atc.TestHistoryBuild{
+								{BuildID: 2, BuildName: "2", Status: "failed", Duration: 1.5},
+								{BuildID: 1, BuildName: "1", Status: "passed", Duration: 0.5},
+							},
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("covers the default number of builds", func() {
+					Expect(fakeJob.TestHistoryCallCount()).To(Equal(1))
+					Expect(fakeJob.TestHistoryArgsForCall(0)).To(Equal(25))
+				})
+
+				It("returns the test history", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"suite": "some-suite",
+							"name": "some-test",
+							"runs": 2,
+							"failures": 1,
+							"flake_rate": 1,
+							"builds": [
+								{"build_id": 2, "build_name": "2", "status": "failed", "duration": 1.5},
+								{"build_id": 1, "build_name": "1", "status": "passed", "duration": 0.5}
+							]
+						}
+					]`))
+				})
+
+				Context("when the number of builds is given", func() {
+					BeforeEach(func() {
+						queryParams = "?builds=5"
+					})
+
+					It("covers that many builds", func() {
+						Expect(fakeJob.TestHistoryArgsForCall(0)).To(Equal(5))
+					})
+				})
+
+				Context("when the number of builds is malformed", func() {
+					BeforeEach(func() {
+						queryParams = "?builds=-1"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+
+				Context("when getting the test history fails", func() {
+					BeforeEach(func() {
+						fakeJob.TestHistoryReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", func() {
 		var response *http.Response
 
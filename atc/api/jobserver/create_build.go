@@ -3,7 +3,11 @@ package jobserver
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -15,6 +19,18 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 
 		jobName := r.FormValue(":job_name")
 
+		var priority *int
+		if value := r.FormValue(atc.CreateJobBuildQueryPriority); value != "" {
+			p, err := strconv.Atoi(value)
+			if err != nil {
+				logger.Info("malformed-priority", lager.Data{"priority": value})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			priority = &p
+		}
+
 		job, found, err := pipeline.Job(jobName)
 		if err != nil {
 			logger.Error("failed-to-get-resource-types", err)
@@ -32,13 +48,25 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		build, err := job.CreateBuild()
+		build, err := job.CreateBuild(atc.BuildCreator{
+			Type: atc.BuildCreatorManual,
+			User: accessor.GetAccessor(r).UserName(),
+		})
 		if err != nil {
 			logger.Error("failed-to-create-job-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
+		if priority != nil {
+			err = build.SetPriority(*priority)
+			if err != nil {
+				logger.Error("failed-to-set-build-priority", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
 		resources, err := pipeline.Resources()
 		if err != nil {
 			logger.Error("failed-to-get-resources", err)
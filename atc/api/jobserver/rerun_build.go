@@ -0,0 +1,73 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) RerunJobBuild(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("rerun-job-build")
+
+		jobName := r.FormValue(":job_name")
+		buildName := r.FormValue(":build_name")
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if job.Config().DisableManualTrigger {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		buildToRerun, found, err := job.Build(buildName)
+		if err != nil {
+			logger.Error("failed-to-get-job-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		// builds which were never scheduled have no inputs to rerun with
+		if !buildToRerun.IsScheduled() {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		build, err := job.RerunBuild(buildToRerun, atc.BuildCreator{
+			Type: atc.BuildCreatorRerun,
+			User: accessor.GetAccessor(r).UserName(),
+		})
+		if err != nil {
+			logger.Error("failed-to-rerun-job-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(present.Build(build))
+		if err != nil {
+			logger.Error("failed-to-encode-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// defaultJobStatsRange is how far back job statistics go when no start of
+// the time range is given.
+const defaultJobStatsRange = 30 * 24 * time.Hour
+
+func (s *Server) GetJobStats(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-job-stats")
+
+		jobName := r.FormValue(":job_name")
+
+		until := time.Now()
+		if urlUntil := r.FormValue(atc.JobStatsQueryUntil); urlUntil != "" {
+			seconds, err := strconv.ParseInt(urlUntil, 10, 64)
+			if err != nil {
+				logger.Info("malformed-until", lager.Data{"until": urlUntil})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			until = time.Unix(seconds, 0)
+		}
+
+		since := until.Add(-defaultJobStatsRange)
+		if urlSince := r.FormValue(atc.JobStatsQuerySince); urlSince != "" {
+			seconds, err := strconv.ParseInt(urlSince, 10, 64)
+			if err != nil {
+				logger.Info("malformed-since", lager.Data{"since": urlSince})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			since = time.Unix(seconds, 0)
+		}
+
+		if since.After(until) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		stats, err := job.Stats(since, until)
+		if err == db.ErrBuildEventsNotInPostgres {
+			logger.Info("build-events-not-in-postgres")
+			w.WriteHeader(http.StatusNotImplemented)
+			w.Write([]byte("job statistics can only be computed when build events are stored in postgres"))
+			return
+		}
+
+		if err != nil {
+			logger.Error("failed-to-get-job-stats", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(stats)
+		if err != nil {
+			logger.Error("failed-to-encode-job-stats", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
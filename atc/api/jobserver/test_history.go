@@ -0,0 +1,75 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+const defaultTestHistoryBuilds = 25
+
+// ListJobTestHistory returns the results of each test across the job's most
+// recent builds, along with how often it failed and flaked.
+//
+// As with build logs, the history of a private job is only visible to users
+// authorized for the team.
+func (s *Server) ListJobTestHistory(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-job-test-history")
+
+		jobName := r.FormValue(":job_name")
+		teamName := r.FormValue(":team_name")
+
+		builds := defaultTestHistoryBuilds
+		if urlBuilds := r.FormValue(atc.TestHistoryQueryBuilds); urlBuilds != "" {
+			var err error
+			builds, err = strconv.Atoi(urlBuilds)
+			if err != nil || builds <= 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+		}
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		acc := accessor.GetAccessor(r)
+		if !job.Public() && !acc.IsAuthorized(teamName) {
+			if acc.IsAuthenticated() {
+				w.WriteHeader(http.StatusForbidden)
+			} else {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+			return
+		}
+
+		history, err := job.TestHistory(builds)
+		if err != nil {
+			logger.Error("failed-to-get-test-history", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(history)
+		if err != nil {
+			logger.Error("failed-to-encode-test-history", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -21,7 +22,10 @@ func (s *Server) CreateBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		build, err := pipeline.CreateStartedBuild(plan)
+		build, err := pipeline.CreateStartedBuild(plan, atc.BuildCreator{
+			Type: atc.BuildCreatorAPI,
+			User: accessor.GetAccessor(r).UserName(),
+		})
 		if err != nil {
 			logger.Error("failed-to-create-one-off-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
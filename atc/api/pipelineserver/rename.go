@@ -29,6 +29,12 @@ func (s *Server) RenamePipeline(pipeline db.Pipeline) http.Handler {
 		}
 
 		err = pipeline.Rename(rename.NewName)
+		if err == db.ErrPipelineNameTaken {
+			logger.Info("pipeline-name-taken")
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
 		if err != nil {
 			logger.Error("failed-to-update-name", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/api/pipelineserver"
 	"github.com/concourse/concourse/atc/db"
@@ -26,6 +27,7 @@ var _ = Describe("Handler", func() {
 		fakePipeline  *dbfakes.FakePipeline
 
 		handler http.Handler
+		query   string
 	)
 
 	BeforeEach(func() {
@@ -37,12 +39,14 @@ var _ = Describe("Handler", func() {
 
 		handlerFactory := pipelineserver.NewScopedHandlerFactory(dbTeamFactory)
 		handler = handlerFactory.HandlerFor(delegate.GetHandler)
+
+		query = "?:team_name=some-team&:pipeline_name=some-pipeline"
 	})
 
 	JustBeforeEach(func() {
 		server = httptest.NewServer(handler)
 
-		request, err := http.NewRequest("POST", server.URL+"?:team_name=some-team&:pipeline_name=some-pipeline", nil)
+		request, err := http.NewRequest("POST", server.URL+query, nil)
 		Expect(err).NotTo(HaveOccurred())
 
 		response, err = new(http.Client).Do(request)
@@ -115,7 +119,34 @@ var _ = Describe("Handler", func() {
 
 				It("looks up the pipeline by the right name", func() {
 					Expect(fakeTeam.PipelineCallCount()).To(Equal(1))
-					Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal("some-pipeline"))
+					Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+				})
+
+				Context("when instance vars are given", func() {
+					BeforeEach(func() {
+						query += "&instance_vars=%7B%22branch%22%3A%22master%22%7D"
+					})
+
+					It("looks up the pipeline instance", func() {
+						Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{
+							Name:         "some-pipeline",
+							InstanceVars: atc.InstanceVars{"branch": "master"},
+						}))
+					})
+				})
+
+				Context("when the instance vars are malformed", func() {
+					BeforeEach(func() {
+						query += "&instance_vars=%7B"
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not call the scoped handler", func() {
+						Expect(delegate.IsCalled).To(BeFalse())
+					})
 				})
 
 				It("returns 200", func() {
@@ -0,0 +1,74 @@
+package policychecker
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/policy"
+	"sigs.k8s.io/yaml"
+)
+
+//go:generate counterfeiter . PolicyChecker
+
+type PolicyChecker interface {
+	Check(string, accessor.Access, *http.Request) (policy.PolicyCheckOutput, error)
+}
+
+type checker struct {
+	policyChecker policy.Checker
+}
+
+func NewApiPolicyChecker(policyChecker policy.Checker) PolicyChecker {
+	return &checker{policyChecker: policyChecker}
+}
+
+// Check builds the input document for an API action from the request and
+// passes it on to the policy checker. Actions which are not subject to policy
+// checking pass without consulting the agent.
+func (c *checker) Check(action string, acc accessor.Access, req *http.Request) (policy.PolicyCheckOutput, error) {
+	// Ignore self invoked API calls.
+	if acc.IsSystem() {
+		return policy.PassedPolicyCheck, nil
+	}
+
+	if c.policyChecker.ShouldSkipAction(action) {
+		return policy.PassedPolicyCheck, nil
+	}
+
+	if !c.policyChecker.ShouldCheckHttpMethod(req.Method) && !c.policyChecker.ShouldCheckAction(action) {
+		return policy.PassedPolicyCheck, nil
+	}
+
+	input := policy.PolicyCheckInput{
+		HttpMethod: req.Method,
+		Action:     action,
+		User:       acc.UserName(),
+		Team:       req.FormValue(":team_name"),
+		Pipeline:   req.FormValue(":pipeline_name"),
+	}
+
+	switch req.Header.Get("Content-type") {
+	case "application/json", "text/vnd.yaml", "text/yaml", "text/x-yaml", "application/x-yaml":
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return policy.PolicyCheckOutput{}, err
+		}
+
+		// restore the body so that the API handler can read it
+		req.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+
+		if len(body) > 0 {
+			var data interface{}
+			err = yaml.Unmarshal(body, &data)
+			if err != nil {
+				return policy.PolicyCheckOutput{}, err
+			}
+
+			input.Data = data
+		}
+	}
+
+	return c.policyChecker.Check(input)
+}
@@ -0,0 +1,130 @@
+package policychecker_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/api/policychecker"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PolicyChecker", func() {
+	var (
+		fakePolicyChecker *policyfakes.FakeChecker
+		fakeAccess        *accessorfakes.FakeAccess
+		checker           policychecker.PolicyChecker
+
+		action string
+		req    *http.Request
+		result policy.PolicyCheckOutput
+		err    error
+	)
+
+	BeforeEach(func() {
+		fakePolicyChecker = new(policyfakes.FakeChecker)
+		fakePolicyChecker.CheckReturns(policy.PolicyCheckOutput{Allowed: false, ShouldBlock: true}, nil)
+
+		fakeAccess = new(accessorfakes.FakeAccess)
+		fakeAccess.UserNameReturns("some-user")
+
+		checker = policychecker.NewApiPolicyChecker(fakePolicyChecker)
+
+		action = "SaveConfig"
+		req, err = http.NewRequest("PUT", "/api/v1/teams/some-team/pipelines/some-pipeline/config?:team_name=some-team&:pipeline_name=some-pipeline", bytes.NewBufferString("jobs:\n- name: some-job\n"))
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Content-Type", "application/x-yaml")
+	})
+
+	JustBeforeEach(func() {
+		result, err = checker.Check(action, fakeAccess, req)
+	})
+
+	Context("when the action is filtered by http method", func() {
+		BeforeEach(func() {
+			fakePolicyChecker.ShouldCheckHttpMethodReturns(true)
+		})
+
+		It("checks the input built from the request", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+			Expect(fakePolicyChecker.CheckArgsForCall(0)).To(Equal(policy.PolicyCheckInput{
+				HttpMethod: "PUT",
+				Action:     "SaveConfig",
+				User:       "some-user",
+				Team:       "some-team",
+				Pipeline:   "some-pipeline",
+				Data: map[string]interface{}{
+					"jobs": []interface{}{
+						map[string]interface{}{"name": "some-job"},
+					},
+				},
+			}))
+		})
+
+		It("returns the result", func() {
+			Expect(result.Allowed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+		})
+
+		It("leaves the request body readable", func() {
+			body, readErr := ioutil.ReadAll(req.Body)
+			Expect(readErr).ToNot(HaveOccurred())
+			Expect(string(body)).To(Equal("jobs:\n- name: some-job\n"))
+		})
+
+		Context("when the action is skipped", func() {
+			BeforeEach(func() {
+				fakePolicyChecker.ShouldSkipActionReturns(true)
+			})
+
+			It("passes without checking", func() {
+				Expect(fakePolicyChecker.CheckCallCount()).To(BeZero())
+				Expect(result).To(Equal(policy.PassedPolicyCheck))
+			})
+		})
+
+		Context("when the request is made by the system", func() {
+			BeforeEach(func() {
+				fakeAccess.IsSystemReturns(true)
+			})
+
+			It("passes without checking", func() {
+				Expect(fakePolicyChecker.CheckCallCount()).To(BeZero())
+				Expect(result).To(Equal(policy.PassedPolicyCheck))
+			})
+		})
+	})
+
+	Context("when the action is filtered by name", func() {
+		BeforeEach(func() {
+			action = "HijackContainer"
+			req, err = http.NewRequest("GET", "/api/v1/teams/some-team/containers/some-handle/hijack?:team_name=some-team", nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			fakePolicyChecker.ShouldCheckActionReturns(true)
+		})
+
+		It("checks the action", func() {
+			Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+			Expect(fakePolicyChecker.CheckArgsForCall(0)).To(Equal(policy.PolicyCheckInput{
+				HttpMethod: "GET",
+				Action:     "HijackContainer",
+				User:       "some-user",
+				Team:       "some-team",
+			}))
+		})
+	})
+
+	Context("when the action is not filtered", func() {
+		It("passes without checking", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakePolicyChecker.CheckCallCount()).To(BeZero())
+			Expect(result).To(Equal(policy.PassedPolicyCheck))
+		})
+	})
+})
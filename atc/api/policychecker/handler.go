@@ -0,0 +1,61 @@
+package policychecker
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/accessor"
+)
+
+const WarningHeader = "X-Concourse-Policy-Check-Warning"
+
+func NewHandler(
+	logger lager.Logger,
+	handler http.Handler,
+	action string,
+	policyChecker PolicyChecker,
+) http.Handler {
+	return policyCheckingHandler{
+		logger:        logger,
+		handler:       handler,
+		action:        action,
+		policyChecker: policyChecker,
+	}
+}
+
+type policyCheckingHandler struct {
+	logger        lager.Logger
+	handler       http.Handler
+	action        string
+	policyChecker PolicyChecker
+}
+
+func (h policyCheckingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	acc := accessor.GetAccessor(r)
+
+	result, err := h.policyChecker.Check(h.action, acc, r)
+	if err != nil {
+		h.logger.Error("policy-check-error", err, lager.Data{"action": h.action})
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "policy check error: %s", err.Error())
+		return
+	}
+
+	if !result.Allowed {
+		reasons := strings.Join(result.Reasons, ", ")
+
+		if result.ShouldBlock {
+			h.logger.Info("policy-check-denied", lager.Data{"action": h.action, "reasons": result.Reasons})
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintf(w, "policy check failed: %s", reasons)
+			return
+		}
+
+		h.logger.Info("policy-check-warning", lager.Data{"action": h.action, "reasons": result.Reasons})
+		w.Header().Set(WarningHeader, reasons)
+	}
+
+	h.handler.ServeHTTP(w, r)
+}
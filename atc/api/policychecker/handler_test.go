@@ -0,0 +1,112 @@
+package policychecker_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/api/policychecker"
+	"github.com/concourse/concourse/atc/api/policychecker/policycheckerfakes"
+	"github.com/concourse/concourse/atc/policy"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Handler", func() {
+	var (
+		innerHandlerCalled bool
+		dummyHandler       http.HandlerFunc
+		fakePolicyChecker  *policycheckerfakes.FakePolicyChecker
+		handler            http.Handler
+		recorder           *httptest.ResponseRecorder
+		req                *http.Request
+	)
+
+	BeforeEach(func() {
+		innerHandlerCalled = false
+		dummyHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			innerHandlerCalled = true
+		})
+
+		fakePolicyChecker = new(policycheckerfakes.FakePolicyChecker)
+
+		var err error
+		req, err = http.NewRequest("PUT", "localhost:8080", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		recorder = httptest.NewRecorder()
+	})
+
+	JustBeforeEach(func() {
+		handler = policychecker.NewHandler(lagertest.NewTestLogger("test"), dummyHandler, "some-action", fakePolicyChecker)
+		handler.ServeHTTP(recorder, req)
+	})
+
+	It("checks the handler's action", func() {
+		Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+		action, _, _ := fakePolicyChecker.CheckArgsForCall(0)
+		Expect(action).To(Equal("some-action"))
+	})
+
+	Context("when the policy check passes", func() {
+		BeforeEach(func() {
+			fakePolicyChecker.CheckReturns(policy.PassedPolicyCheck, nil)
+		})
+
+		It("calls the inner handler", func() {
+			Expect(innerHandlerCalled).To(BeTrue())
+			Expect(recorder.Header().Get(policychecker.WarningHeader)).To(BeEmpty())
+		})
+	})
+
+	Context("when the policy check denies the action", func() {
+		BeforeEach(func() {
+			fakePolicyChecker.CheckReturns(policy.PolicyCheckOutput{
+				Allowed:     false,
+				ShouldBlock: true,
+				Reasons:     []string{"a policy says you can't do that", "another policy also says you can't do that"},
+			}, nil)
+		})
+
+		It("returns 403 with the reasons", func() {
+			Expect(innerHandlerCalled).To(BeFalse())
+			Expect(recorder.Code).To(Equal(http.StatusForbidden))
+
+			body, err := ioutil.ReadAll(recorder.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(Equal("policy check failed: a policy says you can't do that, another policy also says you can't do that"))
+		})
+	})
+
+	Context("when the policy check only warns", func() {
+		BeforeEach(func() {
+			fakePolicyChecker.CheckReturns(policy.PolicyCheckOutput{
+				Allowed:     false,
+				ShouldBlock: false,
+				Reasons:     []string{"a policy says you shouldn't do that"},
+			}, nil)
+		})
+
+		It("calls the inner handler with a warning header", func() {
+			Expect(innerHandlerCalled).To(BeTrue())
+			Expect(recorder.Header().Get(policychecker.WarningHeader)).To(Equal("a policy says you shouldn't do that"))
+		})
+	})
+
+	Context("when the policy check errors", func() {
+		BeforeEach(func() {
+			fakePolicyChecker.CheckReturns(policy.PolicyCheckOutput{}, errors.New("agent unavailable"))
+		})
+
+		It("returns 500", func() {
+			Expect(innerHandlerCalled).To(BeFalse())
+			Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+
+			body, err := ioutil.ReadAll(recorder.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(Equal("policy check error: agent unavailable"))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package policychecker_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestPolicyChecker(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Policy Checker Suite")
+}
@@ -0,0 +1,23 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func ArchivedArtifacts(artifacts []db.ArchivedArtifact) []atc.ArchivedArtifact {
+	presented := []atc.ArchivedArtifact{}
+	for _, a := range artifacts {
+		presented = append(presented, ArchivedArtifact(a))
+	}
+	return presented
+}
+
+func ArchivedArtifact(artifact db.ArchivedArtifact) atc.ArchivedArtifact {
+	return atc.ArchivedArtifact{
+		PlanID:    artifact.PlanID,
+		Name:      artifact.Name,
+		Size:      artifact.Size,
+		CreatedAt: artifact.CreatedAt.Unix(),
+	}
+}
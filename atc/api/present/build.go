@@ -19,13 +19,17 @@ func Build(build db.Build) atc.Build {
 	}
 
 	atcBuild := atc.Build{
-		ID:           build.ID(),
-		Name:         build.Name(),
-		JobName:      build.JobName(),
-		PipelineName: build.PipelineName(),
-		TeamName:     build.TeamName(),
-		Status:       string(build.Status()),
-		APIURL:       apiURL,
+		ID:                   build.ID(),
+		Name:                 build.Name(),
+		JobName:              build.JobName(),
+		PipelineName:         build.PipelineName(),
+		PipelineInstanceVars: build.PipelineInstanceVars(),
+		TeamName:             build.TeamName(),
+		Status:               string(build.Status()),
+		APIURL:               apiURL,
+		Priority:             build.Priority(),
+		TestSummary:          build.TestSummary(),
+		CreatedBy:            build.CreatedBy(),
 	}
 
 	if !build.StartTime().IsZero() {
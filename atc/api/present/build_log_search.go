@@ -0,0 +1,24 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func BuildLogSearchResult(match db.BuildLogMatch) atc.BuildLogSearchResult {
+	lines := make([]atc.BuildLogLine, len(match.Lines))
+	for i, line := range match.Lines {
+		lines[i] = atc.BuildLogLine{
+			EventID: line.EventID,
+			Time:    line.Time,
+			Origin:  string(line.Origin.ID),
+			Source:  string(line.Origin.Source),
+			Line:    line.Line,
+		}
+	}
+
+	return atc.BuildLogSearchResult{
+		Build: Build(match.Build),
+		Lines: lines,
+	}
+}
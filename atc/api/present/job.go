@@ -1,6 +1,8 @@
 package present
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -47,11 +49,25 @@ func Job(
 		})
 	}
 
+	var nextScheduledBuild int64
+	if schedule := job.Config().Schedule; schedule != nil {
+		after := job.ScheduleTime()
+		if after.IsZero() {
+			after = time.Now()
+		}
+
+		next, err := schedule.Next(after)
+		if err == nil && !next.IsZero() {
+			nextScheduledBuild = next.Unix()
+		}
+	}
+
 	return atc.Job{
 		ID: job.ID(),
 
 		Name:                 job.Name(),
 		PipelineName:         job.PipelineName(),
+		PipelineInstanceVars: job.PipelineInstanceVars(),
 		TeamName:             teamName,
 		DisableManualTrigger: job.Config().DisableManualTrigger,
 		Paused:               job.Paused(),
@@ -60,6 +76,7 @@ func Job(
 		NextBuild:            presentedNextBuild,
 		TransitionBuild:      presentedTransitionBuild,
 		HasNewInputs:         job.HasNewInputs(),
+		NextScheduledBuild:   nextScheduledBuild,
 
 		Inputs:  sanitizedInputs,
 		Outputs: sanitizedOutputs,
@@ -0,0 +1,25 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func Semaphore(semaphore db.Semaphore) atc.Semaphore {
+	presented := atc.Semaphore{
+		Name:     semaphore.Name,
+		Capacity: semaphore.Capacity,
+		Holders:  []atc.Build{},
+		Waiting:  []atc.Build{},
+	}
+
+	for _, build := range semaphore.Holders {
+		presented.Holders = append(presented.Holders, Build(build))
+	}
+
+	for _, build := range semaphore.Waiting {
+		presented.Waiting = append(presented.Waiting, Build(build))
+	}
+
+	return presented
+}
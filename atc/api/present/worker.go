@@ -40,6 +40,7 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		State:            string(workerInfo.State()),
 		Version:          version,
 		Ephemeral:        workerInfo.Ephemeral(),
+		Runtime:          workerInfo.Runtime(),
 	}
 
 	if !workerInfo.StartTime().IsZero() {
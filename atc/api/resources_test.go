@@ -15,6 +15,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/vars"
@@ -1510,6 +1511,36 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 
+		Context("when the webhook token comes from a var source of the pipeline", func() {
+			var fakeVarSourceSecrets *credsfakes.FakeSecrets
+
+			BeforeEach(func() {
+				fakePipeline.VarSourcesReturns(atc.VarSourceConfigs{
+					{Name: "some-source", Type: "some-type"},
+				})
+
+				fakeVarSourceSecrets = new(credsfakes.FakeSecrets)
+				fakeVarSourceSecrets.NewSecretLookupPathsReturns([]creds.SecretLookupPath{creds.NewSecretLookupWithPrefix("")})
+				fakeVarSourceSecrets.GetReturns("fake-token", nil, true, nil)
+				fakeVarSourcePool.FindOrCreateReturns(fakeVarSourceSecrets, nil)
+
+				fakeResource.WebhookTokenReturns("((some-source:webhook-token))")
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+				fakePipeline.ResourceTypesReturns(db.ResourceTypes{}, nil)
+				dbCheckFactory.TryCreateCheckReturns(new(dbfakes.FakeCheck), true, nil)
+			})
+
+			It("looks up the token in the var source", func() {
+				Expect(fakeVarSourcePool.FindOrCreateCallCount()).To(Equal(1))
+				Expect(fakeVarSourcePool.FindOrCreateArgsForCall(0).Name).To(Equal("some-source"))
+				Expect(fakeVarSourceSecrets.GetArgsForCall(0)).To(Equal("webhook-token"))
+			})
+
+			It("creates the check", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusCreated))
+			})
+		})
+
 		Context("when unauthorized", func() {
 			BeforeEach(func() {
 				fakeResource.WebhookTokenReturns("wrong-token")
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
@@ -39,7 +40,18 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		variables := creds.NewVariables(s.secretManager, dbPipeline.TeamName(), dbPipeline.Name())
+		access := atc.SecretAccess{
+			TeamName:     dbPipeline.TeamName(),
+			PipelineName: dbPipeline.Name(),
+		}
+
+		variables := creds.NewPipelineVariables(
+			creds.WithSecretAccess(s.secretManager, access),
+			creds.WithVarSourceAccess(s.varSourcePool, access),
+			dbPipeline.TeamName(),
+			dbPipeline.Name(),
+			dbPipeline.VarSources(),
+		)
 		token, err := creds.NewString(variables, dbResource.WebhookToken()).Evaluate()
 		if token != webhookToken {
 			logger.Info("invalid-token", lager.Data{"error": fmt.Sprintf("invalid token for webhook %s", webhookToken)})
@@ -0,0 +1,170 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Secret Accesses API", func() {
+	var (
+		response   *http.Response
+		fakeaccess *accessorfakes.FakeAccess
+		query      url.Values
+	)
+
+	BeforeEach(func() {
+		fakeaccess = new(accessorfakes.FakeAccess)
+		query = url.Values{}
+	})
+
+	Context("GET /api/v1/secret_accesses", func() {
+		JustBeforeEach(func() {
+			fakeAccessor.CreateReturns(fakeaccess)
+
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/secret_accesses", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			req.URL.RawQuery = query.Encode()
+
+			response, err = client.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("not an admin", func() {
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("being an admin", func() {
+				BeforeEach(func() {
+					fakeaccess.IsAdminReturns(true)
+
+					dbSecretAccessFactory.SecretAccessesReturns([]atc.SecretAccess{
+						{
+							ID:           1,
+							Time:         10,
+							TeamName:     "some-team",
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							BuildID:      42,
+							Variable:     "foo",
+							Path:         "/concourse/some-team/foo",
+							Manager:      "vault",
+							CacheHit:     true,
+							Found:        true,
+						},
+					}, nil)
+				})
+
+				It("succeeds", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+				})
+
+				It("returns the secret accesses", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[{
+						"id": 1,
+						"time": 10,
+						"team_name": "some-team",
+						"pipeline_name": "some-pipeline",
+						"job_name": "some-job",
+						"build_id": 42,
+						"variable": "foo",
+						"path": "/concourse/some-team/foo",
+						"manager": "vault",
+						"cache_hit": true,
+						"found": true
+					}]`))
+				})
+
+				It("returns the 100 most recent secret accesses by default", func() {
+					Expect(dbSecretAccessFactory.SecretAccessesCallCount()).To(Equal(1))
+					Expect(dbSecretAccessFactory.SecretAccessesArgsForCall(0)).To(Equal(db.SecretAccessFilter{
+						Limit: 100,
+					}))
+				})
+
+				Context("when filters are given", func() {
+					BeforeEach(func() {
+						query.Set("team", "some-team")
+						query.Set("pipeline", "some-pipeline")
+						query.Set("job", "some-job")
+						query.Set("build_id", "42")
+						query.Set("variable", "foo")
+						query.Set("manager", "vault")
+						query.Set("since", "10")
+						query.Set("until", "20")
+						query.Set("limit", "5")
+					})
+
+					It("filters the secret accesses", func() {
+						Expect(dbSecretAccessFactory.SecretAccessesArgsForCall(0)).To(Equal(db.SecretAccessFilter{
+							TeamName:     "some-team",
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							BuildID:      42,
+							Variable:     "foo",
+							Manager:      "vault",
+							Since:        time.Unix(10, 0),
+							Until:        time.Unix(20, 0),
+							Limit:        5,
+						}))
+					})
+				})
+
+				Context("when a filter is malformed", func() {
+					BeforeEach(func() {
+						query.Set("since", "yesterday")
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(dbSecretAccessFactory.SecretAccessesCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("failing to retrieve secret accesses", func() {
+					BeforeEach(func() {
+						dbSecretAccessFactory.SecretAccessesReturns(nil, errors.New("no db connection"))
+					})
+
+					It("fails", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+		})
+
+		Context("not authenticated", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
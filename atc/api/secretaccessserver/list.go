@@ -0,0 +1,82 @@
+package secretaccessserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// defaultLimit is how many secret accesses are returned when no limit is
+// given.
+const defaultLimit = 100
+
+func (s *Server) ListSecretAccesses(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-secret-accesses")
+
+	filter := db.SecretAccessFilter{
+		TeamName:     r.FormValue(atc.SecretAccessQueryTeam),
+		PipelineName: r.FormValue(atc.SecretAccessQueryPipeline),
+		JobName:      r.FormValue(atc.SecretAccessQueryJob),
+		Variable:     r.FormValue(atc.SecretAccessQueryVariable),
+		Manager:      r.FormValue(atc.SecretAccessQueryManager),
+		Limit:        defaultLimit,
+	}
+
+	for query, value := range map[string]*int{
+		atc.SecretAccessQueryBuildID: &filter.BuildID,
+		atc.SecretAccessQueryLimit:   &filter.Limit,
+	} {
+		urlValue := r.FormValue(query)
+		if urlValue == "" {
+			continue
+		}
+
+		number, err := strconv.Atoi(urlValue)
+		if err != nil || number < 0 {
+			logger.Info("malformed-"+query, lager.Data{query: urlValue})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		*value = number
+	}
+
+	for query, value := range map[string]*time.Time{
+		atc.SecretAccessQuerySince: &filter.Since,
+		atc.SecretAccessQueryUntil: &filter.Until,
+	} {
+		urlValue := r.FormValue(query)
+		if urlValue == "" {
+			continue
+		}
+
+		seconds, err := strconv.ParseInt(urlValue, 10, 64)
+		if err != nil {
+			logger.Info("malformed-"+query, lager.Data{query: urlValue})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		*value = time.Unix(seconds, 0)
+	}
+
+	accesses, err := s.secretAccessFactory.SecretAccesses(filter)
+	if err != nil {
+		logger.Error("failed-to-get-secret-accesses", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	err = json.NewEncoder(w).Encode(accesses)
+	if err != nil {
+		logger.Error("failed-to-encode-secret-accesses", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,21 @@
+package secretaccessserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger              lager.Logger
+	secretAccessFactory db.SecretAccessFactory
+}
+
+func NewServer(
+	logger lager.Logger,
+	secretAccessFactory db.SecretAccessFactory,
+) *Server {
+	return &Server{
+		logger:              logger,
+		secretAccessFactory: secretAccessFactory,
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/concourse/concourse/atc/api/accessor/accessorfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -778,4 +779,310 @@ var _ = Describe("Teams API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/teams/:team_name/logs/search", func() {
+		var (
+			response    *http.Response
+			queryParams string
+
+			fakeBuild *dbfakes.FakeBuild
+		)
+
+		BeforeEach(func() {
+			queryParams = "?q=connection+refused"
+
+			fakeBuild = new(dbfakes.FakeBuild)
+			fakeBuild.IDReturns(42)
+			fakeBuild.NameReturns("3")
+			fakeBuild.JobIDReturns(7)
+			fakeBuild.JobNameReturns("some-job")
+			fakeBuild.PipelineNameReturns("some-pipeline")
+			fakeBuild.TeamNameReturns("some-team")
+			fakeBuild.StatusReturns(db.BuildStatusFailed)
+
+			dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			fakeTeam.SearchBuildLogsReturns([]db.BuildLogMatch{
+				{
+					Build: fakeBuild,
+					Lines: []db.BuildLogLine{
+						{
+							EventID: 3,
+							Time:    123,
+							Origin:  event.Origin{ID: "some-task-id", Source: event.OriginSourceStderr},
+							Line:    "Error: connection refused",
+						},
+					},
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/logs/search" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authorized for the team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			It("searches all of the team's builds", func() {
+				Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(1))
+				Expect(fakeTeam.SearchBuildLogsArgsForCall(0)).To(Equal(db.BuildLogSearch{
+					Query: "connection refused",
+					Limit: 100,
+				}))
+			})
+
+			It("returns the matching builds and lines", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"build": {
+							"id": 42,
+							"name": "3",
+							"job_name": "some-job",
+							"pipeline_name": "some-pipeline",
+							"team_name": "some-team",
+							"status": "failed",
+							"api_url": "/api/v1/builds/42"
+						},
+						"lines": [
+							{
+								"event_id": 3,
+								"time": 123,
+								"origin": "some-task-id",
+								"source": "stderr",
+								"line": "Error: connection refused"
+							}
+						]
+					}
+				]`))
+			})
+
+			Context("when filters are given", func() {
+				BeforeEach(func() {
+					queryParams = "?q=refused&pipeline_name=some-pipeline&job_name=some-job&status=failed&status=errored&from=10&to=20&limit=5"
+				})
+
+				It("passes them through", func() {
+					Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(1))
+					Expect(fakeTeam.SearchBuildLogsArgsForCall(0)).To(Equal(db.BuildLogSearch{
+						Query:        "refused",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						Statuses:     []db.BuildStatus{db.BuildStatusFailed, db.BuildStatusErrored},
+						Since:        time.Unix(10, 0),
+						Until:        time.Unix(20, 0),
+						Limit:        5,
+					}))
+				})
+			})
+
+			Context("when the limit is too large", func() {
+				BeforeEach(func() {
+					queryParams = "?q=refused&limit=100000"
+				})
+
+				It("searches at most the maximum number of events", func() {
+					Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(1))
+					Expect(fakeTeam.SearchBuildLogsArgsForCall(0).Limit).To(Equal(atc.BuildLogSearchMaxLimit))
+				})
+			})
+
+			Context("when the query is missing", func() {
+				BeforeEach(func() {
+					queryParams = "?q=+"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the time range is malformed", func() {
+				BeforeEach(func() {
+					queryParams = "?q=refused&from=yesterday"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					dbTeamFactory.FindTeamReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when searching fails", func() {
+				BeforeEach(func() {
+					fakeTeam.SearchBuildLogsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when build events are not stored in postgres", func() {
+				BeforeEach(func() {
+					fakeTeam.SearchBuildLogsReturns(nil, db.ErrBuildEventsNotInPostgres)
+				})
+
+				It("returns 501 with an explanation", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotImplemented))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(ContainSubstring("only be searched when they are stored in postgres"))
+				})
+			})
+		})
+
+		Context("when not authorized for the team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("only searches public jobs of exposed pipelines", func() {
+				Expect(fakeTeam.SearchBuildLogsCallCount()).To(Equal(1))
+				Expect(fakeTeam.SearchBuildLogsArgsForCall(0).PublicOnly).To(BeTrue())
+			})
+
+			It("returns the matching builds", func() {
+				var results []atc.BuildLogSearchResult
+				err := json.NewDecoder(response.Body).Decode(&results)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(HaveLen(1))
+				Expect(results[0].Build.ID).To(Equal(42))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/semaphores", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			holder := new(dbfakes.FakeBuild)
+			holder.IDReturns(1)
+			holder.NameReturns("4")
+			holder.JobNameReturns("deploy")
+			holder.PipelineNameReturns("some-pipeline")
+			holder.TeamNameReturns("some-team")
+			holder.StatusReturns(db.BuildStatusStarted)
+			holder.StartTimeReturns(time.Unix(1, 0))
+
+			waiting := new(dbfakes.FakeBuild)
+			waiting.IDReturns(2)
+			waiting.NameReturns("7")
+			waiting.JobNameReturns("deploy")
+			waiting.PipelineNameReturns("some-other-pipeline")
+			waiting.TeamNameReturns("some-team")
+			waiting.StatusReturns(db.BuildStatusPending)
+
+			dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+			fakeTeam.SemaphoresReturns([]db.Semaphore{
+				{
+					Name:     "staging",
+					Capacity: 1,
+					Holders:  []db.Build{holder},
+					Waiting:  []db.Build{waiting},
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/semaphores")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized for the team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized for the team", func() {
+			BeforeEach(func() {
+				fakeaccess.IsAuthenticatedReturns(true)
+				fakeaccess.IsAuthorizedReturns(true)
+			})
+
+			It("returns 200 OK", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns Content-Type 'application/json'", func() {
+				Expect(response.Header.Get("Content-Type")).To(Equal("application/json"))
+			})
+
+			It("returns the semaphores with their holders and waiting builds", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"name": "staging",
+						"capacity": 1,
+						"holders": [
+							{
+								"id": 1,
+								"name": "4",
+								"job_name": "deploy",
+								"pipeline_name": "some-pipeline",
+								"team_name": "some-team",
+								"status": "started",
+								"api_url": "/api/v1/builds/1",
+								"start_time": 1
+							}
+						],
+						"waiting": [
+							{
+								"id": 2,
+								"name": "7",
+								"job_name": "deploy",
+								"pipeline_name": "some-other-pipeline",
+								"team_name": "some-team",
+								"status": "pending",
+								"api_url": "/api/v1/builds/2"
+							}
+						]
+					}
+				]`))
+			})
+
+			Context("when listing the semaphores fails", func() {
+				BeforeEach(func() {
+					fakeTeam.SemaphoresReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
 })
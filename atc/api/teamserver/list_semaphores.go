@@ -0,0 +1,39 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// ListSemaphores lists the team's semaphores along with the builds holding
+// and waiting for each of them.
+func (s *Server) ListSemaphores(team db.Team) http.Handler {
+	logger := s.logger.Session("list-semaphores")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		semaphores, err := team.Semaphores()
+		if err != nil {
+			logger.Error("failed-to-list-semaphores", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := []atc.Semaphore{}
+		for _, semaphore := range semaphores {
+			presented = append(presented, present.Semaphore(semaphore))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-semaphores", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
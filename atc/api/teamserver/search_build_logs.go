@@ -0,0 +1,135 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// SearchBuildLogs searches the logs of the team's builds.
+//
+// Users who are not authorized for the team only see results from public
+// jobs of exposed pipelines, i.e. builds whose logs they could otherwise
+// view. At most BuildLogSearchMaxLimit log events are searched.
+func (s *Server) SearchBuildLogs(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("search-build-logs")
+
+	teamName := r.FormValue(":team_name")
+
+	search, err := parseBuildLogSearch(r)
+	if err != nil {
+		logger.Info("malformed-request", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	team, found, err := s.teamFactory.FindTeam(teamName)
+	if err != nil {
+		logger.Error("failed-to-find-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	acc := accessor.GetAccessor(r)
+	authorized := acc.IsAuthenticated() && acc.IsAuthorized(teamName)
+
+	search.PublicOnly = !authorized
+
+	matches, err := team.SearchBuildLogs(search)
+	if err == db.ErrBuildEventsNotInPostgres {
+		logger.Info("build-events-not-in-postgres")
+		w.WriteHeader(http.StatusNotImplemented)
+		w.Write([]byte("build logs can only be searched when they are stored in postgres"))
+		return
+	}
+
+	if err != nil {
+		logger.Error("failed-to-search-build-logs", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	results := []atc.BuildLogSearchResult{}
+	for _, match := range matches {
+		results = append(results, present.BuildLogSearchResult(match))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(results)
+	if err != nil {
+		logger.Error("failed-to-encode-search-results", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func parseBuildLogSearch(r *http.Request) (db.BuildLogSearch, error) {
+	search := db.BuildLogSearch{
+		Query:        strings.TrimSpace(r.FormValue(atc.BuildLogSearchQuery)),
+		PipelineName: r.FormValue(atc.BuildLogSearchPipeline),
+		JobName:      r.FormValue(atc.BuildLogSearchJob),
+		Limit:        atc.PaginationAPIDefaultLimit,
+	}
+
+	if search.Query == "" {
+		return db.BuildLogSearch{}, errors.New("search query must not be empty")
+	}
+
+	for _, status := range r.Form[atc.BuildLogSearchStatus] {
+		search.Statuses = append(search.Statuses, db.BuildStatus(status))
+	}
+
+	var err error
+	search.Since, err = parseUnixTime(r.FormValue(atc.BuildLogSearchFrom))
+	if err != nil {
+		return db.BuildLogSearch{}, fmt.Errorf("malformed '%s': %s", atc.BuildLogSearchFrom, err)
+	}
+
+	search.Until, err = parseUnixTime(r.FormValue(atc.BuildLogSearchTo))
+	if err != nil {
+		return db.BuildLogSearch{}, fmt.Errorf("malformed '%s': %s", atc.BuildLogSearchTo, err)
+	}
+
+	if urlLimit := r.FormValue(atc.BuildLogSearchLimit); urlLimit != "" {
+		search.Limit, err = strconv.Atoi(urlLimit)
+		if err != nil || search.Limit <= 0 {
+			return db.BuildLogSearch{}, fmt.Errorf("malformed '%s': must be a positive integer", atc.BuildLogSearchLimit)
+		}
+
+		if search.Limit > atc.BuildLogSearchMaxLimit {
+			search.Limit = atc.BuildLogSearchMaxLimit
+		}
+	}
+
+	return search, nil
+}
+
+func parseUnixTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(seconds, 0), nil
+}
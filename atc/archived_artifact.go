@@ -0,0 +1,8 @@
+package atc
+
+type ArchivedArtifact struct {
+	PlanID    PlanID `json:"plan_id"`
+	Name      string `json:"name"`
+	Size      int64  `json:"size"`
+	CreatedAt int64  `json:"created_at"`
+}
@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
 	_ "net/http/pprof"
@@ -23,7 +24,9 @@ import (
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/api/buildserver"
 	"github.com/concourse/concourse/atc/api/containerserver"
+	"github.com/concourse/concourse/atc/api/policychecker"
 	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/blobstore"
 	"github.com/concourse/concourse/atc/builds"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/noop"
@@ -33,22 +36,27 @@ import (
 	"github.com/concourse/concourse/atc/db/migration"
 	"github.com/concourse/concourse/atc/engine"
 	"github.com/concourse/concourse/atc/engine/builder"
+	"github.com/concourse/concourse/atc/eventstore"
 	"github.com/concourse/concourse/atc/fetcher"
 	"github.com/concourse/concourse/atc/gc"
+	"github.com/concourse/concourse/atc/keyrotation"
 	"github.com/concourse/concourse/atc/lidar"
 	"github.com/concourse/concourse/atc/lockrunner"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/pipelines"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/radar"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/syslog"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/image"
+	"github.com/concourse/concourse/atc/worker/k8s"
 	"github.com/concourse/concourse/atc/wrappa"
 	"github.com/concourse/concourse/skymarshal"
 	"github.com/concourse/concourse/skymarshal/skycmd"
 	"github.com/concourse/concourse/skymarshal/storage"
+	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/web"
 	"github.com/concourse/flag"
 	"github.com/concourse/retryhttp"
@@ -61,16 +69,29 @@ import (
 	"github.com/tedsuo/ifrit/sigmon"
 	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
+	"sigs.k8s.io/yaml"
 
 	// dynamically registered metric emitters
 	_ "github.com/concourse/concourse/atc/metric/emitter"
 
 	// dynamically registered credential managers
 	_ "github.com/concourse/concourse/atc/creds/credhub"
+	_ "github.com/concourse/concourse/atc/creds/dev"
 	_ "github.com/concourse/concourse/atc/creds/kubernetes"
 	_ "github.com/concourse/concourse/atc/creds/secretsmanager"
 	_ "github.com/concourse/concourse/atc/creds/ssm"
 	_ "github.com/concourse/concourse/atc/creds/vault"
+
+	// dynamically registered policy check agents
+	_ "github.com/concourse/concourse/atc/policy/opa"
+
+	// dynamically registered artifact stores
+	_ "github.com/concourse/concourse/atc/blobstore/local"
+	_ "github.com/concourse/concourse/atc/blobstore/s3"
+
+	// dynamically registered build log stores
+	_ "github.com/concourse/concourse/atc/eventstore/elasticsearch"
+	_ "github.com/concourse/concourse/atc/eventstore/file"
 )
 
 var defaultDriverName = "postgres"
@@ -105,8 +126,12 @@ type RunCommand struct {
 	CredentialManagement creds.CredentialManagementConfig `group:"Credential Management"`
 	CredentialManagers   creds.Managers
 
-	EncryptionKey    flag.Cipher `long:"encryption-key"     description:"A 16 or 32 length key used to encrypt sensitive information before storing it in the database."`
-	OldEncryptionKey flag.Cipher `long:"old-encryption-key" description:"Encryption key previously used for encrypting sensitive information. If provided without a new key, data is encrypted. If provided with a new key, data is re-encrypted."`
+	EncryptionKey     flag.Cipher `long:"encryption-key"      description:"A 16 or 32 length key used to encrypt sensitive information before storing it in the database."`
+	OldEncryptionKey  flag.Cipher `long:"old-encryption-key"  description:"Encryption key previously used for encrypting sensitive information. If provided without a new key, data is decrypted. If provided with a new key, data is re-encrypted in the background."`
+	NextEncryptionKey flag.Cipher `long:"next-encryption-key" description:"Encryption key about to replace the current one. Data encrypted with it can be decrypted, so that ATCs already configured with it as their encryption key can run alongside this one."`
+
+	EncryptionKeyRotationInterval  time.Duration `long:"encryption-key-rotation-interval"   default:"1m"  description:"Interval on which to re-encrypt data encrypted with the old encryption key."`
+	EncryptionKeyRotationBatchSize int           `long:"encryption-key-rotation-batch-size" default:"500" description:"Maximum number of rows to re-encrypt with the new encryption key at once."`
 
 	DebugBindIP   flag.IP `long:"debug-bind-ip"   default:"127.0.0.1" description:"IP address on which to listen for the pprof debugger endpoints."`
 	DebugBindPort uint16  `long:"debug-bind-port" default:"8079"      description:"Port on which to listen for the pprof debugger endpoints."`
@@ -128,6 +153,8 @@ type RunCommand struct {
 
 	CLIArtifactsDir flag.Dir `long:"cli-artifacts-dir" description:"Directory containing downloadable CLI binaries."`
 
+	ConfigRBAC flag.File `long:"config-rbac" description:"YAML file mapping roles to the actions they are permitted to perform, overriding or extending the default role-action mapping."`
+
 	Developer struct {
 		Noop bool `short:"n" long:"noop"              description:"Don't actually do any automatic scheduling or checking."`
 	} `group:"Developer Options"`
@@ -138,6 +165,8 @@ type RunCommand struct {
 		ResourceTypes   map[string]string `long:"resource"         description:"A resource type to advertise for the worker. Can be specified multiple times." value-name:"TYPE:IMAGE"`
 	} `group:"Static Worker (optional)" namespace:"worker"`
 
+	KubernetesWorker k8s.Config `group:"Kubernetes Worker (optional)" namespace:"kubernetes-worker"`
+
 	Metrics struct {
 		HostName            string            `long:"metrics-host-name" description:"Host string to attach to emitted metrics."`
 		Attributes          map[string]string `long:"metrics-attribute" description:"A key-value attribute to attach to emitted metrics. Can be specified multiple times." value-name:"NAME:VALUE"`
@@ -145,6 +174,16 @@ type RunCommand struct {
 		CaptureErrorMetrics bool              `long:"capture-error-metrics" description:"Enable capturing of error log metrics"`
 	} `group:"Metrics & Diagnostics"`
 
+	Tracing tracing.Config `group:"Tracing" namespace:"tracing"`
+
+	PolicyCheckers struct {
+		Filter policy.Filter
+	} `group:"Policy Checking"`
+
+	ArtifactStores struct{} `group:"Artifact Archiving"`
+
+	BuildLogStores struct{} `group:"Build Log Storage"`
+
 	Server struct {
 		XFrameOptions string `long:"x-frame-options" default:"deny" description:"The value to set for X-Frame-Options."`
 		ClusterName   string `long:"cluster-name" description:"A name for this Concourse cluster, to be displayed on the dashboard page."`
@@ -159,6 +198,7 @@ type RunCommand struct {
 		OneOffBuildGracePeriod time.Duration `long:"one-off-grace-period" default:"5m" description:"Period after which one-off build containers will be garbage-collected."`
 		MissingGracePeriod     time.Duration `long:"missing-grace-period" default:"5m" description:"Period after which to reap containers and volumes that were created but went missing from the worker."`
 		CheckRecyclePeriod     time.Duration `long:"check-recycle-period" default:"6h" description:"Period after which to reap checks that are completed."`
+		SecretAccessRetention  time.Duration `long:"secret-access-retention" default:"168h" description:"Period after which to reap the secret accesses recorded by secret auditing. 0 means they are kept forever."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
@@ -184,6 +224,7 @@ type RunCommand struct {
 		EnableTeamAuditLog      bool `long:"enable-team-auditing" description:"Enable auditing for all api requests connected to teams."`
 		EnableWorkerAuditLog    bool `long:"enable-worker-auditing" description:"Enable auditing for all api requests connected to workers."`
 		EnableVolumeAuditLog    bool `long:"enable-volume-auditing" description:"Enable auditing for all api requests connected to volumes."`
+		EnableSecretAuditLog    bool `long:"enable-secret-auditing" description:"Enable auditing for every lookup of a secret in a credential manager."`
 	}
 
 	Syslog struct {
@@ -269,7 +310,7 @@ func (cmd *Migration) migrateDBToVersion() error {
 
 	var strategy encryption.Strategy
 	if newKey != nil {
-		strategy = newKey
+		strategy = encryption.NewKeyRing(newKey)
 	} else {
 		strategy = encryption.NewNoEncryption()
 	}
@@ -298,6 +339,9 @@ func (cmd *RunCommand) WireDynamicFlags(commandFlags *flags.Command) {
 	var metricsGroup *flags.Group
 	var credsGroup *flags.Group
 	var authGroup *flags.Group
+	var policyChecksGroup *flags.Group
+	var artifactStoresGroup *flags.Group
+	var buildLogStoresGroup *flags.Group
 
 	groups := commandFlags.Groups()
 	for i := 0; i < len(groups); i++ {
@@ -315,7 +359,19 @@ func (cmd *RunCommand) WireDynamicFlags(commandFlags *flags.Command) {
 			authGroup = group
 		}
 
-		if metricsGroup != nil && credsGroup != nil && authGroup != nil {
+		if policyChecksGroup == nil && group.ShortDescription == "Policy Checking" {
+			policyChecksGroup = group
+		}
+
+		if artifactStoresGroup == nil && group.ShortDescription == "Artifact Archiving" {
+			artifactStoresGroup = group
+		}
+
+		if buildLogStoresGroup == nil && group.ShortDescription == "Build Log Storage" {
+			buildLogStoresGroup = group
+		}
+
+		if metricsGroup != nil && credsGroup != nil && authGroup != nil && policyChecksGroup != nil && artifactStoresGroup != nil && buildLogStoresGroup != nil {
 			break
 		}
 
@@ -334,6 +390,18 @@ func (cmd *RunCommand) WireDynamicFlags(commandFlags *flags.Command) {
 		panic("could not find Authentication group for registering connectors")
 	}
 
+	if policyChecksGroup == nil {
+		panic("could not find Policy Checking group for registering policy checkers")
+	}
+
+	if artifactStoresGroup == nil {
+		panic("could not find Artifact Archiving group for registering artifact stores")
+	}
+
+	if buildLogStoresGroup == nil {
+		panic("could not find Build Log Storage group for registering build log stores")
+	}
+
 	managerConfigs := make(creds.Managers)
 	for name, p := range creds.ManagerFactories() {
 		managerConfigs[name] = p.AddConfig(credsGroup)
@@ -342,6 +410,12 @@ func (cmd *RunCommand) WireDynamicFlags(commandFlags *flags.Command) {
 
 	metric.WireEmitters(metricsGroup)
 
+	policy.WireCheckers(policyChecksGroup)
+
+	blobstore.WireStores(artifactStoresGroup)
+
+	eventstore.WireStores(buildLogStoresGroup)
+
 	skycmd.WireConnectors(authGroup)
 	skycmd.WireTeamConnectors(authGroup.Find("Authentication (Main Team)"))
 }
@@ -411,6 +485,30 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		return nil, err
 	}
 
+	if err := cmd.Tracing.Prepare(); err != nil {
+		return nil, err
+	}
+
+	policyChecker, err := policy.Initialize(logger.Session("policy-checker"), cmd.Server.ClusterName, concourse.Version, cmd.PolicyCheckers.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	roleActions, err := cmd.configureRBAC()
+	if err != nil {
+		return nil, err
+	}
+
+	artifactStore, err := blobstore.Initialize(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	eventStore, err := eventstore.Initialize(logger)
+	if err != nil {
+		return nil, err
+	}
+
 	lockConn, err := cmd.constructLockConn(retryingDriverName)
 	if err != nil {
 		return nil, err
@@ -418,12 +516,12 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 
 	lockFactory := lock.NewLockFactory(lockConn, metric.LogLockAcquired, metric.LogLockReleased)
 
-	apiConn, err := cmd.constructDBConn(retryingDriverName, logger, cmd.MaxOpenConnections, "api", lockFactory)
+	apiConn, err := cmd.constructDBConn(retryingDriverName, logger, cmd.MaxOpenConnections, "api", lockFactory, eventStore)
 	if err != nil {
 		return nil, err
 	}
 
-	backendConn, err := cmd.constructDBConn(retryingDriverName, logger, cmd.MaxOpenConnections, "backend", lockFactory)
+	backendConn, err := cmd.constructDBConn(retryingDriverName, logger, cmd.MaxOpenConnections, "backend", lockFactory, eventStore)
 	if err != nil {
 		return nil, err
 	}
@@ -438,7 +536,21 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		return nil, err
 	}
 
-	members, err := cmd.constructMembers(logger, reconfigurableSink, apiConn, backendConn, storage, lockFactory, secretManager)
+	varSourcePool := creds.NewVarSourcePool(
+		logger.Session("var-source-pool"),
+		cmd.CredentialManagement,
+		creds.ManagerFactories(),
+	)
+
+	// every lookup of a secret is audited; each consumer notes what it is
+	// looking the secret up for, e.g. its build, with creds.WithSecretAccess
+	secretAuditor := cmd.constructAuditor(logger, db.NewSecretAccessFactory(backendConn))
+	secretManager = creds.NewAuditedSecrets(secretManager, secretAuditor, atc.SecretAccess{
+		Manager: cmd.credentialManagerName(),
+	})
+	varSourcePool = creds.NewAuditedVarSourcePool(varSourcePool, secretAuditor, atc.SecretAccess{})
+
+	members, err := cmd.constructMembers(logger, reconfigurableSink, apiConn, backendConn, storage, lockFactory, secretManager, varSourcePool, policyChecker, roleActions, artifactStore)
 	if err != nil {
 		return nil, err
 	}
@@ -481,6 +593,10 @@ func (cmd *RunCommand) constructMembers(
 	storage storage.Storage,
 	lockFactory lock.LockFactory,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
+	policyChecker policy.Checker,
+	roleActions accessor.RoleActionMap,
+	artifactStore blobstore.Store,
 ) ([]grouper.Member, error) {
 	if cmd.TelemetryOptIn {
 		url := fmt.Sprintf("http://telemetry.concourse-ci.org/?version=%s", concourse.Version)
@@ -492,12 +608,15 @@ func (cmd *RunCommand) constructMembers(
 		}()
 	}
 
-	apiMembers, err := cmd.constructAPIMembers(logger, reconfigurableSink, apiConn, storage, lockFactory, secretManager)
+	// shared so that the API reports the rows the rotator had to skip
+	encryptionKeyRotation := cmd.constructEncryptionKeyRotation(logger, backendConn)
+
+	apiMembers, err := cmd.constructAPIMembers(logger, reconfigurableSink, apiConn, storage, lockFactory, secretManager, varSourcePool, policyChecker, roleActions, artifactStore, encryptionKeyRotation)
 	if err != nil {
 		return nil, err
 	}
 
-	backendMembers, err := cmd.constructBackendMembers(logger, backendConn, lockFactory, secretManager)
+	backendMembers, err := cmd.constructBackendMembers(logger, backendConn, lockFactory, secretManager, varSourcePool, policyChecker, artifactStore, encryptionKeyRotation)
 	if err != nil {
 		return nil, err
 	}
@@ -512,9 +631,15 @@ func (cmd *RunCommand) constructAPIMembers(
 	storage storage.Storage,
 	lockFactory lock.LockFactory,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
+	policyChecker policy.Checker,
+	roleActions accessor.RoleActionMap,
+	artifactStore blobstore.Store,
+	encryptionKeyRotation db.EncryptionKeyRotation,
 ) ([]grouper.Member, error) {
 	teamFactory := db.NewTeamFactory(dbConn, lockFactory)
 	userFactory := db.NewUserFactory(dbConn)
+	secretAccessFactory := db.NewSecretAccessFactory(dbConn)
 
 	_, err := teamFactory.CreateDefaultTeamIfNotExists()
 	if err != nil {
@@ -565,6 +690,11 @@ func (cmd *RunCommand) constructAPIMembers(
 		return nil, err
 	}
 
+	workerRuntimes, err := cmd.constructWorkerRuntimes(teamFactory)
+	if err != nil {
+		return nil, err
+	}
+
 	workerProvider := worker.NewDBWorkerProvider(
 		lockFactory,
 		retryhttp.NewExponentialBackOffFactory(5*time.Minute),
@@ -579,6 +709,7 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbWorkerFactory,
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
+		workerRuntimes,
 	)
 
 	pool := worker.NewPool(workerProvider)
@@ -591,8 +722,8 @@ func (cmd *RunCommand) constructAPIMembers(
 	dbContainerRepository := db.NewContainerRepository(dbConn)
 	gcContainerDestroyer := gc.NewDestroyer(logger, dbContainerRepository, dbVolumeRepository)
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod)
-	dbCheckFactory := db.NewCheckFactory(dbConn, lockFactory, secretManager, cmd.GlobalResourceCheckTimeout)
-	accessFactory := accessor.NewAccessFactory(authHandler.PublicKey())
+	dbCheckFactory := db.NewCheckFactory(dbConn, lockFactory, secretManager, varSourcePool, cmd.GlobalResourceCheckTimeout)
+	accessFactory := accessor.NewAccessFactory(authHandler.PublicKey(), roleActions)
 
 	apiHandler, err := cmd.constructAPIHandler(
 		logger,
@@ -609,10 +740,16 @@ func (cmd *RunCommand) constructAPIMembers(
 		dbCheckFactory,
 		dbResourceConfigFactory,
 		userFactory,
+		secretAccessFactory,
+		encryptionKeyRotation,
 		workerClient,
 		secretManager,
+		varSourcePool,
 		credsManagers,
 		accessFactory,
+		policyChecker,
+		roleActions,
+		artifactStore,
 	)
 
 	if err != nil {
@@ -697,6 +834,10 @@ func (cmd *RunCommand) constructBackendMembers(
 	dbConn db.Conn,
 	lockFactory lock.LockFactory,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
+	policyChecker policy.Checker,
+	artifactStore blobstore.Store,
+	encryptionKeyRotation db.EncryptionKeyRotation,
 ) ([]grouper.Member, error) {
 
 	if cmd.Syslog.Address != "" && cmd.Syslog.Transport == "" {
@@ -732,6 +873,11 @@ func (cmd *RunCommand) constructBackendMembers(
 		return nil, err
 	}
 
+	workerRuntimes, err := cmd.constructWorkerRuntimes(teamFactory)
+	if err != nil {
+		return nil, err
+	}
+
 	workerProvider := worker.NewDBWorkerProvider(
 		lockFactory,
 		retryhttp.NewExponentialBackOffFactory(5*time.Minute),
@@ -746,6 +892,7 @@ func (cmd *RunCommand) constructBackendMembers(
 		dbWorkerFactory,
 		workerVersion,
 		cmd.BaggageclaimResponseHeaderTimeout,
+		workerRuntimes,
 	)
 
 	pool := worker.NewPool(workerProvider)
@@ -769,10 +916,14 @@ func (cmd *RunCommand) constructBackendMembers(
 		dbResourceCacheFactory,
 		dbResourceConfigFactory,
 		secretManager,
+		varSourcePool,
 		defaultLimits,
 		buildContainerStrategy,
 		resourceFactory,
 		lockFactory,
+		teamFactory,
+		policyChecker,
+		artifactStore,
 	)
 
 	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
@@ -791,7 +942,7 @@ func (cmd *RunCommand) constructBackendMembers(
 	dbCheckLifecycle := db.NewCheckLifecycle(dbConn)
 	resourceConfigCheckSessionLifecycle := db.NewResourceConfigCheckSessionLifecycle(dbConn)
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod)
-	dbCheckFactory := db.NewCheckFactory(dbConn, lockFactory, secretManager, cmd.GlobalResourceCheckTimeout)
+	dbCheckFactory := db.NewCheckFactory(dbConn, lockFactory, secretManager, varSourcePool, cmd.GlobalResourceCheckTimeout)
 	dbPipelineFactory := db.NewPipelineFactory(dbConn, lockFactory)
 
 	bus := dbConn.Bus()
@@ -803,6 +954,7 @@ func (cmd *RunCommand) constructBackendMembers(
 				dbPipelineFactory,
 				radarSchedulerFactory,
 				secretManager,
+				varSourcePool,
 				bus,
 			),
 			Interval: 10 * time.Second,
@@ -832,6 +984,10 @@ func (cmd *RunCommand) constructBackendMembers(
 					dbCheckLifecycle,
 					cmd.GC.CheckRecyclePeriod,
 				),
+				gc.NewSecretAccessCollector(
+					db.NewSecretAccessFactory(dbConn),
+					cmd.GC.SecretAccessRetention,
+				),
 				gc.NewVolumeCollector(
 					dbVolumeRepository,
 					cmd.GC.MissingGracePeriod,
@@ -867,6 +1023,7 @@ func (cmd *RunCommand) constructBackendMembers(
 					cmd.MaxDaysToRetainBuildLogs,
 				),
 				syslogDrainConfigured,
+				artifactStore,
 			),
 			"build-reaper",
 			lockFactory,
@@ -934,9 +1091,30 @@ func (cmd *RunCommand) constructBackendMembers(
 			)},
 		)
 	}
+	if cmd.rotatingEncryptionKey() {
+		members = append(members, grouper.Member{
+			Name: "encryption-key-rotator", Runner: lockrunner.NewRunner(
+				logger.Session("encryption-key-rotator"),
+				keyrotation.NewRotator(
+					encryptionKeyRotation,
+					cmd.EncryptionKeyRotationBatchSize,
+				),
+				"encryption-key-rotator",
+				lockFactory,
+				clock.NewClock(),
+				cmd.EncryptionKeyRotationInterval,
+			)},
+		)
+	}
 	if cmd.Worker.GardenURL.URL != nil {
 		members = cmd.appendStaticWorker(logger, dbWorkerFactory, members)
 	}
+	if cmd.KubernetesWorker.IsConfigured() {
+		members, err = cmd.appendKubernetesWorker(logger, dbWorkerFactory, dbContainerRepository, members)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return members, nil
 }
 
@@ -944,6 +1122,18 @@ func workerVersion() (version.Version, error) {
 	return version.NewVersionFromString(concourse.WorkerVersion)
 }
 
+// credentialManagerName returns the name of the configured credential
+// manager, as picked by secretManager.
+func (cmd *RunCommand) credentialManagerName() string {
+	for name, manager := range cmd.CredentialManagers {
+		if manager.IsConfigured() {
+			return name
+		}
+	}
+
+	return "noop"
+}
+
 func (cmd *RunCommand) secretManager(logger lager.Logger) (creds.Secrets, error) {
 	var secretsFactory creds.SecretsFactory = noop.NewNoopFactory()
 	for name, manager := range cmd.CredentialManagers {
@@ -999,6 +1189,40 @@ func (cmd *RunCommand) oldKey() *encryption.Key {
 	return oldKey
 }
 
+func (cmd *RunCommand) nextKey() *encryption.Key {
+	var nextKey *encryption.Key
+	if cmd.NextEncryptionKey.AEAD != nil {
+		nextKey = encryption.NewKey(cmd.NextEncryptionKey.AEAD)
+	}
+	return nextKey
+}
+
+// rotatingEncryptionKey is true when data encrypted with an old key is to be
+// re-encrypted with the current one. An old key equal to the current key is
+// not a rotation, as there is nothing to re-encrypt.
+func (cmd *RunCommand) rotatingEncryptionKey() bool {
+	newKey := cmd.newKey()
+	oldKey := cmd.oldKey()
+
+	return newKey != nil && oldKey != nil && oldKey.ID() != newKey.ID()
+}
+
+func (cmd *RunCommand) constructEncryptionKeyRotation(logger lager.Logger, dbConn db.Conn) db.EncryptionKeyRotation {
+	logger = logger.Session("encryption-key-rotation")
+
+	newKey := cmd.newKey()
+	if newKey == nil {
+		return db.NewEncryptionKeyRotation(logger, dbConn, "", nil)
+	}
+
+	var oldKeyIDs []string
+	if cmd.rotatingEncryptionKey() {
+		oldKeyIDs = append(oldKeyIDs, cmd.oldKey().ID())
+	}
+
+	return db.NewEncryptionKeyRotation(logger, dbConn, newKey.ID(), oldKeyIDs)
+}
+
 func webHandler(logger lager.Logger) (http.Handler, error) {
 	webHandler, err := web.NewHandler(logger)
 	if err != nil {
@@ -1178,6 +1402,13 @@ func (cmd *RunCommand) validate() error {
 		)
 	}
 
+	if cmd.KubernetesWorker.IsConfigured() {
+		err := cmd.KubernetesWorker.Validate()
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("invalid kubernetes worker configuration: %s", err))
+		}
+	}
+
 	return errs.ErrorOrNil()
 }
 
@@ -1202,14 +1433,39 @@ func (cmd *RunCommand) configureMetrics(logger lager.Logger) error {
 	return metric.Initialize(logger.Session("metrics"), host, cmd.Metrics.Attributes, cmd.Metrics.BufferSize)
 }
 
+func (cmd *RunCommand) configureRBAC() (accessor.RoleActionMap, error) {
+	if cmd.ConfigRBAC == "" {
+		return accessor.DefaultRoleActionMap(), nil
+	}
+
+	content, err := ioutil.ReadFile(cmd.ConfigRBAC.Path())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read RBAC config: %s", err)
+	}
+
+	var customizations accessor.RoleActionMap
+	err = yaml.Unmarshal(content, &customizations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse RBAC config: %s", err)
+	}
+
+	roleActions, err := accessor.CustomizeRoleActionMap(customizations)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RBAC config: %s", err)
+	}
+
+	return roleActions, nil
+}
+
 func (cmd *RunCommand) constructDBConn(
 	driverName string,
 	logger lager.Logger,
 	maxConn int,
 	connectionName string,
 	lockFactory lock.LockFactory,
+	eventStore db.EventStore,
 ) (db.Conn, error) {
-	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), cmd.newKey(), cmd.oldKey(), connectionName, lockFactory)
+	dbConn, err := db.Open(logger.Session("db"), driverName, cmd.Postgres.ConnectionString(), cmd.newKey(), cmd.oldKey(), cmd.nextKey(), connectionName, lockFactory)
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %s", err)
 	}
@@ -1223,6 +1479,11 @@ func (cmd *RunCommand) constructDBConn(
 		dbConn = db.Log(logger.Session("log-conn"), dbConn)
 	}
 
+	// Store build events outside of Postgres
+	if eventStore != nil {
+		dbConn = db.WithEventStore(dbConn, eventStore)
+	}
+
 	// Prepare
 	dbConn.SetMaxOpenConns(maxConn)
 	dbConn.SetMaxIdleConns(maxConn / 2)
@@ -1299,10 +1560,14 @@ func (cmd *RunCommand) constructEngine(
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 	defaultLimits atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	resourceFactory resource.ResourceFactory,
 	lockFactory lock.LockFactory,
+	teamFactory db.TeamFactory,
+	policyChecker policy.Checker,
+	artifactStore blobstore.Store,
 ) engine.Engine {
 
 	stepFactory := builder.NewStepFactory(
@@ -1315,6 +1580,9 @@ func (cmd *RunCommand) constructEngine(
 		strategy,
 		resourceFactory,
 		lockFactory,
+		teamFactory,
+		policyChecker,
+		artifactStore,
 	)
 
 	stepBuilder := builder.NewStepBuilder(
@@ -1322,12 +1590,30 @@ func (cmd *RunCommand) constructEngine(
 		builder.NewDelegateFactory(),
 		cmd.ExternalURL.String(),
 		secretManager,
+		varSourcePool,
 		cmd.EnableRedactSecrets,
 	)
 
 	return engine.NewEngine(stepBuilder)
 }
 
+func (cmd *RunCommand) constructAuditor(logger lager.Logger, secretAccesses auditor.SecretAccessSink) auditor.Auditor {
+	return auditor.NewAuditor(
+		cmd.Auditor.EnableBuildAuditLog,
+		cmd.Auditor.EnableContainerAuditLog,
+		cmd.Auditor.EnableJobAuditLog,
+		cmd.Auditor.EnablePipelineAuditLog,
+		cmd.Auditor.EnableResourceAuditLog,
+		cmd.Auditor.EnableSystemAuditLog,
+		cmd.Auditor.EnableTeamAuditLog,
+		cmd.Auditor.EnableWorkerAuditLog,
+		cmd.Auditor.EnableVolumeAuditLog,
+		cmd.Auditor.EnableSecretAuditLog,
+		secretAccesses,
+		logger,
+	)
+}
+
 func (cmd *RunCommand) constructHTTPHandler(
 	logger lager.Logger,
 	webHandler http.Handler,
@@ -1374,10 +1660,16 @@ func (cmd *RunCommand) constructAPIHandler(
 	dbCheckFactory db.CheckFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
 	dbUserFactory db.UserFactory,
+	dbSecretAccessFactory db.SecretAccessFactory,
+	dbEncryptionKeyRotation db.EncryptionKeyRotation,
 	workerClient worker.Client,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 	credsManagers creds.Managers,
 	accessFactory accessor.AccessFactory,
+	policyChecker policy.Checker,
+	roleActions accessor.RoleActionMap,
+	artifactStore blobstore.Store,
 ) (http.Handler, error) {
 
 	checkPipelineAccessHandlerFactory := auth.NewCheckPipelineAccessHandlerFactory(teamFactory)
@@ -1385,20 +1677,21 @@ func (cmd *RunCommand) constructAPIHandler(
 	checkBuildWriteAccessHandlerFactory := auth.NewCheckBuildWriteAccessHandlerFactory(dbBuildFactory)
 	checkWorkerTeamAccessHandlerFactory := auth.NewCheckWorkerTeamAccessHandlerFactory(dbWorkerFactory)
 
-	aud := auditor.NewAuditor(
-		cmd.Auditor.EnableBuildAuditLog,
-		cmd.Auditor.EnableContainerAuditLog,
-		cmd.Auditor.EnableJobAuditLog,
-		cmd.Auditor.EnablePipelineAuditLog,
-		cmd.Auditor.EnableResourceAuditLog,
-		cmd.Auditor.EnableSystemAuditLog,
-		cmd.Auditor.EnableTeamAuditLog,
-		cmd.Auditor.EnableWorkerAuditLog,
-		cmd.Auditor.EnableVolumeAuditLog,
-		logger,
-	)
+	aud := cmd.constructAuditor(logger, dbSecretAccessFactory)
 	apiWrapper := wrappa.MultiWrappa{
 		wrappa.NewAPIMetricsWrappa(logger),
+	}
+
+	// policy checks run after authentication and authorization, so that only
+	// permitted requests are sent to the policy agent
+	if policyChecker != nil {
+		apiWrapper = append(apiWrapper, wrappa.NewPolicyCheckWrappa(
+			logger.Session("policy-checker"),
+			policychecker.NewApiPolicyChecker(policyChecker),
+		))
+	}
+
+	apiWrapper = append(apiWrapper,
 		wrappa.NewAPIAuthWrappa(
 			checkPipelineAccessHandlerFactory,
 			checkBuildReadAccessHandlerFactory,
@@ -1408,7 +1701,8 @@ func (cmd *RunCommand) constructAPIHandler(
 		wrappa.NewConcourseVersionWrappa(concourse.Version),
 		wrappa.NewAccessorWrappa(accessFactory, aud),
 		wrappa.NewCompressionWrappa(logger),
-	}
+		wrappa.NewTracingWrappa(),
+	)
 
 	return api.NewHandler(
 		logger,
@@ -1428,6 +1722,8 @@ func (cmd *RunCommand) constructAPIHandler(
 		dbCheckFactory,
 		resourceConfigFactory,
 		dbUserFactory,
+		dbSecretAccessFactory,
+		dbEncryptionKeyRotation,
 
 		buildserver.NewEventHandler,
 
@@ -1441,7 +1737,10 @@ func (cmd *RunCommand) constructAPIHandler(
 		concourse.Version,
 		concourse.WorkerVersion,
 		secretManager,
+		varSourcePool,
 		credsManagers,
+		roleActions,
+		artifactStore,
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
 	)
 }
@@ -1472,13 +1771,25 @@ func (cmd *RunCommand) constructPipelineSyncer(
 	pipelineFactory db.PipelineFactory,
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 	secretManager creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 	bus db.NotificationsBus,
 ) *pipelines.Syncer {
 	return pipelines.NewSyncer(
 		logger,
 		pipelineFactory,
 		func(pipeline db.Pipeline) ifrit.Runner {
-			variables := creds.NewVariables(secretManager, pipeline.TeamName(), pipeline.Name())
+			access := atc.SecretAccess{
+				TeamName:     pipeline.TeamName(),
+				PipelineName: pipeline.Name(),
+			}
+
+			variables := creds.NewPipelineVariables(
+				creds.WithSecretAccess(secretManager, access),
+				creds.WithVarSourceAccess(varSourcePool, access),
+				pipeline.TeamName(),
+				pipeline.Name(),
+				pipeline.VarSources(),
+			)
 			return grouper.NewParallel(os.Interrupt, grouper.Members{
 				{
 					Name: fmt.Sprintf("radar:%d", pipeline.ID()),
@@ -1539,6 +1850,54 @@ func (cmd *RunCommand) appendStaticWorker(
 	)
 }
 
+// constructWorkerRuntimes returns the runtimes for workers which do not run
+// containers via Garden, keyed by the runtime they register with.
+func (cmd *RunCommand) constructWorkerRuntimes(teamFactory db.TeamFactory) (map[string]worker.Runtime, error) {
+	runtimes := map[string]worker.Runtime{}
+
+	if cmd.KubernetesWorker.IsConfigured() {
+		clientset, restConfig, err := cmd.KubernetesWorker.NewClientset()
+		if err != nil {
+			return nil, err
+		}
+
+		runtimes[atc.WorkerRuntimeKubernetes] = k8s.NewRuntime(
+			clientset,
+			k8s.NewExecutor(restConfig, clientset, cmd.KubernetesWorker.Namespace),
+			teamFactory,
+			cmd.KubernetesWorker,
+		)
+	}
+
+	return runtimes, nil
+}
+
+func (cmd *RunCommand) appendKubernetesWorker(
+	logger lager.Logger,
+	workerFactory db.WorkerFactory,
+	containerRepository db.ContainerRepository,
+	members []grouper.Member,
+) ([]grouper.Member, error) {
+	clientset, _, err := cmd.KubernetesWorker.NewClientset()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(members,
+		grouper.Member{
+			Name: "kubernetes-worker",
+			Runner: k8s.NewRegistrar(
+				logger.Session("kubernetes-worker"),
+				clock.NewClock(),
+				workerFactory,
+				containerRepository,
+				clientset,
+				cmd.KubernetesWorker,
+			),
+		},
+	), nil
+}
+
 func (cmd *RunCommand) isTLSEnabled() bool {
 	return cmd.TLSBindPort != 0
 }
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/metric"
 )
 
 //go:generate counterfeiter . Auditor
@@ -19,6 +20,8 @@ func NewAuditor(
 	EnableTeamAuditLog bool,
 	EnableWorkerAuditLog bool,
 	EnableVolumeAuditLog bool,
+	EnableSecretAuditLog bool,
+	secretAccesses SecretAccessSink,
 	logger lager.Logger,
 ) *auditor {
 	return &auditor{
@@ -31,12 +34,25 @@ func NewAuditor(
 		EnableTeamAuditLog:      EnableTeamAuditLog,
 		EnableWorkerAuditLog:    EnableWorkerAuditLog,
 		EnableVolumeAuditLog:    EnableVolumeAuditLog,
+		EnableSecretAuditLog:    EnableSecretAuditLog,
+		secretAccesses:          secretAccesses,
 		logger:                  logger,
 	}
 }
 
 type Auditor interface {
 	Audit(action string, userName string, r *http.Request)
+
+	// AuditSecretAccess records a lookup of a secret in a credential manager.
+	AuditSecretAccess(access atc.SecretAccess)
+}
+
+//go:generate counterfeiter . SecretAccessSink
+
+// SecretAccessSink keeps the audit trail of secret accesses, e.g. in the
+// database, so that it can be looked up later on.
+type SecretAccessSink interface {
+	CreateSecretAccess(atc.SecretAccess) error
 }
 
 type auditor struct {
@@ -49,6 +65,8 @@ type auditor struct {
 	EnableTeamAuditLog      bool
 	EnableWorkerAuditLog    bool
 	EnableVolumeAuditLog    bool
+	EnableSecretAuditLog    bool
+	secretAccesses          SecretAccessSink
 	logger                  lager.Logger
 }
 
@@ -84,6 +102,41 @@ func (a *auditor) Audit(action string, userName string, r *http.Request) {
 	}
 }
 
+// AuditSecretAccess emits a metric for every secret access. If secret
+// auditing is enabled, the access is also logged and kept in the sink.
+func (a *auditor) AuditSecretAccess(access atc.SecretAccess) {
+	metric.SecretAccessed{
+		TeamName: access.TeamName,
+		Manager:  access.Manager,
+		CacheHit: access.CacheHit,
+		Found:    access.Found,
+		Success:  access.Error == "",
+	}.Emit(a.logger)
+
+	if !a.EnableSecretAuditLog {
+		return
+	}
+
+	a.logger.Info("secret-access", lager.Data{
+		"team":      access.TeamName,
+		"pipeline":  access.PipelineName,
+		"job":       access.JobName,
+		"build-id":  access.BuildID,
+		"variable":  access.Variable,
+		"path":      access.Path,
+		"manager":   access.Manager,
+		"source":    access.Source,
+		"cache-hit": access.CacheHit,
+		"found":     access.Found,
+		"error":     access.Error,
+	})
+
+	err := a.secretAccesses.CreateSecretAccess(access)
+	if err != nil {
+		a.logger.Error("failed-to-record-secret-access", err)
+	}
+}
+
 var loggingLevels = map[string]string{
 	atc.SaveConfig:                    "EnableSystemAuditLog",
 	atc.GetConfig:                     "EnableSystemAuditLog",
@@ -98,6 +151,7 @@ var loggingLevels = map[string]string{
 	atc.GetBuildPreparation:           "EnableBuildAuditLog",
 	atc.GetJob:                        "EnableJobAuditLog",
 	atc.CreateJobBuild:                "EnableJobAuditLog",
+	atc.RerunJobBuild:                 "EnableJobAuditLog",
 	atc.ListAllJobs:                   "EnableJobAuditLog",
 	atc.ListJobs:                      "EnableJobAuditLog",
 	atc.ListJobBuilds:                 "EnableJobAuditLog",
@@ -151,6 +205,8 @@ var loggingLevels = map[string]string{
 	atc.DownloadCLI:                   "EnableSystemAuditLog",
 	atc.GetInfo:                       "EnableSystemAuditLog",
 	atc.GetInfoCreds:                  "EnableSystemAuditLog",
+	atc.ListSecretAccesses:            "EnableSystemAuditLog",
+	atc.GetEncryptionStatus:           "EnableSystemAuditLog",
 	atc.ListContainers:                "EnableContainerAuditLog",
 	atc.GetContainer:                  "EnableContainerAuditLog",
 	atc.HijackContainer:               "EnableContainerAuditLog",
@@ -167,4 +223,9 @@ var loggingLevels = map[string]string{
 	atc.CreateArtifact:                "EnableBuildAuditLog",
 	atc.GetArtifact:                   "EnableBuildAuditLog",
 	atc.ListBuildArtifacts:            "EnableBuildAuditLog",
+	atc.ListArchivedArtifacts:         "EnableBuildAuditLog",
+	atc.GetArchivedArtifact:           "EnableBuildAuditLog",
+	atc.ListBuildApprovals:            "EnableBuildAuditLog",
+	atc.ApproveBuild:                  "EnableBuildAuditLog",
+	atc.RejectBuild:                   "EnableBuildAuditLog",
 }
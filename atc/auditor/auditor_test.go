@@ -5,7 +5,9 @@ import (
 
 	"code.cloudfoundry.org/lager/lagertest"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/auditor/auditorfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -27,10 +29,13 @@ var _ = Describe("Audit", func() {
 		EnableTeamAuditLog      bool
 		EnableWorkerAuditLog    bool
 		EnableVolumeAuditLog    bool
+		EnableSecretAuditLog    bool
+		fakeSecretAccessSink    *auditorfakes.FakeSecretAccessSink
 	)
 
 	BeforeEach(func() {
 		userName = "test"
+		fakeSecretAccessSink = new(auditorfakes.FakeSecretAccessSink)
 
 		var err error
 		req, err = http.NewRequest("GET", "localhost:8080", nil)
@@ -50,6 +55,8 @@ var _ = Describe("Audit", func() {
 			EnableTeamAuditLog,
 			EnableWorkerAuditLog,
 			EnableVolumeAuditLog,
+			EnableSecretAuditLog,
+			fakeSecretAccessSink,
 			logger,
 		)
 	})
@@ -64,6 +71,7 @@ var _ = Describe("Audit", func() {
 		EnableTeamAuditLog = false
 		EnableWorkerAuditLog = false
 		EnableVolumeAuditLog = false
+		EnableSecretAuditLog = false
 	})
 
 	Describe("EnableBuildAuditLog", func() {
@@ -95,6 +103,24 @@ var _ = Describe("Audit", func() {
 			})
 		})
 
+		Context("When EnableBuildAudit is true with an approval action", func() {
+			BeforeEach(func() {
+				EnableBuildAuditLog = true
+			})
+
+			It("Creates a log for each approval action", func() {
+				for _, action := range []string{"ListBuildApprovals", "ApproveBuild", "RejectBuild"} {
+					aud.Audit(action, userName, req)
+				}
+
+				logs := logger.Logs()
+				Expect(logs).To(HaveLen(3))
+				Expect(logs[0].Data["action"]).To(Equal("ListBuildApprovals"))
+				Expect(logs[1].Data["action"]).To(Equal("ApproveBuild"))
+				Expect(logs[2].Data["action"]).To(Equal("RejectBuild"))
+			})
+		})
+
 		Context("When EnableBuildAudit is true with Non Build action", func() {
 			BeforeEach(func() {
 				EnableBuildAuditLog = true
@@ -207,6 +233,19 @@ var _ = Describe("Audit", func() {
 			})
 		})
 
+		Context("When EnableJobAuditLog is true with a rerun action", func() {
+			BeforeEach(func() {
+				EnableJobAuditLog = true
+				dummyAction = "RerunJobBuild"
+			})
+
+			It("Create a log including the action", func() {
+				aud.Audit(dummyAction, userName, req)
+				logs := logger.Logs()
+				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
+			})
+		})
+
 		Context("When EnableJobAuditLog is true with Non Job action", func() {
 			BeforeEach(func() {
 				EnableJobAuditLog = true
@@ -569,4 +608,51 @@ var _ = Describe("Audit", func() {
 			})
 		})
 	})
+
+	Describe("EnableSecretAuditLog", func() {
+		var access atc.SecretAccess
+
+		BeforeEach(func() {
+			access = atc.SecretAccess{
+				TeamName: "some-team",
+				BuildID:  42,
+				Variable: "foo",
+				Path:     "/concourse/some-team/foo",
+				Manager:  "vault",
+				Found:    true,
+			}
+		})
+
+		Context("When EnableSecretAuditLog is false", func() {
+			BeforeEach(func() {
+				EnableSecretAuditLog = false
+			})
+
+			It("Doesn't create a log or record the access", func() {
+				aud.AuditSecretAccess(access)
+				Expect(logger.Logs()).To(BeEmpty())
+				Expect(fakeSecretAccessSink.CreateSecretAccessCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("When EnableSecretAuditLog is true", func() {
+			BeforeEach(func() {
+				EnableSecretAuditLog = true
+			})
+
+			It("Creates a log including the variable", func() {
+				aud.AuditSecretAccess(access)
+				logs := logger.Logs()
+				Expect(logs).To(HaveLen(1))
+				Expect(logs[0].Message).To(Equal("access_handler.secret-access"))
+				Expect(logs[0].Data["variable"]).To(Equal("foo"))
+			})
+
+			It("Records the access", func() {
+				aud.AuditSecretAccess(access)
+				Expect(fakeSecretAccessSink.CreateSecretAccessCallCount()).To(Equal(1))
+				Expect(fakeSecretAccessSink.CreateSecretAccessArgsForCall(0)).To(Equal(access))
+			})
+		})
+	})
 })
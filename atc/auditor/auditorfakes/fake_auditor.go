@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"sync"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/auditor"
 )
 
@@ -16,6 +17,11 @@ type FakeAuditor struct {
 		arg2 string
 		arg3 *http.Request
 	}
+	AuditSecretAccessStub        func(atc.SecretAccess)
+	auditSecretAccessMutex       sync.RWMutex
+	auditSecretAccessArgsForCall []struct {
+		arg1 atc.SecretAccess
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -53,11 +59,44 @@ func (fake *FakeAuditor) AuditArgsForCall(i int) (string, string, *http.Request)
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *FakeAuditor) AuditSecretAccess(arg1 atc.SecretAccess) {
+	fake.auditSecretAccessMutex.Lock()
+	fake.auditSecretAccessArgsForCall = append(fake.auditSecretAccessArgsForCall, struct {
+		arg1 atc.SecretAccess
+	}{arg1})
+	fake.recordInvocation("AuditSecretAccess", []interface{}{arg1})
+	fake.auditSecretAccessMutex.Unlock()
+	if fake.AuditSecretAccessStub != nil {
+		fake.AuditSecretAccessStub(arg1)
+	}
+}
+
+func (fake *FakeAuditor) AuditSecretAccessCallCount() int {
+	fake.auditSecretAccessMutex.RLock()
+	defer fake.auditSecretAccessMutex.RUnlock()
+	return len(fake.auditSecretAccessArgsForCall)
+}
+
+func (fake *FakeAuditor) AuditSecretAccessCalls(stub func(atc.SecretAccess)) {
+	fake.auditSecretAccessMutex.Lock()
+	defer fake.auditSecretAccessMutex.Unlock()
+	fake.AuditSecretAccessStub = stub
+}
+
+func (fake *FakeAuditor) AuditSecretAccessArgsForCall(i int) atc.SecretAccess {
+	fake.auditSecretAccessMutex.RLock()
+	defer fake.auditSecretAccessMutex.RUnlock()
+	argsForCall := fake.auditSecretAccessArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeAuditor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.auditMutex.RLock()
 	defer fake.auditMutex.RUnlock()
+	fake.auditSecretAccessMutex.RLock()
+	defer fake.auditSecretAccessMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,120 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/blobstore"
+)
+
+type LocalConfig struct {
+	Dir string `long:"artifact-store-local-dir" description:"Directory in which to store archived artifacts."`
+}
+
+func init() {
+	blobstore.RegisterStore(&LocalConfig{})
+}
+
+func (c *LocalConfig) Description() string { return "Local Filesystem" }
+func (c *LocalConfig) IsConfigured() bool  { return c.Dir != "" }
+
+func (c *LocalConfig) NewStore(logger lager.Logger) (blobstore.Store, error) {
+	err := os.MkdirAll(c.Dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create artifact store dir: %s", err)
+	}
+
+	return NewStore(c.Dir), nil
+}
+
+// Store keeps blobs as files within a directory, e.g. a persistent disk or a
+// network filesystem shared between all web nodes.
+type Store struct {
+	dir string
+}
+
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Put writes the blob to a temporary file which is renamed into place once
+// complete, so that a partially written blob is never visible.
+func (s *Store) Put(ctx context.Context, key string, src io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".put-")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, src)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, blobstore.ErrBlobNotFound
+		}
+
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (s *Store) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) path(key string) (string, error) {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+
+	if !strings.HasPrefix(path, filepath.Clean(s.dir)+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid key: %s", key)
+	}
+
+	return path, nil
+}
@@ -0,0 +1,110 @@
+package local_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc/blobstore"
+	"github.com/concourse/concourse/atc/blobstore/local"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		ctx   context.Context
+		dir   string
+		store *local.Store
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		var err error
+		dir, err = ioutil.TempDir("", "local-store")
+		Expect(err).ToNot(HaveOccurred())
+
+		store = local.NewStore(dir)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("stores blobs under the key's path", func() {
+		err := store.Put(ctx, "builds/1/some-blob", bytes.NewBufferString("some-content"))
+		Expect(err).ToNot(HaveOccurred())
+
+		content, err := ioutil.ReadFile(filepath.Join(dir, "builds", "1", "some-blob"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("some-content"))
+	})
+
+	It("does not leave temporary files behind", func() {
+		err := store.Put(ctx, "some-blob", bytes.NewBufferString("some-content"))
+		Expect(err).ToNot(HaveOccurred())
+
+		entries, err := ioutil.ReadDir(dir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	It("gets stored blobs", func() {
+		err := store.Put(ctx, "some-blob", bytes.NewBufferString("some-content"))
+		Expect(err).ToNot(HaveOccurred())
+
+		reader, err := store.Get(ctx, "some-blob")
+		Expect(err).ToNot(HaveOccurred())
+
+		defer reader.Close()
+
+		content, err := ioutil.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("some-content"))
+	})
+
+	It("replaces existing blobs", func() {
+		err := store.Put(ctx, "some-blob", bytes.NewBufferString("some-content"))
+		Expect(err).ToNot(HaveOccurred())
+
+		err = store.Put(ctx, "some-blob", bytes.NewBufferString("some-other-content"))
+		Expect(err).ToNot(HaveOccurred())
+
+		reader, err := store.Get(ctx, "some-blob")
+		Expect(err).ToNot(HaveOccurred())
+
+		defer reader.Close()
+
+		content, err := ioutil.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("some-other-content"))
+	})
+
+	It("returns ErrBlobNotFound for unknown keys", func() {
+		_, err := store.Get(ctx, "bogus-blob")
+		Expect(err).To(Equal(blobstore.ErrBlobNotFound))
+	})
+
+	It("deletes blobs", func() {
+		err := store.Put(ctx, "some-blob", bytes.NewBufferString("some-content"))
+		Expect(err).ToNot(HaveOccurred())
+
+		err = store.Delete(ctx, "some-blob")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = store.Get(ctx, "some-blob")
+		Expect(err).To(Equal(blobstore.ErrBlobNotFound))
+	})
+
+	It("does not error when deleting unknown keys", func() {
+		Expect(store.Delete(ctx, "bogus-blob")).To(Succeed())
+	})
+
+	It("rejects keys outside of the directory", func() {
+		err := store.Put(ctx, "../some-blob", bytes.NewBufferString("some-content"))
+		Expect(err).To(MatchError("invalid key: ../some-blob"))
+	})
+})
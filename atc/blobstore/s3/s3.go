@@ -0,0 +1,110 @@
+package s3
+
+import (
+	"context"
+	"io"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/concourse/concourse/atc/blobstore"
+)
+
+type S3Config struct {
+	Bucket          string `long:"artifact-store-s3-bucket" description:"Bucket in which to store archived artifacts."`
+	KeyPrefix       string `long:"artifact-store-s3-key-prefix" description:"Prefix to prepend to the keys of archived artifacts."`
+	Region          string `long:"artifact-store-s3-region" default:"us-east-1" description:"AWS region of the bucket."`
+	Endpoint        string `long:"artifact-store-s3-endpoint" description:"URL of an S3-compatible endpoint to use instead of AWS, e.g. a MinIO server."`
+	ForcePathStyle  bool   `long:"artifact-store-s3-force-path-style" description:"Address the bucket as part of the path rather than the host name. Usually required by S3-compatible endpoints."`
+	AccessKeyID     string `long:"artifact-store-s3-access-key" description:"Access key ID. Defaults to the AWS credential chain."`
+	SecretAccessKey string `long:"artifact-store-s3-secret-key" description:"Secret access key."`
+	SessionToken    string `long:"artifact-store-s3-session-token" description:"Session token."`
+}
+
+func init() {
+	blobstore.RegisterStore(&S3Config{})
+}
+
+func (c *S3Config) Description() string { return "S3" }
+func (c *S3Config) IsConfigured() bool  { return c.Bucket != "" }
+
+func (c *S3Config) NewStore(logger lager.Logger) (blobstore.Store, error) {
+	config := &aws.Config{
+		Region:           aws.String(c.Region),
+		S3ForcePathStyle: aws.Bool(c.ForcePathStyle),
+	}
+
+	if c.Endpoint != "" {
+		config.Endpoint = aws.String(c.Endpoint)
+	}
+
+	if c.AccessKeyID != "" {
+		config.Credentials = credentials.NewStaticCredentials(c.AccessKeyID, c.SecretAccessKey, c.SessionToken)
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		logger.Error("failed-to-create-aws-session", err)
+		return nil, err
+	}
+
+	return NewStore(sess, c.Bucket, c.KeyPrefix), nil
+}
+
+// Store keeps blobs as objects in an S3 bucket.
+type Store struct {
+	client   *s3.S3
+	uploader *s3manager.Uploader
+	bucket   string
+	prefix   string
+}
+
+func NewStore(sess *session.Session, bucket string, prefix string) *Store {
+	return &Store{
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+		bucket:   bucket,
+		prefix:   prefix,
+	}
+}
+
+// Put uploads the blob in parts, as the size of the stream is not known up
+// front.
+func (s *Store) Put(ctx context.Context, key string, src io.Reader) error {
+	_, err := s.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+		Body:   src,
+	})
+
+	return err
+}
+
+func (s *Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, blobstore.ErrBlobNotFound
+		}
+
+		return nil, err
+	}
+
+	return output.Body, nil
+}
+
+func (s *Store) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key),
+	})
+
+	return err
+}
@@ -0,0 +1,103 @@
+package s3_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	awss3 "github.com/aws/aws-sdk-go/service/s3"
+	"github.com/concourse/concourse/atc/blobstore"
+	"github.com/concourse/concourse/atc/blobstore/s3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// These tests run against an S3-compatible endpoint, e.g. a local MinIO
+// server:
+//
+//	docker run -p 9000:9000 -e MINIO_ACCESS_KEY=minio -e MINIO_SECRET_KEY=minio123 minio/minio server /data
+//	MINIO_ENDPOINT=http://127.0.0.1:9000 MINIO_ACCESS_KEY=minio MINIO_SECRET_KEY=minio123 ginkgo atc/blobstore/s3
+var _ = Describe("Store", func() {
+	var (
+		ctx    context.Context
+		bucket string
+		store  blobstore.Store
+	)
+
+	BeforeEach(func() {
+		endpoint := os.Getenv("MINIO_ENDPOINT")
+		if endpoint == "" {
+			Skip("MINIO_ENDPOINT not set")
+		}
+
+		ctx = context.Background()
+		bucket = fmt.Sprintf("concourse-test-%d", time.Now().UnixNano())
+
+		config := &s3.S3Config{
+			Bucket:          bucket,
+			KeyPrefix:       "some-prefix/",
+			Region:          "us-east-1",
+			Endpoint:        endpoint,
+			ForcePathStyle:  true,
+			AccessKeyID:     os.Getenv("MINIO_ACCESS_KEY"),
+			SecretAccessKey: os.Getenv("MINIO_SECRET_KEY"),
+		}
+
+		sess, err := session.NewSession(&aws.Config{
+			Region:           aws.String(config.Region),
+			Endpoint:         aws.String(config.Endpoint),
+			S3ForcePathStyle: aws.Bool(true),
+			Credentials:      credentials.NewStaticCredentials(config.AccessKeyID, config.SecretAccessKey, ""),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = awss3.New(sess).CreateBucket(&awss3.CreateBucketInput{
+			Bucket: aws.String(bucket),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		store, err = config.NewStore(lagertest.NewTestLogger("test"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("gets stored blobs", func() {
+		err := store.Put(ctx, "builds/1/some-blob", bytes.NewBufferString("some-content"))
+		Expect(err).ToNot(HaveOccurred())
+
+		reader, err := store.Get(ctx, "builds/1/some-blob")
+		Expect(err).ToNot(HaveOccurred())
+
+		defer reader.Close()
+
+		content, err := ioutil.ReadAll(reader)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("some-content"))
+	})
+
+	It("returns ErrBlobNotFound for unknown keys", func() {
+		_, err := store.Get(ctx, "bogus-blob")
+		Expect(err).To(Equal(blobstore.ErrBlobNotFound))
+	})
+
+	It("deletes blobs", func() {
+		err := store.Put(ctx, "some-blob", bytes.NewBufferString("some-content"))
+		Expect(err).ToNot(HaveOccurred())
+
+		err = store.Delete(ctx, "some-blob")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = store.Get(ctx, "some-blob")
+		Expect(err).To(Equal(blobstore.ErrBlobNotFound))
+	})
+
+	It("does not error when deleting unknown keys", func() {
+		Expect(store.Delete(ctx, "bogus-blob")).To(Succeed())
+	})
+})
@@ -0,0 +1,95 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	flags "github.com/jessevdk/go-flags"
+)
+
+// ErrBlobNotFound is returned by Store.Get when no blob exists for the key.
+var ErrBlobNotFound = errors.New("blob not found")
+
+//go:generate counterfeiter . Store
+
+// Store persists blobs outside of the workers, so that they outlive the
+// volumes they were produced in. Keys are slash-separated paths.
+type Store interface {
+	// Put stores the contents of the reader under the key, replacing any
+	// existing blob.
+	Put(ctx context.Context, key string, src io.Reader) error
+
+	// Get returns the contents of the blob stored under the key. If there is
+	// no such blob, ErrBlobNotFound is returned.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the blob stored under the key. Deleting a blob that does
+	// not exist is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+//go:generate counterfeiter . StoreFactory
+
+type StoreFactory interface {
+	Description() string
+	IsConfigured() bool
+	NewStore(lager.Logger) (Store, error)
+}
+
+var storeFactories []StoreFactory
+
+func RegisterStore(factory StoreFactory) {
+	storeFactories = append(storeFactories, factory)
+}
+
+func WireStores(group *flags.Group) {
+	for _, factory := range storeFactories {
+		_, err := group.AddGroup(fmt.Sprintf("Artifact Store (%s)", factory.Description()), "", factory)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Initialize constructs the configured Store. If no store is configured, a
+// nil Store is returned and artifact archiving is disabled.
+func Initialize(logger lager.Logger) (Store, error) {
+	var storeDescriptions []string
+	for _, factory := range storeFactories {
+		if factory.IsConfigured() {
+			storeDescriptions = append(storeDescriptions, factory.Description())
+		}
+	}
+	if len(storeDescriptions) > 1 {
+		return nil, fmt.Errorf("Multiple artifact stores configured: %s", strings.Join(storeDescriptions, ", "))
+	}
+
+	for _, factory := range storeFactories {
+		if factory.IsConfigured() {
+			store, err := factory.NewStore(logger.Session("artifact-store"))
+			if err != nil {
+				return nil, err
+			}
+
+			logger.Info("artifact-store-configured", lager.Data{"store": factory.Description()})
+
+			return store, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ArtifactKey returns the key under which the named artifact produced by a
+// step of a build is archived. The step's plan is part of the key, as the same
+// step may run many times in a build, e.g. across a matrix of vars.
+// Artifacts are stored as zstd-compressed tarballs, as streamed out of the
+// volume they were produced in.
+func ArtifactKey(buildID int, planID atc.PlanID, name string) string {
+	return fmt.Sprintf("builds/%d/artifacts/%s/%s.tar.zst", buildID, planID, name)
+}
@@ -0,0 +1,89 @@
+package blobstore_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/blobstore"
+	"github.com/concourse/concourse/atc/blobstore/blobstorefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// store factories are registered globally, so only register the fakes once
+var (
+	fakeStoreFactory      = new(blobstorefakes.FakeStoreFactory)
+	otherFakeStoreFactory = new(blobstorefakes.FakeStoreFactory)
+)
+
+func init() {
+	blobstore.RegisterStore(fakeStoreFactory)
+	blobstore.RegisterStore(otherFakeStoreFactory)
+}
+
+var _ = Describe("Initialize", func() {
+	var (
+		fakeStore *blobstorefakes.FakeStore
+		store     blobstore.Store
+		initErr   error
+	)
+
+	BeforeEach(func() {
+		fakeStore = new(blobstorefakes.FakeStore)
+
+		fakeStoreFactory.DescriptionReturns("fake")
+		fakeStoreFactory.IsConfiguredReturns(true)
+		fakeStoreFactory.NewStoreReturns(fakeStore, nil)
+
+		otherFakeStoreFactory.DescriptionReturns("other-fake")
+		otherFakeStoreFactory.IsConfiguredReturns(false)
+	})
+
+	JustBeforeEach(func() {
+		store, initErr = blobstore.Initialize(lagertest.NewTestLogger("test"))
+	})
+
+	It("returns the configured store", func() {
+		Expect(initErr).ToNot(HaveOccurred())
+		Expect(store).To(Equal(fakeStore))
+	})
+
+	Context("when no store is configured", func() {
+		BeforeEach(func() {
+			fakeStoreFactory.IsConfiguredReturns(false)
+		})
+
+		It("returns no store", func() {
+			Expect(initErr).ToNot(HaveOccurred())
+			Expect(store).To(BeNil())
+		})
+	})
+
+	Context("when multiple stores are configured", func() {
+		BeforeEach(func() {
+			otherFakeStoreFactory.IsConfiguredReturns(true)
+		})
+
+		It("errors", func() {
+			Expect(initErr).To(MatchError("Multiple artifact stores configured: fake, other-fake"))
+		})
+	})
+
+	Context("when constructing the store fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeStoreFactory.NewStoreReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(initErr).To(Equal(disaster))
+		})
+	})
+})
+
+var _ = Describe("ArtifactKey", func() {
+	It("namespaces the artifact by build and plan", func() {
+		Expect(blobstore.ArtifactKey(42, "some-plan", "some-output")).To(Equal("builds/42/artifacts/some-plan/some-output.tar.zst"))
+	})
+})
@@ -11,17 +11,26 @@ const (
 	StatusAborted   BuildStatus = "aborted"
 )
 
+// CreateJobBuildQueryPriority overrides the job's priority for a manually
+// triggered build.
+const CreateJobBuildQueryPriority = "priority"
+
 type Build struct {
-	ID           int    `json:"id"`
-	TeamName     string `json:"team_name"`
-	Name         string `json:"name"`
-	Status       string `json:"status"`
-	JobName      string `json:"job_name,omitempty"`
-	APIURL       string `json:"api_url"`
-	PipelineName string `json:"pipeline_name,omitempty"`
-	StartTime    int64  `json:"start_time,omitempty"`
-	EndTime      int64  `json:"end_time,omitempty"`
-	ReapTime     int64  `json:"reap_time,omitempty"`
+	ID                   int          `json:"id"`
+	TeamName             string       `json:"team_name"`
+	Name                 string       `json:"name"`
+	Status               string       `json:"status"`
+	JobName              string       `json:"job_name,omitempty"`
+	APIURL               string       `json:"api_url"`
+	PipelineName         string       `json:"pipeline_name,omitempty"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	StartTime            int64        `json:"start_time,omitempty"`
+	EndTime              int64        `json:"end_time,omitempty"`
+	ReapTime             int64        `json:"reap_time,omitempty"`
+	Priority             int          `json:"priority,omitempty"`
+	TestSummary          *TestSummary `json:"test_summary,omitempty"`
+
+	CreatedBy *BuildCreator `json:"created_by,omitempty"`
 }
 
 func (b Build) IsRunning() bool {
@@ -41,6 +50,52 @@ func (b Build) OneOff() bool {
 	return b.JobName == ""
 }
 
+type BuildCreatorType string
+
+const (
+	BuildCreatorManual   BuildCreatorType = "manual"
+	BuildCreatorResource BuildCreatorType = "resource"
+	BuildCreatorRerun    BuildCreatorType = "rerun"
+	BuildCreatorSchedule BuildCreatorType = "schedule"
+	BuildCreatorAPI      BuildCreatorType = "api"
+)
+
+// BuildCreator records why a build was created.
+type BuildCreator struct {
+	Type BuildCreatorType `json:"type"`
+
+	// User is the user who triggered, reran or created the build.
+	User string `json:"user,omitempty"`
+
+	// Resource is the resource whose new version, fetched by Input, triggered
+	// the build. Version is filled in once the build starts.
+	Resource string  `json:"resource,omitempty"`
+	Input    string  `json:"input,omitempty"`
+	Version  Version `json:"version,omitempty"`
+
+	// BuildID and BuildName identify the build which was rerun.
+	BuildID   int    `json:"build_id,omitempty"`
+	BuildName string `json:"build_name,omitempty"`
+}
+
+func (c BuildCreator) String() string {
+	var description string
+	switch c.Type {
+	case BuildCreatorResource:
+		description = "new version of " + c.Resource
+	case BuildCreatorRerun:
+		description = "rerun of #" + c.BuildName
+	default:
+		description = string(c.Type)
+	}
+
+	if c.User != "" {
+		description += " by " + c.User
+	}
+
+	return description
+}
+
 type BuildPreparationStatus string
 
 const (
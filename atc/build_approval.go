@@ -0,0 +1,38 @@
+package atc
+
+type BuildApprovalStatus string
+
+const (
+	ApprovalStatusPending  BuildApprovalStatus = "pending"
+	ApprovalStatusApproved BuildApprovalStatus = "approved"
+	ApprovalStatusRejected BuildApprovalStatus = "rejected"
+	ApprovalStatusExpired  BuildApprovalStatus = "expired"
+)
+
+// BuildApproval is the decision requested by an approval step of a build.
+type BuildApproval struct {
+	BuildID      int                 `json:"build_id"`
+	PlanID       PlanID              `json:"plan_id"`
+	Name         string              `json:"name"`
+	Status       BuildApprovalStatus `json:"status"`
+	AllowedRoles []string            `json:"allowed_roles,omitempty"`
+
+	RequestTime int64 `json:"request_time"`
+	Deadline    int64 `json:"deadline,omitempty"`
+
+	DecidedBy  string `json:"decided_by,omitempty"`
+	DecideTime int64  `json:"decide_time,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+}
+
+func (approval BuildApproval) IsPending() bool {
+	return approval.Status == ApprovalStatusPending
+}
+
+// ApprovalDecision is the body of a request approving or rejecting a build.
+type ApprovalDecision struct {
+	// name of the approval step to decide; may be omitted if the build is
+	// waiting for only one approval
+	Name    string `json:"name,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
@@ -0,0 +1,26 @@
+package atc
+
+const (
+	BuildLogSearchQuery    = "q"
+	BuildLogSearchPipeline = "pipeline_name"
+	BuildLogSearchJob      = "job_name"
+	BuildLogSearchStatus   = "status"
+	BuildLogSearchFrom     = "from"
+	BuildLogSearchTo       = "to"
+	BuildLogSearchLimit    = "limit"
+
+	BuildLogSearchMaxLimit = PaginationAPIDefaultLimit * 10
+)
+
+type BuildLogSearchResult struct {
+	Build Build          `json:"build"`
+	Lines []BuildLogLine `json:"lines"`
+}
+
+type BuildLogLine struct {
+	EventID uint   `json:"event_id"`
+	Time    int64  `json:"time"`
+	Origin  string `json:"origin"`
+	Source  string `json:"source,omitempty"`
+	Line    string `json:"line"`
+}
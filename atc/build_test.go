@@ -88,4 +88,26 @@ var _ = Describe("Build", func() {
 			}
 		})
 	})
+
+	Describe("BuildCreator", func() {
+		It("describes manual triggers along with the user", func() {
+			creator := atc.BuildCreator{Type: atc.BuildCreatorManual, User: "some-user"}
+			Expect(creator.String()).To(Equal("manual by some-user"))
+		})
+
+		It("describes the resource which triggered the build", func() {
+			creator := atc.BuildCreator{Type: atc.BuildCreatorResource, Resource: "some-resource", Input: "some-input"}
+			Expect(creator.String()).To(Equal("new version of some-resource"))
+		})
+
+		It("describes the build which was rerun", func() {
+			creator := atc.BuildCreator{Type: atc.BuildCreatorRerun, BuildID: 42, BuildName: "7", User: "some-user"}
+			Expect(creator.String()).To(Equal("rerun of #7 by some-user"))
+		})
+
+		It("describes schedules", func() {
+			creator := atc.BuildCreator{Type: atc.BuildCreatorSchedule}
+			Expect(creator.String()).To(Equal("schedule"))
+		})
+	})
 })
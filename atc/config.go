@@ -17,10 +17,11 @@ const DefaultTeamName = "main"
 type Tags []string
 
 type Config struct {
-	Groups        GroupConfigs    `json:"groups,omitempty"`
-	Resources     ResourceConfigs `json:"resources,omitempty"`
-	ResourceTypes ResourceTypes   `json:"resource_types,omitempty"`
-	Jobs          JobConfigs      `json:"jobs,omitempty"`
+	Groups        GroupConfigs     `json:"groups,omitempty"`
+	Resources     ResourceConfigs  `json:"resources,omitempty"`
+	ResourceTypes ResourceTypes    `json:"resource_types,omitempty"`
+	Jobs          JobConfigs       `json:"jobs,omitempty"`
+	VarSources    VarSourceConfigs `json:"var_sources,omitempty"`
 }
 
 type GroupConfig struct {
@@ -41,6 +42,28 @@ func (groups GroupConfigs) Lookup(name string) (GroupConfig, int, bool) {
 	return GroupConfig{}, -1, false
 }
 
+// VarSourceConfig declares a named credential source of a pipeline, whose
+// vars are referenced as ((name:path)). Type is the name of a registered
+// credential manager, e.g. vault, and Config is the configuration of that
+// manager, using the same keys as its JSON representation.
+type VarSourceConfig struct {
+	Name   string      `json:"name"`
+	Type   string      `json:"type"`
+	Config interface{} `json:"config"`
+}
+
+type VarSourceConfigs []VarSourceConfig
+
+func (sources VarSourceConfigs) Lookup(name string) (VarSourceConfig, bool) {
+	for _, source := range sources {
+		if source.Name == name {
+			return source, true
+		}
+	}
+
+	return VarSourceConfig{}, false
+}
+
 type ResourceConfig struct {
 	Name         string  `json:"name"`
 	Public       bool    `json:"public,omitempty"`
@@ -248,6 +271,15 @@ func (c *InParallelConfig) UnmarshalJSON(payload []byte) error {
 	return nil
 }
 
+// An AcrossVarConfig configures one var of an 'across' step. The step is run
+// once for each combination of values across all vars, with the var set to
+// the current value as a local var, i.e. ((.:var)).
+type AcrossVarConfig struct {
+	Var         string        `json:"var"`
+	Values      []interface{} `json:"values,omitempty"`
+	MaxInFlight int           `json:"max_in_flight,omitempty"`
+}
+
 // A PlanConfig is a flattened set of configuration corresponding to
 // a particular Plan, where Source and Version are populated lazily.
 type PlanConfig struct {
@@ -267,6 +299,11 @@ type PlanConfig struct {
 	// a nested chain of steps to run in parallel
 	InParallel *InParallelConfig `json:"in_parallel,omitempty"`
 
+	// run the step once for each combination of the given vars' values
+	Across []AcrossVarConfig `json:"across,omitempty"`
+	// abort the remaining combinations of an 'across' step once one fails
+	FailFast bool `json:"fail_fast,omitempty"`
+
 	// corresponds to Get and Put resource plans, respectively
 	// name of 'input', e.g. bosh-stemcell
 	Get string `json:"get,omitempty"`
@@ -296,6 +333,28 @@ type PlanConfig struct {
 	// inlined task config
 	TaskConfig *TaskConfig `json:"config,omitempty"`
 
+	// name of 'set_pipeline', e.g. ci; uses TaskConfigPath as the pipeline
+	// config file and TaskVars as its static vars
+	SetPipeline string `json:"set_pipeline,omitempty"`
+	// var files to interpolate into the pipeline config, e.g. ci/vars.yml
+	VarFiles []string `json:"var_files,omitempty"`
+	// vars identifying the instance of the pipeline to set, e.g. branch: master
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+
+	// name of 'load_var', e.g. version; uses TaskConfigPath as the file to load
+	LoadVar string `json:"load_var,omitempty"`
+	// format of the file to load, e.g. json; inferred from the file extension
+	// if not specified
+	Format string `json:"format,omitempty"`
+	// do not redact the loaded value from build logs
+	Reveal bool `json:"reveal,omitempty"`
+
+	// name of 'approval', e.g. deploy-to-prod; uses Timeout as the time to
+	// wait for a decision
+	Approval string `json:"approval,omitempty"`
+	// roles of the team allowed to decide on the approval, e.g. owner
+	AllowedRoles []string `json:"allowed_roles,omitempty"`
+
 	// used by Get and Put for specifying params to the resource
 	// used by Task for passing params to external task config
 	Params Params `json:"params,omitempty"`
@@ -360,6 +419,18 @@ func (config PlanConfig) Name() string {
 		return config.Task
 	}
 
+	if config.SetPipeline != "" {
+		return config.SetPipeline
+	}
+
+	if config.LoadVar != "" {
+		return config.LoadVar
+	}
+
+	if config.Approval != "" {
+		return config.Approval
+	}
+
 	return ""
 }
 
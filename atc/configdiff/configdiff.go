@@ -1,4 +1,5 @@
-package setpipelinehelpers
+// Package configdiff renders the differences between two pipeline configs.
+package configdiff
 
 import (
 	"bytes"
@@ -10,7 +11,6 @@ import (
 	"github.com/aryann/difflib"
 	"github.com/concourse/concourse/atc"
 	"github.com/mgutz/ansi"
-	"github.com/onsi/gomega/gexec"
 	"sigs.k8s.io/yaml"
 )
 
@@ -26,27 +26,27 @@ type Diff struct {
 	After  interface{}
 }
 
-func name(v interface{}) string {
+func objectName(v interface{}) string {
 	return reflect.ValueOf(v).FieldByName("Name").String()
 }
 
 func (diff Diff) Render(to io.Writer, label string) {
 
 	if diff.Before != nil && diff.After != nil {
-		fmt.Fprintf(to, ansi.Color("%s %s has changed:", "yellow")+"\n", label, name(diff.Before))
+		fmt.Fprintf(to, ansi.Color("%s %s has changed:", "yellow")+"\n", label, objectName(diff.Before))
 
 		payloadA, _ := yaml.Marshal(diff.Before)
 		payloadB, _ := yaml.Marshal(diff.After)
 
 		renderDiff(to, string(payloadA), string(payloadB))
 	} else if diff.Before != nil {
-		fmt.Fprintf(to, ansi.Color("%s %s has been removed:", "yellow")+"\n", label, name(diff.Before))
+		fmt.Fprintf(to, ansi.Color("%s %s has been removed:", "yellow")+"\n", label, objectName(diff.Before))
 
 		payloadA, _ := yaml.Marshal(diff.Before)
 
 		renderDiff(to, string(payloadA), "")
 	} else {
-		fmt.Fprintf(to, ansi.Color("%s %s has been added:", "yellow")+"\n", label, name(diff.After))
+		fmt.Fprintf(to, ansi.Color("%s %s has been added:", "yellow")+"\n", label, objectName(diff.After))
 
 		payloadB, _ := yaml.Marshal(diff.After)
 
@@ -66,7 +66,7 @@ func (index GroupIndex) Slice() []interface{} {
 }
 
 func (index GroupIndex) FindEquivalentWithOrder(obj interface{}) (interface{}, int, bool) {
-	return atc.GroupConfigs(index).Lookup(name(obj))
+	return atc.GroupConfigs(index).Lookup(objectName(obj))
 }
 
 type JobIndex atc.JobConfigs
@@ -81,7 +81,7 @@ func (index JobIndex) Slice() []interface{} {
 }
 
 func (index JobIndex) FindEquivalent(obj interface{}) (interface{}, bool) {
-	return atc.JobConfigs(index).Lookup(name(obj))
+	return atc.JobConfigs(index).Lookup(objectName(obj))
 }
 
 type ResourceIndex atc.ResourceConfigs
@@ -96,7 +96,7 @@ func (index ResourceIndex) Slice() []interface{} {
 }
 
 func (index ResourceIndex) FindEquivalent(obj interface{}) (interface{}, bool) {
-	return atc.ResourceConfigs(index).Lookup(name(obj))
+	return atc.ResourceConfigs(index).Lookup(objectName(obj))
 }
 
 type ResourceTypeIndex atc.ResourceTypes
@@ -111,7 +111,7 @@ func (index ResourceTypeIndex) Slice() []interface{} {
 }
 
 func (index ResourceTypeIndex) FindEquivalent(obj interface{}) (interface{}, bool) {
-	return atc.ResourceTypes(index).Lookup(name(obj))
+	return atc.ResourceTypes(index).Lookup(objectName(obj))
 }
 
 func groupDiffIndices(oldIndex GroupIndex, newIndex GroupIndex) Diffs {
@@ -193,7 +193,7 @@ func diffIndices(oldIndex Index, newIndex Index) Diffs {
 
 func renderDiff(to io.Writer, a, b string) {
 	diffs := difflib.Diff(strings.Split(a, "\n"), strings.Split(b, "\n"))
-	indent := gexec.NewPrefixedWriter("\b\b", to)
+	indent := newPrefixedWriter("\b\b", to)
 
 	for _, diff := range diffs {
 		text := diff.Payload
@@ -222,3 +222,90 @@ func practicallyDifferent(a, b interface{}) bool {
 
 	return !bytes.Equal(marshalledA, marshalledB)
 }
+
+// Render renders the differences between oldConfig and newConfig to out,
+// grouped by groups, resources, resource types and jobs. It returns whether
+// any differences were found.
+func Render(out io.Writer, oldConfig atc.Config, newConfig atc.Config) bool {
+	var diffExists bool
+
+	indent := newPrefixedWriter("  ", out)
+
+	groupDiffs := groupDiffIndices(GroupIndex(oldConfig.Groups), GroupIndex(newConfig.Groups))
+	if len(groupDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "groups:")
+
+		for _, diff := range groupDiffs {
+			diff.Render(indent, "group")
+		}
+	}
+
+	resourceDiffs := diffIndices(ResourceIndex(oldConfig.Resources), ResourceIndex(newConfig.Resources))
+	if len(resourceDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "resources:")
+
+		for _, diff := range resourceDiffs {
+			diff.Render(indent, "resource")
+		}
+	}
+
+	resourceTypeDiffs := diffIndices(ResourceTypeIndex(oldConfig.ResourceTypes), ResourceTypeIndex(newConfig.ResourceTypes))
+	if len(resourceTypeDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "resource types:")
+
+		for _, diff := range resourceTypeDiffs {
+			diff.Render(indent, "resource type")
+		}
+	}
+
+	jobDiffs := diffIndices(JobIndex(oldConfig.Jobs), JobIndex(newConfig.Jobs))
+	if len(jobDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "jobs:")
+
+		for _, diff := range jobDiffs {
+			diff.Render(indent, "job")
+		}
+	}
+
+	return diffExists
+}
+
+// prefixedWriter writes its prefix at the start of every line written to it.
+type prefixedWriter struct {
+	prefix    []byte
+	writer    io.Writer
+	atNewline bool
+}
+
+func newPrefixedWriter(prefix string, writer io.Writer) *prefixedWriter {
+	return &prefixedWriter{
+		prefix:    []byte(prefix),
+		writer:    writer,
+		atNewline: true,
+	}
+}
+
+func (w *prefixedWriter) Write(b []byte) (int, error) {
+	toWrite := []byte{}
+
+	for _, c := range b {
+		if w.atNewline {
+			toWrite = append(toWrite, w.prefix...)
+		}
+
+		toWrite = append(toWrite, c)
+
+		w.atNewline = c == '\n'
+	}
+
+	_, err := w.writer.Write(toWrite)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
@@ -0,0 +1,13 @@
+package configdiff_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestConfigDiff(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Config Diff Suite")
+}
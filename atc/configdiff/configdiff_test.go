@@ -0,0 +1,50 @@
+package configdiff_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configdiff"
+	"github.com/onsi/gomega/gbytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Render", func() {
+	var (
+		oldConfig atc.Config
+		newConfig atc.Config
+		out       *gbytes.Buffer
+	)
+
+	BeforeEach(func() {
+		oldConfig = atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job", Public: true},
+				{Name: "removed-job"},
+			},
+		}
+
+		newConfig = atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+				{Name: "added-job"},
+			},
+		}
+
+		out = gbytes.NewBuffer()
+	})
+
+	It("renders the changed, removed and added objects", func() {
+		Expect(configdiff.Render(out, oldConfig, newConfig)).To(BeTrue())
+
+		Expect(out).To(gbytes.Say("jobs:\n"))
+		Expect(out).To(gbytes.Say("job some-job has changed:"))
+		Expect(out).To(gbytes.Say("job removed-job has been removed:"))
+		Expect(out).To(gbytes.Say("job added-job has been added:"))
+	})
+
+	It("returns false when nothing has changed", func() {
+		Expect(configdiff.Render(out, oldConfig, oldConfig)).To(BeFalse())
+		Expect(out.Contents()).To(BeEmpty())
+	})
+})
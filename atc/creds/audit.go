@@ -0,0 +1,203 @@
+package creds
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+)
+
+//go:generate counterfeiter . SecretAccessAuditor
+
+// SecretAccessAuditor records the lookups of secrets, e.g. to an audit log.
+type SecretAccessAuditor interface {
+	AuditSecretAccess(atc.SecretAccess)
+}
+
+type cachingSecrets interface {
+	getCached(string) (interface{}, *time.Time, bool, bool, error)
+}
+
+type auditedSecrets struct {
+	secrets Secrets
+	auditor SecretAccessAuditor
+	access  atc.SecretAccess
+
+	variablesLock sync.Mutex
+	variables     map[string]string
+}
+
+// NewAuditedSecrets returns Secrets which record each lookup of a secret
+// through them, successful or not, with the auditor. The given access
+// describes what the secrets are looked up for, e.g. the team, build and
+// credential manager; the rest of each record is filled in per lookup.
+func NewAuditedSecrets(secrets Secrets, auditor SecretAccessAuditor, access atc.SecretAccess) Secrets {
+	return &auditedSecrets{
+		secrets: secrets,
+		auditor: auditor,
+		access:  access,
+
+		variables: map[string]string{},
+	}
+}
+
+// WithSecretAccess returns the secrets with each lookup through them audited
+// as the given access, e.g. for a particular build, keeping the credential
+// manager and var source they were audited with. Secrets which are not
+// audited are returned as-is.
+func WithSecretAccess(secrets Secrets, access atc.SecretAccess) Secrets {
+	as, ok := secrets.(*auditedSecrets)
+	if !ok {
+		return secrets
+	}
+
+	access.Manager = as.access.Manager
+	access.Source = as.access.Source
+
+	return NewAuditedSecrets(as.secrets, as.auditor, access)
+}
+
+func (as *auditedSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	var (
+		value      interface{}
+		expiration *time.Time
+		found      bool
+		cacheHit   bool
+		err        error
+	)
+
+	if cached, ok := as.secrets.(cachingSecrets); ok {
+		value, expiration, found, cacheHit, err = cached.getCached(secretPath)
+	} else {
+		value, expiration, found, err = as.secrets.Get(secretPath)
+	}
+
+	access := as.access
+	access.Time = time.Now().Unix()
+	access.Variable = as.variable(secretPath)
+	access.Path = secretPath
+	access.CacheHit = cacheHit
+	access.Found = found
+	if err != nil {
+		access.Error = err.Error()
+	}
+
+	as.auditor.AuditSecretAccess(access)
+
+	return value, expiration, found, err
+}
+
+// NewSecretLookupPaths returns the lookup paths of the underlying secrets,
+// noting which variable each secret path is looked up for.
+func (as *auditedSecrets) NewSecretLookupPaths(teamName string, pipelineName string) []SecretLookupPath {
+	lookupPaths := as.secrets.NewSecretLookupPaths(teamName, pipelineName)
+
+	audited := make([]SecretLookupPath, len(lookupPaths))
+	for i, lookupPath := range lookupPaths {
+		audited[i] = auditedLookupPath{
+			SecretLookupPath: lookupPath,
+			secrets:          as,
+		}
+	}
+
+	return audited
+}
+
+// NewLeases returns Leases whose lookups are audited like these secrets.
+func (as *auditedSecrets) NewLeases() Leases {
+	leases := NewLeases(as.secrets)
+
+	return auditedLeases{
+		auditedSecrets: &auditedSecrets{
+			secrets: leases,
+			auditor: as.auditor,
+			access:  as.access,
+
+			variables: map[string]string{},
+		},
+		leases: leases,
+	}
+}
+
+type auditedLeases struct {
+	*auditedSecrets
+	leases Leases
+}
+
+func (al auditedLeases) RevokeAll() {
+	al.leases.RevokeAll()
+}
+
+// variable returns the name of the variable the secret path was looked up
+// for. Without lookup paths, e.g. for the noop credential manager, variables
+// are looked up by their name.
+func (as *auditedSecrets) variable(secretPath string) string {
+	as.variablesLock.Lock()
+	defer as.variablesLock.Unlock()
+
+	variable, found := as.variables[secretPath]
+	if !found {
+		return secretPath
+	}
+
+	return variable
+}
+
+type auditedLookupPath struct {
+	SecretLookupPath
+	secrets *auditedSecrets
+}
+
+func (lp auditedLookupPath) VariableToSecretPath(varName string) (string, error) {
+	secretPath, err := lp.SecretLookupPath.VariableToSecretPath(varName)
+	if err != nil {
+		return "", err
+	}
+
+	lp.secrets.variablesLock.Lock()
+	lp.secrets.variables[secretPath] = varName
+	lp.secrets.variablesLock.Unlock()
+
+	return secretPath, nil
+}
+
+type auditedVarSourcePool struct {
+	pool    VarSourcePool
+	auditor SecretAccessAuditor
+	access  atc.SecretAccess
+}
+
+// NewAuditedVarSourcePool is like NewAuditedSecrets, but for the secrets of
+// the var sources in the pool. Each lookup is recorded with the var source's
+// type as the manager and its name as the source.
+func NewAuditedVarSourcePool(pool VarSourcePool, auditor SecretAccessAuditor, access atc.SecretAccess) VarSourcePool {
+	return auditedVarSourcePool{
+		pool:    pool,
+		auditor: auditor,
+		access:  access,
+	}
+}
+
+// WithVarSourceAccess is like WithSecretAccess, but for the secrets of the
+// var sources in the pool.
+func WithVarSourceAccess(pool VarSourcePool, access atc.SecretAccess) VarSourcePool {
+	p, ok := pool.(auditedVarSourcePool)
+	if !ok {
+		return pool
+	}
+
+	return NewAuditedVarSourcePool(p.pool, p.auditor, access)
+}
+
+func (p auditedVarSourcePool) FindOrCreate(varSource atc.VarSourceConfig) (Secrets, error) {
+	secrets, err := p.pool.FindOrCreate(varSource)
+	if err != nil {
+		return nil, err
+	}
+
+	access := p.access
+	access.Manager = varSource.Type
+	access.Source = varSource.Name
+
+	return NewAuditedSecrets(secrets, p.auditor, access), nil
+}
@@ -0,0 +1,259 @@
+package creds_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Secret access auditing", func() {
+	var (
+		fakeSecrets  *credsfakes.FakeSecrets
+		fakeAuditor  *credsfakes.FakeSecretAccessAuditor
+		access       atc.SecretAccess
+		secrets      creds.Secrets
+		variables    vars.Variables
+		lookupPrefix string
+	)
+
+	BeforeEach(func() {
+		fakeSecrets = new(credsfakes.FakeSecrets)
+		fakeAuditor = new(credsfakes.FakeSecretAccessAuditor)
+
+		lookupPrefix = "/concourse/some-team/"
+		fakeSecrets.NewSecretLookupPathsStub = func(string, string) []creds.SecretLookupPath {
+			return []creds.SecretLookupPath{creds.NewSecretLookupWithPrefix(lookupPrefix)}
+		}
+
+		fakeSecrets.GetStub = func(path string) (interface{}, *time.Time, bool, error) {
+			switch path {
+			case "/concourse/some-team/foo":
+				return "some-secret", nil, true, nil
+			case "/concourse/some-team/broken":
+				return nil, nil, false, errors.New("nope")
+			}
+
+			return nil, nil, false, nil
+		}
+
+		access = atc.SecretAccess{
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+			BuildID:      42,
+			Manager:      "vault",
+		}
+	})
+
+	JustBeforeEach(func() {
+		secrets = creds.NewAuditedSecrets(fakeSecrets, fakeAuditor, access)
+		variables = creds.NewVariables(secrets, "some-team", "some-pipeline")
+	})
+
+	It("records successful lookups with the variable name, never the value", func() {
+		val, found, err := variables.Get(vars.VariableDefinition{Name: "foo"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(val).To(Equal("some-secret"))
+
+		Expect(fakeAuditor.AuditSecretAccessCallCount()).To(Equal(1))
+
+		recorded := fakeAuditor.AuditSecretAccessArgsForCall(0)
+		Expect(recorded.Time).ToNot(BeZero())
+
+		recorded.Time = 0
+		Expect(recorded).To(Equal(atc.SecretAccess{
+			TeamName:     "some-team",
+			PipelineName: "some-pipeline",
+			JobName:      "some-job",
+			BuildID:      42,
+			Variable:     "foo",
+			Path:         "/concourse/some-team/foo",
+			Manager:      "vault",
+			Found:        true,
+		}))
+	})
+
+	It("records lookups of secrets which are not found", func() {
+		_, found, err := variables.Get(vars.VariableDefinition{Name: "missing"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+
+		recorded := fakeAuditor.AuditSecretAccessArgsForCall(0)
+		Expect(recorded.Variable).To(Equal("missing"))
+		Expect(recorded.Found).To(BeFalse())
+		Expect(recorded.Error).To(BeEmpty())
+	})
+
+	It("records failed lookups with their error", func() {
+		_, _, err := variables.Get(vars.VariableDefinition{Name: "broken"})
+		Expect(err).To(MatchError("nope"))
+
+		recorded := fakeAuditor.AuditSecretAccessArgsForCall(0)
+		Expect(recorded.Variable).To(Equal("broken"))
+		Expect(recorded.Error).To(Equal("nope"))
+	})
+
+	Context("when there are no lookup paths", func() {
+		BeforeEach(func() {
+			fakeSecrets.NewSecretLookupPathsReturns(nil)
+			fakeSecrets.NewSecretLookupPathsStub = nil
+			fakeSecrets.GetReturns("some-secret", nil, true, nil)
+			fakeSecrets.GetStub = nil
+		})
+
+		It("records the variable name as the path", func() {
+			_, _, err := variables.Get(vars.VariableDefinition{Name: "foo"})
+			Expect(err).ToNot(HaveOccurred())
+
+			recorded := fakeAuditor.AuditSecretAccessArgsForCall(0)
+			Expect(recorded.Variable).To(Equal("foo"))
+			Expect(recorded.Path).To(Equal("foo"))
+		})
+	})
+
+	Context("when the secrets are cached", func() {
+		JustBeforeEach(func() {
+			cached := creds.NewCachedSecrets(fakeSecrets, creds.SecretCacheConfig{
+				Duration:         time.Minute,
+				DurationNotFound: time.Minute,
+				PurgeInterval:    time.Minute,
+			})
+
+			secrets = creds.NewAuditedSecrets(cached, fakeAuditor, access)
+			variables = creds.NewVariables(secrets, "some-team", "some-pipeline")
+		})
+
+		It("records whether each lookup hit the cache", func() {
+			_, _, err := variables.Get(vars.VariableDefinition{Name: "foo"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = variables.Get(vars.VariableDefinition{Name: "foo"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeAuditor.AuditSecretAccessCallCount()).To(Equal(2))
+			Expect(fakeAuditor.AuditSecretAccessArgsForCall(0).CacheHit).To(BeFalse())
+			Expect(fakeAuditor.AuditSecretAccessArgsForCall(1).CacheHit).To(BeTrue())
+		})
+	})
+
+	Describe("WithSecretAccess", func() {
+		It("records lookups as the given access, keeping the manager", func() {
+			scoped := creds.WithSecretAccess(secrets, atc.SecretAccess{
+				TeamName:     "other-team",
+				PipelineName: "other-pipeline",
+				BuildID:      43,
+			})
+
+			_, _, err := creds.NewVariables(scoped, "some-team", "some-pipeline").
+				Get(vars.VariableDefinition{Name: "foo"})
+			Expect(err).ToNot(HaveOccurred())
+
+			recorded := fakeAuditor.AuditSecretAccessArgsForCall(0)
+			Expect(recorded.TeamName).To(Equal("other-team"))
+			Expect(recorded.PipelineName).To(Equal("other-pipeline"))
+			Expect(recorded.JobName).To(BeEmpty())
+			Expect(recorded.BuildID).To(Equal(43))
+			Expect(recorded.Manager).To(Equal("vault"))
+			Expect(recorded.Variable).To(Equal("foo"))
+		})
+
+		It("returns secrets which are not audited as-is", func() {
+			Expect(creds.WithSecretAccess(fakeSecrets, access)).To(BeIdenticalTo(fakeSecrets))
+		})
+	})
+
+	Context("when the secrets hand out leases", func() {
+		var (
+			fakeLeasingSecrets *credsfakes.FakeLeasingSecrets
+			fakeLeases         *credsfakes.FakeLeases
+		)
+
+		BeforeEach(func() {
+			fakeLeasingSecrets = new(credsfakes.FakeLeasingSecrets)
+			fakeLeases = new(credsfakes.FakeLeases)
+			fakeLeases.GetReturns("some-leased-secret", nil, true, nil)
+			fakeLeasingSecrets.NewLeasesReturns(fakeLeases)
+		})
+
+		It("records lookups in the leases", func() {
+			leases := creds.NewLeases(creds.NewAuditedSecrets(fakeLeasingSecrets, fakeAuditor, access))
+
+			_, _, err := creds.NewVariables(leases, "some-team", "some-pipeline").
+				Get(vars.VariableDefinition{Name: "foo"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeLeasingSecrets.GetCallCount()).To(BeZero())
+			Expect(fakeAuditor.AuditSecretAccessCallCount()).To(Equal(1))
+			Expect(fakeAuditor.AuditSecretAccessArgsForCall(0).BuildID).To(Equal(42))
+
+			leases.RevokeAll()
+			Expect(fakeLeases.RevokeAllCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("NewAuditedVarSourcePool", func() {
+		var fakePool *credsfakes.FakeVarSourcePool
+
+		BeforeEach(func() {
+			fakePool = new(credsfakes.FakeVarSourcePool)
+			fakePool.FindOrCreateReturns(fakeSecrets, nil)
+		})
+
+		It("records lookups in var sources with their type and name", func() {
+			pool := creds.NewAuditedVarSourcePool(fakePool, fakeAuditor, access)
+
+			varSourceSecrets, err := pool.FindOrCreate(atc.VarSourceConfig{
+				Name: "some-source",
+				Type: "some-type",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, err = creds.NewVariables(varSourceSecrets, "some-team", "some-pipeline").
+				Get(vars.VariableDefinition{Name: "foo"})
+			Expect(err).ToNot(HaveOccurred())
+
+			recorded := fakeAuditor.AuditSecretAccessArgsForCall(0)
+			Expect(recorded.Manager).To(Equal("some-type"))
+			Expect(recorded.Source).To(Equal("some-source"))
+			Expect(recorded.BuildID).To(Equal(42))
+			Expect(recorded.Variable).To(Equal("foo"))
+		})
+
+		Describe("WithVarSourceAccess", func() {
+			It("records lookups in var sources as the given access", func() {
+				pool := creds.WithVarSourceAccess(
+					creds.NewAuditedVarSourcePool(fakePool, fakeAuditor, atc.SecretAccess{}),
+					access,
+				)
+
+				varSourceSecrets, err := pool.FindOrCreate(atc.VarSourceConfig{
+					Name: "some-source",
+					Type: "some-type",
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = creds.NewVariables(varSourceSecrets, "some-team", "some-pipeline").
+					Get(vars.VariableDefinition{Name: "foo"})
+				Expect(err).ToNot(HaveOccurred())
+
+				recorded := fakeAuditor.AuditSecretAccessArgsForCall(0)
+				Expect(recorded.TeamName).To(Equal("some-team"))
+				Expect(recorded.BuildID).To(Equal(42))
+				Expect(recorded.Manager).To(Equal("some-type"))
+				Expect(recorded.Source).To(Equal("some-source"))
+			})
+
+			It("returns pools which are not audited as-is", func() {
+				Expect(creds.WithVarSourceAccess(fakePool, access)).To(BeIdenticalTo(fakePool))
+			})
+		})
+	})
+})
@@ -37,11 +37,18 @@ func NewCachedSecrets(secrets Secrets, cacheConfig SecretCacheConfig) *CachedSec
 }
 
 func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	value, expiration, found, _, err := cs.getCached(secretPath)
+	return value, expiration, found, err
+}
+
+// getCached is like Get, but also returns whether the secret was found in
+// the cache.
+func (cs *CachedSecrets) getCached(secretPath string) (interface{}, *time.Time, bool, bool, error) {
 	// if there is a corresponding entry in the cache, return it
 	entry, found := cs.cache.Get(secretPath)
 	if found {
 		result := entry.(CacheEntry)
-		return result.value, result.expiration, result.found, nil
+		return result.value, result.expiration, result.found, true, nil
 	}
 
 	// otherwise, let's make a request to the underlying secret manager
@@ -49,7 +56,7 @@ func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool,
 
 	// we don't want to cache errors, let the errors be retried the next time around
 	if err != nil {
-		return nil, nil, false, err
+		return nil, nil, false, false, err
 	}
 
 	// here we want to cache secret value, expiration, and found flag too
@@ -62,18 +69,49 @@ func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool,
 		if expiration != nil {
 			// if secret lease time expires sooner, make duration smaller than default duration
 			itemDuration := expiration.Sub(time.Now())
+			if itemDuration <= 0 {
+				// secrets which have already expired, e.g. leased secrets, must
+				// not be cached: go-cache would keep them forever
+				return value, expiration, found, false, nil
+			}
+
 			if itemDuration < duration {
 				duration = itemDuration
 			}
 		}
+
 		cs.cache.Set(secretPath, entry, duration)
 	} else {
 		cs.cache.Set(secretPath, entry, cs.cacheConfig.DurationNotFound)
 	}
 
-	return value, expiration, found, nil
+	return value, expiration, found, false, nil
 }
 
 func (cs *CachedSecrets) NewSecretLookupPaths(teamName string, pipelineName string) []SecretLookupPath {
 	return cs.secrets.NewSecretLookupPaths(teamName, pipelineName)
 }
+
+// NewLeases returns Leases which share the cache of these secrets. Leased
+// secrets are never cached, so each build still takes out its own leases.
+func (cs *CachedSecrets) NewLeases() Leases {
+	leases := NewLeases(cs.secrets)
+
+	return cachedLeases{
+		CachedSecrets: &CachedSecrets{
+			secrets:     leases,
+			cacheConfig: cs.cacheConfig,
+			cache:       cs.cache,
+		},
+		leases: leases,
+	}
+}
+
+type cachedLeases struct {
+	*CachedSecrets
+	leases Leases
+}
+
+func (cl cachedLeases) RevokeAll() {
+	cl.leases.RevokeAll()
+}
@@ -162,4 +162,13 @@ var _ = Describe("Caching of secrets", func() {
 		Expect(underlyingMisses).To(BeIdenticalTo(4))
 	})
 
+	It("should not cache secrets which have already expired", func() {
+		expired := time.Now().Add(-time.Second)
+		secretManager.GetStub = makeGetStub("foo", "value", &expired, true, nil, &underlyingReads, &underlyingMisses)
+
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		Expect(underlyingReads).To(BeIdenticalTo(2))
+	})
+
 })
@@ -1,6 +1,8 @@
 package credhub
 
 import (
+	"errors"
+
 	"github.com/concourse/concourse/atc/creds"
 	flags "github.com/jessevdk/go-flags"
 )
@@ -27,3 +29,7 @@ func (factory *credhubManagerFactory) AddConfig(group *flags.Group) creds.Manage
 
 	return manager
 }
+
+func (factory *credhubManagerFactory) NewInstance(interface{}) (creds.Manager, error) {
+	return nil, errors.New("credhub is not supported as a var source")
+}
@@ -0,0 +1,43 @@
+package dev
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+// Dev looks up secrets by walking the loaded secrets tree along the
+// slash-separated segments of the secret path, i.e. the secret
+// /concourse/main/pipeline/foo is found at concourse.main.pipeline.foo.
+type Dev struct {
+	store      *store
+	pathPrefix string
+}
+
+// NewSecretLookupPaths defines how variables will be searched in the underlying secret manager
+func (secrets Dev) NewSecretLookupPaths(teamName string, pipelineName string) []creds.SecretLookupPath {
+	lookupPaths := []creds.SecretLookupPath{}
+	if len(pipelineName) > 0 {
+		lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix(path.Join(secrets.pathPrefix, teamName, pipelineName)+"/"))
+	}
+	lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix(path.Join(secrets.pathPrefix, teamName)+"/"))
+	return lookupPaths
+}
+
+// Get retrieves the value of an individual secret, reloading the secrets
+// first if they have changed on disk. Secrets never expire.
+func (secrets Dev) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	err := secrets.store.refresh()
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	value, found := secrets.store.lookup(strings.Split(secretPath, "/"))
+	if !found {
+		return nil, nil, false, nil
+	}
+
+	return value, nil, true, nil
+}
@@ -0,0 +1,24 @@
+package dev
+
+import (
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type devFactory struct {
+	store      *store
+	pathPrefix string
+}
+
+func NewDevFactory(store *store, pathPrefix string) *devFactory {
+	return &devFactory{
+		store:      store,
+		pathPrefix: pathPrefix,
+	}
+}
+
+func (factory *devFactory) NewSecrets() creds.Secrets {
+	return &Dev{
+		store:      factory.store,
+		pathPrefix: factory.pathPrefix,
+	}
+}
@@ -0,0 +1,191 @@
+package dev_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/dev"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Dev", func() {
+	var (
+		tmpDir    string
+		manager   *dev.DevManager
+		secrets   creds.Secrets
+		variables vars.Variables
+	)
+
+	write := func(path string, content string) {
+		path = filepath.Join(tmpDir, path)
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(path, []byte(content), 0644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "dev-creds")
+		Expect(err).ToNot(HaveOccurred())
+
+		manager = &dev.DevManager{
+			PathPrefix: dev.DefaultPathPrefix,
+		}
+	})
+
+	JustBeforeEach(func() {
+		logger := lagertest.NewTestLogger("test")
+		Expect(manager.Init(logger)).To(Succeed())
+
+		factory, err := manager.NewSecretsFactory(logger)
+		Expect(err).ToNot(HaveOccurred())
+
+		secrets = factory.NewSecrets()
+		variables = creds.NewVariables(secrets, "some-team", "some-pipeline")
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	Context("with a file", func() {
+		BeforeEach(func() {
+			write("secrets.yml", `
+concourse:
+  some-team:
+    team-secret: team-value
+    shadowed: team-value
+    some-pipeline:
+      pipeline-secret: pipeline-value
+      shadowed: pipeline-value
+      some-map:
+        field: field-value
+`)
+
+			manager.Path = filepath.Join(tmpDir, "secrets.yml")
+		})
+
+		It("looks up pipeline secrets", func() {
+			val, found, err := variables.Get(vars.VariableDefinition{Name: "pipeline-secret"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("pipeline-value"))
+		})
+
+		It("looks up team secrets", func() {
+			val, found, err := variables.Get(vars.VariableDefinition{Name: "team-secret"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("team-value"))
+		})
+
+		It("prefers pipeline secrets over team secrets", func() {
+			val, found, err := variables.Get(vars.VariableDefinition{Name: "shadowed"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("pipeline-value"))
+		})
+
+		It("looks up secrets with fields", func() {
+			val, found, err := variables.Get(vars.VariableDefinition{Name: "some-map"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal(map[string]interface{}{"field": "field-value"}))
+		})
+
+		It("does not find missing secrets", func() {
+			_, found, err := variables.Get(vars.VariableDefinition{Name: "bogus"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("never expires secrets", func() {
+			_, expiration, found, err := secrets.Get("/concourse/some-team/team-secret")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(expiration).To(BeNil())
+		})
+
+		Context("when the file changes", func() {
+			It("reloads the secrets", func() {
+				write("secrets.yml", `{"concourse": {"some-team": {"team-secret": "new-value"}}}`)
+
+				// make sure the change is noticed on filesystems with coarse mtimes
+				later := time.Now().Add(time.Minute)
+				Expect(os.Chtimes(manager.Path, later, later)).To(Succeed())
+
+				val, found, err := variables.Get(vars.VariableDefinition{Name: "team-secret"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(val).To(Equal("new-value"))
+			})
+		})
+
+		Context("when the file becomes invalid", func() {
+			It("errors", func() {
+				write("secrets.yml", "- not-a-map")
+
+				later := time.Now().Add(time.Minute)
+				Expect(os.Chtimes(manager.Path, later, later)).To(Succeed())
+
+				_, _, err := variables.Get(vars.VariableDefinition{Name: "team-secret"})
+				Expect(err).To(MatchError(ContainSubstring("must be a map")))
+			})
+		})
+	})
+
+	Context("with a directory", func() {
+		BeforeEach(func() {
+			write("concourse/some-team.yml", "team-secret: team-value")
+			write("concourse/some-team/some-pipeline.json", `{"pipeline-secret": "pipeline-value"}`)
+			write("concourse/some-team/some-pipeline/private-key", "some-key\n")
+			write("concourse/some-team/.hidden.yml", "hidden-secret: hidden-value")
+
+			manager.Path = tmpDir
+		})
+
+		It("looks up secrets of files named after their path", func() {
+			val, found, err := variables.Get(vars.VariableDefinition{Name: "pipeline-secret"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("pipeline-value"))
+		})
+
+		It("merges files with directories of the same name", func() {
+			val, found, err := variables.Get(vars.VariableDefinition{Name: "team-secret"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("team-value"))
+		})
+
+		It("reads other files as plain secrets", func() {
+			val, found, err := variables.Get(vars.VariableDefinition{Name: "private-key"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("some-key"))
+		})
+
+		It("ignores hidden files", func() {
+			_, _, found, err := secrets.Get("/concourse/some-team/.hidden/hidden-secret")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when a file is added", func() {
+			It("reloads the secrets", func() {
+				write("concourse/some-team/new-secret", "new-value")
+
+				val, found, err := variables.Get(vars.VariableDefinition{Name: "new-secret"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(val).To(Equal("new-value"))
+			})
+		})
+	})
+})
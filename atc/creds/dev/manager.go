@@ -0,0 +1,96 @@
+package dev
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+const DefaultPathPrefix = "/concourse"
+
+// DevManager looks up secrets in a YAML or JSON file, or a directory tree of
+// such files, on the ATC host. It needs no network access, which makes it
+// suitable for development clusters and integration tests, but it should
+// never be used in production.
+type DevManager struct {
+	Path       string `mapstructure:"path" long:"path" description:"Path to a YAML or JSON file, or a directory of such files, containing secrets."`
+	PathPrefix string `mapstructure:"path_prefix" long:"path-prefix" default:"/concourse" description:"Path under which to namespace credential lookup."`
+
+	store *store
+}
+
+func (manager *DevManager) MarshalJSON() ([]byte, error) {
+	health, err := manager.Health()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&map[string]interface{}{
+		"path":        manager.Path,
+		"path_prefix": manager.PathPrefix,
+		"health":      health,
+	})
+}
+
+func (manager *DevManager) Init(log lager.Logger) error {
+	manager.store = newStore(log, manager.Path)
+	return nil
+}
+
+func (manager *DevManager) IsConfigured() bool {
+	return manager.Path != ""
+}
+
+func (manager *DevManager) Validate() error {
+	if manager.Path == "" {
+		return errors.New("must provide a path")
+	}
+
+	_, err := os.Stat(manager.Path)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Health reloads the secrets if they have changed, and reports whether they
+// could be loaded.
+func (manager *DevManager) Health() (*creds.HealthResponse, error) {
+	health := &creds.HealthResponse{
+		Method: "load",
+	}
+
+	if manager.store == nil {
+		health.Error = "not initialized"
+		return health, nil
+	}
+
+	err := manager.store.refresh()
+	if err != nil {
+		health.Error = err.Error()
+		return health, nil
+	}
+
+	health.Response = map[string]string{
+		"status": "UP",
+	}
+
+	return health, nil
+}
+
+func (manager *DevManager) NewSecretsFactory(log lager.Logger) (creds.SecretsFactory, error) {
+	if manager.store == nil {
+		manager.store = newStore(log, manager.Path)
+	}
+
+	err := manager.store.refresh()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDevFactory(manager.store, manager.PathPrefix), nil
+}
@@ -0,0 +1,35 @@
+package dev
+
+import (
+	"errors"
+
+	"github.com/concourse/concourse/atc/creds"
+	flags "github.com/jessevdk/go-flags"
+)
+
+type devManagerFactory struct{}
+
+func init() {
+	creds.Register("dev", NewDevManagerFactory())
+}
+
+func NewDevManagerFactory() creds.ManagerFactory {
+	return &devManagerFactory{}
+}
+
+func (factory *devManagerFactory) AddConfig(group *flags.Group) creds.Manager {
+	manager := &DevManager{}
+	subGroup, err := group.AddGroup("Development Credential Management", "", manager)
+	if err != nil {
+		panic(err)
+	}
+
+	subGroup.Namespace = "dev-credentials"
+	return manager
+}
+
+// NewInstance always errors: a var source reading files on the ATC host
+// would let anyone who can set a pipeline read those files.
+func (factory *devManagerFactory) NewInstance(interface{}) (creds.Manager, error) {
+	return nil, errors.New("dev is not supported as a var source")
+}
@@ -0,0 +1,108 @@
+package dev_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds/dev"
+	flags "github.com/jessevdk/go-flags"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DevManager", func() {
+	var (
+		manager dev.DevManager
+		tmpDir  string
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "dev-creds")
+		Expect(err).ToNot(HaveOccurred())
+
+		manager = dev.DevManager{}
+		_, err = flags.ParseArgs(&manager, []string{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	Describe("IsConfigured()", func() {
+		It("fails on empty DevManager", func() {
+			Expect(manager.IsConfigured()).To(BeFalse())
+		})
+
+		It("passes if Path is set", func() {
+			manager.Path = tmpDir
+			Expect(manager.IsConfigured()).To(BeTrue())
+		})
+	})
+
+	Describe("Validate()", func() {
+		It("defaults the path prefix", func() {
+			Expect(manager.PathPrefix).To(Equal(dev.DefaultPathPrefix))
+		})
+
+		It("passes if the path exists", func() {
+			manager.Path = tmpDir
+			Expect(manager.Validate()).To(Succeed())
+		})
+
+		It("fails if the path does not exist", func() {
+			manager.Path = filepath.Join(tmpDir, "bogus")
+			Expect(manager.Validate()).ToNot(Succeed())
+		})
+
+		It("fails without a path", func() {
+			Expect(manager.Validate()).To(MatchError("must provide a path"))
+		})
+	})
+
+	Describe("Health()", func() {
+		var path string
+
+		BeforeEach(func() {
+			path = filepath.Join(tmpDir, "secrets.yml")
+			manager.Path = path
+
+			Expect(manager.Init(lagertest.NewTestLogger("test"))).To(Succeed())
+		})
+
+		It("is up when the secrets can be loaded", func() {
+			Expect(ioutil.WriteFile(path, []byte("foo: bar"), 0644)).To(Succeed())
+
+			health, err := manager.Health()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(health.Error).To(BeEmpty())
+			Expect(health.Response).To(Equal(map[string]string{"status": "UP"}))
+		})
+
+		It("reports an error when the secrets cannot be parsed", func() {
+			Expect(ioutil.WriteFile(path, []byte("{"), 0644)).To(Succeed())
+
+			health, err := manager.Health()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(health.Error).To(ContainSubstring("failed to parse"))
+			Expect(health.Response).To(BeNil())
+		})
+
+		It("reports an error when the secrets are missing", func() {
+			health, err := manager.Health()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(health.Error).ToNot(BeEmpty())
+		})
+	})
+
+	Describe("NewInstance()", func() {
+		It("is not supported", func() {
+			_, err := dev.NewDevManagerFactory().NewInstance(map[string]interface{}{"path": tmpDir})
+			Expect(err).To(MatchError("dev is not supported as a var source"))
+		})
+	})
+})
@@ -0,0 +1,253 @@
+package dev
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"sigs.k8s.io/yaml"
+)
+
+// store holds the secrets loaded from a file or directory tree, and reloads
+// them whenever any of the files is added, removed, or modified.
+//
+// A file's secrets are its parsed content. Within a directory tree, each
+// file's secrets are placed under the file's path relative to the directory,
+// minus any .yml, .yaml, or .json extension; files with any other extension
+// hold a single secret, their content minus a trailing newline. Files and
+// directories starting with a dot are ignored.
+type store struct {
+	logger lager.Logger
+	path   string
+
+	lock    sync.RWMutex
+	stamps  map[string]stamp
+	secrets map[string]interface{}
+}
+
+type stamp struct {
+	modTime int64
+	size    int64
+}
+
+func newStore(logger lager.Logger, path string) *store {
+	return &store{
+		logger: logger,
+		path:   path,
+	}
+}
+
+// refresh reloads the secrets if the files have changed since they were last
+// loaded. Detecting changes takes a stat of every file, which is cheap enough
+// for the handful of files this is meant for.
+func (s *store) refresh() error {
+	stamps, err := s.stat()
+	if err != nil {
+		s.logger.Error("failed-to-stat-secrets", err)
+		return err
+	}
+
+	s.lock.RLock()
+	unchanged := s.secrets != nil && reflect.DeepEqual(stamps, s.stamps)
+	s.lock.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+
+	secrets, err := s.load(stamps)
+	if err != nil {
+		s.logger.Error("failed-to-load-secrets", err)
+		return err
+	}
+
+	s.lock.Lock()
+	s.stamps = stamps
+	s.secrets = secrets
+	s.lock.Unlock()
+
+	s.logger.Info("loaded-secrets", lager.Data{"path": s.path, "files": len(stamps)})
+
+	return nil
+}
+
+func (s *store) lookup(keys []string) (interface{}, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var value interface{} = s.secrets
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+
+		tree, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		value, ok = tree[key]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+func (s *store) stat() (map[string]stamp, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return map[string]stamp{
+			s.path: {modTime: info.ModTime().UnixNano(), size: info.Size()},
+		}, nil
+	}
+
+	stamps := map[string]stamp{}
+	err = filepath.Walk(s.path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path != s.path && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if info.Mode().IsRegular() {
+			stamps[path] = stamp{modTime: info.ModTime().UnixNano(), size: info.Size()}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stamps, nil
+}
+
+func (s *store) load(stamps map[string]stamp) (map[string]interface{}, error) {
+	if _, found := stamps[s.path]; found {
+		value, err := parseFile(s.path)
+		if err != nil {
+			return nil, err
+		}
+
+		secrets, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("secrets in '%s' must be a map", s.path)
+		}
+
+		return secrets, nil
+	}
+
+	secrets := map[string]interface{}{}
+	for path := range stamps {
+		value, err := parseFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		rel, err := filepath.Rel(s.path, path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch filepath.Ext(rel) {
+		case ".yml", ".yaml", ".json":
+			rel = strings.TrimSuffix(rel, filepath.Ext(rel))
+		}
+
+		err = insert(secrets, strings.Split(filepath.ToSlash(rel), "/"), value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return secrets, nil
+}
+
+func parseFile(path string) (interface{}, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+
+	switch filepath.Ext(path) {
+	case ".json":
+		err = json.Unmarshal(content, &value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse '%s' as json: %s", path, err)
+		}
+	case ".yml", ".yaml":
+		err = yaml.Unmarshal(content, &value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse '%s' as yaml: %s", path, err)
+		}
+	default:
+		value = strings.TrimSuffix(string(content), "\n")
+	}
+
+	return value, nil
+}
+
+// insert places the value at the given keys of the tree, merging it with any
+// secrets already there, e.g. from a directory named like a file.
+func insert(tree map[string]interface{}, keys []string, value interface{}) error {
+	parent := tree
+	for i, key := range keys[:len(keys)-1] {
+		sub, found := parent[key]
+		if !found {
+			sub = map[string]interface{}{}
+			parent[key] = sub
+		}
+
+		subTree, ok := sub.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("conflicting secrets at '%s'", strings.Join(keys[:i+1], "/"))
+		}
+
+		parent = subTree
+	}
+
+	key := keys[len(keys)-1]
+
+	existing, found := parent[key]
+	if !found {
+		parent[key] = value
+		return nil
+	}
+
+	_, existingIsTree := existing.(map[string]interface{})
+	valueTree, valueIsTree := value.(map[string]interface{})
+	if !existingIsTree || !valueIsTree {
+		return fmt.Errorf("conflicting secrets at '%s'", strings.Join(keys, "/"))
+	}
+
+	for subKey, subValue := range valueTree {
+		subKeys := append(append([]string{}, keys...), subKey)
+
+		err := insert(tree, subKeys, subValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,8 @@
 package kubernetes
 
 import (
+	"errors"
+
 	"github.com/concourse/concourse/atc/creds"
 	flags "github.com/jessevdk/go-flags"
 )
@@ -27,3 +29,7 @@ func (factory *kubernetesManagerFactory) AddConfig(group *flags.Group) creds.Man
 
 	return manager
 }
+
+func (factory *kubernetesManagerFactory) NewInstance(interface{}) (creds.Manager, error) {
+	return nil, errors.New("kubernetes is not supported as a var source")
+}
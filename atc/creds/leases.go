@@ -0,0 +1,43 @@
+package creds
+
+//go:generate counterfeiter . LeasingSecrets
+
+// LeasingSecrets are Secrets which may hand out secrets under a lease, e.g.
+// dynamic database credentials. A leased secret is only valid while its lease
+// is renewed, so it must not outlive the build it was fetched for.
+type LeasingSecrets interface {
+	Secrets
+
+	// NewLeases returns Secrets which fetch secrets like these Secrets, but
+	// keep renewing the lease of each leased secret until revoked.
+	NewLeases() Leases
+}
+
+//go:generate counterfeiter . Leases
+
+// Leases are the Secrets of a single build. Each leased secret fetched
+// through them is fetched once, with a lease of its own.
+type Leases interface {
+	Secrets
+
+	// RevokeAll stops renewing the leases and revokes them. It is called once
+	// the build has finished.
+	RevokeAll()
+}
+
+// NewLeases returns the Leases of the given secrets, or the secrets as-is if
+// they never hand out leased secrets.
+func NewLeases(secrets Secrets) Leases {
+	leasingSecrets, ok := secrets.(LeasingSecrets)
+	if !ok {
+		return noLeases{secrets}
+	}
+
+	return leasingSecrets.NewLeases()
+}
+
+type noLeases struct {
+	Secrets
+}
+
+func (noLeases) RevokeAll() {}
@@ -0,0 +1,81 @@
+package creds_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Leases", func() {
+	var (
+		fakeLeasingSecrets *credsfakes.FakeLeasingSecrets
+		fakeLeases         *credsfakes.FakeLeases
+		reads              int
+		misses             int
+	)
+
+	BeforeEach(func() {
+		fakeLeasingSecrets = new(credsfakes.FakeLeasingSecrets)
+		fakeLeases = new(credsfakes.FakeLeases)
+		fakeLeasingSecrets.NewLeasesReturns(fakeLeases)
+
+		reads = 0
+		misses = 0
+		fakeLeases.GetStub = makeGetStub("foo", "value", nil, true, nil, &reads, &misses)
+	})
+
+	Describe("NewLeases", func() {
+		It("returns the leases of leasing secrets", func() {
+			Expect(creds.NewLeases(fakeLeasingSecrets)).To(Equal(fakeLeases))
+		})
+
+		It("returns other secrets as-is, never revoking anything", func() {
+			fakeSecrets := new(credsfakes.FakeSecrets)
+			fakeSecrets.GetReturns("value", nil, true, nil)
+
+			leases := creds.NewLeases(fakeSecrets)
+
+			value, _, found, err := leases.Get("foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("value"))
+
+			leases.RevokeAll()
+		})
+	})
+
+	Context("through cached and retryable secrets", func() {
+		var leases creds.Leases
+
+		BeforeEach(func() {
+			secrets := creds.NewCachedSecrets(
+				creds.NewRetryableSecrets(fakeLeasingSecrets, creds.SecretRetryConfig{Attempts: 5, Interval: time.Millisecond}),
+				creds.SecretCacheConfig{Duration: time.Minute, PurgeInterval: time.Minute},
+			)
+
+			leases = creds.NewLeases(secrets)
+		})
+
+		It("fetches secrets through the underlying leases, caching them", func() {
+			value, _, found, err := leases.Get("foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("value"))
+
+			_, _, _, err = leases.Get("foo")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(reads).To(Equal(1))
+			Expect(fakeLeasingSecrets.GetCallCount()).To(BeZero())
+		})
+
+		It("revokes the underlying leases", func() {
+			leases.RevokeAll()
+			Expect(fakeLeases.RevokeAllCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -5,6 +5,8 @@ import (
 	"github.com/jessevdk/go-flags"
 )
 
+//go:generate counterfeiter . Manager
+
 type Manager interface {
 	IsConfigured() bool
 	Validate() error
@@ -14,8 +16,15 @@ type Manager interface {
 	NewSecretsFactory(lager.Logger) (SecretsFactory, error)
 }
 
+//go:generate counterfeiter . ManagerFactory
+
 type ManagerFactory interface {
 	AddConfig(*flags.Group) Manager
+
+	// NewInstance returns a manager configured from the config of a var
+	// source, e.g. a map with the same keys as the manager's JSON
+	// representation.
+	NewInstance(interface{}) (Manager, error)
 }
 
 type Managers map[string]Manager
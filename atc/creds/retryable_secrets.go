@@ -43,3 +43,25 @@ func (rs RetryableSecrets) Get(secretPath string) (interface{}, *time.Time, bool
 func (rs RetryableSecrets) NewSecretLookupPaths(teamName string, pipelineName string) []SecretLookupPath {
 	return rs.secrets.NewSecretLookupPaths(teamName, pipelineName)
 }
+
+// NewLeases returns Leases which retry fetching secrets like these secrets.
+func (rs RetryableSecrets) NewLeases() Leases {
+	leases := NewLeases(rs.secrets)
+
+	return retryableLeases{
+		RetryableSecrets: RetryableSecrets{
+			secrets:     leases,
+			retryConfig: rs.retryConfig,
+		},
+		leases: leases,
+	}
+}
+
+type retryableLeases struct {
+	RetryableSecrets
+	leases Leases
+}
+
+func (rl retryableLeases) RevokeAll() {
+	rl.leases.RevokeAll()
+}
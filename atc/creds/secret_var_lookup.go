@@ -17,6 +17,11 @@ func NewVariables(secrets Secrets, teamName string, pipelineName string) vars.Va
 }
 
 func (sl VariableLookupFromSecrets) Get(varDef vars.VariableDefinition) (interface{}, bool, error) {
+	// vars from other sources, e.g. build-local vars, are never secrets
+	if varDef.Source != "" {
+		return nil, false, nil
+	}
+
 	// try to find a secret according to our var->secret lookup paths
 	if len(sl.LookupPaths) > 0 {
 		for _, rule := range sl.LookupPaths {
@@ -19,12 +19,12 @@ const DefaultPipelineSecretTemplate = "/concourse/{{.Team}}/{{.Pipeline}}/{{.Sec
 const DefaultTeamSecretTemplate = "/concourse/{{.Team}}/{{.Secret}}"
 
 type Manager struct {
-	AwsAccessKeyID         string `long:"access-key" description:"AWS Access key ID"`
-	AwsSecretAccessKey     string `long:"secret-key" description:"AWS Secret Access Key"`
-	AwsSessionToken        string `long:"session-token" description:"AWS Session Token"`
-	AwsRegion              string `long:"region" description:"AWS region to send requests to"`
-	PipelineSecretTemplate string `long:"pipeline-secret-template" description:"AWS Secrets Manager secret identifier template used for pipeline specific parameter" default:"/concourse/{{.Team}}/{{.Pipeline}}/{{.Secret}}"`
-	TeamSecretTemplate     string `long:"team-secret-template" description:"AWS Secrets Manager secret identifier  template used for team specific parameter" default:"/concourse/{{.Team}}/{{.Secret}}"`
+	AwsAccessKeyID         string `mapstructure:"aws_access_key_id" long:"access-key" description:"AWS Access key ID"`
+	AwsSecretAccessKey     string `mapstructure:"aws_secret_access_key" long:"secret-key" description:"AWS Secret Access Key"`
+	AwsSessionToken        string `mapstructure:"aws_session_token" long:"session-token" description:"AWS Session Token"`
+	AwsRegion              string `mapstructure:"aws_region" long:"region" description:"AWS region to send requests to"`
+	PipelineSecretTemplate string `mapstructure:"pipeline_secret_template" long:"pipeline-secret-template" description:"AWS Secrets Manager secret identifier template used for pipeline specific parameter" default:"/concourse/{{.Team}}/{{.Pipeline}}/{{.Secret}}"`
+	TeamSecretTemplate     string `mapstructure:"team_secret_template" long:"team-secret-template" description:"AWS Secrets Manager secret identifier  template used for team specific parameter" default:"/concourse/{{.Team}}/{{.Secret}}"`
 	SecretManager          *SecretsManager
 }
 
@@ -3,6 +3,7 @@ package secretsmanager
 import (
 	"github.com/concourse/concourse/atc/creds"
 	flags "github.com/jessevdk/go-flags"
+	"github.com/mitchellh/mapstructure"
 )
 
 type managerFactory struct{}
@@ -27,3 +28,25 @@ func (factory *managerFactory) AddConfig(group *flags.Group) creds.Manager {
 	subGroup.Namespace = "aws-secretsmanager"
 	return manager
 }
+
+func (factory *managerFactory) NewInstance(config interface{}) (creds.Manager, error) {
+	manager := &Manager{
+		PipelineSecretTemplate: DefaultPipelineSecretTemplate,
+		TeamSecretTemplate:     DefaultTeamSecretTemplate,
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused: true,
+		Result:      manager,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = decoder.Decode(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
@@ -3,6 +3,7 @@ package ssm
 import (
 	"github.com/concourse/concourse/atc/creds"
 	flags "github.com/jessevdk/go-flags"
+	"github.com/mitchellh/mapstructure"
 )
 
 type ssmManagerFactory struct{}
@@ -25,3 +26,25 @@ func (factory *ssmManagerFactory) AddConfig(group *flags.Group) creds.Manager {
 	subGroup.Namespace = "aws-ssm"
 	return manager
 }
+
+func (factory *ssmManagerFactory) NewInstance(config interface{}) (creds.Manager, error) {
+	manager := &SsmManager{
+		PipelineSecretTemplate: DefaultPipelineSecretTemplate,
+		TeamSecretTemplate:     DefaultTeamSecretTemplate,
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused: true,
+		Result:      manager,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = decoder.Decode(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
@@ -0,0 +1,163 @@
+package creds
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
+)
+
+//go:generate counterfeiter . VarSourcePool
+
+// VarSourcePool configures the credential managers of the var sources
+// declared by pipelines. Each distinct var source is configured once, and its
+// secrets are cached independently of the cluster-wide credential manager
+// and of the other var sources.
+type VarSourcePool interface {
+	FindOrCreate(atc.VarSourceConfig) (Secrets, error)
+}
+
+type varSourcePool struct {
+	logger               lager.Logger
+	credentialManagement CredentialManagementConfig
+	factories            map[string]ManagerFactory
+
+	lock    sync.Mutex
+	secrets map[string]Secrets
+}
+
+func NewVarSourcePool(
+	logger lager.Logger,
+	credentialManagement CredentialManagementConfig,
+	factories map[string]ManagerFactory,
+) VarSourcePool {
+	return &varSourcePool{
+		logger:               logger,
+		credentialManagement: credentialManagement,
+		factories:            factories,
+
+		secrets: map[string]Secrets{},
+	}
+}
+
+// FindOrCreate returns the secrets of the given var source. Var sources with
+// the same type and config share their secrets, no matter which pipeline
+// declares them or under which name.
+func (pool *varSourcePool) FindOrCreate(varSource atc.VarSourceConfig) (Secrets, error) {
+	config, err := json.Marshal(varSource.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	key := varSource.Type + ":" + string(config)
+
+	pool.lock.Lock()
+	defer pool.lock.Unlock()
+
+	secrets, found := pool.secrets[key]
+	if found {
+		return secrets, nil
+	}
+
+	factory, found := pool.factories[varSource.Type]
+	if !found {
+		return nil, fmt.Errorf("unknown credential manager type: %s", varSource.Type)
+	}
+
+	manager, err := factory.NewInstance(varSource.Config)
+	if err != nil {
+		return nil, fmt.Errorf("var source '%s' misconfigured: %s", varSource.Name, err)
+	}
+
+	logger := pool.logger.Session("var-source", lager.Data{
+		"name": varSource.Name,
+		"type": varSource.Type,
+	})
+
+	err = manager.Init(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	err = manager.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("var source '%s' misconfigured: %s", varSource.Name, err)
+	}
+
+	secretsFactory, err := manager.NewSecretsFactory(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets = NewRetryableSecrets(secretsFactory.NewSecrets(), pool.credentialManagement.RetryConfig)
+	secrets = NewCachedSecrets(secrets, pool.credentialManagement.CacheConfig)
+
+	pool.secrets[key] = secrets
+
+	return secrets, nil
+}
+
+type pipelineVariables struct {
+	variables    vars.Variables
+	pool         VarSourcePool
+	teamName     string
+	pipelineName string
+	varSources   atc.VarSourceConfigs
+}
+
+// NewPipelineVariables is like NewVariables, but also looks up the
+// ((source:path)) vars of the given var sources of a pipeline.
+//
+// The config of each var source is interpolated with the vars of the
+// cluster-wide credential manager, so that a var source's own credentials
+// need not be written down in the pipeline.
+func NewPipelineVariables(
+	secrets Secrets,
+	pool VarSourcePool,
+	teamName string,
+	pipelineName string,
+	varSources atc.VarSourceConfigs,
+) vars.Variables {
+	return pipelineVariables{
+		variables:    NewVariables(secrets, teamName, pipelineName),
+		pool:         pool,
+		teamName:     teamName,
+		pipelineName: pipelineName,
+		varSources:   varSources,
+	}
+}
+
+func (v pipelineVariables) Get(varDef vars.VariableDefinition) (interface{}, bool, error) {
+	if varDef.Source == "" {
+		return v.variables.Get(varDef)
+	}
+
+	varSource, found := v.varSources.Lookup(varDef.Source)
+	if !found {
+		return nil, false, nil
+	}
+
+	var config interface{}
+	err := evaluate(v.variables, varSource.Config, &config)
+	if err != nil {
+		return nil, false, fmt.Errorf("evaluate var source '%s': %s", varSource.Name, err)
+	}
+
+	varSource.Config = config
+
+	secrets, err := v.pool.FindOrCreate(varSource)
+	if err != nil {
+		return nil, false, err
+	}
+
+	varDef.Source = ""
+
+	return NewVariables(secrets, v.teamName, v.pipelineName).Get(varDef)
+}
+
+func (v pipelineVariables) List() ([]vars.VariableDefinition, error) {
+	return v.variables.List()
+}
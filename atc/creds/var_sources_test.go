@@ -0,0 +1,207 @@
+package creds_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/vars"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Var sources", func() {
+	var (
+		fakeManagerFactory *credsfakes.FakeManagerFactory
+		fakeManager        *credsfakes.FakeManager
+		fakeSecrets        *credsfakes.FakeSecrets
+
+		pool creds.VarSourcePool
+	)
+
+	BeforeEach(func() {
+		fakeManagerFactory = new(credsfakes.FakeManagerFactory)
+		fakeManager = new(credsfakes.FakeManager)
+		fakeSecrets = new(credsfakes.FakeSecrets)
+
+		fakeSecretsFactory := new(credsfakes.FakeSecretsFactory)
+		fakeSecretsFactory.NewSecretsReturns(fakeSecrets)
+
+		fakeManager.NewSecretsFactoryReturns(fakeSecretsFactory, nil)
+		fakeManagerFactory.NewInstanceReturns(fakeManager, nil)
+
+		fakeSecrets.GetStub = func(path string) (interface{}, *time.Time, bool, error) {
+			if path == "some-path" {
+				return map[string]interface{}{"field": "some-secret"}, nil, true, nil
+			}
+
+			return nil, nil, false, nil
+		}
+
+		pool = creds.NewVarSourcePool(
+			lagertest.NewTestLogger("test"),
+			creds.CredentialManagementConfig{},
+			map[string]creds.ManagerFactory{"some-type": fakeManagerFactory},
+		)
+	})
+
+	Describe("VarSourcePool", func() {
+		varSource := atc.VarSourceConfig{
+			Name:   "some-source",
+			Type:   "some-type",
+			Config: map[string]interface{}{"url": "some-url"},
+		}
+
+		It("configures a credential manager for the var source", func() {
+			_, err := pool.FindOrCreate(varSource)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeManagerFactory.NewInstanceCallCount()).To(Equal(1))
+			Expect(fakeManagerFactory.NewInstanceArgsForCall(0)).To(Equal(varSource.Config))
+			Expect(fakeManager.InitCallCount()).To(Equal(1))
+			Expect(fakeManager.ValidateCallCount()).To(Equal(1))
+		})
+
+		It("caches the secrets of the var source", func() {
+			secrets, err := pool.FindOrCreate(varSource)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, found, err := secrets.Get("some-path")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, _, found, err = secrets.Get("some-path")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(fakeSecrets.GetCallCount()).To(Equal(1))
+		})
+
+		It("reuses the secrets of var sources with the same config", func() {
+			secrets, err := pool.FindOrCreate(varSource)
+			Expect(err).ToNot(HaveOccurred())
+
+			renamed := varSource
+			renamed.Name = "other-source"
+
+			otherSecrets, err := pool.FindOrCreate(renamed)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(otherSecrets).To(BeIdenticalTo(secrets))
+			Expect(fakeManagerFactory.NewInstanceCallCount()).To(Equal(1))
+		})
+
+		It("configures var sources with another config separately", func() {
+			_, err := pool.FindOrCreate(varSource)
+			Expect(err).ToNot(HaveOccurred())
+
+			reconfigured := varSource
+			reconfigured.Config = map[string]interface{}{"url": "other-url"}
+
+			_, err = pool.FindOrCreate(reconfigured)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeManagerFactory.NewInstanceCallCount()).To(Equal(2))
+		})
+
+		Context("when the type is unknown", func() {
+			It("errors", func() {
+				unknown := varSource
+				unknown.Type = "bogus-type"
+
+				_, err := pool.FindOrCreate(unknown)
+				Expect(err).To(MatchError("unknown credential manager type: bogus-type"))
+			})
+		})
+
+		Context("when the config is invalid", func() {
+			BeforeEach(func() {
+				fakeManager.ValidateReturns(errors.New("nope"))
+			})
+
+			It("errors and does not keep the var source", func() {
+				_, err := pool.FindOrCreate(varSource)
+				Expect(err).To(MatchError("var source 'some-source' misconfigured: nope"))
+
+				_, err = pool.FindOrCreate(varSource)
+				Expect(err).To(HaveOccurred())
+				Expect(fakeManagerFactory.NewInstanceCallCount()).To(Equal(2))
+			})
+		})
+	})
+
+	Describe("NewPipelineVariables", func() {
+		var (
+			fakeGlobalSecrets *credsfakes.FakeSecrets
+			variables         vars.Variables
+		)
+
+		BeforeEach(func() {
+			fakeGlobalSecrets = new(credsfakes.FakeSecrets)
+			fakeGlobalSecrets.GetStub = func(path string) (interface{}, *time.Time, bool, error) {
+				switch path {
+				case "some-path":
+					return "some-global-secret", nil, true, nil
+				case "vault-url":
+					return "https://vault.example.com", nil, true, nil
+				}
+
+				return nil, nil, false, nil
+			}
+
+			variables = creds.NewPipelineVariables(
+				fakeGlobalSecrets,
+				pool,
+				"some-team",
+				"some-pipeline",
+				atc.VarSourceConfigs{
+					{
+						Name:   "some-source",
+						Type:   "some-type",
+						Config: map[string]interface{}{"url": "((vault-url))"},
+					},
+				},
+			)
+		})
+
+		It("looks up vars without a source in the cluster-wide credential manager", func() {
+			val, found, err := variables.Get(vars.VariableDefinition{Name: "some-path"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal("some-global-secret"))
+		})
+
+		It("looks up vars with a source in the var source", func() {
+			val, found, err := variables.Get(vars.VariableDefinition{Source: "some-source", Name: "some-path"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(val).To(Equal(map[string]interface{}{"field": "some-secret"}))
+		})
+
+		It("configures the var source with vars from the cluster-wide credential manager", func() {
+			_, _, err := variables.Get(vars.VariableDefinition{Source: "some-source", Name: "some-path"})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeManagerFactory.NewInstanceArgsForCall(0)).To(Equal(map[string]interface{}{
+				"url": "https://vault.example.com",
+			}))
+		})
+
+		It("does not find vars of undeclared var sources", func() {
+			_, found, err := variables.Get(vars.VariableDefinition{Source: "bogus-source", Name: "some-path"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("can be used to interpolate fields of a var source's vars", func() {
+			result, err := vars.NewTemplate([]byte("((some-source:some-path.field)) ((some-path))")).
+				Evaluate(variables, vars.EvaluateOpts{ExpectAllKeys: true})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(result)).To(Equal("some-secret some-global-secret\n"))
+		})
+	})
+})
@@ -2,8 +2,11 @@ package vault
 
 import (
 	"fmt"
+	"net/http"
 	"path"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -23,6 +26,9 @@ type APIClient struct {
 	clientValue *atomic.Value
 
 	renewable bool
+
+	mountsLock sync.Mutex
+	kvMounts   map[string]bool
 }
 
 // NewAPIClient with the associated authorization config and underlying vault client.
@@ -37,6 +43,8 @@ func NewAPIClient(logger lager.Logger, apiURL string, tlsConfig *vaultapi.TLSCon
 		clientValue: &atomic.Value{},
 
 		renewable: true,
+
+		kvMounts: map[string]bool{},
 	}
 
 	client, err := ac.baseClient()
@@ -51,8 +59,136 @@ func NewAPIClient(logger lager.Logger, apiURL string, tlsConfig *vaultapi.TLSCon
 
 // Read must be called after a successful login has occurred or an
 // un-authorized client will be used.
-func (ac *APIClient) Read(path string) (*vaultapi.Secret, error) {
-	return ac.client().Logical().Read(path)
+//
+// Secrets in KV v2 mounts are read from the mount's data/ path, so that they
+// can be referenced by the same path as any other secret.
+func (ac *APIClient) Read(secretPath string) (*vaultapi.Secret, error) {
+	return ac.ReadVersion(secretPath, 0)
+}
+
+// ReadVersion is like Read, but reads the given version of the secret, or
+// its latest version if 0. Only KV v2 secrets are versioned.
+func (ac *APIClient) ReadVersion(secretPath string, version int) (*vaultapi.Secret, error) {
+	mountPath, versioned, err := ac.kvMount(secretPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !versioned {
+		if version != 0 {
+			return nil, fmt.Errorf("cannot read version %d of %s: only secrets in KV v2 mounts are versioned", version, secretPath)
+		}
+
+		return ac.client().Logical().Read(secretPath)
+	}
+
+	dataPath := mountPath + "data/" + strings.TrimPrefix(strings.TrimPrefix(secretPath, "/"), mountPath)
+
+	var params map[string][]string
+	if version != 0 {
+		params = map[string][]string{"version": {strconv.Itoa(version)}}
+	}
+
+	secret, err := ac.client().Logical().ReadWithData(dataPath, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret == nil {
+		return nil, nil
+	}
+
+	// deleted and destroyed versions have no data
+	data, ok := secret.Data["data"].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	secret.Data = data
+
+	return secret, nil
+}
+
+// kvMount determines the mount of the secret and whether it is a KV v2
+// mount, the same way the vault CLI does. The mounts are remembered, so this
+// only takes a request for the first secret of each mount.
+func (ac *APIClient) kvMount(secretPath string) (string, bool, error) {
+	relPath := strings.TrimPrefix(secretPath, "/")
+
+	mountPath, versioned, found := ac.knownKVMount(relPath)
+	if found {
+		return mountPath, versioned, nil
+	}
+
+	client := ac.client()
+
+	resp, err := client.RawRequest(client.NewRequest("GET", "/v1/"+path.Join("sys/internal/ui/mounts", relPath)))
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+
+	if err != nil {
+		// vault versions without KV v2 support do not know this endpoint, and
+		// tokens may not be allowed to look up mounts; like the vault CLI,
+		// assume KV v1 then
+		if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden) {
+			return "", false, nil
+		}
+
+		return "", false, err
+	}
+
+	mount, err := vaultapi.ParseSecret(resp.Body)
+	if err != nil {
+		return "", false, err
+	}
+
+	if mount == nil {
+		return "", false, nil
+	}
+
+	mountPath, _ = mount.Data["path"].(string)
+
+	var version string
+	if options, ok := mount.Data["options"].(map[string]interface{}); ok {
+		version, _ = options["version"].(string)
+	}
+
+	if mountPath != "" {
+		ac.mountsLock.Lock()
+		ac.kvMounts[mountPath] = version == "2"
+		ac.mountsLock.Unlock()
+	}
+
+	return mountPath, version == "2", nil
+}
+
+func (ac *APIClient) knownKVMount(relPath string) (string, bool, bool) {
+	ac.mountsLock.Lock()
+	defer ac.mountsLock.Unlock()
+
+	var mountPath string
+	for candidate := range ac.kvMounts {
+		if strings.HasPrefix(relPath, candidate) && len(candidate) > len(mountPath) {
+			mountPath = candidate
+		}
+	}
+
+	if mountPath == "" {
+		return "", false, false
+	}
+
+	return mountPath, ac.kvMounts[mountPath], true
+}
+
+// RenewLease extends the lease of a dynamic secret by the given increment.
+func (ac *APIClient) RenewLease(leaseID string, increment time.Duration) (*vaultapi.Secret, error) {
+	return ac.client().Sys().Renew(leaseID, int(increment.Seconds()))
+}
+
+// RevokeLease revokes the lease of a dynamic secret, invalidating the secret.
+func (ac *APIClient) RevokeLease(leaseID string) error {
+	return ac.client().Sys().Revoke(leaseID)
 }
 
 func (ac *APIClient) loginParams() map[string]interface{} {
@@ -0,0 +1,200 @@
+package vault_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds/vault"
+	vaultapi "github.com/hashicorp/vault/api"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("APIClient", func() {
+	var (
+		fakeVault *ghttp.Server
+		client    *vault.APIClient
+	)
+
+	BeforeEach(func() {
+		fakeVault = ghttp.NewServer()
+
+		var err error
+		client, err = vault.NewAPIClient(
+			lagertest.NewTestLogger("test"),
+			fakeVault.URL(),
+			&vaultapi.TLSConfig{},
+			vault.AuthConfig{ClientToken: "some-token"},
+		)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		fakeVault.Close()
+	})
+
+	Context("when the secret is in a KV v2 mount", func() {
+		BeforeEach(func() {
+			fakeVault.RouteToHandler("GET", "/v1/sys/internal/ui/mounts/concourse/team/foo",
+				ghttp.RespondWith(http.StatusOK, `{"data":{"path":"concourse/","type":"kv","options":{"version":"2"}}}`),
+			)
+		})
+
+		It("reads the latest version from the mount's data path", func() {
+			fakeVault.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/concourse/data/team/foo", ""),
+				ghttp.RespondWith(http.StatusOK, `{"data":{"data":{"value":"bar"},"metadata":{"version":3}}}`),
+			))
+
+			secret, err := client.Read("/concourse/team/foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Data).To(Equal(map[string]interface{}{"value": "bar"}))
+		})
+
+		It("reads the given version", func() {
+			fakeVault.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/concourse/data/team/foo", "version=2"),
+				ghttp.RespondWith(http.StatusOK, `{"data":{"data":{"value":"old"},"metadata":{"version":2}}}`),
+			))
+
+			secret, err := client.ReadVersion("/concourse/team/foo", 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Data).To(Equal(map[string]interface{}{"value": "old"}))
+		})
+
+		It("does not find deleted versions", func() {
+			fakeVault.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/concourse/data/team/foo", "version=1"),
+				ghttp.RespondWith(http.StatusNotFound, `{"data":{"data":null,"metadata":{"version":1,"deletion_time":"2019-12-01T00:00:00Z"}}}`),
+			))
+
+			secret, err := client.ReadVersion("/concourse/team/foo", 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret).To(BeNil())
+		})
+
+		It("only looks up the mount once", func() {
+			fakeVault.RouteToHandler("GET", "/v1/concourse/data/team/foo",
+				ghttp.RespondWith(http.StatusOK, `{"data":{"data":{"value":"bar"}}}`),
+			)
+
+			fakeVault.RouteToHandler("GET", "/v1/concourse/data/team/bar",
+				ghttp.RespondWith(http.StatusOK, `{"data":{"data":{"value":"baz"}}}`),
+			)
+
+			_, err := client.Read("/concourse/team/foo")
+			Expect(err).ToNot(HaveOccurred())
+
+			secret, err := client.Read("/concourse/team/bar")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Data).To(Equal(map[string]interface{}{"value": "baz"}))
+
+			Expect(fakeVault.ReceivedRequests()).To(HaveLen(3))
+		})
+	})
+
+	Context("when the secret is in a KV v1 mount", func() {
+		BeforeEach(func() {
+			fakeVault.RouteToHandler("GET", "/v1/sys/internal/ui/mounts/concourse/team/foo",
+				ghttp.RespondWith(http.StatusOK, `{"data":{"path":"concourse/","type":"kv","options":null}}`),
+			)
+		})
+
+		It("reads the secret from its path", func() {
+			fakeVault.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/concourse/team/foo"),
+				ghttp.RespondWith(http.StatusOK, `{"lease_duration":2764800,"data":{"value":"bar"}}`),
+			))
+
+			secret, err := client.Read("/concourse/team/foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Data).To(Equal(map[string]interface{}{"value": "bar"}))
+		})
+
+		It("errors when reading a version", func() {
+			_, err := client.ReadVersion("/concourse/team/foo", 2)
+			Expect(err).To(MatchError(ContainSubstring("only secrets in KV v2 mounts are versioned")))
+		})
+	})
+
+	Context("when vault does not support looking up mounts", func() {
+		BeforeEach(func() {
+			fakeVault.RouteToHandler("GET", "/v1/sys/internal/ui/mounts/concourse/team/foo",
+				ghttp.RespondWith(http.StatusNotFound, ""),
+			)
+		})
+
+		It("reads the secret from its path", func() {
+			fakeVault.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/concourse/team/foo"),
+				ghttp.RespondWith(http.StatusOK, `{"data":{"value":"bar"}}`),
+			))
+
+			secret, err := client.Read("/concourse/team/foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Data).To(Equal(map[string]interface{}{"value": "bar"}))
+		})
+	})
+
+	Context("when the token may not look up mounts", func() {
+		BeforeEach(func() {
+			fakeVault.RouteToHandler("GET", "/v1/sys/internal/ui/mounts/concourse/team/foo",
+				ghttp.RespondWith(http.StatusForbidden, `{"errors":["permission denied"]}`),
+			)
+		})
+
+		It("reads the secret from its path", func() {
+			fakeVault.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/v1/concourse/team/foo"),
+				ghttp.RespondWith(http.StatusOK, `{"data":{"value":"bar"}}`),
+			))
+
+			secret, err := client.Read("/concourse/team/foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Data).To(Equal(map[string]interface{}{"value": "bar"}))
+		})
+	})
+
+	Context("when looking up the mount fails", func() {
+		BeforeEach(func() {
+			fakeVault.RouteToHandler("GET", "/v1/sys/internal/ui/mounts/concourse/team/foo",
+				ghttp.RespondWith(http.StatusInternalServerError, `{"errors":["oh no"]}`),
+			)
+		})
+
+		It("errors", func() {
+			_, err := client.Read("/concourse/team/foo")
+			Expect(err).To(MatchError(ContainSubstring("oh no")))
+		})
+	})
+
+	Describe("leases", func() {
+		It("renews leases", func() {
+			fakeVault.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/v1/sys/leases/renew"),
+				func(w http.ResponseWriter, r *http.Request) {
+					body, err := ioutil.ReadAll(r.Body)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(body).To(MatchJSON(`{"lease_id":"some-lease","increment":60}`))
+				},
+				ghttp.RespondWith(http.StatusOK, `{"lease_id":"some-lease","lease_duration":60,"renewable":true}`),
+			))
+
+			secret, err := client.RenewLease("some-lease", time.Minute)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.LeaseDuration).To(Equal(60))
+		})
+
+		It("revokes leases", func() {
+			fakeVault.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/v1/sys/leases/revoke/some-lease"),
+				ghttp.RespondWith(http.StatusNoContent, ""),
+			))
+
+			Expect(client.RevokeLease("some-lease")).To(Succeed())
+		})
+	})
+})
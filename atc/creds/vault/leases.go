@@ -0,0 +1,153 @@
+package vault
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+// A LeaseManager renews and revokes the leases of dynamic secrets. It should
+// be thread safe!
+type LeaseManager interface {
+	RenewLease(leaseID string, increment time.Duration) (*vaultapi.Secret, error)
+	RevokeLease(leaseID string) error
+}
+
+// NewLeases returns the Leases of a single build. Each leased secret is read
+// once per build, and its lease is renewed until the build finishes.
+func (v Vault) NewLeases() creds.Leases {
+	return &leases{
+		vault:  v,
+		logger: v.Logger.Session("leases"),
+		leased: map[string]*lease{},
+	}
+}
+
+type leases struct {
+	vault  Vault
+	logger lager.Logger
+
+	lock   sync.Mutex
+	leased map[string]*lease
+}
+
+type lease struct {
+	id    string
+	value interface{}
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func (l *leases) NewSecretLookupPaths(teamName string, pipelineName string) []creds.SecretLookupPath {
+	return l.vault.NewSecretLookupPaths(teamName, pipelineName)
+}
+
+// Get retrieves the value and expiration of an individual secret, taking out
+// a lease on leased secrets which is renewed until RevokeAll is called.
+func (l *leases) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+
+	existing, found := l.leased[secretPath]
+	if found {
+		return existing.value, &now, true, nil
+	}
+
+	secret, expiration, found, err := l.vault.findSecret(secretPath)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if !found {
+		return nil, nil, false, nil
+	}
+
+	if secret.LeaseID == "" {
+		return secretValue(secret), expiration, true, nil
+	}
+
+	newLease := &lease{
+		id:    secret.LeaseID,
+		value: secretValue(secret),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+
+	l.leased[secretPath] = newLease
+
+	logger := l.logger.Session("lease", lager.Data{"lease-id": newLease.id})
+	duration := time.Duration(secret.LeaseDuration) * time.Second
+
+	if secret.Renewable && duration > 0 {
+		go newLease.renew(logger, l.vault.LeaseManager, duration)
+	} else {
+		close(newLease.done)
+	}
+
+	logger.Debug("leased")
+
+	return newLease.value, &now, true, nil
+}
+
+// RevokeAll stops renewing the leases and revokes them.
+func (l *leases) RevokeAll() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for _, lease := range l.leased {
+		close(lease.stop)
+		<-lease.done
+
+		err := l.vault.LeaseManager.RevokeLease(lease.id)
+		if err != nil {
+			l.logger.Error("failed-to-revoke-lease", err, lager.Data{"lease-id": lease.id})
+			continue
+		}
+
+		l.logger.Debug("revoked-lease", lager.Data{"lease-id": lease.id})
+	}
+
+	l.leased = map[string]*lease{}
+}
+
+// renew renews the lease halfway through its duration, until stopped or
+// until the lease has reached its max TTL. Failed renewals are retried
+// halfway through the remaining duration.
+func (lease *lease) renew(logger lager.Logger, manager LeaseManager, duration time.Duration) {
+	defer close(lease.done)
+
+	expiry := time.Now().Add(duration)
+
+	for {
+		select {
+		case <-lease.stop:
+			return
+		case <-time.After(time.Until(expiry) / 2):
+		}
+
+		renewed, err := manager.RenewLease(lease.id, duration)
+		if err != nil {
+			logger.Error("failed-to-renew", err)
+
+			if time.Until(expiry) < time.Second {
+				return
+			}
+
+			continue
+		}
+
+		if renewed == nil || renewed.LeaseDuration == 0 {
+			logger.Info("reached-max-ttl")
+			return
+		}
+
+		expiry = time.Now().Add(time.Duration(renewed.LeaseDuration) * time.Second)
+
+		logger.Debug("renewed", lager.Data{"lease-duration": renewed.LeaseDuration})
+	}
+}
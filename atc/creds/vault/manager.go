@@ -14,34 +14,34 @@ import (
 )
 
 type VaultManager struct {
-	URL string `long:"url" description:"Vault server address used to access secrets."`
+	URL string `mapstructure:"url" long:"url" description:"Vault server address used to access secrets."`
 
-	PathPrefix string `long:"path-prefix" default:"/concourse" description:"Path under which to namespace credential lookup."`
-	SharedPath string `long:"shared-path" description:"Path under which to lookup shared credentials."`
+	PathPrefix string `mapstructure:"path_prefix" long:"path-prefix" default:"/concourse" description:"Path under which to namespace credential lookup."`
+	SharedPath string `mapstructure:"shared_path" long:"shared-path" description:"Path under which to lookup shared credentials."`
 
-	TLS    TLS
-	Auth   AuthConfig
+	TLS    TLS        `mapstructure:",squash"`
+	Auth   AuthConfig `mapstructure:",squash"`
 	Client *APIClient
 }
 
 type TLS struct {
-	CACert     string `long:"ca-cert"              description:"Path to a PEM-encoded CA cert file to use to verify the vault server SSL cert."`
-	CAPath     string `long:"ca-path"              description:"Path to a directory of PEM-encoded CA cert files to verify the vault server SSL cert."`
-	ClientCert string `long:"client-cert"          description:"Path to the client certificate for Vault authorization."`
-	ClientKey  string `long:"client-key"           description:"Path to the client private key for Vault authorization."`
-	ServerName string `long:"server-name"          description:"If set, is used to set the SNI host when connecting via TLS."`
-	Insecure   bool   `long:"insecure-skip-verify" description:"Enable insecure SSL verification."`
+	CACert     string `mapstructure:"ca_cert"              long:"ca-cert"              description:"Path to a PEM-encoded CA cert file to use to verify the vault server SSL cert."`
+	CAPath     string `mapstructure:"ca_path"              long:"ca-path"              description:"Path to a directory of PEM-encoded CA cert files to verify the vault server SSL cert."`
+	ClientCert string `mapstructure:"client_cert"          long:"client-cert"          description:"Path to the client certificate for Vault authorization."`
+	ClientKey  string `mapstructure:"client_key"           long:"client-key"           description:"Path to the client private key for Vault authorization."`
+	ServerName string `mapstructure:"server_name"          long:"server-name"          description:"If set, is used to set the SNI host when connecting via TLS."`
+	Insecure   bool   `mapstructure:"insecure_skip_verify" long:"insecure-skip-verify" description:"Enable insecure SSL verification."`
 }
 
 type AuthConfig struct {
-	ClientToken string `long:"client-token" description:"Client token for accessing secrets within the Vault server."`
+	ClientToken string `mapstructure:"client_token" long:"client-token" description:"Client token for accessing secrets within the Vault server."`
 
-	Backend       string        `long:"auth-backend"               description:"Auth backend to use for logging in to Vault."`
-	BackendMaxTTL time.Duration `long:"auth-backend-max-ttl"       description:"Time after which to force a re-login. If not set, the token will just be continuously renewed."`
-	RetryMax      time.Duration `long:"retry-max"     default:"5m" description:"The maximum time between retries when logging in or re-authing a secret."`
-	RetryInitial  time.Duration `long:"retry-initial" default:"1s" description:"The initial time between retries when logging in or re-authing a secret."`
+	Backend       string        `mapstructure:"auth_backend"       long:"auth-backend"               description:"Auth backend to use for logging in to Vault."`
+	BackendMaxTTL time.Duration `mapstructure:"auth_max_ttl"       long:"auth-backend-max-ttl"       description:"Time after which to force a re-login. If not set, the token will just be continuously renewed."`
+	RetryMax      time.Duration `mapstructure:"auth_retry_max"     long:"retry-max"     default:"5m" description:"The maximum time between retries when logging in or re-authing a secret."`
+	RetryInitial  time.Duration `mapstructure:"auth_retry_initial" long:"retry-initial" default:"1s" description:"The initial time between retries when logging in or re-authing a secret."`
 
-	Params map[string]string `long:"auth-param"  description:"Paramter to pass when logging in via the backend. Can be specified multiple times." value-name:"NAME:VALUE"`
+	Params map[string]string `mapstructure:"auth_params" long:"auth-param"  description:"Paramter to pass when logging in via the backend. Can be specified multiple times." value-name:"NAME:VALUE"`
 }
 
 func (manager *VaultManager) Init(log lager.Logger) error {
@@ -122,5 +122,5 @@ func (manager VaultManager) Health() (*creds.HealthResponse, error) {
 
 func (manager VaultManager) NewSecretsFactory(logger lager.Logger) (creds.SecretsFactory, error) {
 	ra := NewReAuther(manager.Client, manager.Auth.BackendMaxTTL, manager.Auth.RetryInitial, manager.Auth.RetryMax)
-	return NewVaultFactory(manager.Client, ra.LoggedIn(), manager.PathPrefix, manager.SharedPath), nil
+	return NewVaultFactory(logger, manager.Client, manager.Client, ra.LoggedIn(), manager.PathPrefix, manager.SharedPath), nil
 }
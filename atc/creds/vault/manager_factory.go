@@ -1,8 +1,11 @@
 package vault
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc/creds"
 	flags "github.com/jessevdk/go-flags"
+	"github.com/mitchellh/mapstructure"
 )
 
 type vaultManagerFactory struct{}
@@ -27,3 +30,29 @@ func (factory *vaultManagerFactory) AddConfig(group *flags.Group) creds.Manager
 
 	return manager
 }
+
+func (factory *vaultManagerFactory) NewInstance(config interface{}) (creds.Manager, error) {
+	manager := &VaultManager{
+		PathPrefix: "/concourse",
+		Auth: AuthConfig{
+			RetryMax:     5 * time.Minute,
+			RetryInitial: time.Second,
+		},
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:  mapstructure.StringToTimeDurationHookFunc(),
+		ErrorUnused: true,
+		Result:      manager,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = decoder.Decode(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
@@ -1,6 +1,8 @@
 package vault_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc/creds/vault"
 	"github.com/jessevdk/go-flags"
 
@@ -56,4 +58,57 @@ var _ = Describe("VaultManager", func() {
 			Expect(manager.Validate()).ToNot(BeNil())
 		})
 	})
+
+	Describe("NewInstance()", func() {
+		var (
+			config map[string]interface{}
+
+			instance *vault.VaultManager
+			err      error
+		)
+
+		BeforeEach(func() {
+			config = map[string]interface{}{
+				"url":                  "http://vault",
+				"client_token":         "some-token",
+				"shared_path":          "some-shared-path",
+				"ca_cert":              "some-ca-cert",
+				"insecure_skip_verify": true,
+				"auth_max_ttl":         "1h",
+			}
+		})
+
+		JustBeforeEach(func() {
+			var manager interface{}
+			manager, err = vault.NewVaultManagerFactory().NewInstance(config)
+			instance, _ = manager.(*vault.VaultManager)
+		})
+
+		It("configures the manager from the config", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instance.URL).To(Equal("http://vault"))
+			Expect(instance.SharedPath).To(Equal("some-shared-path"))
+			Expect(instance.TLS.CACert).To(Equal("some-ca-cert"))
+			Expect(instance.TLS.Insecure).To(BeTrue())
+			Expect(instance.Auth.ClientToken).To(Equal("some-token"))
+			Expect(instance.Auth.BackendMaxTTL).To(Equal(time.Hour))
+			Expect(instance.Validate()).To(Succeed())
+		})
+
+		It("uses the same defaults as the flags", func() {
+			Expect(instance.PathPrefix).To(Equal("/concourse"))
+			Expect(instance.Auth.RetryMax).To(Equal(5 * time.Minute))
+			Expect(instance.Auth.RetryInitial).To(Equal(time.Second))
+		})
+
+		Context("when the config has an unknown key", func() {
+			BeforeEach(func() {
+				config["bogus"] = "value"
+			})
+
+			It("errors", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
@@ -2,8 +2,11 @@ package vault
 
 import (
 	"path"
+	"strconv"
+	"strings"
 	"time"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/creds"
 
 	vaultapi "github.com/hashicorp/vault/api"
@@ -13,12 +16,20 @@ import (
 // be thread safe!
 type SecretReader interface {
 	Read(path string) (*vaultapi.Secret, error)
+
+	// ReadVersion reads the given version of a versioned (KV v2) secret.
+	ReadVersion(path string, version int) (*vaultapi.Secret, error)
 }
 
 // Vault converts a vault secret to our completely untyped secret
 // data.
+//
+// A specific version of a versioned secret can be selected by suffixing the
+// var's path with @ and the version, e.g. ((some-secret@3.field)).
 type Vault struct {
+	Logger       lager.Logger
 	SecretReader SecretReader
+	LeaseManager LeaseManager
 	Prefix       string
 	SharedPath   string
 }
@@ -35,6 +46,10 @@ func (v Vault) NewSecretLookupPaths(teamName string, pipelineName string) []cred
 }
 
 // Get retrieves the value and expiration of an individual secret
+//
+// Leased secrets, e.g. dynamic database credentials, expire immediately so
+// that they are never cached: their lease may be revoked at any time. Builds
+// fetch them through NewLeases instead, which keeps their leases alive.
 func (v Vault) Get(secretPath string) (interface{}, *time.Time, bool, error) {
 	secret, expiration, found, err := v.findSecret(secretPath)
 	if err != nil {
@@ -44,21 +59,43 @@ func (v Vault) Get(secretPath string) (interface{}, *time.Time, bool, error) {
 		return nil, nil, false, nil
 	}
 
+	if secret.LeaseID != "" {
+		now := time.Now()
+		expiration = &now
+	}
+
+	return secretValue(secret), expiration, true, nil
+}
+
+func secretValue(secret *vaultapi.Secret) interface{} {
 	val, found := secret.Data["value"]
 	if found {
-		return val, expiration, true, nil
+		return val
 	}
 
-	return secret.Data, expiration, true, nil
+	return secret.Data
 }
 
-func (v Vault) findSecret(path string) (*vaultapi.Secret, *time.Time, bool, error) {
-	secret, err := v.SecretReader.Read(path)
+func (v Vault) findSecret(secretPath string) (*vaultapi.Secret, *time.Time, bool, error) {
+	var secret *vaultapi.Secret
+	var err error
+
+	secretPath, version := splitVersion(secretPath)
+	if version != 0 {
+		secret, err = v.SecretReader.ReadVersion(secretPath, version)
+	} else {
+		secret, err = v.SecretReader.Read(secretPath)
+	}
 	if err != nil {
 		return nil, nil, false, err
 	}
 
 	if secret != nil {
+		// versioned secrets have no lease duration and never expire
+		if secret.LeaseDuration == 0 {
+			return secret, nil, true, nil
+		}
+
 		// The lease duration is TTL: the time in seconds for which the lease is valid
 		// A consumer of this secret must renew the lease within that time.
 		duration := time.Duration(secret.LeaseDuration) * time.Second / 2
@@ -68,3 +105,19 @@ func (v Vault) findSecret(path string) (*vaultapi.Secret, *time.Time, bool, erro
 
 	return nil, nil, false, nil
 }
+
+// splitVersion splits the version selector off of the secret path. Paths
+// without a valid version selector are left as-is.
+func splitVersion(secretPath string) (string, int) {
+	i := strings.LastIndex(secretPath, "@")
+	if i == -1 {
+		return secretPath, 0
+	}
+
+	version, err := strconv.Atoi(secretPath[i+1:])
+	if err != nil || version <= 0 {
+		return secretPath, 0
+	}
+
+	return secretPath[:i], version
+}
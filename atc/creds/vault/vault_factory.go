@@ -3,20 +3,25 @@ package vault
 import (
 	"time"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/creds"
 )
 
 // The vaultFactory will return a vault implementation of vars.Variables.
 type vaultFactory struct {
+	logger     lager.Logger
 	sr         SecretReader
+	lm         LeaseManager
 	prefix     string
 	sharedPath string
 	loggedIn   <-chan struct{}
 }
 
-func NewVaultFactory(sr SecretReader, loggedIn <-chan struct{}, prefix string, sharedPath string) *vaultFactory {
+func NewVaultFactory(logger lager.Logger, sr SecretReader, lm LeaseManager, loggedIn <-chan struct{}, prefix string, sharedPath string) *vaultFactory {
 	factory := &vaultFactory{
+		logger:     logger,
 		sr:         sr,
+		lm:         lm,
 		prefix:     prefix,
 		sharedPath: sharedPath,
 		loggedIn:   loggedIn,
@@ -33,7 +38,9 @@ func (factory *vaultFactory) NewSecrets() creds.Secrets {
 	}
 
 	return &Vault{
+		Logger:       factory.logger,
 		SecretReader: factory.sr,
+		LeaseManager: factory.lm,
 		Prefix:       factory.prefix,
 		SharedPath:   factory.sharedPath,
 	}
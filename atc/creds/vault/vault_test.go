@@ -1,6 +1,10 @@
 package vault_test
 
 import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/vault"
 	"github.com/concourse/concourse/vars"
@@ -10,19 +14,27 @@ import (
 )
 
 type MockSecret struct {
-	path   string
-	secret *vaultapi.Secret
+	path    string
+	version int
+	secret  *vaultapi.Secret
 }
 
 type MockSecretReader struct {
 	secrets *[]MockSecret
+	reads   int
 }
 
 func (msr *MockSecretReader) Read(lookupPath string) (*vaultapi.Secret, error) {
+	return msr.ReadVersion(lookupPath, 0)
+}
+
+func (msr *MockSecretReader) ReadVersion(lookupPath string, version int) (*vaultapi.Secret, error) {
 	Expect(lookupPath).ToNot(BeNil())
 
+	msr.reads++
+
 	for _, secret := range *msr.secrets {
-		if lookupPath == secret.path {
+		if lookupPath == secret.path && version == secret.version {
 			return secret.secret, nil
 		}
 	}
@@ -30,15 +42,58 @@ func (msr *MockSecretReader) Read(lookupPath string) (*vaultapi.Secret, error) {
 	return nil, nil
 }
 
+type MockLeaseManager struct {
+	lock    sync.Mutex
+	renewed []string
+	revoked []string
+}
+
+func (mlm *MockLeaseManager) RenewLease(leaseID string, increment time.Duration) (*vaultapi.Secret, error) {
+	mlm.lock.Lock()
+	defer mlm.lock.Unlock()
+
+	mlm.renewed = append(mlm.renewed, leaseID)
+
+	return &vaultapi.Secret{
+		LeaseID:       leaseID,
+		LeaseDuration: int(increment.Seconds()),
+		Renewable:     true,
+	}, nil
+}
+
+func (mlm *MockLeaseManager) RevokeLease(leaseID string) error {
+	mlm.lock.Lock()
+	defer mlm.lock.Unlock()
+
+	mlm.revoked = append(mlm.revoked, leaseID)
+
+	return nil
+}
+
+func (mlm *MockLeaseManager) Renewed() []string {
+	mlm.lock.Lock()
+	defer mlm.lock.Unlock()
+
+	return mlm.renewed
+}
+
+func (mlm *MockLeaseManager) Revoked() []string {
+	mlm.lock.Lock()
+	defer mlm.lock.Unlock()
+
+	return mlm.revoked
+}
+
 var _ = Describe("Vault", func() {
 
 	var v *vault.Vault
 	var variables vars.Variables
 	var msr *MockSecretReader
+	var mlm *MockLeaseManager
 
 	JustBeforeEach(func() {
 
-		msr = &MockSecretReader{&[]MockSecret{
+		msr = &MockSecretReader{secrets: &[]MockSecret{
 			{
 				path: "/concourse/team",
 				secret: &vaultapi.Secret{
@@ -47,8 +102,12 @@ var _ = Describe("Vault", func() {
 			}},
 		}
 
+		mlm = &MockLeaseManager{}
+
 		v = &vault.Vault{
+			Logger:       lagertest.NewTestLogger("test"),
 			SecretReader: msr,
+			LeaseManager: mlm,
 			Prefix:       "/concourse",
 			SharedPath:   "shared",
 		}
@@ -58,7 +117,7 @@ var _ = Describe("Vault", func() {
 
 	Describe("Get()", func() {
 		It("should get secret from pipeline", func() {
-			v.SecretReader = &MockSecretReader{&[]MockSecret{
+			v.SecretReader = &MockSecretReader{secrets: &[]MockSecret{
 				{
 					path: "/concourse/team/pipeline/foo",
 					secret: &vaultapi.Secret{
@@ -73,7 +132,7 @@ var _ = Describe("Vault", func() {
 		})
 
 		It("should get secret from team", func() {
-			v.SecretReader = &MockSecretReader{&[]MockSecret{
+			v.SecretReader = &MockSecretReader{secrets: &[]MockSecret{
 				{
 					path: "/concourse/team/foo",
 					secret: &vaultapi.Secret{
@@ -88,7 +147,7 @@ var _ = Describe("Vault", func() {
 		})
 
 		It("should get secret from shared", func() {
-			v.SecretReader = &MockSecretReader{&[]MockSecret{
+			v.SecretReader = &MockSecretReader{secrets: &[]MockSecret{
 				{
 					path: "/concourse/shared/foo",
 					secret: &vaultapi.Secret{
@@ -103,7 +162,7 @@ var _ = Describe("Vault", func() {
 		})
 
 		It("should get secret from pipeline even its in shared", func() {
-			v.SecretReader = &MockSecretReader{&[]MockSecret{
+			v.SecretReader = &MockSecretReader{secrets: &[]MockSecret{
 				{
 					path: "/concourse/shared/foo",
 					secret: &vaultapi.Secret{
@@ -122,5 +181,132 @@ var _ = Describe("Vault", func() {
 			Expect(found).To(BeTrue())
 			Expect(err).To(BeNil())
 		})
+
+		It("should get a specific version of a secret", func() {
+			v.SecretReader = &MockSecretReader{secrets: &[]MockSecret{
+				{
+					path: "/concourse/team/pipeline/foo",
+					secret: &vaultapi.Secret{
+						Data: map[string]interface{}{"value": "new"},
+					},
+				},
+				{
+					path:    "/concourse/team/pipeline/foo",
+					version: 2,
+					secret: &vaultapi.Secret{
+						Data: map[string]interface{}{"value": "old"},
+					},
+				}},
+			}
+
+			value, found, err := variables.Get(vars.VariableDefinition{Name: "foo@2"})
+			Expect(value).To(BeEquivalentTo("old"))
+			Expect(found).To(BeTrue())
+			Expect(err).To(BeNil())
+
+			value, found, err = variables.Get(vars.VariableDefinition{Name: "foo"})
+			Expect(value).To(BeEquivalentTo("new"))
+			Expect(found).To(BeTrue())
+			Expect(err).To(BeNil())
+		})
+
+		It("should not expire secrets without a lease duration", func() {
+			v.SecretReader = &MockSecretReader{secrets: &[]MockSecret{
+				{
+					path: "/concourse/team/foo",
+					secret: &vaultapi.Secret{
+						Data: map[string]interface{}{"value": "bar"},
+					},
+				}},
+			}
+
+			_, expiration, found, err := v.Get("/concourse/team/foo")
+			Expect(err).To(BeNil())
+			Expect(found).To(BeTrue())
+			Expect(expiration).To(BeNil())
+		})
+
+		It("should expire leased secrets right away", func() {
+			v.SecretReader = &MockSecretReader{secrets: &[]MockSecret{
+				{
+					path: "/concourse/team/foo",
+					secret: &vaultapi.Secret{
+						LeaseID:       "some-lease",
+						LeaseDuration: 60,
+						Data:          map[string]interface{}{"value": "bar"},
+					},
+				}},
+			}
+
+			_, expiration, found, err := v.Get("/concourse/team/foo")
+			Expect(err).To(BeNil())
+			Expect(found).To(BeTrue())
+			Expect(*expiration).ToNot(BeTemporally(">", time.Now()))
+		})
+	})
+
+	Describe("NewLeases()", func() {
+		var leases creds.Leases
+		var leasedReader *MockSecretReader
+
+		JustBeforeEach(func() {
+			leasedReader = &MockSecretReader{secrets: &[]MockSecret{
+				{
+					path: "/concourse/team/leased",
+					secret: &vaultapi.Secret{
+						LeaseID:       "some-lease",
+						LeaseDuration: 2,
+						Renewable:     true,
+						Data:          map[string]interface{}{"username": "some-user"},
+					},
+				},
+				{
+					path: "/concourse/team/static",
+					secret: &vaultapi.Secret{
+						Data: map[string]interface{}{"value": "bar"},
+					},
+				}},
+			}
+
+			v.SecretReader = leasedReader
+			leases = v.NewLeases()
+		})
+
+		AfterEach(func() {
+			leases.RevokeAll()
+		})
+
+		It("should read each leased secret once", func() {
+			value, _, found, err := leases.Get("/concourse/team/leased")
+			Expect(err).To(BeNil())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[string]interface{}{"username": "some-user"}))
+
+			value, _, found, err = leases.Get("/concourse/team/leased")
+			Expect(err).To(BeNil())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[string]interface{}{"username": "some-user"}))
+
+			Expect(leasedReader.reads).To(Equal(1))
+		})
+
+		It("should renew the leases", func() {
+			_, _, _, err := leases.Get("/concourse/team/leased")
+			Expect(err).To(BeNil())
+
+			Eventually(mlm.Renewed, 5*time.Second).Should(ContainElement("some-lease"))
+		})
+
+		It("should revoke the leases", func() {
+			_, _, _, err := leases.Get("/concourse/team/leased")
+			Expect(err).To(BeNil())
+
+			_, _, _, err = leases.Get("/concourse/team/static")
+			Expect(err).To(BeNil())
+
+			leases.RevokeAll()
+
+			Expect(mlm.Revoked()).To(Equal([]string{"some-lease"}))
+		})
 	})
 })
@@ -43,7 +43,7 @@ const (
 	BuildStatusErrored   BuildStatus = "errored"
 )
 
-var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.schema, b.private_plan, b.public_plan, b.create_time, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.drained, b.aborted, b.completed").
+var buildsQuery = psql.Select("b.id, b.name, b.job_id, b.team_id, b.status, b.manually_triggered, b.scheduled, b.schema, b.private_plan, b.public_plan, b.create_time, b.start_time, b.end_time, b.reap_time, j.name, b.pipeline_id, p.name, t.name, b.nonce, b.drained, b.aborted, b.completed, b.test_summary, b.priority, b.created_by, b.rerun_of, p.instance_vars").
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
 	JoinClause("LEFT OUTER JOIN pipelines p ON b.pipeline_id = p.id").
@@ -61,6 +61,7 @@ type Build interface {
 	JobName() string
 	PipelineID() int
 	PipelineName() string
+	PipelineInstanceVars() atc.InstanceVars
 	TeamID() int
 	TeamName() string
 	Schema() string
@@ -76,6 +77,10 @@ type Build interface {
 	IsScheduled() bool
 	IsRunning() bool
 	IsCompleted() bool
+	TestSummary() *atc.TestSummary
+	Priority() int
+	CreatedBy() *atc.BuildCreator
+	RerunOf() int
 
 	Reload() (bool, error)
 
@@ -88,6 +93,8 @@ type Build interface {
 	Finish(BuildStatus) error
 
 	SetInterceptible(bool) error
+	SetPriority(int) error
+	SetCreatedBy(atc.BuildCreator) error
 
 	Events(uint) (EventSource, error)
 	SaveEvent(event atc.Event) error
@@ -95,8 +102,23 @@ type Build interface {
 	Artifacts() ([]WorkerArtifact, error)
 	Artifact(artifactID int) (WorkerArtifact, error)
 
+	SaveArchivedArtifact(planID atc.PlanID, name string, size int64) error
+	ArchivedArtifacts() ([]ArchivedArtifact, error)
+	DeleteArchivedArtifacts() error
+
+	SaveTestResults([]atc.TestResult) error
+	TestResults() ([]atc.TestResult, error)
+
+	RequestApproval(atc.PlanID, atc.ApprovalPlan, time.Time) (atc.BuildApproval, error)
+	Approval(atc.PlanID) (atc.BuildApproval, bool, error)
+	Approvals() ([]atc.BuildApproval, error)
+	DecideApproval(name string, status atc.BuildApprovalStatus, decidedBy string, comment string) (bool, error)
+	ExpireApproval(atc.PlanID) (bool, error)
+	ApprovalNotifier(atc.PlanID) (Notifier, error)
+
 	SaveOutput(string, atc.Source, atc.VersionedResourceTypes, atc.Version, ResourceConfigMetadataFields, string, string) error
 	UseInputs(inputs []BuildInput) error
+	RerunInputs() ([]BuildInput, bool, error)
 
 	Resources() ([]BuildInput, []BuildOutput, error)
 	SaveImageResourceVersion(UsedResourceCache) error
@@ -107,7 +129,7 @@ type Build interface {
 	MarkAsAborted() error
 	IsAborted() bool
 	AbortNotifier() (Notifier, error)
-	Schedule() (bool, error)
+	Schedule([]atc.SemaphoreConfig) (bool, error)
 
 	IsDrained() bool
 	SetDrained(bool) error
@@ -122,10 +144,11 @@ type build struct {
 	teamID   int
 	teamName string
 
-	pipelineID   int
-	pipelineName string
-	jobID        int
-	jobName      string
+	pipelineID           int
+	pipelineName         string
+	pipelineInstanceVars atc.InstanceVars
+	jobID                int
+	jobName              string
 
 	isManuallyTriggered bool
 
@@ -143,6 +166,21 @@ type build struct {
 	drained     bool
 	aborted     bool
 	completed   bool
+
+	testSummary *atc.TestSummary
+	priority    int
+	createdBy   *atc.BuildCreator
+	rerunOf     int
+}
+
+// ArchivedArtifact is an output of a build which has been persisted to the
+// artifact store, so that it outlives the volume it was produced in. It is
+// identified by its name along with the plan of the step which produced it.
+type ArchivedArtifact struct {
+	PlanID    atc.PlanID
+	Name      string
+	Size      int64
+	CreatedAt time.Time
 }
 
 var ErrBuildDisappeared = errors.New("build disappeared from db")
@@ -158,19 +196,20 @@ func (r ResourceNotFoundInPipeline) Error() string {
 	return fmt.Sprintf("resource %s not found in pipeline %s", r.Resource, r.Pipeline)
 }
 
-func (b *build) ID() int                      { return b.id }
-func (b *build) Name() string                 { return b.name }
-func (b *build) JobID() int                   { return b.jobID }
-func (b *build) JobName() string              { return b.jobName }
-func (b *build) PipelineID() int              { return b.pipelineID }
-func (b *build) PipelineName() string         { return b.pipelineName }
-func (b *build) TeamID() int                  { return b.teamID }
-func (b *build) TeamName() string             { return b.teamName }
-func (b *build) IsManuallyTriggered() bool    { return b.isManuallyTriggered }
-func (b *build) Schema() string               { return b.schema }
-func (b *build) PrivatePlan() atc.Plan        { return b.privatePlan }
-func (b *build) PublicPlan() *json.RawMessage { return b.publicPlan }
-func (b *build) HasPlan() bool                { return string(*b.publicPlan) != "{}" }
+func (b *build) ID() int                                { return b.id }
+func (b *build) Name() string                           { return b.name }
+func (b *build) JobID() int                             { return b.jobID }
+func (b *build) JobName() string                        { return b.jobName }
+func (b *build) PipelineID() int                        { return b.pipelineID }
+func (b *build) PipelineName() string                   { return b.pipelineName }
+func (b *build) PipelineInstanceVars() atc.InstanceVars { return b.pipelineInstanceVars }
+func (b *build) TeamID() int                            { return b.teamID }
+func (b *build) TeamName() string                       { return b.teamName }
+func (b *build) IsManuallyTriggered() bool              { return b.isManuallyTriggered }
+func (b *build) Schema() string                         { return b.schema }
+func (b *build) PrivatePlan() atc.Plan                  { return b.privatePlan }
+func (b *build) PublicPlan() *json.RawMessage           { return b.publicPlan }
+func (b *build) HasPlan() bool                          { return string(*b.publicPlan) != "{}" }
 func (b *build) IsNewerThanLastCheckOf(input Resource) bool {
 	return b.createTime.After(input.LastCheckEndTime())
 }
@@ -184,6 +223,11 @@ func (b *build) IsRunning() bool      { return !b.completed }
 func (b *build) IsAborted() bool      { return b.aborted }
 func (b *build) IsCompleted() bool    { return b.completed }
 
+func (b *build) TestSummary() *atc.TestSummary { return b.testSummary }
+func (b *build) Priority() int                 { return b.priority }
+func (b *build) CreatedBy() *atc.BuildCreator  { return b.createdBy }
+func (b *build) RerunOf() int                  { return b.rerunOf }
+
 func (b *build) Reload() (bool, error) {
 	row := buildsQuery.Where(sq.Eq{"b.id": b.id}).
 		RunWith(b.conn).
@@ -242,6 +286,67 @@ func (b *build) SetInterceptible(i bool) error {
 	return nil
 }
 
+// SetPriority overrides the priority the build was created with, e.g. when
+// manually triggering a build which should skip the queue.
+func (b *build) SetPriority(priority int) error {
+	rows, err := psql.Update("builds").
+		Set("priority", priority).
+		Where(sq.Eq{
+			"id": b.id,
+		}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrBuildDisappeared
+	}
+
+	b.priority = priority
+
+	return nil
+}
+
+// SetCreatedBy records why the build was created, e.g. to add the user who
+// manually triggered it.
+func (b *build) SetCreatedBy(createdBy atc.BuildCreator) error {
+	payload, err := json.Marshal(createdBy)
+	if err != nil {
+		return err
+	}
+
+	rows, err := psql.Update("builds").
+		Set("created_by", string(payload)).
+		Where(sq.Eq{
+			"id": b.id,
+		}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrBuildDisappeared
+	}
+
+	b.createdBy = &createdBy
+
+	return nil
+}
+
 func (b *build) Start(plan atc.Plan) (bool, error) {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -260,7 +365,10 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 		return false, err
 	}
 
-	var startTime time.Time
+	var (
+		startTime time.Time
+		createdBy sql.NullString
+	)
 
 	err = psql.Update("builds").
 		Set("status", BuildStatusStarted).
@@ -274,10 +382,10 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 			"status":  "pending",
 			"aborted": false,
 		}).
-		Suffix("RETURNING start_time").
+		Suffix("RETURNING start_time, created_by").
 		RunWith(tx).
 		QueryRow().
-		Scan(&startTime)
+		Scan(&startTime, &createdBy)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, nil
@@ -285,9 +393,17 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 		return false, err
 	}
 
-	err = b.saveEvent(tx, event.Status{
-		Status: atc.StatusStarted,
-		Time:   startTime.Unix(),
+	if createdBy.Valid {
+		err = json.Unmarshal([]byte(createdBy.String), &b.createdBy)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	saved, err := b.saveEvent(tx, event.Status{
+		Status:    atc.StatusStarted,
+		Time:      startTime.Unix(),
+		CreatedBy: b.createdBy,
 	})
 	if err != nil {
 		return false, err
@@ -305,7 +421,7 @@ func (b *build) Start(plan atc.Plan) (bool, error) {
 		return false, err
 	}
 
-	err = b.conn.Bus().Notify(buildEventsChannel(b.id))
+	err = b.flushEvent(saved)
 	if err != nil {
 		return false, err
 	}
@@ -338,7 +454,7 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
-	err = b.saveEvent(tx, event.Status{
+	saved, err := b.saveEvent(tx, event.Status{
 		Status: atc.BuildStatus(status),
 		Time:   endTime.Unix(),
 	})
@@ -353,6 +469,16 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
+	err = releaseSemaphores(tx, b.id)
+	if err != nil {
+		return err
+	}
+
+	err = expirePendingApprovals(tx, b.id)
+	if err != nil {
+		return err
+	}
+
 	if b.jobID != 0 && status == BuildStatusSucceeded {
 		_, err = psql.Delete("build_image_resource_caches birc USING builds b").
 			Where(sq.Expr("birc.build_id = b.id")).
@@ -392,7 +518,7 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
-	err = b.conn.Bus().Notify(buildEventsChannel(b.id))
+	err = b.flushEvent(saved)
 	if err != nil {
 		return err
 	}
@@ -472,11 +598,30 @@ func (b *build) AbortNotifier() (Notifier, error) {
 	})
 }
 
-func (b *build) Schedule() (bool, error) {
+// Schedule marks the build as scheduled, making it a holder of the given
+// semaphores in the same transaction. If any of the semaphores is
+// unavailable the build is left unscheduled.
+func (b *build) Schedule(semaphores []atc.SemaphoreConfig) (bool, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	acquired, err := acquireSemaphores(tx, b.teamID, b.id, semaphores)
+	if err != nil {
+		return false, err
+	}
+
+	if !acquired {
+		return false, nil
+	}
+
 	result, err := psql.Update("builds").
 		Set("scheduled", true).
 		Where(sq.Eq{"id": b.id}).
-		RunWith(b.conn).
+		RunWith(tx).
 		Exec()
 	if err != nil {
 		return false, err
@@ -487,7 +632,16 @@ func (b *build) Schedule() (bool, error) {
 		return false, err
 	}
 
-	return rows == 1, nil
+	if rows != 1 {
+		return false, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 func (b *build) Pipeline() (Pipeline, bool, error) {
@@ -597,17 +751,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		maxInFlightReachedStatus = BuildPreparationStatusBlocking
 	}
 
-	tf := NewTeamFactory(b.conn, b.lockFactory)
-	t, found, err := tf.FindTeam(b.teamName)
-	if err != nil {
-		return BuildPreparation{}, false, err
-	}
-
-	if !found {
-		return BuildPreparation{}, false, nil
-	}
-
-	pipeline, found, err := t.Pipeline(b.pipelineName)
+	pipeline, found, err := b.Pipeline()
 	if err != nil {
 		return BuildPreparation{}, false, err
 	}
@@ -745,18 +889,13 @@ func (b *build) Events(from uint) (EventSource, error) {
 		return nil, err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	source, err := b.conn.EventStore().Get(b, from, notifier)
+	if err != nil {
+		_ = notifier.Close()
+		return nil, err
 	}
 
-	return newBuildEventSource(
-		b.id,
-		table,
-		b.conn,
-		notifier,
-		from,
-	), nil
+	return source, nil
 }
 
 func (b *build) SaveEvent(event atc.Event) error {
@@ -767,7 +906,7 @@ func (b *build) SaveEvent(event atc.Event) error {
 
 	defer Rollback(tx)
 
-	err = b.saveEvent(tx, event)
+	saved, err := b.saveEvent(tx, event)
 	if err != nil {
 		return err
 	}
@@ -777,7 +916,7 @@ func (b *build) SaveEvent(event atc.Event) error {
 		return err
 	}
 
-	return b.conn.Bus().Notify(buildEventsChannel(b.id))
+	return b.flushEvent(saved)
 }
 
 func (b *build) Artifact(artifactID int) (WorkerArtifact, error) {
@@ -830,6 +969,154 @@ func (b *build) Artifacts() ([]WorkerArtifact, error) {
 	return artifacts, nil
 }
 
+func (b *build) SaveArchivedArtifact(planID atc.PlanID, name string, size int64) error {
+	_, err := psql.Insert("archived_artifacts").
+		Columns("build_id", "plan_id", "name", "size").
+		Values(b.id, string(planID), name, size).
+		Suffix("ON CONFLICT (build_id, plan_id, name) DO UPDATE SET size = EXCLUDED.size, created_at = now()").
+		RunWith(b.conn).
+		Exec()
+
+	return err
+}
+
+func (b *build) ArchivedArtifacts() ([]ArchivedArtifact, error) {
+	rows, err := psql.Select("plan_id", "name", "size", "created_at").
+		From("archived_artifacts").
+		Where(sq.Eq{
+			"build_id": b.id,
+		}).
+		OrderBy("name", "plan_id").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	artifacts := []ArchivedArtifact{}
+	for rows.Next() {
+		var artifact ArchivedArtifact
+		err = rows.Scan(&artifact.PlanID, &artifact.Name, &artifact.Size, &artifact.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts, nil
+}
+
+func (b *build) DeleteArchivedArtifacts() error {
+	_, err := psql.Delete("archived_artifacts").
+		Where(sq.Eq{
+			"build_id": b.id,
+		}).
+		RunWith(b.conn).
+		Exec()
+
+	return err
+}
+
+// SaveTestResults records the results of tests run by the build and updates
+// the build's test summary to cover all of its results so far, as a build may
+// run many tasks reporting tests.
+func (b *build) SaveTestResults(results []atc.TestResult) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	var jobID sql.NullInt64
+	if b.jobID != 0 {
+		jobID = sql.NullInt64{Int64: int64(b.jobID), Valid: true}
+	}
+
+	for _, result := range results {
+		_, err = psql.Insert("test_results").
+			Columns("build_id", "job_id", "suite", "name", "status", "duration", "message").
+			Values(b.id, jobID, result.Suite, result.Name, result.Status, result.Duration, result.Message).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	all, err := b.testResults(tx)
+	if err != nil {
+		return err
+	}
+
+	summary := atc.SummarizeTests(all)
+
+	payload, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("builds").
+		Set("test_summary", string(payload)).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	b.testSummary = &summary
+
+	return nil
+}
+
+func (b *build) TestResults() ([]atc.TestResult, error) {
+	return b.testResults(b.conn)
+}
+
+func (b *build) testResults(runner sq.Runner) ([]atc.TestResult, error) {
+	rows, err := psql.Select("suite", "name", "status", "duration", "message").
+		From("test_results").
+		Where(sq.Eq{
+			"build_id": b.id,
+		}).
+		OrderBy("id").
+		RunWith(runner).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	results := []atc.TestResult{}
+	for rows.Next() {
+		var (
+			result  atc.TestResult
+			message sql.NullString
+		)
+
+		err = rows.Scan(&result.Suite, &result.Name, &result.Status, &result.Duration, &message)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Message = message.String
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
 func (b *build) SaveOutput(
 	resourceType string,
 	source atc.Source,
@@ -967,6 +1254,50 @@ func (b *build) UseInputs(inputs []BuildInput) error {
 	return tx.Commit()
 }
 
+// RerunInputs returns the inputs used by the build this build is a rerun of.
+// It returns false if the version of any of them no longer exists.
+func (b *build) RerunInputs() ([]BuildInput, bool, error) {
+	rows, err := psql.Select("inputs.name", "inputs.resource_id", "versions.version").
+		From("build_resource_config_version_inputs inputs").
+		Join("resources ON resources.id = inputs.resource_id").
+		LeftJoin("resource_config_versions versions ON versions.resource_config_scope_id = resources.resource_config_scope_id AND versions.version_md5 = inputs.version_md5").
+		Where(sq.Eq{"inputs.build_id": b.rerunOf}).
+		OrderBy("inputs.name").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, false, err
+	}
+
+	defer Close(rows)
+
+	inputs := []BuildInput{}
+	for rows.Next() {
+		var (
+			input       BuildInput
+			versionBlob sql.NullString
+		)
+
+		err = rows.Scan(&input.Name, &input.ResourceID, &versionBlob)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if !versionBlob.Valid {
+			return nil, false, nil
+		}
+
+		err = json.Unmarshal([]byte(versionBlob.String), &input.Version)
+		if err != nil {
+			return nil, false, err
+		}
+
+		inputs = append(inputs, input)
+	}
+
+	return inputs, true, nil
+}
+
 func (b *build) Resources() ([]BuildInput, []BuildOutput, error) {
 	inputs := []BuildInput{}
 	outputs := []BuildOutput{}
@@ -1106,15 +1437,15 @@ func buildEventSeq(buildid int) string {
 
 func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy) error {
 	var (
-		jobID, pipelineID                                      sql.NullInt64
+		jobID, pipelineID, rerunOf                             sql.NullInt64
 		schema, privatePlan, jobName, pipelineName, publicPlan sql.NullString
 		createTime, startTime, endTime, reapTime               pq.NullTime
-		nonce                                                  sql.NullString
+		nonce, testSummary, createdBy, instanceVars            sql.NullString
 		drained, aborted, completed                            bool
 		status                                                 string
 	)
 
-	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &schema, &privatePlan, &publicPlan, &createTime, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &drained, &aborted, &completed)
+	err := row.Scan(&b.id, &b.name, &jobID, &b.teamID, &status, &b.isManuallyTriggered, &b.scheduled, &schema, &privatePlan, &publicPlan, &createTime, &startTime, &endTime, &reapTime, &jobName, &pipelineID, &pipelineName, &b.teamName, &nonce, &drained, &aborted, &completed, &testSummary, &b.priority, &createdBy, &rerunOf, &instanceVars)
 	if err != nil {
 		return err
 	}
@@ -1132,6 +1463,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.drained = drained
 	b.aborted = aborted
 	b.completed = completed
+	b.rerunOf = int(rerunOf.Int64)
 
 	var (
 		noncense      *string
@@ -1162,25 +1494,61 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		}
 	}
 
+	b.testSummary = nil
+	if testSummary.Valid {
+		err = json.Unmarshal([]byte(testSummary.String), &b.testSummary)
+		if err != nil {
+			return err
+		}
+	}
+
+	b.createdBy = nil
+	if createdBy.Valid {
+		err = json.Unmarshal([]byte(createdBy.String), &b.createdBy)
+		if err != nil {
+			return err
+		}
+	}
+
+	b.pipelineInstanceVars = nil
+	if instanceVars.Valid {
+		err = json.Unmarshal([]byte(instanceVars.String), &b.pipelineInstanceVars)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (b *build) saveEvent(tx Tx, event atc.Event) error {
-	payload, err := json.Marshal(event)
+// saveEvent assigns the event its ID and saves it as part of the transaction.
+// Once the transaction has committed the event must be passed to flushEvent.
+func (b *build) saveEvent(tx Tx, event atc.Event) (BuildEvent, error) {
+	var eventID uint
+	err := tx.QueryRow(`SELECT nextval('` + buildEventSeq(b.id) + `')`).Scan(&eventID)
 	if err != nil {
-		return err
+		return BuildEvent{}, err
 	}
 
-	table := fmt.Sprintf("team_build_events_%d", b.teamID)
-	if b.pipelineID != 0 {
-		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	saved := BuildEvent{EventID: eventID, Event: event}
+
+	err = b.conn.EventStore().Put(tx, b, []BuildEvent{saved})
+	if err != nil {
+		return BuildEvent{}, err
 	}
-	_, err = psql.Insert(table).
-		Columns("event_id", "build_id", "type", "version", "payload").
-		Values(sq.Expr("nextval('"+buildEventSeq(b.id)+"')"), b.id, string(event.EventType()), string(event.Version()), payload).
-		RunWith(tx).
-		Exec()
-	return err
+
+	return saved, nil
+}
+
+// flushEvent hands a saved event to stores outside of Postgres and notifies
+// the build's event watchers.
+func (b *build) flushEvent(saved BuildEvent) error {
+	err := b.conn.EventStore().Flush(b, []BuildEvent{saved})
+	if err != nil {
+		return err
+	}
+
+	return b.conn.Bus().Notify(buildEventsChannel(b.id))
 }
 
 func createBuild(tx Tx, build *build, vals map[string]interface{}) error {
@@ -0,0 +1,298 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+var ErrMultipleApprovalsPending = errors.New("build is waiting for more than one approval")
+
+var buildApprovalsQuery = psql.Select(
+	"a.build_id",
+	"a.plan_id",
+	"a.name",
+	"a.status",
+	"a.allowed_roles",
+	"a.request_time",
+	"a.deadline",
+	"a.decided_by",
+	"a.decide_time",
+	"a.comment",
+).From("build_approvals a")
+
+// RequestApproval records that the approval step with the given plan ID is
+// waiting for a decision, giving up at the deadline unless it is zero.
+//
+// If the step has already requested approval, e.g. before the ATC running
+// the build restarted, the existing request is returned unchanged, keeping
+// its deadline and any decision made since.
+func (b *build) RequestApproval(planID atc.PlanID, plan atc.ApprovalPlan, deadline time.Time) (atc.BuildApproval, error) {
+	var deadlineValue interface{}
+	if !deadline.IsZero() {
+		deadlineValue = deadline
+	}
+
+	allowedRoles := plan.AllowedRoles
+	if allowedRoles == nil {
+		allowedRoles = []string{}
+	}
+
+	_, err := psql.Insert("build_approvals").
+		Columns("build_id", "plan_id", "name", "allowed_roles", "deadline").
+		Values(b.id, string(planID), plan.Name, pq.Array(allowedRoles), deadlineValue).
+		Suffix("ON CONFLICT (build_id, plan_id) DO NOTHING").
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return atc.BuildApproval{}, err
+	}
+
+	approval, found, err := b.Approval(planID)
+	if err != nil {
+		return atc.BuildApproval{}, err
+	}
+
+	if !found {
+		return atc.BuildApproval{}, ErrBuildDisappeared
+	}
+
+	return approval, nil
+}
+
+// Approval returns the approval requested by the step with the given plan
+// ID.
+func (b *build) Approval(planID atc.PlanID) (atc.BuildApproval, bool, error) {
+	row := buildApprovalsQuery.
+		Where(sq.Eq{
+			"a.build_id": b.id,
+			"a.plan_id":  string(planID),
+		}).
+		RunWith(b.conn).
+		QueryRow()
+
+	approval, err := scanBuildApproval(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return atc.BuildApproval{}, false, nil
+		}
+
+		return atc.BuildApproval{}, false, err
+	}
+
+	return approval, true, nil
+}
+
+// Approvals returns the approvals requested by the build's steps, in the
+// order they were requested.
+func (b *build) Approvals() ([]atc.BuildApproval, error) {
+	rows, err := buildApprovalsQuery.
+		Where(sq.Eq{"a.build_id": b.id}).
+		OrderBy("a.request_time ASC", "a.plan_id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	approvals := []atc.BuildApproval{}
+	for rows.Next() {
+		approval, err := scanBuildApproval(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		approvals = append(approvals, approval)
+	}
+
+	return approvals, nil
+}
+
+// DecideApproval approves or rejects the pending approvals of the running
+// build with the given name. If no name is given, the build must be waiting
+// for exactly one approval, otherwise ErrMultipleApprovalsPending is
+// returned.
+//
+// It returns false if the build is not waiting for a matching approval.
+func (b *build) DecideApproval(name string, status atc.BuildApprovalStatus, decidedBy string, comment string) (bool, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	pending := sq.And{
+		sq.Eq{
+			"build_id": b.id,
+			"status":   atc.ApprovalStatusPending,
+		},
+		sq.Expr("EXISTS (SELECT 1 FROM builds WHERE id = build_id AND status = ?)", BuildStatusStarted),
+	}
+
+	if name != "" {
+		pending = append(pending, sq.Eq{"name": name})
+	} else {
+		var names int
+		err = psql.Select("COUNT(DISTINCT name)").
+			From("build_approvals").
+			Where(pending).
+			RunWith(tx).
+			QueryRow().
+			Scan(&names)
+		if err != nil {
+			return false, err
+		}
+
+		if names > 1 {
+			return false, ErrMultipleApprovalsPending
+		}
+	}
+
+	var nullComment sql.NullString
+	if comment != "" {
+		nullComment = sql.NullString{String: comment, Valid: true}
+	}
+
+	result, err := psql.Update("build_approvals").
+		Set("status", status).
+		Set("decided_by", decidedBy).
+		Set("decide_time", sq.Expr("now()")).
+		Set("comment", nullComment).
+		Where(pending).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if rows == 0 {
+		return false, nil
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	return true, b.conn.Bus().Notify(buildApprovalChannel(b.id))
+}
+
+// ExpireApproval gives up on the approval requested by the step with the
+// given plan ID if nobody decided on it yet. It returns false if it has
+// already been decided.
+func (b *build) ExpireApproval(planID atc.PlanID) (bool, error) {
+	result, err := psql.Update("build_approvals").
+		Set("status", atc.ApprovalStatusExpired).
+		Set("decide_time", sq.Expr("now()")).
+		Where(sq.Eq{
+			"build_id": b.id,
+			"plan_id":  string(planID),
+			"status":   atc.ApprovalStatusPending,
+		}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+// ApprovalNotifier returns a Notifier that can be watched for when the
+// approval requested by the step with the given plan ID is decided.
+func (b *build) ApprovalNotifier(planID atc.PlanID) (Notifier, error) {
+	return newConditionNotifier(b.conn.Bus(), buildApprovalChannel(b.id), func() (bool, error) {
+		var decided bool
+		err := psql.Select("status <> 'pending'").
+			From("build_approvals").
+			Where(sq.Eq{
+				"build_id": b.id,
+				"plan_id":  string(planID),
+			}).
+			RunWith(b.conn).
+			QueryRow().
+			Scan(&decided)
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return decided, err
+	})
+}
+
+func expirePendingApprovals(tx Tx, buildID int) error {
+	_, err := psql.Update("build_approvals").
+		Set("status", atc.ApprovalStatusExpired).
+		Set("decide_time", sq.Expr("now()")).
+		Where(sq.Eq{
+			"build_id": buildID,
+			"status":   atc.ApprovalStatusPending,
+		}).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+func buildApprovalChannel(buildID int) string {
+	return fmt.Sprintf("build_approval_%d", buildID)
+}
+
+func scanBuildApproval(row scannable) (atc.BuildApproval, error) {
+	var (
+		approval             atc.BuildApproval
+		planID, status       string
+		requestTime          time.Time
+		deadline, decideTime pq.NullTime
+		decidedBy, comment   sql.NullString
+	)
+
+	err := row.Scan(
+		&approval.BuildID,
+		&planID,
+		&approval.Name,
+		&status,
+		pq.Array(&approval.AllowedRoles),
+		&requestTime,
+		&deadline,
+		&decidedBy,
+		&decideTime,
+		&comment,
+	)
+	if err != nil {
+		return atc.BuildApproval{}, err
+	}
+
+	approval.PlanID = atc.PlanID(planID)
+	approval.Status = atc.BuildApprovalStatus(status)
+	approval.RequestTime = requestTime.Unix()
+	approval.DecidedBy = decidedBy.String
+	approval.Comment = comment.String
+
+	if deadline.Valid {
+		approval.Deadline = deadline.Time.Unix()
+	}
+
+	if decideTime.Valid {
+		approval.DecideTime = decideTime.Time.Unix()
+	}
+
+	return approval, nil
+}
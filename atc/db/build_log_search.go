@@ -0,0 +1,197 @@
+package db
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// BuildLogSearch narrows down a search through a team's build logs.
+type BuildLogSearch struct {
+	Query string
+
+	PipelineName string
+	JobName      string
+	Statuses     []BuildStatus
+
+	Since time.Time
+	Until time.Time
+
+	// PublicOnly limits the search to builds of public jobs in exposed
+	// pipelines.
+	PublicOnly bool
+
+	// Limit is the maximum number of matching log events to return.
+	Limit int
+}
+
+// BuildLogMatch is a build whose logs matched a search, along with the
+// matching lines in the order they were logged.
+type BuildLogMatch struct {
+	Build Build
+	Lines []BuildLogLine
+}
+
+type BuildLogLine struct {
+	EventID uint
+	Time    int64
+	Origin  event.Origin
+	Line    string
+}
+
+// SearchBuildLogs finds the team's builds whose logs contain the words in
+// the search query, most recent build first.
+//
+// Only logs kept in the Postgres build events tables can be searched; the
+// tables are indexed for full-text search over log event payloads. With any
+// other EventStore, ErrBuildEventsNotInPostgres is returned.
+func (t *team) SearchBuildLogs(search BuildLogSearch) ([]BuildLogMatch, error) {
+	if !storesEventsInPostgres(t.conn) {
+		return nil, ErrBuildEventsNotInPostgres
+	}
+
+	query := psql.Select("e.build_id, e.event_id, e.payload").
+		From("build_events e").
+		Join("builds b ON b.id = e.build_id").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		LeftJoin("pipelines p ON p.id = b.pipeline_id").
+		Where(sq.Eq{
+			"b.team_id": t.id,
+			"e.type":    string(event.EventTypeLog),
+		}).
+		Where("to_tsvector('simple', e.payload::json ->> 'payload') @@ plainto_tsquery('simple', ?)", search.Query).
+		OrderBy("e.build_id DESC", "e.event_id ASC")
+
+	if search.PipelineName != "" {
+		query = query.Where(sq.Eq{"p.name": search.PipelineName})
+	}
+
+	if search.JobName != "" {
+		query = query.Where(sq.Eq{"j.name": search.JobName})
+	}
+
+	if len(search.Statuses) > 0 {
+		statuses := make([]string, len(search.Statuses))
+		for i, status := range search.Statuses {
+			statuses[i] = string(status)
+		}
+
+		query = query.Where(sq.Eq{"b.status": statuses})
+	}
+
+	if !search.Since.IsZero() {
+		query = query.Where(sq.GtOrEq{"b.start_time": search.Since})
+	}
+
+	if !search.Until.IsZero() {
+		query = query.Where(sq.LtOrEq{"b.start_time": search.Until})
+	}
+
+	if search.PublicOnly {
+		query = query.Where(sq.Eq{
+			"p.public": true,
+			"j.public": true,
+		})
+	}
+
+	if search.Limit > 0 {
+		query = query.Limit(uint64(search.Limit))
+	}
+
+	rows, err := query.RunWith(t.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	terms := strings.Fields(strings.ToLower(search.Query))
+
+	buildIDs := []int{}
+	lines := map[int][]BuildLogLine{}
+
+	for rows.Next() {
+		var (
+			buildID int
+			eventID uint
+			payload string
+		)
+
+		err = rows.Scan(&buildID, &eventID, &payload)
+		if err != nil {
+			return nil, err
+		}
+
+		var log event.Log
+		err = json.Unmarshal([]byte(payload), &log)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, seen := lines[buildID]; !seen {
+			buildIDs = append(buildIDs, buildID)
+			lines[buildID] = []BuildLogLine{}
+		}
+
+		for _, line := range matchingLines(log.Payload, terms) {
+			lines[buildID] = append(lines[buildID], BuildLogLine{
+				EventID: eventID,
+				Time:    log.Time,
+				Origin:  log.Origin,
+				Line:    line,
+			})
+		}
+	}
+
+	if len(buildIDs) == 0 {
+		return []BuildLogMatch{}, nil
+	}
+
+	builds, err := getBuilds(buildsQuery.Where(sq.Eq{"b.id": buildIDs}), t.conn, t.lockFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	buildsByID := map[int]Build{}
+	for _, build := range builds {
+		buildsByID[build.ID()] = build
+	}
+
+	matches := []BuildLogMatch{}
+	for _, buildID := range buildIDs {
+		build, found := buildsByID[buildID]
+		if !found {
+			continue
+		}
+
+		matches = append(matches, BuildLogMatch{
+			Build: build,
+			Lines: lines[buildID],
+		})
+	}
+
+	return matches, nil
+}
+
+// matchingLines returns the lines of a log payload which contain any of the
+// (lowercased) search terms.
+func matchingLines(payload string, terms []string) []string {
+	matching := []string{}
+
+	for _, line := range strings.Split(payload, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		lower := strings.ToLower(line)
+		for _, term := range terms {
+			if strings.Contains(lower, term) {
+				matching = append(matching, line)
+				break
+			}
+		}
+	}
+
+	return matching
+}
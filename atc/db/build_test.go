@@ -2,11 +2,14 @@ package db_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/algorithm"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -47,6 +50,83 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SetPriority", func() {
+		It("updates the build's priority", func() {
+			build, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.Priority()).To(Equal(0))
+
+			err = build.SetPriority(10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.Priority()).To(Equal(10))
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.Priority()).To(Equal(10))
+		})
+	})
+
+	Describe("SetCreatedBy", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("defaults to a manual trigger for manually created job builds", func() {
+			Expect(build.CreatedBy()).To(Equal(&atc.BuildCreator{Type: atc.BuildCreatorManual}))
+		})
+
+		It("records why the build was created", func() {
+			err := build.SetCreatedBy(atc.BuildCreator{
+				Type: atc.BuildCreatorManual,
+				User: "some-user",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.CreatedBy()).To(Equal(&atc.BuildCreator{
+				Type: atc.BuildCreatorManual,
+				User: "some-user",
+			}))
+		})
+
+		It("includes it in the build's start event", func() {
+			err := build.SetCreatedBy(atc.BuildCreator{
+				Type: atc.BuildCreatorManual,
+				User: "some-user",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			events, err := build.Events(0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			Expect(events.Next()).To(Equal(envelope(event.Status{
+				Status: atc.StatusStarted,
+				Time:   build.StartTime().Unix(),
+				CreatedBy: &atc.BuildCreator{
+					Type: atc.BuildCreatorManual,
+					User: "some-user",
+				},
+			})))
+		})
+	})
+
 	Describe("Drain", func() {
 		It("defaults drain to false in the beginning", func() {
 			build, err := team.CreateOneOffBuild()
@@ -242,6 +322,323 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("TestResults", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("has no results or summary by default", func() {
+			results, err := build.TestResults()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(BeEmpty())
+			Expect(build.TestSummary()).To(BeNil())
+		})
+
+		Context("when results have been saved", func() {
+			BeforeEach(func() {
+				Expect(build.SaveTestResults([]atc.TestResult{
+					{Suite: "math", Name: "adds", Status: atc.TestStatusPassed, Duration: 0.5},
+					{Suite: "math", Name: "divides", Status: atc.TestStatusFailed, Message: "expected 2, got 3"},
+				})).To(Succeed())
+			})
+
+			It("returns the results in the order they were saved", func() {
+				results, err := build.TestResults()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(Equal([]atc.TestResult{
+					{Suite: "math", Name: "adds", Status: atc.TestStatusPassed, Duration: 0.5},
+					{Suite: "math", Name: "divides", Status: atc.TestStatusFailed, Message: "expected 2, got 3"},
+				}))
+			})
+
+			It("summarizes the results on the build", func() {
+				Expect(build.TestSummary()).To(Equal(&atc.TestSummary{Total: 2, Passed: 1, Failed: 1}))
+
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.TestSummary()).To(Equal(&atc.TestSummary{Total: 2, Passed: 1, Failed: 1}))
+			})
+
+			It("includes results saved later on in the summary", func() {
+				Expect(build.SaveTestResults([]atc.TestResult{
+					{Suite: "io", Name: "reads", Status: atc.TestStatusSkipped},
+				})).To(Succeed())
+
+				Expect(build.TestSummary()).To(Equal(&atc.TestSummary{Total: 3, Passed: 1, Failed: 1, Skipped: 1}))
+			})
+
+			It("does not return the results of other builds", func() {
+				otherBuild, err := team.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				results, err := otherBuild.TestResults()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(results).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Approvals", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			started, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+		})
+
+		It("has no approvals by default", func() {
+			approvals, err := build.Approvals()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(approvals).To(BeEmpty())
+
+			_, found, err := build.Approval("some-plan")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when an approval has been requested", func() {
+			var (
+				approval atc.BuildApproval
+				deadline time.Time
+			)
+
+			BeforeEach(func() {
+				deadline = time.Now().Add(time.Hour)
+
+				var err error
+				approval, err = build.RequestApproval("some-plan", atc.ApprovalPlan{
+					Name:         "deploy",
+					AllowedRoles: []string{"owner"},
+				}, deadline)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("is pending", func() {
+				Expect(approval.BuildID).To(Equal(build.ID()))
+				Expect(approval.PlanID).To(Equal(atc.PlanID("some-plan")))
+				Expect(approval.Name).To(Equal("deploy"))
+				Expect(approval.Status).To(Equal(atc.ApprovalStatusPending))
+				Expect(approval.AllowedRoles).To(Equal([]string{"owner"}))
+				Expect(approval.Deadline).To(Equal(deadline.Unix()))
+				Expect(approval.RequestTime).NotTo(BeZero())
+
+				approvals, err := build.Approvals()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approvals).To(Equal([]atc.BuildApproval{approval}))
+			})
+
+			It("keeps the original request when requested again", func() {
+				again, err := build.RequestApproval("some-plan", atc.ApprovalPlan{
+					Name: "deploy",
+				}, time.Now().Add(2*time.Hour))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(again).To(Equal(approval))
+			})
+
+			Context("when it is approved", func() {
+				var decided bool
+
+				BeforeEach(func() {
+					var err error
+					decided, err = build.DecideApproval("deploy", atc.ApprovalStatusApproved, "some-user", "looks good")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("records the decision", func() {
+					Expect(decided).To(BeTrue())
+
+					approval, found, err := build.Approval("some-plan")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(approval.Status).To(Equal(atc.ApprovalStatusApproved))
+					Expect(approval.DecidedBy).To(Equal("some-user"))
+					Expect(approval.Comment).To(Equal("looks good"))
+					Expect(approval.DecideTime).NotTo(BeZero())
+				})
+
+				It("cannot be decided again", func() {
+					decided, err := build.DecideApproval("deploy", atc.ApprovalStatusRejected, "some-other-user", "")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(decided).To(BeFalse())
+				})
+
+				It("cannot expire", func() {
+					expired, err := build.ExpireApproval("some-plan")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(expired).To(BeFalse())
+				})
+			})
+
+			It("can be decided without a name", func() {
+				decided, err := build.DecideApproval("", atc.ApprovalStatusRejected, "some-user", "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decided).To(BeTrue())
+
+				approval, _, err := build.Approval("some-plan")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approval.Status).To(Equal(atc.ApprovalStatusRejected))
+			})
+
+			It("is not decided by another name", func() {
+				decided, err := build.DecideApproval("bogus", atc.ApprovalStatusApproved, "some-user", "")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decided).To(BeFalse())
+			})
+
+			Context("when another approval is pending", func() {
+				BeforeEach(func() {
+					_, err := build.RequestApproval("some-other-plan", atc.ApprovalPlan{
+						Name: "smoke-test",
+					}, time.Time{})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("must be decided by name", func() {
+					_, err := build.DecideApproval("", atc.ApprovalStatusApproved, "some-user", "")
+					Expect(err).To(Equal(db.ErrMultipleApprovalsPending))
+
+					decided, err := build.DecideApproval("smoke-test", atc.ApprovalStatusApproved, "some-user", "")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(decided).To(BeTrue())
+
+					approval, _, err := build.Approval("some-plan")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(approval.Status).To(Equal(atc.ApprovalStatusPending))
+				})
+			})
+
+			It("can expire", func() {
+				expired, err := build.ExpireApproval("some-plan")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(expired).To(BeTrue())
+
+				approval, _, err := build.Approval("some-plan")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approval.Status).To(Equal(atc.ApprovalStatusExpired))
+			})
+
+			It("notifies when it is decided", func() {
+				notifier, err := build.ApprovalNotifier("some-plan")
+				Expect(err).NotTo(HaveOccurred())
+
+				defer notifier.Close()
+
+				Consistently(notifier.Notify()).ShouldNot(Receive())
+
+				_, err = build.DecideApproval("deploy", atc.ApprovalStatusApproved, "some-user", "")
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(notifier.Notify()).Should(Receive())
+			})
+
+			Context("when the build finishes", func() {
+				BeforeEach(func() {
+					err := build.Finish(db.BuildStatusAborted)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("expires the approval", func() {
+					approval, _, err := build.Approval("some-plan")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(approval.Status).To(Equal(atc.ApprovalStatusExpired))
+				})
+
+				It("can no longer be decided", func() {
+					decided, err := build.DecideApproval("deploy", atc.ApprovalStatusApproved, "some-user", "")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(decided).To(BeFalse())
+				})
+			})
+		})
+	})
+
+	Describe("ArchivedArtifacts", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns no artifacts by default", func() {
+			artifacts, err := build.ArchivedArtifacts()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(artifacts).To(BeEmpty())
+		})
+
+		Context("when artifacts have been saved", func() {
+			BeforeEach(func() {
+				Expect(build.SaveArchivedArtifact("some-plan", "some-output", 42)).To(Succeed())
+				Expect(build.SaveArchivedArtifact("some-plan", "another-output", 123)).To(Succeed())
+			})
+
+			It("returns the artifacts ordered by name", func() {
+				artifacts, err := build.ArchivedArtifacts()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(artifacts).To(HaveLen(2))
+
+				Expect(artifacts[0].PlanID).To(Equal(atc.PlanID("some-plan")))
+				Expect(artifacts[0].Name).To(Equal("another-output"))
+				Expect(artifacts[0].Size).To(Equal(int64(123)))
+				Expect(artifacts[0].CreatedAt).ToNot(BeZero())
+
+				Expect(artifacts[1].Name).To(Equal("some-output"))
+				Expect(artifacts[1].Size).To(Equal(int64(42)))
+			})
+
+			It("replaces an artifact saved again by the same plan under the same name", func() {
+				Expect(build.SaveArchivedArtifact("some-plan", "some-output", 7)).To(Succeed())
+
+				artifacts, err := build.ArchivedArtifacts()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(artifacts).To(HaveLen(2))
+				Expect(artifacts[1].Size).To(Equal(int64(7)))
+			})
+
+			It("keeps the artifacts of other plans with the same name apart", func() {
+				Expect(build.SaveArchivedArtifact("other-plan", "some-output", 7)).To(Succeed())
+
+				artifacts, err := build.ArchivedArtifacts()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(artifacts).To(HaveLen(3))
+
+				Expect(artifacts[1].PlanID).To(Equal(atc.PlanID("other-plan")))
+				Expect(artifacts[1].Size).To(Equal(int64(7)))
+
+				Expect(artifacts[2].PlanID).To(Equal(atc.PlanID("some-plan")))
+				Expect(artifacts[2].Size).To(Equal(int64(42)))
+			})
+
+			It("does not return the artifacts of other builds", func() {
+				otherBuild, err := team.CreateOneOffBuild()
+				Expect(err).NotTo(HaveOccurred())
+
+				artifacts, err := otherBuild.ArchivedArtifacts()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(artifacts).To(BeEmpty())
+			})
+
+			It("can delete the artifacts", func() {
+				Expect(build.DeleteArchivedArtifacts()).To(Succeed())
+
+				artifacts, err := build.ArchivedArtifacts()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(artifacts).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("Events", func() {
 		It("saves and emits status events", func() {
 			build, err := team.CreateOneOffBuild()
@@ -365,6 +762,83 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("storing events outside of Postgres", func() {
+		var (
+			fakeEventStore *dbfakes.FakeEventStore
+			build          db.Build
+		)
+
+		BeforeEach(func() {
+			fakeEventStore = new(dbfakes.FakeEventStore)
+
+			storeTeamFactory := db.NewTeamFactory(db.WithEventStore(dbConn, fakeEventStore), lockFactory)
+			storeTeam, found, err := storeTeamFactory.FindTeam(team.Name())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			build, err = storeTeam.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("saves events in the store", func() {
+			err := build.SaveEvent(event.Log{Payload: "some-log"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeEventStore.PutCallCount()).To(Equal(1))
+			_, savedBuild, events := fakeEventStore.PutArgsForCall(0)
+			Expect(savedBuild.ID()).To(Equal(build.ID()))
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Event).To(Equal(event.Log{Payload: "some-log"}))
+
+			Expect(fakeEventStore.FlushCallCount()).To(Equal(1))
+			flushedBuild, flushed := fakeEventStore.FlushArgsForCall(0)
+			Expect(flushedBuild.ID()).To(Equal(build.ID()))
+			Expect(flushed).To(Equal(events))
+		})
+
+		It("saves status events in the store", func() {
+			_, err := build.Start(atc.Plan{})
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeEventStore.PutCallCount()).To(Equal(2))
+			Expect(fakeEventStore.FlushCallCount()).To(Equal(2))
+
+			_, events := fakeEventStore.FlushArgsForCall(1)
+			Expect(events[0].Event).To(BeAssignableToTypeOf(event.Status{}))
+			Expect(events[0].EventID).To(BeNumerically(">", 0))
+		})
+
+		Context("when the transaction saving an event is rolled back", func() {
+			BeforeEach(func() {
+				fakeEventStore.PutReturns(errors.New("nope"))
+			})
+
+			It("does not flush the event to the store", func() {
+				err := build.SaveEvent(event.Log{Payload: "some-log"})
+				Expect(err).To(HaveOccurred())
+
+				Expect(fakeEventStore.FlushCallCount()).To(BeZero())
+			})
+		})
+
+		It("reads events from the store", func() {
+			fakeSource := new(dbfakes.FakeEventSource)
+			fakeEventStore.GetReturns(fakeSource, nil)
+
+			source, err := build.Events(2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(source).To(Equal(fakeSource))
+
+			Expect(fakeEventStore.GetCallCount()).To(Equal(1))
+			gotBuild, from, _ := fakeEventStore.GetArgsForCall(0)
+			Expect(gotBuild.ID()).To(Equal(build.ID()))
+			Expect(from).To(Equal(uint(2)))
+		})
+	})
+
 	Describe("SaveOutput", func() {
 		var pipeline db.Pipeline
 		var job db.Job
@@ -391,7 +865,7 @@ var _ = Describe("Build", func() {
 			}
 
 			var err error
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), false)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, db.ConfigVersion(1), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
@@ -420,7 +894,7 @@ var _ = Describe("Build", func() {
 
 		Context("when the version does not exist", func() {
 			It("can save a build's output", func() {
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = build.SaveOutput("some-type", atc.Source{"some": "explicit-source"}, atc.VersionedResourceTypes{}, atc.Version{"some": "version"}, []db.ResourceConfigMetadataField{
@@ -461,7 +935,7 @@ var _ = Describe("Build", func() {
 			})
 
 			It("does not increment the check order", func() {
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = build.SaveOutput("some-type", atc.Source{"some": "explicit-source"}, atc.VersionedResourceTypes{}, atc.Version{"some": "version"}, []db.ResourceConfigMetadataField{
@@ -530,7 +1004,7 @@ var _ = Describe("Build", func() {
 				},
 			}
 
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), false)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, db.ConfigVersion(1), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
@@ -565,7 +1039,7 @@ var _ = Describe("Build", func() {
 		})
 
 		It("returns build inputs and outputs", func() {
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			// save a normal 'get'
@@ -626,7 +1100,7 @@ var _ = Describe("Build", func() {
 		Context("when a job build", func() {
 			BeforeEach(func() {
 				var err error
-				createdPipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
+				createdPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-job",
@@ -639,7 +1113,7 @@ var _ = Describe("Build", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err = job.CreateBuild()
+				build, err = job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -724,7 +1198,7 @@ var _ = Describe("Build", func() {
 
 			BeforeEach(func() {
 				var err error
-				pipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
+				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 					Resources: atc.ResourceConfigs{
 						{
 							Name: "some-resource",
@@ -747,7 +1221,7 @@ var _ = Describe("Build", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err = job.CreateBuild()
+				build, err = job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				expectedBuildPrep.BuildID = build.ID()
@@ -930,7 +1404,7 @@ var _ = Describe("Build", func() {
 
 			Context("when inputs are not satisfied", func() {
 				BeforeEach(func() {
-					pipeline, _, err = team.SavePipeline("some-pipeline", atc.Config{
+					pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 						Resources: atc.ResourceConfigs{
 							{
 								Name: "some-resource",
@@ -998,7 +1472,7 @@ var _ = Describe("Build", func() {
 						},
 					}
 
-					pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(2), false)
+					pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, db.ConfigVersion(2), false)
 					Expect(err).ToNot(HaveOccurred())
 
 					setupTx, err := dbConn.Begin()
@@ -1081,7 +1555,7 @@ var _ = Describe("Build", func() {
 			)
 
 			BeforeEach(func() {
-				pipeline, _, err := team.SavePipeline("some-pipeline", atc.Config{
+				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-job",
@@ -1094,13 +1568,13 @@ var _ = Describe("Build", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err = job.CreateBuild()
+				build, err = job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(build.IsScheduled()).To(BeFalse())
 			})
 
 			JustBeforeEach(func() {
-				found, err = build.Schedule()
+				found, err = build.Schedule(nil)
 				Expect(err).ToNot(HaveOccurred())
 
 				f, err = build.Reload()
@@ -1163,14 +1637,14 @@ var _ = Describe("Build", func() {
 			}
 
 			var err error
-			pipeline, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), false)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, db.ConfigVersion(1), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err = job.CreateBuild()
+			build, err = job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			setupTx, err := dbConn.Begin()
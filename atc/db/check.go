@@ -28,6 +28,7 @@ type Check interface {
 	TeamID() int
 	TeamName() string
 	PipelineName() string
+	Pipeline() (Pipeline, bool, error)
 	ResourceConfigScopeID() int
 	ResourceConfigID() int
 	BaseResourceTypeID() int
@@ -85,6 +86,7 @@ type check struct {
 type CheckMetadata struct {
 	TeamID             int    `json:"team_id"`
 	TeamName           string `json:"team_name"`
+	PipelineID         int    `json:"pipeline_id"`
 	PipelineName       string `json:"pipeline_name"`
 	ResourceConfigID   int    `json:"resource_config_id"`
 	BaseResourceTypeID int    `json:"base_resource_type_id"`
@@ -112,6 +114,28 @@ func (c *check) PipelineName() string {
 	return c.metadata.PipelineName
 }
 
+func (c *check) Pipeline() (Pipeline, bool, error) {
+	if c.metadata.PipelineID == 0 {
+		return nil, false, nil
+	}
+
+	row := pipelinesQuery.
+		Where(sq.Eq{"p.id": c.metadata.PipelineID}).
+		RunWith(c.conn).
+		QueryRow()
+
+	pipeline := newPipeline(c.conn, c.lockFactory)
+	err := scanPipeline(pipeline, row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return pipeline, true, nil
+}
+
 func (c *check) ResourceConfigID() int {
 	return c.metadata.ResourceConfigID
 }
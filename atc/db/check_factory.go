@@ -55,6 +55,7 @@ type checkFactory struct {
 	lockFactory lock.LockFactory
 
 	secrets             creds.Secrets
+	varSourcePool       creds.VarSourcePool
 	defaultCheckTimeout time.Duration
 }
 
@@ -62,6 +63,7 @@ func NewCheckFactory(
 	conn Conn,
 	lockFactory lock.LockFactory,
 	secrets creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 	defaultCheckTimeout time.Duration,
 ) CheckFactory {
 	return &checkFactory{
@@ -69,6 +71,7 @@ func NewCheckFactory(
 		lockFactory: lockFactory,
 
 		secrets:             secrets,
+		varSourcePool:       varSourcePool,
 		defaultCheckTimeout: defaultCheckTimeout,
 	}
 }
@@ -152,10 +155,29 @@ func (c *checkFactory) TryCreateCheck(checkable Checkable, resourceTypes Resourc
 		}
 	}
 
-	variables := creds.NewVariables(
-		c.secrets,
+	pipeline := newPipeline(c.conn, c.lockFactory)
+	err = scanPipeline(
+		pipeline,
+		pipelinesQuery.
+			Where(sq.Eq{"p.id": checkable.PipelineID()}).
+			RunWith(c.conn).
+			QueryRow(),
+	)
+	if err != nil {
+		return nil, false, err
+	}
+
+	access := atc.SecretAccess{
+		TeamName:     checkable.TeamName(),
+		PipelineName: checkable.PipelineName(),
+	}
+
+	variables := creds.NewPipelineVariables(
+		creds.WithSecretAccess(c.secrets, access),
+		creds.WithVarSourceAccess(c.varSourcePool, access),
 		checkable.TeamName(),
 		checkable.PipelineName(),
+		pipeline.VarSources(),
 	)
 
 	source, err := creds.NewSource(variables, checkable.Source()).Evaluate()
@@ -202,6 +224,7 @@ func (c *checkFactory) TryCreateCheck(checkable Checkable, resourceTypes Resourc
 	meta := CheckMetadata{
 		TeamID:             checkable.TeamID(),
 		TeamName:           checkable.TeamName(),
+		PipelineID:         checkable.PipelineID(),
 		PipelineName:       checkable.PipelineName(),
 		ResourceConfigID:   resourceConfigScope.ResourceConfig().ID(),
 		BaseResourceTypeID: resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
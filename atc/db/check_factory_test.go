@@ -90,6 +90,7 @@ var _ = Describe("CheckFactory", func() {
 
 			fakeResource = new(dbfakes.FakeResource)
 			fakeResource.NameReturns("some-name")
+			fakeResource.PipelineIDReturns(defaultPipeline.ID())
 			fakeResource.TagsReturns([]string{"tag-a", "tag-b"})
 			fakeResource.SourceReturns(atc.Source{"some": "source"})
 
@@ -256,9 +257,8 @@ var _ = Describe("CheckFactory", func() {
 		Context("when the resource has a parent type", func() {
 			BeforeEach(func() {
 				fakeResource.TypeReturns("custom-type")
-				fakeResource.PipelineIDReturns(1)
 				fakeResourceType.NameReturns("custom-type")
-				fakeResourceType.PipelineIDReturns(1)
+				fakeResourceType.PipelineIDReturns(defaultPipeline.ID())
 
 			})
 
@@ -41,6 +41,7 @@ var _ = Describe("Check", func() {
 		metadata := db.CheckMetadata{
 			TeamID:             defaultTeam.ID(),
 			TeamName:           defaultTeam.Name(),
+			PipelineID:         defaultPipeline.ID(),
 			PipelineName:       defaultPipeline.Name(),
 			ResourceConfigID:   resourceConfigScope.ResourceConfig().ID(),
 			BaseResourceTypeID: resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
@@ -56,6 +57,15 @@ var _ = Describe("Check", func() {
 		Expect(err).NotTo(HaveOccurred())
 	})
 
+	Describe("Pipeline", func() {
+		It("returns the pipeline of the checked resource", func() {
+			pipeline, found, err := check.Pipeline()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(pipeline.ID()).To(Equal(defaultPipeline.ID()))
+		})
+	})
+
 	Describe("Start", func() {
 		JustBeforeEach(func() {
 			err = check.Start()
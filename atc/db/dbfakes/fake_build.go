@@ -41,6 +41,58 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	ApprovalStub        func(atc.PlanID) (atc.BuildApproval, bool, error)
+	approvalMutex       sync.RWMutex
+	approvalArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	approvalReturns struct {
+		result1 atc.BuildApproval
+		result2 bool
+		result3 error
+	}
+	approvalReturnsOnCall map[int]struct {
+		result1 atc.BuildApproval
+		result2 bool
+		result3 error
+	}
+	ApprovalNotifierStub        func(atc.PlanID) (db.Notifier, error)
+	approvalNotifierMutex       sync.RWMutex
+	approvalNotifierArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	approvalNotifierReturns struct {
+		result1 db.Notifier
+		result2 error
+	}
+	approvalNotifierReturnsOnCall map[int]struct {
+		result1 db.Notifier
+		result2 error
+	}
+	ApprovalsStub        func() ([]atc.BuildApproval, error)
+	approvalsMutex       sync.RWMutex
+	approvalsArgsForCall []struct {
+	}
+	approvalsReturns struct {
+		result1 []atc.BuildApproval
+		result2 error
+	}
+	approvalsReturnsOnCall map[int]struct {
+		result1 []atc.BuildApproval
+		result2 error
+	}
+	ArchivedArtifactsStub        func() ([]db.ArchivedArtifact, error)
+	archivedArtifactsMutex       sync.RWMutex
+	archivedArtifactsArgsForCall []struct {
+	}
+	archivedArtifactsReturns struct {
+		result1 []db.ArchivedArtifact
+		result2 error
+	}
+	archivedArtifactsReturnsOnCall map[int]struct {
+		result1 []db.ArchivedArtifact
+		result2 error
+	}
 	ArtifactStub        func(int) (db.WorkerArtifact, error)
 	artifactMutex       sync.RWMutex
 	artifactArgsForCall []struct {
@@ -66,6 +118,32 @@ type FakeBuild struct {
 		result1 []db.WorkerArtifact
 		result2 error
 	}
+	CreatedByStub        func() *atc.BuildCreator
+	createdByMutex       sync.RWMutex
+	createdByArgsForCall []struct {
+	}
+	createdByReturns struct {
+		result1 *atc.BuildCreator
+	}
+	createdByReturnsOnCall map[int]struct {
+		result1 *atc.BuildCreator
+	}
+	DecideApprovalStub        func(string, atc.BuildApprovalStatus, string, string) (bool, error)
+	decideApprovalMutex       sync.RWMutex
+	decideApprovalArgsForCall []struct {
+		arg1 string
+		arg2 atc.BuildApprovalStatus
+		arg3 string
+		arg4 string
+	}
+	decideApprovalReturns struct {
+		result1 bool
+		result2 error
+	}
+	decideApprovalReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	DeleteStub        func() (bool, error)
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -78,6 +156,16 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	DeleteArchivedArtifactsStub        func() error
+	deleteArchivedArtifactsMutex       sync.RWMutex
+	deleteArchivedArtifactsArgsForCall []struct {
+	}
+	deleteArchivedArtifactsReturns struct {
+		result1 error
+	}
+	deleteArchivedArtifactsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	EndTimeStub        func() time.Time
 	endTimeMutex       sync.RWMutex
 	endTimeArgsForCall []struct {
@@ -101,6 +189,19 @@ type FakeBuild struct {
 		result1 db.EventSource
 		result2 error
 	}
+	ExpireApprovalStub        func(atc.PlanID) (bool, error)
+	expireApprovalMutex       sync.RWMutex
+	expireApprovalArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	expireApprovalReturns struct {
+		result1 bool
+		result2 error
+	}
+	expireApprovalReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	FinishStub        func(db.BuildStatus) error
 	finishMutex       sync.RWMutex
 	finishArgsForCall []struct {
@@ -279,6 +380,16 @@ type FakeBuild struct {
 	pipelineIDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	PipelineInstanceVarsStub        func() atc.InstanceVars
+	pipelineInstanceVarsMutex       sync.RWMutex
+	pipelineInstanceVarsArgsForCall []struct {
+	}
+	pipelineInstanceVarsReturns struct {
+		result1 atc.InstanceVars
+	}
+	pipelineInstanceVarsReturnsOnCall map[int]struct {
+		result1 atc.InstanceVars
+	}
 	PipelineNameStub        func() string
 	pipelineNameMutex       sync.RWMutex
 	pipelineNameArgsForCall []struct {
@@ -303,6 +414,16 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	PriorityStub        func() int
+	priorityMutex       sync.RWMutex
+	priorityArgsForCall []struct {
+	}
+	priorityReturns struct {
+		result1 int
+	}
+	priorityReturnsOnCall map[int]struct {
+		result1 int
+	}
 	PrivatePlanStub        func() atc.Plan
 	privatePlanMutex       sync.RWMutex
 	privatePlanArgsForCall []struct {
@@ -345,6 +466,45 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	RequestApprovalStub        func(atc.PlanID, atc.ApprovalPlan, time.Time) (atc.BuildApproval, error)
+	requestApprovalMutex       sync.RWMutex
+	requestApprovalArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 atc.ApprovalPlan
+		arg3 time.Time
+	}
+	requestApprovalReturns struct {
+		result1 atc.BuildApproval
+		result2 error
+	}
+	requestApprovalReturnsOnCall map[int]struct {
+		result1 atc.BuildApproval
+		result2 error
+	}
+	RerunInputsStub        func() ([]db.BuildInput, bool, error)
+	rerunInputsMutex       sync.RWMutex
+	rerunInputsArgsForCall []struct {
+	}
+	rerunInputsReturns struct {
+		result1 []db.BuildInput
+		result2 bool
+		result3 error
+	}
+	rerunInputsReturnsOnCall map[int]struct {
+		result1 []db.BuildInput
+		result2 bool
+		result3 error
+	}
+	RerunOfStub        func() int
+	rerunOfMutex       sync.RWMutex
+	rerunOfArgsForCall []struct {
+	}
+	rerunOfReturns struct {
+		result1 int
+	}
+	rerunOfReturnsOnCall map[int]struct {
+		result1 int
+	}
 	ResourcesStub        func() ([]db.BuildInput, []db.BuildOutput, error)
 	resourcesMutex       sync.RWMutex
 	resourcesArgsForCall []struct {
@@ -359,6 +519,19 @@ type FakeBuild struct {
 		result2 []db.BuildOutput
 		result3 error
 	}
+	SaveArchivedArtifactStub        func(atc.PlanID, string, int64) error
+	saveArchivedArtifactMutex       sync.RWMutex
+	saveArchivedArtifactArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 string
+		arg3 int64
+	}
+	saveArchivedArtifactReturns struct {
+		result1 error
+	}
+	saveArchivedArtifactReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveEventStub        func(atc.Event) error
 	saveEventMutex       sync.RWMutex
 	saveEventArgsForCall []struct {
@@ -398,9 +571,21 @@ type FakeBuild struct {
 	saveOutputReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ScheduleStub        func() (bool, error)
+	SaveTestResultsStub        func([]atc.TestResult) error
+	saveTestResultsMutex       sync.RWMutex
+	saveTestResultsArgsForCall []struct {
+		arg1 []atc.TestResult
+	}
+	saveTestResultsReturns struct {
+		result1 error
+	}
+	saveTestResultsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ScheduleStub        func([]atc.SemaphoreConfig) (bool, error)
 	scheduleMutex       sync.RWMutex
 	scheduleArgsForCall []struct {
+		arg1 []atc.SemaphoreConfig
 	}
 	scheduleReturns struct {
 		result1 bool
@@ -420,6 +605,17 @@ type FakeBuild struct {
 	schemaReturnsOnCall map[int]struct {
 		result1 string
 	}
+	SetCreatedByStub        func(atc.BuildCreator) error
+	setCreatedByMutex       sync.RWMutex
+	setCreatedByArgsForCall []struct {
+		arg1 atc.BuildCreator
+	}
+	setCreatedByReturns struct {
+		result1 error
+	}
+	setCreatedByReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetDrainedStub        func(bool) error
 	setDrainedMutex       sync.RWMutex
 	setDrainedArgsForCall []struct {
@@ -442,6 +638,17 @@ type FakeBuild struct {
 	setInterceptibleReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetPriorityStub        func(int) error
+	setPriorityMutex       sync.RWMutex
+	setPriorityArgsForCall []struct {
+		arg1 int
+	}
+	setPriorityReturns struct {
+		result1 error
+	}
+	setPriorityReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StartStub        func(atc.Plan) (bool, error)
 	startMutex       sync.RWMutex
 	startArgsForCall []struct {
@@ -495,6 +702,28 @@ type FakeBuild struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TestResultsStub        func() ([]atc.TestResult, error)
+	testResultsMutex       sync.RWMutex
+	testResultsArgsForCall []struct {
+	}
+	testResultsReturns struct {
+		result1 []atc.TestResult
+		result2 error
+	}
+	testResultsReturnsOnCall map[int]struct {
+		result1 []atc.TestResult
+		result2 error
+	}
+	TestSummaryStub        func() *atc.TestSummary
+	testSummaryMutex       sync.RWMutex
+	testSummaryArgsForCall []struct {
+	}
+	testSummaryReturns struct {
+		result1 *atc.TestSummary
+	}
+	testSummaryReturnsOnCall map[int]struct {
+		result1 *atc.TestSummary
+	}
 	UseInputsStub        func([]db.BuildInput) error
 	useInputsMutex       sync.RWMutex
 	useInputsArgsForCall []struct {
@@ -632,6 +861,245 @@ func (fake *FakeBuild) AcquireTrackingLockReturnsOnCall(i int, result1 lock.Lock
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) Approval(arg1 atc.PlanID) (atc.BuildApproval, bool, error) {
+	fake.approvalMutex.Lock()
+	ret, specificReturn := fake.approvalReturnsOnCall[len(fake.approvalArgsForCall)]
+	fake.approvalArgsForCall = append(fake.approvalArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("Approval", []interface{}{arg1})
+	fake.approvalMutex.Unlock()
+	if fake.ApprovalStub != nil {
+		return fake.ApprovalStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.approvalReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) ApprovalCallCount() int {
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	return len(fake.approvalArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalCalls(stub func(atc.PlanID) (atc.BuildApproval, bool, error)) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = stub
+}
+
+func (fake *FakeBuild) ApprovalArgsForCall(i int) atc.PlanID {
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	argsForCall := fake.approvalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ApprovalReturns(result1 atc.BuildApproval, result2 bool, result3 error) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = nil
+	fake.approvalReturns = struct {
+		result1 atc.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ApprovalReturnsOnCall(i int, result1 atc.BuildApproval, result2 bool, result3 error) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = nil
+	if fake.approvalReturnsOnCall == nil {
+		fake.approvalReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildApproval
+			result2 bool
+			result3 error
+		})
+	}
+	fake.approvalReturnsOnCall[i] = struct {
+		result1 atc.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ApprovalNotifier(arg1 atc.PlanID) (db.Notifier, error) {
+	fake.approvalNotifierMutex.Lock()
+	ret, specificReturn := fake.approvalNotifierReturnsOnCall[len(fake.approvalNotifierArgsForCall)]
+	fake.approvalNotifierArgsForCall = append(fake.approvalNotifierArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("ApprovalNotifier", []interface{}{arg1})
+	fake.approvalNotifierMutex.Unlock()
+	if fake.ApprovalNotifierStub != nil {
+		return fake.ApprovalNotifierStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.approvalNotifierReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ApprovalNotifierCallCount() int {
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	return len(fake.approvalNotifierArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalNotifierCalls(stub func(atc.PlanID) (db.Notifier, error)) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = stub
+}
+
+func (fake *FakeBuild) ApprovalNotifierArgsForCall(i int) atc.PlanID {
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	argsForCall := fake.approvalNotifierArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ApprovalNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = nil
+	fake.approvalNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ApprovalNotifierReturnsOnCall(i int, result1 db.Notifier, result2 error) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = nil
+	if fake.approvalNotifierReturnsOnCall == nil {
+		fake.approvalNotifierReturnsOnCall = make(map[int]struct {
+			result1 db.Notifier
+			result2 error
+		})
+	}
+	fake.approvalNotifierReturnsOnCall[i] = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) Approvals() ([]atc.BuildApproval, error) {
+	fake.approvalsMutex.Lock()
+	ret, specificReturn := fake.approvalsReturnsOnCall[len(fake.approvalsArgsForCall)]
+	fake.approvalsArgsForCall = append(fake.approvalsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Approvals", []interface{}{})
+	fake.approvalsMutex.Unlock()
+	if fake.ApprovalsStub != nil {
+		return fake.ApprovalsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.approvalsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ApprovalsCallCount() int {
+	fake.approvalsMutex.RLock()
+	defer fake.approvalsMutex.RUnlock()
+	return len(fake.approvalsArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalsCalls(stub func() ([]atc.BuildApproval, error)) {
+	fake.approvalsMutex.Lock()
+	defer fake.approvalsMutex.Unlock()
+	fake.ApprovalsStub = stub
+}
+
+func (fake *FakeBuild) ApprovalsReturns(result1 []atc.BuildApproval, result2 error) {
+	fake.approvalsMutex.Lock()
+	defer fake.approvalsMutex.Unlock()
+	fake.ApprovalsStub = nil
+	fake.approvalsReturns = struct {
+		result1 []atc.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ApprovalsReturnsOnCall(i int, result1 []atc.BuildApproval, result2 error) {
+	fake.approvalsMutex.Lock()
+	defer fake.approvalsMutex.Unlock()
+	fake.ApprovalsStub = nil
+	if fake.approvalsReturnsOnCall == nil {
+		fake.approvalsReturnsOnCall = make(map[int]struct {
+			result1 []atc.BuildApproval
+			result2 error
+		})
+	}
+	fake.approvalsReturnsOnCall[i] = struct {
+		result1 []atc.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ArchivedArtifacts() ([]db.ArchivedArtifact, error) {
+	fake.archivedArtifactsMutex.Lock()
+	ret, specificReturn := fake.archivedArtifactsReturnsOnCall[len(fake.archivedArtifactsArgsForCall)]
+	fake.archivedArtifactsArgsForCall = append(fake.archivedArtifactsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ArchivedArtifacts", []interface{}{})
+	fake.archivedArtifactsMutex.Unlock()
+	if fake.ArchivedArtifactsStub != nil {
+		return fake.ArchivedArtifactsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.archivedArtifactsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ArchivedArtifactsCallCount() int {
+	fake.archivedArtifactsMutex.RLock()
+	defer fake.archivedArtifactsMutex.RUnlock()
+	return len(fake.archivedArtifactsArgsForCall)
+}
+
+func (fake *FakeBuild) ArchivedArtifactsCalls(stub func() ([]db.ArchivedArtifact, error)) {
+	fake.archivedArtifactsMutex.Lock()
+	defer fake.archivedArtifactsMutex.Unlock()
+	fake.ArchivedArtifactsStub = stub
+}
+
+func (fake *FakeBuild) ArchivedArtifactsReturns(result1 []db.ArchivedArtifact, result2 error) {
+	fake.archivedArtifactsMutex.Lock()
+	defer fake.archivedArtifactsMutex.Unlock()
+	fake.ArchivedArtifactsStub = nil
+	fake.archivedArtifactsReturns = struct {
+		result1 []db.ArchivedArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ArchivedArtifactsReturnsOnCall(i int, result1 []db.ArchivedArtifact, result2 error) {
+	fake.archivedArtifactsMutex.Lock()
+	defer fake.archivedArtifactsMutex.Unlock()
+	fake.ArchivedArtifactsStub = nil
+	if fake.archivedArtifactsReturnsOnCall == nil {
+		fake.archivedArtifactsReturnsOnCall = make(map[int]struct {
+			result1 []db.ArchivedArtifact
+			result2 error
+		})
+	}
+	fake.archivedArtifactsReturnsOnCall[i] = struct {
+		result1 []db.ArchivedArtifact
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Artifact(arg1 int) (db.WorkerArtifact, error) {
 	fake.artifactMutex.Lock()
 	ret, specificReturn := fake.artifactReturnsOnCall[len(fake.artifactArgsForCall)]
@@ -750,6 +1218,124 @@ func (fake *FakeBuild) ArtifactsReturnsOnCall(i int, result1 []db.WorkerArtifact
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) CreatedBy() *atc.BuildCreator {
+	fake.createdByMutex.Lock()
+	ret, specificReturn := fake.createdByReturnsOnCall[len(fake.createdByArgsForCall)]
+	fake.createdByArgsForCall = append(fake.createdByArgsForCall, struct {
+	}{})
+	fake.recordInvocation("CreatedBy", []interface{}{})
+	fake.createdByMutex.Unlock()
+	if fake.CreatedByStub != nil {
+		return fake.CreatedByStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.createdByReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) CreatedByCallCount() int {
+	fake.createdByMutex.RLock()
+	defer fake.createdByMutex.RUnlock()
+	return len(fake.createdByArgsForCall)
+}
+
+func (fake *FakeBuild) CreatedByCalls(stub func() *atc.BuildCreator) {
+	fake.createdByMutex.Lock()
+	defer fake.createdByMutex.Unlock()
+	fake.CreatedByStub = stub
+}
+
+func (fake *FakeBuild) CreatedByReturns(result1 *atc.BuildCreator) {
+	fake.createdByMutex.Lock()
+	defer fake.createdByMutex.Unlock()
+	fake.CreatedByStub = nil
+	fake.createdByReturns = struct {
+		result1 *atc.BuildCreator
+	}{result1}
+}
+
+func (fake *FakeBuild) CreatedByReturnsOnCall(i int, result1 *atc.BuildCreator) {
+	fake.createdByMutex.Lock()
+	defer fake.createdByMutex.Unlock()
+	fake.CreatedByStub = nil
+	if fake.createdByReturnsOnCall == nil {
+		fake.createdByReturnsOnCall = make(map[int]struct {
+			result1 *atc.BuildCreator
+		})
+	}
+	fake.createdByReturnsOnCall[i] = struct {
+		result1 *atc.BuildCreator
+	}{result1}
+}
+
+func (fake *FakeBuild) DecideApproval(arg1 string, arg2 atc.BuildApprovalStatus, arg3 string, arg4 string) (bool, error) {
+	fake.decideApprovalMutex.Lock()
+	ret, specificReturn := fake.decideApprovalReturnsOnCall[len(fake.decideApprovalArgsForCall)]
+	fake.decideApprovalArgsForCall = append(fake.decideApprovalArgsForCall, struct {
+		arg1 string
+		arg2 atc.BuildApprovalStatus
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("DecideApproval", []interface{}{arg1, arg2, arg3, arg4})
+	fake.decideApprovalMutex.Unlock()
+	if fake.DecideApprovalStub != nil {
+		return fake.DecideApprovalStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.decideApprovalReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) DecideApprovalCallCount() int {
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
+	return len(fake.decideApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) DecideApprovalCalls(stub func(string, atc.BuildApprovalStatus, string, string) (bool, error)) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = stub
+}
+
+func (fake *FakeBuild) DecideApprovalArgsForCall(i int) (string, atc.BuildApprovalStatus, string, string) {
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
+	argsForCall := fake.decideApprovalArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeBuild) DecideApprovalReturns(result1 bool, result2 error) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = nil
+	fake.decideApprovalReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) DecideApprovalReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = nil
+	if fake.decideApprovalReturnsOnCall == nil {
+		fake.decideApprovalReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.decideApprovalReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Delete() (bool, error) {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -805,6 +1391,58 @@ func (fake *FakeBuild) DeleteReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) DeleteArchivedArtifacts() error {
+	fake.deleteArchivedArtifactsMutex.Lock()
+	ret, specificReturn := fake.deleteArchivedArtifactsReturnsOnCall[len(fake.deleteArchivedArtifactsArgsForCall)]
+	fake.deleteArchivedArtifactsArgsForCall = append(fake.deleteArchivedArtifactsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("DeleteArchivedArtifacts", []interface{}{})
+	fake.deleteArchivedArtifactsMutex.Unlock()
+	if fake.DeleteArchivedArtifactsStub != nil {
+		return fake.DeleteArchivedArtifactsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteArchivedArtifactsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) DeleteArchivedArtifactsCallCount() int {
+	fake.deleteArchivedArtifactsMutex.RLock()
+	defer fake.deleteArchivedArtifactsMutex.RUnlock()
+	return len(fake.deleteArchivedArtifactsArgsForCall)
+}
+
+func (fake *FakeBuild) DeleteArchivedArtifactsCalls(stub func() error) {
+	fake.deleteArchivedArtifactsMutex.Lock()
+	defer fake.deleteArchivedArtifactsMutex.Unlock()
+	fake.DeleteArchivedArtifactsStub = stub
+}
+
+func (fake *FakeBuild) DeleteArchivedArtifactsReturns(result1 error) {
+	fake.deleteArchivedArtifactsMutex.Lock()
+	defer fake.deleteArchivedArtifactsMutex.Unlock()
+	fake.DeleteArchivedArtifactsStub = nil
+	fake.deleteArchivedArtifactsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) DeleteArchivedArtifactsReturnsOnCall(i int, result1 error) {
+	fake.deleteArchivedArtifactsMutex.Lock()
+	defer fake.deleteArchivedArtifactsMutex.Unlock()
+	fake.DeleteArchivedArtifactsStub = nil
+	if fake.deleteArchivedArtifactsReturnsOnCall == nil {
+		fake.deleteArchivedArtifactsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteArchivedArtifactsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) EndTime() time.Time {
 	fake.endTimeMutex.Lock()
 	ret, specificReturn := fake.endTimeReturnsOnCall[len(fake.endTimeArgsForCall)]
@@ -920,6 +1558,69 @@ func (fake *FakeBuild) EventsReturnsOnCall(i int, result1 db.EventSource, result
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) ExpireApproval(arg1 atc.PlanID) (bool, error) {
+	fake.expireApprovalMutex.Lock()
+	ret, specificReturn := fake.expireApprovalReturnsOnCall[len(fake.expireApprovalArgsForCall)]
+	fake.expireApprovalArgsForCall = append(fake.expireApprovalArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("ExpireApproval", []interface{}{arg1})
+	fake.expireApprovalMutex.Unlock()
+	if fake.ExpireApprovalStub != nil {
+		return fake.ExpireApprovalStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.expireApprovalReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ExpireApprovalCallCount() int {
+	fake.expireApprovalMutex.RLock()
+	defer fake.expireApprovalMutex.RUnlock()
+	return len(fake.expireApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) ExpireApprovalCalls(stub func(atc.PlanID) (bool, error)) {
+	fake.expireApprovalMutex.Lock()
+	defer fake.expireApprovalMutex.Unlock()
+	fake.ExpireApprovalStub = stub
+}
+
+func (fake *FakeBuild) ExpireApprovalArgsForCall(i int) atc.PlanID {
+	fake.expireApprovalMutex.RLock()
+	defer fake.expireApprovalMutex.RUnlock()
+	argsForCall := fake.expireApprovalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ExpireApprovalReturns(result1 bool, result2 error) {
+	fake.expireApprovalMutex.Lock()
+	defer fake.expireApprovalMutex.Unlock()
+	fake.ExpireApprovalStub = nil
+	fake.expireApprovalReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ExpireApprovalReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.expireApprovalMutex.Lock()
+	defer fake.expireApprovalMutex.Unlock()
+	fake.ExpireApprovalStub = nil
+	if fake.expireApprovalReturnsOnCall == nil {
+		fake.expireApprovalReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.expireApprovalReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Finish(arg1 db.BuildStatus) error {
 	fake.finishMutex.Lock()
 	ret, specificReturn := fake.finishReturnsOnCall[len(fake.finishArgsForCall)]
@@ -1829,6 +2530,58 @@ func (fake *FakeBuild) PipelineIDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeBuild) PipelineInstanceVars() atc.InstanceVars {
+	fake.pipelineInstanceVarsMutex.Lock()
+	ret, specificReturn := fake.pipelineInstanceVarsReturnsOnCall[len(fake.pipelineInstanceVarsArgsForCall)]
+	fake.pipelineInstanceVarsArgsForCall = append(fake.pipelineInstanceVarsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineInstanceVars", []interface{}{})
+	fake.pipelineInstanceVarsMutex.Unlock()
+	if fake.PipelineInstanceVarsStub != nil {
+		return fake.PipelineInstanceVarsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelineInstanceVarsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) PipelineInstanceVarsCallCount() int {
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
+	return len(fake.pipelineInstanceVarsArgsForCall)
+}
+
+func (fake *FakeBuild) PipelineInstanceVarsCalls(stub func() atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = stub
+}
+
+func (fake *FakeBuild) PipelineInstanceVarsReturns(result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	fake.pipelineInstanceVarsReturns = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
+func (fake *FakeBuild) PipelineInstanceVarsReturnsOnCall(i int, result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	if fake.pipelineInstanceVarsReturnsOnCall == nil {
+		fake.pipelineInstanceVarsReturnsOnCall = make(map[int]struct {
+			result1 atc.InstanceVars
+		})
+	}
+	fake.pipelineInstanceVarsReturnsOnCall[i] = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
 func (fake *FakeBuild) PipelineName() string {
 	fake.pipelineNameMutex.Lock()
 	ret, specificReturn := fake.pipelineNameReturnsOnCall[len(fake.pipelineNameArgsForCall)]
@@ -1921,22 +2674,74 @@ func (fake *FakeBuild) PreparationReturns(result1 db.BuildPreparation, result2 b
 	}{result1, result2, result3}
 }
 
-func (fake *FakeBuild) PreparationReturnsOnCall(i int, result1 db.BuildPreparation, result2 bool, result3 error) {
-	fake.preparationMutex.Lock()
-	defer fake.preparationMutex.Unlock()
-	fake.PreparationStub = nil
-	if fake.preparationReturnsOnCall == nil {
-		fake.preparationReturnsOnCall = make(map[int]struct {
-			result1 db.BuildPreparation
-			result2 bool
-			result3 error
+func (fake *FakeBuild) PreparationReturnsOnCall(i int, result1 db.BuildPreparation, result2 bool, result3 error) {
+	fake.preparationMutex.Lock()
+	defer fake.preparationMutex.Unlock()
+	fake.PreparationStub = nil
+	if fake.preparationReturnsOnCall == nil {
+		fake.preparationReturnsOnCall = make(map[int]struct {
+			result1 db.BuildPreparation
+			result2 bool
+			result3 error
+		})
+	}
+	fake.preparationReturnsOnCall[i] = struct {
+		result1 db.BuildPreparation
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) Priority() int {
+	fake.priorityMutex.Lock()
+	ret, specificReturn := fake.priorityReturnsOnCall[len(fake.priorityArgsForCall)]
+	fake.priorityArgsForCall = append(fake.priorityArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Priority", []interface{}{})
+	fake.priorityMutex.Unlock()
+	if fake.PriorityStub != nil {
+		return fake.PriorityStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.priorityReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) PriorityCallCount() int {
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
+	return len(fake.priorityArgsForCall)
+}
+
+func (fake *FakeBuild) PriorityCalls(stub func() int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = stub
+}
+
+func (fake *FakeBuild) PriorityReturns(result1 int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = nil
+	fake.priorityReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) PriorityReturnsOnCall(i int, result1 int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = nil
+	if fake.priorityReturnsOnCall == nil {
+		fake.priorityReturnsOnCall = make(map[int]struct {
+			result1 int
 		})
 	}
-	fake.preparationReturnsOnCall[i] = struct {
-		result1 db.BuildPreparation
-		result2 bool
-		result3 error
-	}{result1, result2, result3}
+	fake.priorityReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
 }
 
 func (fake *FakeBuild) PrivatePlan() atc.Plan {
@@ -2150,6 +2955,181 @@ func (fake *FakeBuild) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) RequestApproval(arg1 atc.PlanID, arg2 atc.ApprovalPlan, arg3 time.Time) (atc.BuildApproval, error) {
+	fake.requestApprovalMutex.Lock()
+	ret, specificReturn := fake.requestApprovalReturnsOnCall[len(fake.requestApprovalArgsForCall)]
+	fake.requestApprovalArgsForCall = append(fake.requestApprovalArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 atc.ApprovalPlan
+		arg3 time.Time
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("RequestApproval", []interface{}{arg1, arg2, arg3})
+	fake.requestApprovalMutex.Unlock()
+	if fake.RequestApprovalStub != nil {
+		return fake.RequestApprovalStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.requestApprovalReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) RequestApprovalCallCount() int {
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	return len(fake.requestApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) RequestApprovalCalls(stub func(atc.PlanID, atc.ApprovalPlan, time.Time) (atc.BuildApproval, error)) {
+	fake.requestApprovalMutex.Lock()
+	defer fake.requestApprovalMutex.Unlock()
+	fake.RequestApprovalStub = stub
+}
+
+func (fake *FakeBuild) RequestApprovalArgsForCall(i int) (atc.PlanID, atc.ApprovalPlan, time.Time) {
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	argsForCall := fake.requestApprovalArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuild) RequestApprovalReturns(result1 atc.BuildApproval, result2 error) {
+	fake.requestApprovalMutex.Lock()
+	defer fake.requestApprovalMutex.Unlock()
+	fake.RequestApprovalStub = nil
+	fake.requestApprovalReturns = struct {
+		result1 atc.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) RequestApprovalReturnsOnCall(i int, result1 atc.BuildApproval, result2 error) {
+	fake.requestApprovalMutex.Lock()
+	defer fake.requestApprovalMutex.Unlock()
+	fake.RequestApprovalStub = nil
+	if fake.requestApprovalReturnsOnCall == nil {
+		fake.requestApprovalReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildApproval
+			result2 error
+		})
+	}
+	fake.requestApprovalReturnsOnCall[i] = struct {
+		result1 atc.BuildApproval
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) RerunInputs() ([]db.BuildInput, bool, error) {
+	fake.rerunInputsMutex.Lock()
+	ret, specificReturn := fake.rerunInputsReturnsOnCall[len(fake.rerunInputsArgsForCall)]
+	fake.rerunInputsArgsForCall = append(fake.rerunInputsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RerunInputs", []interface{}{})
+	fake.rerunInputsMutex.Unlock()
+	if fake.RerunInputsStub != nil {
+		return fake.RerunInputsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.rerunInputsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) RerunInputsCallCount() int {
+	fake.rerunInputsMutex.RLock()
+	defer fake.rerunInputsMutex.RUnlock()
+	return len(fake.rerunInputsArgsForCall)
+}
+
+func (fake *FakeBuild) RerunInputsCalls(stub func() ([]db.BuildInput, bool, error)) {
+	fake.rerunInputsMutex.Lock()
+	defer fake.rerunInputsMutex.Unlock()
+	fake.RerunInputsStub = stub
+}
+
+func (fake *FakeBuild) RerunInputsReturns(result1 []db.BuildInput, result2 bool, result3 error) {
+	fake.rerunInputsMutex.Lock()
+	defer fake.rerunInputsMutex.Unlock()
+	fake.RerunInputsStub = nil
+	fake.rerunInputsReturns = struct {
+		result1 []db.BuildInput
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) RerunInputsReturnsOnCall(i int, result1 []db.BuildInput, result2 bool, result3 error) {
+	fake.rerunInputsMutex.Lock()
+	defer fake.rerunInputsMutex.Unlock()
+	fake.RerunInputsStub = nil
+	if fake.rerunInputsReturnsOnCall == nil {
+		fake.rerunInputsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildInput
+			result2 bool
+			result3 error
+		})
+	}
+	fake.rerunInputsReturnsOnCall[i] = struct {
+		result1 []db.BuildInput
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) RerunOf() int {
+	fake.rerunOfMutex.Lock()
+	ret, specificReturn := fake.rerunOfReturnsOnCall[len(fake.rerunOfArgsForCall)]
+	fake.rerunOfArgsForCall = append(fake.rerunOfArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RerunOf", []interface{}{})
+	fake.rerunOfMutex.Unlock()
+	if fake.RerunOfStub != nil {
+		return fake.RerunOfStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.rerunOfReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) RerunOfCallCount() int {
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
+	return len(fake.rerunOfArgsForCall)
+}
+
+func (fake *FakeBuild) RerunOfCalls(stub func() int) {
+	fake.rerunOfMutex.Lock()
+	defer fake.rerunOfMutex.Unlock()
+	fake.RerunOfStub = stub
+}
+
+func (fake *FakeBuild) RerunOfReturns(result1 int) {
+	fake.rerunOfMutex.Lock()
+	defer fake.rerunOfMutex.Unlock()
+	fake.RerunOfStub = nil
+	fake.rerunOfReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) RerunOfReturnsOnCall(i int, result1 int) {
+	fake.rerunOfMutex.Lock()
+	defer fake.rerunOfMutex.Unlock()
+	fake.RerunOfStub = nil
+	if fake.rerunOfReturnsOnCall == nil {
+		fake.rerunOfReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.rerunOfReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuild) Resources() ([]db.BuildInput, []db.BuildOutput, error) {
 	fake.resourcesMutex.Lock()
 	ret, specificReturn := fake.resourcesReturnsOnCall[len(fake.resourcesArgsForCall)]
@@ -2208,6 +3188,68 @@ func (fake *FakeBuild) ResourcesReturnsOnCall(i int, result1 []db.BuildInput, re
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) SaveArchivedArtifact(arg1 atc.PlanID, arg2 string, arg3 int64) error {
+	fake.saveArchivedArtifactMutex.Lock()
+	ret, specificReturn := fake.saveArchivedArtifactReturnsOnCall[len(fake.saveArchivedArtifactArgsForCall)]
+	fake.saveArchivedArtifactArgsForCall = append(fake.saveArchivedArtifactArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 string
+		arg3 int64
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SaveArchivedArtifact", []interface{}{arg1, arg2, arg3})
+	fake.saveArchivedArtifactMutex.Unlock()
+	if fake.SaveArchivedArtifactStub != nil {
+		return fake.SaveArchivedArtifactStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveArchivedArtifactReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveArchivedArtifactCallCount() int {
+	fake.saveArchivedArtifactMutex.RLock()
+	defer fake.saveArchivedArtifactMutex.RUnlock()
+	return len(fake.saveArchivedArtifactArgsForCall)
+}
+
+func (fake *FakeBuild) SaveArchivedArtifactCalls(stub func(atc.PlanID, string, int64) error) {
+	fake.saveArchivedArtifactMutex.Lock()
+	defer fake.saveArchivedArtifactMutex.Unlock()
+	fake.SaveArchivedArtifactStub = stub
+}
+
+func (fake *FakeBuild) SaveArchivedArtifactArgsForCall(i int) (atc.PlanID, string, int64) {
+	fake.saveArchivedArtifactMutex.RLock()
+	defer fake.saveArchivedArtifactMutex.RUnlock()
+	argsForCall := fake.saveArchivedArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuild) SaveArchivedArtifactReturns(result1 error) {
+	fake.saveArchivedArtifactMutex.Lock()
+	defer fake.saveArchivedArtifactMutex.Unlock()
+	fake.SaveArchivedArtifactStub = nil
+	fake.saveArchivedArtifactReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveArchivedArtifactReturnsOnCall(i int, result1 error) {
+	fake.saveArchivedArtifactMutex.Lock()
+	defer fake.saveArchivedArtifactMutex.Unlock()
+	fake.SaveArchivedArtifactStub = nil
+	if fake.saveArchivedArtifactReturnsOnCall == nil {
+		fake.saveArchivedArtifactReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveArchivedArtifactReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SaveEvent(arg1 atc.Event) error {
 	fake.saveEventMutex.Lock()
 	ret, specificReturn := fake.saveEventReturnsOnCall[len(fake.saveEventArgsForCall)]
@@ -2394,15 +3436,86 @@ func (fake *FakeBuild) SaveOutputReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeBuild) Schedule() (bool, error) {
+func (fake *FakeBuild) SaveTestResults(arg1 []atc.TestResult) error {
+	var arg1Copy []atc.TestResult
+	if arg1 != nil {
+		arg1Copy = make([]atc.TestResult, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.saveTestResultsMutex.Lock()
+	ret, specificReturn := fake.saveTestResultsReturnsOnCall[len(fake.saveTestResultsArgsForCall)]
+	fake.saveTestResultsArgsForCall = append(fake.saveTestResultsArgsForCall, struct {
+		arg1 []atc.TestResult
+	}{arg1Copy})
+	fake.recordInvocation("SaveTestResults", []interface{}{arg1Copy})
+	fake.saveTestResultsMutex.Unlock()
+	if fake.SaveTestResultsStub != nil {
+		return fake.SaveTestResultsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveTestResultsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveTestResultsCallCount() int {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	return len(fake.saveTestResultsArgsForCall)
+}
+
+func (fake *FakeBuild) SaveTestResultsCalls(stub func([]atc.TestResult) error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = stub
+}
+
+func (fake *FakeBuild) SaveTestResultsArgsForCall(i int) []atc.TestResult {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	argsForCall := fake.saveTestResultsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveTestResultsReturns(result1 error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = nil
+	fake.saveTestResultsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveTestResultsReturnsOnCall(i int, result1 error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = nil
+	if fake.saveTestResultsReturnsOnCall == nil {
+		fake.saveTestResultsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveTestResultsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) Schedule(arg1 []atc.SemaphoreConfig) (bool, error) {
+	var arg1Copy []atc.SemaphoreConfig
+	if arg1 != nil {
+		arg1Copy = make([]atc.SemaphoreConfig, len(arg1))
+		copy(arg1Copy, arg1)
+	}
 	fake.scheduleMutex.Lock()
 	ret, specificReturn := fake.scheduleReturnsOnCall[len(fake.scheduleArgsForCall)]
 	fake.scheduleArgsForCall = append(fake.scheduleArgsForCall, struct {
-	}{})
-	fake.recordInvocation("Schedule", []interface{}{})
+		arg1 []atc.SemaphoreConfig
+	}{arg1Copy})
+	fake.recordInvocation("Schedule", []interface{}{arg1Copy})
 	fake.scheduleMutex.Unlock()
 	if fake.ScheduleStub != nil {
-		return fake.ScheduleStub()
+		return fake.ScheduleStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2417,12 +3530,19 @@ func (fake *FakeBuild) ScheduleCallCount() int {
 	return len(fake.scheduleArgsForCall)
 }
 
-func (fake *FakeBuild) ScheduleCalls(stub func() (bool, error)) {
+func (fake *FakeBuild) ScheduleCalls(stub func([]atc.SemaphoreConfig) (bool, error)) {
 	fake.scheduleMutex.Lock()
 	defer fake.scheduleMutex.Unlock()
 	fake.ScheduleStub = stub
 }
 
+func (fake *FakeBuild) ScheduleArgsForCall(i int) []atc.SemaphoreConfig {
+	fake.scheduleMutex.RLock()
+	defer fake.scheduleMutex.RUnlock()
+	argsForCall := fake.scheduleArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeBuild) ScheduleReturns(result1 bool, result2 error) {
 	fake.scheduleMutex.Lock()
 	defer fake.scheduleMutex.Unlock()
@@ -2501,6 +3621,66 @@ func (fake *FakeBuild) SchemaReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SetCreatedBy(arg1 atc.BuildCreator) error {
+	fake.setCreatedByMutex.Lock()
+	ret, specificReturn := fake.setCreatedByReturnsOnCall[len(fake.setCreatedByArgsForCall)]
+	fake.setCreatedByArgsForCall = append(fake.setCreatedByArgsForCall, struct {
+		arg1 atc.BuildCreator
+	}{arg1})
+	fake.recordInvocation("SetCreatedBy", []interface{}{arg1})
+	fake.setCreatedByMutex.Unlock()
+	if fake.SetCreatedByStub != nil {
+		return fake.SetCreatedByStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setCreatedByReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SetCreatedByCallCount() int {
+	fake.setCreatedByMutex.RLock()
+	defer fake.setCreatedByMutex.RUnlock()
+	return len(fake.setCreatedByArgsForCall)
+}
+
+func (fake *FakeBuild) SetCreatedByCalls(stub func(atc.BuildCreator) error) {
+	fake.setCreatedByMutex.Lock()
+	defer fake.setCreatedByMutex.Unlock()
+	fake.SetCreatedByStub = stub
+}
+
+func (fake *FakeBuild) SetCreatedByArgsForCall(i int) atc.BuildCreator {
+	fake.setCreatedByMutex.RLock()
+	defer fake.setCreatedByMutex.RUnlock()
+	argsForCall := fake.setCreatedByArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SetCreatedByReturns(result1 error) {
+	fake.setCreatedByMutex.Lock()
+	defer fake.setCreatedByMutex.Unlock()
+	fake.SetCreatedByStub = nil
+	fake.setCreatedByReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetCreatedByReturnsOnCall(i int, result1 error) {
+	fake.setCreatedByMutex.Lock()
+	defer fake.setCreatedByMutex.Unlock()
+	fake.SetCreatedByStub = nil
+	if fake.setCreatedByReturnsOnCall == nil {
+		fake.setCreatedByReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setCreatedByReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SetDrained(arg1 bool) error {
 	fake.setDrainedMutex.Lock()
 	ret, specificReturn := fake.setDrainedReturnsOnCall[len(fake.setDrainedArgsForCall)]
@@ -2621,6 +3801,66 @@ func (fake *FakeBuild) SetInterceptibleReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SetPriority(arg1 int) error {
+	fake.setPriorityMutex.Lock()
+	ret, specificReturn := fake.setPriorityReturnsOnCall[len(fake.setPriorityArgsForCall)]
+	fake.setPriorityArgsForCall = append(fake.setPriorityArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("SetPriority", []interface{}{arg1})
+	fake.setPriorityMutex.Unlock()
+	if fake.SetPriorityStub != nil {
+		return fake.SetPriorityStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setPriorityReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SetPriorityCallCount() int {
+	fake.setPriorityMutex.RLock()
+	defer fake.setPriorityMutex.RUnlock()
+	return len(fake.setPriorityArgsForCall)
+}
+
+func (fake *FakeBuild) SetPriorityCalls(stub func(int) error) {
+	fake.setPriorityMutex.Lock()
+	defer fake.setPriorityMutex.Unlock()
+	fake.SetPriorityStub = stub
+}
+
+func (fake *FakeBuild) SetPriorityArgsForCall(i int) int {
+	fake.setPriorityMutex.RLock()
+	defer fake.setPriorityMutex.RUnlock()
+	argsForCall := fake.setPriorityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SetPriorityReturns(result1 error) {
+	fake.setPriorityMutex.Lock()
+	defer fake.setPriorityMutex.Unlock()
+	fake.SetPriorityStub = nil
+	fake.setPriorityReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetPriorityReturnsOnCall(i int, result1 error) {
+	fake.setPriorityMutex.Lock()
+	defer fake.setPriorityMutex.Unlock()
+	fake.SetPriorityStub = nil
+	if fake.setPriorityReturnsOnCall == nil {
+		fake.setPriorityReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setPriorityReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Start(arg1 atc.Plan) (bool, error) {
 	fake.startMutex.Lock()
 	ret, specificReturn := fake.startReturnsOnCall[len(fake.startArgsForCall)]
@@ -2892,6 +4132,113 @@ func (fake *FakeBuild) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) TestResults() ([]atc.TestResult, error) {
+	fake.testResultsMutex.Lock()
+	ret, specificReturn := fake.testResultsReturnsOnCall[len(fake.testResultsArgsForCall)]
+	fake.testResultsArgsForCall = append(fake.testResultsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("TestResults", []interface{}{})
+	fake.testResultsMutex.Unlock()
+	if fake.TestResultsStub != nil {
+		return fake.TestResultsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.testResultsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) TestResultsCallCount() int {
+	fake.testResultsMutex.RLock()
+	defer fake.testResultsMutex.RUnlock()
+	return len(fake.testResultsArgsForCall)
+}
+
+func (fake *FakeBuild) TestResultsCalls(stub func() ([]atc.TestResult, error)) {
+	fake.testResultsMutex.Lock()
+	defer fake.testResultsMutex.Unlock()
+	fake.TestResultsStub = stub
+}
+
+func (fake *FakeBuild) TestResultsReturns(result1 []atc.TestResult, result2 error) {
+	fake.testResultsMutex.Lock()
+	defer fake.testResultsMutex.Unlock()
+	fake.TestResultsStub = nil
+	fake.testResultsReturns = struct {
+		result1 []atc.TestResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) TestResultsReturnsOnCall(i int, result1 []atc.TestResult, result2 error) {
+	fake.testResultsMutex.Lock()
+	defer fake.testResultsMutex.Unlock()
+	fake.TestResultsStub = nil
+	if fake.testResultsReturnsOnCall == nil {
+		fake.testResultsReturnsOnCall = make(map[int]struct {
+			result1 []atc.TestResult
+			result2 error
+		})
+	}
+	fake.testResultsReturnsOnCall[i] = struct {
+		result1 []atc.TestResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) TestSummary() *atc.TestSummary {
+	fake.testSummaryMutex.Lock()
+	ret, specificReturn := fake.testSummaryReturnsOnCall[len(fake.testSummaryArgsForCall)]
+	fake.testSummaryArgsForCall = append(fake.testSummaryArgsForCall, struct {
+	}{})
+	fake.recordInvocation("TestSummary", []interface{}{})
+	fake.testSummaryMutex.Unlock()
+	if fake.TestSummaryStub != nil {
+		return fake.TestSummaryStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.testSummaryReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) TestSummaryCallCount() int {
+	fake.testSummaryMutex.RLock()
+	defer fake.testSummaryMutex.RUnlock()
+	return len(fake.testSummaryArgsForCall)
+}
+
+func (fake *FakeBuild) TestSummaryCalls(stub func() *atc.TestSummary) {
+	fake.testSummaryMutex.Lock()
+	defer fake.testSummaryMutex.Unlock()
+	fake.TestSummaryStub = stub
+}
+
+func (fake *FakeBuild) TestSummaryReturns(result1 *atc.TestSummary) {
+	fake.testSummaryMutex.Lock()
+	defer fake.testSummaryMutex.Unlock()
+	fake.TestSummaryStub = nil
+	fake.testSummaryReturns = struct {
+		result1 *atc.TestSummary
+	}{result1}
+}
+
+func (fake *FakeBuild) TestSummaryReturnsOnCall(i int, result1 *atc.TestSummary) {
+	fake.testSummaryMutex.Lock()
+	defer fake.testSummaryMutex.Unlock()
+	fake.TestSummaryStub = nil
+	if fake.testSummaryReturnsOnCall == nil {
+		fake.testSummaryReturnsOnCall = make(map[int]struct {
+			result1 *atc.TestSummary
+		})
+	}
+	fake.testSummaryReturnsOnCall[i] = struct {
+		result1 *atc.TestSummary
+	}{result1}
+}
+
 func (fake *FakeBuild) UseInputs(arg1 []db.BuildInput) error {
 	var arg1Copy []db.BuildInput
 	if arg1 != nil {
@@ -2964,16 +4311,32 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.abortNotifierMutex.RUnlock()
 	fake.acquireTrackingLockMutex.RLock()
 	defer fake.acquireTrackingLockMutex.RUnlock()
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	fake.approvalsMutex.RLock()
+	defer fake.approvalsMutex.RUnlock()
+	fake.archivedArtifactsMutex.RLock()
+	defer fake.archivedArtifactsMutex.RUnlock()
 	fake.artifactMutex.RLock()
 	defer fake.artifactMutex.RUnlock()
 	fake.artifactsMutex.RLock()
 	defer fake.artifactsMutex.RUnlock()
+	fake.createdByMutex.RLock()
+	defer fake.createdByMutex.RUnlock()
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.deleteArchivedArtifactsMutex.RLock()
+	defer fake.deleteArchivedArtifactsMutex.RUnlock()
 	fake.endTimeMutex.RLock()
 	defer fake.endTimeMutex.RUnlock()
 	fake.eventsMutex.RLock()
 	defer fake.eventsMutex.RUnlock()
+	fake.expireApprovalMutex.RLock()
+	defer fake.expireApprovalMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
 	fake.hasPlanMutex.RLock()
@@ -3008,10 +4371,14 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
 	defer fake.pipelineIDMutex.RUnlock()
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
 	fake.pipelineNameMutex.RLock()
 	defer fake.pipelineNameMutex.RUnlock()
 	fake.preparationMutex.RLock()
 	defer fake.preparationMutex.RUnlock()
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
 	fake.privatePlanMutex.RLock()
 	defer fake.privatePlanMutex.RUnlock()
 	fake.publicPlanMutex.RLock()
@@ -3020,22 +4387,36 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.reapTimeMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	fake.rerunInputsMutex.RLock()
+	defer fake.rerunInputsMutex.RUnlock()
+	fake.rerunOfMutex.RLock()
+	defer fake.rerunOfMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
+	fake.saveArchivedArtifactMutex.RLock()
+	defer fake.saveArchivedArtifactMutex.RUnlock()
 	fake.saveEventMutex.RLock()
 	defer fake.saveEventMutex.RUnlock()
 	fake.saveImageResourceVersionMutex.RLock()
 	defer fake.saveImageResourceVersionMutex.RUnlock()
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
 	fake.scheduleMutex.RLock()
 	defer fake.scheduleMutex.RUnlock()
 	fake.schemaMutex.RLock()
 	defer fake.schemaMutex.RUnlock()
+	fake.setCreatedByMutex.RLock()
+	defer fake.setCreatedByMutex.RUnlock()
 	fake.setDrainedMutex.RLock()
 	defer fake.setDrainedMutex.RUnlock()
 	fake.setInterceptibleMutex.RLock()
 	defer fake.setInterceptibleMutex.RUnlock()
+	fake.setPriorityMutex.RLock()
+	defer fake.setPriorityMutex.RUnlock()
 	fake.startMutex.RLock()
 	defer fake.startMutex.RUnlock()
 	fake.startTimeMutex.RLock()
@@ -3046,6 +4427,10 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.testResultsMutex.RLock()
+	defer fake.testResultsMutex.RUnlock()
+	fake.testSummaryMutex.RLock()
+	defer fake.testSummaryMutex.RUnlock()
 	fake.useInputsMutex.RLock()
 	defer fake.useInputsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -3,6 +3,7 @@ package dbfakes
 
 import (
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -79,9 +80,10 @@ type FakeJob struct {
 	configReturnsOnCall map[int]struct {
 		result1 atc.JobConfig
 	}
-	CreateBuildStub        func() (db.Build, error)
+	CreateBuildStub        func(atc.BuildCreator) (db.Build, error)
 	createBuildMutex       sync.RWMutex
 	createBuildArgsForCall []struct {
+		arg1 atc.BuildCreator
 	}
 	createBuildReturns struct {
 		result1 db.Build
@@ -101,9 +103,10 @@ type FakeJob struct {
 	deleteNextInputMappingReturnsOnCall map[int]struct {
 		result1 error
 	}
-	EnsurePendingBuildExistsStub        func() error
+	EnsurePendingBuildExistsStub        func(atc.BuildCreator) error
 	ensurePendingBuildExistsMutex       sync.RWMutex
 	ensurePendingBuildExistsArgsForCall []struct {
+		arg1 atc.BuildCreator
 	}
 	ensurePendingBuildExistsReturns struct {
 		result1 error
@@ -111,6 +114,20 @@ type FakeJob struct {
 	ensurePendingBuildExistsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	EnsureScheduledBuildExistsStub        func(time.Time, time.Time) (bool, error)
+	ensureScheduledBuildExistsMutex       sync.RWMutex
+	ensureScheduledBuildExistsArgsForCall []struct {
+		arg1 time.Time
+		arg2 time.Time
+	}
+	ensureScheduledBuildExistsReturns struct {
+		result1 bool
+		result2 error
+	}
+	ensureScheduledBuildExistsReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	FinishedAndNextBuildStub        func() (db.Build, db.Build, error)
 	finishedAndNextBuildMutex       sync.RWMutex
 	finishedAndNextBuildArgsForCall []struct {
@@ -261,6 +278,16 @@ type FakeJob struct {
 	pipelineIDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	PipelineInstanceVarsStub        func() atc.InstanceVars
+	pipelineInstanceVarsMutex       sync.RWMutex
+	pipelineInstanceVarsArgsForCall []struct {
+	}
+	pipelineInstanceVarsReturns struct {
+		result1 atc.InstanceVars
+	}
+	pipelineInstanceVarsReturnsOnCall map[int]struct {
+		result1 atc.InstanceVars
+	}
 	PipelineNameStub        func() string
 	pipelineNameMutex       sync.RWMutex
 	pipelineNameArgsForCall []struct {
@@ -293,6 +320,20 @@ type FakeJob struct {
 		result1 bool
 		result2 error
 	}
+	RerunBuildStub        func(db.Build, atc.BuildCreator) (db.Build, error)
+	rerunBuildMutex       sync.RWMutex
+	rerunBuildArgsForCall []struct {
+		arg1 db.Build
+		arg2 atc.BuildCreator
+	}
+	rerunBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	rerunBuildReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	SaveIndependentInputMappingStub        func(algorithm.InputMapping) error
 	saveIndependentInputMappingMutex       sync.RWMutex
 	saveIndependentInputMappingArgsForCall []struct {
@@ -315,6 +356,16 @@ type FakeJob struct {
 	saveNextInputMappingReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ScheduleTimeStub        func() time.Time
+	scheduleTimeMutex       sync.RWMutex
+	scheduleTimeArgsForCall []struct {
+	}
+	scheduleTimeReturns struct {
+		result1 time.Time
+	}
+	scheduleTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	SetHasNewInputsStub        func(bool) error
 	setHasNewInputsMutex       sync.RWMutex
 	setHasNewInputsArgsForCall []struct {
@@ -337,6 +388,20 @@ type FakeJob struct {
 	setMaxInFlightReachedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StatsStub        func(time.Time, time.Time) (atc.JobStats, error)
+	statsMutex       sync.RWMutex
+	statsArgsForCall []struct {
+		arg1 time.Time
+		arg2 time.Time
+	}
+	statsReturns struct {
+		result1 atc.JobStats
+		result2 error
+	}
+	statsReturnsOnCall map[int]struct {
+		result1 atc.JobStats
+		result2 error
+	}
 	TagsStub        func() []string
 	tagsMutex       sync.RWMutex
 	tagsArgsForCall []struct {
@@ -367,6 +432,19 @@ type FakeJob struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TestHistoryStub        func(int) ([]atc.TestHistory, error)
+	testHistoryMutex       sync.RWMutex
+	testHistoryArgsForCall []struct {
+		arg1 int
+	}
+	testHistoryReturns struct {
+		result1 []atc.TestHistory
+		result2 error
+	}
+	testHistoryReturnsOnCall map[int]struct {
+		result1 []atc.TestHistory
+		result2 error
+	}
 	UnpauseStub        func() error
 	unpauseMutex       sync.RWMutex
 	unpauseArgsForCall []struct {
@@ -706,15 +784,16 @@ func (fake *FakeJob) ConfigReturnsOnCall(i int, result1 atc.JobConfig) {
 	}{result1}
 }
 
-func (fake *FakeJob) CreateBuild() (db.Build, error) {
+func (fake *FakeJob) CreateBuild(arg1 atc.BuildCreator) (db.Build, error) {
 	fake.createBuildMutex.Lock()
 	ret, specificReturn := fake.createBuildReturnsOnCall[len(fake.createBuildArgsForCall)]
 	fake.createBuildArgsForCall = append(fake.createBuildArgsForCall, struct {
-	}{})
-	fake.recordInvocation("CreateBuild", []interface{}{})
+		arg1 atc.BuildCreator
+	}{arg1})
+	fake.recordInvocation("CreateBuild", []interface{}{arg1})
 	fake.createBuildMutex.Unlock()
 	if fake.CreateBuildStub != nil {
-		return fake.CreateBuildStub()
+		return fake.CreateBuildStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -729,12 +808,19 @@ func (fake *FakeJob) CreateBuildCallCount() int {
 	return len(fake.createBuildArgsForCall)
 }
 
-func (fake *FakeJob) CreateBuildCalls(stub func() (db.Build, error)) {
+func (fake *FakeJob) CreateBuildCalls(stub func(atc.BuildCreator) (db.Build, error)) {
 	fake.createBuildMutex.Lock()
 	defer fake.createBuildMutex.Unlock()
 	fake.CreateBuildStub = stub
 }
 
+func (fake *FakeJob) CreateBuildArgsForCall(i int) atc.BuildCreator {
+	fake.createBuildMutex.RLock()
+	defer fake.createBuildMutex.RUnlock()
+	argsForCall := fake.createBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeJob) CreateBuildReturns(result1 db.Build, result2 error) {
 	fake.createBuildMutex.Lock()
 	defer fake.createBuildMutex.Unlock()
@@ -813,15 +899,16 @@ func (fake *FakeJob) DeleteNextInputMappingReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeJob) EnsurePendingBuildExists() error {
+func (fake *FakeJob) EnsurePendingBuildExists(arg1 atc.BuildCreator) error {
 	fake.ensurePendingBuildExistsMutex.Lock()
 	ret, specificReturn := fake.ensurePendingBuildExistsReturnsOnCall[len(fake.ensurePendingBuildExistsArgsForCall)]
 	fake.ensurePendingBuildExistsArgsForCall = append(fake.ensurePendingBuildExistsArgsForCall, struct {
-	}{})
-	fake.recordInvocation("EnsurePendingBuildExists", []interface{}{})
+		arg1 atc.BuildCreator
+	}{arg1})
+	fake.recordInvocation("EnsurePendingBuildExists", []interface{}{arg1})
 	fake.ensurePendingBuildExistsMutex.Unlock()
 	if fake.EnsurePendingBuildExistsStub != nil {
-		return fake.EnsurePendingBuildExistsStub()
+		return fake.EnsurePendingBuildExistsStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -836,12 +923,19 @@ func (fake *FakeJob) EnsurePendingBuildExistsCallCount() int {
 	return len(fake.ensurePendingBuildExistsArgsForCall)
 }
 
-func (fake *FakeJob) EnsurePendingBuildExistsCalls(stub func() error) {
+func (fake *FakeJob) EnsurePendingBuildExistsCalls(stub func(atc.BuildCreator) error) {
 	fake.ensurePendingBuildExistsMutex.Lock()
 	defer fake.ensurePendingBuildExistsMutex.Unlock()
 	fake.EnsurePendingBuildExistsStub = stub
 }
 
+func (fake *FakeJob) EnsurePendingBuildExistsArgsForCall(i int) atc.BuildCreator {
+	fake.ensurePendingBuildExistsMutex.RLock()
+	defer fake.ensurePendingBuildExistsMutex.RUnlock()
+	argsForCall := fake.ensurePendingBuildExistsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeJob) EnsurePendingBuildExistsReturns(result1 error) {
 	fake.ensurePendingBuildExistsMutex.Lock()
 	defer fake.ensurePendingBuildExistsMutex.Unlock()
@@ -865,6 +959,70 @@ func (fake *FakeJob) EnsurePendingBuildExistsReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeJob) EnsureScheduledBuildExists(arg1 time.Time, arg2 time.Time) (bool, error) {
+	fake.ensureScheduledBuildExistsMutex.Lock()
+	ret, specificReturn := fake.ensureScheduledBuildExistsReturnsOnCall[len(fake.ensureScheduledBuildExistsArgsForCall)]
+	fake.ensureScheduledBuildExistsArgsForCall = append(fake.ensureScheduledBuildExistsArgsForCall, struct {
+		arg1 time.Time
+		arg2 time.Time
+	}{arg1, arg2})
+	fake.recordInvocation("EnsureScheduledBuildExists", []interface{}{arg1, arg2})
+	fake.ensureScheduledBuildExistsMutex.Unlock()
+	if fake.EnsureScheduledBuildExistsStub != nil {
+		return fake.EnsureScheduledBuildExistsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.ensureScheduledBuildExistsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) EnsureScheduledBuildExistsCallCount() int {
+	fake.ensureScheduledBuildExistsMutex.RLock()
+	defer fake.ensureScheduledBuildExistsMutex.RUnlock()
+	return len(fake.ensureScheduledBuildExistsArgsForCall)
+}
+
+func (fake *FakeJob) EnsureScheduledBuildExistsCalls(stub func(time.Time, time.Time) (bool, error)) {
+	fake.ensureScheduledBuildExistsMutex.Lock()
+	defer fake.ensureScheduledBuildExistsMutex.Unlock()
+	fake.EnsureScheduledBuildExistsStub = stub
+}
+
+func (fake *FakeJob) EnsureScheduledBuildExistsArgsForCall(i int) (time.Time, time.Time) {
+	fake.ensureScheduledBuildExistsMutex.RLock()
+	defer fake.ensureScheduledBuildExistsMutex.RUnlock()
+	argsForCall := fake.ensureScheduledBuildExistsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) EnsureScheduledBuildExistsReturns(result1 bool, result2 error) {
+	fake.ensureScheduledBuildExistsMutex.Lock()
+	defer fake.ensureScheduledBuildExistsMutex.Unlock()
+	fake.EnsureScheduledBuildExistsStub = nil
+	fake.ensureScheduledBuildExistsReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) EnsureScheduledBuildExistsReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.ensureScheduledBuildExistsMutex.Lock()
+	defer fake.ensureScheduledBuildExistsMutex.Unlock()
+	fake.EnsureScheduledBuildExistsStub = nil
+	if fake.ensureScheduledBuildExistsReturnsOnCall == nil {
+		fake.ensureScheduledBuildExistsReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.ensureScheduledBuildExistsReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) FinishedAndNextBuild() (db.Build, db.Build, error) {
 	fake.finishedAndNextBuildMutex.Lock()
 	ret, specificReturn := fake.finishedAndNextBuildReturnsOnCall[len(fake.finishedAndNextBuildArgsForCall)]
@@ -1594,6 +1752,58 @@ func (fake *FakeJob) PipelineIDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeJob) PipelineInstanceVars() atc.InstanceVars {
+	fake.pipelineInstanceVarsMutex.Lock()
+	ret, specificReturn := fake.pipelineInstanceVarsReturnsOnCall[len(fake.pipelineInstanceVarsArgsForCall)]
+	fake.pipelineInstanceVarsArgsForCall = append(fake.pipelineInstanceVarsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineInstanceVars", []interface{}{})
+	fake.pipelineInstanceVarsMutex.Unlock()
+	if fake.PipelineInstanceVarsStub != nil {
+		return fake.PipelineInstanceVarsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelineInstanceVarsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) PipelineInstanceVarsCallCount() int {
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
+	return len(fake.pipelineInstanceVarsArgsForCall)
+}
+
+func (fake *FakeJob) PipelineInstanceVarsCalls(stub func() atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = stub
+}
+
+func (fake *FakeJob) PipelineInstanceVarsReturns(result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	fake.pipelineInstanceVarsReturns = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
+func (fake *FakeJob) PipelineInstanceVarsReturnsOnCall(i int, result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	if fake.pipelineInstanceVarsReturnsOnCall == nil {
+		fake.pipelineInstanceVarsReturnsOnCall = make(map[int]struct {
+			result1 atc.InstanceVars
+		})
+	}
+	fake.pipelineInstanceVarsReturnsOnCall[i] = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
 func (fake *FakeJob) PipelineName() string {
 	fake.pipelineNameMutex.Lock()
 	ret, specificReturn := fake.pipelineNameReturnsOnCall[len(fake.pipelineNameArgsForCall)]
@@ -1753,6 +1963,70 @@ func (fake *FakeJob) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeJob) RerunBuild(arg1 db.Build, arg2 atc.BuildCreator) (db.Build, error) {
+	fake.rerunBuildMutex.Lock()
+	ret, specificReturn := fake.rerunBuildReturnsOnCall[len(fake.rerunBuildArgsForCall)]
+	fake.rerunBuildArgsForCall = append(fake.rerunBuildArgsForCall, struct {
+		arg1 db.Build
+		arg2 atc.BuildCreator
+	}{arg1, arg2})
+	fake.recordInvocation("RerunBuild", []interface{}{arg1, arg2})
+	fake.rerunBuildMutex.Unlock()
+	if fake.RerunBuildStub != nil {
+		return fake.RerunBuildStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.rerunBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) RerunBuildCallCount() int {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	return len(fake.rerunBuildArgsForCall)
+}
+
+func (fake *FakeJob) RerunBuildCalls(stub func(db.Build, atc.BuildCreator) (db.Build, error)) {
+	fake.rerunBuildMutex.Lock()
+	defer fake.rerunBuildMutex.Unlock()
+	fake.RerunBuildStub = stub
+}
+
+func (fake *FakeJob) RerunBuildArgsForCall(i int) (db.Build, atc.BuildCreator) {
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
+	argsForCall := fake.rerunBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) RerunBuildReturns(result1 db.Build, result2 error) {
+	fake.rerunBuildMutex.Lock()
+	defer fake.rerunBuildMutex.Unlock()
+	fake.RerunBuildStub = nil
+	fake.rerunBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) RerunBuildReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.rerunBuildMutex.Lock()
+	defer fake.rerunBuildMutex.Unlock()
+	fake.RerunBuildStub = nil
+	if fake.rerunBuildReturnsOnCall == nil {
+		fake.rerunBuildReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.rerunBuildReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) SaveIndependentInputMapping(arg1 algorithm.InputMapping) error {
 	fake.saveIndependentInputMappingMutex.Lock()
 	ret, specificReturn := fake.saveIndependentInputMappingReturnsOnCall[len(fake.saveIndependentInputMappingArgsForCall)]
@@ -1873,6 +2147,58 @@ func (fake *FakeJob) SaveNextInputMappingReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJob) ScheduleTime() time.Time {
+	fake.scheduleTimeMutex.Lock()
+	ret, specificReturn := fake.scheduleTimeReturnsOnCall[len(fake.scheduleTimeArgsForCall)]
+	fake.scheduleTimeArgsForCall = append(fake.scheduleTimeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ScheduleTime", []interface{}{})
+	fake.scheduleTimeMutex.Unlock()
+	if fake.ScheduleTimeStub != nil {
+		return fake.ScheduleTimeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.scheduleTimeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) ScheduleTimeCallCount() int {
+	fake.scheduleTimeMutex.RLock()
+	defer fake.scheduleTimeMutex.RUnlock()
+	return len(fake.scheduleTimeArgsForCall)
+}
+
+func (fake *FakeJob) ScheduleTimeCalls(stub func() time.Time) {
+	fake.scheduleTimeMutex.Lock()
+	defer fake.scheduleTimeMutex.Unlock()
+	fake.ScheduleTimeStub = stub
+}
+
+func (fake *FakeJob) ScheduleTimeReturns(result1 time.Time) {
+	fake.scheduleTimeMutex.Lock()
+	defer fake.scheduleTimeMutex.Unlock()
+	fake.ScheduleTimeStub = nil
+	fake.scheduleTimeReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeJob) ScheduleTimeReturnsOnCall(i int, result1 time.Time) {
+	fake.scheduleTimeMutex.Lock()
+	defer fake.scheduleTimeMutex.Unlock()
+	fake.ScheduleTimeStub = nil
+	if fake.scheduleTimeReturnsOnCall == nil {
+		fake.scheduleTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.scheduleTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeJob) SetHasNewInputs(arg1 bool) error {
 	fake.setHasNewInputsMutex.Lock()
 	ret, specificReturn := fake.setHasNewInputsReturnsOnCall[len(fake.setHasNewInputsArgsForCall)]
@@ -1993,6 +2319,70 @@ func (fake *FakeJob) SetMaxInFlightReachedReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeJob) Stats(arg1 time.Time, arg2 time.Time) (atc.JobStats, error) {
+	fake.statsMutex.Lock()
+	ret, specificReturn := fake.statsReturnsOnCall[len(fake.statsArgsForCall)]
+	fake.statsArgsForCall = append(fake.statsArgsForCall, struct {
+		arg1 time.Time
+		arg2 time.Time
+	}{arg1, arg2})
+	fake.recordInvocation("Stats", []interface{}{arg1, arg2})
+	fake.statsMutex.Unlock()
+	if fake.StatsStub != nil {
+		return fake.StatsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.statsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) StatsCallCount() int {
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	return len(fake.statsArgsForCall)
+}
+
+func (fake *FakeJob) StatsCalls(stub func(time.Time, time.Time) (atc.JobStats, error)) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = stub
+}
+
+func (fake *FakeJob) StatsArgsForCall(i int) (time.Time, time.Time) {
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	argsForCall := fake.statsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeJob) StatsReturns(result1 atc.JobStats, result2 error) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	fake.statsReturns = struct {
+		result1 atc.JobStats
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) StatsReturnsOnCall(i int, result1 atc.JobStats, result2 error) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	if fake.statsReturnsOnCall == nil {
+		fake.statsReturnsOnCall = make(map[int]struct {
+			result1 atc.JobStats
+			result2 error
+		})
+	}
+	fake.statsReturnsOnCall[i] = struct {
+		result1 atc.JobStats
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) Tags() []string {
 	fake.tagsMutex.Lock()
 	ret, specificReturn := fake.tagsReturnsOnCall[len(fake.tagsArgsForCall)]
@@ -2149,6 +2539,69 @@ func (fake *FakeJob) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeJob) TestHistory(arg1 int) ([]atc.TestHistory, error) {
+	fake.testHistoryMutex.Lock()
+	ret, specificReturn := fake.testHistoryReturnsOnCall[len(fake.testHistoryArgsForCall)]
+	fake.testHistoryArgsForCall = append(fake.testHistoryArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("TestHistory", []interface{}{arg1})
+	fake.testHistoryMutex.Unlock()
+	if fake.TestHistoryStub != nil {
+		return fake.TestHistoryStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.testHistoryReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) TestHistoryCallCount() int {
+	fake.testHistoryMutex.RLock()
+	defer fake.testHistoryMutex.RUnlock()
+	return len(fake.testHistoryArgsForCall)
+}
+
+func (fake *FakeJob) TestHistoryCalls(stub func(int) ([]atc.TestHistory, error)) {
+	fake.testHistoryMutex.Lock()
+	defer fake.testHistoryMutex.Unlock()
+	fake.TestHistoryStub = stub
+}
+
+func (fake *FakeJob) TestHistoryArgsForCall(i int) int {
+	fake.testHistoryMutex.RLock()
+	defer fake.testHistoryMutex.RUnlock()
+	argsForCall := fake.testHistoryArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) TestHistoryReturns(result1 []atc.TestHistory, result2 error) {
+	fake.testHistoryMutex.Lock()
+	defer fake.testHistoryMutex.Unlock()
+	fake.TestHistoryStub = nil
+	fake.testHistoryReturns = struct {
+		result1 []atc.TestHistory
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) TestHistoryReturnsOnCall(i int, result1 []atc.TestHistory, result2 error) {
+	fake.testHistoryMutex.Lock()
+	defer fake.testHistoryMutex.Unlock()
+	fake.TestHistoryStub = nil
+	if fake.testHistoryReturnsOnCall == nil {
+		fake.testHistoryReturnsOnCall = make(map[int]struct {
+			result1 []atc.TestHistory
+			result2 error
+		})
+	}
+	fake.testHistoryReturnsOnCall[i] = struct {
+		result1 []atc.TestHistory
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) Unpause() error {
 	fake.unpauseMutex.Lock()
 	ret, specificReturn := fake.unpauseReturnsOnCall[len(fake.unpauseArgsForCall)]
@@ -2280,6 +2733,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.deleteNextInputMappingMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
 	defer fake.ensurePendingBuildExistsMutex.RUnlock()
+	fake.ensureScheduledBuildExistsMutex.RLock()
+	defer fake.ensureScheduledBuildExistsMutex.RUnlock()
 	fake.finishedAndNextBuildMutex.RLock()
 	defer fake.finishedAndNextBuildMutex.RUnlock()
 	fake.firstLoggedBuildIDMutex.RLock()
@@ -2306,26 +2761,36 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.pausedMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
 	defer fake.pipelineIDMutex.RUnlock()
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
 	fake.pipelineNameMutex.RLock()
 	defer fake.pipelineNameMutex.RUnlock()
 	fake.publicMutex.RLock()
 	defer fake.publicMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.rerunBuildMutex.RLock()
+	defer fake.rerunBuildMutex.RUnlock()
 	fake.saveIndependentInputMappingMutex.RLock()
 	defer fake.saveIndependentInputMappingMutex.RUnlock()
 	fake.saveNextInputMappingMutex.RLock()
 	defer fake.saveNextInputMappingMutex.RUnlock()
+	fake.scheduleTimeMutex.RLock()
+	defer fake.scheduleTimeMutex.RUnlock()
 	fake.setHasNewInputsMutex.RLock()
 	defer fake.setHasNewInputsMutex.RUnlock()
 	fake.setMaxInFlightReachedMutex.RLock()
 	defer fake.setMaxInFlightReachedMutex.RUnlock()
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
 	fake.teamIDMutex.RLock()
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.testHistoryMutex.RLock()
+	defer fake.testHistoryMutex.RUnlock()
 	fake.unpauseMutex.RLock()
 	defer fake.unpauseMutex.RUnlock()
 	fake.updateFirstLoggedBuildIDMutex.RLock()
@@ -85,10 +85,11 @@ type FakeTeam struct {
 		result1 db.Build
 		result2 error
 	}
-	CreateStartedBuildStub        func(atc.Plan) (db.Build, error)
+	CreateStartedBuildStub        func(atc.Plan, atc.BuildCreator) (db.Build, error)
 	createStartedBuildMutex       sync.RWMutex
 	createStartedBuildArgsForCall []struct {
 		arg1 atc.Plan
+		arg2 atc.BuildCreator
 	}
 	createStartedBuildReturns struct {
 		result1 db.Build
@@ -108,12 +109,13 @@ type FakeTeam struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
-	FindCheckContainersStub        func(string, string, creds.Secrets) ([]db.Container, map[int]time.Time, error)
+	FindCheckContainersStub        func(atc.PipelineRef, string, creds.Secrets, creds.VarSourcePool) ([]db.Container, map[int]time.Time, error)
 	findCheckContainersMutex       sync.RWMutex
 	findCheckContainersArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 creds.Secrets
+		arg4 creds.VarSourcePool
 	}
 	findCheckContainersReturns struct {
 		result1 []db.Container
@@ -271,10 +273,10 @@ type FakeTeam struct {
 	orderPipelinesReturnsOnCall map[int]struct {
 		result1 error
 	}
-	PipelineStub        func(string) (db.Pipeline, bool, error)
+	PipelineStub        func(atc.PipelineRef) (db.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	pipelineReturns struct {
 		result1 db.Pipeline
@@ -336,10 +338,10 @@ type FakeTeam struct {
 	renameReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SavePipelineStub        func(string, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)
+	SavePipelineStub        func(atc.PipelineRef, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)
 	savePipelineMutex       sync.RWMutex
 	savePipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 atc.Config
 		arg3 db.ConfigVersion
 		arg4 bool
@@ -368,6 +370,31 @@ type FakeTeam struct {
 		result1 db.Worker
 		result2 error
 	}
+	SearchBuildLogsStub        func(db.BuildLogSearch) ([]db.BuildLogMatch, error)
+	searchBuildLogsMutex       sync.RWMutex
+	searchBuildLogsArgsForCall []struct {
+		arg1 db.BuildLogSearch
+	}
+	searchBuildLogsReturns struct {
+		result1 []db.BuildLogMatch
+		result2 error
+	}
+	searchBuildLogsReturnsOnCall map[int]struct {
+		result1 []db.BuildLogMatch
+		result2 error
+	}
+	SemaphoresStub        func() ([]db.Semaphore, error)
+	semaphoresMutex       sync.RWMutex
+	semaphoresArgsForCall []struct {
+	}
+	semaphoresReturns struct {
+		result1 []db.Semaphore
+		result2 error
+	}
+	semaphoresReturnsOnCall map[int]struct {
+		result1 []db.Semaphore
+		result2 error
+	}
 	UpdateProviderAuthStub        func(atc.TeamAuth) error
 	updateProviderAuthMutex       sync.RWMutex
 	updateProviderAuthArgsForCall []struct {
@@ -741,16 +768,17 @@ func (fake *FakeTeam) CreateOneOffBuildReturnsOnCall(i int, result1 db.Build, re
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) CreateStartedBuild(arg1 atc.Plan) (db.Build, error) {
+func (fake *FakeTeam) CreateStartedBuild(arg1 atc.Plan, arg2 atc.BuildCreator) (db.Build, error) {
 	fake.createStartedBuildMutex.Lock()
 	ret, specificReturn := fake.createStartedBuildReturnsOnCall[len(fake.createStartedBuildArgsForCall)]
 	fake.createStartedBuildArgsForCall = append(fake.createStartedBuildArgsForCall, struct {
 		arg1 atc.Plan
-	}{arg1})
-	fake.recordInvocation("CreateStartedBuild", []interface{}{arg1})
+		arg2 atc.BuildCreator
+	}{arg1, arg2})
+	fake.recordInvocation("CreateStartedBuild", []interface{}{arg1, arg2})
 	fake.createStartedBuildMutex.Unlock()
 	if fake.CreateStartedBuildStub != nil {
-		return fake.CreateStartedBuildStub(arg1)
+		return fake.CreateStartedBuildStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -765,17 +793,17 @@ func (fake *FakeTeam) CreateStartedBuildCallCount() int {
 	return len(fake.createStartedBuildArgsForCall)
 }
 
-func (fake *FakeTeam) CreateStartedBuildCalls(stub func(atc.Plan) (db.Build, error)) {
+func (fake *FakeTeam) CreateStartedBuildCalls(stub func(atc.Plan, atc.BuildCreator) (db.Build, error)) {
 	fake.createStartedBuildMutex.Lock()
 	defer fake.createStartedBuildMutex.Unlock()
 	fake.CreateStartedBuildStub = stub
 }
 
-func (fake *FakeTeam) CreateStartedBuildArgsForCall(i int) atc.Plan {
+func (fake *FakeTeam) CreateStartedBuildArgsForCall(i int) (atc.Plan, atc.BuildCreator) {
 	fake.createStartedBuildMutex.RLock()
 	defer fake.createStartedBuildMutex.RUnlock()
 	argsForCall := fake.createStartedBuildArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeTeam) CreateStartedBuildReturns(result1 db.Build, result2 error) {
@@ -856,18 +884,19 @@ func (fake *FakeTeam) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTeam) FindCheckContainers(arg1 string, arg2 string, arg3 creds.Secrets) ([]db.Container, map[int]time.Time, error) {
+func (fake *FakeTeam) FindCheckContainers(arg1 atc.PipelineRef, arg2 string, arg3 creds.Secrets, arg4 creds.VarSourcePool) ([]db.Container, map[int]time.Time, error) {
 	fake.findCheckContainersMutex.Lock()
 	ret, specificReturn := fake.findCheckContainersReturnsOnCall[len(fake.findCheckContainersArgsForCall)]
 	fake.findCheckContainersArgsForCall = append(fake.findCheckContainersArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 creds.Secrets
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("FindCheckContainers", []interface{}{arg1, arg2, arg3})
+		arg4 creds.VarSourcePool
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("FindCheckContainers", []interface{}{arg1, arg2, arg3, arg4})
 	fake.findCheckContainersMutex.Unlock()
 	if fake.FindCheckContainersStub != nil {
-		return fake.FindCheckContainersStub(arg1, arg2, arg3)
+		return fake.FindCheckContainersStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -882,17 +911,17 @@ func (fake *FakeTeam) FindCheckContainersCallCount() int {
 	return len(fake.findCheckContainersArgsForCall)
 }
 
-func (fake *FakeTeam) FindCheckContainersCalls(stub func(string, string, creds.Secrets) ([]db.Container, map[int]time.Time, error)) {
+func (fake *FakeTeam) FindCheckContainersCalls(stub func(atc.PipelineRef, string, creds.Secrets, creds.VarSourcePool) ([]db.Container, map[int]time.Time, error)) {
 	fake.findCheckContainersMutex.Lock()
 	defer fake.findCheckContainersMutex.Unlock()
 	fake.FindCheckContainersStub = stub
 }
 
-func (fake *FakeTeam) FindCheckContainersArgsForCall(i int) (string, string, creds.Secrets) {
+func (fake *FakeTeam) FindCheckContainersArgsForCall(i int) (atc.PipelineRef, string, creds.Secrets, creds.VarSourcePool) {
 	fake.findCheckContainersMutex.RLock()
 	defer fake.findCheckContainersMutex.RUnlock()
 	argsForCall := fake.findCheckContainersArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeTeam) FindCheckContainersReturns(result1 []db.Container, result2 map[int]time.Time, result3 error) {
@@ -1613,11 +1642,11 @@ func (fake *FakeTeam) OrderPipelinesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTeam) Pipeline(arg1 string) (db.Pipeline, bool, error) {
+func (fake *FakeTeam) Pipeline(arg1 atc.PipelineRef) (db.Pipeline, bool, error) {
 	fake.pipelineMutex.Lock()
 	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
 	fake.pipelineArgsForCall = append(fake.pipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("Pipeline", []interface{}{arg1})
 	fake.pipelineMutex.Unlock()
@@ -1637,13 +1666,13 @@ func (fake *FakeTeam) PipelineCallCount() int {
 	return len(fake.pipelineArgsForCall)
 }
 
-func (fake *FakeTeam) PipelineCalls(stub func(string) (db.Pipeline, bool, error)) {
+func (fake *FakeTeam) PipelineCalls(stub func(atc.PipelineRef) (db.Pipeline, bool, error)) {
 	fake.pipelineMutex.Lock()
 	defer fake.pipelineMutex.Unlock()
 	fake.PipelineStub = stub
 }
 
-func (fake *FakeTeam) PipelineArgsForCall(i int) string {
+func (fake *FakeTeam) PipelineArgsForCall(i int) atc.PipelineRef {
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	argsForCall := fake.pipelineArgsForCall[i]
@@ -1915,11 +1944,11 @@ func (fake *FakeTeam) RenameReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTeam) SavePipeline(arg1 string, arg2 atc.Config, arg3 db.ConfigVersion, arg4 bool) (db.Pipeline, bool, error) {
+func (fake *FakeTeam) SavePipeline(arg1 atc.PipelineRef, arg2 atc.Config, arg3 db.ConfigVersion, arg4 bool) (db.Pipeline, bool, error) {
 	fake.savePipelineMutex.Lock()
 	ret, specificReturn := fake.savePipelineReturnsOnCall[len(fake.savePipelineArgsForCall)]
 	fake.savePipelineArgsForCall = append(fake.savePipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 atc.Config
 		arg3 db.ConfigVersion
 		arg4 bool
@@ -1942,13 +1971,13 @@ func (fake *FakeTeam) SavePipelineCallCount() int {
 	return len(fake.savePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) SavePipelineCalls(stub func(string, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)) {
+func (fake *FakeTeam) SavePipelineCalls(stub func(atc.PipelineRef, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)) {
 	fake.savePipelineMutex.Lock()
 	defer fake.savePipelineMutex.Unlock()
 	fake.SavePipelineStub = stub
 }
 
-func (fake *FakeTeam) SavePipelineArgsForCall(i int) (string, atc.Config, db.ConfigVersion, bool) {
+func (fake *FakeTeam) SavePipelineArgsForCall(i int) (atc.PipelineRef, atc.Config, db.ConfigVersion, bool) {
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
 	argsForCall := fake.savePipelineArgsForCall[i]
@@ -2048,6 +2077,124 @@ func (fake *FakeTeam) SaveWorkerReturnsOnCall(i int, result1 db.Worker, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SearchBuildLogs(arg1 db.BuildLogSearch) ([]db.BuildLogMatch, error) {
+	fake.searchBuildLogsMutex.Lock()
+	ret, specificReturn := fake.searchBuildLogsReturnsOnCall[len(fake.searchBuildLogsArgsForCall)]
+	fake.searchBuildLogsArgsForCall = append(fake.searchBuildLogsArgsForCall, struct {
+		arg1 db.BuildLogSearch
+	}{arg1})
+	fake.recordInvocation("SearchBuildLogs", []interface{}{arg1})
+	fake.searchBuildLogsMutex.Unlock()
+	if fake.SearchBuildLogsStub != nil {
+		return fake.SearchBuildLogsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.searchBuildLogsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SearchBuildLogsCallCount() int {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return len(fake.searchBuildLogsArgsForCall)
+}
+
+func (fake *FakeTeam) SearchBuildLogsCalls(stub func(db.BuildLogSearch) ([]db.BuildLogMatch, error)) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = stub
+}
+
+func (fake *FakeTeam) SearchBuildLogsArgsForCall(i int) db.BuildLogSearch {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	argsForCall := fake.searchBuildLogsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturns(result1 []db.BuildLogMatch, result2 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	fake.searchBuildLogsReturns = struct {
+		result1 []db.BuildLogMatch
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturnsOnCall(i int, result1 []db.BuildLogMatch, result2 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	if fake.searchBuildLogsReturnsOnCall == nil {
+		fake.searchBuildLogsReturnsOnCall = make(map[int]struct {
+			result1 []db.BuildLogMatch
+			result2 error
+		})
+	}
+	fake.searchBuildLogsReturnsOnCall[i] = struct {
+		result1 []db.BuildLogMatch
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) Semaphores() ([]db.Semaphore, error) {
+	fake.semaphoresMutex.Lock()
+	ret, specificReturn := fake.semaphoresReturnsOnCall[len(fake.semaphoresArgsForCall)]
+	fake.semaphoresArgsForCall = append(fake.semaphoresArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Semaphores", []interface{}{})
+	fake.semaphoresMutex.Unlock()
+	if fake.SemaphoresStub != nil {
+		return fake.SemaphoresStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.semaphoresReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SemaphoresCallCount() int {
+	fake.semaphoresMutex.RLock()
+	defer fake.semaphoresMutex.RUnlock()
+	return len(fake.semaphoresArgsForCall)
+}
+
+func (fake *FakeTeam) SemaphoresCalls(stub func() ([]db.Semaphore, error)) {
+	fake.semaphoresMutex.Lock()
+	defer fake.semaphoresMutex.Unlock()
+	fake.SemaphoresStub = stub
+}
+
+func (fake *FakeTeam) SemaphoresReturns(result1 []db.Semaphore, result2 error) {
+	fake.semaphoresMutex.Lock()
+	defer fake.semaphoresMutex.Unlock()
+	fake.SemaphoresStub = nil
+	fake.semaphoresReturns = struct {
+		result1 []db.Semaphore
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SemaphoresReturnsOnCall(i int, result1 []db.Semaphore, result2 error) {
+	fake.semaphoresMutex.Lock()
+	defer fake.semaphoresMutex.Unlock()
+	fake.SemaphoresStub = nil
+	if fake.semaphoresReturnsOnCall == nil {
+		fake.semaphoresReturnsOnCall = make(map[int]struct {
+			result1 []db.Semaphore
+			result2 error
+		})
+	}
+	fake.semaphoresReturnsOnCall[i] = struct {
+		result1 []db.Semaphore
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) UpdateProviderAuth(arg1 atc.TeamAuth) error {
 	fake.updateProviderAuthMutex.Lock()
 	ret, specificReturn := fake.updateProviderAuthReturnsOnCall[len(fake.updateProviderAuthArgsForCall)]
@@ -2220,6 +2367,10 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.savePipelineMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	fake.semaphoresMutex.RLock()
+	defer fake.semaphoresMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
 	fake.workersMutex.RLock()
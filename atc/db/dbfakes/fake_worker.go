@@ -267,6 +267,16 @@ type FakeWorker struct {
 	retireReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RuntimeStub        func() string
+	runtimeMutex       sync.RWMutex
+	runtimeArgsForCall []struct {
+	}
+	runtimeReturns struct {
+		result1 string
+	}
+	runtimeReturnsOnCall map[int]struct {
+		result1 string
+	}
 	StartTimeStub        func() time.Time
 	startTimeMutex       sync.RWMutex
 	startTimeArgsForCall []struct {
@@ -1617,6 +1627,58 @@ func (fake *FakeWorker) RetireReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Runtime() string {
+	fake.runtimeMutex.Lock()
+	ret, specificReturn := fake.runtimeReturnsOnCall[len(fake.runtimeArgsForCall)]
+	fake.runtimeArgsForCall = append(fake.runtimeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Runtime", []interface{}{})
+	fake.runtimeMutex.Unlock()
+	if fake.RuntimeStub != nil {
+		return fake.RuntimeStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.runtimeReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) RuntimeCallCount() int {
+	fake.runtimeMutex.RLock()
+	defer fake.runtimeMutex.RUnlock()
+	return len(fake.runtimeArgsForCall)
+}
+
+func (fake *FakeWorker) RuntimeCalls(stub func() string) {
+	fake.runtimeMutex.Lock()
+	defer fake.runtimeMutex.Unlock()
+	fake.RuntimeStub = stub
+}
+
+func (fake *FakeWorker) RuntimeReturns(result1 string) {
+	fake.runtimeMutex.Lock()
+	defer fake.runtimeMutex.Unlock()
+	fake.RuntimeStub = nil
+	fake.runtimeReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWorker) RuntimeReturnsOnCall(i int, result1 string) {
+	fake.runtimeMutex.Lock()
+	defer fake.runtimeMutex.Unlock()
+	fake.RuntimeStub = nil
+	if fake.runtimeReturnsOnCall == nil {
+		fake.runtimeReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.runtimeReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeWorker) StartTime() time.Time {
 	fake.startTimeMutex.Lock()
 	ret, specificReturn := fake.startTimeReturnsOnCall[len(fake.startTimeArgsForCall)]
@@ -1980,6 +2042,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.resourceTypesMutex.RUnlock()
 	fake.retireMutex.RLock()
 	defer fake.retireMutex.RUnlock()
+	fake.runtimeMutex.RLock()
+	defer fake.runtimeMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
 	fake.stateMutex.RLock()
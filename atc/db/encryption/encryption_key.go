@@ -3,20 +3,36 @@ package encryption
 import (
 	"crypto/cipher"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"io"
 )
 
+// keyIDMessage is sealed with each key to identify it.
+const keyIDMessage = "concourse-encryption-key-id"
+
 type Key struct {
 	aesgcm cipher.AEAD
+	id     string
 }
 
 func NewKey(a cipher.AEAD) *Key {
+	// the key itself is only available as an AEAD, so it is identified by a
+	// digest of a fixed message sealed with it. Every ATC derives the same ID
+	// from the same key, without the ID revealing anything about the key.
+	fingerprint := sha256.Sum256(a.Seal(nil, make([]byte, a.NonceSize()), []byte(keyIDMessage), nil))
+
 	return &Key{
 		aesgcm: a,
+		id:     hex.EncodeToString(fingerprint[:4]),
 	}
 }
 
+// ID identifies the key among the keys data may be encrypted with.
+func (e Key) ID() string {
+	return e.id
+}
+
 func (e Key) Encrypt(plaintext []byte) (string, *string, error) {
 	nonce := make([]byte, 12)
 	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
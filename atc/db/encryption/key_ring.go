@@ -0,0 +1,103 @@
+package encryption
+
+import "strings"
+
+// keyIDSeparator separates the ID of the key from the nonce. Nonces are hex
+// encoded, so they never contain it.
+const keyIDSeparator = ":"
+
+// KeyRing encrypts data with its primary key, and decrypts data encrypted
+// with any of its keys.
+//
+// While the ring holds more than one key, i.e. while keys are being rotated,
+// the ID of the key used is stored along with the nonce of each ciphertext,
+// so that ATCs encrypting with different keys can run alongside each other.
+// With a single key the nonce is stored as is, so that the data stays
+// readable by ATCs which predate key IDs. Data encrypted without a key ID is
+// decrypted with whichever key fits.
+type KeyRing struct {
+	primary *Key
+	keys    []*Key
+}
+
+func NewKeyRing(primary *Key, others ...*Key) *KeyRing {
+	keys := []*Key{primary}
+	for _, key := range others {
+		if key != nil && key.ID() != primary.ID() {
+			keys = append(keys, key)
+		}
+	}
+
+	return &KeyRing{
+		primary: primary,
+		keys:    keys,
+	}
+}
+
+// PrimaryKeyID returns the ID of the key data is encrypted with.
+func (r *KeyRing) PrimaryKeyID() string {
+	return r.primary.ID()
+}
+
+// KeyIDs returns the IDs of all keys data can be decrypted with, starting
+// with the primary key.
+func (r *KeyRing) KeyIDs() []string {
+	ids := make([]string, len(r.keys))
+	for i, key := range r.keys {
+		ids[i] = key.ID()
+	}
+
+	return ids
+}
+
+func (r *KeyRing) Encrypt(plaintext []byte) (string, *string, error) {
+	ciphertext, nonce, err := r.primary.Encrypt(plaintext)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(r.keys) == 1 {
+		return ciphertext, nonce, nil
+	}
+
+	keyNonce := r.primary.ID() + keyIDSeparator + *nonce
+
+	return ciphertext, &keyNonce, nil
+}
+
+func (r *KeyRing) Decrypt(text string, n *string) ([]byte, error) {
+	if n == nil {
+		return nil, ErrDataIsNotEncrypted
+	}
+
+	keyID, nonce, identified := splitNonce(*n)
+	if !identified {
+		for _, key := range r.keys {
+			plaintext, err := key.Decrypt(text, n)
+			if err == nil {
+				return plaintext, nil
+			}
+		}
+
+		return nil, ErrDataIsEncryptedWithUnknownKey
+	}
+
+	for _, key := range r.keys {
+		if key.ID() == keyID {
+			return key.Decrypt(text, &nonce)
+		}
+	}
+
+	return nil, ErrDataIsEncryptedWithUnknownKey
+}
+
+// splitNonce returns the ID of the key and the nonce stored by a KeyRing. Data
+// encrypted before keys were identified has no key ID.
+func splitNonce(n string) (string, string, bool) {
+	i := strings.Index(n, keyIDSeparator)
+	if i == -1 {
+		return "", n, false
+	}
+
+	return n[:i], n[i+len(keyIDSeparator):], true
+}
@@ -0,0 +1,111 @@
+package encryption_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"strings"
+
+	"github.com/concourse/concourse/atc/db/encryption"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Key Ring", func() {
+	var (
+		oldKey *encryption.Key
+		newKey *encryption.Key
+
+		plaintext []byte
+	)
+
+	newKeyFrom := func(k string) *encryption.Key {
+		block, err := aes.NewCipher([]byte(k))
+		Expect(err).ToNot(HaveOccurred())
+
+		aesgcm, err := cipher.NewGCM(block)
+		Expect(err).ToNot(HaveOccurred())
+
+		return encryption.NewKey(aesgcm)
+	}
+
+	BeforeEach(func() {
+		oldKey = newKeyFrom("AES256Key-32Characters1234567890")
+		newKey = newKeyFrom("AES256Key-32Characters9564567123")
+
+		plaintext = []byte("exampleplaintext")
+	})
+
+	Describe("key IDs", func() {
+		It("identifies the same key by the same ID", func() {
+			Expect(newKey.ID()).To(Equal(newKeyFrom("AES256Key-32Characters9564567123").ID()))
+		})
+
+		It("identifies different keys by different IDs", func() {
+			Expect(newKey.ID()).ToNot(Equal(oldKey.ID()))
+		})
+	})
+
+	It("stores the ID of the primary key along with the nonce", func() {
+		ring := encryption.NewKeyRing(newKey, oldKey)
+		Expect(ring.PrimaryKeyID()).To(Equal(newKey.ID()))
+		Expect(ring.KeyIDs()).To(Equal([]string{newKey.ID(), oldKey.ID()}))
+
+		encryptedText, nonce, err := ring.Encrypt(plaintext)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*nonce).To(HavePrefix(newKey.ID() + ":"))
+
+		decryptedText, err := ring.Decrypt(encryptedText, nonce)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decryptedText).To(Equal(plaintext))
+	})
+
+	It("does not store the ID of its only key, so that ATCs predating key IDs can decrypt the data", func() {
+		ring := encryption.NewKeyRing(newKey, newKey)
+
+		encryptedText, nonce, err := ring.Encrypt(plaintext)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(strings.Contains(*nonce, ":")).To(BeFalse())
+
+		decryptedText, err := newKey.Decrypt(encryptedText, nonce)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decryptedText).To(Equal(plaintext))
+	})
+
+	It("decrypts data encrypted by ATCs with another of its keys as their primary key", func() {
+		encryptedText, nonce, err := encryption.NewKeyRing(oldKey, newKey).Encrypt(plaintext)
+		Expect(err).ToNot(HaveOccurred())
+
+		decryptedText, err := encryption.NewKeyRing(newKey, oldKey).Decrypt(encryptedText, nonce)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decryptedText).To(Equal(plaintext))
+	})
+
+	It("decrypts data encrypted before keys were identified with any of its keys", func() {
+		encryptedText, nonce, err := oldKey.Encrypt(plaintext)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(strings.Contains(*nonce, ":")).To(BeFalse())
+
+		decryptedText, err := encryption.NewKeyRing(newKey, oldKey).Decrypt(encryptedText, nonce)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decryptedText).To(Equal(plaintext))
+	})
+
+	It("fails to decrypt data encrypted with a key it does not have", func() {
+		encryptedText, nonce, err := encryption.NewKeyRing(oldKey).Encrypt(plaintext)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = encryption.NewKeyRing(newKey).Decrypt(encryptedText, nonce)
+		Expect(err).To(Equal(encryption.ErrDataIsEncryptedWithUnknownKey))
+
+		encryptedText, nonce, err = oldKey.Encrypt(plaintext)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = encryption.NewKeyRing(newKey).Decrypt(encryptedText, nonce)
+		Expect(err).To(Equal(encryption.ErrDataIsEncryptedWithUnknownKey))
+	})
+
+	It("fails to decrypt data that is not encrypted", func() {
+		_, err := encryption.NewKeyRing(newKey).Decrypt("exampleplaintext", nil)
+		Expect(err).To(Equal(encryption.ErrDataIsNotEncrypted))
+	})
+})
@@ -4,6 +4,7 @@ import "errors"
 
 var ErrDataIsEncrypted = errors.New("failed to decrypt data that is encrypted")
 var ErrDataIsNotEncrypted = errors.New("failed to decrypt data that is not encrypted")
+var ErrDataIsEncryptedWithUnknownKey = errors.New("failed to decrypt data that is encrypted with an unknown key")
 
 //go:generate counterfeiter . Strategy
 
@@ -0,0 +1,266 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/encryption"
+	"github.com/lib/pq"
+)
+
+//go:generate counterfeiter . EncryptionKeyRotation
+
+// EncryptionKeyRotation re-encrypts the data in the database with the
+// current encryption key, a batch at a time, so that the old key can be
+// retired without downtime.
+type EncryptionKeyRotation interface {
+	// RotateBatch re-encrypts up to limit rows which are not yet encrypted
+	// with the current key, returning how many were re-encrypted.
+	RotateBatch(limit int) (int, error)
+
+	Status() (atc.EncryptionStatus, error)
+}
+
+type encryptionKeyRotation struct {
+	logger lager.Logger
+	conn   Conn
+
+	keyID     string
+	oldKeyIDs []string
+
+	// primary keys of the rows which no key could decrypt, by table; they are
+	// skipped so that they do not hold up the rest of the rotation
+	undecryptable  map[string][]string
+	undecryptableL sync.Mutex
+}
+
+// NewEncryptionKeyRotation re-encrypts data encrypted with any of oldKeyIDs,
+// with an unrecorded key, or not at all, with the current encryption key of
+// the conn, identified by keyID.
+//
+// Data encrypted with keys not in oldKeyIDs is left alone, as it may have
+// been encrypted by ATCs which have already moved on to the next key.
+//
+// Without any oldKeyIDs there is no rotation configured, and nothing is
+// re-encrypted, so that data stays readable by ATCs which predate key IDs.
+func NewEncryptionKeyRotation(logger lager.Logger, conn Conn, keyID string, oldKeyIDs []string) EncryptionKeyRotation {
+	return &encryptionKeyRotation{
+		logger: logger,
+		conn:   conn,
+
+		keyID:     keyID,
+		oldKeyIDs: oldKeyIDs,
+
+		undecryptable: map[string][]string{},
+	}
+}
+
+func (r *encryptionKeyRotation) configured() bool {
+	return r.keyID != "" && len(r.oldKeyIDs) > 0
+}
+
+func (r *encryptionKeyRotation) RotateBatch(limit int) (int, error) {
+	if !r.configured() {
+		return 0, nil
+	}
+
+	rotated := 0
+
+	for _, ec := range encryptedColumns {
+		if rotated >= limit {
+			break
+		}
+
+		n, err := r.rotateColumn(ec, limit-rotated)
+		if err != nil {
+			return rotated, err
+		}
+
+		rotated += n
+	}
+
+	return rotated, nil
+}
+
+func (r *encryptionKeyRotation) rotateColumn(ec encryptedColumn, limit int) (int, error) {
+	rows, err := r.conn.Query(`
+		SELECT `+ec.PrimaryKey+`, nonce, `+ec.Column+`
+		FROM `+ec.Table+`
+		WHERE `+ec.Column+` IS NOT NULL
+		AND (
+			nonce IS NULL
+			OR strpos(nonce, ':') = 0
+			OR split_part(nonce, ':', 1) = ANY($1)
+		)
+		AND NOT (`+ec.PrimaryKey+`::text = ANY($2))
+		LIMIT $3
+	`, pq.Array(r.oldKeyIDs), pq.Array(r.undecryptableRows(ec.Table)), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	type encryptedRow struct {
+		primaryKey interface{}
+		nonce      sql.NullString
+		val        string
+	}
+
+	var toRotate []encryptedRow
+	for rows.Next() {
+		var row encryptedRow
+
+		err := rows.Scan(&row.primaryKey, &row.nonce, &row.val)
+		if err != nil {
+			Close(rows)
+			return 0, err
+		}
+
+		toRotate = append(toRotate, row)
+	}
+
+	Close(rows)
+
+	strategy := r.conn.EncryptionStrategy()
+
+	rotated := 0
+	for _, row := range toRotate {
+		plaintext := []byte(row.val)
+		if row.nonce.Valid {
+			plaintext, err = strategy.Decrypt(row.val, &row.nonce.String)
+			if err == encryption.ErrDataIsEncryptedWithUnknownKey {
+				r.logger.Error("skipping-undecryptable-row", err, lager.Data{
+					"table":       ec.Table,
+					"primary-key": row.primaryKey,
+				})
+
+				r.addUndecryptableRow(ec.Table, row.primaryKey)
+				continue
+			}
+
+			if err != nil {
+				return rotated, err
+			}
+		}
+
+		encrypted, nonce, err := strategy.Encrypt(plaintext)
+		if err != nil {
+			return rotated, err
+		}
+
+		// only update the row if nothing else has since, so as to not clobber
+		// newer data
+		result, err := r.conn.Exec(`
+			UPDATE `+ec.Table+`
+			SET `+ec.Column+` = $1, nonce = $2
+			WHERE `+ec.PrimaryKey+` = $3
+			AND nonce IS NOT DISTINCT FROM $4
+		`, encrypted, nonce, row.primaryKey, row.nonce)
+		if err != nil {
+			return rotated, err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return rotated, err
+		}
+
+		rotated += int(affected)
+	}
+
+	return rotated, nil
+}
+
+func (r *encryptionKeyRotation) undecryptableRows(table string) []string {
+	r.undecryptableL.Lock()
+	defer r.undecryptableL.Unlock()
+
+	return append([]string{}, r.undecryptable[table]...)
+}
+
+func (r *encryptionKeyRotation) addUndecryptableRow(table string, primaryKey interface{}) {
+	r.undecryptableL.Lock()
+	defer r.undecryptableL.Unlock()
+
+	r.undecryptable[table] = append(r.undecryptable[table], fmt.Sprint(primaryKey))
+}
+
+func (r *encryptionKeyRotation) Status() (atc.EncryptionStatus, error) {
+	status := atc.EncryptionStatus{
+		KeyID:     r.keyID,
+		OldKeyIDs: r.oldKeyIDs,
+		Tables:    []atc.EncryptedTableStatus{},
+	}
+
+	for _, ec := range encryptedColumns {
+		table, err := r.columnStatus(ec)
+		if err != nil {
+			return atc.EncryptionStatus{}, err
+		}
+
+		status.Tables = append(status.Tables, table)
+	}
+
+	return status, nil
+}
+
+func (r *encryptionKeyRotation) columnStatus(ec encryptedColumn) (atc.EncryptedTableStatus, error) {
+	rows, err := r.conn.Query(`
+		SELECT
+			CASE WHEN strpos(nonce, ':') > 0 THEN split_part(nonce, ':', 1) END,
+			nonce IS NULL,
+			count(*)
+		FROM ` + ec.Table + `
+		WHERE ` + ec.Column + ` IS NOT NULL
+		GROUP BY 1, 2
+	`)
+	if err != nil {
+		return atc.EncryptedTableStatus{}, err
+	}
+
+	defer Close(rows)
+
+	table := atc.EncryptedTableStatus{
+		Table:  ec.Table,
+		Column: ec.Column,
+		Keys:   map[string]int{},
+	}
+
+	for rows.Next() {
+		var (
+			keyID     sql.NullString
+			plaintext bool
+			count     int
+		)
+
+		err := rows.Scan(&keyID, &plaintext, &count)
+		if err != nil {
+			return atc.EncryptedTableStatus{}, err
+		}
+
+		switch {
+		case plaintext:
+			table.Plaintext += count
+		case !keyID.Valid:
+			table.Legacy += count
+		default:
+			table.Keys[keyID.String] += count
+		}
+	}
+
+	// without a rotation configured there is nothing to re-encrypt
+	if !r.configured() {
+		return table, nil
+	}
+
+	table.Undecryptable = len(r.undecryptableRows(ec.Table))
+
+	table.Remaining = table.Plaintext + table.Legacy - table.Undecryptable
+	for _, id := range r.oldKeyIDs {
+		table.Remaining += table.Keys[id]
+	}
+
+	return table, nil
+}
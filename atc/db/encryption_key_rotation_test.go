@@ -0,0 +1,207 @@
+package db_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/encryption"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EncryptionKeyRotation", func() {
+	var (
+		oldKey  *encryption.Key
+		newKey  *encryption.Key
+		nextKey *encryption.Key
+
+		newConn  db.Conn
+		rotation db.EncryptionKeyRotation
+	)
+
+	newKeyFrom := func(k string) *encryption.Key {
+		block, err := aes.NewCipher([]byte(k))
+		Expect(err).ToNot(HaveOccurred())
+
+		aesgcm, err := cipher.NewGCM(block)
+		Expect(err).ToNot(HaveOccurred())
+
+		return encryption.NewKey(aesgcm)
+	}
+
+	openConn := func(newKey, oldKey, nextKey *encryption.Key) db.Conn {
+		conn, err := db.Open(logger, "postgres", postgresRunner.DataSourceName(), newKey, oldKey, nextKey, "test", nil)
+		Expect(err).ToNot(HaveOccurred())
+		return conn
+	}
+
+	resourceSource := func(conn db.Conn) atc.Source {
+		pipeline, found, err := db.NewTeamFactory(conn, lockFactory).GetByID(defaultTeam.ID()).Pipeline(atc.PipelineRef{Name: "default-pipeline"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		resource, found, err := pipeline.Resource("some-resource")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		return resource.Source()
+	}
+
+	BeforeEach(func() {
+		oldKey = newKeyFrom("AES256Key-32Characters1234567890")
+		newKey = newKeyFrom("AES256Key-32Characters9564567123")
+		nextKey = newKeyFrom("AES256Key-32Characters0987654321")
+
+		// encrypts the default pipeline with the old key, without its ID as it
+		// is the only key
+		oldConn := openConn(oldKey, nil, nil)
+		Expect(oldConn.Close()).To(Succeed())
+
+		newConn = openConn(newKey, oldKey, nil)
+		rotation = db.NewEncryptionKeyRotation(logger, newConn, newKey.ID(), []string{oldKey.ID()})
+	})
+
+	AfterEach(func() {
+		Expect(newConn.Close()).To(Succeed())
+	})
+
+	rotateAll := func() {
+		for {
+			rotated, err := rotation.RotateBatch(2)
+			Expect(err).ToNot(HaveOccurred())
+
+			if rotated == 0 {
+				break
+			}
+		}
+	}
+
+	It("reports the rows left to re-encrypt with the new key", func() {
+		status, err := rotation.Status()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.KeyID).To(Equal(newKey.ID()))
+		Expect(status.OldKeyIDs).To(Equal([]string{oldKey.ID()}))
+		Expect(status.Remaining()).ToNot(BeZero())
+
+		var resources atc.EncryptedTableStatus
+		for _, table := range status.Tables {
+			if table.Table == "resources" {
+				resources = table
+			}
+		}
+
+		Expect(resources).To(Equal(atc.EncryptedTableStatus{
+			Table:     "resources",
+			Column:    "config",
+			Legacy:    1,
+			Keys:      map[string]int{},
+			Remaining: 1,
+		}))
+	})
+
+	Context("when no old key is configured", func() {
+		BeforeEach(func() {
+			rotation = db.NewEncryptionKeyRotation(logger, newConn, newKey.ID(), nil)
+		})
+
+		It("does not re-encrypt anything", func() {
+			rotated, err := rotation.RotateBatch(100)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotated).To(BeZero())
+
+			status, err := rotation.Status()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.Remaining()).To(BeZero())
+		})
+	})
+
+	Context("when a row is encrypted with an unknown key", func() {
+		BeforeEach(func() {
+			unknownKey := newKeyFrom("AES256Key-32Characters5555555555")
+
+			encrypted, nonce, err := unknownKey.Encrypt([]byte(`{"source":{"some":"source"}}`))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = newConn.Exec(`UPDATE resources SET config = $1, nonce = $2`, encrypted, nonce)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("skips it and re-encrypts the rest", func() {
+			rotateAll()
+
+			status, err := rotation.Status()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.Remaining()).To(BeZero())
+
+			var resources atc.EncryptedTableStatus
+			for _, table := range status.Tables {
+				if table.Table == "resources" {
+					resources = table
+				}
+			}
+
+			Expect(resources).To(Equal(atc.EncryptedTableStatus{
+				Table:         "resources",
+				Column:        "config",
+				Legacy:        1,
+				Keys:          map[string]int{},
+				Undecryptable: 1,
+			}))
+		})
+	})
+
+	It("re-encrypts rows in batches", func() {
+		remaining := func() int {
+			status, err := rotation.Status()
+			Expect(err).ToNot(HaveOccurred())
+			return status.Remaining()
+		}
+
+		before := remaining()
+
+		rotated, err := rotation.RotateBatch(1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rotated).To(Equal(1))
+		Expect(remaining()).To(Equal(before - 1))
+
+		rotateAll()
+		Expect(remaining()).To(BeZero())
+	})
+
+	It("leaves the data readable with only the new key", func() {
+		rotateAll()
+
+		newOnlyConn := openConn(newKey, nil, nil)
+		defer newOnlyConn.Close()
+
+		Expect(resourceSource(newOnlyConn)).To(Equal(atc.Source{"some": "source"}))
+	})
+
+	Context("when data has been encrypted with the next key", func() {
+		BeforeEach(func() {
+			rotateAll()
+
+			nextConn := openConn(nextKey, newKey, nil)
+			defer nextConn.Close()
+
+			nextRotation := db.NewEncryptionKeyRotation(logger, nextConn, nextKey.ID(), []string{newKey.ID()})
+
+			rotated, err := nextRotation.RotateBatch(100)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotated).ToNot(BeZero())
+		})
+
+		It("leaves it alone", func() {
+			rotated, err := rotation.RotateBatch(100)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotated).To(BeZero())
+
+			status, err := rotation.Status()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.Remaining()).To(BeZero())
+		})
+	})
+})
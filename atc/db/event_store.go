@@ -0,0 +1,152 @@
+package db
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// ErrBuildEventsNotInPostgres is returned by queries across the events of
+// many builds, which can only be answered when the events are stored in
+// Postgres.
+var ErrBuildEventsNotInPostgres = errors.New("build events are not stored in postgres")
+
+// BuildEvent is an event along with its ID, which orders it within its
+// build's event stream.
+type BuildEvent struct {
+	EventID uint
+	Event   atc.Event
+}
+
+//go:generate counterfeiter . EventStore
+
+// EventStore persists build events, i.e. build logs. Events are stored in
+// Postgres by default.
+type EventStore interface {
+	// Put saves events for the build as part of the given transaction, which
+	// assigned the events their IDs.
+	//
+	// Stores outside of Postgres can't take part in the transaction, and save
+	// the events in Flush instead.
+	Put(tx Tx, build Build, events []BuildEvent) error
+
+	// Flush is given the events once the transaction given to Put has
+	// committed, so that stores outside of Postgres never save the events of
+	// a transaction which was rolled back.
+	Flush(build Build, events []BuildEvent) error
+
+	// Get returns the build's events, starting from the given offset. The
+	// notifier fires when new events are saved for the build.
+	//
+	// Once the build has completed and all of its events have been returned,
+	// the source returns ErrEndOfBuildEventStream.
+	Get(build Build, from uint, notifier Notifier) (EventSource, error)
+
+	// Delete removes all events of the given builds.
+	Delete(buildIDs []int) error
+}
+
+// WithEventStore returns a Conn which stores build events in the given store
+// rather than in Postgres.
+func WithEventStore(conn Conn, store EventStore) Conn {
+	return &eventStoreConn{
+		Conn:  conn,
+		store: store,
+	}
+}
+
+type eventStoreConn struct {
+	Conn
+
+	store EventStore
+}
+
+func (conn *eventStoreConn) EventStore() EventStore {
+	return conn.store
+}
+
+// NewPostgresEventStore constructs the default EventStore, which saves events
+// in the pipeline_build_events_N and team_build_events_N tables.
+func NewPostgresEventStore(conn Conn) EventStore {
+	return &postgresEventStore{conn: conn}
+}
+
+type postgresEventStore struct {
+	conn Conn
+}
+
+// storesEventsInPostgres returns whether the events of builds are in the
+// build events tables, i.e. whether they can be queried directly.
+func storesEventsInPostgres(conn Conn) bool {
+	_, ok := conn.EventStore().(*postgresEventStore)
+	return ok
+}
+
+func (store *postgresEventStore) Put(tx Tx, build Build, events []BuildEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	insert := psql.Insert(buildEventsTable(build)).
+		Columns("event_id", "build_id", "type", "version", "payload")
+
+	for _, e := range events {
+		payload, err := json.Marshal(e.Event)
+		if err != nil {
+			return err
+		}
+
+		insert = insert.Values(e.EventID, build.ID(), string(e.Event.EventType()), string(e.Event.Version()), payload)
+	}
+
+	_, err := insert.RunWith(tx).Exec()
+	return err
+}
+
+func (store *postgresEventStore) Flush(build Build, events []BuildEvent) error {
+	return nil
+}
+
+func (store *postgresEventStore) Get(build Build, from uint, notifier Notifier) (EventSource, error) {
+	return newBuildEventSource(
+		build.ID(),
+		buildEventsTable(build),
+		store.conn,
+		notifier,
+		from,
+	), nil
+}
+
+func (store *postgresEventStore) Delete(buildIDs []int) error {
+	if len(buildIDs) == 0 {
+		return nil
+	}
+
+	interfaceBuildIDs := make([]interface{}, len(buildIDs))
+	for i, buildID := range buildIDs {
+		interfaceBuildIDs[i] = buildID
+	}
+
+	indexStrings := make([]string, len(buildIDs))
+	for i := range indexStrings {
+		indexStrings[i] = "$" + strconv.Itoa(i+1)
+	}
+
+	_, err := store.conn.Exec(`
+		DELETE FROM build_events
+		WHERE build_id IN (`+strings.Join(indexStrings, ",")+`)
+	`, interfaceBuildIDs...)
+	return err
+}
+
+func buildEventsTable(build Build) string {
+	if build.PipelineID() != 0 {
+		return fmt.Sprintf("pipeline_build_events_%d", build.PipelineID())
+	}
+
+	return fmt.Sprintf("team_build_events_%d", build.TeamID())
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/concourse/concourse/atc"
@@ -21,6 +22,7 @@ type Job interface {
 	FirstLoggedBuildID() int
 	PipelineID() int
 	PipelineName() string
+	PipelineInstanceVars() atc.InstanceVars
 	TeamID() int
 	TeamName() string
 	Config() atc.JobConfig
@@ -32,15 +34,19 @@ type Job interface {
 	Pause() error
 	Unpause() error
 
-	CreateBuild() (Build, error)
+	CreateBuild(createdBy atc.BuildCreator) (Build, error)
+	RerunBuild(buildToRerun Build, rerunBy atc.BuildCreator) (Build, error)
 	Builds(page Page) ([]Build, Pagination, error)
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
 	Build(name string) (Build, bool, error)
 	FinishedAndNextBuild() (Build, Build, error)
 	UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error
-	EnsurePendingBuildExists() error
+	EnsurePendingBuildExists(createdBy atc.BuildCreator) error
 	GetPendingBuilds() ([]Build, error)
 
+	ScheduleTime() time.Time
+	EnsureScheduledBuildExists(lastScheduleTime time.Time, scheduleTime time.Time) (bool, error)
+
 	GetIndependentBuildInputs() ([]BuildInput, error)
 	GetNextBuildInputs() ([]BuildInput, bool, error)
 	SaveNextInputMapping(inputMapping algorithm.InputMapping) error
@@ -53,11 +59,14 @@ type Job interface {
 
 	ClearTaskCache(string, string) (int64, error)
 
+	TestHistory(builds int) ([]atc.TestHistory, error)
+	Stats(since time.Time, until time.Time) (atc.JobStats, error)
+
 	SetHasNewInputs(bool) error
 	HasNewInputs() bool
 }
 
-var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs").
+var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs", "j.schedule_time", "p.instance_vars").
 	From("jobs j, pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id").
 	Where(sq.Expr("j.pipeline_id = p.id"))
@@ -73,17 +82,19 @@ func (e FirstLoggedBuildIDDecreasedError) Error() string {
 }
 
 type job struct {
-	id                 int
-	name               string
-	paused             bool
-	firstLoggedBuildID int
-	pipelineID         int
-	pipelineName       string
-	teamID             int
-	teamName           string
-	config             atc.JobConfig
-	tags               []string
-	hasNewInputs       bool
+	id                   int
+	name                 string
+	paused               bool
+	firstLoggedBuildID   int
+	pipelineID           int
+	pipelineName         string
+	pipelineInstanceVars atc.InstanceVars
+	teamID               int
+	teamName             string
+	config               atc.JobConfig
+	tags                 []string
+	hasNewInputs         bool
+	scheduleTime         time.Time
 
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -123,18 +134,20 @@ func (jobs Jobs) Configs() atc.JobConfigs {
 	return configs
 }
 
-func (j *job) ID() int                 { return j.id }
-func (j *job) Name() string            { return j.name }
-func (j *job) Paused() bool            { return j.paused }
-func (j *job) FirstLoggedBuildID() int { return j.firstLoggedBuildID }
-func (j *job) PipelineID() int         { return j.pipelineID }
-func (j *job) PipelineName() string    { return j.pipelineName }
-func (j *job) TeamID() int             { return j.teamID }
-func (j *job) TeamName() string        { return j.teamName }
-func (j *job) Config() atc.JobConfig   { return j.config }
-func (j *job) Tags() []string          { return j.tags }
-func (j *job) Public() bool            { return j.Config().Public }
-func (j *job) HasNewInputs() bool      { return j.hasNewInputs }
+func (j *job) ID() int                                { return j.id }
+func (j *job) Name() string                           { return j.name }
+func (j *job) Paused() bool                           { return j.paused }
+func (j *job) FirstLoggedBuildID() int                { return j.firstLoggedBuildID }
+func (j *job) PipelineID() int                        { return j.pipelineID }
+func (j *job) PipelineName() string                   { return j.pipelineName }
+func (j *job) PipelineInstanceVars() atc.InstanceVars { return j.pipelineInstanceVars }
+func (j *job) TeamID() int                            { return j.teamID }
+func (j *job) TeamName() string                       { return j.teamName }
+func (j *job) Config() atc.JobConfig                  { return j.config }
+func (j *job) Tags() []string                         { return j.tags }
+func (j *job) Public() bool                           { return j.Config().Public }
+func (j *job) HasNewInputs() bool                     { return j.hasNewInputs }
+func (j *job) ScheduleTime() time.Time                { return j.scheduleTime }
 
 func (j *job) Reload() (bool, error) {
 	row := jobsQuery.Where(sq.Eq{"j.id": j.id}).
@@ -273,15 +286,22 @@ func (j *job) GetNextPendingBuildBySerialGroup(serialGroups []string) (Build, bo
 		return nil, false, err
 	}
 
-	row := buildsQuery.Options(`DISTINCT ON (b.id)`).
-		Join(`jobs_serial_groups jsg ON j.id = jsg.job_id`).
+	serialGroupJobs, args, err := sq.Select("job_id").
+		From("jobs_serial_groups").
+		Where(sq.Eq{"serial_group": serialGroups}).
+		ToSql()
+	if err != nil {
+		return nil, false, err
+	}
+
+	row := buildsQuery.
+		Where(sq.Expr("j.id IN ("+serialGroupJobs+")", args...)).
 		Where(sq.Eq{
-			"jsg.serial_group":    serialGroups,
 			"b.status":            BuildStatusPending,
 			"j.paused":            false,
 			"j.inputs_determined": true,
 			"j.pipeline_id":       j.pipelineID}).
-		OrderBy("b.id ASC").
+		OrderBy("b.priority DESC", "b.id ASC").
 		Limit(1).
 		RunWith(j.conn).
 		QueryRow()
@@ -425,7 +445,12 @@ func (j *job) DeleteNextInputMapping() error {
 	return tx.Commit()
 }
 
-func (j *job) EnsurePendingBuildExists() error {
+func (j *job) EnsurePendingBuildExists(createdBy atc.BuildCreator) error {
+	createdByPayload, err := json.Marshal(createdBy)
+	if err != nil {
+		return err
+	}
+
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return err
@@ -439,12 +464,12 @@ func (j *job) EnsurePendingBuildExists() error {
 	}
 
 	rows, err := tx.Query(`
-		INSERT INTO builds (name, job_id, pipeline_id, team_id, status)
-		SELECT $1, $2, $3, $4, 'pending'
+		INSERT INTO builds (name, job_id, pipeline_id, team_id, status, priority, created_by)
+		SELECT $1, $2, $3, $4, 'pending', $5, $6
 		WHERE NOT EXISTS
 			(SELECT id FROM builds WHERE job_id = $2 AND status = 'pending')
 		RETURNING id
-	`, buildName, j.id, j.pipelineID, j.teamID)
+	`, buildName, j.id, j.pipelineID, j.teamID, j.config.Priority, string(createdByPayload))
 	if err != nil {
 		return err
 	}
@@ -474,6 +499,80 @@ func (j *job) EnsurePendingBuildExists() error {
 	return nil
 }
 
+// EnsureScheduledBuildExists records that the job's schedule triggered at the
+// given time and makes sure a pending build exists for it, marked as
+// created by the schedule unless a pending build already existed.
+//
+// It returns false without doing anything if the schedule has triggered
+// since lastScheduleTime, e.g. by another ATC.
+func (j *job) EnsureScheduledBuildExists(lastScheduleTime time.Time, scheduleTime time.Time) (bool, error) {
+	createdBy, err := json.Marshal(atc.BuildCreator{Type: atc.BuildCreatorSchedule})
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	var newScheduleTime time.Time
+	err = tx.QueryRow(`
+		UPDATE jobs
+		SET schedule_time = $3
+		WHERE id = $1 AND schedule_time = $2
+		RETURNING schedule_time
+	`, j.id, lastScheduleTime, scheduleTime).Scan(&newScheduleTime)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	var pending bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (SELECT id FROM builds WHERE job_id = $1 AND status = 'pending')
+	`, j.id).Scan(&pending)
+	if err != nil {
+		return false, err
+	}
+
+	if !pending {
+		buildName, err := j.getNewBuildName(tx)
+		if err != nil {
+			return false, err
+		}
+
+		var buildID int
+		err = tx.QueryRow(`
+			INSERT INTO builds (name, job_id, pipeline_id, team_id, status, priority, created_by)
+			VALUES ($1, $2, $3, $4, 'pending', $5, $6)
+			RETURNING id
+		`, buildName, j.id, j.pipelineID, j.teamID, j.config.Priority, string(createdBy)).Scan(&buildID)
+		if err != nil {
+			return false, err
+		}
+
+		err = createBuildEventSeq(tx, buildID)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	j.scheduleTime = newScheduleTime
+
+	return true, nil
+}
+
 func (j *job) GetPendingBuilds() ([]Build, error) {
 	builds := []Build{}
 
@@ -494,7 +593,7 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 			"b.job_id": j.id,
 			"b.status": BuildStatusPending,
 		}).
-		OrderBy("b.id ASC").
+		OrderBy("b.priority DESC", "b.id ASC").
 		RunWith(j.conn).
 		Query()
 	if err != nil {
@@ -516,7 +615,12 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 	return builds, nil
 }
 
-func (j *job) CreateBuild() (Build, error) {
+func (j *job) CreateBuild(createdBy atc.BuildCreator) (Build, error) {
+	createdByPayload, err := json.Marshal(createdBy)
+	if err != nil {
+		return nil, err
+	}
+
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -537,6 +641,92 @@ func (j *job) CreateBuild() (Build, error) {
 		"team_id":            j.teamID,
 		"status":             BuildStatusPending,
 		"manually_triggered": true,
+		"priority":           j.config.Priority,
+		"created_by":         string(createdByPayload),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = updateNextBuildForJob(tx, j.id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return build, nil
+}
+
+// RerunBuild creates a pending build which will run with the same input
+// versions as the given build, named after it. Reruns of a rerun rerun the
+// original build.
+//
+// The build is recorded as created by the given rerun, which is completed
+// with the original build.
+func (j *job) RerunBuild(buildToRerun Build, rerunBy atc.BuildCreator) (Build, error) {
+	rerunOf := buildToRerun.ID()
+	if buildToRerun.RerunOf() != 0 {
+		rerunOf = buildToRerun.RerunOf()
+	}
+
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Rollback(tx)
+
+	// lock the original build so that concurrent reruns are numbered in turn
+	var rerunOfName string
+	err = psql.Select("name").
+		From("builds").
+		Where(sq.Eq{"id": rerunOf}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&rerunOfName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrBuildDisappeared
+		}
+		return nil, err
+	}
+
+	var rerunNumber int
+	err = psql.Select("COALESCE(MAX(rerun_number), 0) + 1").
+		From("builds").
+		Where(sq.Eq{"rerun_of": rerunOf}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&rerunNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	rerunBy.Type = atc.BuildCreatorRerun
+	rerunBy.BuildID = rerunOf
+	rerunBy.BuildName = rerunOfName
+
+	createdBy, err := json.Marshal(rerunBy)
+	if err != nil {
+		return nil, err
+	}
+
+	build := &build{conn: j.conn, lockFactory: j.lockFactory}
+	err = createBuild(tx, build, map[string]interface{}{
+		"name":         fmt.Sprintf("%s.%d", rerunOfName, rerunNumber),
+		"job_id":       j.id,
+		"pipeline_id":  j.pipelineID,
+		"team_id":      j.teamID,
+		"status":       BuildStatusPending,
+		"priority":     j.config.Priority,
+		"created_by":   string(createdBy),
+		"rerun_of":     rerunOf,
+		"rerun_number": rerunNumber,
 	})
 	if err != nil {
 		return nil, err
@@ -825,15 +1015,27 @@ func (j *job) finishedBuild() (Build, error) {
 
 func scanJob(j *job, row scannable) error {
 	var (
-		configBlob []byte
-		nonce      sql.NullString
+		configBlob   []byte
+		nonce        sql.NullString
+		schedule     pq.NullTime
+		instanceVars sql.NullString
 	)
 
-	err := row.Scan(&j.id, &j.name, &configBlob, &j.paused, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs)
+	err := row.Scan(&j.id, &j.name, &configBlob, &j.paused, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &schedule, &instanceVars)
 	if err != nil {
 		return err
 	}
 
+	j.scheduleTime = schedule.Time
+
+	j.pipelineInstanceVars = nil
+	if instanceVars.Valid {
+		err = json.Unmarshal([]byte(instanceVars.String), &j.pipelineInstanceVars)
+		if err != nil {
+			return err
+		}
+	}
+
 	es := j.conn.EncryptionStrategy()
 
 	var noncense *string
@@ -875,3 +1077,85 @@ func scanJobs(conn Conn, lockFactory lock.LockFactory, rows *sql.Rows) (Jobs, er
 
 	return jobs, nil
 }
+
+// TestHistory returns the results of the tests reported by the job's most
+// recent builds, along with how often each test failed or flaked across them.
+func (j *job) TestHistory(builds int) ([]atc.TestHistory, error) {
+	rows, err := psql.Select("r.suite", "r.name", "r.status", "r.duration", "b.id", "b.name").
+		From("test_results r").
+		Join("builds b ON b.id = r.build_id").
+		Where(sq.Eq{"r.job_id": j.id}).
+		Where(sq.Expr("r.build_id IN (SELECT id FROM builds WHERE job_id = ? ORDER BY id DESC LIMIT ?)", j.id, builds)).
+		OrderBy("r.suite", "r.name", "b.id DESC", "r.id").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	histories := []atc.TestHistory{}
+	for rows.Next() {
+		var (
+			suite, name string
+			build       atc.TestHistoryBuild
+		)
+
+		err = rows.Scan(&suite, &name, &build.Status, &build.Duration, &build.BuildID, &build.BuildName)
+		if err != nil {
+			return nil, err
+		}
+
+		last := len(histories) - 1
+		if last < 0 || histories[last].Suite != suite || histories[last].Name != name {
+			histories = append(histories, atc.TestHistory{Suite: suite, Name: name})
+			last++
+		}
+
+		histories[last].Builds = append(histories[last].Builds, build)
+	}
+
+	for i, history := range histories {
+		histories[i].Runs = len(history.Builds)
+		histories[i].Failures, histories[i].FlakeRate = failuresAndFlakeRate(history.Builds)
+	}
+
+	return histories, nil
+}
+
+// failuresAndFlakeRate counts the failed runs of a test and determines how
+// often it flipped between passing and failing from one run to the next.
+// Skipped runs are ignored.
+func failuresAndFlakeRate(builds []atc.TestHistoryBuild) (int, float64) {
+	var (
+		failures int
+		runs     int
+		flips    int
+		failed   bool
+	)
+
+	for _, build := range builds {
+		if build.Status == atc.TestStatusSkipped {
+			continue
+		}
+
+		isFailure := build.Status == atc.TestStatusFailed || build.Status == atc.TestStatusErrored
+		if isFailure {
+			failures++
+		}
+
+		if runs > 0 && isFailure != failed {
+			flips++
+		}
+
+		failed = isFailure
+		runs++
+	}
+
+	if runs < 2 {
+		return failures, 0
+	}
+
+	return failures, float64(flips) / float64(runs-1)
+}
@@ -16,7 +16,7 @@ var _ = Describe("Job Factory", func() {
 		otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "other-team"})
 		Expect(err).NotTo(HaveOccurred())
 
-		publicPipeline, _, err := otherTeam.SavePipeline("public-pipeline", atc.Config{
+		publicPipeline, _, err := otherTeam.SavePipeline(atc.PipelineRef{Name: "public-pipeline"}, atc.Config{
 			Jobs: atc.JobConfigs{
 				{Name: "public-pipeline-job"},
 			},
@@ -24,7 +24,7 @@ var _ = Describe("Job Factory", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(publicPipeline.Expose()).To(Succeed())
 
-		_, _, err = otherTeam.SavePipeline("private-pipeline", atc.Config{
+		_, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "private-pipeline"}, atc.Config{
 			Jobs: atc.JobConfigs{
 				{Name: "private-pipeline-job"},
 			},
@@ -47,13 +47,13 @@ var _ = Describe("Job Factory", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			transitionBuild, err := job.CreateBuild()
+			transitionBuild, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			err = transitionBuild.Finish(db.BuildStatusSucceeded)
 			Expect(err).ToNot(HaveOccurred())
 
-			finishedBuild, err := job.CreateBuild()
+			finishedBuild, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			err = finishedBuild.Finish(db.BuildStatusSucceeded)
@@ -63,7 +63,7 @@ var _ = Describe("Job Factory", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			nextBuild, err := job.CreateBuild()
+			nextBuild, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			visibleJobs, err := jobFactory.VisibleJobs([]string{"default-team"})
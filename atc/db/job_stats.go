@@ -0,0 +1,327 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/lib/pq"
+)
+
+// stepEventTypes are the events marking the phases of each step, from which
+// step durations are derived.
+var stepEventTypes = map[atc.EventType]string{
+	event.EventTypeInitializeGet:         "initialize",
+	event.EventTypeStartGet:              "start",
+	event.EventTypeFinishGet:             "finish",
+	event.EventTypeInitializePut:         "initialize",
+	event.EventTypeStartPut:              "start",
+	event.EventTypeFinishPut:             "finish",
+	event.EventTypeInitializeTask:        "initialize",
+	event.EventTypeStartTask:             "start",
+	event.EventTypeFinishTask:            "finish",
+	event.EventTypeInitializeSetPipeline: "initialize",
+	event.EventTypeStartSetPipeline:      "start",
+	event.EventTypeFinishSetPipeline:     "finish",
+	event.EventTypeInitializeLoadVar:     "initialize",
+	event.EventTypeStartLoadVar:          "start",
+	event.EventTypeFinishLoadVar:         "finish",
+	event.EventTypeInitializeApproval:    "initialize",
+	event.EventTypeStartApproval:         "start",
+	event.EventTypeFinishApproval:        "finish",
+}
+
+// Stats summarizes the job's builds created within the given time range:
+// how they ended, how long they and each of their steps took, and how long
+// they were pending.
+//
+// Step durations are derived from the build events, which are queried
+// directly, so ErrBuildEventsNotInPostgres is returned when they are kept in
+// another EventStore.
+func (j *job) Stats(since time.Time, until time.Time) (atc.JobStats, error) {
+	if !storesEventsInPostgres(j.conn) {
+		return atc.JobStats{}, ErrBuildEventsNotInPostgres
+	}
+
+	stats := atc.JobStats{
+		Since: since.Unix(),
+		Until: until.Unix(),
+		Steps: []atc.StepStats{},
+	}
+
+	window := sq.And{
+		sq.Eq{"job_id": j.id},
+		sq.GtOrEq{"create_time": since},
+		sq.Lt{"create_time": until},
+	}
+
+	rows, err := psql.Select("id", "status", "create_time", "start_time", "end_time", "public_plan").
+		From("builds").
+		Where(window).
+		OrderBy("id DESC").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return atc.JobStats{}, err
+	}
+
+	defer Close(rows)
+
+	var durations, queueTimes []float64
+
+	steps := map[int]map[string]stepKey{}
+
+	for rows.Next() {
+		var (
+			id                             int
+			status                         string
+			createTime, startTime, endTime pq.NullTime
+			publicPlan                     sql.NullString
+		)
+
+		err = rows.Scan(&id, &status, &createTime, &startTime, &endTime, &publicPlan)
+		if err != nil {
+			return atc.JobStats{}, err
+		}
+
+		stats.Builds++
+
+		switch BuildStatus(status) {
+		case BuildStatusSucceeded:
+			stats.Succeeded++
+		case BuildStatusFailed:
+			stats.Failed++
+		case BuildStatusErrored:
+			stats.Errored++
+		case BuildStatusAborted:
+			stats.Aborted++
+		}
+
+		if startTime.Valid && createTime.Valid {
+			queueTimes = append(queueTimes, startTime.Time.Sub(createTime.Time).Seconds())
+		}
+
+		if startTime.Valid && endTime.Valid && BuildStatus(status) != BuildStatusAborted {
+			durations = append(durations, endTime.Time.Sub(startTime.Time).Seconds())
+		}
+
+		if publicPlan.Valid {
+			steps[id] = planSteps([]byte(publicPlan.String))
+		}
+	}
+
+	if completed := stats.Succeeded + stats.Failed + stats.Errored; completed > 0 {
+		stats.SuccessRate = float64(stats.Succeeded) / float64(completed)
+	}
+
+	stats.Duration = atc.NewDurationStats(durations)
+	stats.QueueTime = atc.NewDurationStats(queueTimes)
+
+	if stats.Builds == 0 {
+		return stats, nil
+	}
+
+	stats.Steps, err = j.stepStats(window, steps)
+	if err != nil {
+		return atc.JobStats{}, err
+	}
+
+	return stats, nil
+}
+
+type stepKey struct {
+	stepType string
+	name     string
+}
+
+type stepTimes struct {
+	initialize int64
+	start      int64
+	finish     int64
+}
+
+// stepStats collects the durations of each step from the build events of
+// the builds in the window. Steps are returned in the order they ran in the
+// most recent build which ran them.
+func (j *job) stepStats(window sq.Sqlizer, steps map[int]map[string]stepKey) ([]atc.StepStats, error) {
+	eventTypes := []string{}
+	for eventType := range stepEventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	buildIDs, args, err := sq.Select("id").From("builds").Where(window).ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := psql.Select("build_id", "type", "payload").
+		From(fmt.Sprintf("pipeline_build_events_%d", j.pipelineID)).
+		Where(sq.Eq{"type": eventTypes}).
+		Where(sq.Expr("build_id IN ("+buildIDs+")", args...)).
+		OrderBy("build_id DESC", "event_id ASC").
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var (
+		order        []stepKey
+		initializing = map[stepKey][]float64{}
+		running      = map[stepKey][]float64{}
+	)
+
+	record := func(buildID int, times map[string]*stepTimes) {
+		for origin, t := range times {
+			key, found := steps[buildID][origin]
+			if !found || t.initialize == 0 || t.finish == 0 {
+				continue
+			}
+
+			if t.start != 0 {
+				initializing[key] = append(initializing[key], float64(t.start-t.initialize))
+			}
+
+			running[key] = append(running[key], float64(t.finish-t.initialize))
+		}
+	}
+
+	var (
+		currentBuild int
+		times        map[string]*stepTimes
+	)
+
+	for rows.Next() {
+		var (
+			buildID   int
+			eventType string
+			payload   string
+		)
+
+		err = rows.Scan(&buildID, &eventType, &payload)
+		if err != nil {
+			return nil, err
+		}
+
+		if buildID != currentBuild {
+			record(currentBuild, times)
+
+			currentBuild = buildID
+			times = map[string]*stepTimes{}
+		}
+
+		var e struct {
+			Origin event.Origin `json:"origin"`
+			Time   int64        `json:"time"`
+		}
+
+		err = json.Unmarshal([]byte(payload), &e)
+		if err != nil {
+			return nil, err
+		}
+
+		origin := string(e.Origin.ID)
+
+		key, found := steps[buildID][origin]
+		if found && !containsStep(order, key) {
+			order = append(order, key)
+		}
+
+		t, found := times[origin]
+		if !found {
+			t = &stepTimes{}
+			times[origin] = t
+		}
+
+		switch stepEventTypes[atc.EventType(eventType)] {
+		case "initialize":
+			t.initialize = e.Time
+		case "start":
+			t.start = e.Time
+		case "finish":
+			t.finish = e.Time
+		}
+	}
+
+	record(currentBuild, times)
+
+	stats := []atc.StepStats{}
+	for _, key := range order {
+		if len(running[key]) == 0 {
+			continue
+		}
+
+		stats = append(stats, atc.StepStats{
+			Type:           key.stepType,
+			Name:           key.name,
+			Initialization: atc.NewDurationStats(initializing[key]),
+			Duration:       atc.NewDurationStats(running[key]),
+		})
+	}
+
+	return stats, nil
+}
+
+func containsStep(keys []stepKey, key stepKey) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// planSteps maps the IDs of the steps in a build's public plan to their type
+// and name.
+func planSteps(publicPlan []byte) map[string]stepKey {
+	var plan interface{}
+	err := json.Unmarshal(publicPlan, &plan)
+	if err != nil {
+		return map[string]stepKey{}
+	}
+
+	steps := map[string]stepKey{}
+	collectPlanSteps(plan, steps)
+
+	return steps
+}
+
+func collectPlanSteps(plan interface{}, steps map[string]stepKey) {
+	switch p := plan.(type) {
+	case []interface{}:
+		for _, sub := range p {
+			collectPlanSteps(sub, steps)
+		}
+
+	case map[string]interface{}:
+		if id, ok := p["id"].(string); ok {
+			for _, stepType := range []string{"get", "put", "task", "set_pipeline", "load_var", "approval"} {
+				step, ok := p[stepType].(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				name, _ := step["name"].(string)
+				if name == "" {
+					// gets and puts are named after their resource by default
+					name, _ = step["resource"].(string)
+				}
+
+				if name != "" {
+					steps[id] = stepKey{stepType: stepType, name: name}
+				}
+			}
+		}
+
+		for _, sub := range p {
+			collectPlanSteps(sub, steps)
+		}
+	}
+}
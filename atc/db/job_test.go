@@ -6,6 +6,8 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/algorithm"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -23,7 +25,7 @@ var _ = Describe("Job", func() {
 		Expect(err).ToNot(HaveOccurred())
 
 		var created bool
-		pipeline, created, err = team.SavePipeline("fake-pipeline", atc.Config{
+		pipeline, created, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
 			Jobs: atc.JobConfigs{
 				{
 					Name: "some-job",
@@ -122,6 +124,43 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("PipelineInstanceVars", func() {
+		It("is empty for jobs of a pipeline without instance vars", func() {
+			Expect(job.PipelineInstanceVars()).To(BeEmpty())
+		})
+
+		Context("when the job belongs to a pipeline instance", func() {
+			var instanceJob db.Job
+
+			BeforeEach(func() {
+				instance, _, err := team.SavePipeline(atc.PipelineRef{
+					Name:         "fake-pipeline",
+					InstanceVars: atc.InstanceVars{"branch": "feature"},
+				}, atc.Config{
+					Jobs: atc.JobConfigs{{Name: "some-job"}},
+				}, db.ConfigVersion(0), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				var found bool
+				instanceJob, found, err = instance.Job("some-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("returns the instance vars of the pipeline, as do its builds", func() {
+				Expect(instanceJob.PipelineInstanceVars()).To(Equal(atc.InstanceVars{"branch": "feature"}))
+
+				build, err := instanceJob.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				found, err := build.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.PipelineInstanceVars()).To(Equal(atc.InstanceVars{"branch": "feature"}))
+			})
+		})
+	})
+
 	Describe("Pause and Unpause", func() {
 		It("starts out as unpaused", func() {
 			Expect(job.Paused()).To(BeFalse())
@@ -157,7 +196,7 @@ var _ = Describe("Job", func() {
 		BeforeEach(func() {
 			var created bool
 			var err error
-			otherPipeline, created, err = team.SavePipeline("other-pipeline", atc.Config{
+			otherPipeline, created, err = team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 				Jobs: atc.JobConfigs{
 					{Name: "some-job"},
 				},
@@ -178,13 +217,13 @@ var _ = Describe("Job", func() {
 			Expect(next).To(BeNil())
 			Expect(finished).To(BeNil())
 
-			finishedBuild, err := job.CreateBuild()
+			finishedBuild, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			err = finishedBuild.Finish(db.BuildStatusSucceeded)
 			Expect(err).NotTo(HaveOccurred())
 
-			otherFinishedBuild, err := otherJob.CreateBuild()
+			otherFinishedBuild, err := otherJob.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			err = otherFinishedBuild.Finish(db.BuildStatusSucceeded)
@@ -196,14 +235,14 @@ var _ = Describe("Job", func() {
 			Expect(next).To(BeNil())
 			Expect(finished.ID()).To(Equal(finishedBuild.ID()))
 
-			nextBuild, err := job.CreateBuild()
+			nextBuild, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			started, err := nextBuild.Start(atc.Plan{})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(started).To(BeTrue())
 
-			otherNextBuild, err := otherJob.CreateBuild()
+			otherNextBuild, err := otherJob.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			otherStarted, err := otherNextBuild.Start(atc.Plan{})
@@ -216,7 +255,7 @@ var _ = Describe("Job", func() {
 			Expect(next.ID()).To(Equal(nextBuild.ID()))
 			Expect(finished.ID()).To(Equal(finishedBuild.ID()))
 
-			anotherRunningBuild, err := job.CreateBuild()
+			anotherRunningBuild, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			finished, next, err = job.FinishedAndNextBuild()
@@ -293,10 +332,10 @@ var _ = Describe("Job", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err := someJob.CreateBuild()
+				build, err := someJob.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err = someOtherJob.CreateBuild()
+				_, err = someOtherJob.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				builds[i] = build
@@ -387,7 +426,7 @@ var _ = Describe("Job", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), false)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err = pipeline.Job("some-job")
@@ -395,7 +434,7 @@ var _ = Describe("Job", func() {
 			Expect(found).To(BeTrue())
 
 			for i := range builds {
-				builds[i], err = job.CreateBuild()
+				builds[i], err = job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				buildStart := time.Date(2020, 11, i+1, 0, 0, 0, 0, time.UTC)
@@ -474,12 +513,12 @@ var _ = Describe("Job", func() {
 		Context("when a build exists", func() {
 			BeforeEach(func() {
 				var err error
-				firstBuild, err = job.CreateBuild()
+				firstBuild, err = job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
 			It("finds the latest build", func() {
-				secondBuild, err := job.CreateBuild()
+				secondBuild, err := job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				build, found, err := job.Build("latest")
@@ -521,28 +560,28 @@ var _ = Describe("Job", func() {
 
 			BeforeEach(func() {
 				var err error
-				_, err = job.CreateBuild()
+				_, err = job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
-				startedBuild, err = job.CreateBuild()
+				startedBuild, err = job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
-				_, err = startedBuild.Schedule()
+				_, err = startedBuild.Schedule(nil)
 				Expect(err).NotTo(HaveOccurred())
 				_, err = startedBuild.Start(atc.Plan{})
 				Expect(err).NotTo(HaveOccurred())
 
-				scheduledBuild, err = job.CreateBuild()
+				scheduledBuild, err = job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
-				scheduled, err := scheduledBuild.Schedule()
+				scheduled, err := scheduledBuild.Schedule(nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(scheduled).To(BeTrue())
 
 				for _, s := range []db.BuildStatus{db.BuildStatusSucceeded, db.BuildStatusFailed, db.BuildStatusErrored, db.BuildStatusAborted} {
-					finishedBuild, err := job.CreateBuild()
+					finishedBuild, err := job.CreateBuild(defaultBuildCreatedBy)
 					Expect(err).NotTo(HaveOccurred())
 
-					scheduled, err = finishedBuild.Schedule()
+					scheduled, err = finishedBuild.Schedule(nil)
 					Expect(err).NotTo(HaveOccurred())
 					Expect(scheduled).To(BeTrue())
 
@@ -554,7 +593,7 @@ var _ = Describe("Job", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				_, err = otherJob.CreateBuild()
+				_, err = otherJob.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -576,17 +615,17 @@ var _ = Describe("Job", func() {
 
 			BeforeEach(func() {
 				var err error
-				_, err = job.CreateBuild()
+				_, err = job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				otherSerialJob, found, err := pipeline.Job("other-serial-group-job")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				serialGroupBuild, err = otherSerialJob.CreateBuild()
+				serialGroupBuild, err = otherSerialJob.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
-				scheduled, err := serialGroupBuild.Schedule()
+				scheduled, err := serialGroupBuild.Schedule(nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(scheduled).To(BeTrue())
 
@@ -594,10 +633,10 @@ var _ = Describe("Job", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				differentSerialGroupBuild, err := differentSerialJob.CreateBuild()
+				differentSerialGroupBuild, err := differentSerialJob.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
-				scheduled, err = differentSerialGroupBuild.Schedule()
+				scheduled, err = differentSerialGroupBuild.Schedule(nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(scheduled).To(BeTrue())
 			})
@@ -631,10 +670,10 @@ var _ = Describe("Job", func() {
 			var actualBuild db.Build
 
 			BeforeEach(func() {
-				_, err := job1.CreateBuild()
+				_, err := job1.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
-				actualBuild, err = job2.CreateBuild()
+				actualBuild, err = job2.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				err = job2.SaveNextInputMapping(nil)
@@ -649,14 +688,35 @@ var _ = Describe("Job", func() {
 			})
 		})
 
+		It("should return the highest-priority pending build in a group of jobs first", func() {
+			_, err := job1.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+
+			priorityBuild, err := job2.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = priorityBuild.SetPriority(10)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = job1.SaveNextInputMapping(nil)
+			Expect(err).NotTo(HaveOccurred())
+			err = job2.SaveNextInputMapping(nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			build, found, err := job1.GetNextPendingBuildBySerialGroup([]string{"serial-group"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.ID()).To(Equal(priorityBuild.ID()))
+		})
+
 		It("should return the next most pending build in a group of jobs", func() {
-			buildOne, err := job1.CreateBuild()
+			buildOne, err := job1.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
-			buildTwo, err := job1.CreateBuild()
+			buildTwo, err := job1.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
-			buildThree, err := job2.CreateBuild()
+			buildThree, err := job2.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).NotTo(HaveOccurred())
 
 			err = job1.SaveNextInputMapping(nil)
@@ -697,7 +757,7 @@ var _ = Describe("Job", func() {
 			Expect(found).To(BeTrue())
 			Expect(build.ID()).To(Equal(buildTwo.ID()))
 
-			scheduled, err := buildTwo.Schedule()
+			scheduled, err := buildTwo.Schedule(nil)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(scheduled).To(BeTrue())
 			Expect(buildTwo.Finish(db.BuildStatusSucceeded)).To(Succeed())
@@ -788,7 +848,7 @@ var _ = Describe("Job", func() {
 				},
 			}
 
-			pipeline2, _, err = team.SavePipeline("some-pipeline-2", config, 1, false)
+			pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline-2"}, config, 1, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource2, found, err = pipeline2.Resource("some-resource")
@@ -968,7 +1028,7 @@ var _ = Describe("Job", func() {
 				},
 			}
 
-			pipeline2, _, err = team.SavePipeline("some-pipeline-2", config, 1, false)
+			pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline-2"}, config, 1, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource2, found, err = pipeline2.Resource("some-resource")
@@ -1120,10 +1180,10 @@ var _ = Describe("Job", func() {
 				},
 			}
 			var err error
-			otherPipeline, _, err = team.SavePipeline("some-other-pipeline", pipelineConfig, db.ConfigVersion(1), false)
+			otherPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-other-pipeline"}, pipelineConfig, db.ConfigVersion(1), false)
 			Expect(err).ToNot(HaveOccurred())
 
-			build1DB, err = job.CreateBuild()
+			build1DB, err = job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(build1DB.ID()).NotTo(BeZero())
@@ -1138,6 +1198,10 @@ var _ = Describe("Job", func() {
 			Expect(found).To(BeTrue())
 		})
 
+		It("records who created the build", func() {
+			Expect(build1DB.CreatedBy()).To(Equal(&defaultBuildCreatedBy))
+		})
+
 		It("becomes the next pending build for job", func() {
 			nextPendings, err := job.GetPendingBuilds()
 			Expect(err).NotTo(HaveOccurred())
@@ -1155,7 +1219,7 @@ var _ = Describe("Job", func() {
 
 		Context("and another build for a different pipeline is created with the same job name", func() {
 			BeforeEach(func() {
-				otherBuild, err := otherJob.CreateBuild()
+				otherBuild, err := otherJob.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(otherBuild.ID()).NotTo(BeZero())
@@ -1183,7 +1247,7 @@ var _ = Describe("Job", func() {
 			BeforeEach(func() {
 				var err error
 				var found bool
-				found, err = build1DB.Schedule()
+				found, err = build1DB.Schedule(nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 			})
@@ -1247,7 +1311,7 @@ var _ = Describe("Job", func() {
 
 			BeforeEach(func() {
 				var err error
-				build2DB, err = job.CreateBuild()
+				build2DB, err = job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(build2DB.ID()).NotTo(BeZero())
@@ -1275,10 +1339,203 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("RerunBuild", func() {
+		var (
+			resource      db.Resource
+			originalBuild db.Build
+			rerunBuild    db.Build
+		)
+
+		BeforeEach(func() {
+			setupTx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			brt := db.BaseResourceType{
+				Name: "some-type",
+			}
+
+			_, err = brt.FindOrCreate(setupTx, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setupTx.Commit()).To(Succeed())
+
+			var found bool
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resourceConfigScope, err := resource.SetResourceConfig(atc.Source{"some": "source"}, atc.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "v1"}, {"version": "v2"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			originalBuild, err = job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = originalBuild.UseInputs([]db.BuildInput{
+				{Name: "some-input", ResourceID: resource.ID(), Version: atc.Version{"version": "v1"}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			rerunBuild, err = job.RerunBuild(originalBuild, atc.BuildCreator{
+				Type: atc.BuildCreatorRerun,
+				User: "some-user",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("creates a pending build named after the original build", func() {
+			Expect(rerunBuild.Name()).To(Equal(originalBuild.Name() + ".1"))
+			Expect(rerunBuild.Status()).To(Equal(db.BuildStatusPending))
+			Expect(rerunBuild.IsManuallyTriggered()).To(BeFalse())
+			Expect(rerunBuild.RerunOf()).To(Equal(originalBuild.ID()))
+		})
+
+		It("records that the build is a rerun of the original build", func() {
+			Expect(rerunBuild.CreatedBy()).To(Equal(&atc.BuildCreator{
+				Type:      atc.BuildCreatorRerun,
+				User:      "some-user",
+				BuildID:   originalBuild.ID(),
+				BuildName: originalBuild.Name(),
+			}))
+		})
+
+		It("reruns with the inputs of the original build", func() {
+			inputs, found, err := rerunBuild.RerunInputs()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(inputs).To(Equal([]db.BuildInput{
+				{Name: "some-input", ResourceID: resource.ID(), Version: atc.Version{"version": "v1"}},
+			}))
+		})
+
+		It("numbers subsequent reruns of the original build", func() {
+			secondRerun, err := job.RerunBuild(originalBuild, defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secondRerun.Name()).To(Equal(originalBuild.Name() + ".2"))
+
+			rerunOfRerun, err := job.RerunBuild(rerunBuild, defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rerunOfRerun.Name()).To(Equal(originalBuild.Name() + ".3"))
+			Expect(rerunOfRerun.RerunOf()).To(Equal(originalBuild.ID()))
+		})
+
+		It("does not change the job's next build inputs", func() {
+			_, found, err := job.GetNextBuildInputs()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("EnsureScheduledBuildExists", func() {
+		var (
+			scheduledPipeline db.Pipeline
+			scheduledJob      db.Job
+		)
+
+		BeforeEach(func() {
+			var err error
+			scheduledPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "scheduled-pipeline"}, atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name:     "nightly",
+						Priority: 5,
+						Schedule: &atc.ScheduleConfig{Cron: "0 2 * * *"},
+					},
+				},
+			}, db.ConfigVersion(0), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			scheduledJob, found, err = scheduledPipeline.Job("nightly")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("starts the schedule when the job is configured", func() {
+			Expect(scheduledJob.ScheduleTime()).To(BeTemporally("~", time.Now(), time.Minute))
+		})
+
+		It("does not restart the schedule when the pipeline is saved again", func() {
+			lastScheduleTime := scheduledJob.ScheduleTime()
+
+			_, _, err := team.SavePipeline(atc.PipelineRef{Name: "scheduled-pipeline"}, atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name:     "nightly",
+						Schedule: &atc.ScheduleConfig{Cron: "0 3 * * *"},
+					},
+				},
+			}, scheduledPipeline.ConfigVersion(), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err := scheduledJob.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(scheduledJob.ScheduleTime()).To(BeTemporally("==", lastScheduleTime))
+		})
+
+		It("does not have a schedule time for jobs without a schedule", func() {
+			Expect(job.ScheduleTime()).To(BeZero())
+		})
+
+		Context("when the schedule triggers", func() {
+			var (
+				lastScheduleTime time.Time
+				scheduleTime     time.Time
+				created          bool
+			)
+
+			BeforeEach(func() {
+				lastScheduleTime = scheduledJob.ScheduleTime()
+				scheduleTime = lastScheduleTime.Add(time.Hour)
+
+				var err error
+				created, err = scheduledJob.EnsureScheduledBuildExists(lastScheduleTime, scheduleTime)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("creates a pending build triggered by the schedule", func() {
+				Expect(created).To(BeTrue())
+
+				pendingBuilds, err := scheduledJob.GetPendingBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(1))
+				Expect(pendingBuilds[0].CreatedBy()).To(Equal(&atc.BuildCreator{Type: atc.BuildCreatorSchedule}))
+				Expect(pendingBuilds[0].Priority()).To(Equal(5))
+			})
+
+			It("records the schedule time", func() {
+				Expect(scheduledJob.ScheduleTime()).To(BeTemporally("~", scheduleTime, time.Millisecond))
+
+				found, err := scheduledJob.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(scheduledJob.ScheduleTime()).To(BeTemporally("~", scheduleTime, time.Millisecond))
+			})
+
+			It("does nothing when the schedule has triggered since", func() {
+				created, err := scheduledJob.EnsureScheduledBuildExists(lastScheduleTime, scheduleTime.Add(time.Hour))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created).To(BeFalse())
+			})
+
+			It("does not create another build while one is pending", func() {
+				created, err := scheduledJob.EnsureScheduledBuildExists(scheduledJob.ScheduleTime(), scheduleTime.Add(time.Hour))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created).To(BeTrue())
+
+				pendingBuilds, err := scheduledJob.GetPendingBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(1))
+			})
+		})
+	})
+
 	Describe("EnsurePendingBuildExists", func() {
 		Context("when only a started build exists", func() {
 			BeforeEach(func() {
-				build1, err := job.CreateBuild()
+				build1, err := job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).NotTo(HaveOccurred())
 
 				started, err := build1.Start(atc.Plan{})
@@ -1287,19 +1544,28 @@ var _ = Describe("Job", func() {
 			})
 
 			It("creates a build", func() {
-				err := job.EnsurePendingBuildExists()
+				err := job.EnsurePendingBuildExists(atc.BuildCreator{
+					Type:     atc.BuildCreatorResource,
+					Resource: "some-resource",
+					Input:    "some-input",
+				})
 				Expect(err).NotTo(HaveOccurred())
 
 				pendingBuilds, err := job.GetPendingBuilds()
 				Expect(err).NotTo(HaveOccurred())
 				Expect(pendingBuilds).To(HaveLen(1))
+				Expect(pendingBuilds[0].CreatedBy()).To(Equal(&atc.BuildCreator{
+					Type:     atc.BuildCreatorResource,
+					Resource: "some-resource",
+					Input:    "some-input",
+				}))
 			})
 
 			It("doesn't create another build the second time it's called", func() {
-				err := job.EnsurePendingBuildExists()
+				err := job.EnsurePendingBuildExists(atc.BuildCreator{Type: atc.BuildCreatorResource})
 				Expect(err).NotTo(HaveOccurred())
 
-				err = job.EnsurePendingBuildExists()
+				err = job.EnsurePendingBuildExists(atc.BuildCreator{Type: atc.BuildCreatorResource})
 				Expect(err).NotTo(HaveOccurred())
 
 				builds2, err := job.GetPendingBuilds()
@@ -1317,6 +1583,64 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("Priority", func() {
+		var priorityJob db.Job
+
+		BeforeEach(func() {
+			priorityPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "priority-pipeline"}, atc.Config{
+				Jobs: atc.JobConfigs{
+					{
+						Name:     "priority-job",
+						Priority: 10,
+					},
+				},
+			}, db.ConfigVersion(0), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			priorityJob, found, err = priorityPipeline.Job("priority-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("creates manually triggered builds with the job's priority", func() {
+			build, err := priorityJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.Priority()).To(Equal(10))
+		})
+
+		It("creates scheduled builds with the job's priority", func() {
+			err := priorityJob.EnsurePendingBuildExists(atc.BuildCreator{Type: atc.BuildCreatorResource})
+			Expect(err).ToNot(HaveOccurred())
+
+			pendingBuilds, err := priorityJob.GetPendingBuilds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(1))
+			Expect(pendingBuilds[0].Priority()).To(Equal(10))
+		})
+
+		It("returns the pending builds with the highest priority first", func() {
+			build1, err := priorityJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			build2, err := priorityJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			build3, err := priorityJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build3.SetPriority(20)
+			Expect(err).ToNot(HaveOccurred())
+
+			pendingBuilds, err := priorityJob.GetPendingBuilds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(3))
+			Expect(pendingBuilds[0].ID()).To(Equal(build3.ID()))
+			Expect(pendingBuilds[1].ID()).To(Equal(build1.ID()))
+			Expect(pendingBuilds[2].ID()).To(Equal(build2.ID()))
+		})
+	})
+
 	Describe("Clear task cache", func() {
 		Context("when task cache exists", func() {
 			var (
@@ -1480,4 +1804,196 @@ var _ = Describe("Job", func() {
 		})
 
 	})
+
+	Describe("TestHistory", func() {
+		var builds []db.Build
+
+		BeforeEach(func() {
+			builds = nil
+
+			for _, statuses := range [][]string{
+				{atc.TestStatusPassed, atc.TestStatusPassed},
+				{atc.TestStatusFailed, atc.TestStatusPassed},
+				{atc.TestStatusPassed, atc.TestStatusSkipped},
+			} {
+				build, err := job.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = build.SaveTestResults([]atc.TestResult{
+					{Suite: "some-suite", Name: "flaky", Status: statuses[0]},
+					{Suite: "some-suite", Name: "stable", Status: statuses[1]},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				builds = append(builds, build)
+			}
+		})
+
+		It("returns each test's results across builds, most recent first", func() {
+			histories, err := job.TestHistory(10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(histories).To(HaveLen(2))
+
+			Expect(histories[0].Suite).To(Equal("some-suite"))
+			Expect(histories[0].Name).To(Equal("flaky"))
+			Expect(histories[0].Runs).To(Equal(3))
+			Expect(histories[0].Failures).To(Equal(1))
+			Expect(histories[0].FlakeRate).To(Equal(1.0))
+			Expect(histories[0].Builds).To(Equal([]atc.TestHistoryBuild{
+				{BuildID: builds[2].ID(), BuildName: builds[2].Name(), Status: atc.TestStatusPassed},
+				{BuildID: builds[1].ID(), BuildName: builds[1].Name(), Status: atc.TestStatusFailed},
+				{BuildID: builds[0].ID(), BuildName: builds[0].Name(), Status: atc.TestStatusPassed},
+			}))
+
+			Expect(histories[1].Name).To(Equal("stable"))
+			Expect(histories[1].Runs).To(Equal(3))
+			Expect(histories[1].Failures).To(Equal(0))
+			Expect(histories[1].FlakeRate).To(Equal(0.0))
+		})
+
+		It("only considers the given number of most recent builds", func() {
+			histories, err := job.TestHistory(1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(histories).To(HaveLen(2))
+			Expect(histories[0].Runs).To(Equal(1))
+			Expect(histories[0].Builds[0].BuildID).To(Equal(builds[2].ID()))
+		})
+	})
+
+	Describe("Stats", func() {
+		var (
+			since time.Time
+			until time.Time
+		)
+
+		BeforeEach(func() {
+			now := time.Now()
+			since = now.Add(-time.Hour)
+			until = now.Add(time.Hour)
+
+			planFactory := atc.NewPlanFactory(0)
+
+			for i, run := range []struct {
+				status   db.BuildStatus
+				queued   int64
+				duration int64
+				task     int64
+			}{
+				{db.BuildStatusSucceeded, 10, 100, 60},
+				{db.BuildStatusFailed, 20, 200, 120},
+				{db.BuildStatusSucceeded, 30, 300, 180},
+			} {
+				build, err := job.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				plan := planFactory.NewPlan(atc.DoPlan{
+					planFactory.NewPlan(atc.GetPlan{Name: "some-input", Resource: "some-resource"}),
+					planFactory.NewPlan(atc.TaskPlan{Name: "unit"}),
+				})
+
+				started, err := build.Start(plan)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(started).To(BeTrue())
+
+				getID := event.OriginID((*plan.Do)[0].ID)
+				taskID := event.OriginID((*plan.Do)[1].ID)
+
+				start := now.Add(-time.Duration(10*(i+1)) * time.Minute).Unix()
+
+				for _, e := range []atc.Event{
+					event.InitializeGet{Origin: event.Origin{ID: getID}, Time: start},
+					event.StartGet{Origin: event.Origin{ID: getID}, Time: start + 1},
+					event.FinishGet{Origin: event.Origin{ID: getID}, Time: start + 5},
+					event.InitializeTask{Origin: event.Origin{ID: taskID}, Time: start + 5},
+					event.StartTask{Origin: event.Origin{ID: taskID}, Time: start + 15},
+					event.FinishTask{Origin: event.Origin{ID: taskID}, Time: start + 5 + run.task},
+				} {
+					err = build.SaveEvent(e)
+					Expect(err).ToNot(HaveOccurred())
+				}
+
+				err = build.Finish(run.status)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbConn.Exec(`
+					UPDATE builds
+					SET create_time = to_timestamp($1), start_time = to_timestamp($2), end_time = to_timestamp($3)
+					WHERE id = $4
+				`, start-run.queued, start, start+run.duration, build.ID())
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			pending, err := job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = pending.MarkAsAborted()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("counts the builds by status", func() {
+			stats, err := job.Stats(since, until)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(stats.Since).To(Equal(since.Unix()))
+			Expect(stats.Until).To(Equal(until.Unix()))
+			Expect(stats.Builds).To(Equal(4))
+			Expect(stats.Succeeded).To(Equal(2))
+			Expect(stats.Failed).To(Equal(1))
+			Expect(stats.Errored).To(Equal(0))
+			Expect(stats.SuccessRate).To(BeNumerically("~", 2.0/3.0))
+		})
+
+		It("summarizes the durations and queue times of the builds", func() {
+			stats, err := job.Stats(since, until)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(stats.Duration).To(Equal(atc.DurationStats{Count: 3, Mean: 200, P50: 200, P95: 300, Max: 300}))
+			Expect(stats.QueueTime).To(Equal(atc.DurationStats{Count: 3, Mean: 20, P50: 20, P95: 30, Max: 30}))
+		})
+
+		It("summarizes the durations of each step", func() {
+			stats, err := job.Stats(since, until)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(stats.Steps).To(Equal([]atc.StepStats{
+				{
+					Type:           "get",
+					Name:           "some-input",
+					Initialization: atc.DurationStats{Count: 3, Mean: 1, P50: 1, P95: 1, Max: 1},
+					Duration:       atc.DurationStats{Count: 3, Mean: 5, P50: 5, P95: 5, Max: 5},
+				},
+				{
+					Type:           "task",
+					Name:           "unit",
+					Initialization: atc.DurationStats{Count: 3, Mean: 10, P50: 10, P95: 10, Max: 10},
+					Duration:       atc.DurationStats{Count: 3, Mean: 120, P50: 120, P95: 180, Max: 180},
+				},
+			}))
+		})
+
+		It("only considers builds created within the range", func() {
+			stats, err := job.Stats(since, time.Now().Add(-15*time.Minute))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(stats.Builds).To(Equal(2))
+			Expect(stats.Failed).To(Equal(1))
+		})
+
+		Context("when build events are stored outside of postgres", func() {
+			It("errors", func() {
+				storePipeline, found, err := db.NewTeamFactory(db.WithEventStore(dbConn, new(dbfakes.FakeEventStore)), lockFactory).
+					GetByID(pipeline.TeamID()).
+					Pipeline(atc.PipelineRef{Name: pipeline.Name()})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				storeJob, found, err := storePipeline.Job(job.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, err = storeJob.Stats(since, until)
+				Expect(err).To(Equal(db.ErrBuildEventsNotInPostgres))
+			})
+		})
+	})
 })
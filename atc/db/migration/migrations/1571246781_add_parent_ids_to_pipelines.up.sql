@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE pipelines
+    ADD COLUMN parent_job_id integer REFERENCES jobs (id) ON DELETE SET NULL,
+    ADD COLUMN parent_build_id integer REFERENCES builds (id) ON DELETE SET NULL;
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+  DROP INDEX pipelines_name_team_id_instance_vars;
+
+  DELETE FROM pipelines
+    WHERE instance_vars IS NOT NULL;
+
+  ALTER TABLE pipelines
+    DROP COLUMN instance_vars,
+    ADD CONSTRAINT pipelines_name_team_id UNIQUE (name, team_id);
+COMMIT;
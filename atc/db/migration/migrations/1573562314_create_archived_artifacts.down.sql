@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE archived_artifacts;
+COMMIT;
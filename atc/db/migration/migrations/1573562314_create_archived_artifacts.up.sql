@@ -0,0 +1,10 @@
+BEGIN;
+  CREATE TABLE archived_artifacts (
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    plan_id text NOT NULL,
+    name text NOT NULL,
+    size bigint NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (build_id, plan_id, name)
+  );
+COMMIT;
@@ -0,0 +1,34 @@
+BEGIN;
+  CREATE OR REPLACE FUNCTION on_pipeline_insert() RETURNS TRIGGER AS $$
+  BEGIN
+          EXECUTE format('CREATE TABLE IF NOT EXISTS pipeline_build_events_%s () INHERITS (build_events)', NEW.id);
+          EXECUTE format('CREATE INDEX IF NOT EXISTS pipeline_build_events_%s_build_id ON pipeline_build_events_%s (build_id)', NEW.id, NEW.id);
+          EXECUTE format('CREATE UNIQUE INDEX IF NOT EXISTS pipeline_build_events_%s_build_id_event_id ON pipeline_build_events_%s (build_id, event_id)', NEW.id, NEW.id);
+          RETURN NULL;
+  END;
+  $$ LANGUAGE plpgsql;
+
+
+  CREATE OR REPLACE FUNCTION on_team_insert() RETURNS TRIGGER AS $$
+  BEGIN
+          EXECUTE format('CREATE TABLE IF NOT EXISTS team_build_events_%s () INHERITS (build_events)', NEW.id);
+          RETURN NULL;
+  END;
+  $$ LANGUAGE plpgsql;
+
+
+  DROP INDEX IF EXISTS build_events_log_search;
+
+  DO $$
+  DECLARE
+          events_table name;
+  BEGIN
+          FOR events_table IN
+                  SELECT c.relname FROM pg_inherits i JOIN pg_class c ON c.oid = i.inhrelid
+                  WHERE i.inhparent = 'build_events'::regclass
+          LOOP
+                  EXECUTE format('DROP INDEX IF EXISTS %I', events_table || '_log_search');
+          END LOOP;
+  END
+  $$;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE builds DROP COLUMN test_summary;
+
+  DROP TABLE test_results;
+COMMIT;
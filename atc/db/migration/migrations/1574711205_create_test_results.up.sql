@@ -0,0 +1,17 @@
+BEGIN;
+  CREATE TABLE test_results (
+    id bigserial PRIMARY KEY,
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    job_id integer REFERENCES jobs (id) ON DELETE CASCADE,
+    suite text NOT NULL,
+    name text NOT NULL,
+    status text NOT NULL,
+    duration double precision NOT NULL DEFAULT 0,
+    message text
+  );
+
+  CREATE INDEX test_results_build_id_idx ON test_results (build_id);
+  CREATE INDEX test_results_job_id_suite_name_idx ON test_results (job_id, suite, name);
+
+  ALTER TABLE builds ADD COLUMN test_summary json;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  DROP INDEX builds_pending_priority_idx;
+
+  ALTER TABLE builds DROP COLUMN priority;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN priority integer NOT NULL DEFAULT 0;
+
+  CREATE INDEX builds_pending_priority_idx ON builds (pipeline_id, priority DESC, id) WHERE status = 'pending';
+COMMIT;
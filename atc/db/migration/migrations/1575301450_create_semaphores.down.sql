@@ -0,0 +1,5 @@
+BEGIN;
+  DROP TABLE semaphore_holders;
+
+  DROP TABLE jobs_semaphores;
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+  CREATE TABLE jobs_semaphores (
+    job_id integer NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    capacity integer NOT NULL,
+    PRIMARY KEY (job_id, name)
+  );
+
+  CREATE INDEX jobs_semaphores_team_id_name_idx ON jobs_semaphores (team_id, name);
+
+  CREATE TABLE semaphore_holders (
+    team_id integer NOT NULL REFERENCES teams (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    acquire_time timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (team_id, name, build_id)
+  );
+
+  CREATE INDEX semaphore_holders_build_id_idx ON semaphore_holders (build_id);
+COMMIT;
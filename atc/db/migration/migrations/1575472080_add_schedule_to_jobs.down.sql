@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE builds DROP COLUMN trigger_reason;
+
+  ALTER TABLE jobs DROP COLUMN schedule_time;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE jobs ADD COLUMN schedule_time timestamptz;
+
+  ALTER TABLE builds ADD COLUMN trigger_reason text;
+COMMIT;
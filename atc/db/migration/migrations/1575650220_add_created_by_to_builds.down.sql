@@ -0,0 +1,9 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN trigger_reason text;
+
+  UPDATE builds
+  SET trigger_reason = created_by->>'type'
+  WHERE created_by->>'type' = 'schedule';
+
+  ALTER TABLE builds DROP COLUMN created_by;
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN created_by jsonb;
+
+  UPDATE builds
+  SET created_by = json_build_object('type', trigger_reason)
+  WHERE trigger_reason IS NOT NULL;
+
+  UPDATE builds
+  SET created_by = '{"type":"manual"}'
+  WHERE manually_triggered AND created_by IS NULL;
+
+  ALTER TABLE builds DROP COLUMN trigger_reason;
+COMMIT;
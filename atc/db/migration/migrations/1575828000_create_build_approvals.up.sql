@@ -0,0 +1,15 @@
+BEGIN;
+  CREATE TABLE build_approvals (
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    plan_id text NOT NULL,
+    name text NOT NULL,
+    status text NOT NULL DEFAULT 'pending',
+    allowed_roles text[] NOT NULL DEFAULT '{}',
+    request_time timestamp with time zone NOT NULL DEFAULT now(),
+    deadline timestamp with time zone,
+    decided_by text,
+    decide_time timestamp with time zone,
+    comment text,
+    PRIMARY KEY (build_id, plan_id)
+  );
+COMMIT;
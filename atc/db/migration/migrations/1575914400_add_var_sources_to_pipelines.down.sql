@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE pipelines
+    DROP COLUMN var_sources,
+    DROP COLUMN nonce;
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+  CREATE TABLE secret_accesses (
+    id bigserial PRIMARY KEY,
+    time timestamp with time zone NOT NULL DEFAULT now(),
+    team_name text NOT NULL DEFAULT '',
+    pipeline_name text NOT NULL DEFAULT '',
+    job_name text NOT NULL DEFAULT '',
+    build_id integer,
+    variable text NOT NULL,
+    path text NOT NULL,
+    manager text NOT NULL,
+    source text NOT NULL DEFAULT '',
+    cache_hit boolean NOT NULL DEFAULT false,
+    found boolean NOT NULL DEFAULT false,
+    error text NOT NULL DEFAULT ''
+  );
+
+  CREATE INDEX secret_accesses_time_idx ON secret_accesses (time);
+  CREATE INDEX secret_accesses_team_name_idx ON secret_accesses (team_name);
+  CREATE INDEX secret_accesses_build_id_idx ON secret_accesses (build_id);
+COMMIT;
@@ -0,0 +1,74 @@
+package migrations
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+func (self *migrations) Up_1576087200() error {
+	type job struct {
+		id     int
+		config string
+		nonce  sql.NullString
+	}
+
+	tx, err := self.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	_, err = tx.Exec("ALTER TABLE jobs ADD COLUMN public boolean NOT NULL DEFAULT false")
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query("SELECT id, config, nonce FROM jobs")
+	if err != nil {
+		return err
+	}
+
+	jobs := []job{}
+	for rows.Next() {
+
+		job := job{}
+		if err = rows.Scan(&job.id, &job.config, &job.nonce); err != nil {
+			return err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	for _, job := range jobs {
+
+		var noncense *string
+		if job.nonce.Valid {
+			noncense = &job.nonce.String
+		}
+
+		decrypted, err := self.Strategy.Decrypt(job.config, noncense)
+		if err != nil {
+			return err
+		}
+
+		var payload struct {
+			Public bool `json:"public"`
+		}
+		err = json.Unmarshal(decrypted, &payload)
+		if err != nil {
+			return err
+		}
+
+		if !payload.Public {
+			continue
+		}
+
+		_, err = tx.Exec("UPDATE jobs SET public = true WHERE id = $1", job.id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
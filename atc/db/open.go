@@ -3,7 +3,6 @@ package db
 import (
 	"database/sql"
 	"database/sql/driver"
-	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -23,6 +22,7 @@ import (
 type Conn interface {
 	Bus() NotificationsBus
 	EncryptionStrategy() encryption.Strategy
+	EventStore() EventStore
 
 	Ping() error
 	Driver() driver.Driver
@@ -53,11 +53,17 @@ type Tx interface {
 	Stmt(stmt *sql.Stmt) *sql.Stmt
 }
 
-func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *encryption.Key, oldKey *encryption.Key, connectionName string, lockFactory lock.LockFactory) (Conn, error) {
+// Open connects to the database, encrypting with newKey and decrypting with
+// any of newKey, oldKey, or nextKey, so that ATCs configured with either side
+// of a key rotation can run alongside each other. Data encrypted with oldKey
+// is re-encrypted with newKey in the background by the EncryptionKeyRotation.
+//
+// If only oldKey is given, all data is decrypted back to plaintext.
+func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *encryption.Key, oldKey *encryption.Key, nextKey *encryption.Key, connectionName string, lockFactory lock.LockFactory) (Conn, error) {
 	for {
 		var strategy encryption.Strategy
 		if newKey != nil {
-			strategy = newKey
+			strategy = encryption.NewKeyRing(newKey, oldKey, nextKey)
 		} else {
 			strategy = encryption.NewNoEncryption()
 		}
@@ -73,18 +79,15 @@ func Open(logger lager.Logger, sqlDriver string, sqlDataSource string, newKey *e
 			return nil, err
 		}
 
-		switch {
-		case oldKey != nil && newKey == nil:
-			err = decryptToPlaintext(logger.Session("decrypt"), sqlDb, oldKey)
-		case oldKey != nil && newKey != nil:
-			err = encryptWithNewKey(logger.Session("rotate"), sqlDb, newKey, oldKey)
-		}
-		if err != nil {
-			return nil, err
+		if oldKey != nil && newKey == nil {
+			err = decryptToPlaintext(logger.Session("decrypt"), sqlDb, encryption.NewKeyRing(oldKey, nextKey))
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		if newKey != nil {
-			err = encryptPlaintext(logger.Session("encrypt"), sqlDb, newKey)
+			err = encryptPlaintext(logger.Session("encrypt"), sqlDb, strategy)
 			if err != nil {
 				return nil, err
 			}
@@ -128,9 +131,10 @@ var encryptedColumns = []encryptedColumn{
 	{"builds", "private_plan", "id"},
 	{"cert_cache", "cert", "domain"},
 	{"checks", "plan", "id"},
+	{"pipelines", "var_sources", "id"},
 }
 
-func encryptPlaintext(logger lager.Logger, sqlDB *sql.DB, key *encryption.Key) error {
+func encryptPlaintext(logger lager.Logger, sqlDB *sql.DB, strategy encryption.Strategy) error {
 	for _, ec := range encryptedColumns {
 		rows, err := sqlDB.Query(`
 			SELECT ` + ec.PrimaryKey + `, ` + ec.Column + `
@@ -168,7 +172,7 @@ func encryptPlaintext(logger lager.Logger, sqlDB *sql.DB, key *encryption.Key) e
 				"primary-key": primaryKey,
 			})
 
-			encrypted, nonce, err := key.Encrypt([]byte(val.String))
+			encrypted, nonce, err := strategy.Encrypt([]byte(val.String))
 			if err != nil {
 				rLog.Error("failed-to-encrypt", err)
 				return err
@@ -197,7 +201,7 @@ func encryptPlaintext(logger lager.Logger, sqlDB *sql.DB, key *encryption.Key) e
 	return nil
 }
 
-func decryptToPlaintext(logger lager.Logger, sqlDB *sql.DB, oldKey *encryption.Key) error {
+func decryptToPlaintext(logger lager.Logger, sqlDB *sql.DB, strategy encryption.Strategy) error {
 	for _, ec := range encryptedColumns {
 		rows, err := sqlDB.Query(`
 			SELECT ` + ec.PrimaryKey + `, nonce, ` + ec.Column + `
@@ -230,7 +234,7 @@ func decryptToPlaintext(logger lager.Logger, sqlDB *sql.DB, oldKey *encryption.K
 				"primary-key": primaryKey,
 			})
 
-			decrypted, err := oldKey.Decrypt(val, &nonce)
+			decrypted, err := strategy.Decrypt(val, &nonce)
 			if err != nil {
 				rLog.Error("failed-to-decrypt", err)
 				return err
@@ -259,82 +263,6 @@ func decryptToPlaintext(logger lager.Logger, sqlDB *sql.DB, oldKey *encryption.K
 	return nil
 }
 
-var ErrEncryptedWithUnknownKey = errors.New("row encrypted with neither old nor new key")
-
-func encryptWithNewKey(logger lager.Logger, sqlDB *sql.DB, newKey *encryption.Key, oldKey *encryption.Key) error {
-	for _, ec := range encryptedColumns {
-		rows, err := sqlDB.Query(`
-			SELECT ` + ec.PrimaryKey + `, nonce, ` + ec.Column + `
-			FROM ` + ec.Table + `
-			WHERE nonce IS NOT NULL
-		`)
-		if err != nil {
-			return err
-		}
-
-		tLog := logger.Session("table", lager.Data{
-			"table": ec.Table,
-		})
-
-		encryptedRows := 0
-
-		for rows.Next() {
-			var (
-				primaryKey interface{}
-				val, nonce string
-			)
-
-			err := rows.Scan(&primaryKey, &nonce, &val)
-			if err != nil {
-				tLog.Error("failed-to-scan", err)
-				return err
-			}
-
-			rLog := tLog.Session("row", lager.Data{
-				"primary-key": primaryKey,
-			})
-
-			decrypted, err := oldKey.Decrypt(val, &nonce)
-			if err != nil {
-				_, err = newKey.Decrypt(val, &nonce)
-				if err == nil {
-					rLog.Debug("already-encrypted-with-new-key")
-					continue
-				}
-
-				logger.Error("failed-to-decrypt-with-either-key", err)
-				return ErrEncryptedWithUnknownKey
-			}
-
-			encrypted, newNonce, err := newKey.Encrypt(decrypted)
-			if err != nil {
-				rLog.Error("failed-to-encrypt", err)
-				return err
-			}
-
-			_, err = sqlDB.Exec(`
-				UPDATE `+ec.Table+`
-				SET `+ec.Column+` = $1, nonce = $2
-				WHERE `+ec.PrimaryKey+` = $3
-			`, encrypted, newNonce, primaryKey)
-			if err != nil {
-				rLog.Error("failed-to-update", err)
-				return err
-			}
-
-			encryptedRows++
-		}
-
-		if encryptedRows > 0 {
-			tLog.Info("re-encrypted-existing-encrypted-data", lager.Data{
-				"rows": encryptedRows,
-			})
-		}
-	}
-
-	return nil
-}
-
 type db struct {
 	*sql.DB
 
@@ -355,6 +283,10 @@ func (db *db) EncryptionStrategy() encryption.Strategy {
 	return db.encryption
 }
 
+func (db *db) EventStore() EventStore {
+	return NewPostgresEventStore(db)
+}
+
 func (db *db) Close() error {
 	var errs error
 	dbErr := db.DB.Close()
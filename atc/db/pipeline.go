@@ -3,6 +3,7 @@ package db
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -16,6 +17,10 @@ import (
 	"github.com/concourse/concourse/atc/event"
 )
 
+// ErrPipelineNameTaken is returned when renaming a pipeline to the name of
+// another of the team's pipelines.
+var ErrPipelineNameTaken = errors.New("pipeline name is already taken")
+
 type ErrResourceNotFound struct {
 	Name string
 }
@@ -34,12 +39,16 @@ type Cause struct {
 type Pipeline interface {
 	ID() int
 	Name() string
+	InstanceVars() atc.InstanceVars
 	TeamID() int
 	TeamName() string
 	Groups() atc.GroupConfigs
+	VarSources() atc.VarSourceConfigs
 	ConfigVersion() ConfigVersion
 	Public() bool
 	Paused() bool
+	ParentJobID() int
+	ParentBuildID() int
 
 	CheckPaused() (bool, error)
 	Reload() (bool, error)
@@ -52,7 +61,7 @@ type Pipeline interface {
 	Builds(page Page) ([]Build, Pagination, error)
 
 	CreateOneOffBuild() (Build, error)
-	CreateStartedBuild(plan atc.Plan) (Build, error)
+	CreateStartedBuild(plan atc.Plan, createdBy atc.BuildCreator) (Build, error)
 
 	GetAllPendingBuilds() (map[string][]Build, error)
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
@@ -81,6 +90,8 @@ type Pipeline interface {
 	Pause() error
 	Unpause() error
 
+	SetParentIDs(jobID, buildID int) error
+
 	Destroy() error
 	Rename(string) error
 }
@@ -88,12 +99,16 @@ type Pipeline interface {
 type pipeline struct {
 	id            int
 	name          string
+	instanceVars  atc.InstanceVars
 	teamID        int
 	teamName      string
 	groups        atc.GroupConfigs
+	varSources    atc.VarSourceConfigs
 	configVersion ConfigVersion
 	paused        bool
 	public        bool
+	parentJobID   int
+	parentBuildID int
 
 	cacheIndex int
 	versionsDB *algorithm.VersionsDB
@@ -108,12 +123,17 @@ type ConfigVersion int
 var pipelinesQuery = psql.Select(`
 		p.id,
 		p.name,
+		p.instance_vars,
 		p.groups,
 		p.version,
 		p.team_id,
 		t.name,
 		p.paused,
-		p.public
+		p.public,
+		p.parent_job_id,
+		p.parent_build_id,
+		p.var_sources,
+		p.nonce
 	`).
 	From("pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id")
@@ -125,14 +145,18 @@ func newPipeline(conn Conn, lockFactory lock.LockFactory) *pipeline {
 	}
 }
 
-func (p *pipeline) ID() int                      { return p.id }
-func (p *pipeline) Name() string                 { return p.name }
-func (p *pipeline) TeamID() int                  { return p.teamID }
-func (p *pipeline) TeamName() string             { return p.teamName }
-func (p *pipeline) Groups() atc.GroupConfigs     { return p.groups }
-func (p *pipeline) ConfigVersion() ConfigVersion { return p.configVersion }
-func (p *pipeline) Public() bool                 { return p.public }
-func (p *pipeline) Paused() bool                 { return p.paused }
+func (p *pipeline) ID() int                          { return p.id }
+func (p *pipeline) Name() string                     { return p.name }
+func (p *pipeline) InstanceVars() atc.InstanceVars   { return p.instanceVars }
+func (p *pipeline) TeamID() int                      { return p.teamID }
+func (p *pipeline) TeamName() string                 { return p.teamName }
+func (p *pipeline) Groups() atc.GroupConfigs         { return p.groups }
+func (p *pipeline) VarSources() atc.VarSourceConfigs { return p.varSources }
+func (p *pipeline) ConfigVersion() ConfigVersion     { return p.configVersion }
+func (p *pipeline) Public() bool                     { return p.public }
+func (p *pipeline) Paused() bool                     { return p.paused }
+func (p *pipeline) ParentJobID() int                 { return p.parentJobID }
+func (p *pipeline) ParentBuildID() int               { return p.parentBuildID }
 
 // IMPORTANT: This method is broken with the new resource config versions changes
 func (p *pipeline) Causality(versionedResourceID int) ([]Cause, error) {
@@ -272,7 +296,7 @@ func (p *pipeline) GetAllPendingBuilds() (map[string][]Build, error) {
 			"j.active":      true,
 			"b.pipeline_id": p.id,
 		}).
-		OrderBy("b.id").
+		OrderBy("b.priority DESC", "b.id").
 		RunWith(p.conn).
 		Query()
 	if err != nil {
@@ -605,6 +629,21 @@ func (p *pipeline) Pause() error {
 	return err
 }
 
+// SetParentIDs records the job and build which last set the pipeline via a
+// set_pipeline step.
+func (p *pipeline) SetParentIDs(jobID, buildID int) error {
+	_, err := psql.Update("pipelines").
+		Set("parent_job_id", jobID).
+		Set("parent_build_id", buildID).
+		Where(sq.Eq{
+			"id": p.id,
+		}).
+		RunWith(p.conn).
+		Exec()
+
+	return err
+}
+
 func (p *pipeline) Unpause() error {
 	_, err := psql.Update("pipelines").
 		Set("paused", false).
@@ -641,16 +680,60 @@ func (p *pipeline) Expose() error {
 	return err
 }
 
+// Rename renames the pipeline along with all of its instances, which are
+// identified by the name. Instances are never merged into another pipeline's
+// instances, so renaming to the name of another of the team's pipelines fails
+// with ErrPipelineNameTaken.
 func (p *pipeline) Rename(name string) error {
-	_, err := psql.Update("pipelines").
+	if name == p.name {
+		return nil
+	}
+
+	tx, err := p.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	var existing int
+	err = psql.Select("COUNT(1)").
+		From("pipelines").
+		Where(sq.Eq{
+			"name":    name,
+			"team_id": p.teamID,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&existing)
+	if err != nil {
+		return err
+	}
+
+	if existing != 0 {
+		return ErrPipelineNameTaken
+	}
+
+	_, err = psql.Update("pipelines").
 		Set("name", name).
 		Where(sq.Eq{
-			"id": p.id,
+			"name":    p.name,
+			"team_id": p.teamID,
 		}).
-		RunWith(p.conn).
+		RunWith(tx).
 		Exec()
+	if err != nil {
+		return err
+	}
 
-	return err
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	p.name = name
+
+	return nil
 }
 
 func (p *pipeline) Destroy() error {
@@ -846,6 +929,8 @@ func (p *pipeline) LoadVersionsDB() (*algorithm.VersionsDB, error) {
 	return db, nil
 }
 
+// DeleteBuildEventsByBuildIDs deletes the builds' events from the configured
+// EventStore and marks the builds as reaped.
 func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 	if len(buildIDs) == 0 {
 		return nil
@@ -861,31 +946,16 @@ func (p *pipeline) DeleteBuildEventsByBuildIDs(buildIDs []int) error {
 		indexStrings[i] = "$" + strconv.Itoa(i+1)
 	}
 
-	tx, err := p.conn.Begin()
-	if err != nil {
-		return err
-	}
-
-	defer Rollback(tx)
-
-	_, err = tx.Exec(`
-   DELETE FROM build_events
-	 WHERE build_id IN (`+strings.Join(indexStrings, ",")+`)
-	 `, interfaceBuildIDs...)
+	err := p.conn.EventStore().Delete(buildIDs)
 	if err != nil {
 		return err
 	}
 
-	_, err = tx.Exec(`
+	_, err = p.conn.Exec(`
 		UPDATE builds
 		SET reap_time = now()
 		WHERE id IN (`+strings.Join(indexStrings, ",")+`)
 	`, interfaceBuildIDs...)
-	if err != nil {
-		return err
-	}
-
-	err = tx.Commit()
 	return err
 }
 
@@ -965,7 +1035,7 @@ func (p *pipeline) CreateOneOffBuild() (Build, error) {
 	return build, nil
 }
 
-func (p *pipeline) CreateStartedBuild(plan atc.Plan) (Build, error) {
+func (p *pipeline) CreateStartedBuild(plan atc.Plan, createdBy atc.BuildCreator) (Build, error) {
 	tx, err := p.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -978,6 +1048,11 @@ func (p *pipeline) CreateStartedBuild(plan atc.Plan) (Build, error) {
 		return nil, err
 	}
 
+	createdByPayload, err := json.Marshal(createdBy)
+	if err != nil {
+		return nil, err
+	}
+
 	encryptedPlan, nonce, err := p.conn.EncryptionStrategy().Encrypt(metadata)
 	if err != nil {
 		return nil, err
@@ -994,14 +1069,16 @@ func (p *pipeline) CreateStartedBuild(plan atc.Plan) (Build, error) {
 		"private_plan": encryptedPlan,
 		"public_plan":  plan.Public(),
 		"nonce":        nonce,
+		"created_by":   string(createdByPayload),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	err = build.saveEvent(tx, event.Status{
-		Status: atc.StatusStarted,
-		Time:   build.StartTime().Unix(),
+	saved, err := build.saveEvent(tx, event.Status{
+		Status:    atc.StatusStarted,
+		Time:      build.StartTime().Unix(),
+		CreatedBy: build.CreatedBy(),
 	})
 	if err != nil {
 		return nil, err
@@ -1016,7 +1093,7 @@ func (p *pipeline) CreateStartedBuild(plan atc.Plan) (Build, error) {
 		return nil, err
 	}
 
-	if err = p.conn.Bus().Notify(buildEventsChannel(build.id)); err != nil {
+	if err = build.flushEvent(saved); err != nil {
 		return nil, err
 	}
 
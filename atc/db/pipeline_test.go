@@ -117,7 +117,7 @@ var _ = Describe("Pipeline", func() {
 			},
 		}
 		var created bool
-		pipeline, created, err = team.SavePipeline("fake-pipeline", pipelineConfig, db.ConfigVersion(0), false)
+		pipeline, created, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, db.ConfigVersion(0), false)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(created).To(BeTrue())
 
@@ -207,17 +207,88 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
-	Describe("Rename", func() {
-		JustBeforeEach(func() {
-			Expect(pipeline.Rename("oopsies")).To(Succeed())
+	Describe("SetParentIDs", func() {
+		var build db.Build
+
+		BeforeEach(func() {
+			var err error
+			build, err = job.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("records the job and build which set the pipeline", func() {
+			Expect(pipeline.ParentJobID()).To(BeZero())
+			Expect(pipeline.ParentBuildID()).To(BeZero())
+
+			Expect(pipeline.SetParentIDs(job.ID(), build.ID())).To(Succeed())
+
+			found, err := pipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(pipeline.ParentJobID()).To(Equal(job.ID()))
+			Expect(pipeline.ParentBuildID()).To(Equal(build.ID()))
 		})
+	})
 
+	Describe("Rename", func() {
 		It("renames the pipeline", func() {
-			pipeline, found, err := team.Pipeline("oopsies")
+			Expect(pipeline.Rename("oopsies")).To(Succeed())
+
+			pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: "oopsies"})
 			Expect(pipeline.Name()).To(Equal("oopsies"))
 			Expect(found).To(BeTrue())
 			Expect(err).ToNot(HaveOccurred())
 		})
+
+		Context("when the pipeline has instances", func() {
+			var instance db.Pipeline
+
+			BeforeEach(func() {
+				var err error
+				instance, _, err = team.SavePipeline(atc.PipelineRef{
+					Name:         pipeline.Name(),
+					InstanceVars: atc.InstanceVars{"branch": "feature"},
+				}, atc.Config{}, db.ConfigVersion(0), false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("renames all of the instances", func() {
+				Expect(instance.Rename("oopsies")).To(Succeed())
+
+				renamed, found, err := team.Pipeline(atc.PipelineRef{Name: "oopsies"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(renamed.ID()).To(Equal(pipeline.ID()))
+
+				renamedInstance, found, err := team.Pipeline(atc.PipelineRef{
+					Name:         "oopsies",
+					InstanceVars: atc.InstanceVars{"branch": "feature"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(renamedInstance.ID()).To(Equal(instance.ID()))
+			})
+		})
+
+		Context("when another pipeline has the name", func() {
+			BeforeEach(func() {
+				_, _, err := team.SavePipeline(atc.PipelineRef{
+					Name:         "oopsies",
+					InstanceVars: atc.InstanceVars{"branch": "feature"},
+				}, atc.Config{}, db.ConfigVersion(0), false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not merge the pipelines", func() {
+				Expect(pipeline.Rename("oopsies")).To(Equal(db.ErrPipelineNameTaken))
+
+				found, err := pipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(pipeline.Name()).ToNot(Equal("oopsies"))
+			})
+		})
 	})
 
 	Describe("Resource Config Versions", func() {
@@ -386,10 +457,10 @@ var _ = Describe("Pipeline", func() {
 			}
 
 			var err error
-			dbPipeline, _, err = team.SavePipeline("pipeline-name", pipelineConfig, 0, false)
+			dbPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline-name"}, pipelineConfig, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
-			otherDBPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, false)
+			otherDBPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "other-pipeline-name"}, otherPipelineConfig, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, _, err = dbPipeline.Resource(resourceName)
@@ -549,7 +620,7 @@ var _ = Describe("Pipeline", func() {
 			}))
 
 			By("including outputs of successful builds")
-			build1DB, err := aJob.CreateBuild()
+			build1DB, err := aJob.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			err = build1DB.SaveOutput("some-type", atc.Source{"source-config": "some-value"}, atc.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-resource")
@@ -596,7 +667,7 @@ var _ = Describe("Pipeline", func() {
 			}))
 
 			By("not including outputs of failed builds")
-			build2DB, err := aJob.CreateBuild()
+			build2DB, err := aJob.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			err = build2DB.SaveOutput("some-type", atc.Source{"source-config": "some-value"}, atc.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-resource")
@@ -645,7 +716,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			otherPipelineBuild, err := anotherJob.CreateBuild()
+			otherPipelineBuild, err := anotherJob.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			err = otherPipelineBuild.SaveOutput("some-type", atc.Source{"other-source-config": "some-other-value"}, atc.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-other-resource")
@@ -694,7 +765,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build1DB, err = aJob.CreateBuild()
+			build1DB, err = aJob.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			err = build1DB.UseInputs([]db.BuildInput{
@@ -838,7 +909,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = resourceConfigScope.SaveVersions([]atc.Version{
@@ -869,7 +940,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				beforeVR, found, err := resourceConfigScope.LatestVersion()
@@ -957,7 +1028,7 @@ var _ = Describe("Pipeline", func() {
 				},
 			}
 			var err error
-			pipelineDB, _, err = team.SavePipeline("some-pipeline", pipelineConfig, db.ConfigVersion(1), false)
+			pipelineDB, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, pipelineConfig, db.ConfigVersion(1), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			var found bool
@@ -975,7 +1046,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build1, err := aJob.CreateBuild()
+				build1, err := aJob.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = resourceConfigScope.SaveVersions([]atc.Version{{"version": "disabled"}})
@@ -1120,7 +1191,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			By("populating build inputs")
@@ -1152,7 +1223,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeFalse())
 
-			_, found, err = team.Pipeline(pipeline.Name())
+			_, found, err = team.Pipeline(atc.PipelineRef{Name: pipeline.Name()})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeFalse())
 		})
@@ -1161,7 +1232,7 @@ var _ = Describe("Pipeline", func() {
 	Describe("GetPendingBuilds/GetAllPendingBuilds", func() {
 		Context("when a build is created", func() {
 			BeforeEach(func() {
-				_, err := job.CreateBuild()
+				_, err := job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -1198,7 +1269,7 @@ var _ = Describe("Pipeline", func() {
 				},
 			}
 			var err error
-			otherPipeline, _, err = team.SavePipeline("other-pipeline-name", otherPipelineConfig, 0, false)
+			otherPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "other-pipeline-name"}, otherPipelineConfig, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -1214,7 +1285,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err = job.CreateBuild()
+				build, err = job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				savedResource, _, err = pipeline.Resource("some-resource")
@@ -1287,7 +1358,7 @@ var _ = Describe("Pipeline", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					otherBuild, err := job.CreateBuild()
+					otherBuild, err := job.CreateBuild(defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 
 					otherSavedResource, _, err := otherPipeline.Resource("some-other-resource")
@@ -1372,7 +1443,7 @@ var _ = Describe("Pipeline", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(created).To(BeTrue())
 
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = build.UseInputs([]db.BuildInput{{Name: "some-resource", Version: atc.Version{"version": "1"}, ResourceID: resource.ID()}})
@@ -1460,7 +1531,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			firstJobBuild, err := job.CreateBuild()
+			firstJobBuild, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			actualDashboard, err = pipeline.Dashboard()
@@ -1492,7 +1563,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			secondJobBuild, err := job.CreateBuild()
+			secondJobBuild, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			actualDashboard, err = pipeline.Dashboard()
@@ -1671,12 +1742,12 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(defaultBuildCreatedBy)
 
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build)
 
-			secondBuild, err := job.CreateBuild()
+			secondBuild, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild)
 
@@ -1684,7 +1755,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			_, err = someOtherJob.CreateBuild()
+			_, err = someOtherJob.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			dbBuild, found, err := buildFactory.Build(build.ID())
@@ -1806,11 +1877,11 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build)
 
-			secondBuild, err = job.CreateBuild()
+			secondBuild, err = job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild)
 
@@ -1818,7 +1889,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			_, err = someOtherJob.CreateBuild()
+			_, err = someOtherJob.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			dbBuild, found, err := buildFactory.Build(build.ID())
@@ -1918,11 +1989,11 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build)
 
-			secondBuild, err := job.CreateBuild()
+			secondBuild, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild)
 
@@ -1930,7 +2001,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			thirdBuild, err := someOtherJob.CreateBuild()
+			thirdBuild, err := someOtherJob.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, thirdBuild)
 		})
@@ -1975,7 +2046,10 @@ var _ = Describe("Pipeline", func() {
 				},
 			}
 
-			startedBuild, err = pipeline.CreateStartedBuild(plan)
+			startedBuild, err = pipeline.CreateStartedBuild(plan, atc.BuildCreator{
+				Type: atc.BuildCreatorAPI,
+				User: "some-user",
+			})
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -1986,6 +2060,10 @@ var _ = Describe("Pipeline", func() {
 			Expect(startedBuild.Name()).To(Equal(strconv.Itoa(startedBuild.ID())))
 			Expect(startedBuild.TeamName()).To(Equal(team.Name()))
 			Expect(startedBuild.Status()).To(Equal(db.BuildStatusStarted))
+			Expect(startedBuild.CreatedBy()).To(Equal(&atc.BuildCreator{
+				Type: atc.BuildCreatorAPI,
+				User: "some-user",
+			}))
 		})
 
 		It("saves the public plan", func() {
@@ -2008,6 +2086,10 @@ var _ = Describe("Pipeline", func() {
 			Expect(events.Next()).To(Equal(envelope(event.Status{
 				Status: atc.StatusStarted,
 				Time:   startedBuild.StartTime().Unix(),
+				CreatedBy: &atc.BuildCreator{
+					Type: atc.BuildCreatorAPI,
+					User: "some-user",
+				},
 			})))
 		})
 	})
@@ -2159,7 +2241,7 @@ var _ = Describe("Pipeline", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), false)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err = pipeline.Job("some-job")
@@ -2167,7 +2249,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(found).To(BeTrue())
 
 			for i := range builds {
-				builds[i], err = job.CreateBuild()
+				builds[i], err = job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				buildStart := time.Date(2020, 11, i+1, 0, 0, 0, 0, time.UTC)
@@ -2179,14 +2261,14 @@ var _ = Describe("Pipeline", func() {
 				Expect(found).To(BeTrue())
 			}
 
-			otherPipeline, _, err := team.SavePipeline("another-pipeline", config, db.ConfigVersion(1), false)
+			otherPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "another-pipeline"}, config, db.ConfigVersion(1), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			otherJob, found, err := otherPipeline.Job("some-job")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			_, err = otherJob.CreateBuild()
+			_, err = otherJob.CreateBuild(defaultBuildCreatedBy)
 		})
 
 		Context("when not providing boundaries", func() {
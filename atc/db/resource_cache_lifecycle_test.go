@@ -29,7 +29,7 @@ var _ = Describe("ResourceCacheLifecycle", func() {
 			}
 
 			resourceCacheForJobBuild := func() (db.UsedResourceCache, db.Build) {
-				build, err := defaultJob.CreateBuild()
+				build, err := defaultJob.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 				return createResourceCacheWithUser(db.ForBuild(build.ID())), build
 			}
@@ -0,0 +1,202 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+//go:generate counterfeiter . SecretAccessFactory
+
+// SecretAccessFactory keeps the audit trail of lookups of secrets in
+// credential managers.
+type SecretAccessFactory interface {
+	CreateSecretAccess(atc.SecretAccess) error
+	SecretAccesses(SecretAccessFilter) ([]atc.SecretAccess, error)
+
+	// RemoveExpiredSecretAccesses removes the secret accesses older than the
+	// retention period.
+	RemoveExpiredSecretAccesses(retention time.Duration) error
+}
+
+// SecretAccessFilter narrows down the secret accesses returned by
+// SecretAccesses. Zero fields match any secret access.
+type SecretAccessFilter struct {
+	TeamName     string
+	PipelineName string
+	JobName      string
+	BuildID      int
+	Variable     string
+	Manager      string
+
+	Since time.Time
+	Until time.Time
+
+	Limit int
+}
+
+type secretAccessFactory struct {
+	conn Conn
+}
+
+func NewSecretAccessFactory(conn Conn) SecretAccessFactory {
+	return &secretAccessFactory{
+		conn: conn,
+	}
+}
+
+func (f *secretAccessFactory) CreateSecretAccess(access atc.SecretAccess) error {
+	accessTime := time.Now()
+	if access.Time != 0 {
+		accessTime = time.Unix(access.Time, 0)
+	}
+
+	var buildID sql.NullInt64
+	if access.BuildID != 0 {
+		buildID = sql.NullInt64{Int64: int64(access.BuildID), Valid: true}
+	}
+
+	_, err := psql.Insert("secret_accesses").
+		Columns(
+			"time",
+			"team_name",
+			"pipeline_name",
+			"job_name",
+			"build_id",
+			"variable",
+			"path",
+			"manager",
+			"source",
+			"cache_hit",
+			"found",
+			"error",
+		).
+		Values(
+			accessTime,
+			access.TeamName,
+			access.PipelineName,
+			access.JobName,
+			buildID,
+			access.Variable,
+			access.Path,
+			access.Manager,
+			access.Source,
+			access.CacheHit,
+			access.Found,
+			access.Error,
+		).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+// SecretAccesses returns the secret accesses matching the filter, most
+// recent first.
+func (f *secretAccessFactory) SecretAccesses(filter SecretAccessFilter) ([]atc.SecretAccess, error) {
+	query := psql.Select(
+		"id",
+		"time",
+		"team_name",
+		"pipeline_name",
+		"job_name",
+		"build_id",
+		"variable",
+		"path",
+		"manager",
+		"source",
+		"cache_hit",
+		"found",
+		"error",
+	).
+		From("secret_accesses").
+		OrderBy("id DESC")
+
+	eq := sq.Eq{}
+	if filter.TeamName != "" {
+		eq["team_name"] = filter.TeamName
+	}
+	if filter.PipelineName != "" {
+		eq["pipeline_name"] = filter.PipelineName
+	}
+	if filter.JobName != "" {
+		eq["job_name"] = filter.JobName
+	}
+	if filter.BuildID != 0 {
+		eq["build_id"] = filter.BuildID
+	}
+	if filter.Variable != "" {
+		eq["variable"] = filter.Variable
+	}
+	if filter.Manager != "" {
+		eq["manager"] = filter.Manager
+	}
+
+	query = query.Where(eq)
+
+	if !filter.Since.IsZero() {
+		query = query.Where(sq.GtOrEq{"time": filter.Since})
+	}
+
+	if !filter.Until.IsZero() {
+		query = query.Where(sq.Lt{"time": filter.Until})
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(uint64(filter.Limit))
+	}
+
+	rows, err := query.RunWith(f.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	accesses := []atc.SecretAccess{}
+	for rows.Next() {
+		var (
+			access     atc.SecretAccess
+			accessTime time.Time
+			buildID    sql.NullInt64
+		)
+
+		err = rows.Scan(
+			&access.ID,
+			&accessTime,
+			&access.TeamName,
+			&access.PipelineName,
+			&access.JobName,
+			&buildID,
+			&access.Variable,
+			&access.Path,
+			&access.Manager,
+			&access.Source,
+			&access.CacheHit,
+			&access.Found,
+			&access.Error,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		access.Time = accessTime.Unix()
+		access.BuildID = int(buildID.Int64)
+
+		accesses = append(accesses, access)
+	}
+
+	return accesses, nil
+}
+
+func (f *secretAccessFactory) RemoveExpiredSecretAccesses(retention time.Duration) error {
+	_, err := psql.Delete("secret_accesses").
+		Where(sq.Gt{
+			"now() - time": fmt.Sprintf("%.0f seconds", retention.Seconds()),
+		}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
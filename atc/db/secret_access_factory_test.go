@@ -0,0 +1,130 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretAccessFactory", func() {
+	var (
+		now      time.Time
+		accesses []atc.SecretAccess
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+
+		for _, access := range []atc.SecretAccess{
+			{
+				Time:         now.Add(-time.Hour).Unix(),
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				JobName:      "some-job",
+				BuildID:      1,
+				Variable:     "foo",
+				Path:         "/concourse/some-team/foo",
+				Manager:      "vault",
+				Found:        true,
+			},
+			{
+				Time:     now.Unix(),
+				TeamName: "some-team",
+				Variable: "bar",
+				Path:     "/concourse/some-team/bar",
+				Manager:  "vault",
+				CacheHit: true,
+				Error:    "some-error",
+			},
+			{
+				Time:     now.Unix(),
+				TeamName: "other-team",
+				Variable: "foo",
+				Path:     "foo",
+				Manager:  "some-type",
+				Source:   "some-source",
+				Found:    true,
+			},
+		} {
+			err := secretAccessFactory.CreateSecretAccess(access)
+			Expect(err).ToNot(HaveOccurred())
+		}
+	})
+
+	It("returns all secret accesses, most recent first", func() {
+		var err error
+		accesses, err = secretAccessFactory.SecretAccesses(db.SecretAccessFilter{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(accesses).To(HaveLen(3))
+
+		Expect(accesses[0].ID).ToNot(BeZero())
+		accesses[0].ID = 0
+		Expect(accesses[0]).To(Equal(atc.SecretAccess{
+			Time:     now.Unix(),
+			TeamName: "other-team",
+			Variable: "foo",
+			Path:     "foo",
+			Manager:  "some-type",
+			Source:   "some-source",
+			Found:    true,
+		}))
+
+		Expect(accesses[1].CacheHit).To(BeTrue())
+		Expect(accesses[1].Error).To(Equal("some-error"))
+		Expect(accesses[1].BuildID).To(BeZero())
+
+		Expect(accesses[2].BuildID).To(Equal(1))
+		Expect(accesses[2].JobName).To(Equal("some-job"))
+	})
+
+	It("filters secret accesses", func() {
+		var err error
+		accesses, err = secretAccessFactory.SecretAccesses(db.SecretAccessFilter{
+			TeamName: "some-team",
+			Variable: "foo",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(accesses).To(HaveLen(1))
+		Expect(accesses[0].BuildID).To(Equal(1))
+
+		accesses, err = secretAccessFactory.SecretAccesses(db.SecretAccessFilter{
+			Manager: "vault",
+			Since:   now.Add(-time.Minute),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(accesses).To(HaveLen(1))
+		Expect(accesses[0].Variable).To(Equal("bar"))
+
+		accesses, err = secretAccessFactory.SecretAccesses(db.SecretAccessFilter{
+			Until: now.Add(-time.Minute),
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(accesses).To(HaveLen(1))
+		Expect(accesses[0].JobName).To(Equal("some-job"))
+	})
+
+	It("limits the number of secret accesses", func() {
+		var err error
+		accesses, err = secretAccessFactory.SecretAccesses(db.SecretAccessFilter{Limit: 2})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(accesses).To(HaveLen(2))
+		Expect(accesses[1].Variable).To(Equal("bar"))
+	})
+
+	It("removes secret accesses older than the retention period", func() {
+		err := secretAccessFactory.RemoveExpiredSecretAccesses(30 * time.Minute)
+		Expect(err).ToNot(HaveOccurred())
+
+		accesses, err = secretAccessFactory.SecretAccesses(db.SecretAccessFilter{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(accesses).To(HaveLen(2))
+
+		for _, access := range accesses {
+			Expect(access.JobName).ToNot(Equal("some-job"))
+		}
+	})
+})
@@ -0,0 +1,197 @@
+package db
+
+import (
+	"database/sql"
+	"sort"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// Semaphore is a team-wide lock declared by the team's jobs, along with the
+// builds holding it and the pending builds waiting for it.
+type Semaphore struct {
+	Name string
+
+	// Capacity is the smallest capacity any of the jobs declaring the
+	// semaphore gives it, or 0 if no job declares it anymore.
+	Capacity int
+
+	Holders []Build
+	Waiting []Build
+}
+
+// acquireSemaphores makes the build a holder of each of the given semaphores
+// of its team, unless any of them is already held by as many builds as its
+// capacity. Either all semaphores are acquired or none are, as long as the
+// caller rolls back the transaction when they are not.
+//
+// Acquiring semaphores the build already holds succeeds. The semaphores are
+// released when the build finishes.
+func acquireSemaphores(tx Tx, teamID int, buildID int, semaphores []atc.SemaphoreConfig) (bool, error) {
+	if len(semaphores) == 0 {
+		return true, nil
+	}
+
+	// serialize acquisitions within the team, across pipelines and ATCs
+	_, err := tx.Exec(`SELECT id FROM teams WHERE id = $1 FOR UPDATE`, teamID)
+	if err != nil {
+		return false, err
+	}
+
+	sorted := make([]atc.SemaphoreConfig, len(semaphores))
+	copy(sorted, semaphores)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	for _, semaphore := range sorted {
+		var held int
+		err = psql.Select("COUNT(*)").
+			From("semaphore_holders").
+			Where(sq.Eq{
+				"team_id": teamID,
+				"name":    semaphore.Name,
+			}).
+			Where(sq.NotEq{"build_id": buildID}).
+			RunWith(tx).
+			QueryRow().
+			Scan(&held)
+		if err != nil {
+			return false, err
+		}
+
+		if held >= semaphore.Capacity() {
+			return false, nil
+		}
+
+		_, err = tx.Exec(`
+			INSERT INTO semaphore_holders (team_id, name, build_id)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+		`, teamID, semaphore.Name, buildID)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func releaseSemaphores(tx Tx, buildID int) error {
+	_, err := psql.Delete("semaphore_holders").
+		Where(sq.Eq{"build_id": buildID}).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+// Semaphores returns the semaphores declared by the team's jobs or held by
+// its builds, ordered by name.
+func (t *team) Semaphores() ([]Semaphore, error) {
+	rows, err := t.conn.Query(`
+		SELECT name, MIN(capacity)
+		FROM (
+			SELECT name, capacity FROM jobs_semaphores WHERE team_id = $1
+			UNION ALL
+			SELECT name, NULL FROM semaphore_holders WHERE team_id = $1
+		) s
+		GROUP BY name
+		ORDER BY name
+	`, t.id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	semaphores := []Semaphore{}
+	for rows.Next() {
+		var (
+			name     string
+			capacity sql.NullInt64
+		)
+
+		err = rows.Scan(&name, &capacity)
+		if err != nil {
+			return nil, err
+		}
+
+		semaphores = append(semaphores, Semaphore{
+			Name:     name,
+			Capacity: int(capacity.Int64),
+		})
+	}
+
+	for i, semaphore := range semaphores {
+		holders, holdersArgs, err := sq.Select("build_id").
+			From("semaphore_holders").
+			Where(sq.Eq{
+				"team_id": t.id,
+				"name":    semaphore.Name,
+			}).
+			ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		declaringJobs, declaringJobsArgs, err := sq.Select("job_id").
+			From("jobs_semaphores").
+			Where(sq.Eq{
+				"team_id": t.id,
+				"name":    semaphore.Name,
+			}).
+			ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		semaphores[i].Holders, err = t.semaphoreBuilds(
+			sq.Expr("b.id IN ("+holders+")", holdersArgs...),
+			"b.id ASC",
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		semaphores[i].Waiting, err = t.semaphoreBuilds(
+			sq.And{
+				sq.Eq{"b.status": BuildStatusPending},
+				sq.Expr("b.job_id IN ("+declaringJobs+")", declaringJobsArgs...),
+				sq.Expr("b.id NOT IN ("+holders+")", holdersArgs...),
+			},
+			"b.priority DESC", "b.id ASC",
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return semaphores, nil
+}
+
+func (t *team) semaphoreBuilds(where sq.Sqlizer, orderBy ...string) ([]Build, error) {
+	rows, err := buildsQuery.
+		Where(where).
+		OrderBy(orderBy...).
+		RunWith(t.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	builds := []Build{}
+	for rows.Next() {
+		build := &build{conn: t.conn, lockFactory: t.lockFactory}
+		err = scanBuild(build, rows, t.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		builds = append(builds, build)
+	}
+
+	return builds, nil
+}
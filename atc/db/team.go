@@ -30,23 +30,25 @@ type Team interface {
 	Rename(string) error
 
 	SavePipeline(
-		pipelineName string,
+		pipelineRef atc.PipelineRef,
 		config atc.Config,
 		from ConfigVersion,
 		initiallyPaused bool,
 	) (Pipeline, bool, error)
 
-	Pipeline(pipelineName string) (Pipeline, bool, error)
+	Pipeline(pipelineRef atc.PipelineRef) (Pipeline, bool, error)
 	Pipelines() ([]Pipeline, error)
 	PublicPipelines() ([]Pipeline, error)
 	OrderPipelines([]string) error
 
 	CreateOneOffBuild() (Build, error)
-	CreateStartedBuild(plan atc.Plan) (Build, error)
+	CreateStartedBuild(plan atc.Plan, createdBy atc.BuildCreator) (Build, error)
 
 	PrivateAndPublicBuilds(Page) ([]Build, Pagination, error)
 	Builds(page Page) ([]Build, Pagination, error)
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
+	SearchBuildLogs(BuildLogSearch) ([]BuildLogMatch, error)
+	Semaphores() ([]Semaphore, error)
 
 	SaveWorker(atcWorker atc.Worker, ttl time.Duration) (Worker, error)
 	Workers() ([]Worker, error)
@@ -57,7 +59,7 @@ type Team interface {
 	IsContainerWithinTeam(string, bool) (bool, error)
 
 	FindContainerByHandle(string) (Container, bool, error)
-	FindCheckContainers(string, string, creds.Secrets) ([]Container, map[int]time.Time, error)
+	FindCheckContainers(atc.PipelineRef, string, creds.Secrets, creds.VarSourcePool) ([]Container, map[int]time.Time, error)
 	FindContainersByMetadata(ContainerMetadata) ([]Container, error)
 	FindCreatedContainerByHandle(string) (CreatedContainer, bool, error)
 	FindWorkerForContainer(handle string) (Worker, bool, error)
@@ -337,7 +339,7 @@ func (t *team) FindCreatedContainerByHandle(
 }
 
 func (t *team) SavePipeline(
-	pipelineName string,
+	pipelineRef atc.PipelineRef,
 	config atc.Config,
 	from ConfigVersion,
 	initiallyPaused bool,
@@ -347,6 +349,18 @@ func (t *team) SavePipeline(
 		return nil, false, err
 	}
 
+	// var sources may configure credentials, so they're encrypted like the
+	// configs of jobs and resources
+	varSourcesPayload, err := json.Marshal(config.VarSources)
+	if err != nil {
+		return nil, false, err
+	}
+
+	encryptedVarSources, nonce, err := t.conn.EncryptionStrategy().Encrypt(varSourcesPayload)
+	if err != nil {
+		return nil, false, err
+	}
+
 	jobGroups := make(map[string][]string)
 	for _, group := range config.Groups {
 		for _, job := range group.Jobs {
@@ -364,26 +378,43 @@ func (t *team) SavePipeline(
 
 	defer Rollback(tx)
 
-	err = tx.QueryRow(`
-		SELECT COUNT(1)
-		FROM pipelines
-		WHERE name = $1
-	  AND team_id = $2
-	`, pipelineName, t.id).Scan(&existingConfig)
+	instanceVarsPayload, err := instanceVarsPayload(pipelineRef.InstanceVars)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = psql.Select("COUNT(1)").
+		From("pipelines").
+		Where(sq.Eq{
+			"name":          pipelineRef.Name,
+			"instance_vars": instanceVarsPayload,
+			"team_id":       t.id,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&existingConfig)
 	if err != nil {
 		return nil, false, err
 	}
 
 	var pipelineID int
 	if existingConfig == 0 {
+		// instances of a pipeline share the ordering of the first instance so
+		// that they are listed together
 		err = psql.Insert("pipelines").
 			SetMap(map[string]interface{}{
-				"name":     pipelineName,
-				"groups":   groupsPayload,
-				"version":  sq.Expr("nextval('config_version_seq')"),
-				"ordering": sq.Expr("currval('pipelines_id_seq')"),
-				"paused":   initiallyPaused,
-				"team_id":  t.id,
+				"name":          pipelineRef.Name,
+				"instance_vars": instanceVarsPayload,
+				"groups":        groupsPayload,
+				"var_sources":   encryptedVarSources,
+				"nonce":         nonce,
+				"version":       sq.Expr("nextval('config_version_seq')"),
+				"ordering": sq.Expr(`COALESCE(
+					(SELECT MIN(ordering) FROM pipelines WHERE name = ? AND team_id = ?),
+					currval('pipelines_id_seq')
+				)`, pipelineRef.Name, t.id),
+				"paused":  initiallyPaused,
+				"team_id": t.id,
 			}).
 			Suffix("RETURNING id").
 			RunWith(tx).
@@ -396,11 +427,14 @@ func (t *team) SavePipeline(
 	} else {
 		update := psql.Update("pipelines").
 			Set("groups", groupsPayload).
+			Set("var_sources", encryptedVarSources).
+			Set("nonce", nonce).
 			Set("version", sq.Expr("nextval('config_version_seq')")).
 			Where(sq.Eq{
-				"name":    pipelineName,
-				"version": from,
-				"team_id": t.id,
+				"name":          pipelineRef.Name,
+				"instance_vars": instanceVarsPayload,
+				"version":       from,
+				"team_id":       t.id,
 			}).
 			Suffix("RETURNING id")
 
@@ -425,6 +459,18 @@ func (t *team) SavePipeline(
 			return nil, false, err
 		}
 
+		_, err = tx.Exec(`
+      DELETE FROM jobs_semaphores
+      WHERE job_id in (
+        SELECT j.id
+        FROM jobs j
+        WHERE j.pipeline_id = $1
+      )
+		`, pipelineID)
+		if err != nil {
+			return nil, false, err
+		}
+
 		_, err = tx.Exec(`
 			UPDATE jobs
 			SET active = false
@@ -494,6 +540,13 @@ func (t *team) SavePipeline(
 				return nil, false, err
 			}
 		}
+
+		for _, semaphore := range job.Semaphores {
+			err = t.registerSemaphore(tx, job.Name, semaphore, pipelineID)
+			if err != nil {
+				return nil, false, err
+			}
+		}
 	}
 
 	err = removeUnusedWorkerTaskCaches(tx, pipelineID, config.Jobs)
@@ -522,15 +575,21 @@ func (t *team) SavePipeline(
 	return pipeline, created, nil
 }
 
-func (t *team) Pipeline(pipelineName string) (Pipeline, bool, error) {
+func (t *team) Pipeline(pipelineRef atc.PipelineRef) (Pipeline, bool, error) {
+	instanceVarsPayload, err := instanceVarsPayload(pipelineRef.InstanceVars)
+	if err != nil {
+		return nil, false, err
+	}
+
 	pipeline := newPipeline(t.conn, t.lockFactory)
 
-	err := scanPipeline(
+	err = scanPipeline(
 		pipeline,
 		pipelinesQuery.
 			Where(sq.Eq{
-				"p.team_id": t.id,
-				"p.name":    pipelineName,
+				"p.team_id":       t.id,
+				"p.name":          pipelineRef.Name,
+				"p.instance_vars": instanceVarsPayload,
 			}).
 			RunWith(t.conn).
 			QueryRow(),
@@ -550,7 +609,7 @@ func (t *team) Pipelines() ([]Pipeline, error) {
 		Where(sq.Eq{
 			"team_id": t.id,
 		}).
-		OrderBy("ordering").
+		OrderBy("ordering", "p.id").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -571,7 +630,7 @@ func (t *team) PublicPipelines() ([]Pipeline, error) {
 			"team_id": t.id,
 			"public":  true,
 		}).
-		OrderBy("team_id ASC", "ordering ASC").
+		OrderBy("team_id ASC", "ordering ASC", "p.id ASC").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -586,6 +645,8 @@ func (t *team) PublicPipelines() ([]Pipeline, error) {
 	return pipelines, nil
 }
 
+// OrderPipelines orders the team's pipelines by name. All instances of a
+// pipeline share its position, and are listed in the order they were created.
 func (t *team) OrderPipelines(pipelineNames []string) error {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -644,7 +705,7 @@ func (t *team) CreateOneOffBuild() (Build, error) {
 	return build, nil
 }
 
-func (t *team) CreateStartedBuild(plan atc.Plan) (Build, error) {
+func (t *team) CreateStartedBuild(plan atc.Plan, createdBy atc.BuildCreator) (Build, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -657,6 +718,11 @@ func (t *team) CreateStartedBuild(plan atc.Plan) (Build, error) {
 		return nil, err
 	}
 
+	createdByPayload, err := json.Marshal(createdBy)
+	if err != nil {
+		return nil, err
+	}
+
 	encryptedPlan, nonce, err := t.conn.EncryptionStrategy().Encrypt(metadata)
 	if err != nil {
 		return nil, err
@@ -672,14 +738,16 @@ func (t *team) CreateStartedBuild(plan atc.Plan) (Build, error) {
 		"private_plan": encryptedPlan,
 		"public_plan":  plan.Public(),
 		"nonce":        nonce,
+		"created_by":   string(createdByPayload),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	err = build.saveEvent(tx, event.Status{
-		Status: atc.StatusStarted,
-		Time:   build.StartTime().Unix(),
+	saved, err := build.saveEvent(tx, event.Status{
+		Status:    atc.StatusStarted,
+		Time:      build.StartTime().Unix(),
+		CreatedBy: build.CreatedBy(),
 	})
 	if err != nil {
 		return nil, err
@@ -694,7 +762,7 @@ func (t *team) CreateStartedBuild(plan atc.Plan) (Build, error) {
 		return nil, err
 	}
 
-	if err = t.conn.Bus().Notify(buildEventsChannel(build.id)); err != nil {
+	if err = build.flushEvent(saved); err != nil {
 		return nil, err
 	}
 
@@ -763,8 +831,8 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 	return tx.Commit()
 }
 
-func (t *team) FindCheckContainers(pipelineName string, resourceName string, secretManager creds.Secrets) ([]Container, map[int]time.Time, error) {
-	pipeline, found, err := t.Pipeline(pipelineName)
+func (t *team) FindCheckContainers(pipelineRef atc.PipelineRef, resourceName string, secretManager creds.Secrets, varSourcePool creds.VarSourcePool) ([]Container, map[int]time.Time, error) {
+	pipeline, found, err := t.Pipeline(pipelineRef)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -785,7 +853,18 @@ func (t *team) FindCheckContainers(pipelineName string, resourceName string, sec
 		return nil, nil, err
 	}
 
-	variables := creds.NewVariables(secretManager, t.name, pipeline.Name())
+	access := atc.SecretAccess{
+		TeamName:     t.name,
+		PipelineName: pipeline.Name(),
+	}
+
+	variables := creds.NewPipelineVariables(
+		creds.WithSecretAccess(secretManager, access),
+		creds.WithVarSourceAccess(varSourcePool, access),
+		t.name,
+		pipeline.Name(),
+		pipeline.VarSources(),
+	)
 
 	versionedResourceTypes := pipelineResourceTypes.Deserialize()
 
@@ -972,11 +1051,16 @@ func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string
 		return err
 	}
 
+	// a schedule starts counting from when it is first configured, rather
+	// than triggering a build for every tick it would have had in the past
+	scheduled := job.Schedule != nil
+
 	updated, err := checkIfRowsUpdated(tx, `
 		UPDATE jobs
-		SET config = $3, interruptible = $4, active = true, nonce = $5, tags = $6
+		SET config = $3, interruptible = $4, active = true, nonce = $5, tags = $6,
+			schedule_time = CASE WHEN $7 THEN COALESCE(schedule_time, now()) END, public = $8
 		WHERE name = $1 AND pipeline_id = $2
-	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, pq.Array(groups))
+	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, pq.Array(groups), scheduled, job.Public)
 	if err != nil {
 		return err
 	}
@@ -986,9 +1070,9 @@ func (t *team) saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO jobs (name, pipeline_id, config, interruptible, active, nonce, tags)
-		VALUES ($1, $2, $3, $4, true, $5, $6)
-	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, pq.Array(groups))
+		INSERT INTO jobs (name, pipeline_id, config, interruptible, active, nonce, tags, schedule_time, public)
+		VALUES ($1, $2, $3, $4, true, $5, $6, CASE WHEN $7 THEN now() END, $8)
+	`, job.Name, pipelineID, encryptedPayload, job.Interruptible, nonce, pq.Array(groups), scheduled, job.Public)
 
 	return swallowUniqueViolation(err)
 }
@@ -1009,6 +1093,20 @@ func (t *team) registerSerialGroup(tx Tx, jobName, serialGroup string, pipelineI
 	return swallowUniqueViolation(err)
 }
 
+func (t *team) registerSemaphore(tx Tx, jobName string, semaphore atc.SemaphoreConfig, pipelineID int) error {
+	_, err := tx.Exec(`
+    INSERT INTO jobs_semaphores (job_id, team_id, name, capacity) VALUES
+    ((SELECT j.id
+        FROM jobs j
+       WHERE j.name = $1
+         AND j.pipeline_id = $2
+       LIMIT 1), $3, $4, $5)`,
+		jobName, pipelineID, t.id, semaphore.Name, semaphore.Capacity(),
+	)
+
+	return swallowUniqueViolation(err)
+}
+
 func (t *team) saveResource(tx Tx, resource atc.ResourceConfig, pipelineID int) error {
 	configPayload, err := json.Marshal(resource)
 	if err != nil {
@@ -1144,13 +1242,43 @@ func (t *team) findContainer(whereClause sq.Sqlizer) (CreatingContainer, Created
 	return creating, created, nil
 }
 
+// instanceVarsPayload returns the value stored in the instance_vars column
+// for the given vars. Pipelines without instance vars store NULL, which
+// squirrel turns into an IS NULL comparison.
+func instanceVarsPayload(instanceVars atc.InstanceVars) (interface{}, error) {
+	if len(instanceVars) == 0 {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(instanceVars)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(payload), nil
+}
+
 func scanPipeline(p *pipeline, scan scannable) error {
-	var groups sql.NullString
-	err := scan.Scan(&p.id, &p.name, &groups, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public)
+	var (
+		groups, instanceVars       sql.NullString
+		parentJobID, parentBuildID sql.NullInt64
+		varSources, nonce          sql.NullString
+	)
+	err := scan.Scan(&p.id, &p.name, &instanceVars, &groups, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &parentJobID, &parentBuildID, &varSources, &nonce)
 	if err != nil {
 		return err
 	}
 
+	p.parentJobID = int(parentJobID.Int64)
+	p.parentBuildID = int(parentBuildID.Int64)
+
+	if instanceVars.Valid {
+		err = json.Unmarshal([]byte(instanceVars.String), &p.instanceVars)
+		if err != nil {
+			return err
+		}
+	}
+
 	if groups.Valid {
 		var pipelineGroups atc.GroupConfigs
 		err = json.Unmarshal([]byte(groups.String), &pipelineGroups)
@@ -1161,6 +1289,26 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		p.groups = pipelineGroups
 	}
 
+	if varSources.Valid {
+		var noncense *string
+		if nonce.Valid {
+			noncense = &nonce.String
+		}
+
+		decryptedVarSources, err := p.conn.EncryptionStrategy().Decrypt(varSources.String, noncense)
+		if err != nil {
+			return err
+		}
+
+		var pipelineVarSources atc.VarSourceConfigs
+		err = json.Unmarshal(decryptedVarSources, &pipelineVarSources)
+		if err != nil {
+			return err
+		}
+
+		p.varSources = pipelineVarSources
+	}
+
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/event"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -40,7 +41,7 @@ var _ = Describe("Team", func() {
 		var otherTeamPipeline db.Pipeline
 
 		BeforeEach(func() {
-			otherTeamPipeline, _, err = otherTeam.SavePipeline("fake-pipeline", atc.Config{
+			otherTeamPipeline, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
 				Jobs: atc.JobConfigs{
 					{Name: "job-name"},
 				},
@@ -295,7 +296,7 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			metaContainers = make(map[db.ContainerMetadata][]db.Container)
@@ -421,7 +422,7 @@ var _ = Describe("Team", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				firstContainerCreating, err = defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-job"), defaultTeam.ID()), db.ContainerMetadata{Type: "task", StepName: "some-task"})
@@ -519,7 +520,7 @@ var _ = Describe("Team", func() {
 					otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "other-team"})
 					Expect(err).NotTo(HaveOccurred())
 
-					otherPipeline, _, err := otherTeam.SavePipeline("other-pipeline", atc.Config{
+					otherPipeline, _, err := otherTeam.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 						Jobs: atc.JobConfigs{
 							{
 								Name: "some-job",
@@ -670,7 +671,7 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err := job.CreateBuild()
+			build, err := job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 
 			creatingContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-job"), defaultTeam.ID()), db.ContainerMetadata{Type: "task", StepName: "some-task"})
@@ -872,14 +873,14 @@ var _ = Describe("Team", func() {
 		Context("when the team has configured pipelines", func() {
 			BeforeEach(func() {
 				var err error
-				pipeline1, _, err = team.SavePipeline("fake-pipeline", atc.Config{
+				pipeline1, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{Name: "job-name"},
 					},
 				}, db.ConfigVersion(1), false)
 				Expect(err).ToNot(HaveOccurred())
 
-				pipeline2, _, err = team.SavePipeline("fake-pipeline-two", atc.Config{
+				pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline-two"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{Name: "job-fake"},
 					},
@@ -913,14 +914,14 @@ var _ = Describe("Team", func() {
 		Context("when the team has configured pipelines", func() {
 			BeforeEach(func() {
 				var err error
-				_, _, err = team.SavePipeline("fake-pipeline", atc.Config{
+				_, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{Name: "job-name"},
 					},
 				}, db.ConfigVersion(1), false)
 				Expect(err).ToNot(HaveOccurred())
 
-				pipeline2, _, err = team.SavePipeline("fake-pipeline-two", atc.Config{
+				pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline-two"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{Name: "job-fake"},
 					},
@@ -954,14 +955,14 @@ var _ = Describe("Team", func() {
 
 		BeforeEach(func() {
 			var err error
-			pipeline1, _, err = team.SavePipeline("pipeline-name-a", atc.Config{}, 0, false)
+			pipeline1, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline-name-a"}, atc.Config{}, 0, false)
 			Expect(err).ToNot(HaveOccurred())
-			pipeline2, _, err = team.SavePipeline("pipeline-name-b", atc.Config{}, 0, false)
+			pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "pipeline-name-b"}, atc.Config{}, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
-			otherPipeline1, _, err = otherTeam.SavePipeline("pipeline-name-a", atc.Config{}, 0, false)
+			otherPipeline1, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "pipeline-name-a"}, atc.Config{}, 0, false)
 			Expect(err).ToNot(HaveOccurred())
-			otherPipeline2, _, err = otherTeam.SavePipeline("pipeline-name-b", atc.Config{}, 0, false)
+			otherPipeline2, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "pipeline-name-b"}, atc.Config{}, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -986,6 +987,31 @@ var _ = Describe("Team", func() {
 			Expect(otherTeamOrderedPipelines[1].ID()).To(Equal(otherPipeline2.ID()))
 		})
 
+		Context("when pipelines have instances", func() {
+			var instance1 db.Pipeline
+
+			BeforeEach(func() {
+				var err error
+				instance1, _, err = team.SavePipeline(atc.PipelineRef{
+					Name:         "pipeline-name-a",
+					InstanceVars: atc.InstanceVars{"branch": "feature"},
+				}, atc.Config{}, 0, false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("moves the instances along with the pipeline, in the order they were created", func() {
+				err := team.OrderPipelines([]string{"pipeline-name-b", "pipeline-name-a"})
+				Expect(err).ToNot(HaveOccurred())
+
+				orderedPipelines, err := team.Pipelines()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(orderedPipelines).To(HaveLen(3))
+				Expect(orderedPipelines[0].ID()).To(Equal(pipeline2.ID()))
+				Expect(orderedPipelines[1].ID()).To(Equal(pipeline1.ID()))
+				Expect(orderedPipelines[2].ID()).To(Equal(instance1.ID()))
+			})
+		})
+
 		Context("when pipeline does not exist", func() {
 			It("returns error ", func() {
 				err := otherTeam.OrderPipelines([]string{"pipeline-name-a", "pipeline-does-not-exist"})
@@ -1048,7 +1074,10 @@ var _ = Describe("Team", func() {
 				},
 			}
 
-			startedBuild, err = team.CreateStartedBuild(plan)
+			startedBuild, err = team.CreateStartedBuild(plan, atc.BuildCreator{
+				Type: atc.BuildCreatorAPI,
+				User: "some-user",
+			})
 			Expect(err).ToNot(HaveOccurred())
 		})
 
@@ -1059,6 +1088,10 @@ var _ = Describe("Team", func() {
 			Expect(startedBuild.Name()).To(Equal(strconv.Itoa(startedBuild.ID())))
 			Expect(startedBuild.TeamName()).To(Equal(team.Name()))
 			Expect(startedBuild.Status()).To(Equal(db.BuildStatusStarted))
+			Expect(startedBuild.CreatedBy()).To(Equal(&atc.BuildCreator{
+				Type: atc.BuildCreatorAPI,
+				User: "some-user",
+			}))
 		})
 
 		It("saves the public plan", func() {
@@ -1081,6 +1114,10 @@ var _ = Describe("Team", func() {
 			Expect(events.Next()).To(Equal(envelope(event.Status{
 				Status: atc.StatusStarted,
 				Time:   startedBuild.StartTime().Unix(),
+				CreatedBy: &atc.BuildCreator{
+					Type: atc.BuildCreatorAPI,
+					User: "some-user",
+				},
 			})))
 		})
 	})
@@ -1117,7 +1154,7 @@ var _ = Describe("Team", func() {
 					},
 				}
 				var err error
-				pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), false)
+				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false)
 				Expect(err).ToNot(HaveOccurred())
 
 				job, found, err := pipeline.Job("some-job")
@@ -1125,7 +1162,7 @@ var _ = Describe("Team", func() {
 				Expect(found).To(BeTrue())
 
 				for i := 3; i < 5; i++ {
-					build, err := job.CreateBuild()
+					build, err := job.CreateBuild(defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 					allBuilds[i] = build
 					pipelineBuilds[i-3] = build
@@ -1268,7 +1305,7 @@ var _ = Describe("Team", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), false)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -1276,7 +1313,7 @@ var _ = Describe("Team", func() {
 			Expect(found).To(BeTrue())
 
 			for i := range builds {
-				builds[i], err = job.CreateBuild()
+				builds[i], err = job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				buildStart := time.Date(2020, 11, i+1, 0, 0, 0, 0, time.UTC)
@@ -1374,18 +1411,18 @@ var _ = Describe("Team", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), false)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			build, err = job.CreateBuild()
+			build, err = job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, build)
 
-			secondBuild, err = job.CreateBuild()
+			secondBuild, err = job.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, secondBuild)
 
@@ -1393,7 +1430,7 @@ var _ = Describe("Team", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			thirdBuild, err = someOtherJob.CreateBuild()
+			thirdBuild, err = someOtherJob.CreateBuild(defaultBuildCreatedBy)
 			Expect(err).ToNot(HaveOccurred())
 			expectedBuilds = append(expectedBuilds, thirdBuild)
 		})
@@ -1626,26 +1663,87 @@ var _ = Describe("Team", func() {
 		})
 
 		It("returns true for created", func() {
-			_, created, err := team.SavePipeline(pipelineName, config, 0, false)
+			_, created, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).To(BeTrue())
 		})
 
 		It("caches the team id", func() {
-			_, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
-			pipeline, found, err := team.Pipeline(pipelineName)
+			pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 			Expect(pipeline.TeamID()).To(Equal(team.ID()))
 		})
 
+		Context("when saving instances of a pipeline", func() {
+			var (
+				defaultInstance db.Pipeline
+				releaseInstance db.Pipeline
+				releaseRef      atc.PipelineRef
+			)
+
+			BeforeEach(func() {
+				releaseRef = atc.PipelineRef{
+					Name:         pipelineName,
+					InstanceVars: atc.InstanceVars{"release": "5.1"},
+				}
+
+				var err error
+				defaultInstance, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-other-pipeline"}, config, 0, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				var created bool
+				releaseInstance, created, err = team.SavePipeline(releaseRef, config, 0, false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created).To(BeTrue())
+			})
+
+			It("saves a separate pipeline for each instance", func() {
+				Expect(releaseInstance.ID()).ToNot(Equal(defaultInstance.ID()))
+				Expect(releaseInstance.Name()).To(Equal(pipelineName))
+				Expect(releaseInstance.InstanceVars()).To(Equal(atc.InstanceVars{"release": "5.1"}))
+				Expect(defaultInstance.InstanceVars()).To(BeNil())
+			})
+
+			It("finds each instance by its ref", func() {
+				pipeline, found, err := team.Pipeline(releaseRef)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(pipeline.ID()).To(Equal(releaseInstance.ID()))
+
+				pipeline, found, err = team.Pipeline(atc.PipelineRef{Name: pipelineName})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(pipeline.ID()).To(Equal(defaultInstance.ID()))
+			})
+
+			It("updates the existing instance", func() {
+				_, created, err := team.SavePipeline(releaseRef, config, releaseInstance.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(created).To(BeFalse())
+			})
+
+			It("lists the instances next to each other", func() {
+				pipelines, err := team.Pipelines()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pipelines).To(HaveLen(3))
+				Expect(pipelines[0].ID()).To(Equal(defaultInstance.ID()))
+				Expect(pipelines[1].ID()).To(Equal(releaseInstance.ID()))
+				Expect(pipelines[2].Name()).To(Equal("some-other-pipeline"))
+			})
+		})
+
 		It("can be saved as paused", func() {
-			_, _, err := team.SavePipeline(pipelineName, config, 0, true)
+			_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, true)
 			Expect(err).ToNot(HaveOccurred())
 
-			pipeline, found, err := team.Pipeline(pipelineName)
+			pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
@@ -1653,10 +1751,10 @@ var _ = Describe("Team", func() {
 		})
 
 		It("can be saved as unpaused", func() {
-			_, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
-			pipeline, found, err := team.Pipeline(pipelineName)
+			pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
@@ -1664,7 +1762,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("creates all of the resources from the pipeline in the database", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, found, err := savedPipeline.Resource("some-resource")
@@ -1677,14 +1775,14 @@ var _ = Describe("Team", func() {
 		})
 
 		It("updates resource config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			config.Resources[0].Source = atc.Source{
 				"source-other-config": "some-other-value",
 			}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, found, err := savedPipeline.Resource("some-resource")
@@ -1697,7 +1795,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("clears out api pinned version when resaving a pinned version on the pipeline config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, found, err := pipeline.Resource("some-resource")
@@ -1741,7 +1839,7 @@ var _ = Describe("Team", func() {
 				"version": "v2",
 			}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, found, err = savedPipeline.Resource("some-resource")
@@ -1752,7 +1850,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("does not clear the api pinned version when resaving pipeline config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, found, err := pipeline.Resource("some-resource")
@@ -1792,7 +1890,7 @@ var _ = Describe("Team", func() {
 			Expect(reloaded).To(BeTrue())
 			Expect(resource.APIPinnedVersion()).To(Equal(atc.Version{"version": "v1"}))
 
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource, found, err = savedPipeline.Resource("some-resource")
@@ -1802,12 +1900,12 @@ var _ = Describe("Team", func() {
 		})
 
 		It("marks resource as inactive if it is no longer in config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			config.Resources = []atc.ResourceConfig{}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err := savedPipeline.Resource("some-resource")
@@ -1816,7 +1914,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("creates all of the resource types from the pipeline in the database", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			resourceType, found, err := savedPipeline.ResourceType("some-resource-type")
@@ -1829,14 +1927,14 @@ var _ = Describe("Team", func() {
 		})
 
 		It("updates resource type config from the pipeline in the database", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			config.ResourceTypes[0].Source = atc.Source{
 				"source-other-config": "some-other-value",
 			}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			resourceType, found, err := savedPipeline.ResourceType("some-resource-type")
@@ -1849,12 +1947,12 @@ var _ = Describe("Team", func() {
 		})
 
 		It("marks resource type as inactive if it is no longer in config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			config.ResourceTypes = []atc.ResourceType{}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err := savedPipeline.ResourceType("some-resource-type")
@@ -1863,7 +1961,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("creates all of the jobs from the pipeline in the database", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := savedPipeline.Job("some-job")
@@ -1873,12 +1971,12 @@ var _ = Describe("Team", func() {
 		})
 
 		It("updates job config", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			config.Jobs[0].Public = false
 
-			_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -1888,12 +1986,12 @@ var _ = Describe("Team", func() {
 		})
 
 		It("marks job inactive when it is no longer in pipeline", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			config.Jobs = []atc.JobConfig{}
 
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err := savedPipeline.Job("some-job")
@@ -1942,7 +2040,7 @@ var _ = Describe("Team", func() {
 			})
 
 			It("should handle when there are multiple name changes", func() {
-				pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 				Expect(err).ToNot(HaveOccurred())
 
 				job, _, _ := pipeline.Job("some-job")
@@ -1954,7 +2052,7 @@ var _ = Describe("Team", func() {
 				config.Jobs[1].Name = "new-other-job"
 				config.Jobs[1].OldName = "new-job"
 
-				updatedPipeline, _, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+				updatedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 				Expect(err).ToNot(HaveOccurred())
 
 				updatedJob, _, _ := updatedPipeline.Job("new-job")
@@ -1965,7 +2063,7 @@ var _ = Describe("Team", func() {
 			})
 
 			It("should return an error when there is a swap with job name", func() {
-				pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+				pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 				Expect(err).ToNot(HaveOccurred())
 
 				config.Jobs[0].Name = "new-job"
@@ -1974,31 +2072,31 @@ var _ = Describe("Team", func() {
 				config.Jobs[1].Name = "some-job"
 				config.Jobs[1].OldName = "new-job"
 
-				_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+				_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 				Expect(err).To(HaveOccurred())
 			})
 
 			Context("when new job name is in database but is inactive", func() {
 				It("should successfully update job name", func() {
-					pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+					pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 					Expect(err).ToNot(HaveOccurred())
 
 					config.Jobs = config.Jobs[:len(config.Jobs)-1]
 
-					_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+					_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 					Expect(err).ToNot(HaveOccurred())
 
 					config.Jobs[0].Name = "new-job"
 					config.Jobs[0].OldName = "some-job"
 
-					_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion()+1, false)
+					_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion()+1, false)
 					Expect(err).ToNot(HaveOccurred())
 				})
 			})
 		})
 
 		It("removes task caches for jobs that are no longer in pipeline", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -2014,7 +2112,7 @@ var _ = Describe("Team", func() {
 
 			config.Jobs = []atc.JobConfig{}
 
-			_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err = taskCacheFactory.Find(job.ID(), "some-task", "some-path")
@@ -2023,7 +2121,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("removes task caches for tasks that are no longer exist", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -2049,7 +2147,7 @@ var _ = Describe("Team", func() {
 				},
 			}
 
-			_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err = taskCacheFactory.Find(job.ID(), "some-task", "some-path")
@@ -2058,10 +2156,10 @@ var _ = Describe("Team", func() {
 		})
 
 		It("should not remove task caches in other pipeline", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
-			otherPipeline, _, err := team.SavePipeline("other-pipeline", config, 0, false)
+			otherPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := pipeline.Job("some-job")
@@ -2098,7 +2196,7 @@ var _ = Describe("Team", func() {
 				},
 			}
 
-			_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			_, found, err = taskCacheFactory.Find(job.ID(), "some-task", "some-path")
@@ -2111,7 +2209,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("creates all of the serial groups from the jobs in the database", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			serialGroups := []SerialGroup{}
@@ -2142,7 +2240,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("saves tags in the jobs table", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineName, otherConfig, 0, false)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, otherConfig, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := savedPipeline.Job("some-other-job")
@@ -2153,7 +2251,7 @@ var _ = Describe("Team", func() {
 		})
 
 		It("updates tags in the jobs table", func() {
-			savedPipeline, _, err := team.SavePipeline(pipelineName, otherConfig, 0, false)
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, otherConfig, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err := savedPipeline.Job("some-other-job")
@@ -2173,7 +2271,7 @@ var _ = Describe("Team", func() {
 				},
 			}
 
-			savedPipeline, _, err = team.SavePipeline(pipelineName, otherConfig, savedPipeline.ConfigVersion(), false)
+			savedPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, otherConfig, savedPipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err = savedPipeline.Job("some-other-job")
@@ -2184,62 +2282,83 @@ var _ = Describe("Team", func() {
 		})
 
 		It("it returns created as false when updated", func() {
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
-			_, created, err := team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+			_, created, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(created).To(BeFalse())
 		})
 
 		Context("updating an existing pipeline", func() {
 			It("maintains paused if the pipeline is paused", func() {
-				_, _, err := team.SavePipeline(pipelineName, config, 0, true)
+				_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, true)
 				Expect(err).ToNot(HaveOccurred())
 
-				pipeline, found, err := team.Pipeline(pipelineName)
+				pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(pipeline.Paused()).To(BeTrue())
 
-				_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), false)
+				_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), false)
 				Expect(err).ToNot(HaveOccurred())
 
-				pipeline, found, err = team.Pipeline(pipelineName)
+				pipeline, found, err = team.Pipeline(atc.PipelineRef{Name: pipelineName})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(pipeline.Paused()).To(BeTrue())
 			})
 
 			It("maintains unpaused if the pipeline is unpaused", func() {
-				_, _, err := team.SavePipeline(pipelineName, config, 0, false)
+				_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 				Expect(err).ToNot(HaveOccurred())
 
-				pipeline, found, err := team.Pipeline(pipelineName)
+				pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(pipeline.Paused()).To(BeFalse())
 
-				_, _, err = team.SavePipeline(pipelineName, config, pipeline.ConfigVersion(), true)
+				_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, pipeline.ConfigVersion(), true)
 				Expect(err).ToNot(HaveOccurred())
 
-				pipeline, found, err = team.Pipeline(pipelineName)
+				pipeline, found, err = team.Pipeline(atc.PipelineRef{Name: pipelineName})
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(pipeline.Paused()).To(BeFalse())
 			})
 		})
 
+		It("saves the var sources", func() {
+			config.VarSources = atc.VarSourceConfigs{
+				{
+					Name: "some-var-source",
+					Type: "vault",
+					Config: map[string]interface{}{
+						"url": "https://vault.example.com",
+					},
+				},
+			}
+
+			savedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "a-pipeline-name"}, config, 0, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(savedPipeline.VarSources()).To(Equal(config.VarSources))
+
+			pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: "a-pipeline-name"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(pipeline.VarSources()).To(Equal(config.VarSources))
+		})
+
 		It("can lookup a pipeline by name", func() {
 			pipelineName := "a-pipeline-name"
 			otherPipelineName := "an-other-pipeline-name"
 
-			_, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			_, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
-			_, _, err = team.SavePipeline(otherPipelineName, otherConfig, 0, false)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: otherPipelineName}, otherConfig, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
-			pipeline, found, err := team.Pipeline(pipelineName)
+			pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 			Expect(pipeline.Name()).To(Equal(pipelineName))
@@ -2257,7 +2376,7 @@ var _ = Describe("Team", func() {
 				Jobs:          jobs.Configs(),
 			}, config)
 
-			otherPipeline, found, err := team.Pipeline(otherPipelineName)
+			otherPipeline, found, err := team.Pipeline(atc.PipelineRef{Name: otherPipelineName})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 			Expect(otherPipeline.Name()).To(Equal(otherPipelineName))
@@ -2282,10 +2401,10 @@ var _ = Describe("Team", func() {
 			otherPipelineName := "an-other-pipeline-name"
 
 			By("being able to save the config")
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
-			otherPipeline, _, err := team.SavePipeline(otherPipelineName, otherConfig, 0, false)
+			otherPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: otherPipelineName}, otherConfig, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			By("returning the saved config to later gets")
@@ -2355,22 +2474,22 @@ var _ = Describe("Team", func() {
 			})
 
 			By("not allowing non-sequential updates")
-			_, _, err = team.SavePipeline(pipelineName, updatedConfig, pipeline.ConfigVersion()-1, false)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, updatedConfig, pipeline.ConfigVersion()-1, false)
 			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
-			_, _, err = team.SavePipeline(pipelineName, updatedConfig, pipeline.ConfigVersion()+10, false)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, updatedConfig, pipeline.ConfigVersion()+10, false)
 			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
-			_, _, err = team.SavePipeline(otherPipelineName, updatedConfig, otherPipeline.ConfigVersion()-1, false)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: otherPipelineName}, updatedConfig, otherPipeline.ConfigVersion()-1, false)
 			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
-			_, _, err = team.SavePipeline(otherPipelineName, updatedConfig, otherPipeline.ConfigVersion()+10, false)
+			_, _, err = team.SavePipeline(atc.PipelineRef{Name: otherPipelineName}, updatedConfig, otherPipeline.ConfigVersion()+10, false)
 			Expect(err).To(Equal(db.ErrConfigComparisonFailed))
 
 			By("being able to update the config with a valid con")
-			pipeline, _, err = team.SavePipeline(pipelineName, updatedConfig, pipeline.ConfigVersion(), false)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: pipelineName}, updatedConfig, pipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
-			otherPipeline, _, err = team.SavePipeline(otherPipelineName, updatedConfig, otherPipeline.ConfigVersion(), false)
+			otherPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: otherPipelineName}, updatedConfig, otherPipeline.ConfigVersion(), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			By("returning the updated config")
@@ -2427,7 +2546,7 @@ var _ = Describe("Team", func() {
 
 			pipelineName := "a-pipeline-name"
 
-			pipeline, _, err := team.SavePipeline(pipelineName, config, 0, false)
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: pipelineName}, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			resourceTypes, err := pipeline.ResourceTypes()
@@ -2445,27 +2564,27 @@ var _ = Describe("Team", func() {
 
 		Context("when there are multiple teams", func() {
 			It("can allow pipelines with the same name across teams", func() {
-				teamPipeline, _, err := team.SavePipeline("steve", config, 0, true)
+				teamPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "steve"}, config, 0, true)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(teamPipeline.Paused()).To(BeTrue())
 
 				By("allowing you to save a pipeline with the same name in another team")
-				otherTeamPipeline, _, err := otherTeam.SavePipeline("steve", otherConfig, 0, true)
+				otherTeamPipeline, _, err := otherTeam.SavePipeline(atc.PipelineRef{Name: "steve"}, otherConfig, 0, true)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(otherTeamPipeline.Paused()).To(BeTrue())
 
 				By("updating the pipeline config for the correct team's pipeline")
-				teamPipeline, _, err = team.SavePipeline("steve", otherConfig, teamPipeline.ConfigVersion(), false)
+				teamPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "steve"}, otherConfig, teamPipeline.ConfigVersion(), false)
 				Expect(err).ToNot(HaveOccurred())
 
-				_, _, err = otherTeam.SavePipeline("steve", config, otherTeamPipeline.ConfigVersion(), false)
+				_, _, err = otherTeam.SavePipeline(atc.PipelineRef{Name: "steve"}, config, otherTeamPipeline.ConfigVersion(), false)
 				Expect(err).ToNot(HaveOccurred())
 
 				By("cannot cross update configs")
-				_, _, err = team.SavePipeline("steve", otherConfig, otherTeamPipeline.ConfigVersion(), false)
+				_, _, err = team.SavePipeline(atc.PipelineRef{Name: "steve"}, otherConfig, otherTeamPipeline.ConfigVersion(), false)
 				Expect(err).To(HaveOccurred())
 
-				_, _, err = team.SavePipeline("steve", otherConfig, otherTeamPipeline.ConfigVersion(), true)
+				_, _, err = team.SavePipeline(atc.PipelineRef{Name: "steve"}, otherConfig, otherTeamPipeline.ConfigVersion(), true)
 				Expect(err).To(HaveOccurred())
 			})
 		})
@@ -2474,6 +2593,7 @@ var _ = Describe("Team", func() {
 	Describe("FindCheckContainers", func() {
 		var (
 			fakeSecretManager *credsfakes.FakeSecrets
+			fakeVarSourcePool *credsfakes.FakeVarSourcePool
 		)
 
 		expiries := db.ContainerOwnerExpiries{
@@ -2484,6 +2604,8 @@ var _ = Describe("Team", func() {
 		BeforeEach(func() {
 			fakeSecretManager = new(credsfakes.FakeSecrets)
 			fakeSecretManager.GetReturns("", nil, false, nil)
+
+			fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
 		})
 
 		Context("when pipeline exists", func() {
@@ -2515,7 +2637,7 @@ var _ = Describe("Team", func() {
 					})
 
 					It("returns check container for resource", func() {
-						containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers("default-pipeline", "some-resource", fakeSecretManager)
+						containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers(atc.PipelineRef{Name: "default-pipeline"}, "some-resource", fakeSecretManager, fakeVarSourcePool)
 						Expect(err).ToNot(HaveOccurred())
 						Expect(containers).To(HaveLen(1))
 						Expect(containers[0].ID()).To(Equal(resourceContainer.ID()))
@@ -2533,7 +2655,7 @@ var _ = Describe("Team", func() {
 						)
 
 						BeforeEach(func() {
-							otherPipeline, _, err = defaultTeam.SavePipeline("other-pipeline", atc.Config{
+							otherPipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 								Resources: atc.ResourceConfigs{
 									{
 										Name: "some-resource",
@@ -2568,7 +2690,7 @@ var _ = Describe("Team", func() {
 						})
 
 						It("returns the same check container", func() {
-							containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers("other-pipeline", "some-resource", fakeSecretManager)
+							containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers(atc.PipelineRef{Name: "other-pipeline"}, "some-resource", fakeSecretManager, fakeVarSourcePool)
 							Expect(err).ToNot(HaveOccurred())
 							Expect(containers).To(HaveLen(1))
 							Expect(containers[0].ID()).To(Equal(otherResourceContainer.ID()))
@@ -2581,7 +2703,7 @@ var _ = Describe("Team", func() {
 
 				Context("when check container does not exist", func() {
 					It("returns empty list", func() {
-						containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers("default-pipeline", "some-resource", fakeSecretManager)
+						containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers(atc.PipelineRef{Name: "default-pipeline"}, "some-resource", fakeSecretManager, fakeVarSourcePool)
 						Expect(err).ToNot(HaveOccurred())
 						Expect(containers).To(BeEmpty())
 						Expect(checkContainersExpiresAt).To(BeEmpty())
@@ -2591,7 +2713,7 @@ var _ = Describe("Team", func() {
 
 			Context("when resource does not exist", func() {
 				It("returns empty list", func() {
-					containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers("default-pipeline", "non-existent-resource", fakeSecretManager)
+					containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers(atc.PipelineRef{Name: "default-pipeline"}, "non-existent-resource", fakeSecretManager, fakeVarSourcePool)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(containers).To(BeEmpty())
 					Expect(checkContainersExpiresAt).To(BeEmpty())
@@ -2601,7 +2723,7 @@ var _ = Describe("Team", func() {
 
 		Context("when pipeline does not exist", func() {
 			It("returns empty list", func() {
-				containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers("non-existent-pipeline", "some-resource", fakeSecretManager)
+				containers, checkContainersExpiresAt, err := defaultTeam.FindCheckContainers(atc.PipelineRef{Name: "non-existent-pipeline"}, "some-resource", fakeSecretManager, fakeVarSourcePool)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(containers).To(BeEmpty())
 				Expect(checkContainersExpiresAt).To(BeEmpty())
@@ -2672,7 +2794,7 @@ var _ = Describe("Team", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(found).To(BeTrue())
 
-				build, err := job.CreateBuild()
+				build, err := job.CreateBuild(defaultBuildCreatedBy)
 				Expect(err).ToNot(HaveOccurred())
 
 				creatingContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), atc.PlanID("some-job"), defaultTeam.ID()), db.ContainerMetadata{Type: "task", StepName: "some-task"})
@@ -2711,4 +2833,307 @@ var _ = Describe("Team", func() {
 			})
 		})
 	})
+
+	Describe("SearchBuildLogs", func() {
+		var (
+			jobBuild    db.Build
+			oneOffBuild db.Build
+
+			search  db.BuildLogSearch
+			matches []db.BuildLogMatch
+		)
+
+		BeforeEach(func() {
+			var err error
+			jobBuild, err = defaultJob.CreateBuild(defaultBuildCreatedBy)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = jobBuild.SaveEvent(event.Log{
+				Origin:  event.Origin{ID: "some-task-id", Source: event.OriginSourceStdout},
+				Payload: "compiling\nError: connection refused\ndone\n",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = jobBuild.SaveEvent(event.Log{
+				Origin:  event.Origin{ID: "some-task-id", Source: event.OriginSourceStdout},
+				Payload: "all good\n",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = jobBuild.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+
+			oneOffBuild, err = defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = oneOffBuild.SaveEvent(event.Log{
+				Origin:  event.Origin{ID: "some-other-task-id", Source: event.OriginSourceStderr},
+				Payload: "connection REFUSED again\n",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			search = db.BuildLogSearch{Query: "refused"}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			matches, err = defaultTeam.SearchBuildLogs(search)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the matching builds, most recent first, with the matching lines", func() {
+			Expect(matches).To(HaveLen(2))
+
+			Expect(matches[0].Build.ID()).To(Equal(oneOffBuild.ID()))
+			Expect(matches[0].Lines).To(HaveLen(1))
+			Expect(matches[0].Lines[0].Line).To(Equal("connection REFUSED again"))
+			Expect(matches[0].Lines[0].Origin.ID).To(Equal(event.OriginID("some-other-task-id")))
+
+			Expect(matches[1].Build.ID()).To(Equal(jobBuild.ID()))
+			Expect(matches[1].Lines).To(HaveLen(1))
+			Expect(matches[1].Lines[0].Line).To(Equal("Error: connection refused"))
+			Expect(matches[1].Lines[0].Origin.ID).To(Equal(event.OriginID("some-task-id")))
+		})
+
+		Context("when filtering by pipeline and job", func() {
+			BeforeEach(func() {
+				search.PipelineName = defaultPipeline.Name()
+				search.JobName = defaultJob.Name()
+			})
+
+			It("only returns builds of the job", func() {
+				Expect(matches).To(HaveLen(1))
+				Expect(matches[0].Build.ID()).To(Equal(jobBuild.ID()))
+			})
+		})
+
+		Context("when filtering by status", func() {
+			BeforeEach(func() {
+				search.Statuses = []db.BuildStatus{db.BuildStatusPending}
+			})
+
+			It("only returns builds with the status", func() {
+				Expect(matches).To(HaveLen(1))
+				Expect(matches[0].Build.ID()).To(Equal(oneOffBuild.ID()))
+			})
+		})
+
+		Context("when only searching public pipelines", func() {
+			BeforeEach(func() {
+				search.PublicOnly = true
+			})
+
+			It("returns nothing while the pipeline is hidden", func() {
+				Expect(matches).To(BeEmpty())
+			})
+
+			Context("when the pipeline is exposed", func() {
+				BeforeEach(func() {
+					err := defaultPipeline.Expose()
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("returns nothing while the job is private", func() {
+					Expect(matches).To(BeEmpty())
+				})
+
+				Context("when the job is public", func() {
+					BeforeEach(func() {
+						_, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: defaultPipeline.Name()}, atc.Config{
+							Jobs: atc.JobConfigs{
+								{
+									Name:   "some-job",
+									Public: true,
+								},
+							},
+						}, defaultPipeline.ConfigVersion(), false)
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					It("only returns builds of the job", func() {
+						Expect(matches).To(HaveLen(1))
+						Expect(matches[0].Build.ID()).To(Equal(jobBuild.ID()))
+					})
+				})
+			})
+		})
+
+		Context("when nothing matches", func() {
+			BeforeEach(func() {
+				search.Query = "nonexistent"
+			})
+
+			It("returns no matches", func() {
+				Expect(matches).To(BeEmpty())
+			})
+		})
+
+		Context("when the logs belong to another team", func() {
+			It("does not return them", func() {
+				otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-searching-team"})
+				Expect(err).ToNot(HaveOccurred())
+
+				otherMatches, err := otherTeam.SearchBuildLogs(search)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(otherMatches).To(BeEmpty())
+			})
+		})
+
+		Context("when build events are stored outside of postgres", func() {
+			It("errors", func() {
+				storeTeamFactory := db.NewTeamFactory(db.WithEventStore(dbConn, new(dbfakes.FakeEventStore)), lockFactory)
+
+				storeTeam, found, err := storeTeamFactory.FindTeam(defaultTeam.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				_, err = storeTeam.SearchBuildLogs(search)
+				Expect(err).To(Equal(db.ErrBuildEventsNotInPostgres))
+			})
+		})
+	})
+
+	Describe("Semaphores", func() {
+		var (
+			stagingConfig atc.SemaphoreConfig
+
+			job1, job2 db.Job
+		)
+
+		BeforeEach(func() {
+			stagingConfig = atc.SemaphoreConfig{Name: "staging"}
+
+			for i, pipelineName := range []string{"some-pipeline", "some-other-pipeline"} {
+				pipeline, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: pipelineName}, atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "deploy",
+							Semaphores: []atc.SemaphoreConfig{
+								{Name: "staging", RawCapacity: i + 1},
+							},
+						},
+					},
+				}, db.ConfigVersion(0), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				job, found, err := pipeline.Job("deploy")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				if i == 0 {
+					job1 = job
+				} else {
+					job2 = job
+				}
+			}
+		})
+
+		It("lists the semaphores declared by the team's jobs with their smallest capacity", func() {
+			semaphores, err := defaultTeam.Semaphores()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(semaphores).To(HaveLen(1))
+			Expect(semaphores[0].Name).To(Equal("staging"))
+			Expect(semaphores[0].Capacity).To(Equal(1))
+			Expect(semaphores[0].Holders).To(BeEmpty())
+			Expect(semaphores[0].Waiting).To(BeEmpty())
+		})
+
+		Context("when builds of jobs across pipelines contend for a semaphore", func() {
+			var build1, build2 db.Build
+
+			BeforeEach(func() {
+				var err error
+				build1, err = job1.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				build2, err = job2.CreateBuild(defaultBuildCreatedBy)
+				Expect(err).ToNot(HaveOccurred())
+
+				acquired, err := build1.Schedule([]atc.SemaphoreConfig{stagingConfig})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+
+			It("does not let another build acquire it beyond its capacity", func() {
+				acquired, err := build2.Schedule([]atc.SemaphoreConfig{stagingConfig})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeFalse())
+			})
+
+			It("leaves a build which cannot acquire it unscheduled", func() {
+				_, err := build2.Schedule([]atc.SemaphoreConfig{stagingConfig})
+				Expect(err).ToNot(HaveOccurred())
+
+				found, err := build2.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build2.IsScheduled()).To(BeFalse())
+
+				found, err = build1.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build1.IsScheduled()).To(BeTrue())
+			})
+
+			It("lets another build acquire it within its capacity", func() {
+				acquired, err := build2.Schedule([]atc.SemaphoreConfig{{Name: "staging", RawCapacity: 2}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+
+			It("lets the holder acquire it again", func() {
+				acquired, err := build1.Schedule([]atc.SemaphoreConfig{stagingConfig})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+
+			It("does not acquire any semaphore when one is unavailable", func() {
+				acquired, err := build2.Schedule([]atc.SemaphoreConfig{{Name: "production"}, stagingConfig})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeFalse())
+
+				semaphores, err := defaultTeam.Semaphores()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(semaphores).To(HaveLen(1))
+				Expect(semaphores[0].Name).To(Equal("staging"))
+			})
+
+			It("shows the holder and the waiting builds", func() {
+				semaphores, err := defaultTeam.Semaphores()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(semaphores).To(HaveLen(1))
+
+				Expect(semaphores[0].Holders).To(HaveLen(1))
+				Expect(semaphores[0].Holders[0].ID()).To(Equal(build1.ID()))
+
+				Expect(semaphores[0].Waiting).To(HaveLen(1))
+				Expect(semaphores[0].Waiting[0].ID()).To(Equal(build2.ID()))
+			})
+
+			Context("when the holder finishes", func() {
+				BeforeEach(func() {
+					err := build1.Finish(db.BuildStatusSucceeded)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("releases the semaphore", func() {
+					acquired, err := build2.Schedule([]atc.SemaphoreConfig{stagingConfig})
+					Expect(err).ToNot(HaveOccurred())
+					Expect(acquired).To(BeTrue())
+				})
+			})
+
+			It("does not share the semaphore with other teams", func() {
+				otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+				Expect(err).ToNot(HaveOccurred())
+
+				otherBuild, err := otherTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				acquired, err := otherBuild.Schedule([]atc.SemaphoreConfig{stagingConfig})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(acquired).To(BeTrue())
+			})
+		})
+	})
 })
@@ -54,7 +54,8 @@ var workersQuery = psql.Select(`
 		w.team_id,
 		w.start_time,
 		w.expires,
-		w.ephemeral
+		w.ephemeral,
+		w.runtime
 	`).
 	From("workers w").
 	LeftJoin("teams t ON w.team_id = t.id")
@@ -140,6 +141,7 @@ func scanWorker(worker *worker, row scannable) error {
 		startTime     pq.NullTime
 		expiresAt     pq.NullTime
 		ephemeral     sql.NullBool
+		runtime       sql.NullString
 	)
 
 	err := row.Scan(
@@ -162,6 +164,7 @@ func scanWorker(worker *worker, row scannable) error {
 		&startTime,
 		&expiresAt,
 		&ephemeral,
+		&runtime,
 	)
 	if err != nil {
 		return err
@@ -215,6 +218,10 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.ephemeral = ephemeral.Bool
 	}
 
+	if runtime.Valid {
+		worker.runtime = runtime.String
+	}
+
 	err = json.Unmarshal(resourceTypes, &worker.resourceTypes)
 	if err != nil {
 		return err
@@ -411,6 +418,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		string(workerState),
 		teamID,
 		atcWorker.Ephemeral,
+		atcWorker.Runtime,
 	}
 
 	conflictValues := values
@@ -442,6 +450,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"state",
 			"team_id",
 			"ephemeral",
+			"runtime",
 		).
 		Values(append([]interface{}{
 			sq.Expr(expires),
@@ -466,7 +475,8 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				version = ?,
 				state = ?,
 				team_id = ?,
-				ephemeral = ?
+				ephemeral = ?,
+				runtime = ?
 			WHERE `+matchTeamUpsert,
 			conflictValues...,
 		).
@@ -509,6 +519,7 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		teamID:           workerTeamID,
 		startTime:        time.Unix(atcWorker.StartTime, 0),
 		ephemeral:        atcWorker.Ephemeral,
+		runtime:          atcWorker.Runtime,
 		conn:             conn,
 	}
 
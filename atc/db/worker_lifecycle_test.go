@@ -224,7 +224,7 @@ var _ = Describe("Worker Lifecycle", func() {
 
 			Context("when worker has build with uninterruptible job", func() {
 				BeforeEach(func() {
-					pipeline, created, err := defaultTeam.SavePipeline("some-pipeline", atc.Config{
+					pipeline, created, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 						Jobs: atc.JobConfigs{
 							{
 								Name:          "some-job",
@@ -239,7 +239,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					dbBuild, err = job.CreateBuild()
+					dbBuild, err = job.CreateBuild(defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -256,7 +256,7 @@ var _ = Describe("Worker Lifecycle", func() {
 
 			Context("when worker has build with interruptible job", func() {
 				BeforeEach(func() {
-					pipeline, created, err := defaultTeam.SavePipeline("some-pipeline", atc.Config{
+					pipeline, created, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 						Jobs: atc.JobConfigs{
 							{
 								Name:          "some-job",
@@ -271,7 +271,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					dbBuild, err = job.CreateBuild()
+					dbBuild, err = job.CreateBuild(defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -471,7 +471,7 @@ var _ = Describe("Worker Lifecycle", func() {
 
 			Context("when worker has build with uninterruptible job", func() {
 				BeforeEach(func() {
-					pipeline, created, err := defaultTeam.SavePipeline("some-pipeline", atc.Config{
+					pipeline, created, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 						Jobs: atc.JobConfigs{
 							{
 								Name:          "some-job",
@@ -486,7 +486,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					dbBuild, err = job.CreateBuild()
+					dbBuild, err = job.CreateBuild(defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 				})
 
@@ -503,7 +503,7 @@ var _ = Describe("Worker Lifecycle", func() {
 
 			Context("when worker has build with interruptible job", func() {
 				BeforeEach(func() {
-					pipeline, created, err := defaultTeam.SavePipeline("some-pipeline", atc.Config{
+					pipeline, created, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, atc.Config{
 						Jobs: atc.JobConfigs{
 							{
 								Name:          "some-job",
@@ -518,7 +518,7 @@ var _ = Describe("Worker Lifecycle", func() {
 					Expect(err).ToNot(HaveOccurred())
 					Expect(found).To(BeTrue())
 
-					dbBuild, err = job.CreateBuild()
+					dbBuild, err = job.CreateBuild(defaultBuildCreatedBy)
 					Expect(err).ToNot(HaveOccurred())
 				})
 
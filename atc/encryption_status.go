@@ -0,0 +1,41 @@
+package atc
+
+// EncryptionStatus reports the progress of re-encrypting the data in the
+// database with the current encryption key.
+type EncryptionStatus struct {
+	// KeyID identifies the key data is encrypted with, and OldKeyIDs the keys
+	// data is being re-encrypted from.
+	KeyID     string   `json:"key_id,omitempty"`
+	OldKeyIDs []string `json:"old_key_ids,omitempty"`
+
+	Tables []EncryptedTableStatus `json:"tables"`
+}
+
+// EncryptedTableStatus counts the rows of an encrypted column by the key
+// they are encrypted with.
+type EncryptedTableStatus struct {
+	Table  string `json:"table"`
+	Column string `json:"column"`
+
+	// Plaintext rows are not encrypted at all, and Legacy rows are encrypted
+	// with a key that was not recorded.
+	Plaintext int            `json:"plaintext"`
+	Legacy    int            `json:"legacy"`
+	Keys      map[string]int `json:"keys"`
+
+	// Undecryptable rows could not be decrypted with any key, and are skipped.
+	Undecryptable int `json:"undecryptable,omitempty"`
+
+	// Remaining is the number of rows left to re-encrypt with the current key.
+	Remaining int `json:"remaining"`
+}
+
+// Remaining is the number of rows left to re-encrypt across all tables.
+func (status EncryptionStatus) Remaining() int {
+	remaining := 0
+	for _, table := range status.Tables {
+		remaining += table.Remaining
+	}
+
+	return remaining
+}
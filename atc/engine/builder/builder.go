@@ -1,6 +1,7 @@
 package builder
 
 import (
+	"context"
 	"errors"
 	"strconv"
 	"strings"
@@ -21,6 +22,9 @@ type StepFactory interface {
 	PutStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, exec.PutDelegate) exec.Step
 	TaskStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, exec.TaskDelegate) exec.Step
 	CheckStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, exec.CheckDelegate) exec.Step
+	SetPipelineStep(atc.Plan, exec.StepMetadata, exec.SetPipelineDelegate) exec.Step
+	LoadVarStep(atc.Plan, exec.StepMetadata, exec.LoadVarDelegate) exec.Step
+	ApprovalStep(atc.Plan, db.Build, exec.StepMetadata, exec.ApprovalDelegate) exec.Step
 	ArtifactInputStep(atc.Plan, db.Build, exec.BuildStepDelegate) exec.Step
 	ArtifactOutputStep(atc.Plan, db.Build, exec.BuildStepDelegate) exec.Step
 }
@@ -32,6 +36,9 @@ type DelegateFactory interface {
 	PutDelegate(db.Build, atc.PlanID, vars.CredVarsTracker) exec.PutDelegate
 	TaskDelegate(db.Build, atc.PlanID, vars.CredVarsTracker) exec.TaskDelegate
 	CheckDelegate(db.Check, atc.PlanID, vars.CredVarsTracker) exec.CheckDelegate
+	SetPipelineDelegate(db.Build, atc.PlanID, vars.CredVarsTracker) exec.SetPipelineDelegate
+	LoadVarDelegate(db.Build, atc.PlanID, vars.CredVarsTracker) exec.LoadVarDelegate
+	ApprovalDelegate(db.Build, atc.PlanID, vars.CredVarsTracker) exec.ApprovalDelegate
 	BuildStepDelegate(db.Build, atc.PlanID, vars.CredVarsTracker) exec.BuildStepDelegate
 }
 
@@ -40,6 +47,7 @@ func NewStepBuilder(
 	delegateFactory DelegateFactory,
 	externalURL string,
 	secrets creds.Secrets,
+	varSourcePool creds.VarSourcePool,
 	redactSecrets bool,
 ) *stepBuilder {
 	return &stepBuilder{
@@ -47,6 +55,7 @@ func NewStepBuilder(
 		delegateFactory: delegateFactory,
 		externalURL:     externalURL,
 		secrets:         secrets,
+		varSourcePool:   varSourcePool,
 		redactSecrets:   redactSecrets,
 	}
 }
@@ -56,6 +65,7 @@ type stepBuilder struct {
 	delegateFactory DelegateFactory
 	externalURL     string
 	secrets         creds.Secrets
+	varSourcePool   creds.VarSourcePool
 	redactSecrets   bool
 }
 
@@ -68,8 +78,22 @@ func (builder *stepBuilder) BuildStep(build db.Build) (exec.Step, error) {
 		return exec.IdentityStep{}, errors.New("Schema not supported")
 	}
 
-	credVarsTracker := vars.NewCredVarsTracker(creds.NewVariables(builder.secrets, build.TeamName(), build.PipelineName()), builder.redactSecrets)
-	return builder.buildStep(build, build.PrivatePlan(), credVarsTracker), nil
+	access := atc.SecretAccess{
+		TeamName:     build.TeamName(),
+		PipelineName: build.PipelineName(),
+		JobName:      build.JobName(),
+		BuildID:      build.ID(),
+	}
+
+	leases := creds.NewLeases(creds.WithSecretAccess(builder.secrets, access))
+
+	variables, err := builder.variables(leases, access, build.Pipeline)
+	if err != nil {
+		return exec.IdentityStep{}, err
+	}
+
+	credVarsTracker := vars.NewCredVarsTracker(variables, builder.redactSecrets)
+	return leasedStep{builder.buildStep(build, build.PrivatePlan(), credVarsTracker), leases}, nil
 }
 
 func (builder *stepBuilder) CheckStep(check db.Check) (exec.Step, error) {
@@ -82,8 +106,56 @@ func (builder *stepBuilder) CheckStep(check db.Check) (exec.Step, error) {
 		return exec.IdentityStep{}, errors.New("Schema not supported")
 	}
 
-	credVarsTracker := vars.NewCredVarsTracker(creds.NewVariables(builder.secrets, check.TeamName(), check.PipelineName()), builder.redactSecrets)
-	return builder.buildCheckStep(check, check.Plan(), credVarsTracker), nil
+	access := atc.SecretAccess{
+		TeamName:     check.TeamName(),
+		PipelineName: check.PipelineName(),
+	}
+
+	leases := creds.NewLeases(creds.WithSecretAccess(builder.secrets, access))
+
+	variables, err := builder.variables(leases, access, check.Pipeline)
+	if err != nil {
+		return exec.IdentityStep{}, err
+	}
+
+	credVarsTracker := vars.NewCredVarsTracker(variables, builder.redactSecrets)
+	return leasedStep{builder.buildCheckStep(check, check.Plan(), credVarsTracker), leases}, nil
+}
+
+// variables returns the vars of the build or check, including those of the
+// var sources declared by its pipeline, if any. Each lookup of a secret in a
+// var source is audited as the given access.
+func (builder *stepBuilder) variables(secrets creds.Secrets, access atc.SecretAccess, pipeline func() (db.Pipeline, bool, error)) (vars.Variables, error) {
+	var varSources atc.VarSourceConfigs
+
+	dbPipeline, found, err := pipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		varSources = dbPipeline.VarSources()
+	}
+
+	return creds.NewPipelineVariables(
+		secrets,
+		creds.WithVarSourceAccess(builder.varSourcePool, access),
+		access.TeamName,
+		access.PipelineName,
+		varSources,
+	), nil
+}
+
+// leasedStep revokes the leases of the secrets used by the build or check once
+// it has finished running.
+type leasedStep struct {
+	exec.Step
+	leases creds.Leases
+}
+
+func (step leasedStep) Run(ctx context.Context, state exec.RunState) error {
+	defer step.leases.RevokeAll()
+	return step.Step.Run(ctx, state)
 }
 
 func (builder *stepBuilder) buildStep(build db.Build, plan atc.Plan, credVarsTracker vars.CredVarsTracker) exec.Step {
@@ -95,6 +167,10 @@ func (builder *stepBuilder) buildStep(build db.Build, plan atc.Plan, credVarsTra
 		return builder.buildParallelStep(build, plan, credVarsTracker)
 	}
 
+	if plan.Across != nil {
+		return builder.buildAcrossStep(build, plan, credVarsTracker)
+	}
+
 	if plan.Do != nil {
 		return builder.buildDoStep(build, plan, credVarsTracker)
 	}
@@ -139,6 +215,18 @@ func (builder *stepBuilder) buildStep(build db.Build, plan atc.Plan, credVarsTra
 		return builder.buildPutStep(build, plan, credVarsTracker)
 	}
 
+	if plan.SetPipeline != nil {
+		return builder.buildSetPipelineStep(build, plan, credVarsTracker)
+	}
+
+	if plan.LoadVar != nil {
+		return builder.buildLoadVarStep(build, plan, credVarsTracker)
+	}
+
+	if plan.Approval != nil {
+		return builder.buildApprovalStep(build, plan, credVarsTracker)
+	}
+
 	if plan.Retry != nil {
 		return builder.buildRetryStep(build, plan, credVarsTracker)
 	}
@@ -180,6 +268,25 @@ func (builder *stepBuilder) buildParallelStep(build db.Build, plan atc.Plan, cre
 	return exec.InParallel(steps, plan.InParallel.Limit, plan.InParallel.FailFast)
 }
 
+func (builder *stepBuilder) buildAcrossStep(build db.Build, plan atc.Plan, credVarsTracker vars.CredVarsTracker) exec.Step {
+
+	var steps []exec.Step
+
+	for _, scopedPlan := range plan.Across.Steps {
+		scope := credVarsTracker.NewLocalScope()
+		for i, acrossVar := range plan.Across.Vars {
+			scope.AddLocalVar(acrossVar.Var, scopedPlan.Values[i], false)
+		}
+
+		innerPlan := scopedPlan.Step
+		innerPlan.Attempts = plan.Attempts
+		step := builder.buildStep(build, innerPlan, scope)
+		steps = append(steps, step)
+	}
+
+	return exec.Across(plan.Across.Vars, steps, plan.Across.FailFast)
+}
+
 func (builder *stepBuilder) buildDoStep(build db.Build, plan atc.Plan, credVarsTracker vars.CredVarsTracker) exec.Step {
 
 	var step exec.Step = exec.IdentityStep{}
@@ -351,6 +458,49 @@ func (builder *stepBuilder) buildTaskStep(build db.Build, plan atc.Plan, credVar
 	)
 }
 
+func (builder *stepBuilder) buildSetPipelineStep(build db.Build, plan atc.Plan, credVarsTracker vars.CredVarsTracker) exec.Step {
+
+	stepMetadata := builder.stepMetadata(
+		build,
+		builder.externalURL,
+	)
+
+	return builder.stepFactory.SetPipelineStep(
+		plan,
+		stepMetadata,
+		builder.delegateFactory.SetPipelineDelegate(build, plan.ID, credVarsTracker),
+	)
+}
+
+func (builder *stepBuilder) buildLoadVarStep(build db.Build, plan atc.Plan, credVarsTracker vars.CredVarsTracker) exec.Step {
+
+	stepMetadata := builder.stepMetadata(
+		build,
+		builder.externalURL,
+	)
+
+	return builder.stepFactory.LoadVarStep(
+		plan,
+		stepMetadata,
+		builder.delegateFactory.LoadVarDelegate(build, plan.ID, credVarsTracker),
+	)
+}
+
+func (builder *stepBuilder) buildApprovalStep(build db.Build, plan atc.Plan, credVarsTracker vars.CredVarsTracker) exec.Step {
+
+	stepMetadata := builder.stepMetadata(
+		build,
+		builder.externalURL,
+	)
+
+	return builder.stepFactory.ApprovalStep(
+		plan,
+		build,
+		stepMetadata,
+		builder.delegateFactory.ApprovalDelegate(build, plan.ID, credVarsTracker),
+	)
+}
+
 func (builder *stepBuilder) buildArtifactInputStep(build db.Build, plan atc.Plan, credVarsTracker vars.CredVarsTracker) exec.Step {
 
 	return builder.stepFactory.ArtifactInputStep(
@@ -401,14 +551,15 @@ func (builder *stepBuilder) stepMetadata(
 	externalURL string,
 ) exec.StepMetadata {
 	return exec.StepMetadata{
-		BuildID:      build.ID(),
-		BuildName:    build.Name(),
-		TeamID:       build.TeamID(),
-		TeamName:     build.TeamName(),
-		JobID:        build.JobID(),
-		JobName:      build.JobName(),
-		PipelineID:   build.PipelineID(),
-		PipelineName: build.PipelineName(),
-		ExternalURL:  externalURL,
+		BuildID:       build.ID(),
+		BuildName:     build.Name(),
+		TeamID:        build.TeamID(),
+		TeamName:      build.TeamName(),
+		JobID:         build.JobID(),
+		JobName:       build.JobName(),
+		PipelineID:    build.PipelineID(),
+		PipelineName:  build.PipelineName(),
+		ExternalURL:   externalURL,
+		BuildPriority: build.Priority(),
 	}
 }
@@ -1,16 +1,22 @@
 package builder_test
 
 import (
+	"context"
+	"errors"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/engine/builder"
 	"github.com/concourse/concourse/atc/engine/builder/builderfakes"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/vars"
 )
 
 type StepBuilder interface {
@@ -28,6 +34,8 @@ var _ = Describe("Builder", func() {
 			fakeStepFactory     *builderfakes.FakeStepFactory
 			fakeDelegateFactory *builderfakes.FakeDelegateFactory
 			fakeSecretManager   *credsfakes.FakeSecrets
+			fakeVarSourcePool   *credsfakes.FakeVarSourcePool
+			fakeSecretAuditor   *credsfakes.FakeSecretAccessAuditor
 
 			planFactory atc.PlanFactory
 			stepBuilder StepBuilder
@@ -37,12 +45,15 @@ var _ = Describe("Builder", func() {
 			fakeStepFactory = new(builderfakes.FakeStepFactory)
 			fakeDelegateFactory = new(builderfakes.FakeDelegateFactory)
 			fakeSecretManager = new(credsfakes.FakeSecrets)
+			fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
+			fakeSecretAuditor = new(credsfakes.FakeSecretAccessAuditor)
 
 			stepBuilder = builder.NewStepBuilder(
 				fakeStepFactory,
 				fakeDelegateFactory,
 				"http://example.com",
-				fakeSecretManager,
+				creds.NewAuditedSecrets(fakeSecretManager, fakeSecretAuditor, atc.SecretAccess{Manager: "some-manager"}),
+				creds.NewAuditedVarSourcePool(fakeVarSourcePool, fakeSecretAuditor, atc.SecretAccess{}),
 				false,
 			)
 
@@ -62,6 +73,7 @@ var _ = Describe("Builder", func() {
 		Context("with a build", func() {
 			var (
 				fakeBuild *dbfakes.FakeBuild
+				step      exec.Step
 
 				expectedPlan     atc.Plan
 				expectedMetadata exec.StepMetadata
@@ -77,24 +89,26 @@ var _ = Describe("Builder", func() {
 				fakeBuild.PipelineIDReturns(2222)
 				fakeBuild.TeamNameReturns("some-team")
 				fakeBuild.TeamIDReturns(1111)
+				fakeBuild.PriorityReturns(5)
 
 				expectedMetadata = exec.StepMetadata{
-					BuildID:      4444,
-					BuildName:    "42",
-					TeamID:       1111,
-					TeamName:     "some-team",
-					JobID:        3333,
-					JobName:      "some-job",
-					PipelineID:   2222,
-					PipelineName: "some-pipeline",
-					ExternalURL:  "http://example.com",
+					BuildID:       4444,
+					BuildName:     "42",
+					TeamID:        1111,
+					TeamName:      "some-team",
+					JobID:         3333,
+					JobName:       "some-job",
+					PipelineID:    2222,
+					PipelineName:  "some-pipeline",
+					ExternalURL:   "http://example.com",
+					BuildPriority: 5,
 				}
 			})
 
 			JustBeforeEach(func() {
 				fakeBuild.PrivatePlanReturns(expectedPlan)
 
-				_, err = stepBuilder.BuildStep(fakeBuild)
+				step, err = stepBuilder.BuildStep(fakeBuild)
 			})
 
 			Context("when the build has the wrong schema", func() {
@@ -116,6 +130,155 @@ var _ = Describe("Builder", func() {
 					Expect(err).NotTo(HaveOccurred())
 				})
 
+				Context("when looking up the build's pipeline fails", func() {
+					BeforeEach(func() {
+						fakeBuild.PipelineReturns(nil, false, errors.New("nope"))
+					})
+
+					It("errors", func() {
+						Expect(err).To(MatchError("nope"))
+					})
+				})
+
+				Context("when the build's pipeline declares var sources", func() {
+					var fakeVarSourceSecrets *credsfakes.FakeSecrets
+
+					BeforeEach(func() {
+						fakePipeline := new(dbfakes.FakePipeline)
+						fakePipeline.VarSourcesReturns(atc.VarSourceConfigs{
+							{
+								Name:   "some-source",
+								Type:   "some-type",
+								Config: map[string]interface{}{"some": "config"},
+							},
+						})
+						fakeBuild.PipelineReturns(fakePipeline, true, nil)
+
+						fakeVarSourceSecrets = new(credsfakes.FakeSecrets)
+						fakeVarSourceSecrets.GetReturns("some-value", nil, true, nil)
+						fakeVarSourcePool.FindOrCreateReturns(fakeVarSourceSecrets, nil)
+
+						expectedPlan = planFactory.NewPlan(atc.TaskPlan{Name: "some-task"})
+					})
+
+					It("looks up the vars of a var source in it", func() {
+						Expect(fakeDelegateFactory.TaskDelegateCallCount()).To(Equal(1))
+
+						_, _, credVarsTracker := fakeDelegateFactory.TaskDelegateArgsForCall(0)
+						val, found, err := credVarsTracker.Get(vars.VariableDefinition{Source: "some-source", Name: "some-var"})
+						Expect(err).ToNot(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(val).To(Equal("some-value"))
+
+						Expect(fakeVarSourcePool.FindOrCreateCallCount()).To(Equal(1))
+						Expect(fakeVarSourcePool.FindOrCreateArgsForCall(0).Name).To(Equal("some-source"))
+						Expect(fakeVarSourceSecrets.GetArgsForCall(0)).To(Equal("some-var"))
+					})
+
+					It("audits lookups in the var source as the build", func() {
+						_, _, credVarsTracker := fakeDelegateFactory.TaskDelegateArgsForCall(0)
+						_, _, err := credVarsTracker.Get(vars.VariableDefinition{Source: "some-source", Name: "some-var"})
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(fakeSecretAuditor.AuditSecretAccessCallCount()).To(Equal(1))
+
+						access := fakeSecretAuditor.AuditSecretAccessArgsForCall(0)
+						Expect(access.Manager).To(Equal("some-type"))
+						Expect(access.Source).To(Equal("some-source"))
+						Expect(access.BuildID).To(Equal(4444))
+						Expect(access.Variable).To(Equal("some-var"))
+					})
+				})
+
+				Context("when the build looks up a secret", func() {
+					BeforeEach(func() {
+						fakeSecretManager.GetReturns("some-value", nil, true, nil)
+
+						expectedPlan = planFactory.NewPlan(atc.TaskPlan{Name: "some-task"})
+					})
+
+					It("audits the lookup as the build", func() {
+						_, _, credVarsTracker := fakeDelegateFactory.TaskDelegateArgsForCall(0)
+						_, found, err := credVarsTracker.Get(vars.VariableDefinition{Name: "some-var"})
+						Expect(err).ToNot(HaveOccurred())
+						Expect(found).To(BeTrue())
+
+						Expect(fakeSecretAuditor.AuditSecretAccessCallCount()).To(Equal(1))
+
+						access := fakeSecretAuditor.AuditSecretAccessArgsForCall(0)
+						Expect(access.TeamName).To(Equal("some-team"))
+						Expect(access.PipelineName).To(Equal("some-pipeline"))
+						Expect(access.JobName).To(Equal("some-job"))
+						Expect(access.BuildID).To(Equal(4444))
+						Expect(access.Variable).To(Equal("some-var"))
+						Expect(access.Manager).To(Equal("some-manager"))
+						Expect(access.Found).To(BeTrue())
+					})
+				})
+
+				Context("when the secrets hand out leases", func() {
+					var (
+						fakeLeasingSecrets *credsfakes.FakeLeasingSecrets
+						fakeLeases         *credsfakes.FakeLeases
+						fakeTaskStep       *execfakes.FakeStep
+					)
+
+					BeforeEach(func() {
+						fakeLeasingSecrets = new(credsfakes.FakeLeasingSecrets)
+						fakeLeases = new(credsfakes.FakeLeases)
+						fakeLeases.GetReturns("some-leased-value", nil, true, nil)
+						fakeLeasingSecrets.NewLeasesReturns(fakeLeases)
+
+						stepBuilder = builder.NewStepBuilder(
+							fakeStepFactory,
+							fakeDelegateFactory,
+							"http://example.com",
+							creds.NewAuditedSecrets(fakeLeasingSecrets, fakeSecretAuditor, atc.SecretAccess{Manager: "some-manager"}),
+							creds.NewAuditedVarSourcePool(fakeVarSourcePool, fakeSecretAuditor, atc.SecretAccess{}),
+							false,
+						)
+
+						fakeTaskStep = new(execfakes.FakeStep)
+						fakeStepFactory.TaskStepReturns(fakeTaskStep)
+
+						expectedPlan = planFactory.NewPlan(atc.TaskPlan{Name: "some-task"})
+					})
+
+					It("looks up the build's vars in its own leases", func() {
+						Expect(fakeLeasingSecrets.NewLeasesCallCount()).To(Equal(1))
+
+						_, _, credVarsTracker := fakeDelegateFactory.TaskDelegateArgsForCall(0)
+						val, found, err := credVarsTracker.Get(vars.VariableDefinition{Name: "some-var"})
+						Expect(err).ToNot(HaveOccurred())
+						Expect(found).To(BeTrue())
+						Expect(val).To(Equal("some-leased-value"))
+
+						Expect(fakeLeasingSecrets.GetCallCount()).To(BeZero())
+					})
+
+					It("audits lookups in the leases as the build", func() {
+						_, _, credVarsTracker := fakeDelegateFactory.TaskDelegateArgsForCall(0)
+						_, _, err := credVarsTracker.Get(vars.VariableDefinition{Name: "some-var"})
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(fakeSecretAuditor.AuditSecretAccessCallCount()).To(Equal(1))
+
+						access := fakeSecretAuditor.AuditSecretAccessArgsForCall(0)
+						Expect(access.BuildID).To(Equal(4444))
+						Expect(access.Manager).To(Equal("some-manager"))
+					})
+
+					It("revokes the leases once the build has run", func() {
+						Expect(fakeLeases.RevokeAllCallCount()).To(BeZero())
+
+						err := step.Run(context.Background(), exec.NewRunState())
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(fakeTaskStep.RunCallCount()).To(Equal(1))
+						Expect(fakeLeases.RevokeAllCallCount()).To(Equal(1))
+					})
+				})
+
 				Context("with a putget in an aggregate", func() {
 					var (
 						putPlan               atc.Plan
@@ -520,6 +683,121 @@ var _ = Describe("Builder", func() {
 						})
 					})
 
+					Context("that contains set_pipeline steps", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.SetPipelinePlan{
+								Name:     "some-pipeline",
+								File:     "some-input/pipeline.yml",
+								Vars:     atc.Params{"foo": "bar"},
+								VarFiles: []string{"some-input/vars.yml"},
+							})
+						})
+
+						It("constructs set_pipeline steps correctly", func() {
+							Expect(fakeStepFactory.SetPipelineStepCallCount()).To(Equal(1))
+							plan, stepMetadata, _ := fakeStepFactory.SetPipelineStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan))
+							Expect(stepMetadata).To(Equal(expectedMetadata))
+						})
+
+						It("constructs the delegate for the plan", func() {
+							Expect(fakeDelegateFactory.SetPipelineDelegateCallCount()).To(Equal(1))
+							build, planID, _ := fakeDelegateFactory.SetPipelineDelegateArgsForCall(0)
+							Expect(build).To(Equal(fakeBuild))
+							Expect(planID).To(Equal(expectedPlan.ID))
+						})
+					})
+
+					Context("that contains load_var steps", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.LoadVarPlan{
+								Name: "some-var",
+								File: "some-input/version",
+							})
+						})
+
+						It("constructs load_var steps correctly", func() {
+							Expect(fakeStepFactory.LoadVarStepCallCount()).To(Equal(1))
+							plan, stepMetadata, _ := fakeStepFactory.LoadVarStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan))
+							Expect(stepMetadata).To(Equal(expectedMetadata))
+						})
+
+						It("constructs the delegate for the plan", func() {
+							Expect(fakeDelegateFactory.LoadVarDelegateCallCount()).To(Equal(1))
+							build, planID, _ := fakeDelegateFactory.LoadVarDelegateArgsForCall(0)
+							Expect(build).To(Equal(fakeBuild))
+							Expect(planID).To(Equal(expectedPlan.ID))
+						})
+					})
+
+					Context("that contains approval steps", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.ApprovalPlan{
+								Name:    "deploy",
+								Timeout: "1h",
+							})
+						})
+
+						It("constructs approval steps correctly", func() {
+							Expect(fakeStepFactory.ApprovalStepCallCount()).To(Equal(1))
+							plan, build, stepMetadata, _ := fakeStepFactory.ApprovalStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan))
+							Expect(build).To(Equal(fakeBuild))
+							Expect(stepMetadata).To(Equal(expectedMetadata))
+						})
+
+						It("constructs the delegate for the plan", func() {
+							Expect(fakeDelegateFactory.ApprovalDelegateCallCount()).To(Equal(1))
+							build, planID, _ := fakeDelegateFactory.ApprovalDelegateArgsForCall(0)
+							Expect(build).To(Equal(fakeBuild))
+							Expect(planID).To(Equal(expectedPlan.ID))
+						})
+					})
+
+					Context("that contains an across step", func() {
+						var taskPlans []atc.Plan
+
+						BeforeEach(func() {
+							taskPlans = []atc.Plan{
+								planFactory.NewPlan(atc.TaskPlan{Name: "some-task"}),
+								planFactory.NewPlan(atc.TaskPlan{Name: "some-task"}),
+							}
+
+							expectedPlan = planFactory.NewPlan(atc.AcrossPlan{
+								Vars: []atc.AcrossVar{
+									{Var: "some-var", Values: []interface{}{"a", "b"}},
+								},
+								Steps: []atc.VarScopedPlan{
+									{Step: taskPlans[0], Values: []interface{}{"a"}},
+									{Step: taskPlans[1], Values: []interface{}{"b"}},
+								},
+							})
+						})
+
+						It("constructs a step for each combination", func() {
+							Expect(fakeStepFactory.TaskStepCallCount()).To(Equal(2))
+
+							plan, _, _, _ := fakeStepFactory.TaskStepArgsForCall(0)
+							Expect(plan).To(Equal(taskPlans[0]))
+
+							plan, _, _, _ = fakeStepFactory.TaskStepArgsForCall(1)
+							Expect(plan).To(Equal(taskPlans[1]))
+						})
+
+						It("exposes the combination's values as local vars", func() {
+							Expect(fakeDelegateFactory.TaskDelegateCallCount()).To(Equal(2))
+
+							for i, value := range []string{"a", "b"} {
+								_, _, credVarsTracker := fakeDelegateFactory.TaskDelegateArgsForCall(i)
+								val, found, err := credVarsTracker.Get(vars.VariableDefinition{Source: vars.LocalVarSource, Name: "some-var"})
+								Expect(err).ToNot(HaveOccurred())
+								Expect(found).To(BeTrue())
+								Expect(val).To(Equal(value))
+							}
+						})
+					})
+
 					Context("that contains outputs", func() {
 						var (
 							putPlan          atc.Plan
@@ -761,6 +1039,8 @@ var _ = Describe("Builder", func() {
 			fakeStepFactory     *builderfakes.FakeStepFactory
 			fakeDelegateFactory *builderfakes.FakeDelegateFactory
 			fakeSecretManager   *credsfakes.FakeSecrets
+			fakeVarSourcePool   *credsfakes.FakeVarSourcePool
+			fakeSecretAuditor   *credsfakes.FakeSecretAccessAuditor
 
 			planFactory atc.PlanFactory
 			stepBuilder StepBuilder
@@ -770,12 +1050,15 @@ var _ = Describe("Builder", func() {
 			fakeStepFactory = new(builderfakes.FakeStepFactory)
 			fakeDelegateFactory = new(builderfakes.FakeDelegateFactory)
 			fakeSecretManager = new(credsfakes.FakeSecrets)
+			fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
+			fakeSecretAuditor = new(credsfakes.FakeSecretAccessAuditor)
 
 			stepBuilder = builder.NewStepBuilder(
 				fakeStepFactory,
 				fakeDelegateFactory,
 				"http://example.com",
-				fakeSecretManager,
+				creds.NewAuditedSecrets(fakeSecretManager, fakeSecretAuditor, atc.SecretAccess{Manager: "some-manager"}),
+				creds.NewAuditedVarSourcePool(fakeVarSourcePool, fakeSecretAuditor, atc.SecretAccess{}),
 				false,
 			)
 
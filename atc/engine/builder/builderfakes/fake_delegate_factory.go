@@ -12,6 +12,19 @@ import (
 )
 
 type FakeDelegateFactory struct {
+	ApprovalDelegateStub        func(db.Build, atc.PlanID, vars.CredVarsTracker) exec.ApprovalDelegate
+	approvalDelegateMutex       sync.RWMutex
+	approvalDelegateArgsForCall []struct {
+		arg1 db.Build
+		arg2 atc.PlanID
+		arg3 vars.CredVarsTracker
+	}
+	approvalDelegateReturns struct {
+		result1 exec.ApprovalDelegate
+	}
+	approvalDelegateReturnsOnCall map[int]struct {
+		result1 exec.ApprovalDelegate
+	}
 	BuildStepDelegateStub        func(db.Build, atc.PlanID, vars.CredVarsTracker) exec.BuildStepDelegate
 	buildStepDelegateMutex       sync.RWMutex
 	buildStepDelegateArgsForCall []struct {
@@ -51,6 +64,19 @@ type FakeDelegateFactory struct {
 	getDelegateReturnsOnCall map[int]struct {
 		result1 exec.GetDelegate
 	}
+	LoadVarDelegateStub        func(db.Build, atc.PlanID, vars.CredVarsTracker) exec.LoadVarDelegate
+	loadVarDelegateMutex       sync.RWMutex
+	loadVarDelegateArgsForCall []struct {
+		arg1 db.Build
+		arg2 atc.PlanID
+		arg3 vars.CredVarsTracker
+	}
+	loadVarDelegateReturns struct {
+		result1 exec.LoadVarDelegate
+	}
+	loadVarDelegateReturnsOnCall map[int]struct {
+		result1 exec.LoadVarDelegate
+	}
 	PutDelegateStub        func(db.Build, atc.PlanID, vars.CredVarsTracker) exec.PutDelegate
 	putDelegateMutex       sync.RWMutex
 	putDelegateArgsForCall []struct {
@@ -64,6 +90,19 @@ type FakeDelegateFactory struct {
 	putDelegateReturnsOnCall map[int]struct {
 		result1 exec.PutDelegate
 	}
+	SetPipelineDelegateStub        func(db.Build, atc.PlanID, vars.CredVarsTracker) exec.SetPipelineDelegate
+	setPipelineDelegateMutex       sync.RWMutex
+	setPipelineDelegateArgsForCall []struct {
+		arg1 db.Build
+		arg2 atc.PlanID
+		arg3 vars.CredVarsTracker
+	}
+	setPipelineDelegateReturns struct {
+		result1 exec.SetPipelineDelegate
+	}
+	setPipelineDelegateReturnsOnCall map[int]struct {
+		result1 exec.SetPipelineDelegate
+	}
 	TaskDelegateStub        func(db.Build, atc.PlanID, vars.CredVarsTracker) exec.TaskDelegate
 	taskDelegateMutex       sync.RWMutex
 	taskDelegateArgsForCall []struct {
@@ -81,6 +120,68 @@ type FakeDelegateFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeDelegateFactory) ApprovalDelegate(arg1 db.Build, arg2 atc.PlanID, arg3 vars.CredVarsTracker) exec.ApprovalDelegate {
+	fake.approvalDelegateMutex.Lock()
+	ret, specificReturn := fake.approvalDelegateReturnsOnCall[len(fake.approvalDelegateArgsForCall)]
+	fake.approvalDelegateArgsForCall = append(fake.approvalDelegateArgsForCall, struct {
+		arg1 db.Build
+		arg2 atc.PlanID
+		arg3 vars.CredVarsTracker
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ApprovalDelegate", []interface{}{arg1, arg2, arg3})
+	fake.approvalDelegateMutex.Unlock()
+	if fake.ApprovalDelegateStub != nil {
+		return fake.ApprovalDelegateStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.approvalDelegateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeDelegateFactory) ApprovalDelegateCallCount() int {
+	fake.approvalDelegateMutex.RLock()
+	defer fake.approvalDelegateMutex.RUnlock()
+	return len(fake.approvalDelegateArgsForCall)
+}
+
+func (fake *FakeDelegateFactory) ApprovalDelegateCalls(stub func(db.Build, atc.PlanID, vars.CredVarsTracker) exec.ApprovalDelegate) {
+	fake.approvalDelegateMutex.Lock()
+	defer fake.approvalDelegateMutex.Unlock()
+	fake.ApprovalDelegateStub = stub
+}
+
+func (fake *FakeDelegateFactory) ApprovalDelegateArgsForCall(i int) (db.Build, atc.PlanID, vars.CredVarsTracker) {
+	fake.approvalDelegateMutex.RLock()
+	defer fake.approvalDelegateMutex.RUnlock()
+	argsForCall := fake.approvalDelegateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDelegateFactory) ApprovalDelegateReturns(result1 exec.ApprovalDelegate) {
+	fake.approvalDelegateMutex.Lock()
+	defer fake.approvalDelegateMutex.Unlock()
+	fake.ApprovalDelegateStub = nil
+	fake.approvalDelegateReturns = struct {
+		result1 exec.ApprovalDelegate
+	}{result1}
+}
+
+func (fake *FakeDelegateFactory) ApprovalDelegateReturnsOnCall(i int, result1 exec.ApprovalDelegate) {
+	fake.approvalDelegateMutex.Lock()
+	defer fake.approvalDelegateMutex.Unlock()
+	fake.ApprovalDelegateStub = nil
+	if fake.approvalDelegateReturnsOnCall == nil {
+		fake.approvalDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.ApprovalDelegate
+		})
+	}
+	fake.approvalDelegateReturnsOnCall[i] = struct {
+		result1 exec.ApprovalDelegate
+	}{result1}
+}
+
 func (fake *FakeDelegateFactory) BuildStepDelegate(arg1 db.Build, arg2 atc.PlanID, arg3 vars.CredVarsTracker) exec.BuildStepDelegate {
 	fake.buildStepDelegateMutex.Lock()
 	ret, specificReturn := fake.buildStepDelegateReturnsOnCall[len(fake.buildStepDelegateArgsForCall)]
@@ -267,6 +368,68 @@ func (fake *FakeDelegateFactory) GetDelegateReturnsOnCall(i int, result1 exec.Ge
 	}{result1}
 }
 
+func (fake *FakeDelegateFactory) LoadVarDelegate(arg1 db.Build, arg2 atc.PlanID, arg3 vars.CredVarsTracker) exec.LoadVarDelegate {
+	fake.loadVarDelegateMutex.Lock()
+	ret, specificReturn := fake.loadVarDelegateReturnsOnCall[len(fake.loadVarDelegateArgsForCall)]
+	fake.loadVarDelegateArgsForCall = append(fake.loadVarDelegateArgsForCall, struct {
+		arg1 db.Build
+		arg2 atc.PlanID
+		arg3 vars.CredVarsTracker
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("LoadVarDelegate", []interface{}{arg1, arg2, arg3})
+	fake.loadVarDelegateMutex.Unlock()
+	if fake.LoadVarDelegateStub != nil {
+		return fake.LoadVarDelegateStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.loadVarDelegateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeDelegateFactory) LoadVarDelegateCallCount() int {
+	fake.loadVarDelegateMutex.RLock()
+	defer fake.loadVarDelegateMutex.RUnlock()
+	return len(fake.loadVarDelegateArgsForCall)
+}
+
+func (fake *FakeDelegateFactory) LoadVarDelegateCalls(stub func(db.Build, atc.PlanID, vars.CredVarsTracker) exec.LoadVarDelegate) {
+	fake.loadVarDelegateMutex.Lock()
+	defer fake.loadVarDelegateMutex.Unlock()
+	fake.LoadVarDelegateStub = stub
+}
+
+func (fake *FakeDelegateFactory) LoadVarDelegateArgsForCall(i int) (db.Build, atc.PlanID, vars.CredVarsTracker) {
+	fake.loadVarDelegateMutex.RLock()
+	defer fake.loadVarDelegateMutex.RUnlock()
+	argsForCall := fake.loadVarDelegateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDelegateFactory) LoadVarDelegateReturns(result1 exec.LoadVarDelegate) {
+	fake.loadVarDelegateMutex.Lock()
+	defer fake.loadVarDelegateMutex.Unlock()
+	fake.LoadVarDelegateStub = nil
+	fake.loadVarDelegateReturns = struct {
+		result1 exec.LoadVarDelegate
+	}{result1}
+}
+
+func (fake *FakeDelegateFactory) LoadVarDelegateReturnsOnCall(i int, result1 exec.LoadVarDelegate) {
+	fake.loadVarDelegateMutex.Lock()
+	defer fake.loadVarDelegateMutex.Unlock()
+	fake.LoadVarDelegateStub = nil
+	if fake.loadVarDelegateReturnsOnCall == nil {
+		fake.loadVarDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.LoadVarDelegate
+		})
+	}
+	fake.loadVarDelegateReturnsOnCall[i] = struct {
+		result1 exec.LoadVarDelegate
+	}{result1}
+}
+
 func (fake *FakeDelegateFactory) PutDelegate(arg1 db.Build, arg2 atc.PlanID, arg3 vars.CredVarsTracker) exec.PutDelegate {
 	fake.putDelegateMutex.Lock()
 	ret, specificReturn := fake.putDelegateReturnsOnCall[len(fake.putDelegateArgsForCall)]
@@ -329,6 +492,68 @@ func (fake *FakeDelegateFactory) PutDelegateReturnsOnCall(i int, result1 exec.Pu
 	}{result1}
 }
 
+func (fake *FakeDelegateFactory) SetPipelineDelegate(arg1 db.Build, arg2 atc.PlanID, arg3 vars.CredVarsTracker) exec.SetPipelineDelegate {
+	fake.setPipelineDelegateMutex.Lock()
+	ret, specificReturn := fake.setPipelineDelegateReturnsOnCall[len(fake.setPipelineDelegateArgsForCall)]
+	fake.setPipelineDelegateArgsForCall = append(fake.setPipelineDelegateArgsForCall, struct {
+		arg1 db.Build
+		arg2 atc.PlanID
+		arg3 vars.CredVarsTracker
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SetPipelineDelegate", []interface{}{arg1, arg2, arg3})
+	fake.setPipelineDelegateMutex.Unlock()
+	if fake.SetPipelineDelegateStub != nil {
+		return fake.SetPipelineDelegateStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setPipelineDelegateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeDelegateFactory) SetPipelineDelegateCallCount() int {
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	return len(fake.setPipelineDelegateArgsForCall)
+}
+
+func (fake *FakeDelegateFactory) SetPipelineDelegateCalls(stub func(db.Build, atc.PlanID, vars.CredVarsTracker) exec.SetPipelineDelegate) {
+	fake.setPipelineDelegateMutex.Lock()
+	defer fake.setPipelineDelegateMutex.Unlock()
+	fake.SetPipelineDelegateStub = stub
+}
+
+func (fake *FakeDelegateFactory) SetPipelineDelegateArgsForCall(i int) (db.Build, atc.PlanID, vars.CredVarsTracker) {
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
+	argsForCall := fake.setPipelineDelegateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDelegateFactory) SetPipelineDelegateReturns(result1 exec.SetPipelineDelegate) {
+	fake.setPipelineDelegateMutex.Lock()
+	defer fake.setPipelineDelegateMutex.Unlock()
+	fake.SetPipelineDelegateStub = nil
+	fake.setPipelineDelegateReturns = struct {
+		result1 exec.SetPipelineDelegate
+	}{result1}
+}
+
+func (fake *FakeDelegateFactory) SetPipelineDelegateReturnsOnCall(i int, result1 exec.SetPipelineDelegate) {
+	fake.setPipelineDelegateMutex.Lock()
+	defer fake.setPipelineDelegateMutex.Unlock()
+	fake.SetPipelineDelegateStub = nil
+	if fake.setPipelineDelegateReturnsOnCall == nil {
+		fake.setPipelineDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.SetPipelineDelegate
+		})
+	}
+	fake.setPipelineDelegateReturnsOnCall[i] = struct {
+		result1 exec.SetPipelineDelegate
+	}{result1}
+}
+
 func (fake *FakeDelegateFactory) TaskDelegate(arg1 db.Build, arg2 atc.PlanID, arg3 vars.CredVarsTracker) exec.TaskDelegate {
 	fake.taskDelegateMutex.Lock()
 	ret, specificReturn := fake.taskDelegateReturnsOnCall[len(fake.taskDelegateArgsForCall)]
@@ -394,14 +619,20 @@ func (fake *FakeDelegateFactory) TaskDelegateReturnsOnCall(i int, result1 exec.T
 func (fake *FakeDelegateFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.approvalDelegateMutex.RLock()
+	defer fake.approvalDelegateMutex.RUnlock()
 	fake.buildStepDelegateMutex.RLock()
 	defer fake.buildStepDelegateMutex.RUnlock()
 	fake.checkDelegateMutex.RLock()
 	defer fake.checkDelegateMutex.RUnlock()
 	fake.getDelegateMutex.RLock()
 	defer fake.getDelegateMutex.RUnlock()
+	fake.loadVarDelegateMutex.RLock()
+	defer fake.loadVarDelegateMutex.RUnlock()
 	fake.putDelegateMutex.RLock()
 	defer fake.putDelegateMutex.RUnlock()
+	fake.setPipelineDelegateMutex.RLock()
+	defer fake.setPipelineDelegateMutex.RUnlock()
 	fake.taskDelegateMutex.RLock()
 	defer fake.taskDelegateMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
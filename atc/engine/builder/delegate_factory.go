@@ -39,6 +39,18 @@ func (delegate *delegateFactory) CheckDelegate(check db.Check, planID atc.PlanID
 	return NewCheckDelegate(check, planID, credVarsTracker, clock.NewClock())
 }
 
+func (delegate *delegateFactory) SetPipelineDelegate(build db.Build, planID atc.PlanID, credVarsTracker vars.CredVarsTracker) exec.SetPipelineDelegate {
+	return NewSetPipelineDelegate(build, planID, credVarsTracker, clock.NewClock())
+}
+
+func (delegate *delegateFactory) LoadVarDelegate(build db.Build, planID atc.PlanID, credVarsTracker vars.CredVarsTracker) exec.LoadVarDelegate {
+	return NewLoadVarDelegate(build, planID, credVarsTracker, clock.NewClock())
+}
+
+func (delegate *delegateFactory) ApprovalDelegate(build db.Build, planID atc.PlanID, credVarsTracker vars.CredVarsTracker) exec.ApprovalDelegate {
+	return NewApprovalDelegate(build, planID, credVarsTracker, clock.NewClock())
+}
+
 func (delegate *delegateFactory) BuildStepDelegate(build db.Build, planID atc.PlanID, credVarsTracker vars.CredVarsTracker) exec.BuildStepDelegate {
 	return NewBuildStepDelegate(build, planID, credVarsTracker, clock.NewClock())
 }
@@ -294,6 +306,233 @@ func (d *taskDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus)
 	logger.Info("finished", lager.Data{"exit-status": exitStatus})
 }
 
+func (d *taskDelegate) SaveArchivedArtifact(logger lager.Logger, name string, size int64) error {
+	err := d.build.SaveArchivedArtifact(atc.PlanID(d.eventOrigin.ID), name, size)
+	if err != nil {
+		logger.Error("failed-to-save-archived-artifact", err, lager.Data{"artifact": name})
+		return err
+	}
+
+	logger.Info("archived-artifact", lager.Data{"artifact": name, "size": size})
+
+	return nil
+}
+
+func (d *taskDelegate) SaveTestResults(logger lager.Logger, results []atc.TestResult) error {
+	err := d.build.SaveTestResults(results)
+	if err != nil {
+		logger.Error("failed-to-save-test-results", err)
+		return err
+	}
+
+	logger.Info("saved-test-results", lager.Data{"tests": len(results)})
+
+	return nil
+}
+
+func NewSetPipelineDelegate(build db.Build, planID atc.PlanID, credVarsTracker vars.CredVarsTracker, clock clock.Clock) exec.SetPipelineDelegate {
+	return &setPipelineDelegate{
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, credVarsTracker, clock),
+
+		eventOrigin: event.Origin{ID: event.OriginID(planID)},
+		build:       build,
+		clock:       clock,
+	}
+}
+
+type setPipelineDelegate struct {
+	exec.BuildStepDelegate
+
+	build       db.Build
+	eventOrigin event.Origin
+	clock       clock.Clock
+}
+
+func (d *setPipelineDelegate) Initializing(logger lager.Logger) {
+	err := d.build.SaveEvent(event.InitializeSetPipeline{
+		Origin: d.eventOrigin,
+		Time:   d.clock.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-initialize-set-pipeline-event", err)
+		return
+	}
+
+	logger.Info("initializing")
+}
+
+func (d *setPipelineDelegate) Starting(logger lager.Logger) {
+	err := d.build.SaveEvent(event.StartSetPipeline{
+		Origin: d.eventOrigin,
+		Time:   d.clock.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-start-set-pipeline-event", err)
+		return
+	}
+
+	logger.Debug("starting")
+}
+
+func (d *setPipelineDelegate) Finished(logger lager.Logger, succeeded bool) {
+	// PR#4398: close to flush stdout and stderr
+	d.Stdout().(io.Closer).Close()
+	d.Stderr().(io.Closer).Close()
+
+	err := d.build.SaveEvent(event.FinishSetPipeline{
+		Origin:    d.eventOrigin,
+		Time:      d.clock.Now().Unix(),
+		Succeeded: succeeded,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-set-pipeline-event", err)
+		return
+	}
+
+	logger.Info("finished", lager.Data{"succeeded": succeeded})
+}
+
+func (d *setPipelineDelegate) SetPipelineChanged(logger lager.Logger, changed bool) {
+	err := d.build.SaveEvent(event.SetPipelineChanged{
+		Origin:  d.eventOrigin,
+		Changed: changed,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-set-pipeline-changed-event", err)
+		return
+	}
+
+	logger.Debug("set-pipeline-changed", lager.Data{"changed": changed})
+}
+
+func NewLoadVarDelegate(build db.Build, planID atc.PlanID, credVarsTracker vars.CredVarsTracker, clock clock.Clock) exec.LoadVarDelegate {
+	return &loadVarDelegate{
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, credVarsTracker, clock),
+
+		eventOrigin: event.Origin{ID: event.OriginID(planID)},
+		build:       build,
+		clock:       clock,
+	}
+}
+
+type loadVarDelegate struct {
+	exec.BuildStepDelegate
+
+	build       db.Build
+	eventOrigin event.Origin
+	clock       clock.Clock
+}
+
+func (d *loadVarDelegate) Initializing(logger lager.Logger) {
+	err := d.build.SaveEvent(event.InitializeLoadVar{
+		Origin: d.eventOrigin,
+		Time:   d.clock.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-initialize-load-var-event", err)
+		return
+	}
+
+	logger.Info("initializing")
+}
+
+func (d *loadVarDelegate) Starting(logger lager.Logger) {
+	err := d.build.SaveEvent(event.StartLoadVar{
+		Origin: d.eventOrigin,
+		Time:   d.clock.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-start-load-var-event", err)
+		return
+	}
+
+	logger.Debug("starting")
+}
+
+func (d *loadVarDelegate) Finished(logger lager.Logger, succeeded bool) {
+	// PR#4398: close to flush stdout and stderr
+	d.Stdout().(io.Closer).Close()
+	d.Stderr().(io.Closer).Close()
+
+	err := d.build.SaveEvent(event.FinishLoadVar{
+		Origin:    d.eventOrigin,
+		Time:      d.clock.Now().Unix(),
+		Succeeded: succeeded,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-load-var-event", err)
+		return
+	}
+
+	logger.Info("finished", lager.Data{"succeeded": succeeded})
+}
+
+func NewApprovalDelegate(build db.Build, planID atc.PlanID, credVarsTracker vars.CredVarsTracker, clock clock.Clock) exec.ApprovalDelegate {
+	return &approvalDelegate{
+		BuildStepDelegate: NewBuildStepDelegate(build, planID, credVarsTracker, clock),
+
+		eventOrigin: event.Origin{ID: event.OriginID(planID)},
+		build:       build,
+		clock:       clock,
+	}
+}
+
+type approvalDelegate struct {
+	exec.BuildStepDelegate
+
+	build       db.Build
+	eventOrigin event.Origin
+	clock       clock.Clock
+}
+
+func (d *approvalDelegate) Initializing(logger lager.Logger) {
+	err := d.build.SaveEvent(event.InitializeApproval{
+		Origin: d.eventOrigin,
+		Time:   d.clock.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-initialize-approval-event", err)
+		return
+	}
+
+	logger.Info("initializing")
+}
+
+func (d *approvalDelegate) Starting(logger lager.Logger, approval atc.BuildApproval) {
+	err := d.build.SaveEvent(event.StartApproval{
+		Origin:   d.eventOrigin,
+		Time:     d.clock.Now().Unix(),
+		Deadline: approval.Deadline,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-start-approval-event", err)
+		return
+	}
+
+	logger.Debug("starting")
+}
+
+func (d *approvalDelegate) Finished(logger lager.Logger, approval atc.BuildApproval, succeeded bool) {
+	// PR#4398: close to flush stdout and stderr
+	d.Stdout().(io.Closer).Close()
+	d.Stderr().(io.Closer).Close()
+
+	err := d.build.SaveEvent(event.FinishApproval{
+		Origin:    d.eventOrigin,
+		Time:      d.clock.Now().Unix(),
+		Status:    approval.Status,
+		DecidedBy: approval.DecidedBy,
+		Comment:   approval.Comment,
+		Succeeded: succeeded,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-finish-approval-event", err)
+		return
+	}
+
+	logger.Info("finished", lager.Data{"status": approval.Status})
+}
+
 func NewCheckDelegate(check db.Check, planID atc.PlanID, credVarsTracker vars.CredVarsTracker, clock clock.Clock) exec.CheckDelegate {
 	return &checkDelegate{
 		BuildStepDelegate: NewBuildStepDelegate(nil, planID, credVarsTracker, clock),
@@ -264,6 +264,252 @@ var _ = Describe("DelegateFactory", func() {
 				Expect(event.EventType()).To(Equal(atc.EventType("finish-task")))
 			})
 		})
+
+		Describe("SaveArchivedArtifact", func() {
+			var saveErr error
+
+			JustBeforeEach(func() {
+				saveErr = delegate.SaveArchivedArtifact(logger, "some-output", 42)
+			})
+
+			It("saves the artifact against the build", func() {
+				Expect(saveErr).ToNot(HaveOccurred())
+				Expect(fakeBuild.SaveArchivedArtifactCallCount()).To(Equal(1))
+				planID, name, size := fakeBuild.SaveArchivedArtifactArgsForCall(0)
+				Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+				Expect(name).To(Equal("some-output"))
+				Expect(size).To(Equal(int64(42)))
+			})
+
+			Context("when saving fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeBuild.SaveArchivedArtifactReturns(disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(saveErr).To(Equal(disaster))
+				})
+			})
+		})
+
+		Describe("SaveTestResults", func() {
+			var (
+				results []atc.TestResult
+				saveErr error
+			)
+
+			BeforeEach(func() {
+				results = []atc.TestResult{
+					{Suite: "some-suite", Name: "some-test", Status: atc.TestStatusPassed},
+				}
+			})
+
+			JustBeforeEach(func() {
+				saveErr = delegate.SaveTestResults(logger, results)
+			})
+
+			It("saves the results against the build", func() {
+				Expect(saveErr).ToNot(HaveOccurred())
+				Expect(fakeBuild.SaveTestResultsCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveTestResultsArgsForCall(0)).To(Equal(results))
+			})
+
+			Context("when saving fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeBuild.SaveTestResultsReturns(disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(saveErr).To(Equal(disaster))
+				})
+			})
+		})
+	})
+
+	Describe("SetPipelineDelegate", func() {
+		var delegate exec.SetPipelineDelegate
+
+		BeforeEach(func() {
+			delegate = builder.NewSetPipelineDelegate(fakeBuild, "some-plan-id", credVarsTracker, fakeClock)
+		})
+
+		Describe("Initializing", func() {
+			JustBeforeEach(func() {
+				delegate.Initializing(logger)
+			})
+
+			It("saves an event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.InitializeSetPipeline{
+					Origin: event.Origin{ID: event.OriginID("some-plan-id")},
+					Time:   123456789,
+				}))
+			})
+		})
+
+		Describe("Starting", func() {
+			JustBeforeEach(func() {
+				delegate.Starting(logger)
+			})
+
+			It("saves an event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.StartSetPipeline{
+					Origin: event.Origin{ID: event.OriginID("some-plan-id")},
+					Time:   123456789,
+				}))
+			})
+		})
+
+		Describe("Finished", func() {
+			JustBeforeEach(func() {
+				delegate.Finished(logger, true)
+			})
+
+			It("saves an event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.FinishSetPipeline{
+					Origin:    event.Origin{ID: event.OriginID("some-plan-id")},
+					Time:      123456789,
+					Succeeded: true,
+				}))
+			})
+		})
+
+		Describe("SetPipelineChanged", func() {
+			JustBeforeEach(func() {
+				delegate.SetPipelineChanged(logger, true)
+			})
+
+			It("saves an event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.SetPipelineChanged{
+					Origin:  event.Origin{ID: event.OriginID("some-plan-id")},
+					Changed: true,
+				}))
+			})
+		})
+	})
+
+	Describe("LoadVarDelegate", func() {
+		var delegate exec.LoadVarDelegate
+
+		BeforeEach(func() {
+			delegate = builder.NewLoadVarDelegate(fakeBuild, "some-plan-id", credVarsTracker, fakeClock)
+		})
+
+		Describe("Initializing", func() {
+			JustBeforeEach(func() {
+				delegate.Initializing(logger)
+			})
+
+			It("saves an event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.InitializeLoadVar{
+					Origin: event.Origin{ID: event.OriginID("some-plan-id")},
+					Time:   123456789,
+				}))
+			})
+		})
+
+		Describe("Starting", func() {
+			JustBeforeEach(func() {
+				delegate.Starting(logger)
+			})
+
+			It("saves an event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.StartLoadVar{
+					Origin: event.Origin{ID: event.OriginID("some-plan-id")},
+					Time:   123456789,
+				}))
+			})
+		})
+
+		Describe("Finished", func() {
+			JustBeforeEach(func() {
+				delegate.Finished(logger, true)
+			})
+
+			It("saves an event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.FinishLoadVar{
+					Origin:    event.Origin{ID: event.OriginID("some-plan-id")},
+					Time:      123456789,
+					Succeeded: true,
+				}))
+			})
+		})
+	})
+
+	Describe("ApprovalDelegate", func() {
+		var (
+			delegate exec.ApprovalDelegate
+			approval atc.BuildApproval
+		)
+
+		BeforeEach(func() {
+			delegate = builder.NewApprovalDelegate(fakeBuild, "some-plan-id", credVarsTracker, fakeClock)
+
+			approval = atc.BuildApproval{
+				Name:      "deploy",
+				Status:    atc.ApprovalStatusApproved,
+				Deadline:  987654321,
+				DecidedBy: "some-user",
+				Comment:   "ship it",
+			}
+		})
+
+		Describe("Initializing", func() {
+			JustBeforeEach(func() {
+				delegate.Initializing(logger)
+			})
+
+			It("saves an event", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.InitializeApproval{
+					Origin: event.Origin{ID: event.OriginID("some-plan-id")},
+					Time:   123456789,
+				}))
+			})
+		})
+
+		Describe("Starting", func() {
+			JustBeforeEach(func() {
+				delegate.Starting(logger, approval)
+			})
+
+			It("saves an event with the deadline", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.StartApproval{
+					Origin:   event.Origin{ID: event.OriginID("some-plan-id")},
+					Time:     123456789,
+					Deadline: 987654321,
+				}))
+			})
+		})
+
+		Describe("Finished", func() {
+			JustBeforeEach(func() {
+				delegate.Finished(logger, approval, true)
+			})
+
+			It("saves an event with the decision", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.FinishApproval{
+					Origin:    event.Origin{ID: event.OriginID("some-plan-id")},
+					Time:      123456789,
+					Status:    atc.ApprovalStatusApproved,
+					DecidedBy: "some-user",
+					Comment:   "ship it",
+					Succeeded: true,
+				}))
+			})
+		})
 	})
 
 	Describe("CheckDelegate", func() {
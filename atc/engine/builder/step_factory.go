@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"code.cloudfoundry.org/clock"
+
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/blobstore"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/fetcher"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
 )
@@ -24,6 +28,9 @@ type stepFactory struct {
 	strategy              worker.ContainerPlacementStrategy
 	resourceFactory       resource.ResourceFactory
 	lockFactory           lock.LockFactory
+	teamFactory           db.TeamFactory
+	policyChecker         policy.Checker
+	artifactStore         blobstore.Store
 }
 
 func NewStepFactory(
@@ -36,6 +43,9 @@ func NewStepFactory(
 	strategy worker.ContainerPlacementStrategy,
 	resourceFactory resource.ResourceFactory,
 	lockFactory lock.LockFactory,
+	teamFactory db.TeamFactory,
+	policyChecker policy.Checker,
+	artifactStore blobstore.Store,
 ) *stepFactory {
 	return &stepFactory{
 		pool:                  pool,
@@ -47,6 +57,9 @@ func NewStepFactory(
 		strategy:              strategy,
 		resourceFactory:       resourceFactory,
 		lockFactory:           lockFactory,
+		teamFactory:           teamFactory,
+		policyChecker:         policyChecker,
+		artifactStore:         artifactStore,
 	}
 }
 
@@ -137,11 +150,62 @@ func (factory *stepFactory) TaskStep(
 		factory.client,
 		delegate,
 		factory.lockFactory,
+		factory.policyChecker,
+		factory.artifactStore,
 	)
 
 	return exec.LogError(taskStep, delegate)
 }
 
+func (factory *stepFactory) SetPipelineStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	delegate exec.SetPipelineDelegate,
+) exec.Step {
+	spStep := exec.NewSetPipelineStep(
+		plan.ID,
+		*plan.SetPipeline,
+		stepMetadata,
+		delegate,
+		factory.teamFactory,
+	)
+
+	return exec.LogError(spStep, delegate)
+}
+
+func (factory *stepFactory) LoadVarStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	delegate exec.LoadVarDelegate,
+) exec.Step {
+	loadVarStep := exec.NewLoadVarStep(
+		plan.ID,
+		*plan.LoadVar,
+		stepMetadata,
+		delegate,
+	)
+
+	return exec.LogError(loadVarStep, delegate)
+}
+
+func (factory *stepFactory) ApprovalStep(
+	plan atc.Plan,
+	build db.Build,
+	stepMetadata exec.StepMetadata,
+	delegate exec.ApprovalDelegate,
+) exec.Step {
+	approvalStep := exec.NewApprovalStep(
+		plan.ID,
+		*plan.Approval,
+		stepMetadata,
+		delegate,
+		build,
+		clock.NewClock(),
+	)
+
+	return exec.LogError(approvalStep, delegate)
+}
+
 func (factory *stepFactory) ArtifactInputStep(
 	plan atc.Plan,
 	build db.Build,
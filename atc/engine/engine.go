@@ -3,6 +3,7 @@ package engine
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 
@@ -13,13 +14,14 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/tracing"
 )
 
 //go:generate counterfeiter . Engine
 
 type Engine interface {
 	NewBuild(db.Build) Runnable
-	NewCheck(db.Check) Runnable
+	NewCheck(context.Context, db.Check) Runnable
 	ReleaseAll(lager.Logger)
 }
 
@@ -79,9 +81,10 @@ func (engine *engine) NewBuild(build db.Build) Runnable {
 	)
 }
 
-func (engine *engine) NewCheck(check db.Check) Runnable {
+func (engine *engine) NewCheck(ctx context.Context, check db.Check) Runnable {
 
-	ctx, cancel := context.WithCancel(context.Background())
+	// the check runs on past the caller, so only carry over its span
+	ctx, cancel := context.WithCancel(tracing.Detach(ctx))
 
 	return NewCheck(
 		ctx,
@@ -203,8 +206,18 @@ func (b *engineBuild) Run(logger lager.Logger) {
 
 	done := make(chan error)
 	go func() {
-		ctx := lagerctx.NewContext(b.ctx, logger)
-		done <- step.Run(ctx, state)
+		ctx, span := tracing.StartSpan(b.ctx, "build", tracing.Attrs{
+			"build_id": strconv.Itoa(b.build.ID()),
+			"build":    b.build.Name(),
+			"team":     b.build.TeamName(),
+			"pipeline": b.build.PipelineName(),
+			"job":      b.build.JobName(),
+		})
+
+		err := step.Run(lagerctx.NewContext(ctx, logger), state)
+		tracing.End(span, err)
+
+		done <- err
 	}()
 
 	select {
@@ -361,8 +374,17 @@ func (c *engineCheck) Run(logger lager.Logger) {
 
 	done := make(chan error)
 	go func() {
-		ctx := lagerctx.NewContext(c.ctx, logger)
-		done <- step.Run(ctx, state)
+		ctx, span := tracing.StartSpan(c.ctx, "check", tracing.Attrs{
+			"check_id":                 strconv.Itoa(c.check.ID()),
+			"team":                     c.check.TeamName(),
+			"pipeline":                 c.check.PipelineName(),
+			"resource_config_scope_id": strconv.Itoa(c.check.ResourceConfigScopeID()),
+		})
+
+		err := step.Run(lagerctx.NewContext(ctx, logger), state)
+		tracing.End(span, err)
+
+		done <- err
 	}()
 
 	select {
@@ -83,10 +83,13 @@ func (StartTask) Version() atc.EventVersion { return "5.0" }
 type Status struct {
 	Status atc.BuildStatus `json:"status"`
 	Time   int64           `json:"time"`
+
+	// CreatedBy is set on the status event of a build starting.
+	CreatedBy *atc.BuildCreator `json:"created_by,omitempty"`
 }
 
 func (Status) EventType() atc.EventType  { return EventTypeStatus }
-func (Status) Version() atc.EventVersion { return "1.0" }
+func (Status) Version() atc.EventVersion { return "1.1" }
 
 type Log struct {
 	Time    int64  `json:"time"`
@@ -164,3 +167,90 @@ type FinishPut struct {
 
 func (FinishPut) EventType() atc.EventType  { return EventTypeFinishPut }
 func (FinishPut) Version() atc.EventVersion { return "5.1" }
+
+type InitializeSetPipeline struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time,omitempty"`
+}
+
+func (InitializeSetPipeline) EventType() atc.EventType  { return EventTypeInitializeSetPipeline }
+func (InitializeSetPipeline) Version() atc.EventVersion { return "1.0" }
+
+type StartSetPipeline struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time,omitempty"`
+}
+
+func (StartSetPipeline) EventType() atc.EventType  { return EventTypeStartSetPipeline }
+func (StartSetPipeline) Version() atc.EventVersion { return "1.0" }
+
+type FinishSetPipeline struct {
+	Origin    Origin `json:"origin"`
+	Time      int64  `json:"time"`
+	Succeeded bool   `json:"succeeded"`
+}
+
+func (FinishSetPipeline) EventType() atc.EventType  { return EventTypeFinishSetPipeline }
+func (FinishSetPipeline) Version() atc.EventVersion { return "1.0" }
+
+type SetPipelineChanged struct {
+	Origin  Origin `json:"origin"`
+	Changed bool   `json:"changed"`
+}
+
+func (SetPipelineChanged) EventType() atc.EventType  { return EventTypeSetPipelineChanged }
+func (SetPipelineChanged) Version() atc.EventVersion { return "1.0" }
+
+type InitializeLoadVar struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time,omitempty"`
+}
+
+func (InitializeLoadVar) EventType() atc.EventType  { return EventTypeInitializeLoadVar }
+func (InitializeLoadVar) Version() atc.EventVersion { return "1.0" }
+
+type StartLoadVar struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time,omitempty"`
+}
+
+func (StartLoadVar) EventType() atc.EventType  { return EventTypeStartLoadVar }
+func (StartLoadVar) Version() atc.EventVersion { return "1.0" }
+
+type FinishLoadVar struct {
+	Origin    Origin `json:"origin"`
+	Time      int64  `json:"time"`
+	Succeeded bool   `json:"succeeded"`
+}
+
+func (FinishLoadVar) EventType() atc.EventType  { return EventTypeFinishLoadVar }
+func (FinishLoadVar) Version() atc.EventVersion { return "1.0" }
+
+type InitializeApproval struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time,omitempty"`
+}
+
+func (InitializeApproval) EventType() atc.EventType  { return EventTypeInitializeApproval }
+func (InitializeApproval) Version() atc.EventVersion { return "1.0" }
+
+type StartApproval struct {
+	Origin   Origin `json:"origin"`
+	Time     int64  `json:"time,omitempty"`
+	Deadline int64  `json:"deadline,omitempty"`
+}
+
+func (StartApproval) EventType() atc.EventType  { return EventTypeStartApproval }
+func (StartApproval) Version() atc.EventVersion { return "1.0" }
+
+type FinishApproval struct {
+	Origin    Origin                  `json:"origin"`
+	Time      int64                   `json:"time"`
+	Status    atc.BuildApprovalStatus `json:"status"`
+	DecidedBy string                  `json:"decided_by,omitempty"`
+	Comment   string                  `json:"comment,omitempty"`
+	Succeeded bool                    `json:"succeeded"`
+}
+
+func (FinishApproval) EventType() atc.EventType  { return EventTypeFinishApproval }
+func (FinishApproval) Version() atc.EventVersion { return "1.0" }
@@ -43,6 +43,16 @@ func init() {
 	RegisterEvent(InitializePut{})
 	RegisterEvent(StartPut{})
 	RegisterEvent(FinishPut{})
+	RegisterEvent(InitializeSetPipeline{})
+	RegisterEvent(StartSetPipeline{})
+	RegisterEvent(FinishSetPipeline{})
+	RegisterEvent(SetPipelineChanged{})
+	RegisterEvent(InitializeLoadVar{})
+	RegisterEvent(StartLoadVar{})
+	RegisterEvent(FinishLoadVar{})
+	RegisterEvent(InitializeApproval{})
+	RegisterEvent(StartApproval{})
+	RegisterEvent(FinishApproval{})
 	RegisterEvent(Status{})
 	RegisterEvent(Log{})
 	RegisterEvent(Error{})
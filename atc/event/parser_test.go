@@ -65,6 +65,16 @@ var _ = Describe("ParseEvent", func() {
 		Entry("InitializePut", event.InitializePut{}),
 		Entry("StartPut", event.StartPut{}),
 		Entry("FinishPut", event.FinishPut{}),
+		Entry("InitializeSetPipeline", event.InitializeSetPipeline{}),
+		Entry("StartSetPipeline", event.StartSetPipeline{}),
+		Entry("FinishSetPipeline", event.FinishSetPipeline{}),
+		Entry("SetPipelineChanged", event.SetPipelineChanged{}),
+		Entry("InitializeLoadVar", event.InitializeLoadVar{}),
+		Entry("StartLoadVar", event.StartLoadVar{}),
+		Entry("FinishLoadVar", event.FinishLoadVar{}),
+		Entry("InitializeApproval", event.InitializeApproval{}),
+		Entry("StartApproval", event.StartApproval{}),
+		Entry("FinishApproval", event.FinishApproval{}),
 		Entry("Status", event.Status{}),
 		Entry("Log", event.Log{}),
 		Entry("Error", event.Error{}),
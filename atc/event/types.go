@@ -36,6 +36,36 @@ const (
 	// finished putting something
 	EventTypeFinishPut atc.EventType = "finish-put"
 
+	// initialize setting a pipeline
+	EventTypeInitializeSetPipeline atc.EventType = "initialize-set-pipeline"
+
+	// started setting a pipeline
+	EventTypeStartSetPipeline atc.EventType = "start-set-pipeline"
+
+	// finished setting a pipeline
+	EventTypeFinishSetPipeline atc.EventType = "finish-set-pipeline"
+
+	// pipeline config set by a set_pipeline step has (or hasn't) changed
+	EventTypeSetPipelineChanged atc.EventType = "set-pipeline-changed"
+
+	// initialize loading a var
+	EventTypeInitializeLoadVar atc.EventType = "initialize-load-var"
+
+	// started loading a var
+	EventTypeStartLoadVar atc.EventType = "start-load-var"
+
+	// finished loading a var
+	EventTypeFinishLoadVar atc.EventType = "finish-load-var"
+
+	// initialize waiting for an approval
+	EventTypeInitializeApproval atc.EventType = "initialize-approval"
+
+	// started waiting for an approval
+	EventTypeStartApproval atc.EventType = "start-approval"
+
+	// approval was decided or expired
+	EventTypeFinishApproval atc.EventType = "finish-approval"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
 )
@@ -0,0 +1,436 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/eventstore"
+	"github.com/concourse/flag"
+)
+
+type ElasticsearchConfig struct {
+	URL      flag.URL `long:"build-log-elasticsearch-url" description:"URL of an Elasticsearch cluster in which to store build logs."`
+	Index    string   `long:"build-log-elasticsearch-index" default:"concourse-build-events" description:"Index in which to store build events."`
+	Username string   `long:"build-log-elasticsearch-username" description:"Username with which to authenticate to Elasticsearch."`
+	Password string   `long:"build-log-elasticsearch-password" description:"Password with which to authenticate to Elasticsearch."`
+
+	Timeout time.Duration `long:"build-log-elasticsearch-timeout" default:"30s" description:"Timeout for requests to Elasticsearch."`
+}
+
+func init() {
+	eventstore.RegisterStore(&ElasticsearchConfig{})
+}
+
+func (c *ElasticsearchConfig) Description() string { return "Elasticsearch" }
+func (c *ElasticsearchConfig) IsConfigured() bool  { return c.URL.URL != nil }
+
+func (c *ElasticsearchConfig) NewStore(logger lager.Logger) (db.EventStore, error) {
+	store := NewStore(
+		&http.Client{Timeout: c.Timeout},
+		c.URL.String(),
+		c.Index,
+		c.Username,
+		c.Password,
+	)
+
+	err := store.CreateIndex()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create build events index: %s", err)
+	}
+
+	return store, nil
+}
+
+// Store keeps build events as documents in an Elasticsearch index, one
+// document per event. Events are ordered by the event ID assigned when they
+// were saved.
+type Store struct {
+	client   *http.Client
+	url      string
+	index    string
+	username string
+	password string
+}
+
+func NewStore(client *http.Client, url string, index string, username string, password string) *Store {
+	return &Store{
+		client:   client,
+		url:      strings.TrimRight(url, "/"),
+		index:    index,
+		username: username,
+		password: password,
+	}
+}
+
+// Document is the representation of an event in the index. The event's
+// payload is kept as a string so that differing event schemas never
+// conflict in the index's mapping; the payload of log events is also
+// indexed as Message so that it may be searched.
+type Document struct {
+	BuildID      int    `json:"build_id"`
+	BuildName    string `json:"build_name"`
+	EventID      uint   `json:"event_id"`
+	TeamName     string `json:"team_name"`
+	PipelineName string `json:"pipeline_name,omitempty"`
+	JobName      string `json:"job_name,omitempty"`
+
+	Type    string `json:"type"`
+	Version string `json:"version"`
+	Payload string `json:"payload"`
+	Message string `json:"message,omitempty"`
+}
+
+const indexMapping = `{
+  "mappings": {
+    "properties": {
+      "build_id": {"type": "long"},
+      "build_name": {"type": "keyword"},
+      "event_id": {"type": "long"},
+      "team_name": {"type": "keyword"},
+      "pipeline_name": {"type": "keyword"},
+      "job_name": {"type": "keyword"},
+      "type": {"type": "keyword"},
+      "version": {"type": "keyword"},
+      "payload": {"type": "keyword", "index": false, "doc_values": false},
+      "message": {"type": "text"}
+    }
+  }
+}`
+
+// CreateIndex creates the index with the mapping for event documents, unless
+// it already exists.
+func (s *Store) CreateIndex() error {
+	res, err := s.do("HEAD", "/"+s.index, nil)
+	if err != nil {
+		return err
+	}
+
+	res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	res, err = s.do("PUT", "/"+s.index, strings.NewReader(indexMapping))
+	if err != nil {
+		return err
+	}
+
+	return checkResponse(res, nil)
+}
+
+// Put does nothing, as Elasticsearch can't take part in the transaction; the
+// events are saved once it has committed by Flush.
+func (s *Store) Put(tx db.Tx, build db.Build, events []db.BuildEvent) error {
+	return nil
+}
+
+// Flush saves the events with the bulk API, waiting for them to be visible to
+// searches so that they can be streamed as soon as watchers are notified.
+func (s *Store) Flush(build db.Build, events []db.BuildEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	body := new(bytes.Buffer)
+	encoder := json.NewEncoder(body)
+
+	for _, e := range events {
+		payload, err := json.Marshal(e.Event)
+		if err != nil {
+			return err
+		}
+
+		doc := Document{
+			BuildID:      build.ID(),
+			BuildName:    build.Name(),
+			EventID:      e.EventID,
+			TeamName:     build.TeamName(),
+			PipelineName: build.PipelineName(),
+			JobName:      build.JobName(),
+			Type:         string(e.Event.EventType()),
+			Version:      string(e.Event.Version()),
+			Payload:      string(payload),
+		}
+
+		if log, ok := e.Event.(event.Log); ok {
+			doc.Message = log.Payload
+		}
+
+		err = encoder.Encode(map[string]interface{}{
+			"index": map[string]string{
+				"_index": s.index,
+				"_id":    fmt.Sprintf("%d-%d", build.ID(), e.EventID),
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(doc)
+		if err != nil {
+			return err
+		}
+	}
+
+	res, err := s.do("POST", "/_bulk?refresh=wait_for", body)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Error json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+
+	err = checkResponse(res, &result)
+	if err != nil {
+		return err
+	}
+
+	if result.Errors {
+		for _, item := range result.Items {
+			for _, action := range item {
+				if len(action.Error) > 0 {
+					return fmt.Errorf("failed to index build event: %s", action.Error)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) Get(build db.Build, from uint, notifier db.Notifier) (db.EventSource, error) {
+	reader := &eventReader{store: s, buildID: build.ID()}
+	return eventstore.NewEventSource(reader.fetch, notifier, from), nil
+}
+
+func (s *Store) Delete(buildIDs []int) error {
+	if len(buildIDs) == 0 {
+		return nil
+	}
+
+	query, err := json.Marshal(map[string]interface{}{
+		"query": map[string]interface{}{
+			"terms": map[string]interface{}{
+				"build_id": buildIDs,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := s.do("POST", "/"+s.index+"/_delete_by_query?conflicts=proceed", bytes.NewReader(query))
+	if err != nil {
+		return err
+	}
+
+	return checkResponse(res, nil)
+}
+
+type searchHit struct {
+	Source Document `json:"_source"`
+}
+
+// search returns up to size of the build's events which follow the given
+// event ID, or the build's first events if after is nil.
+func (s *Store) search(buildID int, after *uint, size int) ([]searchHit, error) {
+	query := map[string]interface{}{
+		"size": size,
+		"query": map[string]interface{}{
+			"term": map[string]interface{}{
+				"build_id": buildID,
+			},
+		},
+		"sort": []interface{}{
+			map[string]string{"event_id": "asc"},
+		},
+	}
+
+	if after != nil {
+		query["search_after"] = []uint{*after}
+	}
+
+	payload, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.do("POST", "/"+s.index+"/_search", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Hits struct {
+			Hits []searchHit `json:"hits"`
+		} `json:"hits"`
+	}
+
+	err = checkResponse(res, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Hits.Hits, nil
+}
+
+func (s *Store) do(method string, path string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, s.url+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(path, "/_bulk") {
+		req.Header.Set("Content-Type", "application/x-ndjson")
+	} else {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if s.username != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+
+	return s.client.Do(req)
+}
+
+func checkResponse(res *http.Response, result interface{}) error {
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch returned %d: %s", res.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(result)
+}
+
+// gapTimeout is how long a reader waits for a missing event ID to be filled
+// before giving up on it, e.g. because the transaction it was assigned in was
+// rolled back.
+const gapTimeout = 10 * time.Second
+
+// eventReader pages through a build's events in order of their IDs.
+//
+// Events are only flushed to the index once the transaction which assigned
+// their IDs has committed, so an event may become visible after events with
+// higher IDs. Rather than searching after the last event it read, which would
+// skip such an event, the reader searches after the lowest ID it is still
+// waiting for, skipping the events after it which it has already read.
+type eventReader struct {
+	store   *Store
+	buildID int
+
+	// every event with an ID below next has been read or given up on
+	next      uint
+	readAhead map[uint]bool
+	gapSince  time.Time
+
+	read uint
+}
+
+func (reader *eventReader) fetch(from uint, limit int) ([]event.Envelope, error) {
+	if from != reader.read || reader.readAhead == nil {
+		reader.next = 0
+		reader.readAhead = map[uint]bool{}
+		reader.gapSince = time.Time{}
+		reader.read = 0
+	}
+
+	var cursor *uint
+	if reader.next > 0 {
+		before := reader.next - 1
+		cursor = &before
+	}
+
+	events := []event.Envelope{}
+	for len(events) < limit {
+		hits, err := reader.store.search(reader.buildID, cursor, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hit := range hits {
+			eventID := hit.Source.EventID
+			cursor = &eventID
+
+			if reader.readAhead[eventID] {
+				continue
+			}
+
+			reader.readAhead[eventID] = true
+			reader.read++
+
+			if reader.read <= from {
+				continue
+			}
+
+			data := json.RawMessage(hit.Source.Payload)
+			events = append(events, event.Envelope{
+				Data:    &data,
+				Event:   atc.EventType(hit.Source.Type),
+				Version: atc.EventVersion(hit.Source.Version),
+			})
+
+			if len(events) == limit {
+				break
+			}
+		}
+
+		if len(hits) < limit {
+			break
+		}
+	}
+
+	reader.advance()
+
+	return events, nil
+}
+
+// advance moves past the events read in order, giving up on a missing event
+// once it has been missing for longer than gapTimeout.
+func (reader *eventReader) advance() {
+	for reader.readAhead[reader.next] {
+		delete(reader.readAhead, reader.next)
+		reader.next++
+	}
+
+	if len(reader.readAhead) == 0 {
+		reader.gapSince = time.Time{}
+		return
+	}
+
+	if reader.gapSince.IsZero() {
+		reader.gapSince = time.Now()
+		return
+	}
+
+	if time.Since(reader.gapSince) < gapTimeout {
+		return
+	}
+
+	for !reader.readAhead[reader.next] {
+		reader.next++
+	}
+
+	reader.gapSince = time.Time{}
+	reader.advance()
+}
@@ -0,0 +1,13 @@
+package elasticsearch_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestElasticsearch(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Elasticsearch Build Log Store Suite")
+}
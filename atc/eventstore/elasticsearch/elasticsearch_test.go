@@ -0,0 +1,307 @@
+package elasticsearch_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/eventstore/elasticsearch"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		server *ghttp.Server
+		store  *elasticsearch.Store
+
+		fakeBuild *dbfakes.FakeBuild
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		store = elasticsearch.NewStore(http.DefaultClient, server.URL(), "some-index", "some-user", "some-password")
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+		fakeBuild.NameReturns("7")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.JobNameReturns("some-job")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("CreateIndex", func() {
+		Context("when the index does not exist", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("HEAD", "/some-index"),
+						ghttp.VerifyBasicAuth("some-user", "some-password"),
+						ghttp.RespondWith(http.StatusNotFound, nil),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/some-index"),
+						ghttp.RespondWith(http.StatusOK, `{"acknowledged":true}`),
+					),
+				)
+			})
+
+			It("creates it", func() {
+				Expect(store.CreateIndex()).To(Succeed())
+				Expect(server.ReceivedRequests()).To(HaveLen(2))
+			})
+		})
+
+		Context("when the index exists", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("HEAD", "/some-index"),
+						ghttp.RespondWith(http.StatusOK, nil),
+					),
+				)
+			})
+
+			It("leaves it alone", func() {
+				Expect(store.CreateIndex()).To(Succeed())
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe("Put", func() {
+		It("does not index the events until they are flushed", func() {
+			err := store.Put(nil, fakeBuild, []db.BuildEvent{
+				{EventID: 3, Event: event.Log{Payload: "some-log"}},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+
+	Describe("Flush", func() {
+		var putErr error
+
+		JustBeforeEach(func() {
+			putErr = store.Flush(fakeBuild, []db.BuildEvent{
+				{EventID: 3, Event: event.Log{Payload: "some-log"}},
+			})
+		})
+
+		Context("when indexing succeeds", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/_bulk", "refresh=wait_for"),
+						ghttp.VerifyContentType("application/x-ndjson"),
+						ghttp.VerifyBody([]byte(
+							`{"index":{"_id":"42-3","_index":"some-index"}}`+"\n"+
+								`{"build_id":42,"build_name":"7","event_id":3,"team_name":"some-team","pipeline_name":"some-pipeline","job_name":"some-job","type":"log","version":"5.1","payload":"{\"time\":0,\"origin\":{},\"payload\":\"some-log\"}","message":"some-log"}`+"\n",
+						)),
+						ghttp.RespondWith(http.StatusOK, `{"errors":false,"items":[{"index":{}}]}`),
+					),
+				)
+			})
+
+			It("indexes the events", func() {
+				Expect(putErr).ToNot(HaveOccurred())
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("when an event fails to index", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusOK, `{"errors":true,"items":[{"index":{"error":{"type":"mapper_parsing_exception"}}}]}`),
+				)
+			})
+
+			It("errors", func() {
+				Expect(putErr).To(MatchError(ContainSubstring("mapper_parsing_exception")))
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.RespondWith(http.StatusServiceUnavailable, `unavailable`),
+				)
+			})
+
+			It("errors", func() {
+				Expect(putErr).To(MatchError("elasticsearch returned 503: unavailable"))
+			})
+		})
+	})
+
+	Describe("Get", func() {
+		var (
+			fakeNotifier *dbfakes.FakeNotifier
+			source       db.EventSource
+		)
+
+		BeforeEach(func() {
+			fakeNotifier = new(dbfakes.FakeNotifier)
+			fakeNotifier.NotifyReturns(make(chan struct{}))
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/some-index/_search"),
+					ghttp.VerifyJSON(`{
+						"size": 2000,
+						"query": {"term": {"build_id": 42}},
+						"sort": [{"event_id": "asc"}]
+					}`),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"hits": map[string]interface{}{
+							"hits": []interface{}{
+								hit(0, event.Status{Status: atc.StatusStarted, Time: 1}),
+								hit(1, event.Log{Payload: "some-log"}),
+								hit(2, event.Status{Status: atc.StatusSucceeded, Time: 2}),
+							},
+						},
+					}),
+				),
+			)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			source, err = store.Get(fakeBuild, 0, fakeNotifier)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(source.Close()).To(Succeed())
+		})
+
+		It("returns the build's events in order and ends the stream", func() {
+			Expect(source.Next()).To(Equal(envelope(event.Status{Status: atc.StatusStarted, Time: 1})))
+			Expect(source.Next()).To(Equal(envelope(event.Log{Payload: "some-log"})))
+			Expect(source.Next()).To(Equal(envelope(event.Status{Status: atc.StatusSucceeded, Time: 2})))
+
+			_, err := source.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+	})
+
+	Context("when an event is flushed after events with higher IDs", func() {
+		var (
+			notify chan struct{}
+			source db.EventSource
+		)
+
+		BeforeEach(func() {
+			notify = make(chan struct{}, 1)
+
+			fakeNotifier := new(dbfakes.FakeNotifier)
+			fakeNotifier.NotifyReturns(notify)
+
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/some-index/_search"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"hits": map[string]interface{}{
+							"hits": []interface{}{
+								hit(0, event.Status{Status: atc.StatusStarted, Time: 1}),
+								hit(2, event.Log{Payload: "later-log"}),
+							},
+						},
+					}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/some-index/_search"),
+					ghttp.VerifyJSON(`{
+						"size": 2000,
+						"query": {"term": {"build_id": 42}},
+						"sort": [{"event_id": "asc"}],
+						"search_after": [0]
+					}`),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string]interface{}{
+						"hits": map[string]interface{}{
+							"hits": []interface{}{
+								hit(1, event.Log{Payload: "earlier-log"}),
+								hit(2, event.Log{Payload: "later-log"}),
+								hit(3, event.Status{Status: atc.StatusSucceeded, Time: 2}),
+							},
+						},
+					}),
+				),
+			)
+
+			var err error
+			source, err = store.Get(fakeBuild, 0, fakeNotifier)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(source.Close()).To(Succeed())
+		})
+
+		It("returns it once it is visible, without repeating the others", func() {
+			Expect(source.Next()).To(Equal(envelope(event.Status{Status: atc.StatusStarted, Time: 1})))
+			Expect(source.Next()).To(Equal(envelope(event.Log{Payload: "later-log"})))
+
+			notify <- struct{}{}
+
+			Expect(source.Next()).To(Equal(envelope(event.Log{Payload: "earlier-log"})))
+			Expect(source.Next()).To(Equal(envelope(event.Status{Status: atc.StatusSucceeded, Time: 2})))
+
+			_, err := source.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+	})
+
+	Describe("Delete", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/some-index/_delete_by_query", "conflicts=proceed"),
+					ghttp.VerifyJSON(`{"query": {"terms": {"build_id": [1, 2]}}}`),
+					ghttp.RespondWith(http.StatusOK, `{"deleted":5}`),
+				),
+			)
+		})
+
+		It("deletes the builds' events", func() {
+			Expect(store.Delete([]int{1, 2})).To(Succeed())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
+
+func hit(eventID uint, ev atc.Event) map[string]interface{} {
+	payload, err := json.Marshal(ev)
+	Expect(err).ToNot(HaveOccurred())
+
+	return map[string]interface{}{
+		"_source": map[string]interface{}{
+			"build_id": 42,
+			"event_id": eventID,
+			"type":     string(ev.EventType()),
+			"version":  string(ev.Version()),
+			"payload":  string(payload),
+		},
+	}
+}
+
+func envelope(ev atc.Event) event.Envelope {
+	payload, err := json.Marshal(ev)
+	Expect(err).ToNot(HaveOccurred())
+
+	data := json.RawMessage(payload)
+
+	return event.Envelope{
+		Event:   ev.EventType(),
+		Version: ev.Version(),
+		Data:    &data,
+	}
+}
@@ -0,0 +1,13 @@
+package eventstore_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestEventstore(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Eventstore Suite")
+}
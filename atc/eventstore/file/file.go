@@ -0,0 +1,309 @@
+package file
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/eventstore"
+)
+
+type FileConfig struct {
+	Dir string `long:"build-log-file-dir" description:"Directory in which to store build logs, one compressed file per build."`
+}
+
+func init() {
+	eventstore.RegisterStore(&FileConfig{})
+}
+
+func (c *FileConfig) Description() string { return "Filesystem" }
+func (c *FileConfig) IsConfigured() bool  { return c.Dir != "" }
+
+func (c *FileConfig) NewStore(logger lager.Logger) (db.EventStore, error) {
+	err := os.MkdirAll(c.Dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create build log dir: %s", err)
+	}
+
+	return NewStore(c.Dir), nil
+}
+
+// Store keeps each build's events in a file within a directory, e.g. a
+// persistent disk or a network filesystem shared between all web nodes.
+//
+// Events of running builds are appended to a plain file, one JSON event per
+// line. Once the build's final status event is saved the file is compressed
+// and the plain file is removed.
+//
+// Events are appended in the order they are flushed, i.e. the order in which
+// the transactions saving them committed, rather than in order of their IDs,
+// so that readers never miss an event by reading on from where they left off.
+// Writers lock the file, so that web nodes sharing the directory don't
+// interleave their writes.
+type Store struct {
+	dir string
+
+	lock sync.Mutex
+}
+
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Put does nothing, as the filesystem can't take part in the transaction;
+// the events are saved once it has committed by Flush.
+func (s *Store) Put(tx db.Tx, build db.Build, events []db.BuildEvent) error {
+	return nil
+}
+
+func (s *Store) Flush(build db.Build, events []db.BuildEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	file, err := os.OpenFile(s.path(build.ID()), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	err = lockFile(file)
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	completed := false
+
+	encoder := json.NewEncoder(file)
+	for _, e := range events {
+		payload, err := json.Marshal(e.Event)
+		if err != nil {
+			_ = file.Close()
+			return err
+		}
+
+		data := json.RawMessage(payload)
+		envelope := event.Envelope{
+			Data:    &data,
+			Event:   e.Event.EventType(),
+			Version: e.Event.Version(),
+		}
+
+		err = encoder.Encode(envelope)
+		if err != nil {
+			_ = file.Close()
+			return err
+		}
+
+		if eventstore.IsFinalStatus(envelope) {
+			completed = true
+		}
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	if completed {
+		return s.compress(build.ID())
+	}
+
+	return nil
+}
+
+func (s *Store) Get(build db.Build, from uint, notifier db.Notifier) (db.EventSource, error) {
+	reader := &eventReader{store: s, buildID: build.ID()}
+	return eventstore.NewEventSource(reader.fetch, notifier, from), nil
+}
+
+func (s *Store) Delete(buildIDs []int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, buildID := range buildIDs {
+		for _, path := range []string{s.path(buildID), s.compressedPath(buildID)} {
+			err := os.Remove(path)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// compress writes the compressed file to a temporary file which is renamed
+// into place once complete, so that a partially written file is never
+// visible.
+func (s *Store) compress(buildID int) error {
+	src, err := os.Open(s.path(buildID))
+	if err != nil {
+		return err
+	}
+
+	defer src.Close()
+
+	tmp, err := ioutil.TempFile(s.dir, ".compress-")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	zw := gzip.NewWriter(tmp)
+
+	_, err = io.Copy(zw, src)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	err = zw.Close()
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmp.Name(), s.compressedPath(buildID))
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(s.path(buildID))
+}
+
+func (s *Store) path(buildID int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%d.jsonl", buildID))
+}
+
+func (s *Store) compressedPath(buildID int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%d.jsonl.gz", buildID))
+}
+
+// eventReader reads a build's events, remembering its position in the plain
+// file so that each fetch of a running build only reads new events.
+type eventReader struct {
+	store   *Store
+	buildID int
+
+	offset int64
+	read   uint
+}
+
+func (reader *eventReader) fetch(from uint, limit int) ([]event.Envelope, error) {
+	file, compressed, err := reader.open()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	defer file.Close()
+
+	var src io.Reader = file
+	skip := from
+
+	if compressed {
+		zr, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+
+		src = zr
+	} else if from == reader.read {
+		_, err = file.Seek(reader.offset, io.SeekStart)
+		if err != nil {
+			return nil, err
+		}
+
+		skip = 0
+	} else {
+		reader.offset = 0
+		reader.read = 0
+	}
+
+	buffered := bufio.NewReader(src)
+
+	events := []event.Envelope{}
+	for len(events) < limit {
+		line, err := buffered.ReadBytes('\n')
+		if err != nil {
+			// a partially written line is read again on the next fetch
+			if err == io.EOF {
+				break
+			}
+
+			return nil, err
+		}
+
+		if !compressed {
+			reader.offset += int64(len(line))
+			reader.read++
+		}
+
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		var envelope event.Envelope
+		err = json.Unmarshal(line, &envelope)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, envelope)
+	}
+
+	return events, nil
+}
+
+// open prefers the compressed file, falling back to the plain file while the
+// build is running. The compressed file is checked again in case the build
+// completed in between.
+func (reader *eventReader) open() (*os.File, bool, error) {
+	file, err := os.Open(reader.store.compressedPath(reader.buildID))
+	if err == nil {
+		return file, true, nil
+	}
+
+	if !os.IsNotExist(err) {
+		return nil, false, err
+	}
+
+	file, err = os.Open(reader.store.path(reader.buildID))
+	if err == nil {
+		return file, false, nil
+	}
+
+	if !os.IsNotExist(err) {
+		return nil, false, err
+	}
+
+	file, err = os.Open(reader.store.compressedPath(reader.buildID))
+	if err != nil {
+		return nil, false, err
+	}
+
+	return file, true, nil
+}
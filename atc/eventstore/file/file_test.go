@@ -0,0 +1,150 @@
+package file_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/eventstore/file"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		dir   string
+		store *file.Store
+
+		fakeBuild    *dbfakes.FakeBuild
+		fakeNotifier *dbfakes.FakeNotifier
+		notify       chan struct{}
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "build-logs")
+		Expect(err).ToNot(HaveOccurred())
+
+		store = file.NewStore(dir)
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(42)
+
+		notify = make(chan struct{}, 1)
+		fakeNotifier = new(dbfakes.FakeNotifier)
+		fakeNotifier.NotifyReturns(notify)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	put := func(events ...atc.Event) {
+		buildEvents := []db.BuildEvent{}
+		for i, ev := range events {
+			buildEvents = append(buildEvents, db.BuildEvent{EventID: uint(i), Event: ev})
+		}
+
+		Expect(store.Put(nil, fakeBuild, buildEvents)).To(Succeed())
+		Expect(store.Flush(fakeBuild, buildEvents)).To(Succeed())
+	}
+
+	It("does not write events until they are flushed", func() {
+		err := store.Put(nil, fakeBuild, []db.BuildEvent{{EventID: 0, Event: event.Log{Payload: "some-log"}}})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(filepath.Join(dir, "42.jsonl")).ToNot(BeAnExistingFile())
+	})
+
+	Context("while the build is running", func() {
+		BeforeEach(func() {
+			put(
+				event.Status{Status: atc.StatusStarted, Time: 1},
+				event.Log{Payload: "some-log"},
+			)
+		})
+
+		It("appends events to a plain file", func() {
+			Expect(filepath.Join(dir, "42.jsonl")).To(BeAnExistingFile())
+			Expect(filepath.Join(dir, "42.jsonl.gz")).ToNot(BeAnExistingFile())
+		})
+
+		It("streams events as they are saved", func() {
+			source, err := store.Get(fakeBuild, 0, fakeNotifier)
+			Expect(err).ToNot(HaveOccurred())
+			defer db.Close(source)
+
+			Expect(source.Next()).To(Equal(envelope(event.Status{Status: atc.StatusStarted, Time: 1})))
+			Expect(source.Next()).To(Equal(envelope(event.Log{Payload: "some-log"})))
+
+			put(event.Log{Payload: "more-log"})
+			notify <- struct{}{}
+
+			Expect(source.Next()).To(Equal(envelope(event.Log{Payload: "more-log"})))
+		})
+
+		It("streams events from an offset", func() {
+			source, err := store.Get(fakeBuild, 1, fakeNotifier)
+			Expect(err).ToNot(HaveOccurred())
+			defer db.Close(source)
+
+			Expect(source.Next()).To(Equal(envelope(event.Log{Payload: "some-log"})))
+		})
+	})
+
+	Context("once the build has completed", func() {
+		BeforeEach(func() {
+			put(
+				event.Status{Status: atc.StatusStarted, Time: 1},
+				event.Log{Payload: "some-log"},
+			)
+
+			put(event.Status{Status: atc.StatusSucceeded, Time: 2})
+		})
+
+		It("compresses the file", func() {
+			Expect(filepath.Join(dir, "42.jsonl")).ToNot(BeAnExistingFile())
+			Expect(filepath.Join(dir, "42.jsonl.gz")).To(BeAnExistingFile())
+		})
+
+		It("returns all events and ends the stream", func() {
+			source, err := store.Get(fakeBuild, 0, fakeNotifier)
+			Expect(err).ToNot(HaveOccurred())
+			defer db.Close(source)
+
+			Expect(source.Next()).To(Equal(envelope(event.Status{Status: atc.StatusStarted, Time: 1})))
+			Expect(source.Next()).To(Equal(envelope(event.Log{Payload: "some-log"})))
+			Expect(source.Next()).To(Equal(envelope(event.Status{Status: atc.StatusSucceeded, Time: 2})))
+
+			_, err = source.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+
+		It("can delete the build's events", func() {
+			Expect(store.Delete([]int{42})).To(Succeed())
+			Expect(filepath.Join(dir, "42.jsonl.gz")).ToNot(BeAnExistingFile())
+		})
+	})
+
+	It("tolerates deleting builds without events", func() {
+		Expect(store.Delete([]int{41})).To(Succeed())
+	})
+})
+
+func envelope(ev atc.Event) event.Envelope {
+	payload, err := json.Marshal(ev)
+	Expect(err).ToNot(HaveOccurred())
+
+	data := json.RawMessage(payload)
+
+	return event.Envelope{
+		Event:   ev.EventType(),
+		Version: ev.Version(),
+		Data:    &data,
+	}
+}
@@ -0,0 +1,15 @@
+// +build !windows
+
+package file
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile takes an exclusive lock on the file which is shared with other
+// processes, e.g. other web nodes writing to the same network filesystem. The
+// lock is released when the file is closed.
+func lockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+}
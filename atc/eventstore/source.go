@@ -0,0 +1,140 @@
+package eventstore
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// FetchFunc returns up to limit events, starting at the given offset.
+type FetchFunc func(from uint, limit int) ([]event.Envelope, error)
+
+// NewEventSource constructs an EventSource which fetches a build's events in
+// batches, waiting on the notifier once it has caught up.
+//
+// Events stored outside of Postgres are not saved in the same transaction as
+// the build's completion, so rather than checking whether the build has
+// completed the source ends once it has returned the build's final status
+// event.
+func NewEventSource(fetch FetchFunc, notifier db.Notifier, from uint) db.EventSource {
+	wg := new(sync.WaitGroup)
+
+	source := &eventSource{
+		fetch:    fetch,
+		notifier: notifier,
+
+		events: make(chan event.Envelope, batchSize),
+		stop:   make(chan struct{}),
+		wg:     wg,
+	}
+
+	wg.Add(1)
+	go source.collectEvents(from)
+
+	return source
+}
+
+const batchSize = 2000
+
+type eventSource struct {
+	fetch    FetchFunc
+	notifier db.Notifier
+
+	events chan event.Envelope
+	stop   chan struct{}
+	err    error
+	wg     *sync.WaitGroup
+}
+
+func (source *eventSource) Next() (event.Envelope, error) {
+	e, ok := <-source.events
+	if !ok {
+		return event.Envelope{}, source.err
+	}
+
+	return e, nil
+}
+
+func (source *eventSource) Close() error {
+	select {
+	case <-source.stop:
+		return nil
+	default:
+		close(source.stop)
+	}
+
+	source.wg.Wait()
+
+	return source.notifier.Close()
+}
+
+func (source *eventSource) collectEvents(cursor uint) {
+	defer source.wg.Done()
+
+	for {
+		select {
+		case <-source.stop:
+			source.end(db.ErrBuildEventStreamClosed)
+			return
+		default:
+		}
+
+		events, err := source.fetch(cursor, batchSize)
+		if err != nil {
+			source.end(err)
+			return
+		}
+
+		for _, ev := range events {
+			cursor++
+
+			select {
+			case source.events <- ev:
+			case <-source.stop:
+				source.end(db.ErrBuildEventStreamClosed)
+				return
+			}
+
+			if IsFinalStatus(ev) {
+				source.end(db.ErrEndOfBuildEventStream)
+				return
+			}
+		}
+
+		if len(events) == batchSize {
+			// still more events
+			continue
+		}
+
+		select {
+		case <-source.notifier.Notify():
+		case <-source.stop:
+			source.end(db.ErrBuildEventStreamClosed)
+			return
+		}
+	}
+}
+
+func (source *eventSource) end(err error) {
+	source.err = err
+	close(source.events)
+}
+
+// IsFinalStatus returns true if the event is the status event saved when a
+// build completes.
+func IsFinalStatus(ev event.Envelope) bool {
+	if ev.Event != event.EventTypeStatus || ev.Data == nil {
+		return false
+	}
+
+	var status event.Status
+	err := json.Unmarshal(*ev.Data, &status)
+	if err != nil {
+		return false
+	}
+
+	return status.Status != atc.StatusStarted && status.Status != atc.StatusPending
+}
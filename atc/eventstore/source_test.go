@@ -0,0 +1,132 @@
+package eventstore_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/eventstore"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EventSource", func() {
+	var (
+		fakeNotifier *dbfakes.FakeNotifier
+		notify       chan struct{}
+
+		stored   []event.Envelope
+		fetchErr error
+
+		source db.EventSource
+	)
+
+	fetch := func(from uint, limit int) ([]event.Envelope, error) {
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+
+		if int(from) >= len(stored) {
+			return nil, nil
+		}
+
+		end := int(from) + limit
+		if end > len(stored) {
+			end = len(stored)
+		}
+
+		return stored[from:end], nil
+	}
+
+	BeforeEach(func() {
+		notify = make(chan struct{}, 1)
+
+		fakeNotifier = new(dbfakes.FakeNotifier)
+		fakeNotifier.NotifyReturns(notify)
+
+		stored = nil
+		fetchErr = nil
+	})
+
+	JustBeforeEach(func() {
+		source = eventstore.NewEventSource(fetch, fakeNotifier, 0)
+	})
+
+	AfterEach(func() {
+		Expect(source.Close()).To(Succeed())
+	})
+
+	Context("when the build has completed", func() {
+		BeforeEach(func() {
+			stored = []event.Envelope{
+				envelope(event.Status{Status: atc.StatusStarted}),
+				envelope(event.Log{Payload: "some-log"}),
+				envelope(event.Status{Status: atc.StatusSucceeded}),
+			}
+		})
+
+		It("returns the events and then ends the stream", func() {
+			Expect(source.Next()).To(Equal(stored[0]))
+			Expect(source.Next()).To(Equal(stored[1]))
+			Expect(source.Next()).To(Equal(stored[2]))
+
+			_, err := source.Next()
+			Expect(err).To(Equal(db.ErrEndOfBuildEventStream))
+		})
+	})
+
+	Context("when the build is running", func() {
+		BeforeEach(func() {
+			stored = []event.Envelope{
+				envelope(event.Status{Status: atc.StatusStarted}),
+			}
+		})
+
+		It("waits to be notified of new events", func() {
+			Expect(source.Next()).To(Equal(stored[0]))
+
+			next := make(chan event.Envelope)
+			go func() {
+				defer GinkgoRecover()
+
+				ev, err := source.Next()
+				Expect(err).ToNot(HaveOccurred())
+				next <- ev
+			}()
+
+			Consistently(next).ShouldNot(Receive())
+
+			stored = append(stored, envelope(event.Log{Payload: "some-log"}))
+			notify <- struct{}{}
+
+			Eventually(next).Should(Receive(Equal(stored[1])))
+		})
+	})
+
+	Context("when fetching fails", func() {
+		BeforeEach(func() {
+			fetchErr = errors.New("nope")
+		})
+
+		It("returns the error", func() {
+			_, err := source.Next()
+			Expect(err).To(Equal(fetchErr))
+		})
+	})
+})
+
+func envelope(ev atc.Event) event.Envelope {
+	payload, err := json.Marshal(ev)
+	Expect(err).ToNot(HaveOccurred())
+
+	data := json.RawMessage(payload)
+
+	return event.Envelope{
+		Event:   ev.EventType(),
+		Version: ev.Version(),
+		Data:    &data,
+	}
+}
@@ -0,0 +1,62 @@
+package eventstore
+
+import (
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	flags "github.com/jessevdk/go-flags"
+)
+
+//go:generate counterfeiter . StoreFactory
+
+type StoreFactory interface {
+	Description() string
+	IsConfigured() bool
+	NewStore(lager.Logger) (db.EventStore, error)
+}
+
+var storeFactories []StoreFactory
+
+func RegisterStore(factory StoreFactory) {
+	storeFactories = append(storeFactories, factory)
+}
+
+func WireStores(group *flags.Group) {
+	for _, factory := range storeFactories {
+		_, err := group.AddGroup(fmt.Sprintf("Build Log Storage (%s)", factory.Description()), "", factory)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Initialize constructs the configured store. If no store is configured, a
+// nil store is returned and build events are stored in Postgres.
+func Initialize(logger lager.Logger) (db.EventStore, error) {
+	var storeDescriptions []string
+	for _, factory := range storeFactories {
+		if factory.IsConfigured() {
+			storeDescriptions = append(storeDescriptions, factory.Description())
+		}
+	}
+	if len(storeDescriptions) > 1 {
+		return nil, fmt.Errorf("Multiple build log stores configured: %s", strings.Join(storeDescriptions, ", "))
+	}
+
+	for _, factory := range storeFactories {
+		if factory.IsConfigured() {
+			store, err := factory.NewStore(logger.Session("event-store"))
+			if err != nil {
+				return nil, err
+			}
+
+			logger.Info("build-log-store-configured", lager.Data{"store": factory.Description()})
+
+			return store, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,85 @@
+package eventstore_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/eventstore"
+	"github.com/concourse/concourse/atc/eventstore/eventstorefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// store factories are registered globally, so only register the fakes once
+var (
+	fakeStoreFactory      = new(eventstorefakes.FakeStoreFactory)
+	otherFakeStoreFactory = new(eventstorefakes.FakeStoreFactory)
+)
+
+func init() {
+	eventstore.RegisterStore(fakeStoreFactory)
+	eventstore.RegisterStore(otherFakeStoreFactory)
+}
+
+var _ = Describe("Initialize", func() {
+	var (
+		fakeStore *dbfakes.FakeEventStore
+		store     db.EventStore
+		initErr   error
+	)
+
+	BeforeEach(func() {
+		fakeStore = new(dbfakes.FakeEventStore)
+
+		fakeStoreFactory.DescriptionReturns("fake")
+		fakeStoreFactory.IsConfiguredReturns(true)
+		fakeStoreFactory.NewStoreReturns(fakeStore, nil)
+
+		otherFakeStoreFactory.DescriptionReturns("other-fake")
+		otherFakeStoreFactory.IsConfiguredReturns(false)
+	})
+
+	JustBeforeEach(func() {
+		store, initErr = eventstore.Initialize(lagertest.NewTestLogger("test"))
+	})
+
+	It("returns the configured store", func() {
+		Expect(initErr).ToNot(HaveOccurred())
+		Expect(store).To(Equal(fakeStore))
+	})
+
+	Context("when no store is configured", func() {
+		BeforeEach(func() {
+			fakeStoreFactory.IsConfiguredReturns(false)
+		})
+
+		It("returns no store", func() {
+			Expect(initErr).ToNot(HaveOccurred())
+			Expect(store).To(BeNil())
+		})
+	})
+
+	Context("when multiple stores are configured", func() {
+		BeforeEach(func() {
+			otherFakeStoreFactory.IsConfiguredReturns(true)
+		})
+
+		It("errors", func() {
+			Expect(initErr).To(MatchError("Multiple build log stores configured: fake, other-fake"))
+		})
+	})
+
+	Context("when constructing the store fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeStoreFactory.NewStoreReturns(nil, disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(initErr).To(Equal(disaster))
+		})
+	})
+})
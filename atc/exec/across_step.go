@@ -0,0 +1,70 @@
+package exec
+
+import (
+	"context"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// AcrossStep is a step of steps to run once for each combination of values of
+// the across vars.
+type AcrossStep struct {
+	steps []Step
+	root  Step
+}
+
+// Across constructs an AcrossStep. The steps must be given in the same order
+// as the combinations of the vars' values, with the values of the last var
+// varying fastest.
+//
+// The combinations are run as nested InParallel steps, one level per var, so
+// that each var's MaxInFlight limits how many of its values are run at once.
+// A MaxInFlight of less than 1 means the values are run one at a time.
+func Across(vars []atc.AcrossVar, steps []Step, failFast bool) AcrossStep {
+	return AcrossStep{
+		steps: steps,
+		root:  acrossTree(vars, steps, failFast),
+	}
+}
+
+func acrossTree(vars []atc.AcrossVar, steps []Step, failFast bool) Step {
+	if len(vars) == 0 {
+		return steps[0]
+	}
+
+	numValues := len(vars[0].Values)
+
+	stepsPerValue := 0
+	if numValues > 0 {
+		stepsPerValue = len(steps) / numValues
+	}
+
+	children := make([]Step, numValues)
+	for i := range children {
+		children[i] = acrossTree(vars[1:], steps[i*stepsPerValue:(i+1)*stepsPerValue], failFast)
+	}
+
+	limit := vars[0].MaxInFlight
+	if limit < 1 {
+		limit = 1
+	}
+
+	return InParallel(children, limit, failFast)
+}
+
+// Run executes the steps, aborting any outstanding steps if fail fast is set
+// and a step fails.
+func (step AcrossStep) Run(ctx context.Context, state RunState) error {
+	return step.root.Run(ctx, state)
+}
+
+// Succeeded is true if all of the steps' Succeeded is true
+func (step AcrossStep) Succeeded() bool {
+	for _, s := range step.steps {
+		if !s.Succeeded() {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,163 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Across", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		vars      []atc.AcrossVar
+		fakeSteps []*execfakes.FakeStep
+		failFast  bool
+
+		state *execfakes.FakeRunState
+
+		step    Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		vars = []atc.AcrossVar{
+			{Var: "var1", Values: []interface{}{"a", "b"}},
+			{Var: "var2", Values: []interface{}{"1", "2"}, MaxInFlight: 2},
+		}
+
+		fakeSteps = nil
+		for i := 0; i < 4; i++ {
+			fakeStep := new(execfakes.FakeStep)
+			fakeStep.SucceededReturns(true)
+
+			fakeSteps = append(fakeSteps, fakeStep)
+		}
+
+		failFast = false
+
+		state = new(execfakes.FakeRunState)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		var steps []Step
+		for _, fakeStep := range fakeSteps {
+			steps = append(steps, fakeStep)
+		}
+
+		step = Across(vars, steps, failFast)
+		stepErr = step.Run(ctx, state)
+	})
+
+	It("runs every combination", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+
+		for _, fakeStep := range fakeSteps {
+			Expect(fakeStep.RunCallCount()).To(Equal(1))
+			_, runState := fakeStep.RunArgsForCall(0)
+			Expect(runState).To(Equal(state))
+		}
+	})
+
+	It("succeeds", func() {
+		Expect(step.Succeeded()).To(BeTrue())
+	})
+
+	Context("when a var's values are run one at a time", func() {
+		BeforeEach(func() {
+			vars[1].MaxInFlight = 0
+			ch := make(chan struct{}, 1)
+
+			fakeSteps[0].RunStub = func(context.Context, RunState) error {
+				time.Sleep(10 * time.Millisecond)
+				ch <- struct{}{}
+				return nil
+			}
+
+			fakeSteps[1].RunStub = func(context.Context, RunState) error {
+				defer GinkgoRecover()
+
+				select {
+				case <-ch:
+				default:
+					Fail("second combination started before the first could complete")
+				}
+				return nil
+			}
+		})
+
+		It("runs the combinations sequentially", func() {
+			Expect(fakeSteps[0].RunCallCount()).To(Equal(1))
+			Expect(fakeSteps[1].RunCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when a step fails", func() {
+		BeforeEach(func() {
+			fakeSteps[0].SucceededReturns(false)
+		})
+
+		It("runs the remaining combinations", func() {
+			for _, fakeStep := range fakeSteps {
+				Expect(fakeStep.RunCallCount()).To(Equal(1))
+			}
+		})
+
+		It("fails", func() {
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+
+		Context("when fail fast is set", func() {
+			BeforeEach(func() {
+				failFast = true
+			})
+
+			It("does not run the combinations for the other values", func() {
+				Expect(fakeSteps[2].RunCallCount()).To(BeZero())
+				Expect(fakeSteps[3].RunCallCount()).To(BeZero())
+			})
+
+			It("fails", func() {
+				Expect(step.Succeeded()).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when a step errors", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeSteps[3].RunReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(stepErr.Error()).To(ContainSubstring("nope"))
+		})
+	})
+
+	Context("when a var has no values", func() {
+		BeforeEach(func() {
+			vars[0].Values = nil
+			fakeSteps = nil
+		})
+
+		It("succeeds without running anything", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+	})
+})
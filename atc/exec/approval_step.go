@@ -0,0 +1,155 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate counterfeiter . ApprovalDelegate
+
+type ApprovalDelegate interface {
+	BuildStepDelegate
+
+	Initializing(lager.Logger)
+	Starting(lager.Logger, atc.BuildApproval)
+	Finished(lager.Logger, atc.BuildApproval, bool)
+}
+
+// ApprovalStep pauses the build until a user approves or rejects it through
+// the API. It holds no containers or volumes while waiting.
+type ApprovalStep struct {
+	planID    atc.PlanID
+	plan      atc.ApprovalPlan
+	metadata  StepMetadata
+	delegate  ApprovalDelegate
+	build     db.Build
+	clock     clock.Clock
+	succeeded bool
+}
+
+func NewApprovalStep(
+	planID atc.PlanID,
+	plan atc.ApprovalPlan,
+	metadata StepMetadata,
+	delegate ApprovalDelegate,
+	build db.Build,
+	clock clock.Clock,
+) Step {
+	return &ApprovalStep{
+		planID:   planID,
+		plan:     plan,
+		metadata: metadata,
+		delegate: delegate,
+		build:    build,
+		clock:    clock,
+	}
+}
+
+// Run requests approval and waits until it is decided. The request is
+// persisted, so when the build is resumed by another ATC the step picks up
+// the existing request, along with its deadline and any decision made in the
+// meantime.
+//
+// The step succeeds if the approval is granted, and fails if it is rejected
+// or nobody decided before the deadline.
+func (step *ApprovalStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("approval-step", lager.Data{
+		"step-name": step.plan.Name,
+		"job-id":    step.metadata.JobID,
+	})
+
+	step.delegate.Initializing(logger)
+
+	stdout := step.delegate.Stdout()
+
+	var deadline time.Time
+	if step.plan.Timeout != "" {
+		timeout, err := time.ParseDuration(step.plan.Timeout)
+		if err != nil {
+			return err
+		}
+
+		deadline = step.clock.Now().Add(timeout)
+	}
+
+	approval, err := step.build.RequestApproval(step.planID, step.plan, deadline)
+	if err != nil {
+		return err
+	}
+
+	notifier, err := step.build.ApprovalNotifier(step.planID)
+	if err != nil {
+		return err
+	}
+
+	defer notifier.Close()
+
+	step.delegate.Starting(logger, approval)
+
+	if approval.IsPending() {
+		fmt.Fprintf(stdout, "waiting for approval '%s'\n", step.plan.Name)
+	}
+
+	var expired <-chan time.Time
+	if approval.Deadline != 0 {
+		timer := step.clock.NewTimer(time.Unix(approval.Deadline, 0).Sub(step.clock.Now()))
+		defer timer.Stop()
+
+		expired = timer.C()
+	}
+
+	for approval.IsPending() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-notifier.Notify():
+
+		case <-expired:
+			_, err = step.build.ExpireApproval(step.planID)
+			if err != nil {
+				return err
+			}
+		}
+
+		var found bool
+		approval, found, err = step.build.Approval(step.planID)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			return db.ErrBuildDisappeared
+		}
+	}
+
+	switch approval.Status {
+	case atc.ApprovalStatusApproved:
+		fmt.Fprintf(stdout, "approved by %s\n", approval.DecidedBy)
+	case atc.ApprovalStatusRejected:
+		fmt.Fprintf(stdout, "rejected by %s\n", approval.DecidedBy)
+	case atc.ApprovalStatusExpired:
+		fmt.Fprintf(stdout, "nobody decided before the deadline\n")
+	}
+
+	if approval.Comment != "" {
+		fmt.Fprintf(stdout, "comment: %s\n", approval.Comment)
+	}
+
+	step.succeeded = approval.Status == atc.ApprovalStatusApproved
+	step.delegate.Finished(logger, approval, step.succeeded)
+
+	return nil
+}
+
+func (step *ApprovalStep) Succeeded() bool {
+	return step.succeeded
+}
@@ -0,0 +1,261 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("ApprovalStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeBuild    *dbfakes.FakeBuild
+		fakeNotifier *dbfakes.FakeNotifier
+		fakeDelegate *execfakes.FakeApprovalDelegate
+		fakeClock    *fakeclock.FakeClock
+
+		notify chan struct{}
+		stdout *gbytes.Buffer
+
+		plan    atc.ApprovalPlan
+		pending atc.BuildApproval
+
+		step    exec.Step
+		stepErr chan error
+	)
+
+	now := time.Date(2019, 12, 8, 12, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeClock = fakeclock.NewFakeClock(now)
+
+		notify = make(chan struct{}, 1)
+		fakeNotifier = new(dbfakes.FakeNotifier)
+		fakeNotifier.NotifyReturns(notify)
+
+		pending = atc.BuildApproval{
+			BuildID: 42,
+			PlanID:  "some-plan-id",
+			Name:    "deploy",
+			Status:  atc.ApprovalStatusPending,
+		}
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.RequestApprovalReturns(pending, nil)
+		fakeBuild.ApprovalReturns(pending, true, nil)
+		fakeBuild.ApprovalNotifierReturns(fakeNotifier, nil)
+
+		stdout = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeApprovalDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+
+		plan = atc.ApprovalPlan{
+			Name:         "deploy",
+			AllowedRoles: []string{"owner"},
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewApprovalStep(
+			"some-plan-id",
+			plan,
+			exec.StepMetadata{JobID: 1},
+			fakeDelegate,
+			fakeBuild,
+			fakeClock,
+		)
+
+		// steps from earlier specs return once cancelled; keep them from
+		// reporting to this spec
+		errs := make(chan error, 1)
+		go func(step exec.Step, ctx context.Context) {
+			errs <- step.Run(ctx, exec.NewRunState())
+		}(step, ctx)
+
+		stepErr = errs
+	})
+
+	It("requests approval without a deadline", func() {
+		Eventually(fakeBuild.RequestApprovalCallCount).Should(Equal(1))
+
+		planID, requestedPlan, deadline := fakeBuild.RequestApprovalArgsForCall(0)
+		Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+		Expect(requestedPlan).To(Equal(plan))
+		Expect(deadline).To(BeZero())
+	})
+
+	It("waits for a decision", func() {
+		Eventually(stdout).Should(gbytes.Say("waiting for approval 'deploy'"))
+		Expect(fakeDelegate.StartingCallCount()).To(Equal(1))
+		Consistently(stepErr).ShouldNot(Receive())
+	})
+
+	Context("when the approval is granted", func() {
+		BeforeEach(func() {
+			approved := pending
+			approved.Status = atc.ApprovalStatusApproved
+			approved.DecidedBy = "some-user"
+			approved.Comment = "ship it"
+
+			fakeBuild.ApprovalReturns(approved, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			Eventually(fakeDelegate.StartingCallCount).Should(Equal(1))
+			notify <- struct{}{}
+		})
+
+		It("succeeds", func() {
+			Eventually(stepErr).Should(Receive(BeNil()))
+			Expect(step.Succeeded()).To(BeTrue())
+
+			Expect(stdout).To(gbytes.Say("approved by some-user"))
+			Expect(stdout).To(gbytes.Say("comment: ship it"))
+
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, approval, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(approval.Status).To(Equal(atc.ApprovalStatusApproved))
+			Expect(succeeded).To(BeTrue())
+		})
+
+		It("stops listening for decisions", func() {
+			Eventually(stepErr).Should(Receive())
+			Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the approval is rejected", func() {
+		BeforeEach(func() {
+			rejected := pending
+			rejected.Status = atc.ApprovalStatusRejected
+			rejected.DecidedBy = "some-user"
+
+			fakeBuild.ApprovalReturns(rejected, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			Eventually(fakeDelegate.StartingCallCount).Should(Equal(1))
+			notify <- struct{}{}
+		})
+
+		It("fails", func() {
+			Eventually(stepErr).Should(Receive(BeNil()))
+			Expect(step.Succeeded()).To(BeFalse())
+
+			Expect(stdout).To(gbytes.Say("rejected by some-user"))
+
+			_, _, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+		})
+	})
+
+	Context("when the approval was decided before the step resumed", func() {
+		BeforeEach(func() {
+			approved := pending
+			approved.Status = atc.ApprovalStatusApproved
+			approved.DecidedBy = "some-user"
+
+			fakeBuild.RequestApprovalReturns(approved, nil)
+		})
+
+		It("succeeds without waiting", func() {
+			Eventually(stepErr).Should(Receive(BeNil()))
+			Expect(step.Succeeded()).To(BeTrue())
+			Expect(fakeBuild.ApprovalCallCount()).To(BeZero())
+			Expect(stdout).NotTo(gbytes.Say("waiting"))
+		})
+	})
+
+	Context("when a timeout is given", func() {
+		BeforeEach(func() {
+			plan.Timeout = "1h"
+
+			withDeadline := pending
+			withDeadline.Deadline = now.Add(time.Hour).Unix()
+
+			fakeBuild.RequestApprovalReturns(withDeadline, nil)
+
+			expired := withDeadline
+			expired.Status = atc.ApprovalStatusExpired
+
+			fakeBuild.ExpireApprovalStub = func(atc.PlanID) (bool, error) {
+				fakeBuild.ApprovalReturns(expired, true, nil)
+				return true, nil
+			}
+		})
+
+		It("requests approval with a deadline", func() {
+			Eventually(fakeBuild.RequestApprovalCallCount).Should(Equal(1))
+
+			_, _, deadline := fakeBuild.RequestApprovalArgsForCall(0)
+			Expect(deadline).To(Equal(now.Add(time.Hour)))
+		})
+
+		It("fails once the deadline passes", func() {
+			Eventually(fakeDelegate.StartingCallCount).Should(Equal(1))
+			Consistently(stepErr).ShouldNot(Receive())
+
+			fakeClock.WaitForWatcherAndIncrement(time.Hour)
+
+			Eventually(stepErr).Should(Receive(BeNil()))
+			Expect(fakeBuild.ExpireApprovalCallCount()).To(Equal(1))
+			Expect(fakeBuild.ExpireApprovalArgsForCall(0)).To(Equal(atc.PlanID("some-plan-id")))
+			Expect(step.Succeeded()).To(BeFalse())
+			Expect(stdout).To(gbytes.Say("nobody decided before the deadline"))
+		})
+	})
+
+	Context("when the timeout is invalid", func() {
+		BeforeEach(func() {
+			plan.Timeout = "bogus"
+		})
+
+		It("errors without requesting approval", func() {
+			Eventually(stepErr).Should(Receive(HaveOccurred()))
+			Expect(fakeBuild.RequestApprovalCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when requesting approval fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeBuild.RequestApprovalReturns(atc.BuildApproval{}, disaster)
+		})
+
+		It("errors", func() {
+			Eventually(stepErr).Should(Receive(Equal(disaster)))
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when the build is aborted while waiting", func() {
+		It("stops waiting", func() {
+			Eventually(fakeDelegate.StartingCallCount).Should(Equal(1))
+
+			cancel()
+
+			Eventually(stepErr).Should(Receive(Equal(context.Canceled)))
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+})
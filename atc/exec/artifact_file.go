@@ -0,0 +1,41 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc/exec/artifact"
+)
+
+// readArtifactFile reads a file from the artifact.Repository, given a path
+// whose first segment is the name of the artifact, e.g. some-input/foo.yml.
+func readArtifactFile(ctx context.Context, logger lager.Logger, repo *artifact.Repository, path string) ([]byte, error) {
+	segs := strings.SplitN(path, "/", 2)
+	if len(segs) != 2 {
+		return nil, UnspecifiedArtifactSourceError{path}
+	}
+
+	sourceName := artifact.Name(segs[0])
+	filePath := segs[1]
+
+	source, found := repo.SourceFor(sourceName)
+	if !found {
+		return nil, UnknownArtifactSourceError{sourceName, path}
+	}
+
+	stream, err := source.StreamFile(ctx, logger, filePath)
+	if err != nil {
+		if err == baggageclaim.ErrFileNotFound {
+			return nil, fmt.Errorf("file '%s/%s' not found", sourceName, filePath)
+		}
+		return nil, err
+	}
+
+	defer stream.Close()
+
+	return ioutil.ReadAll(stream)
+}
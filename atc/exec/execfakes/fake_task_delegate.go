@@ -42,6 +42,31 @@ type FakeTaskDelegate struct {
 		arg1 lager.Logger
 		arg2 atc.TaskConfig
 	}
+	SaveArchivedArtifactStub        func(lager.Logger, string, int64) error
+	saveArchivedArtifactMutex       sync.RWMutex
+	saveArchivedArtifactArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 int64
+	}
+	saveArchivedArtifactReturns struct {
+		result1 error
+	}
+	saveArchivedArtifactReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SaveTestResultsStub        func(lager.Logger, []atc.TestResult) error
+	saveTestResultsMutex       sync.RWMutex
+	saveTestResultsArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 []atc.TestResult
+	}
+	saveTestResultsReturns struct {
+		result1 error
+	}
+	saveTestResultsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	StartingStub        func(lager.Logger, atc.TaskConfig)
 	startingMutex       sync.RWMutex
 	startingArgsForCall []struct {
@@ -238,6 +263,134 @@ func (fake *FakeTaskDelegate) InitializingArgsForCall(i int) (lager.Logger, atc.
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeTaskDelegate) SaveArchivedArtifact(arg1 lager.Logger, arg2 string, arg3 int64) error {
+	fake.saveArchivedArtifactMutex.Lock()
+	ret, specificReturn := fake.saveArchivedArtifactReturnsOnCall[len(fake.saveArchivedArtifactArgsForCall)]
+	fake.saveArchivedArtifactArgsForCall = append(fake.saveArchivedArtifactArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 int64
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SaveArchivedArtifact", []interface{}{arg1, arg2, arg3})
+	fake.saveArchivedArtifactMutex.Unlock()
+	if fake.SaveArchivedArtifactStub != nil {
+		return fake.SaveArchivedArtifactStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveArchivedArtifactReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDelegate) SaveArchivedArtifactCallCount() int {
+	fake.saveArchivedArtifactMutex.RLock()
+	defer fake.saveArchivedArtifactMutex.RUnlock()
+	return len(fake.saveArchivedArtifactArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) SaveArchivedArtifactCalls(stub func(lager.Logger, string, int64) error) {
+	fake.saveArchivedArtifactMutex.Lock()
+	defer fake.saveArchivedArtifactMutex.Unlock()
+	fake.SaveArchivedArtifactStub = stub
+}
+
+func (fake *FakeTaskDelegate) SaveArchivedArtifactArgsForCall(i int) (lager.Logger, string, int64) {
+	fake.saveArchivedArtifactMutex.RLock()
+	defer fake.saveArchivedArtifactMutex.RUnlock()
+	argsForCall := fake.saveArchivedArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTaskDelegate) SaveArchivedArtifactReturns(result1 error) {
+	fake.saveArchivedArtifactMutex.Lock()
+	defer fake.saveArchivedArtifactMutex.Unlock()
+	fake.SaveArchivedArtifactStub = nil
+	fake.saveArchivedArtifactReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) SaveArchivedArtifactReturnsOnCall(i int, result1 error) {
+	fake.saveArchivedArtifactMutex.Lock()
+	defer fake.saveArchivedArtifactMutex.Unlock()
+	fake.SaveArchivedArtifactStub = nil
+	if fake.saveArchivedArtifactReturnsOnCall == nil {
+		fake.saveArchivedArtifactReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveArchivedArtifactReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) SaveTestResults(arg1 lager.Logger, arg2 []atc.TestResult) error {
+	var arg2Copy []atc.TestResult
+	if arg2 != nil {
+		arg2Copy = make([]atc.TestResult, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.saveTestResultsMutex.Lock()
+	ret, specificReturn := fake.saveTestResultsReturnsOnCall[len(fake.saveTestResultsArgsForCall)]
+	fake.saveTestResultsArgsForCall = append(fake.saveTestResultsArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 []atc.TestResult
+	}{arg1, arg2Copy})
+	fake.recordInvocation("SaveTestResults", []interface{}{arg1, arg2Copy})
+	fake.saveTestResultsMutex.Unlock()
+	if fake.SaveTestResultsStub != nil {
+		return fake.SaveTestResultsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveTestResultsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDelegate) SaveTestResultsCallCount() int {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	return len(fake.saveTestResultsArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) SaveTestResultsCalls(stub func(lager.Logger, []atc.TestResult) error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = stub
+}
+
+func (fake *FakeTaskDelegate) SaveTestResultsArgsForCall(i int) (lager.Logger, []atc.TestResult) {
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
+	argsForCall := fake.saveTestResultsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTaskDelegate) SaveTestResultsReturns(result1 error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = nil
+	fake.saveTestResultsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) SaveTestResultsReturnsOnCall(i int, result1 error) {
+	fake.saveTestResultsMutex.Lock()
+	defer fake.saveTestResultsMutex.Unlock()
+	fake.SaveTestResultsStub = nil
+	if fake.saveTestResultsReturnsOnCall == nil {
+		fake.saveTestResultsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveTestResultsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTaskDelegate) Starting(arg1 lager.Logger, arg2 atc.TaskConfig) {
 	fake.startingMutex.Lock()
 	fake.startingArgsForCall = append(fake.startingArgsForCall, struct {
@@ -437,6 +590,10 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.imageVersionDeterminedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.saveArchivedArtifactMutex.RLock()
+	defer fake.saveArchivedArtifactMutex.RUnlock()
+	fake.saveTestResultsMutex.RLock()
+	defer fake.saveTestResultsMutex.RUnlock()
 	fake.startingMutex.RLock()
 	defer fake.startingMutex.RUnlock()
 	fake.stderrMutex.RLock()
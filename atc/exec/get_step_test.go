@@ -185,7 +185,8 @@ var _ = Describe("GetStep", func() {
 
 			Expect(fakeResourceFetcher.FetchCallCount()).To(Equal(1))
 			fctx, _, actualContainerMetadata, actualWorker, actualContainerSpec, actualResourceTypes, resourceInstance, delegate := fakeResourceFetcher.FetchArgsForCall(0)
-			Expect(fctx).To(Equal(ctx))
+			// the context carries the step's span, but is still cancelled with ctx
+			Expect(fctx.Done()).To(BeIdenticalTo(ctx.Done()))
 			Expect(actualContainerMetadata).To(Equal(db.ContainerMetadata{
 				PipelineID:       4567,
 				Type:             db.ContainerTypeGet,
@@ -0,0 +1,136 @@
+package exec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"sigs.k8s.io/yaml"
+)
+
+//go:generate counterfeiter . LoadVarDelegate
+
+type LoadVarDelegate interface {
+	BuildStepDelegate
+
+	Initializing(lager.Logger)
+	Starting(lager.Logger)
+	Finished(lager.Logger, bool)
+}
+
+// LoadVarStep loads a value from a file in the artifact.Repository and adds it
+// to the build's local vars, so that later steps can refer to it as
+// ((.:name)).
+type LoadVarStep struct {
+	planID    atc.PlanID
+	plan      atc.LoadVarPlan
+	metadata  StepMetadata
+	delegate  LoadVarDelegate
+	succeeded bool
+}
+
+func NewLoadVarStep(
+	planID atc.PlanID,
+	plan atc.LoadVarPlan,
+	metadata StepMetadata,
+	delegate LoadVarDelegate,
+) Step {
+	return &LoadVarStep{
+		planID:   planID,
+		plan:     plan,
+		metadata: metadata,
+		delegate: delegate,
+	}
+}
+
+// Run reads the file and parses it according to the plan's format. If no
+// format is specified, it is inferred from the file extension: .json files
+// are parsed as JSON, .yml and .yaml files as YAML, and anything else is
+// trimmed of surrounding whitespace.
+//
+// Unless the plan reveals the value, it is redacted from build logs when
+// secret redaction is enabled.
+func (step *LoadVarStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("load-var-step", lager.Data{
+		"step-name": step.plan.Name,
+		"job-id":    step.metadata.JobID,
+	})
+
+	step.delegate.Initializing(logger)
+
+	stdout := step.delegate.Stdout()
+
+	step.delegate.Starting(logger)
+
+	content, err := readArtifactFile(ctx, logger, state.Artifacts(), step.plan.File)
+	if err != nil {
+		return err
+	}
+
+	value, err := step.parse(content)
+	if err != nil {
+		return err
+	}
+
+	step.delegate.Variables().AddLocalVar(step.plan.Name, value, !step.plan.Reveal)
+
+	fmt.Fprintf(stdout, "loaded var '%s' from '%s'\n", step.plan.Name, step.plan.File)
+
+	step.succeeded = true
+	step.delegate.Finished(logger, true)
+
+	return nil
+}
+
+func (step *LoadVarStep) Succeeded() bool {
+	return step.succeeded
+}
+
+func (step *LoadVarStep) parse(content []byte) (interface{}, error) {
+	format := step.plan.Format
+	if format == "" {
+		switch filepath.Ext(step.plan.File) {
+		case ".json":
+			format = atc.LoadVarFormatJSON
+		case ".yml", ".yaml":
+			format = atc.LoadVarFormatYAML
+		default:
+			format = atc.LoadVarFormatTrim
+		}
+	}
+
+	switch format {
+	case atc.LoadVarFormatRaw:
+		return string(content), nil
+
+	case atc.LoadVarFormatTrim:
+		return strings.TrimSpace(string(content)), nil
+
+	case atc.LoadVarFormatJSON:
+		var value interface{}
+		err := json.Unmarshal(content, &value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse '%s' as json: %s", step.plan.File, err)
+		}
+
+		return value, nil
+
+	case atc.LoadVarFormatYAML:
+		var value interface{}
+		err := yaml.Unmarshal(content, &value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse '%s' as yaml: %s", step.plan.File, err)
+		}
+
+		return value, nil
+
+	default:
+		return nil, fmt.Errorf("unknown format '%s' for file '%s'", format, step.plan.File)
+	}
+}
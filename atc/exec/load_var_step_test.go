@@ -0,0 +1,195 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("LoadVarStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeArtifactSource *workerfakes.FakeArtifactSource
+		fakeDelegate       *execfakes.FakeLoadVarDelegate
+
+		credVarsTracker vars.CredVarsTracker
+
+		stdout *gbytes.Buffer
+		stderr *gbytes.Buffer
+
+		state exec.RunState
+
+		plan atc.LoadVarPlan
+
+		step    exec.Step
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeArtifactSource = new(workerfakes.FakeArtifactSource)
+		fakeArtifactSource.StreamFileStub = func(_ context.Context, _ lager.Logger, path string) (io.ReadCloser, error) {
+			switch path {
+			case "version":
+				return gbytes.BufferWithBytes([]byte("1.2.3\n")), nil
+			case "info.json":
+				return gbytes.BufferWithBytes([]byte(`{"version": "1.2.3"}`)), nil
+			case "info.yml":
+				return gbytes.BufferWithBytes([]byte("version: 1.2.3\n")), nil
+			case "invalid.json":
+				return gbytes.BufferWithBytes([]byte(`{"version"`)), nil
+			default:
+				return nil, errors.New("unknown file")
+			}
+		}
+
+		state = exec.NewRunState()
+		state.Artifacts().RegisterSource("some-artifact", fakeArtifactSource)
+
+		stdout = gbytes.NewBuffer()
+		stderr = gbytes.NewBuffer()
+
+		credVarsTracker = vars.NewCredVarsTracker(vars.StaticVariables{}, true)
+
+		fakeDelegate = new(execfakes.FakeLoadVarDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StderrReturns(stderr)
+		fakeDelegate.VariablesReturns(credVarsTracker)
+
+		plan = atc.LoadVarPlan{
+			Name: "some-var",
+			File: "some-artifact/version",
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewLoadVarStep(
+			"some-plan-id",
+			plan,
+			exec.StepMetadata{JobID: 1},
+			fakeDelegate,
+		)
+
+		stepErr = step.Run(ctx, state)
+	})
+
+	localVar := func() interface{} {
+		val, found, err := credVarsTracker.Get(vars.VariableDefinition{
+			Source: vars.LocalVarSource,
+			Name:   "some-var",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+		return val
+	}
+
+	It("adds the trimmed file content as a local var", func() {
+		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(localVar()).To(Equal("1.2.3"))
+	})
+
+	It("redacts the value", func() {
+		mapit := vars.NewMapCredVarsTrackerIterator()
+		credVarsTracker.IterateInterpolatedCreds(mapit)
+		Expect(mapit.Data).To(ContainElement("1.2.3"))
+	})
+
+	It("succeeds", func() {
+		Expect(stdout).To(gbytes.Say("loaded var 'some-var' from 'some-artifact/version'"))
+
+		Expect(fakeDelegate.InitializingCallCount()).To(Equal(1))
+		Expect(fakeDelegate.StartingCallCount()).To(Equal(1))
+		Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+		_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+		Expect(succeeded).To(BeTrue())
+		Expect(step.Succeeded()).To(BeTrue())
+	})
+
+	Context("when the value is revealed", func() {
+		BeforeEach(func() {
+			plan.Reveal = true
+		})
+
+		It("does not redact the value", func() {
+			mapit := vars.NewMapCredVarsTrackerIterator()
+			credVarsTracker.IterateInterpolatedCreds(mapit)
+			Expect(mapit.Data).To(BeEmpty())
+		})
+	})
+
+	Context("when the format is raw", func() {
+		BeforeEach(func() {
+			plan.Format = "raw"
+		})
+
+		It("adds the file content as-is", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(localVar()).To(Equal("1.2.3\n"))
+		})
+	})
+
+	Context("when the file is json", func() {
+		BeforeEach(func() {
+			plan.File = "some-artifact/info.json"
+		})
+
+		It("adds the parsed content", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(localVar()).To(Equal(map[string]interface{}{"version": "1.2.3"}))
+		})
+	})
+
+	Context("when the file is yaml", func() {
+		BeforeEach(func() {
+			plan.File = "some-artifact/info.yml"
+		})
+
+		It("adds the parsed content", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(localVar()).To(Equal(map[string]interface{}{"version": "1.2.3"}))
+		})
+	})
+
+	Context("when the file cannot be parsed", func() {
+		BeforeEach(func() {
+			plan.File = "some-artifact/invalid.json"
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(HaveOccurred())
+			Expect(stepErr.Error()).To(ContainSubstring("failed to parse 'some-artifact/invalid.json' as json"))
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when the file's artifact source is unknown", func() {
+		BeforeEach(func() {
+			plan.File = "some-other-artifact/version"
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(Equal(exec.UnknownArtifactSourceError{
+				SourceName: "some-other-artifact",
+				ConfigPath: "some-other-artifact/version",
+			}))
+		})
+	})
+})
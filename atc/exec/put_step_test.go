@@ -266,7 +266,8 @@ var _ = Describe("PutStep", func() {
 			It("puts the resource with the given context", func() {
 				Expect(fakeResource.PutCallCount()).To(Equal(1))
 				putCtx, _, _, _ := fakeResource.PutArgsForCall(0)
-				Expect(putCtx).To(Equal(ctx))
+				// the context carries the step's span, but is still cancelled with ctx
+				Expect(putCtx.Done()).To(BeIdenticalTo(ctx.Done()))
 			})
 
 			It("puts the resource with the correct source and params", func() {
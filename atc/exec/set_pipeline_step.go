@@ -0,0 +1,235 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configdiff"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/vars"
+	"sigs.k8s.io/yaml"
+)
+
+//go:generate counterfeiter . SetPipelineDelegate
+
+type SetPipelineDelegate interface {
+	BuildStepDelegate
+
+	Initializing(lager.Logger)
+	Starting(lager.Logger)
+	Finished(lager.Logger, bool)
+	SetPipelineChanged(lager.Logger, bool)
+}
+
+// SetPipelineStep sets a pipeline within the build's team, using a config
+// file fetched from the artifact.Repository.
+type SetPipelineStep struct {
+	planID      atc.PlanID
+	plan        atc.SetPipelinePlan
+	metadata    StepMetadata
+	delegate    SetPipelineDelegate
+	teamFactory db.TeamFactory
+	succeeded   bool
+}
+
+func NewSetPipelineStep(
+	planID atc.PlanID,
+	plan atc.SetPipelinePlan,
+	metadata StepMetadata,
+	delegate SetPipelineDelegate,
+	teamFactory db.TeamFactory,
+) Step {
+	return &SetPipelineStep{
+		planID:      planID,
+		plan:        plan,
+		metadata:    metadata,
+		delegate:    delegate,
+		teamFactory: teamFactory,
+	}
+}
+
+// Run reads the pipeline config file (and any var files) out of the
+// artifact.Repository, interpolates the static vars into it and validates the
+// result.
+//
+// If the config differs from the pipeline's current config, the diff is
+// printed and the new config is saved, recording the build as the pipeline's
+// parent. Credentials are left uninterpolated so that they are resolved when
+// the pipeline runs.
+//
+// Invalid configuration causes the step to fail rather than error.
+func (step *SetPipelineStep) Run(ctx context.Context, state RunState) error {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("set-pipeline-step", lager.Data{
+		"step-name": step.plan.Name,
+		"job-id":    step.metadata.JobID,
+	})
+
+	step.delegate.Initializing(logger)
+
+	stdout := step.delegate.Stdout()
+	stderr := step.delegate.Stderr()
+
+	config, err := step.fetchConfig(ctx, logger, state.Artifacts())
+	if err != nil {
+		return err
+	}
+
+	warnings, errorMessages := config.Validate()
+	for _, warning := range warnings {
+		fmt.Fprintf(stderr, "WARNING: %s\n", warning.Message)
+	}
+
+	if len(errorMessages) > 0 {
+		fmt.Fprintln(stderr, "invalid pipeline:")
+
+		for _, message := range errorMessages {
+			fmt.Fprintf(stderr, "- %s\n", message)
+		}
+
+		step.delegate.Finished(logger, false)
+		return nil
+	}
+
+	step.delegate.Starting(logger)
+
+	team := step.teamFactory.GetByID(step.metadata.TeamID)
+
+	fromVersion := db.ConfigVersion(0)
+	existingConfig := atc.Config{}
+
+	pipelineRef := atc.PipelineRef{
+		Name:         step.plan.Name,
+		InstanceVars: step.plan.InstanceVars,
+	}
+
+	pipeline, found, err := team.Pipeline(pipelineRef)
+	if err != nil {
+		return err
+	}
+
+	if found {
+		fromVersion = pipeline.ConfigVersion()
+
+		existingConfig, err = step.pipelineConfig(pipeline)
+		if err != nil {
+			return err
+		}
+	}
+
+	diffExists := configdiff.Render(stdout, existingConfig, config)
+	if !diffExists {
+		logger.Debug("no-diff")
+
+		fmt.Fprintln(stdout, "no diff found.")
+		step.succeeded = true
+		step.delegate.SetPipelineChanged(logger, false)
+		step.delegate.Finished(logger, true)
+		return nil
+	}
+
+	fmt.Fprintf(stdout, "setting pipeline: %s\n", pipelineRef.String())
+	step.delegate.SetPipelineChanged(logger, true)
+
+	pipeline, created, err := team.SavePipeline(pipelineRef, config, fromVersion, false)
+	if err != nil {
+		return err
+	}
+
+	err = pipeline.SetParentIDs(step.metadata.JobID, step.metadata.BuildID)
+	if err != nil {
+		return err
+	}
+
+	if !created {
+		err = step.teamFactory.NotifyResourceScanner()
+		if err != nil {
+			logger.Error("failed-to-notify-resource-scanner", err)
+		}
+	}
+
+	fmt.Fprintln(stdout, "done")
+	logger.Info("saved-pipeline", lager.Data{"team": team.Name(), "pipeline": pipeline.Name()})
+
+	step.succeeded = true
+	step.delegate.Finished(logger, true)
+
+	return nil
+}
+
+func (step *SetPipelineStep) Succeeded() bool {
+	return step.succeeded
+}
+
+func (step *SetPipelineStep) fetchConfig(ctx context.Context, logger lager.Logger, repo *artifact.Repository) (atc.Config, error) {
+	content, err := readArtifactFile(ctx, logger, repo, step.plan.File)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	// instance vars take precedence over vars given in the plan, which take
+	// precedence over var files; var files specified later take precedence
+	// over those specified earlier
+	params := []vars.Variables{
+		vars.StaticVariables(step.plan.InstanceVars),
+		vars.StaticVariables(step.plan.Vars),
+	}
+
+	for i := len(step.plan.VarFiles) - 1; i >= 0; i-- {
+		path := step.plan.VarFiles[i]
+
+		payload, err := readArtifactFile(ctx, logger, repo, path)
+		if err != nil {
+			return atc.Config{}, err
+		}
+
+		var staticVars vars.StaticVariables
+		err = yaml.Unmarshal(payload, &staticVars)
+		if err != nil {
+			return atc.Config{}, fmt.Errorf("failed to unmarshal var file '%s': %s", path, err)
+		}
+
+		params = append(params, staticVars)
+	}
+
+	evaluated, err := vars.NewTemplateResolver(content, params).Resolve(false, false)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	var config atc.Config
+	err = yaml.Unmarshal(evaluated, &config)
+	if err != nil {
+		return atc.Config{}, fmt.Errorf("failed to unmarshal pipeline config '%s': %s", step.plan.File, err)
+	}
+
+	return config, nil
+}
+
+func (step *SetPipelineStep) pipelineConfig(pipeline db.Pipeline) (atc.Config, error) {
+	jobs, err := pipeline.Jobs()
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	resources, err := pipeline.Resources()
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	resourceTypes, err := pipeline.ResourceTypes()
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	return atc.Config{
+		Groups:        pipeline.Groups(),
+		Resources:     resources.Configs(),
+		ResourceTypes: resourceTypes.Configs(),
+		Jobs:          jobs.Configs(),
+	}, nil
+}
@@ -0,0 +1,358 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("SetPipelineStep", func() {
+	const pipelineYAML = `
+jobs:
+- name: some-job
+  plan:
+  - task: some-task
+    config:
+      platform: linux
+      image_resource:
+        type: registry-image
+        source: {repository: ((image))}
+      run: {path: echo}
+`
+
+	const varsYAML = `image: some-image-from-file`
+
+	const invalidYAML = `
+jobs:
+- plan: []
+`
+
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeTeamFactory    *dbfakes.FakeTeamFactory
+		fakeTeam           *dbfakes.FakeTeam
+		fakePipeline       *dbfakes.FakePipeline
+		fakeArtifactSource *workerfakes.FakeArtifactSource
+
+		fakeDelegate *execfakes.FakeSetPipelineDelegate
+
+		stdout *gbytes.Buffer
+		stderr *gbytes.Buffer
+
+		state exec.RunState
+
+		plan     atc.SetPipelinePlan
+		metadata exec.StepMetadata
+
+		step    exec.Step
+		stepErr error
+
+		expectedConfig atc.Config
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		fakeTeamFactory = new(dbfakes.FakeTeamFactory)
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeam.NameReturns("some-team")
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+
+		fakePipeline = new(dbfakes.FakePipeline)
+		fakePipeline.NameReturns("some-pipeline")
+		fakeTeam.SavePipelineReturns(fakePipeline, true, nil)
+
+		fakeArtifactSource = new(workerfakes.FakeArtifactSource)
+		fakeArtifactSource.StreamFileStub = func(_ context.Context, _ lager.Logger, path string) (io.ReadCloser, error) {
+			switch path {
+			case "pipeline.yml":
+				return gbytes.BufferWithBytes([]byte(pipelineYAML)), nil
+			case "vars.yml":
+				return gbytes.BufferWithBytes([]byte(varsYAML)), nil
+			case "invalid.yml":
+				return gbytes.BufferWithBytes([]byte(invalidYAML)), nil
+			default:
+				return nil, errors.New("unknown file")
+			}
+		}
+
+		state = exec.NewRunState()
+		state.Artifacts().RegisterSource("some-artifact", fakeArtifactSource)
+
+		stdout = gbytes.NewBuffer()
+		stderr = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeSetPipelineDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StderrReturns(stderr)
+
+		plan = atc.SetPipelinePlan{
+			Name: "some-pipeline",
+			File: "some-artifact/pipeline.yml",
+			Vars: atc.Params{"image": "some-image"},
+		}
+
+		metadata = exec.StepMetadata{
+			TeamID:  1,
+			JobID:   2,
+			BuildID: 3,
+		}
+
+		expectedConfig = atc.Config{
+			Jobs: atc.JobConfigs{
+				{
+					Name: "some-job",
+					Plan: atc.PlanSequence{
+						{
+							Task: "some-task",
+							TaskConfig: &atc.TaskConfig{
+								Platform: "linux",
+								ImageResource: &atc.ImageResource{
+									Type:   "registry-image",
+									Source: atc.Source{"repository": "some-image"},
+								},
+								Run: atc.TaskRunConfig{Path: "echo"},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewSetPipelineStep(
+			"some-plan-id",
+			plan,
+			metadata,
+			fakeDelegate,
+			fakeTeamFactory,
+		)
+
+		stepErr = step.Run(ctx, state)
+	})
+
+	Context("when the pipeline does not exist yet", func() {
+		BeforeEach(func() {
+			fakeTeam.PipelineReturns(nil, false, nil)
+		})
+
+		It("looks up the build's team", func() {
+			Expect(fakeTeamFactory.GetByIDArgsForCall(0)).To(Equal(1))
+		})
+
+		It("saves the interpolated config as an unpaused pipeline", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+
+			Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+			ref, config, from, paused := fakeTeam.SavePipelineArgsForCall(0)
+			Expect(ref).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+			Expect(config).To(Equal(expectedConfig))
+			Expect(from).To(Equal(db.ConfigVersion(0)))
+			Expect(paused).To(BeFalse())
+		})
+
+		It("records the job and build as the pipeline's parent", func() {
+			Expect(fakePipeline.SetParentIDsCallCount()).To(Equal(1))
+			jobID, buildID := fakePipeline.SetParentIDsArgsForCall(0)
+			Expect(jobID).To(Equal(2))
+			Expect(buildID).To(Equal(3))
+		})
+
+		It("prints the diff", func() {
+			Expect(stdout).To(gbytes.Say("jobs:"))
+			Expect(stdout).To(gbytes.Say("job some-job has been added:"))
+			Expect(stdout).To(gbytes.Say("setting pipeline: some-pipeline"))
+			Expect(stdout).To(gbytes.Say("done"))
+		})
+
+		It("emits the changed event", func() {
+			Expect(fakeDelegate.SetPipelineChangedCallCount()).To(Equal(1))
+			_, changed := fakeDelegate.SetPipelineChangedArgsForCall(0)
+			Expect(changed).To(BeTrue())
+		})
+
+		It("does not notify the resource scanner", func() {
+			Expect(fakeTeamFactory.NotifyResourceScannerCallCount()).To(BeZero())
+		})
+
+		It("succeeds", func() {
+			Expect(fakeDelegate.InitializingCallCount()).To(Equal(1))
+			Expect(fakeDelegate.StartingCallCount()).To(Equal(1))
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeTrue())
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+
+		Context("when var files are specified", func() {
+			BeforeEach(func() {
+				plan.Vars = nil
+				plan.VarFiles = []string{"some-artifact/vars.yml"}
+
+				expectedConfig.Jobs[0].Plan[0].TaskConfig.ImageResource.Source = atc.Source{
+					"repository": "some-image-from-file",
+				}
+			})
+
+			It("interpolates the vars from the files", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+
+				_, config, _, _ := fakeTeam.SavePipelineArgsForCall(0)
+				Expect(config).To(Equal(expectedConfig))
+			})
+		})
+
+		Context("when instance vars are specified", func() {
+			BeforeEach(func() {
+				plan.Vars = nil
+				plan.InstanceVars = atc.InstanceVars{"image": "some-instance-image"}
+
+				expectedConfig.Jobs[0].Plan[0].TaskConfig.ImageResource.Source = atc.Source{
+					"repository": "some-instance-image",
+				}
+			})
+
+			It("saves the pipeline instance with the instance vars interpolated", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+
+				ref, config, _, _ := fakeTeam.SavePipelineArgsForCall(0)
+				Expect(ref).To(Equal(atc.PipelineRef{
+					Name:         "some-pipeline",
+					InstanceVars: atc.InstanceVars{"image": "some-instance-image"},
+				}))
+				Expect(config).To(Equal(expectedConfig))
+			})
+
+			It("prints the instance being set", func() {
+				Expect(stdout).To(gbytes.Say("setting pipeline: some-pipeline/image:some-instance-image"))
+			})
+		})
+
+		Context("when saving the pipeline fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeTeam.SavePipelineReturns(nil, false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr).To(Equal(disaster))
+				Expect(step.Succeeded()).To(BeFalse())
+			})
+		})
+	})
+
+	Context("when the pipeline already exists", func() {
+		BeforeEach(func() {
+			existingPipeline := new(dbfakes.FakePipeline)
+			existingPipeline.ConfigVersionReturns(db.ConfigVersion(42))
+			fakeTeam.PipelineReturns(existingPipeline, true, nil)
+			fakeTeam.SavePipelineReturns(fakePipeline, false, nil)
+		})
+
+		It("saves the pipeline from the current config version", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+
+			Expect(fakeTeam.SavePipelineCallCount()).To(Equal(1))
+			_, _, from, _ := fakeTeam.SavePipelineArgsForCall(0)
+			Expect(from).To(Equal(db.ConfigVersion(42)))
+		})
+
+		It("notifies the resource scanner", func() {
+			Expect(fakeTeamFactory.NotifyResourceScannerCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the pipeline config has not changed", func() {
+		BeforeEach(func() {
+			existingPipeline := new(dbfakes.FakePipeline)
+			existingPipeline.JobsReturns(db.Jobs{fakeJobWithConfig(expectedConfig.Jobs[0])}, nil)
+			fakeTeam.PipelineReturns(existingPipeline, true, nil)
+		})
+
+		It("does not save the pipeline", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+			Expect(stdout).To(gbytes.Say("no diff found."))
+		})
+
+		It("emits the unchanged event", func() {
+			Expect(fakeDelegate.SetPipelineChangedCallCount()).To(Equal(1))
+			_, changed := fakeDelegate.SetPipelineChangedArgsForCall(0)
+			Expect(changed).To(BeFalse())
+		})
+
+		It("succeeds", func() {
+			Expect(step.Succeeded()).To(BeTrue())
+		})
+	})
+
+	Context("when the pipeline config is invalid", func() {
+		BeforeEach(func() {
+			plan.File = "some-artifact/invalid.yml"
+		})
+
+		It("does not save the pipeline", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(fakeTeam.SavePipelineCallCount()).To(BeZero())
+		})
+
+		It("fails", func() {
+			Expect(stderr).To(gbytes.Say("invalid pipeline:"))
+			Expect(stderr).To(gbytes.Say("- invalid jobs:\n\tjobs\\[0\\] has no name\n"))
+
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+			Expect(step.Succeeded()).To(BeFalse())
+		})
+	})
+
+	Context("when the config file's artifact source is unknown", func() {
+		BeforeEach(func() {
+			plan.File = "some-other-artifact/pipeline.yml"
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(Equal(exec.UnknownArtifactSourceError{
+				SourceName: "some-other-artifact",
+				ConfigPath: "some-other-artifact/pipeline.yml",
+			}))
+		})
+	})
+
+	Context("when the config file path does not specify an artifact", func() {
+		BeforeEach(func() {
+			plan.File = "pipeline.yml"
+		})
+
+		It("returns an error", func() {
+			Expect(stepErr).To(Equal(exec.UnspecifiedArtifactSourceError{Path: "pipeline.yml"}))
+		})
+	})
+})
+
+func fakeJobWithConfig(config atc.JobConfig) db.Job {
+	fakeJob := new(dbfakes.FakeJob)
+	fakeJob.ConfigReturns(config)
+	return fakeJob
+}
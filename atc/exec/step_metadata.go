@@ -2,6 +2,9 @@ package exec
 
 import (
 	"fmt"
+	"strconv"
+
+	"github.com/concourse/concourse/tracing"
 )
 
 type StepMetadata struct {
@@ -17,6 +20,7 @@ type StepMetadata struct {
 	ResourceConfigID      int
 	BaseResourceTypeID    int
 	ExternalURL           string
+	BuildPriority         int
 }
 
 func (metadata StepMetadata) Env() []string {
@@ -60,3 +64,21 @@ func (metadata StepMetadata) Env() []string {
 
 	return env
 }
+
+// tracingAttrs returns the given attrs along with the build's identifying
+// metadata, for attaching to a step's span.
+func (metadata StepMetadata) tracingAttrs(attrs tracing.Attrs) tracing.Attrs {
+	tracingAttrs := tracing.Attrs{
+		"build_id": strconv.Itoa(metadata.BuildID),
+		"build":    metadata.BuildName,
+		"team":     metadata.TeamName,
+		"pipeline": metadata.PipelineName,
+		"job":      metadata.JobName,
+	}
+
+	for key, value := range attrs {
+		tracingAttrs[key] = value
+	}
+
+	return tracingAttrs
+}
@@ -12,12 +12,16 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/blobstore"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/exec/artifact"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/atc/testreport"
 	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
 )
 
@@ -50,6 +54,16 @@ func (err TaskImageSourceParametersError) Error() string {
 	return fmt.Sprintf("failed to evaluate image resource parameters: %s", err.Err)
 }
 
+// PolicyCheckNotPassedError is returned when the policy checker denies
+// running the task.
+type PolicyCheckNotPassedError struct {
+	Reasons []string
+}
+
+func (err PolicyCheckNotPassedError) Error() string {
+	return fmt.Sprintf("policy check failed: %s", strings.Join(err.Reasons, ", "))
+}
+
 //go:generate counterfeiter . TaskDelegate
 
 type TaskDelegate interface {
@@ -58,6 +72,9 @@ type TaskDelegate interface {
 	Initializing(lager.Logger, atc.TaskConfig)
 	Starting(lager.Logger, atc.TaskConfig)
 	Finished(lager.Logger, ExitStatus)
+
+	SaveArchivedArtifact(lager.Logger, string, int64) error
+	SaveTestResults(lager.Logger, []atc.TestResult) error
 }
 
 // TaskStep executes a TaskConfig, whose inputs will be fetched from the
@@ -72,6 +89,8 @@ type TaskStep struct {
 	workerClient      worker.Client
 	delegate          TaskDelegate
 	lockFactory       lock.LockFactory
+	policyChecker     policy.Checker
+	artifactStore     blobstore.Store
 	succeeded         bool
 }
 
@@ -85,6 +104,8 @@ func NewTaskStep(
 	workerClient worker.Client,
 	delegate TaskDelegate,
 	lockFactory lock.LockFactory,
+	policyChecker policy.Checker,
+	artifactStore blobstore.Store,
 ) Step {
 	return &TaskStep{
 		planID:            planID,
@@ -96,6 +117,8 @@ func NewTaskStep(
 		workerClient:      workerClient,
 		delegate:          delegate,
 		lockFactory:       lockFactory,
+		policyChecker:     policyChecker,
+		artifactStore:     artifactStore,
 	}
 }
 
@@ -107,6 +130,10 @@ func NewTaskStep(
 // If any inputs are not available in the artifact.Repository, MissingInputsError
 // is returned.
 //
+// If policy checking is enabled for running tasks, the resolved TaskConfig is
+// checked before anything is run, and PolicyCheckNotPassedError is returned if
+// the policy denies it.
+//
 // Once all the inputs are satisfied, the task's script will be executed. If
 // the task is canceled via the context, the script will be interrupted.
 //
@@ -114,7 +141,24 @@ func NewTaskStep(
 // are registered with the artifact.Repository. If no outputs are specified, the
 // task's entire working directory is registered as an ArtifactSource under the
 // name of the task.
+//
+// Outputs marked to be archived are then persisted to the artifact store,
+// regardless of the script's exit status, and recorded against the build.
+// Likewise, the test reports declared on outputs are parsed and their results
+// recorded against the build. Missing or malformed reports only result in a
+// warning, as a failing script may well not have written them.
 func (step *TaskStep) Run(ctx context.Context, state RunState) error {
+	ctx, span := tracing.StartSpan(ctx, "task", step.metadata.tracingAttrs(tracing.Attrs{
+		"name": step.plan.Name,
+	}))
+
+	err := step.run(ctx, state)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (step *TaskStep) run(ctx context.Context, state RunState) error {
 	logger := lagerctx.FromContext(ctx)
 	logger = logger.Session("task-step", lager.Data{
 		"step-name": step.plan.Name,
@@ -173,6 +217,19 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 		config.Limits.Memory = step.defaultLimits.Memory
 	}
 
+	err = step.checkPolicy(config)
+	if err != nil {
+		return err
+	}
+
+	if step.artifactStore == nil {
+		for _, output := range config.Outputs {
+			if output.Archive {
+				fmt.Fprintf(step.delegate.Stderr(), "[WARNING] no artifact store configured; output '%s' will not be archived\n", output.Name)
+			}
+		}
+	}
+
 	step.delegate.Initializing(logger, config)
 
 	workerSpec, err := step.workerSpec(logger, resourceTypes, repository, config)
@@ -185,6 +242,9 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 		return err
 	}
 
+	// propagate the trace so that the task's own tooling can continue it
+	containerSpec.Env = append(containerSpec.Env, tracing.Env(ctx)...)
+
 	processSpec := worker.TaskProcessSpec{
 		Path:         config.Run.Path,
 		Args:         config.Run.Args,
@@ -243,13 +303,28 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 	}
 
 	step.succeeded = (result.Status == 0)
-	step.delegate.Finished(logger, ExitStatus(result.Status))
 
 	err = step.registerOutputs(logger, repository, config, result.VolumeMounts, step.containerMetadata)
 	if err != nil {
 		return err
 	}
 
+	// record the test results and archive the outputs before the step is
+	// seen as finished, so that a failure to do so is not reported after the
+	// task already finished; test results are recorded regardless of whether
+	// archiving succeeds
+	err = step.collectTestReports(ctx, logger, repository, config)
+	if err != nil {
+		return err
+	}
+
+	err = step.archiveOutputs(ctx, logger, repository, config)
+	if err != nil {
+		return err
+	}
+
+	step.delegate.Finished(logger, ExitStatus(result.Status))
+
 	// Do not initialize caches for one-off builds
 	if step.metadata.JobID != 0 {
 		err = step.registerCaches(logger, repository, config, result.VolumeMounts, step.containerMetadata)
@@ -262,6 +337,36 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 
 }
 
+func (step *TaskStep) checkPolicy(config atc.TaskConfig) error {
+	if step.policyChecker == nil || !step.policyChecker.ShouldCheckAction(policy.ActionRunTask) {
+		return nil
+	}
+
+	result, err := step.policyChecker.Check(policy.PolicyCheckInput{
+		Action:   policy.ActionRunTask,
+		Team:     step.metadata.TeamName,
+		Pipeline: step.metadata.PipelineName,
+		Data: map[string]interface{}{
+			"name":       step.plan.Name,
+			"privileged": step.plan.Privileged,
+			"config":     config,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("policy check error: %s", err)
+	}
+
+	if !result.Allowed {
+		if result.ShouldBlock {
+			return PolicyCheckNotPassedError{Reasons: result.Reasons}
+		}
+
+		fmt.Fprintln(step.delegate.Stderr(), "[WARNING] policy check:", strings.Join(result.Reasons, ", "))
+	}
+
+	return nil
+}
+
 func (step *TaskStep) Succeeded() bool {
 	return step.succeeded
 }
@@ -354,6 +459,7 @@ func (step *TaskStep) containerSpec(logger lager.Logger, repository *artifact.Re
 		Dir:       metadata.WorkingDirectory,
 		Env:       config.Params.Env(),
 		Type:      metadata.Type,
+		Priority:  step.metadata.BuildPriority,
 
 		Inputs:  []worker.InputSource{},
 		Outputs: worker.OutputPaths{},
@@ -414,6 +520,99 @@ func (step *TaskStep) registerOutputs(logger lager.Logger, repository *artifact.
 	return nil
 }
 
+func (step *TaskStep) archiveOutputs(ctx context.Context, logger lager.Logger, repository *artifact.Repository, config atc.TaskConfig) error {
+	if step.artifactStore == nil {
+		return nil
+	}
+
+	for _, output := range config.Outputs {
+		if !output.Archive {
+			continue
+		}
+
+		outputName := output.Name
+		if destinationName, ok := step.plan.OutputMapping[output.Name]; ok {
+			outputName = destinationName
+		}
+
+		source, found := repository.SourceFor(artifact.Name(outputName))
+		if !found {
+			continue
+		}
+
+		logger.Debug("archiving-output", lager.Data{"output": outputName})
+
+		destination := &archiveDestination{
+			store: step.artifactStore,
+			key:   blobstore.ArtifactKey(step.metadata.BuildID, step.planID, outputName),
+		}
+
+		err := source.StreamTo(ctx, logger, destination)
+		if err != nil {
+			return fmt.Errorf("failed to archive output '%s': %s", outputName, err)
+		}
+
+		err = step.delegate.SaveArchivedArtifact(logger, outputName, destination.size)
+		if err != nil {
+			return fmt.Errorf("failed to record archived output '%s': %s", outputName, err)
+		}
+	}
+
+	return nil
+}
+
+func (step *TaskStep) collectTestReports(ctx context.Context, logger lager.Logger, repository *artifact.Repository, config atc.TaskConfig) error {
+	results := []atc.TestResult{}
+
+	for _, output := range config.Outputs {
+		if len(output.Reports) == 0 {
+			continue
+		}
+
+		outputName := output.Name
+		if destinationName, ok := step.plan.OutputMapping[output.Name]; ok {
+			outputName = destinationName
+		}
+
+		source, found := repository.SourceFor(artifact.Name(outputName))
+		if !found {
+			continue
+		}
+
+		for _, report := range output.Reports {
+			reportResults, err := step.parseTestReport(ctx, logger, source, report)
+			if err != nil {
+				fmt.Fprintf(step.delegate.Stderr(), "[WARNING] failed to read test report '%s/%s': %s\n", outputName, report.Path, err)
+				continue
+			}
+
+			results = append(results, reportResults...)
+		}
+	}
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	err := step.delegate.SaveTestResults(logger, results)
+	if err != nil {
+		return fmt.Errorf("failed to record test results: %s", err)
+	}
+
+	return nil
+}
+
+func (step *TaskStep) parseTestReport(ctx context.Context, logger lager.Logger, source worker.ArtifactSource, report atc.TaskReportConfig) ([]atc.TestResult, error) {
+	stream, err := source.StreamFile(ctx, logger, report.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer stream.Close()
+
+	return testreport.Parse(report.Format, stream)
+}
+
 func (step *TaskStep) registerCaches(logger lager.Logger, repository *artifact.Repository, config atc.TaskConfig, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) error {
 	logger.Debug("initializing-caches", lager.Data{"caches": config.Caches})
 
@@ -465,6 +664,38 @@ func (src *taskArtifactSource) VolumeOn(logger lager.Logger, w worker.Worker) (w
 	return w.LookupVolume(logger, src.Handle())
 }
 
+// archiveDestination receives the compressed stream of an artifact and puts
+// it in the artifact store as-is, counting its size along the way.
+type archiveDestination struct {
+	store blobstore.Store
+	key   string
+	size  int64
+}
+
+func (dest *archiveDestination) StreamIn(ctx context.Context, path string, src io.Reader) error {
+	counter := &countingReader{reader: src}
+
+	err := dest.store.Put(ctx, dest.key, counter)
+	if err != nil {
+		return err
+	}
+
+	dest.size = counter.count
+
+	return nil
+}
+
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
 type taskInputSource struct {
 	config        atc.TaskInputConfig
 	source        worker.ArtifactSource
@@ -16,14 +16,21 @@ import (
 	"github.com/onsi/gomega/gbytes"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/blobstore"
+	"github.com/concourse/concourse/atc/blobstore/blobstorefakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/artifact"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
+	"go.opentelemetry.io/otel/api/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 var _ = Describe("TaskStep", func() {
@@ -40,6 +47,11 @@ var _ = Describe("TaskStep", func() {
 
 		fakeLockFactory *lockfakes.FakeLockFactory
 
+		fakePolicyChecker *policyfakes.FakeChecker
+
+		fakeArtifactStore *blobstorefakes.FakeStore
+		artifactStore     blobstore.Store
+
 		fakeDelegate *execfakes.FakeTaskDelegate
 		taskPlan     *atc.TaskPlan
 
@@ -60,9 +72,11 @@ var _ = Describe("TaskStep", func() {
 		}
 
 		stepMetadata = exec.StepMetadata{
-			TeamID:  123,
-			BuildID: 1234,
-			JobID:   12345,
+			TeamID:       123,
+			TeamName:     "some-team",
+			BuildID:      1234,
+			JobID:        12345,
+			PipelineName: "some-pipeline",
 		}
 
 		planID = atc.PlanID(42)
@@ -80,6 +94,11 @@ var _ = Describe("TaskStep", func() {
 
 		fakeLockFactory = new(lockfakes.FakeLockFactory)
 
+		fakePolicyChecker = new(policyfakes.FakeChecker)
+
+		fakeArtifactStore = new(blobstorefakes.FakeStore)
+		artifactStore = fakeArtifactStore
+
 		credVars := vars.StaticVariables{"source-param": "super-secret-source"}
 		credVarsTracker = vars.NewCredVarsTracker(credVars, true)
 
@@ -141,6 +160,8 @@ var _ = Describe("TaskStep", func() {
 			fakeClient,
 			fakeDelegate,
 			fakeLockFactory,
+			fakePolicyChecker,
+			artifactStore,
 		)
 
 		stepErr = taskStep.Run(ctx, state)
@@ -238,6 +259,23 @@ var _ = Describe("TaskStep", func() {
 			Expect(containerSpec.User).To(BeEmpty())
 		})
 
+		Context("when the build has a priority", func() {
+			BeforeEach(func() {
+				stepMetadata.BuildPriority = 10
+			})
+
+			AfterEach(func() {
+				stepMetadata.BuildPriority = 0
+			})
+
+			It("places the container with the build's priority", func() {
+				Expect(fakeClient.RunTaskStepCallCount()).To(Equal(1))
+
+				_, _, _, _, containerSpec, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+				Expect(containerSpec.Priority).To(Equal(10))
+			})
+		})
+
 		It("creates the task process spec with the correct parameters", func() {
 			Expect(fakeClient.RunTaskStepCallCount()).To(Equal(1))
 
@@ -248,6 +286,95 @@ var _ = Describe("TaskStep", func() {
 			Expect(taskProcessSpec.Args).To(Equal([]string{"some", "args"}))
 		})
 
+		Context("when tracing is configured", func() {
+			BeforeEach(func() {
+				provider, err := sdktrace.NewProvider()
+				Expect(err).ToNot(HaveOccurred())
+
+				tracing.ConfigureTraceProvider(provider)
+			})
+
+			AfterEach(func() {
+				tracing.ConfigureTraceProvider(trace.NoopProvider{})
+			})
+
+			It("propagates the trace context to the task container", func() {
+				Expect(fakeClient.RunTaskStepCallCount()).To(Equal(1))
+				_, _, _, _, containerSpec, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+				Expect(containerSpec.Env).To(ContainElement(MatchRegexp("^TRACEPARENT=00-[0-9a-f]{32}-[0-9a-f]{16}-01$")))
+			})
+		})
+
+		It("does not check policy by default", func() {
+			Expect(fakePolicyChecker.CheckCallCount()).To(BeZero())
+		})
+
+		Context("when policy checking is enabled for tasks", func() {
+			BeforeEach(func() {
+				fakePolicyChecker.ShouldCheckActionStub = func(action string) bool {
+					return action == policy.ActionRunTask
+				}
+				fakePolicyChecker.CheckReturns(policy.PassedPolicyCheck, nil)
+			})
+
+			It("checks the resolved config before running", func() {
+				Expect(fakePolicyChecker.CheckCallCount()).To(Equal(1))
+				input := fakePolicyChecker.CheckArgsForCall(0)
+				Expect(input.Action).To(Equal(policy.ActionRunTask))
+				Expect(input.Team).To(Equal("some-team"))
+				Expect(input.Pipeline).To(Equal("some-pipeline"))
+
+				data := input.Data.(map[string]interface{})
+				Expect(data["name"]).To(Equal("some-task"))
+				Expect(data["privileged"]).To(BeFalse())
+				Expect(data["config"].(atc.TaskConfig).Run.Path).To(Equal("ls"))
+
+				Expect(fakeClient.RunTaskStepCallCount()).To(Equal(1))
+			})
+
+			Context("when the policy denies the task", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckReturns(policy.PolicyCheckOutput{
+						Allowed:     false,
+						ShouldBlock: true,
+						Reasons:     []string{"no privileged tasks"},
+					}, nil)
+				})
+
+				It("returns an error without running the task", func() {
+					Expect(stepErr).To(Equal(exec.PolicyCheckNotPassedError{Reasons: []string{"no privileged tasks"}}))
+					Expect(stepErr).To(MatchError("policy check failed: no privileged tasks"))
+					Expect(fakeClient.RunTaskStepCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the policy only warns about the task", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckReturns(policy.PolicyCheckOutput{
+						Allowed:     false,
+						ShouldBlock: false,
+						Reasons:     []string{"consider not doing that"},
+					}, nil)
+				})
+
+				It("prints a warning and runs the task", func() {
+					Expect(stderrBuf).To(gbytes.Say(`\[WARNING\] policy check: consider not doing that`))
+					Expect(fakeClient.RunTaskStepCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the policy check errors", func() {
+				BeforeEach(func() {
+					fakePolicyChecker.CheckReturns(policy.PolicyCheckOutput{}, errors.New("agent unavailable"))
+				})
+
+				It("returns the error", func() {
+					Expect(stepErr).To(MatchError("policy check error: agent unavailable"))
+					Expect(fakeClient.RunTaskStepCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when privileged", func() {
 			BeforeEach(func() {
 				taskPlan.Privileged = true
@@ -1191,5 +1318,283 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when outputs are marked to be archived", func() {
+			var (
+				fakeVolume *workerfakes.FakeVolume
+
+				finishedAfterArchiving bool
+			)
+
+			BeforeEach(func() {
+				finishedAfterArchiving = false
+				fakeDelegate.FinishedStub = func(lager.Logger, exec.ExitStatus) {
+					finishedAfterArchiving = fakeDelegate.SaveArchivedArtifactCallCount() > 0
+				}
+
+				taskPlan.OutputMapping = map[string]string{"generic-output": "specific-output"}
+				taskPlan.Config = &atc.TaskConfig{
+					Platform: "some-platform",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+					Outputs: []atc.TaskOutputConfig{
+						{Name: "generic-output", Archive: true},
+						{Name: "some-other-output"},
+					},
+				}
+
+				fakeVolume = new(workerfakes.FakeVolume)
+				fakeVolume.HandleReturns("some-handle")
+				fakeVolume.StreamOutReturns(ioutil.NopCloser(strings.NewReader("some-archive")), nil)
+
+				fakeOtherVolume := new(workerfakes.FakeVolume)
+				fakeOtherVolume.HandleReturns("some-other-handle")
+
+				fakeClient.RunTaskStepReturns(worker.TaskResult{
+					Status: 0,
+					VolumeMounts: []worker.VolumeMount{
+						{
+							Volume:    fakeVolume,
+							MountPath: "some-artifact-root/generic-output/",
+						},
+						{
+							Volume:    fakeOtherVolume,
+							MountPath: "some-artifact-root/some-other-output/",
+						},
+					},
+				})
+
+				fakeArtifactStore.PutStub = func(ctx context.Context, key string, src io.Reader) error {
+					_, err := ioutil.ReadAll(src)
+					return err
+				}
+			})
+
+			It("puts the output in the artifact store under its mapped name", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+
+				Expect(fakeVolume.StreamOutCallCount()).To(Equal(1))
+				_, path := fakeVolume.StreamOutArgsForCall(0)
+				Expect(path).To(Equal("."))
+
+				Expect(fakeArtifactStore.PutCallCount()).To(Equal(1))
+				_, key, _ := fakeArtifactStore.PutArgsForCall(0)
+				Expect(key).To(Equal("builds/1234/artifacts/" + string(planID) + "/specific-output.tar.zst"))
+			})
+
+			It("archives the outputs before the step is finished", func() {
+				Expect(fakeDelegate.SaveArchivedArtifactCallCount()).To(Equal(1))
+				Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+				Expect(finishedAfterArchiving).To(BeTrue())
+			})
+
+			It("records the archived artifact with its size", func() {
+				Expect(fakeDelegate.SaveArchivedArtifactCallCount()).To(Equal(1))
+				_, name, size := fakeDelegate.SaveArchivedArtifactArgsForCall(0)
+				Expect(name).To(Equal("specific-output"))
+				Expect(size).To(Equal(int64(len("some-archive"))))
+			})
+
+			Context("when putting the output in the artifact store fails", func() {
+				BeforeEach(func() {
+					fakeArtifactStore.PutStub = nil
+					fakeArtifactStore.PutReturns(errors.New("nope"))
+				})
+
+				It("returns an error", func() {
+					Expect(stepErr).To(MatchError("failed to archive output 'specific-output': nope"))
+				})
+
+				It("does not record the archived artifact", func() {
+					Expect(fakeDelegate.SaveArchivedArtifactCallCount()).To(BeZero())
+				})
+
+				It("does not finish the step", func() {
+					Expect(fakeDelegate.FinishedCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when recording the archived artifact fails", func() {
+				BeforeEach(func() {
+					fakeDelegate.SaveArchivedArtifactReturns(errors.New("nope"))
+				})
+
+				It("returns an error", func() {
+					Expect(stepErr).To(MatchError("failed to record archived output 'specific-output': nope"))
+				})
+			})
+
+			Context("when no artifact store is configured", func() {
+				BeforeEach(func() {
+					artifactStore = nil
+				})
+
+				It("warns that the output will not be archived", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stderrBuf).To(gbytes.Say(`\[WARNING\] no artifact store configured; output 'generic-output' will not be archived`))
+				})
+
+				It("still registers the output", func() {
+					_, found := repo.SourceFor("specific-output")
+					Expect(found).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when outputs declare test reports", func() {
+			var (
+				fakeVolume *workerfakes.FakeVolume
+				reports    map[string]string
+
+				finishedAfterSavingResults bool
+			)
+
+			BeforeEach(func() {
+				finishedAfterSavingResults = false
+				fakeDelegate.FinishedStub = func(lager.Logger, exec.ExitStatus) {
+					finishedAfterSavingResults = fakeDelegate.SaveTestResultsCallCount() > 0
+				}
+
+				taskPlan.OutputMapping = map[string]string{"generic-output": "specific-output"}
+				taskPlan.Config = &atc.TaskConfig{
+					Platform: "some-platform",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+					Outputs: []atc.TaskOutputConfig{
+						{
+							Name: "generic-output",
+							Reports: []atc.TaskReportConfig{
+								{Path: "unit.xml"},
+								{Path: "integration.xml", Format: atc.TaskReportFormatJUnit},
+							},
+						},
+					},
+				}
+
+				reports = map[string]string{
+					"unit.xml":        `<testsuite name="unit"><testcase name="adds"/><testcase name="divides"><failure message="nope"/></testcase></testsuite>`,
+					"integration.xml": `<testsuites><testsuite name="integration"><testcase name="talks"/></testsuite></testsuites>`,
+				}
+
+				fakeVolume = new(workerfakes.FakeVolume)
+				fakeVolume.HandleReturns("some-handle")
+				fakeVolume.StreamOutStub = func(ctx context.Context, path string) (io.ReadCloser, error) {
+					buffer := gbytes.NewBuffer()
+
+					content, found := reports[path]
+					if !found {
+						return buffer, nil
+					}
+
+					zstdWriter := zstd.NewWriter(buffer)
+					tarWriter := tar.NewWriter(zstdWriter)
+
+					err := tarWriter.WriteHeader(&tar.Header{
+						Name: path,
+						Mode: 0644,
+						Size: int64(len(content)),
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = tarWriter.Write([]byte(content))
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(tarWriter.Close()).To(Succeed())
+					Expect(zstdWriter.Close()).To(Succeed())
+
+					return buffer, nil
+				}
+
+				fakeClient.RunTaskStepReturns(worker.TaskResult{
+					Status: 1,
+					VolumeMounts: []worker.VolumeMount{
+						{
+							Volume:    fakeVolume,
+							MountPath: "some-artifact-root/generic-output/",
+						},
+					},
+				})
+			})
+
+			It("records the results of every report, even though the task failed", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+
+				Expect(fakeDelegate.SaveTestResultsCallCount()).To(Equal(1))
+				_, results := fakeDelegate.SaveTestResultsArgsForCall(0)
+				Expect(results).To(Equal([]atc.TestResult{
+					{Suite: "unit", Name: "adds", Status: atc.TestStatusPassed},
+					{Suite: "unit", Name: "divides", Status: atc.TestStatusFailed, Message: "nope"},
+					{Suite: "integration", Name: "talks", Status: atc.TestStatusPassed},
+				}))
+			})
+
+			It("records the results before the step is finished", func() {
+				Expect(fakeDelegate.SaveTestResultsCallCount()).To(Equal(1))
+				Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+				Expect(finishedAfterSavingResults).To(BeTrue())
+			})
+
+			Context("when archiving the outputs fails", func() {
+				BeforeEach(func() {
+					taskPlan.Config.Outputs[0].Archive = true
+					fakeArtifactStore.PutReturns(errors.New("nope"))
+				})
+
+				It("still records the results", func() {
+					Expect(stepErr).To(MatchError("failed to archive output 'specific-output': nope"))
+					Expect(fakeDelegate.SaveTestResultsCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when a report is missing", func() {
+				BeforeEach(func() {
+					delete(reports, "integration.xml")
+				})
+
+				It("warns and records the results of the other reports", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stderrBuf).To(gbytes.Say(`\[WARNING\] failed to read test report 'specific-output/integration.xml': file not found: integration.xml`))
+
+					_, results := fakeDelegate.SaveTestResultsArgsForCall(0)
+					Expect(results).To(HaveLen(2))
+				})
+			})
+
+			Context("when a report is malformed", func() {
+				BeforeEach(func() {
+					reports["unit.xml"] = "not xml"
+				})
+
+				It("warns and records the results of the other reports", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(stderrBuf).To(gbytes.Say(`\[WARNING\] failed to read test report 'specific-output/unit.xml': malformed JUnit report`))
+
+					_, results := fakeDelegate.SaveTestResultsArgsForCall(0)
+					Expect(results).To(HaveLen(1))
+				})
+			})
+
+			Context("when no report could be read", func() {
+				BeforeEach(func() {
+					reports = map[string]string{}
+				})
+
+				It("does not record any results", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(fakeDelegate.SaveTestResultsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when recording the results fails", func() {
+				BeforeEach(func() {
+					fakeDelegate.SaveTestResultsReturns(errors.New("nope"))
+				})
+
+				It("returns an error", func() {
+					Expect(stepErr).To(MatchError("failed to record test results: nope"))
+				})
+			})
+		})
 	})
 })
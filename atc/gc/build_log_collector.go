@@ -8,6 +8,7 @@ import (
 
 	"time"
 
+	"github.com/concourse/concourse/atc/blobstore"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -16,6 +17,7 @@ type buildLogCollector struct {
 	batchSize                   int
 	drainerConfigured           bool
 	buildLogRetentionCalculator BuildLogRetentionCalculator
+	artifactStore               blobstore.Store
 }
 
 func NewBuildLogCollector(
@@ -23,12 +25,14 @@ func NewBuildLogCollector(
 	batchSize int,
 	buildLogRetentionCalculator BuildLogRetentionCalculator,
 	drainerConfigured bool,
+	artifactStore blobstore.Store,
 ) Collector {
 	return &buildLogCollector{
 		pipelineFactory:             pipelineFactory,
 		batchSize:                   batchSize,
 		drainerConfigured:           drainerConfigured,
 		buildLogRetentionCalculator: buildLogRetentionCalculator,
+		artifactStore:               artifactStore,
 	}
 }
 
@@ -118,6 +122,7 @@ func (br *buildLogCollector) Run(ctx context.Context) error {
 				firstBuildToRetain = buildsToRetain[len(buildsToRetain)-1].ID()
 			}
 
+			buildsToDelete := []db.Build{}
 			buildIDsToDelete := []int{}
 			for i := len(buildsToConsiderDeleting) - 1; i >= 0; i-- {
 				build := buildsToConsiderDeleting[i]
@@ -138,6 +143,7 @@ func (br *buildLogCollector) Run(ctx context.Context) error {
 					}
 				}
 
+				buildsToDelete = append(buildsToDelete, build)
 				buildIDsToDelete = append(buildIDsToDelete, build.ID())
 			}
 
@@ -150,6 +156,11 @@ func (br *buildLogCollector) Run(ctx context.Context) error {
 				"build-ids": buildIDsToDelete,
 			})
 
+			err = br.deleteArchivedArtifacts(ctx, logger, buildsToDelete)
+			if err != nil {
+				return err
+			}
+
 			err = pipeline.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
 			if err != nil {
 				logger.Error("failed-to-delete-build-events", err)
@@ -166,3 +177,47 @@ func (br *buildLogCollector) Run(ctx context.Context) error {
 
 	return nil
 }
+
+// deleteArchivedArtifacts reaps the archived artifacts of builds along with
+// their logs. The blobs are deleted before the records so that a failure
+// part-way through leaves nothing orphaned in the artifact store.
+//
+// Without an artifact store the records are left alone, as they are all that
+// is left to find the blobs by once a store is configured again.
+func (br *buildLogCollector) deleteArchivedArtifacts(ctx context.Context, logger lager.Logger, builds []db.Build) error {
+	if br.artifactStore == nil {
+		return nil
+	}
+
+	for _, build := range builds {
+		artifacts, err := build.ArchivedArtifacts()
+		if err != nil {
+			logger.Error("failed-to-get-archived-artifacts", err)
+			return err
+		}
+
+		if len(artifacts) == 0 {
+			continue
+		}
+
+		for _, artifact := range artifacts {
+			err = br.artifactStore.Delete(ctx, blobstore.ArtifactKey(build.ID(), artifact.PlanID, artifact.Name))
+			if err != nil {
+				logger.Error("failed-to-delete-archived-artifact", err, lager.Data{
+					"build-id": build.ID(),
+					"plan-id":  artifact.PlanID,
+					"artifact": artifact.Name,
+				})
+				return err
+			}
+		}
+
+		err = build.DeleteArchivedArtifacts()
+		if err != nil {
+			logger.Error("failed-to-delete-archived-artifact-records", err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/blobstore/blobstorefakes"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/gc"
@@ -21,12 +22,14 @@ var _ = Describe("BuildLogCollector", func() {
 		fakePipelineFactory *dbfakes.FakePipelineFactory
 		batchSize           int
 		buildLogRetainCalc  BuildLogRetentionCalculator
+		fakeArtifactStore   *blobstorefakes.FakeStore
 	)
 
 	BeforeEach(func() {
 		fakePipelineFactory = new(dbfakes.FakePipelineFactory)
 		batchSize = 5
 		buildLogRetainCalc = NewBuildLogRetentionCalculator(0, 0, 0, 0)
+		fakeArtifactStore = new(blobstorefakes.FakeStore)
 	})
 
 	JustBeforeEach(func() {
@@ -35,6 +38,7 @@ var _ = Describe("BuildLogCollector", func() {
 			batchSize,
 			buildLogRetainCalc,
 			false,
+			fakeArtifactStore,
 		)
 	})
 
@@ -83,6 +87,7 @@ var _ = Describe("BuildLogCollector", func() {
 						batchSize,
 						buildLogRetainCalc,
 						true,
+						fakeArtifactStore,
 					)
 				})
 				BeforeEach(func() {
@@ -142,6 +147,93 @@ var _ = Describe("BuildLogCollector", func() {
 					Expect(fakePipeline.DeleteBuildEventsByBuildIDsArgsForCall(0)).To(ConsistOf(1, 2, 3, 4, 5))
 				})
 			})
+
+			Context("when the builds being reaped have archived artifacts", func() {
+				var buildWithArtifacts *dbfakes.FakeBuild
+
+				BeforeEach(func() {
+					buildWithArtifacts = new(dbfakes.FakeBuild)
+					buildWithArtifacts.IDReturns(4)
+					buildWithArtifacts.ArchivedArtifactsReturns([]db.ArchivedArtifact{
+						{PlanID: "some-plan", Name: "some-artifact"},
+						{PlanID: "some-other-plan", Name: "some-artifact"},
+					}, nil)
+
+					fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
+						if page == (db.Page{Until: 5, Limit: 5}) {
+							return []db.Build{sb(1), sb(2), sb(3), buildWithArtifacts, sb(5)}, db.Pagination{}, nil
+						} else if page == (db.Page{Limit: 10}) {
+							return []db.Build{sb(6)}, db.Pagination{}, nil
+						}
+						return []db.Build{}, db.Pagination{}, nil
+					}
+				})
+
+				It("deletes the artifacts from the artifact store", func() {
+					err := buildLogCollector.Run(context.TODO())
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeArtifactStore.DeleteCallCount()).To(Equal(2))
+					_, key := fakeArtifactStore.DeleteArgsForCall(0)
+					Expect(key).To(Equal("builds/4/artifacts/some-plan/some-artifact.tar.zst"))
+					_, key = fakeArtifactStore.DeleteArgsForCall(1)
+					Expect(key).To(Equal("builds/4/artifacts/some-other-plan/some-artifact.tar.zst"))
+				})
+
+				It("deletes the archived artifact records", func() {
+					err := buildLogCollector.Run(context.TODO())
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(buildWithArtifacts.DeleteArchivedArtifactsCallCount()).To(Equal(1))
+				})
+
+				Context("when deleting an artifact from the store fails", func() {
+					var disaster error
+
+					BeforeEach(func() {
+						disaster = errors.New("sorry pal")
+						fakeArtifactStore.DeleteReturns(disaster)
+					})
+
+					It("returns the error", func() {
+						err := buildLogCollector.Run(context.TODO())
+						Expect(err).To(Equal(disaster))
+					})
+
+					It("does not delete the records or the build events", func() {
+						buildLogCollector.Run(context.TODO())
+						Expect(buildWithArtifacts.DeleteArchivedArtifactsCallCount()).To(BeZero())
+						Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when no artifact store is configured", func() {
+					JustBeforeEach(func() {
+						buildLogCollector = NewBuildLogCollector(
+							fakePipelineFactory,
+							batchSize,
+							buildLogRetainCalc,
+							false,
+							nil,
+						)
+					})
+
+					It("leaves the archived artifact records alone", func() {
+						err := buildLogCollector.Run(context.TODO())
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(buildWithArtifacts.ArchivedArtifactsCallCount()).To(BeZero())
+						Expect(buildWithArtifacts.DeleteArchivedArtifactsCallCount()).To(BeZero())
+					})
+
+					It("still deletes the build events", func() {
+						err := buildLogCollector.Run(context.TODO())
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(Equal(1))
+					})
+				})
+			})
 			Context("when there are more build logs than we can reap in this run", func() {
 				BeforeEach(func() {
 					fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
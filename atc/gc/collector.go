@@ -23,6 +23,7 @@ type aggregateCollector struct {
 	resourceConfigCheckSessionCollector Collector
 	artifactCollector                   Collector
 	checkCollector                      Collector
+	secretAccessCollector               Collector
 }
 
 func NewCollector(
@@ -33,6 +34,7 @@ func NewCollector(
 	resourceCaches Collector,
 	artifactCollector Collector,
 	checkCollector Collector,
+	secretAccessCollector Collector,
 	volumes Collector,
 	containers Collector,
 	resourceConfigCheckSessionCollector Collector,
@@ -45,6 +47,7 @@ func NewCollector(
 		resourceCacheCollector:              resourceCaches,
 		artifactCollector:                   artifactCollector,
 		checkCollector:                      checkCollector,
+		secretAccessCollector:               secretAccessCollector,
 		volumeCollector:                     volumes,
 		containerCollector:                  containers,
 		resourceConfigCheckSessionCollector: resourceConfigCheckSessionCollector,
@@ -96,6 +99,11 @@ func (c *aggregateCollector) Run(ctx context.Context) error {
 		logger.Error("check-collector", err)
 	}
 
+	err = c.secretAccessCollector.Run(ctx)
+	if err != nil {
+		logger.Error("secret-access-collector", err)
+	}
+
 	err = c.containerCollector.Run(ctx)
 	if err != nil {
 		logger.Error("container-collector", err)
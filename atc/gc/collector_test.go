@@ -21,6 +21,7 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeResourceCacheCollector              *gcfakes.FakeCollector
 		fakeArtifactCollector                   *gcfakes.FakeCollector
 		fakeCheckCollector                      *gcfakes.FakeCollector
+		fakeSecretAccessCollector               *gcfakes.FakeCollector
 		fakeVolumeCollector                     *gcfakes.FakeCollector
 		fakeContainerCollector                  *gcfakes.FakeCollector
 		fakeResourceConfigCheckSessionCollector *gcfakes.FakeCollector
@@ -37,6 +38,7 @@ var _ = Describe("Aggregate Collector", func() {
 		fakeResourceCacheCollector = new(gcfakes.FakeCollector)
 		fakeArtifactCollector = new(gcfakes.FakeCollector)
 		fakeCheckCollector = new(gcfakes.FakeCollector)
+		fakeSecretAccessCollector = new(gcfakes.FakeCollector)
 		fakeVolumeCollector = new(gcfakes.FakeCollector)
 		fakeContainerCollector = new(gcfakes.FakeCollector)
 		fakeResourceConfigCheckSessionCollector = new(gcfakes.FakeCollector)
@@ -49,6 +51,7 @@ var _ = Describe("Aggregate Collector", func() {
 			fakeResourceCacheCollector,
 			fakeArtifactCollector,
 			fakeCheckCollector,
+			fakeSecretAccessCollector,
 			fakeVolumeCollector,
 			fakeContainerCollector,
 			fakeResourceConfigCheckSessionCollector,
@@ -82,6 +85,7 @@ var _ = Describe("Aggregate Collector", func() {
 				Expect(fakeResourceCacheCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeArtifactCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeCheckCollector.RunCallCount()).To(Equal(1))
+				Expect(fakeSecretAccessCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeVolumeCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeContainerCollector.RunCallCount()).To(Equal(1))
 				Expect(fakeResourceConfigCheckSessionCollector.RunCallCount()).To(Equal(1))
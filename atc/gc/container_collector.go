@@ -239,6 +239,12 @@ func markHijackedContainerAsDestroying(
 }
 
 func findContainer(gardenClient gclient.Client, handle string) (gclient.Container, bool, error) {
+	// workers with a non-Garden runtime have no Garden client; their
+	// containers are destroyed immediately rather than given a grace time
+	if gardenClient == nil {
+		return nil, false, nil
+	}
+
 	gardenContainer, err := gardenClient.Lookup(handle)
 	if err != nil {
 		if _, ok := err.(garden.ContainerNotFoundError); ok {
@@ -228,6 +228,16 @@ var _ = Describe("ContainerCollector", func() {
 						Expect(createdContainer.DestroyingCallCount()).To(Equal(1))
 					})
 				})
+
+				Context("when the worker does not use garden", func() {
+					BeforeEach(func() {
+						fakeWorker.GardenClientReturns(nil)
+					})
+
+					It("marks container as destroying", func() {
+						Expect(createdContainer.DestroyingCallCount()).To(Equal(1))
+					})
+				})
 			})
 
 			It("marks all found containers (created and destroying only, no creating) as destroying", func() {
@@ -50,6 +50,8 @@ var (
 	usedResourceType db.ResourceType
 	logger           *lagertest.TestLogger
 	fakeLogFunc      = func(logger lager.Logger, id lock.LockID) {}
+
+	defaultBuildCreatedBy = atc.BuildCreator{Type: atc.BuildCreatorManual}
 )
 
 var _ = BeforeSuite(func() {
@@ -103,7 +105,7 @@ var _ = BeforeEach(func() {
 		},
 	}
 
-	defaultPipeline, _, err = defaultTeam.SavePipeline("default-pipeline", atcConfig, db.ConfigVersion(0), false)
+	defaultPipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "default-pipeline"}, atcConfig, db.ConfigVersion(0), false)
 	Expect(err).NotTo(HaveOccurred())
 
 	var found bool
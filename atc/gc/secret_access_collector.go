@@ -0,0 +1,37 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type secretAccessCollector struct {
+	secretAccessFactory db.SecretAccessFactory
+	retention           time.Duration
+}
+
+// NewSecretAccessCollector removes the secret accesses recorded by secret
+// auditing once they are older than the retention period. A zero retention
+// period keeps them forever.
+func NewSecretAccessCollector(secretAccessFactory db.SecretAccessFactory, retention time.Duration) *secretAccessCollector {
+	return &secretAccessCollector{
+		secretAccessFactory: secretAccessFactory,
+		retention:           retention,
+	}
+}
+
+func (c *secretAccessCollector) Run(ctx context.Context) error {
+	if c.retention == 0 {
+		return nil
+	}
+
+	logger := lagerctx.FromContext(ctx).Session("secret-access-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	return c.secretAccessFactory.RemoveExpiredSecretAccesses(c.retention)
+}
@@ -0,0 +1,42 @@
+package gc_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretAccessCollector", func() {
+	var fakeSecretAccessFactory *dbfakes.FakeSecretAccessFactory
+
+	BeforeEach(func() {
+		fakeSecretAccessFactory = new(dbfakes.FakeSecretAccessFactory)
+	})
+
+	Describe("Run", func() {
+		It("removes the secret accesses older than the retention period", func() {
+			collector := gc.NewSecretAccessCollector(fakeSecretAccessFactory, time.Hour*24)
+
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeSecretAccessFactory.RemoveExpiredSecretAccessesCallCount()).To(Equal(1))
+			Expect(fakeSecretAccessFactory.RemoveExpiredSecretAccessesArgsForCall(0)).To(Equal(time.Hour * 24))
+		})
+
+		Context("when there is no retention period", func() {
+			It("keeps every secret access", func() {
+				collector := gc.NewSecretAccessCollector(fakeSecretAccessFactory, 0)
+
+				err := collector.Run(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSecretAccessFactory.RemoveExpiredSecretAccessesCallCount()).To(BeZero())
+			})
+		})
+	})
+})
@@ -1,5 +1,11 @@
 package atc
 
+import (
+	"time"
+
+	"github.com/gorhill/cronexpr"
+)
+
 type JobConfig struct {
 	Name    string `json:"name"`
 	OldName string `json:"old_name,omitempty"`
@@ -14,6 +20,21 @@ type JobConfig struct {
 
 	BuildLogRetention *BuildLogRetention `json:"build_log_retention,omitempty"`
 
+	// Priority orders the job's pending builds against those of other jobs:
+	// higher-priority builds are started first and, with the
+	// limit-active-tasks placement strategy, their tasks are placed on
+	// workers first.
+	Priority int `json:"priority,omitempty"`
+
+	// Semaphores are team-wide locks that the job's builds hold while they
+	// run, limiting how many builds of the team's jobs which declare the same
+	// semaphore run at once, across pipelines.
+	Semaphores []SemaphoreConfig `json:"semaphores,omitempty"`
+
+	// Schedule triggers builds of the job periodically, without the need for
+	// a time resource.
+	Schedule *ScheduleConfig `json:"schedule,omitempty"`
+
 	Abort   *PlanConfig `json:"on_abort,omitempty"`
 	Error   *PlanConfig `json:"on_error,omitempty"`
 	Failure *PlanConfig `json:"on_failure,omitempty"`
@@ -28,6 +49,55 @@ type BuildLogRetention struct {
 	Days   int `json:"days,omitempty"`
 }
 
+type SemaphoreConfig struct {
+	Name string `json:"name"`
+
+	// Capacity is the number of builds which may hold the semaphore at once.
+	// It defaults to 1, making the semaphore a lock.
+	RawCapacity int `json:"capacity,omitempty"`
+}
+
+func (config SemaphoreConfig) Capacity() int {
+	if config.RawCapacity == 0 {
+		return 1
+	}
+
+	return config.RawCapacity
+}
+
+type ScheduleConfig struct {
+	// Cron is a cron expression, e.g. "0 2 * * 1-5" for 2am on weekdays.
+	Cron string `json:"cron"`
+
+	// Location is the name of the timezone the cron expression is evaluated
+	// in, e.g. "America/Toronto". It defaults to UTC.
+	Location string `json:"location,omitempty"`
+}
+
+// Next returns the first time the schedule triggers after the given time,
+// or the zero time if it never triggers again.
+func (config ScheduleConfig) Next(after time.Time) (time.Time, error) {
+	expression, err := cronexpr.Parse(config.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	location, err := config.location()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return expression.Next(after.In(location)), nil
+}
+
+func (config ScheduleConfig) location() (*time.Location, error) {
+	if config.Location == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(config.Location)
+}
+
 func (config JobConfig) Hooks() Hooks {
 	return Hooks{
 		Abort:   config.Abort,
@@ -1,6 +1,8 @@
 package atc_test
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 
 	. "github.com/onsi/ginkgo"
@@ -1018,4 +1020,38 @@ var _ = Describe("JobConfig", func() {
 			})
 		})
 	})
+
+	Describe("ScheduleConfig", func() {
+		Describe("Next", func() {
+			It("returns the next time the cron expression matches in UTC", func() {
+				schedule := atc.ScheduleConfig{Cron: "30 2 * * *"}
+
+				next, err := schedule.Next(time.Date(2019, 12, 3, 12, 0, 0, 0, time.UTC))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(next).To(Equal(time.Date(2019, 12, 4, 2, 30, 0, 0, time.UTC)))
+			})
+
+			It("evaluates the cron expression in the configured location", func() {
+				schedule := atc.ScheduleConfig{Cron: "0 9 * * *", Location: "America/Toronto"}
+
+				next, err := schedule.Next(time.Date(2019, 12, 3, 12, 0, 0, 0, time.UTC))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(next.UTC()).To(Equal(time.Date(2019, 12, 3, 14, 0, 0, 0, time.UTC)))
+			})
+
+			It("returns an error for an invalid cron expression", func() {
+				schedule := atc.ScheduleConfig{Cron: "every tuesday"}
+
+				_, err := schedule.Next(time.Now())
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("returns an error for an unknown location", func() {
+				schedule := atc.ScheduleConfig{Cron: "0 9 * * *", Location: "Mars/Olympus_Mons"}
+
+				_, err := schedule.Next(time.Now())
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
@@ -0,0 +1,91 @@
+package atc
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	JobStatsQuerySince = "since"
+	JobStatsQueryUntil = "until"
+)
+
+// JobStats summarizes the builds of a job created within a time range.
+type JobStats struct {
+	Since int64 `json:"since"`
+	Until int64 `json:"until"`
+
+	Builds    int `json:"builds"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+	Errored   int `json:"errored"`
+	Aborted   int `json:"aborted"`
+
+	// SuccessRate is the fraction of succeeded builds among the builds that
+	// succeeded, failed or errored. Aborted and unfinished builds are ignored.
+	SuccessRate float64 `json:"success_rate"`
+
+	// Duration is the time from starting to finishing each completed build.
+	Duration DurationStats `json:"duration"`
+
+	// QueueTime is the time each build spent pending, from being created to
+	// being started.
+	QueueTime DurationStats `json:"queue_time"`
+
+	Steps []StepStats `json:"steps"`
+}
+
+// StepStats summarizes how long a step of the job's builds takes.
+type StepStats struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+
+	// Initialization is the time from initializing the step (e.g. fetching
+	// its image) to starting it.
+	Initialization DurationStats `json:"initialization"`
+
+	// Duration is the time from initializing the step to finishing it.
+	Duration DurationStats `json:"duration"`
+}
+
+// DurationStats describes a set of durations, in seconds.
+type DurationStats struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean"`
+	P50   float64 `json:"p50"`
+	P95   float64 `json:"p95"`
+	Max   float64 `json:"max"`
+}
+
+// NewDurationStats computes the statistics of the given durations, in
+// seconds. Percentiles use the nearest-rank method.
+func NewDurationStats(durations []float64) DurationStats {
+	if len(durations) == 0 {
+		return DurationStats{}
+	}
+
+	sorted := append([]float64{}, durations...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, d := range sorted {
+		sum += d
+	}
+
+	return DurationStats{
+		Count: len(sorted),
+		Mean:  sum / float64(len(sorted)),
+		P50:   percentile(sorted, 50),
+		P95:   percentile(sorted, 95),
+		Max:   sorted[len(sorted)-1],
+	}
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
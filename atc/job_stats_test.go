@@ -0,0 +1,46 @@
+package atc_test
+
+import (
+	. "github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DurationStats", func() {
+	Describe("NewDurationStats", func() {
+		It("computes the mean, percentiles and maximum", func() {
+			durations := []float64{}
+			for i := 20; i >= 1; i-- {
+				durations = append(durations, float64(i))
+			}
+
+			Expect(NewDurationStats(durations)).To(Equal(DurationStats{
+				Count: 20,
+				Mean:  10.5,
+				P50:   10,
+				P95:   19,
+				Max:   20,
+			}))
+		})
+
+		It("does not reorder the given durations", func() {
+			durations := []float64{3, 1, 2}
+			NewDurationStats(durations)
+			Expect(durations).To(Equal([]float64{3, 1, 2}))
+		})
+
+		It("uses the only duration for every percentile", func() {
+			Expect(NewDurationStats([]float64{42})).To(Equal(DurationStats{
+				Count: 1,
+				Mean:  42,
+				P50:   42,
+				P95:   42,
+				Max:   42,
+			}))
+		})
+
+		It("is empty without durations", func() {
+			Expect(NewDurationStats(nil)).To(Equal(DurationStats{}))
+		})
+	})
+})
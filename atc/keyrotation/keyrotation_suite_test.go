@@ -0,0 +1,13 @@
+package keyrotation_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestKeyRotation(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Key Rotation Suite")
+}
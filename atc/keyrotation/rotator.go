@@ -0,0 +1,79 @@
+package keyrotation
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate counterfeiter . Rotator
+
+// Rotator re-encrypts the data in the database with the current encryption
+// key in the background, a batch at a time.
+type Rotator interface {
+	Run(context.Context) error
+}
+
+type rotator struct {
+	rotation  db.EncryptionKeyRotation
+	batchSize int
+}
+
+func NewRotator(rotation db.EncryptionKeyRotation, batchSize int) Rotator {
+	return &rotator{
+		rotation:  rotation,
+		batchSize: batchSize,
+	}
+}
+
+func (r *rotator) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("encryption-key-rotator")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	status, err := r.rotation.Status()
+	if err != nil {
+		logger.Error("failed-to-get-status", err)
+		return err
+	}
+
+	remaining := status.Remaining()
+	if remaining == 0 {
+		return nil
+	}
+
+	logger.Info("rotating", lager.Data{"remaining": remaining})
+
+	rotated := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		n, err := r.rotation.RotateBatch(r.batchSize)
+		if err != nil {
+			logger.Error("failed-to-rotate-batch", err, lager.Data{"rotated": rotated})
+			return err
+		}
+
+		if n == 0 {
+			break
+		}
+
+		rotated += n
+
+		logger.Info("rotated-batch", lager.Data{
+			"rotated":   rotated,
+			"remaining": remaining - rotated,
+		})
+	}
+
+	logger.Info("finished-rotating", lager.Data{"rotated": rotated})
+
+	return nil
+}
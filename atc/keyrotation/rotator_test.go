@@ -0,0 +1,89 @@
+package keyrotation_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/keyrotation"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Rotator", func() {
+	var (
+		fakeRotation *dbfakes.FakeEncryptionKeyRotation
+		rotator      keyrotation.Rotator
+
+		runErr error
+	)
+
+	BeforeEach(func() {
+		fakeRotation = new(dbfakes.FakeEncryptionKeyRotation)
+		rotator = keyrotation.NewRotator(fakeRotation, 2)
+	})
+
+	JustBeforeEach(func() {
+		ctx := lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+		runErr = rotator.Run(ctx)
+	})
+
+	Context("when there is nothing left to re-encrypt", func() {
+		BeforeEach(func() {
+			fakeRotation.StatusReturns(atc.EncryptionStatus{
+				Tables: []atc.EncryptedTableStatus{{Table: "jobs", Remaining: 0}},
+			}, nil)
+		})
+
+		It("does nothing", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeRotation.RotateBatchCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when there are rows left to re-encrypt", func() {
+		BeforeEach(func() {
+			fakeRotation.StatusReturns(atc.EncryptionStatus{
+				Tables: []atc.EncryptedTableStatus{
+					{Table: "jobs", Remaining: 2},
+					{Table: "resources", Remaining: 1},
+				},
+			}, nil)
+
+			fakeRotation.RotateBatchReturnsOnCall(0, 2, nil)
+			fakeRotation.RotateBatchReturnsOnCall(1, 1, nil)
+			fakeRotation.RotateBatchReturnsOnCall(2, 0, nil)
+		})
+
+		It("re-encrypts them in batches until none are left", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeRotation.RotateBatchCallCount()).To(Equal(3))
+			Expect(fakeRotation.RotateBatchArgsForCall(0)).To(Equal(2))
+		})
+
+		Context("when re-encrypting a batch fails", func() {
+			BeforeEach(func() {
+				fakeRotation.RotateBatchReturnsOnCall(1, 0, errors.New("disaster"))
+			})
+
+			It("stops and returns the error", func() {
+				Expect(runErr).To(MatchError("disaster"))
+				Expect(fakeRotation.RotateBatchCallCount()).To(Equal(2))
+			})
+		})
+	})
+
+	Context("when getting the status fails", func() {
+		BeforeEach(func() {
+			fakeRotation.StatusReturns(atc.EncryptionStatus{}, errors.New("disaster"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("disaster"))
+		})
+	})
+})
@@ -7,6 +7,8 @@ import (
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/tracing"
+	"go.opentelemetry.io/otel/api/kv"
 )
 
 func NewChecker(
@@ -35,18 +37,24 @@ func (c *checker) Run(ctx context.Context) error {
 	c.logger.Info("start")
 	defer c.logger.Info("end")
 
+	ctx, span := tracing.StartSpan(ctx, "lidar.checker", nil)
+
 	checks, err := c.checkFactory.StartedChecks()
 	if err != nil {
 		c.logger.Error("failed-to-fetch-resource-checks", err)
+		tracing.End(span, err)
 		return err
 	}
 
+	span.SetAttributes(kv.Int("checks", len(checks)))
+	defer tracing.End(span, nil)
+
 	for _, ck := range checks {
 		if _, exists := c.running.LoadOrStore(ck.ID(), true); !exists {
 			go func(check db.Check) {
 				defer c.running.Delete(check.ID())
 
-				engineCheck := c.engine.NewCheck(check)
+				engineCheck := c.engine.NewCheck(ctx, check)
 				engineCheck.Run(c.logger.WithData(lager.Data{
 					"check": check.ID(),
 				}))
@@ -14,6 +14,9 @@ import (
 	"github.com/concourse/concourse/atc/engine"
 	"github.com/concourse/concourse/atc/engine/enginefakes"
 	"github.com/concourse/concourse/atc/lidar"
+	"github.com/concourse/concourse/tracing"
+	"go.opentelemetry.io/otel/api/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 type Checker interface {
@@ -75,7 +78,7 @@ var _ = Describe("Checker", func() {
 					fakeCheck3,
 				}, nil)
 
-				fakeEngine.NewCheckStub = func(build db.Check) engine.Runnable {
+				fakeEngine.NewCheckStub = func(ctx context.Context, build db.Check) engine.Runnable {
 					time.Sleep(time.Second)
 					return new(enginefakes.FakeRunnable)
 				}
@@ -88,6 +91,26 @@ var _ = Describe("Checker", func() {
 			It("runs all pending checks", func() {
 				Eventually(fakeEngine.NewCheckCallCount).Should(Equal(3))
 			})
+
+			Context("when tracing is configured", func() {
+				BeforeEach(func() {
+					provider, err := sdktrace.NewProvider()
+					Expect(err).ToNot(HaveOccurred())
+
+					tracing.ConfigureTraceProvider(provider)
+				})
+
+				AfterEach(func() {
+					tracing.ConfigureTraceProvider(trace.NoopProvider{})
+				})
+
+				It("runs the checks within the checker's span", func() {
+					Eventually(fakeEngine.NewCheckCallCount).Should(Equal(3))
+
+					ctx, _ := fakeEngine.NewCheckArgsForCall(0)
+					Expect(trace.SpanFromContext(ctx).SpanContext().IsValid()).To(BeTrue())
+				})
+			})
 		})
 
 		Context("when a check is already running", func() {
@@ -96,7 +119,7 @@ var _ = Describe("Checker", func() {
 				fakeCheck := new(dbfakes.FakeCheck)
 				fakeCheck.IDReturns(1)
 
-				fakeEngine.NewCheckStub = func(build db.Check) engine.Runnable {
+				fakeEngine.NewCheckStub = func(ctx context.Context, build db.Check) engine.Runnable {
 					time.Sleep(time.Second)
 					return new(enginefakes.FakeRunnable)
 				}
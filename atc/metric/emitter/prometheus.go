@@ -40,6 +40,8 @@ type PrometheusEmitter struct {
 
 	resourceChecksVec *prometheus.CounterVec
 
+	secretsAccessedVec *prometheus.CounterVec
+
 	schedulingFullDuration    *prometheus.CounterVec
 	schedulingLoadingDuration *prometheus.CounterVec
 
@@ -305,6 +307,17 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(resourceChecksVec)
 
+	secretsAccessedVec := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "secrets",
+			Name:      "accessed_total",
+			Help:      "Counts the number of secrets looked up in credential managers",
+		},
+		[]string{"team", "manager", "cache_hit", "result"},
+	)
+	prometheus.MustRegister(secretsAccessedVec)
+
 	listener, err := net.Listen("tcp", config.bind())
 	if err != nil {
 		return nil, err
@@ -335,6 +348,8 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 
 		resourceChecksVec: resourceChecksVec,
 
+		secretsAccessedVec: secretsAccessedVec,
+
 		schedulingFullDuration:    schedulingFullDuration,
 		schedulingLoadingDuration: schedulingLoadingDuration,
 
@@ -392,6 +407,8 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.databaseMetrics(logger, event)
 	case "resource checked":
 		emitter.resourceMetric(logger, event)
+	case "secret accessed":
+		emitter.secretMetric(logger, event)
 	default:
 		// unless we have a specific metric, we do nothing
 	}
@@ -691,6 +708,33 @@ func (emitter *PrometheusEmitter) resourceMetric(logger lager.Logger, event metr
 	emitter.resourceChecksVec.WithLabelValues(team, pipeline).Inc()
 }
 
+func (emitter *PrometheusEmitter) secretMetric(logger lager.Logger, event metric.Event) {
+	team, exists := event.Attributes["team_name"]
+	if !exists {
+		logger.Error("failed-to-find-team-name-in-event", fmt.Errorf("expected team_name to exist in event.Attributes"))
+		return
+	}
+	manager, exists := event.Attributes["manager"]
+	if !exists {
+		logger.Error("failed-to-find-manager-in-event", fmt.Errorf("expected manager to exist in event.Attributes"))
+		return
+	}
+	cacheHit, exists := event.Attributes["cache_hit"]
+	if !exists {
+		logger.Error("failed-to-find-cache-hit-in-event", fmt.Errorf("expected cache_hit to exist in event.Attributes"))
+		return
+	}
+
+	result := "found"
+	if event.State != metric.EventStateOK {
+		result = "error"
+	} else if event.Attributes["found"] != "true" {
+		result = "not_found"
+	}
+
+	emitter.secretsAccessedVec.WithLabelValues(team, manager, cacheHit, result).Inc()
+}
+
 // updateLastSeen tracks for each worker when it last received a metric event.
 func (emitter *PrometheusEmitter) updateLastSeen(event metric.Event) {
 	emitter.mu.Lock()
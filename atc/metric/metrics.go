@@ -469,6 +469,35 @@ func (event ResourceCheck) Emit(logger lager.Logger) {
 	)
 }
 
+type SecretAccessed struct {
+	TeamName string
+	Manager  string
+	CacheHit bool
+	Found    bool
+	Success  bool
+}
+
+func (event SecretAccessed) Emit(logger lager.Logger) {
+	state := EventStateOK
+	if !event.Success {
+		state = EventStateWarning
+	}
+	emit(
+		logger.Session("secret-accessed"),
+		Event{
+			Name:  "secret accessed",
+			Value: 1,
+			State: state,
+			Attributes: map[string]string{
+				"team_name": event.TeamName,
+				"manager":   event.Manager,
+				"cache_hit": strconv.FormatBool(event.CacheHit),
+				"found":     strconv.FormatBool(event.Found),
+			},
+		},
+	)
+}
+
 type CheckFinished struct {
 	ResourceConfigScopeID string
 	CheckName             string
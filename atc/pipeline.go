@@ -1,12 +1,113 @@
 package atc
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
 type Pipeline struct {
-	ID       int          `json:"id"`
-	Name     string       `json:"name"`
-	Paused   bool         `json:"paused"`
-	Public   bool         `json:"public"`
-	Groups   GroupConfigs `json:"groups,omitempty"`
-	TeamName string       `json:"team_name"`
+	ID            int          `json:"id"`
+	Name          string       `json:"name"`
+	InstanceVars  InstanceVars `json:"instance_vars,omitempty"`
+	Paused        bool         `json:"paused"`
+	Public        bool         `json:"public"`
+	Groups        GroupConfigs `json:"groups,omitempty"`
+	TeamName      string       `json:"team_name"`
+	ParentJobID   int          `json:"parent_job_id,omitempty"`
+	ParentBuildID int          `json:"parent_build_id,omitempty"`
+}
+
+func (p Pipeline) Ref() PipelineRef {
+	return PipelineRef{
+		Name:         p.Name,
+		InstanceVars: p.InstanceVars,
+	}
+}
+
+// InstanceVars are the vars identifying one instance of a pipeline among
+// others which share the same name.
+type InstanceVars map[string]interface{}
+
+// InstanceVarsQueryParam is the query parameter used to scope pipeline API
+// requests to a pipeline instance. Its value is the JSON encoded InstanceVars.
+const InstanceVarsQueryParam = "instance_vars"
+
+// InstanceVarsFromQueryParams parses the instance vars out of a request's
+// query parameters, returning nil if none were given.
+func InstanceVarsFromQueryParams(params url.Values) (InstanceVars, error) {
+	payload := params.Get(InstanceVarsQueryParam)
+	if payload == "" {
+		return nil, nil
+	}
+
+	var instanceVars InstanceVars
+	err := json.Unmarshal([]byte(payload), &instanceVars)
+	if err != nil {
+		return nil, fmt.Errorf("malformed instance vars: %s", err)
+	}
+
+	return instanceVars, nil
+}
+
+// PipelineRef identifies a pipeline within a team. A ref without any
+// InstanceVars refers to the pipeline which has no instance vars.
+type PipelineRef struct {
+	Name         string       `json:"name"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+}
+
+// String formats the ref as name/key1:value1,key2:value2, with the keys
+// sorted so that the same instance always prints the same way. Values are
+// formatted as JSON, with strings left unquoted unless they would otherwise
+// read back as something else, e.g. version:"5.1".
+func (ref PipelineRef) String() string {
+	if len(ref.InstanceVars) == 0 {
+		return ref.Name
+	}
+
+	keys := make([]string, 0, len(ref.InstanceVars))
+	for key := range ref.InstanceVars {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		if str, ok := ref.InstanceVars[key].(string); ok && isPlainString(str) {
+			pairs[i] = key + ":" + str
+			continue
+		}
+
+		value, _ := json.Marshal(ref.InstanceVars[key])
+		pairs[i] = key + ":" + string(value)
+	}
+
+	return ref.Name + "/" + strings.Join(pairs, ",")
+}
+
+func isPlainString(str string) bool {
+	if strings.ContainsAny(str, `,"'[]{}`) {
+		return false
+	}
+
+	var val interface{}
+	err := yaml.Unmarshal([]byte(str), &val)
+	return err == nil && val == str
+}
+
+func (ref PipelineRef) QueryParams() url.Values {
+	if len(ref.InstanceVars) == 0 {
+		return nil
+	}
+
+	payload, _ := json.Marshal(ref.InstanceVars)
+	return url.Values{InstanceVarsQueryParam: []string{string(payload)}}
 }
 
 type RenameRequest struct {
@@ -0,0 +1,73 @@
+package atc_test
+
+import (
+	"net/url"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("PipelineRef", func() {
+	Describe("String", func() {
+		It("returns the name if there are no instance vars", func() {
+			ref := atc.PipelineRef{Name: "some-pipeline"}
+			Expect(ref.String()).To(Equal("some-pipeline"))
+		})
+
+		It("appends the instance vars sorted by key", func() {
+			ref := atc.PipelineRef{
+				Name: "some-pipeline",
+				InstanceVars: atc.InstanceVars{
+					"version": "5.1",
+					"branch":  "master",
+					"count":   1,
+				},
+			}
+			Expect(ref.String()).To(Equal(`some-pipeline/branch:master,count:1,version:"5.1"`))
+		})
+
+		It("formats structured values as JSON", func() {
+			ref := atc.PipelineRef{
+				Name: "some-pipeline",
+				InstanceVars: atc.InstanceVars{
+					"tags": []interface{}{"a", "b"},
+					"path": "feature/foo",
+				},
+			}
+			Expect(ref.String()).To(Equal(`some-pipeline/path:feature/foo,tags:["a","b"]`))
+		})
+	})
+
+	Describe("QueryParams", func() {
+		It("returns nothing if there are no instance vars", func() {
+			ref := atc.PipelineRef{Name: "some-pipeline"}
+			Expect(ref.QueryParams()).To(BeNil())
+		})
+
+		It("round-trips the instance vars", func() {
+			ref := atc.PipelineRef{
+				Name:         "some-pipeline",
+				InstanceVars: atc.InstanceVars{"version": "5.1"},
+			}
+
+			instanceVars, err := atc.InstanceVarsFromQueryParams(ref.QueryParams())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instanceVars).To(Equal(ref.InstanceVars))
+		})
+	})
+
+	Describe("InstanceVarsFromQueryParams", func() {
+		It("returns nil if the param is missing", func() {
+			instanceVars, err := atc.InstanceVarsFromQueryParams(url.Values{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instanceVars).To(BeNil())
+		})
+
+		It("errors if the param is malformed", func() {
+			_, err := atc.InstanceVarsFromQueryParams(url.Values{"instance_vars": {"{"}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
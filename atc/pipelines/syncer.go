@@ -2,8 +2,10 @@ package pipelines
 
 import (
 	"os"
+	"reflect"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/tedsuo/ifrit"
 )
@@ -20,7 +22,8 @@ type Syncer struct {
 }
 
 type runningPipeline struct {
-	Name string
+	Name       string
+	VarSources atc.VarSourceConfigs
 
 	ifrit.Process
 
@@ -62,7 +65,11 @@ func (syncer *Syncer) Sync() {
 				continue
 			}
 
-			if pipeline.ID() == id && pipeline.Name() == runningPipeline.Name {
+			// the pipeline's vars are looked up with its name and var sources,
+			// so it's restarted when either changes
+			if pipeline.ID() == id &&
+				pipeline.Name() == runningPipeline.Name &&
+				reflect.DeepEqual(pipeline.VarSources(), runningPipeline.VarSources) {
 				found = true
 			}
 		}
@@ -86,9 +93,10 @@ func (syncer *Syncer) Sync() {
 		process := ifrit.Invoke(runner)
 
 		syncer.runningPipelines[pipeline.ID()] = runningPipeline{
-			Name:    pipeline.Name(),
-			Process: process,
-			Exited:  process.Wait(),
+			Name:       pipeline.Name(),
+			VarSources: pipeline.VarSources(),
+			Process:    process,
+			Exited:     process.Wait(),
 		}
 	}
 }
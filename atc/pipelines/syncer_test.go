@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/pipelines"
@@ -141,6 +142,27 @@ var _ = Describe("Pipelines Syncer", func() {
 		})
 	})
 
+	Context("when a pipeline's var sources were changed", func() {
+		It("recreates the process with the new var sources", func() {
+			Eventually(fakeRunner.RunCallCount).Should(Equal(1))
+			Eventually(otherFakeRunner.RunCallCount).Should(Equal(1))
+
+			pipeline1.VarSourcesReturns(atc.VarSourceConfigs{
+				{Name: "some-var-source", Type: "vault"},
+			})
+
+			syncer.Sync()
+
+			Eventually(fakeRunner.RunCallCount).Should(Equal(2))
+
+			signals, _ := fakeRunner.RunArgsForCall(0)
+			Eventually(signals).Should(Receive(Equal(os.Interrupt)))
+
+			syncer.Sync()
+			Consistently(fakeRunner.RunCallCount).Should(Equal(2))
+		})
+	})
+
 	Context("when a pipeline is paused", func() {
 		JustBeforeEach(func() {
 			Eventually(fakeRunner.RunCallCount).Should(Equal(1))
@@ -4,21 +4,25 @@ type Plan struct {
 	ID       PlanID `json:"id"`
 	Attempts []int  `json:"attempts,omitempty"`
 
-	Aggregate  *AggregatePlan  `json:"aggregate,omitempty"`
-	InParallel *InParallelPlan `json:"in_parallel,omitempty"`
-	Do         *DoPlan         `json:"do,omitempty"`
-	Get        *GetPlan        `json:"get,omitempty"`
-	Put        *PutPlan        `json:"put,omitempty"`
-	Check      *CheckPlan      `json:"check,omitempty"`
-	Task       *TaskPlan       `json:"task,omitempty"`
-	OnAbort    *OnAbortPlan    `json:"on_abort,omitempty"`
-	OnError    *OnErrorPlan    `json:"on_error,omitempty"`
-	Ensure     *EnsurePlan     `json:"ensure,omitempty"`
-	OnSuccess  *OnSuccessPlan  `json:"on_success,omitempty"`
-	OnFailure  *OnFailurePlan  `json:"on_failure,omitempty"`
-	Try        *TryPlan        `json:"try,omitempty"`
-	Timeout    *TimeoutPlan    `json:"timeout,omitempty"`
-	Retry      *RetryPlan      `json:"retry,omitempty"`
+	Aggregate   *AggregatePlan   `json:"aggregate,omitempty"`
+	InParallel  *InParallelPlan  `json:"in_parallel,omitempty"`
+	Across      *AcrossPlan      `json:"across,omitempty"`
+	Do          *DoPlan          `json:"do,omitempty"`
+	Get         *GetPlan         `json:"get,omitempty"`
+	Put         *PutPlan         `json:"put,omitempty"`
+	Check       *CheckPlan       `json:"check,omitempty"`
+	Task        *TaskPlan        `json:"task,omitempty"`
+	SetPipeline *SetPipelinePlan `json:"set_pipeline,omitempty"`
+	LoadVar     *LoadVarPlan     `json:"load_var,omitempty"`
+	Approval    *ApprovalPlan    `json:"approval,omitempty"`
+	OnAbort     *OnAbortPlan     `json:"on_abort,omitempty"`
+	OnError     *OnErrorPlan     `json:"on_error,omitempty"`
+	Ensure      *EnsurePlan      `json:"ensure,omitempty"`
+	OnSuccess   *OnSuccessPlan   `json:"on_success,omitempty"`
+	OnFailure   *OnFailurePlan   `json:"on_failure,omitempty"`
+	Try         *TryPlan         `json:"try,omitempty"`
+	Timeout     *TimeoutPlan     `json:"timeout,omitempty"`
+	Retry       *RetryPlan       `json:"retry,omitempty"`
 
 	// used for 'fly execute'
 	ArtifactInput  *ArtifactInputPlan  `json:"artifact_input,omitempty"`
@@ -81,6 +85,27 @@ type InParallelPlan struct {
 	FailFast bool   `json:"fail_fast,omitempty"`
 }
 
+// An AcrossPlan runs a step once for each combination of its vars' values.
+// Steps are ordered such that the values of the last var vary fastest.
+type AcrossPlan struct {
+	Vars     []AcrossVar     `json:"vars"`
+	Steps    []VarScopedPlan `json:"steps"`
+	FailFast bool            `json:"fail_fast,omitempty"`
+}
+
+type AcrossVar struct {
+	Var         string        `json:"name"`
+	Values      []interface{} `json:"values"`
+	MaxInFlight int           `json:"max_in_flight,omitempty"`
+}
+
+// A VarScopedPlan is a step to run with each of the AcrossPlan's vars set to
+// the corresponding value.
+type VarScopedPlan struct {
+	Step   Plan          `json:"step"`
+	Values []interface{} `json:"values"`
+}
+
 type DoPlan []Plan
 
 type GetPlan struct {
@@ -137,6 +162,41 @@ type TaskPlan struct {
 	VersionedResourceTypes VersionedResourceTypes `json:"resource_types,omitempty"`
 }
 
+type SetPipelinePlan struct {
+	Name         string       `json:"name"`
+	File         string       `json:"file"`
+	Vars         Params       `json:"vars,omitempty"`
+	VarFiles     []string     `json:"var_files,omitempty"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+}
+
+// A LoadVarPlan loads a file from an artifact as a local var of the build.
+type LoadVarPlan struct {
+	Name   string `json:"name"`
+	File   string `json:"file"`
+	Format string `json:"format,omitempty"`
+	Reveal bool   `json:"reveal,omitempty"`
+}
+
+const (
+	LoadVarFormatRaw  = "raw"
+	LoadVarFormatTrim = "trim"
+	LoadVarFormatJSON = "json"
+	LoadVarFormatYAML = "yaml"
+)
+
+// An ApprovalPlan pauses the build until a user approves or rejects it.
+type ApprovalPlan struct {
+	Name string `json:"name"`
+
+	// roles of the build's team allowed to decide; any role allowed to
+	// approve builds may decide if empty
+	AllowedRoles []string `json:"allowed_roles,omitempty"`
+
+	// duration after which the approval is rejected if nobody decided
+	Timeout string `json:"timeout,omitempty"`
+}
+
 type RetryPlan []Plan
 
 type DependentGetPlan struct {
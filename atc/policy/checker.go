@@ -0,0 +1,153 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	flags "github.com/jessevdk/go-flags"
+)
+
+const ActionRunTask = "RunTask"
+
+type Filter struct {
+	HttpMethods   []string `long:"policy-check-filter-http-method" description:"API HTTP method to go through policy check. Can be specified multiple times."`
+	Actions       []string `long:"policy-check-filter-action" description:"Action name to go through policy check, regardless of its HTTP method. Can be specified multiple times."`
+	ActionsToSkip []string `long:"policy-check-filter-action-skip" description:"Action name to skip policy check, regardless of its HTTP method. Can be specified multiple times."`
+}
+
+type PolicyCheckInput struct {
+	Service        string      `json:"service"`
+	ClusterName    string      `json:"cluster_name"`
+	ClusterVersion string      `json:"cluster_version"`
+	HttpMethod     string      `json:"http_method,omitempty"`
+	Action         string      `json:"action"`
+	User           string      `json:"user,omitempty"`
+	Team           string      `json:"team,omitempty"`
+	Pipeline       string      `json:"pipeline,omitempty"`
+	Data           interface{} `json:"data,omitempty"`
+}
+
+type PolicyCheckOutput struct {
+	Allowed     bool
+	ShouldBlock bool
+	Reasons     []string
+}
+
+// PassedPolicyCheck is returned for actions which are not subject to policy
+// checking.
+var PassedPolicyCheck = PolicyCheckOutput{Allowed: true}
+
+//go:generate counterfeiter . Agent
+
+// Agent decides whether an action is allowed by policy.
+type Agent interface {
+	Check(PolicyCheckInput) (PolicyCheckOutput, error)
+}
+
+//go:generate counterfeiter . AgentFactory
+
+type AgentFactory interface {
+	Description() string
+	IsConfigured() bool
+	NewAgent(lager.Logger) (Agent, error)
+}
+
+var agentFactories []AgentFactory
+
+func RegisterAgent(factory AgentFactory) {
+	agentFactories = append(agentFactories, factory)
+}
+
+func WireCheckers(group *flags.Group) {
+	for _, factory := range agentFactories {
+		_, err := group.AddGroup(fmt.Sprintf("Policy Check Agent (%s)", factory.Description()), "", factory)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+//go:generate counterfeiter . Checker
+
+type Checker interface {
+	ShouldCheckHttpMethod(string) bool
+	ShouldCheckAction(string) bool
+	ShouldSkipAction(string) bool
+
+	Check(PolicyCheckInput) (PolicyCheckOutput, error)
+}
+
+// Initialize constructs a Checker using the configured agent. If no agent is
+// configured, a nil Checker is returned and policy checking is disabled.
+func Initialize(logger lager.Logger, clusterName string, clusterVersion string, filter Filter) (Checker, error) {
+	logger.Debug("policy-checker-initialize")
+
+	var agentDescriptions []string
+	for _, factory := range agentFactories {
+		if factory.IsConfigured() {
+			agentDescriptions = append(agentDescriptions, factory.Description())
+		}
+	}
+	if len(agentDescriptions) > 1 {
+		return nil, fmt.Errorf("Multiple policy check agents configured: %s", strings.Join(agentDescriptions, ", "))
+	}
+
+	for _, factory := range agentFactories {
+		if factory.IsConfigured() {
+			agent, err := factory.NewAgent(logger.Session("policy-check-agent"))
+			if err != nil {
+				return nil, err
+			}
+
+			logger.Info("policy-check-agent-configured", lager.Data{"agent": factory.Description()})
+
+			return &AgentChecker{
+				filter:         filter,
+				agent:          agent,
+				clusterName:    clusterName,
+				clusterVersion: clusterVersion,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// AgentChecker filters actions and forwards the ones subject to policy to an
+// Agent, filling in details about the cluster.
+type AgentChecker struct {
+	filter         Filter
+	agent          Agent
+	clusterName    string
+	clusterVersion string
+}
+
+func (c *AgentChecker) ShouldCheckHttpMethod(method string) bool {
+	return inArray(c.filter.HttpMethods, method)
+}
+
+func (c *AgentChecker) ShouldCheckAction(action string) bool {
+	return inArray(c.filter.Actions, action)
+}
+
+func (c *AgentChecker) ShouldSkipAction(action string) bool {
+	return inArray(c.filter.ActionsToSkip, action)
+}
+
+func (c *AgentChecker) Check(input PolicyCheckInput) (PolicyCheckOutput, error) {
+	input.Service = "concourse"
+	input.ClusterName = c.clusterName
+	input.ClusterVersion = c.clusterVersion
+
+	return c.agent.Check(input)
+}
+
+func inArray(array []string, target string) bool {
+	for _, ele := range array {
+		if strings.EqualFold(ele, target) {
+			return true
+		}
+	}
+	return false
+}
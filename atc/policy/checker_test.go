@@ -0,0 +1,139 @@
+package policy_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/policyfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// agent factories are registered globally, so only register the fake once
+var fakeAgentFactory = new(policyfakes.FakeAgentFactory)
+
+func init() {
+	policy.RegisterAgent(fakeAgentFactory)
+}
+
+var _ = Describe("Policy checker", func() {
+	var (
+		logger    *lagertest.TestLogger
+		filter    policy.Filter
+		fakeAgent *policyfakes.FakeAgent
+		checker   policy.Checker
+		initErr   error
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("policy-test")
+
+		filter = policy.Filter{
+			HttpMethods:   []string{"PUT", "POST"},
+			Actions:       []string{"HijackContainer", policy.ActionRunTask},
+			ActionsToSkip: []string{"SetTeam"},
+		}
+
+		fakeAgent = new(policyfakes.FakeAgent)
+
+		fakeAgentFactory.DescriptionReturns("fake")
+		fakeAgentFactory.IsConfiguredReturns(true)
+		fakeAgentFactory.NewAgentReturns(fakeAgent, nil)
+	})
+
+	JustBeforeEach(func() {
+		checker, initErr = policy.Initialize(logger, "some-cluster", "some-version", filter)
+	})
+
+	It("initializes", func() {
+		Expect(initErr).ToNot(HaveOccurred())
+		Expect(checker).ToNot(BeNil())
+	})
+
+	Context("when no agent is configured", func() {
+		BeforeEach(func() {
+			fakeAgentFactory.IsConfiguredReturns(false)
+		})
+
+		It("returns no checker", func() {
+			Expect(initErr).ToNot(HaveOccurred())
+			Expect(checker).To(BeNil())
+		})
+	})
+
+	Context("when the agent fails to initialize", func() {
+		BeforeEach(func() {
+			fakeAgentFactory.NewAgentReturns(nil, errors.New("bad config"))
+		})
+
+		It("errors", func() {
+			Expect(initErr).To(MatchError("bad config"))
+		})
+	})
+
+	It("checks the filtered http methods", func() {
+		Expect(checker.ShouldCheckHttpMethod("PUT")).To(BeTrue())
+		Expect(checker.ShouldCheckHttpMethod("put")).To(BeTrue())
+		Expect(checker.ShouldCheckHttpMethod("GET")).To(BeFalse())
+	})
+
+	It("checks the filtered actions", func() {
+		Expect(checker.ShouldCheckAction("HijackContainer")).To(BeTrue())
+		Expect(checker.ShouldCheckAction(policy.ActionRunTask)).To(BeTrue())
+		Expect(checker.ShouldCheckAction("ListPipelines")).To(BeFalse())
+	})
+
+	It("skips the filtered actions", func() {
+		Expect(checker.ShouldSkipAction("SetTeam")).To(BeTrue())
+		Expect(checker.ShouldSkipAction("SaveConfig")).To(BeFalse())
+	})
+
+	Describe("Check", func() {
+		var (
+			output   policy.PolicyCheckOutput
+			checkErr error
+		)
+
+		JustBeforeEach(func() {
+			output, checkErr = checker.Check(policy.PolicyCheckInput{
+				Action: "SaveConfig",
+				Team:   "some-team",
+			})
+		})
+
+		Context("when the agent allows the action", func() {
+			BeforeEach(func() {
+				fakeAgent.CheckReturns(policy.PassedPolicyCheck, nil)
+			})
+
+			It("passes the input with cluster details to the agent", func() {
+				Expect(fakeAgent.CheckCallCount()).To(Equal(1))
+				Expect(fakeAgent.CheckArgsForCall(0)).To(Equal(policy.PolicyCheckInput{
+					Service:        "concourse",
+					ClusterName:    "some-cluster",
+					ClusterVersion: "some-version",
+					Action:         "SaveConfig",
+					Team:           "some-team",
+				}))
+			})
+
+			It("returns the agent's output", func() {
+				Expect(checkErr).ToNot(HaveOccurred())
+				Expect(output).To(Equal(policy.PassedPolicyCheck))
+			})
+		})
+
+		Context("when the agent fails", func() {
+			disaster := errors.New("agent unavailable")
+
+			BeforeEach(func() {
+				fakeAgent.CheckReturns(policy.PolicyCheckOutput{}, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(checkErr).To(Equal(disaster))
+			})
+		})
+	})
+})
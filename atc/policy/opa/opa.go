@@ -0,0 +1,102 @@
+package opa
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/policy"
+)
+
+type OpaConfig struct {
+	URL     string        `long:"opa-url" description:"OPA policy check endpoint."`
+	Timeout time.Duration `long:"opa-timeout" default:"5s" description:"OPA request timeout."`
+}
+
+type opaInput struct {
+	Input policy.PolicyCheckInput `json:"input"`
+}
+
+type opaResult struct {
+	Result *struct {
+		Allowed bool     `json:"allowed"`
+		Block   *bool    `json:"block"`
+		Reasons []string `json:"reasons"`
+	} `json:"result"`
+}
+
+func init() {
+	policy.RegisterAgent(&OpaConfig{})
+}
+
+func (c *OpaConfig) Description() string { return "Open Policy Agent" }
+func (c *OpaConfig) IsConfigured() bool  { return c.URL != "" }
+
+func (c *OpaConfig) NewAgent(logger lager.Logger) (policy.Agent, error) {
+	return opa{*c, logger}, nil
+}
+
+type opa struct {
+	config OpaConfig
+	logger lager.Logger
+}
+
+// Check posts the input document to the OPA endpoint. The policy is expected
+// to produce a result document containing "allowed" and optionally "block"
+// and "reasons". A denied action blocks unless "block" is explicitly false, in
+// which case the reasons are only surfaced as warnings.
+func (c opa) Check(input policy.PolicyCheckInput) (policy.PolicyCheckOutput, error) {
+	data := opaInput{input}
+	jsonBytes, err := json.Marshal(data)
+	if err != nil {
+		return policy.PolicyCheckOutput{}, err
+	}
+
+	c.logger.Debug("opa-check", lager.Data{"input": string(jsonBytes)})
+
+	req, err := http.NewRequest("POST", c.config.URL, bytes.NewBuffer(jsonBytes))
+	if err != nil {
+		return policy.PolicyCheckOutput{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{
+		Timeout: c.config.Timeout,
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return policy.PolicyCheckOutput{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return policy.PolicyCheckOutput{}, fmt.Errorf("opa returned status: %d", resp.StatusCode)
+	}
+
+	var result opaResult
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if err != nil {
+		return policy.PolicyCheckOutput{}, fmt.Errorf("opa returned bad response: %s", err)
+	}
+
+	// an undefined result means the policy does not exist or did not match
+	// the input; fail closed rather than silently allowing the action
+	if result.Result == nil {
+		return policy.PolicyCheckOutput{}, fmt.Errorf("opa returned no result")
+	}
+
+	shouldBlock := true
+	if result.Result.Block != nil {
+		shouldBlock = *result.Result.Block
+	}
+
+	return policy.PolicyCheckOutput{
+		Allowed:     result.Result.Allowed,
+		ShouldBlock: shouldBlock,
+		Reasons:     result.Result.Reasons,
+	}, nil
+}
@@ -0,0 +1,123 @@
+package opa_test
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/opa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("OPA Policy Checker", func() {
+	var (
+		logger     *lagertest.TestLogger
+		fakeServer *ghttp.Server
+		agent      policy.Agent
+		result     policy.PolicyCheckOutput
+		checkErr   error
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("opa-test")
+		fakeServer = ghttp.NewServer()
+	})
+
+	AfterEach(func() {
+		fakeServer.Close()
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		agent, err = (&opa.OpaConfig{
+			URL:     fakeServer.URL(),
+			Timeout: time.Second,
+		}).NewAgent(logger)
+		Expect(err).ToNot(HaveOccurred())
+
+		result, checkErr = agent.Check(policy.PolicyCheckInput{
+			Service: "concourse",
+			Action:  "SaveConfig",
+			Team:    "some-team",
+		})
+	})
+
+	Context("when OPA allows the action", func() {
+		BeforeEach(func() {
+			fakeServer.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/"),
+				ghttp.VerifyJSON(`{"input":{"service":"concourse","cluster_name":"","cluster_version":"","action":"SaveConfig","team":"some-team"}}`),
+				ghttp.RespondWith(http.StatusOK, `{"result":{"allowed":true}}`),
+			))
+		})
+
+		It("passes", func() {
+			Expect(checkErr).ToNot(HaveOccurred())
+			Expect(result.Allowed).To(BeTrue())
+		})
+	})
+
+	Context("when OPA denies the action", func() {
+		BeforeEach(func() {
+			fakeServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `{"result":{"allowed":false,"reasons":["a policy says you can't do that"]}}`),
+			)
+		})
+
+		It("blocks by default", func() {
+			Expect(checkErr).ToNot(HaveOccurred())
+			Expect(result.Allowed).To(BeFalse())
+			Expect(result.ShouldBlock).To(BeTrue())
+			Expect(result.Reasons).To(ConsistOf("a policy says you can't do that"))
+		})
+
+		Context("when the result says not to block", func() {
+			BeforeEach(func() {
+				fakeServer.SetHandler(0,
+					ghttp.RespondWith(http.StatusOK, `{"result":{"allowed":false,"block":false,"reasons":["just a warning"]}}`),
+				)
+			})
+
+			It("only warns", func() {
+				Expect(checkErr).ToNot(HaveOccurred())
+				Expect(result.Allowed).To(BeFalse())
+				Expect(result.ShouldBlock).To(BeFalse())
+				Expect(result.Reasons).To(ConsistOf("just a warning"))
+			})
+		})
+	})
+
+	Context("when OPA returns no result", func() {
+		BeforeEach(func() {
+			fakeServer.AppendHandlers(ghttp.RespondWith(http.StatusOK, `{}`))
+		})
+
+		It("errors", func() {
+			Expect(checkErr).To(MatchError("opa returned no result"))
+		})
+	})
+
+	Context("when OPA returns a bad status", func() {
+		BeforeEach(func() {
+			fakeServer.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, "{}"))
+		})
+
+		It("errors", func() {
+			Expect(checkErr).To(MatchError("opa returned status: 500"))
+		})
+	})
+
+	Context("when OPA returns invalid json", func() {
+		BeforeEach(func() {
+			fakeServer.AppendHandlers(ghttp.RespondWith(http.StatusOK, "hello"))
+		})
+
+		It("errors", func() {
+			Expect(checkErr).To(HaveOccurred())
+			Expect(checkErr.Error()).To(ContainSubstring("opa returned bad response"))
+		})
+	})
+})
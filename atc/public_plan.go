@@ -8,11 +8,15 @@ func (plan Plan) Public() *json.RawMessage {
 
 		Aggregate      *json.RawMessage `json:"aggregate,omitempty"`
 		InParallel     *json.RawMessage `json:"in_parallel,omitempty"`
+		Across         *json.RawMessage `json:"across,omitempty"`
 		Do             *json.RawMessage `json:"do,omitempty"`
 		Get            *json.RawMessage `json:"get,omitempty"`
 		Put            *json.RawMessage `json:"put,omitempty"`
 		Check          *json.RawMessage `json:"check,omitempty"`
 		Task           *json.RawMessage `json:"task,omitempty"`
+		SetPipeline    *json.RawMessage `json:"set_pipeline,omitempty"`
+		LoadVar        *json.RawMessage `json:"load_var,omitempty"`
+		Approval       *json.RawMessage `json:"approval,omitempty"`
 		OnAbort        *json.RawMessage `json:"on_abort,omitempty"`
 		OnError        *json.RawMessage `json:"on_error,omitempty"`
 		Ensure         *json.RawMessage `json:"ensure,omitempty"`
@@ -36,6 +40,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.InParallel = plan.InParallel.Public()
 	}
 
+	if plan.Across != nil {
+		public.Across = plan.Across.Public()
+	}
+
 	if plan.Do != nil {
 		public.Do = plan.Do.Public()
 	}
@@ -56,6 +64,18 @@ func (plan Plan) Public() *json.RawMessage {
 		public.Task = plan.Task.Public()
 	}
 
+	if plan.SetPipeline != nil {
+		public.SetPipeline = plan.SetPipeline.Public()
+	}
+
+	if plan.LoadVar != nil {
+		public.LoadVar = plan.LoadVar.Public()
+	}
+
+	if plan.Approval != nil {
+		public.Approval = plan.Approval.Public()
+	}
+
 	if plan.OnAbort != nil {
 		public.OnAbort = plan.OnAbort.Public()
 	}
@@ -131,6 +151,32 @@ func (plan InParallelPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan AcrossPlan) Public() *json.RawMessage {
+	type scopedStep struct {
+		Step   *json.RawMessage `json:"step"`
+		Values []interface{}    `json:"values"`
+	}
+
+	steps := make([]scopedStep, len(plan.Steps))
+
+	for i := 0; i < len(plan.Steps); i++ {
+		steps[i] = scopedStep{
+			Step:   plan.Steps[i].Step.Public(),
+			Values: plan.Steps[i].Values,
+		}
+	}
+
+	return enc(struct {
+		Vars     []AcrossVar  `json:"vars"`
+		Steps    []scopedStep `json:"steps"`
+		FailFast bool         `json:"fail_fast,omitempty"`
+	}{
+		Vars:     plan.Vars,
+		Steps:    steps,
+		FailFast: plan.FailFast,
+	})
+}
+
 func (plan DoPlan) Public() *json.RawMessage {
 	public := make([]*json.RawMessage, len(plan))
 
@@ -249,6 +295,38 @@ func (plan TaskPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan SetPipelinePlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name         string       `json:"name"`
+		InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+	}{
+		Name:         plan.Name,
+		InstanceVars: plan.InstanceVars,
+	})
+}
+
+func (plan LoadVarPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+		File string `json:"file"`
+	}{
+		Name: plan.Name,
+		File: plan.File,
+	})
+}
+
+func (plan ApprovalPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name         string   `json:"name"`
+		AllowedRoles []string `json:"allowed_roles,omitempty"`
+		Timeout      string   `json:"timeout,omitempty"`
+	}{
+		Name:         plan.Name,
+		AllowedRoles: plan.AllowedRoles,
+		Timeout:      plan.Timeout,
+	})
+}
+
 func (plan TimeoutPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step     *json.RawMessage `json:"step"`
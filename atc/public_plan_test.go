@@ -350,6 +350,41 @@ var _ = Describe("Plan", func() {
 							},
 						},
 					},
+					atc.Plan{
+						ID: "38",
+						Across: &atc.AcrossPlan{
+							Vars: []atc.AcrossVar{
+								{
+									Var:         "some-var",
+									Values:      []interface{}{"a", "b"},
+									MaxInFlight: 2,
+								},
+							},
+							Steps: []atc.VarScopedPlan{
+								{
+									Step: atc.Plan{
+										ID: "39",
+										Task: &atc.TaskPlan{
+											Name:   "name",
+											Params: atc.Params{"some": "secret"},
+										},
+									},
+									Values: []interface{}{"a"},
+								},
+								{
+									Step: atc.Plan{
+										ID: "40",
+										Task: &atc.TaskPlan{
+											Name:   "name",
+											Params: atc.Params{"some": "secret"},
+										},
+									},
+									Values: []interface{}{"b"},
+								},
+							},
+							FailFast: true,
+						},
+					},
 				},
 			}
 
@@ -608,6 +643,41 @@ var _ = Describe("Plan", func() {
 				"limit": 1,
 				"fail_fast": true
 			}
+		},
+		{
+			"id": "38",
+			"across": {
+				"vars": [
+					{
+						"name": "some-var",
+						"values": ["a", "b"],
+						"max_in_flight": 2
+					}
+				],
+				"steps": [
+					{
+						"step": {
+							"id": "39",
+							"task": {
+								"name": "name",
+								"privileged": false
+							}
+						},
+						"values": ["a"]
+					},
+					{
+						"step": {
+							"id": "40",
+							"task": {
+								"name": "name",
+								"privileged": false
+							}
+						},
+						"values": ["b"]
+					}
+				],
+				"fail_fast": true
+			}
 		}
   ]
 }
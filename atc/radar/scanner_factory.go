@@ -4,10 +4,12 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/clock"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/vars"
 )
 
 // ScannerFactory is the same interface as resourceserver/server.go
@@ -58,7 +60,7 @@ func NewScannerFactory(
 }
 
 func (f *scannerFactory) NewResourceScanner(dbPipeline db.Pipeline) Scanner {
-	variables := creds.NewVariables(f.secretManager, dbPipeline.TeamName(), dbPipeline.Name())
+	variables := f.variables(dbPipeline)
 
 	return NewResourceScanner(
 		clock.NewClock(),
@@ -74,7 +76,7 @@ func (f *scannerFactory) NewResourceScanner(dbPipeline db.Pipeline) Scanner {
 }
 
 func (f *scannerFactory) NewResourceTypeScanner(dbPipeline db.Pipeline) Scanner {
-	variables := creds.NewVariables(f.secretManager, dbPipeline.TeamName(), dbPipeline.Name())
+	variables := f.variables(dbPipeline)
 
 	return NewResourceTypeScanner(
 		clock.NewClock(),
@@ -88,3 +90,14 @@ func (f *scannerFactory) NewResourceTypeScanner(dbPipeline db.Pipeline) Scanner
 		f.strategy,
 	)
 }
+
+// variables returns the vars of the pipeline, with each lookup of a secret
+// audited as the pipeline's.
+func (f *scannerFactory) variables(dbPipeline db.Pipeline) vars.Variables {
+	secrets := creds.WithSecretAccess(f.secretManager, atc.SecretAccess{
+		TeamName:     dbPipeline.TeamName(),
+		PipelineName: dbPipeline.Name(),
+	})
+
+	return creds.NewVariables(secrets, dbPipeline.TeamName(), dbPipeline.Name())
+}
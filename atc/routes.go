@@ -19,6 +19,7 @@ const (
 
 	GetJob         = "GetJob"
 	CreateJobBuild = "CreateJobBuild"
+	RerunJobBuild  = "RerunJobBuild"
 	ListAllJobs    = "ListAllJobs"
 	ListJobs       = "ListJobs"
 	ListJobBuilds  = "ListJobBuilds"
@@ -81,6 +82,7 @@ const (
 	DownloadCLI  = "DownloadCLI"
 	GetInfo      = "Info"
 	GetInfoCreds = "InfoCreds"
+	GetInfoRoles = "InfoRoles"
 
 	ListContainers           = "ListContainers"
 	GetContainer             = "GetContainer"
@@ -99,11 +101,30 @@ const (
 	DestroyTeam    = "DestroyTeam"
 	ListTeamBuilds = "ListTeamBuilds"
 
+	SearchBuildLogs = "SearchBuildLogs"
+	ListSemaphores  = "ListSemaphores"
+
 	CreateArtifact     = "CreateArtifact"
 	GetArtifact        = "GetArtifact"
 	ListBuildArtifacts = "ListBuildArtifacts"
 
+	ListArchivedArtifacts = "ListArchivedArtifacts"
+	GetArchivedArtifact   = "GetArchivedArtifact"
+
+	ListBuildTestResults = "ListBuildTestResults"
+	ListJobTestHistory   = "ListJobTestHistory"
+
+	ListBuildApprovals = "ListBuildApprovals"
+	ApproveBuild       = "ApproveBuild"
+	RejectBuild        = "RejectBuild"
+
+	GetJobStats = "GetJobStats"
+
 	ListActiveUsersSince = "ListActiveUsersSince"
+
+	ListSecretAccesses = "ListSecretAccesses"
+
+	GetEncryptionStatus = "GetEncryptionStatus"
 )
 
 const (
@@ -125,6 +146,12 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
+	{Path: "/api/v1/builds/:build_id/archived-artifacts", Method: "GET", Name: ListArchivedArtifacts},
+	{Path: "/api/v1/builds/:build_id/archived-artifacts/:plan_id/:artifact_name", Method: "GET", Name: GetArchivedArtifact},
+	{Path: "/api/v1/builds/:build_id/tests", Method: "GET", Name: ListBuildTestResults},
+	{Path: "/api/v1/builds/:build_id/approvals", Method: "GET", Name: ListBuildApprovals},
+	{Path: "/api/v1/builds/:build_id/approve", Method: "PUT", Name: ApproveBuild},
+	{Path: "/api/v1/builds/:build_id/reject", Method: "PUT", Name: RejectBuild},
 
 	{Path: "/api/v1/checks/:check_id", Method: "GET", Name: GetCheck},
 
@@ -134,7 +161,10 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "GET", Name: ListJobBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: ListJobInputs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/tests", Method: "GET", Name: ListJobTestHistory},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/stats", Method: "GET", Name: GetJobStats},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "POST", Name: RerunJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/badge", Method: "GET", Name: JobBadge},
@@ -192,9 +222,14 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/cli", Method: "GET", Name: DownloadCLI},
 	{Path: "/api/v1/info", Method: "GET", Name: GetInfo},
 	{Path: "/api/v1/info/creds", Method: "GET", Name: GetInfoCreds},
+	{Path: "/api/v1/info/roles", Method: "GET", Name: GetInfoRoles},
 
 	{Path: "/api/v1/users", Method: "GET", Name: ListActiveUsersSince},
 
+	{Path: "/api/v1/secret_accesses", Method: "GET", Name: ListSecretAccesses},
+
+	{Path: "/api/v1/encryption", Method: "GET", Name: GetEncryptionStatus},
+
 	{Path: "/api/v1/containers/destroying", Method: "GET", Name: ListDestroyingContainers},
 	{Path: "/api/v1/containers/report", Method: "PUT", Name: ReportWorkerContainers},
 	{Path: "/api/v1/teams/:team_name/containers", Method: "GET", Name: ListContainers},
@@ -211,6 +246,8 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/rename", Method: "PUT", Name: RenameTeam},
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
+	{Path: "/api/v1/teams/:team_name/logs/search", Method: "GET", Name: SearchBuildLogs},
+	{Path: "/api/v1/teams/:team_name/semaphores", Method: "GET", Name: ListSemaphores},
 
 	{Path: "/api/v1/teams/:team_name/artifacts", Method: "POST", Name: CreateArtifact},
 	{Path: "/api/v1/teams/:team_name/artifacts/:artifact_id", Method: "GET", Name: GetArtifact},
@@ -134,13 +134,38 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		resourceTypes = dbResourceTypes.Deserialize()
 	}
 
-	buildInputs, found, err := job.GetNextBuildInputs()
-	if err != nil {
-		logger.Error("failed-to-get-next-build-inputs", err)
-		return false, err
-	}
-	if !found {
-		return false, nil
+	var (
+		buildInputs []db.BuildInput
+		found       bool
+	)
+
+	if nextPendingBuild.RerunOf() != 0 {
+		buildInputs, found, err = nextPendingBuild.RerunInputs()
+		if err != nil {
+			logger.Error("failed-to-get-rerun-inputs", err)
+			return false, err
+		}
+
+		if !found {
+			// the versions used by the rerun build are gone, so it can't be
+			// reproduced
+			logger.Info("rerun-inputs-not-found")
+			if err = nextPendingBuild.Finish(db.BuildStatusErrored); err != nil {
+				logger.Error("failed-to-mark-build-as-errored", err)
+				return false, err
+			}
+
+			return true, nil
+		}
+	} else {
+		buildInputs, found, err = job.GetNextBuildInputs()
+		if err != nil {
+			logger.Error("failed-to-get-next-build-inputs", err)
+			return false, err
+		}
+		if !found {
+			return false, nil
+		}
 	}
 
 	pipelinePaused, err := s.pipeline.CheckPaused()
@@ -156,14 +181,16 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, nil
 	}
 
-	updated, err := nextPendingBuild.Schedule()
+	// the job's semaphores are acquired along with scheduling the build, so
+	// that they are not held by a build which failed to be scheduled
+	updated, err := nextPendingBuild.Schedule(job.Config().Semaphores)
 	if err != nil {
 		logger.Error("failed-to-update-build-to-scheduled", err)
 		return false, err
 	}
 
 	if !updated {
-		logger.Debug("build-already-scheduled")
+		logger.Debug("build-not-scheduled")
 		return false, nil
 	}
 
@@ -172,6 +199,12 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return false, err
 	}
 
+	err = s.recordTriggeringVersion(nextPendingBuild, buildInputs)
+	if err != nil {
+		logger.Error("failed-to-record-triggering-version", err)
+		return false, err
+	}
+
 	resourceConfigs := atc.ResourceConfigs{}
 	for _, v := range resources {
 		resourceConfigs = append(resourceConfigs, atc.ResourceConfig{
@@ -206,3 +239,22 @@ func (s *buildStarter) tryStartNextPendingBuild(
 
 	return true, nil
 }
+
+// recordTriggeringVersion adds the version of the resource whose new version
+// triggered the build to its creator, now that the build's inputs are known.
+func (s *buildStarter) recordTriggeringVersion(build db.Build, buildInputs []db.BuildInput) error {
+	createdBy := build.CreatedBy()
+	if createdBy == nil || createdBy.Type != atc.BuildCreatorResource || createdBy.Version != nil {
+		return nil
+	}
+
+	for _, input := range buildInputs {
+		if input.Name == createdBy.Input {
+			recorded := *createdBy
+			recorded.Version = input.Version
+			return build.SetCreatedBy(recorded)
+		}
+	}
+
+	return nil
+}
@@ -464,6 +464,44 @@ var _ = Describe("BuildStarter", func() {
 								pendingBuild1.UseInputsReturns(nil)
 							})
 
+							It("does not change why builds not triggered by a resource were created", func() {
+								Expect(pendingBuild1.SetCreatedByCallCount()).To(BeZero())
+							})
+
+							Context("when the build was triggered by a new version of a resource", func() {
+								BeforeEach(func() {
+									job.GetNextBuildInputsReturns([]db.BuildInput{
+										{Name: "some-input", Version: atc.Version{"ref": "abc"}},
+									}, true, nil)
+
+									pendingBuild1.CreatedByReturns(&atc.BuildCreator{
+										Type:     atc.BuildCreatorResource,
+										Resource: "some-resource",
+										Input:    "some-input",
+									})
+								})
+
+								It("records the version which triggered the build", func() {
+									Expect(pendingBuild1.SetCreatedByCallCount()).To(Equal(1))
+									Expect(pendingBuild1.SetCreatedByArgsForCall(0)).To(Equal(atc.BuildCreator{
+										Type:     atc.BuildCreatorResource,
+										Resource: "some-resource",
+										Input:    "some-input",
+										Version:  atc.Version{"ref": "abc"},
+									}))
+								})
+
+								Context("when recording the version fails", func() {
+									BeforeEach(func() {
+										pendingBuild1.SetCreatedByReturns(disaster)
+									})
+
+									It("returns the error", func() {
+										Expect(tryStartErr).To(Equal(disaster))
+									})
+								})
+							})
+
 							Context("when creating the build plan fails", func() {
 								BeforeEach(func() {
 									fakeFactory.CreateReturns(atc.Plan{}, disaster)
@@ -622,6 +660,60 @@ var _ = Describe("BuildStarter", func() {
 						itUpdatedMaxInFlightForTheFirstBuild()
 					})
 
+					Context("when the build is a rerun", func() {
+						var rerunInputs []db.BuildInput
+
+						BeforeEach(func() {
+							rerunInputs = []db.BuildInput{
+								{Name: "some-input", ResourceID: 11, Version: atc.Version{"ref": "old"}},
+							}
+
+							pendingBuild1.RerunOfReturns(42)
+							pendingBuild1.RerunInputsReturns(rerunInputs, true, nil)
+							pendingBuild1.StartReturns(true, nil)
+							fakeFactory.CreateReturns(atc.Plan{Task: &atc.TaskPlan{ConfigPath: "some-task-1.yml"}}, nil)
+
+							job.GetNextBuildInputsReturns(nil, false, nil)
+						})
+
+						It("runs with the inputs of the original build", func() {
+							Expect(pendingBuild1.UseInputsCallCount()).To(Equal(1))
+							Expect(pendingBuild1.UseInputsArgsForCall(0)).To(Equal(rerunInputs))
+
+							Expect(fakeFactory.CreateCallCount()).To(BeNumerically(">=", 1))
+							_, _, _, actualBuildInputs := fakeFactory.CreateArgsForCall(0)
+							Expect(actualBuildInputs).To(Equal(rerunInputs))
+
+							Expect(pendingBuild1.StartCallCount()).To(Equal(1))
+						})
+
+						It("does not touch the job's next build inputs", func() {
+							Expect(fakeInputMapper.SaveNextInputMappingCallCount()).To(BeZero())
+						})
+
+						Context("when getting the inputs of the original build fails", func() {
+							BeforeEach(func() {
+								pendingBuild1.RerunInputsReturns(nil, false, disaster)
+							})
+
+							itReturnsTheError()
+						})
+
+						Context("when the versions of the original build are gone", func() {
+							BeforeEach(func() {
+								pendingBuild1.RerunInputsReturns(nil, false, nil)
+							})
+
+							It("errors the build without starting it", func() {
+								Expect(tryStartErr).NotTo(HaveOccurred())
+
+								Expect(pendingBuild1.FinishCallCount()).To(Equal(1))
+								Expect(pendingBuild1.FinishArgsForCall(0)).To(Equal(db.BuildStatusErrored))
+								Expect(pendingBuild1.StartCallCount()).To(BeZero())
+							})
+						})
+					})
+
 					Context("when checking if the pipeline is paused fails", func() {
 						BeforeEach(func() {
 							fakePipeline.CheckPausedReturns(false, disaster)
@@ -648,6 +740,38 @@ var _ = Describe("BuildStarter", func() {
 						itDoesntReturnAnErrorOrMarkTheBuildAsScheduled()
 						itUpdatedMaxInFlightForTheFirstBuild()
 					})
+
+					Context("when the job declares semaphores", func() {
+						var semaphores []atc.SemaphoreConfig
+
+						BeforeEach(func() {
+							semaphores = []atc.SemaphoreConfig{{Name: "staging", RawCapacity: 2}}
+							job.ConfigReturns(atc.JobConfig{Name: "some-job", Semaphores: semaphores})
+						})
+
+						It("schedules the build with the job's semaphores", func() {
+							Expect(pendingBuild1.ScheduleCallCount()).To(Equal(1))
+							Expect(pendingBuild1.ScheduleArgsForCall(0)).To(Equal(semaphores))
+						})
+
+						Context("when the semaphores are unavailable", func() {
+							BeforeEach(func() {
+								pendingBuild1.ScheduleReturns(false, nil)
+							})
+
+							It("doesn't return an error", func() {
+								Expect(tryStartErr).NotTo(HaveOccurred())
+							})
+
+							It("doesn't try to use inputs for the build", func() {
+								Expect(pendingBuild1.UseInputsCallCount()).To(BeZero())
+							})
+
+							It("doesn't try to start the later builds", func() {
+								Expect(pendingBuild2.ScheduleCallCount()).To(BeZero())
+							})
+						})
+					})
 				})
 			})
 		})
@@ -100,24 +100,13 @@ func (factory *buildFactory) constructPlanFromConfig(
 	var plan atc.Plan
 	var err error
 
-	if planConfig.Attempts == 0 {
-		plan, err = factory.constructUnhookedPlan(planConfig, resources, resourceTypes, inputs)
-		if err != nil {
-			return atc.Plan{}, err
-		}
+	if planConfig.Across != nil {
+		plan, err = factory.across(planConfig, resources, resourceTypes, inputs)
 	} else {
-		retryStep := make(atc.RetryPlan, planConfig.Attempts)
-
-		for i := 0; i < planConfig.Attempts; i++ {
-			attempt, err := factory.constructUnhookedPlan(planConfig, resources, resourceTypes, inputs)
-			if err != nil {
-				return atc.Plan{}, err
-			}
-
-			retryStep[i] = attempt
-		}
-
-		plan = factory.planFactory.NewPlan(retryStep)
+		plan, err = factory.constructRetryablePlan(planConfig, resources, resourceTypes, inputs)
+	}
+	if err != nil {
+		return atc.Plan{}, err
 	}
 
 	return factory.applyHooks(constructionParams{
@@ -129,6 +118,88 @@ func (factory *buildFactory) constructPlanFromConfig(
 	})
 }
 
+func (factory *buildFactory) constructRetryablePlan(
+	planConfig atc.PlanConfig,
+	resources atc.ResourceConfigs,
+	resourceTypes atc.VersionedResourceTypes,
+	inputs []db.BuildInput,
+) (atc.Plan, error) {
+	if planConfig.Attempts == 0 {
+		return factory.constructUnhookedPlan(planConfig, resources, resourceTypes, inputs)
+	}
+
+	retryStep := make(atc.RetryPlan, planConfig.Attempts)
+
+	for i := 0; i < planConfig.Attempts; i++ {
+		attempt, err := factory.constructUnhookedPlan(planConfig, resources, resourceTypes, inputs)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		retryStep[i] = attempt
+	}
+
+	return factory.planFactory.NewPlan(retryStep), nil
+}
+
+// across expands the step into one plan for each combination of the across
+// vars' values. Hooks are not part of the expanded plans; they apply to the
+// across step as a whole.
+func (factory *buildFactory) across(
+	planConfig atc.PlanConfig,
+	resources atc.ResourceConfigs,
+	resourceTypes atc.VersionedResourceTypes,
+	inputs []db.BuildInput,
+) (atc.Plan, error) {
+	acrossPlan := atc.AcrossPlan{
+		FailFast: planConfig.FailFast,
+	}
+
+	for _, acrossVar := range planConfig.Across {
+		acrossPlan.Vars = append(acrossPlan.Vars, atc.AcrossVar{
+			Var:         acrossVar.Var,
+			Values:      acrossVar.Values,
+			MaxInFlight: acrossVar.MaxInFlight,
+		})
+	}
+
+	for _, values := range acrossCombinations(planConfig.Across) {
+		step, err := factory.constructRetryablePlan(planConfig, resources, resourceTypes, inputs)
+		if err != nil {
+			return atc.Plan{}, err
+		}
+
+		acrossPlan.Steps = append(acrossPlan.Steps, atc.VarScopedPlan{
+			Step:   step,
+			Values: values,
+		})
+	}
+
+	return factory.planFactory.NewPlan(acrossPlan), nil
+}
+
+// acrossCombinations returns the cartesian product of the vars' values, with
+// the values of the last var varying fastest.
+func acrossCombinations(vars []atc.AcrossVarConfig) [][]interface{} {
+	combinations := [][]interface{}{{}}
+
+	for _, acrossVar := range vars {
+		var next [][]interface{}
+
+		for _, combination := range combinations {
+			for _, value := range acrossVar.Values {
+				values := make([]interface{}, len(combination), len(combination)+1)
+				copy(values, combination)
+				next = append(next, append(values, value))
+			}
+		}
+
+		combinations = next
+	}
+
+	return combinations
+}
+
 func (factory *buildFactory) constructUnhookedPlan(
 	planConfig atc.PlanConfig,
 	resources atc.ResourceConfigs,
@@ -242,6 +313,31 @@ func (factory *buildFactory) constructUnhookedPlan(
 
 			VersionedResourceTypes: resourceTypes,
 		})
+
+	case planConfig.SetPipeline != "":
+		plan = factory.planFactory.NewPlan(atc.SetPipelinePlan{
+			Name:         planConfig.SetPipeline,
+			File:         planConfig.TaskConfigPath,
+			Vars:         planConfig.TaskVars,
+			VarFiles:     planConfig.VarFiles,
+			InstanceVars: planConfig.InstanceVars,
+		})
+
+	case planConfig.LoadVar != "":
+		plan = factory.planFactory.NewPlan(atc.LoadVarPlan{
+			Name:   planConfig.LoadVar,
+			File:   planConfig.TaskConfigPath,
+			Format: planConfig.Format,
+			Reveal: planConfig.Reveal,
+		})
+
+	case planConfig.Approval != "":
+		plan = factory.planFactory.NewPlan(atc.ApprovalPlan{
+			Name:         planConfig.Approval,
+			AllowedRoles: planConfig.AllowedRoles,
+			Timeout:      planConfig.Timeout,
+		})
+
 	case planConfig.Try != nil:
 		nextStep, err := factory.constructPlanFromConfig(
 			*planConfig.Try,
@@ -300,7 +396,9 @@ func (factory *buildFactory) constructUnhookedPlan(
 		})
 	}
 
-	if planConfig.Timeout != "" {
+	// approval steps persist their own deadline so that it survives restarts,
+	// rather than being interrupted like other steps
+	if planConfig.Timeout != "" && planConfig.Approval == "" {
 		plan = factory.planFactory.NewPlan(atc.TimeoutPlan{
 			Duration: planConfig.Timeout,
 			Step:     plan,
@@ -0,0 +1,127 @@
+package factory_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/scheduler/factory"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Across", func() {
+	var (
+		buildFactory factory.BuildFactory
+
+		resourceTypes       atc.VersionedResourceTypes
+		actualPlanFactory   atc.PlanFactory
+		expectedPlanFactory atc.PlanFactory
+
+		acrossVars []atc.AcrossVar
+	)
+
+	BeforeEach(func() {
+		actualPlanFactory = atc.NewPlanFactory(123)
+		expectedPlanFactory = atc.NewPlanFactory(123)
+
+		buildFactory = factory.NewBuildFactory(actualPlanFactory)
+
+		resourceTypes = atc.VersionedResourceTypes{
+			{
+				ResourceType: atc.ResourceType{
+					Name:   "some-custom-resource",
+					Type:   "registry-image",
+					Source: atc.Source{"some": "custom-source"},
+				},
+				Version: atc.Version{"some": "version"},
+			},
+		}
+
+		acrossVars = []atc.AcrossVar{
+			{Var: "var1", Values: []interface{}{"a", "b"}},
+			{Var: "var2", Values: []interface{}{"1", "2"}, MaxInFlight: 2},
+		}
+	})
+
+	Context("when I have an across step", func() {
+		It("returns a plan for each combination of values", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "some-task",
+						Across: []atc.AcrossVarConfig{
+							{Var: "var1", Values: []interface{}{"a", "b"}},
+							{Var: "var2", Values: []interface{}{"1", "2"}, MaxInFlight: 2},
+						},
+						FailFast: true,
+					},
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			var steps []atc.VarScopedPlan
+			for _, values := range [][]interface{}{{"a", "1"}, {"a", "2"}, {"b", "1"}, {"b", "2"}} {
+				steps = append(steps, atc.VarScopedPlan{
+					Step: expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:                   "some-task",
+						VersionedResourceTypes: resourceTypes,
+					}),
+					Values: values,
+				})
+			}
+
+			expected := expectedPlanFactory.NewPlan(atc.AcrossPlan{
+				Vars:     acrossVars,
+				Steps:    steps,
+				FailFast: true,
+			})
+			Expect(actual).To(Equal(expected))
+		})
+	})
+
+	Context("when an across step has hooks and attempts", func() {
+		It("retries each combination and runs the hooks once", func() {
+			actual, err := buildFactory.Create(atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Task: "some-task",
+						Across: []atc.AcrossVarConfig{
+							{Var: "var1", Values: []interface{}{"a", "b"}},
+						},
+						Attempts: 2,
+						Success: &atc.PlanConfig{
+							Task: "some-hook",
+						},
+					},
+				},
+			}, nil, resourceTypes, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			var steps []atc.VarScopedPlan
+			for _, value := range []interface{}{"a", "b"} {
+				retry := atc.RetryPlan{}
+				for i := 0; i < 2; i++ {
+					retry = append(retry, expectedPlanFactory.NewPlan(atc.TaskPlan{
+						Name:                   "some-task",
+						VersionedResourceTypes: resourceTypes,
+					}))
+				}
+
+				steps = append(steps, atc.VarScopedPlan{
+					Step:   expectedPlanFactory.NewPlan(retry),
+					Values: []interface{}{value},
+				})
+			}
+
+			expected := expectedPlanFactory.NewPlan(atc.OnSuccessPlan{
+				Step: expectedPlanFactory.NewPlan(atc.AcrossPlan{
+					Vars:  acrossVars[:1],
+					Steps: steps,
+				}),
+				Next: expectedPlanFactory.NewPlan(atc.TaskPlan{
+					Name:                   "some-hook",
+					VersionedResourceTypes: resourceTypes,
+				}),
+			})
+			Expect(actual).To(Equal(expected))
+		})
+	})
+})
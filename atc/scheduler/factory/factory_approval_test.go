@@ -0,0 +1,65 @@
+package factory_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/scheduler/factory"
+	"github.com/concourse/concourse/atc/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory Approval", func() {
+	Describe("ApprovalPlan", func() {
+		var (
+			buildFactory factory.BuildFactory
+
+			input               atc.JobConfig
+			actualPlanFactory   atc.PlanFactory
+			expectedPlanFactory atc.PlanFactory
+		)
+
+		BeforeEach(func() {
+			actualPlanFactory = atc.NewPlanFactory(123)
+			expectedPlanFactory = atc.NewPlanFactory(123)
+			buildFactory = factory.NewBuildFactory(actualPlanFactory)
+
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						Approval:     "deploy-to-prod",
+						AllowedRoles: []string{"owner"},
+					},
+				},
+			}
+		})
+
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(input, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.ApprovalPlan{
+				Name:         "deploy-to-prod",
+				AllowedRoles: []string{"owner"},
+			})
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+
+		Context("when a timeout is given", func() {
+			BeforeEach(func() {
+				input.Plan[0].Timeout = "24h"
+			})
+
+			It("gives the approval the timeout instead of interrupting it", func() {
+				actual, err := buildFactory.Create(input, nil, nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				expected := expectedPlanFactory.NewPlan(atc.ApprovalPlan{
+					Name:         "deploy-to-prod",
+					AllowedRoles: []string{"owner"},
+					Timeout:      "24h",
+				})
+				Expect(actual).To(testhelpers.MatchPlan(expected))
+			})
+		})
+	})
+})
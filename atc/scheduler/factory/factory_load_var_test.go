@@ -0,0 +1,51 @@
+package factory_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/scheduler/factory"
+	"github.com/concourse/concourse/atc/testhelpers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Factory LoadVar", func() {
+	Describe("LoadVarPlan", func() {
+		var (
+			buildFactory factory.BuildFactory
+
+			input               atc.JobConfig
+			actualPlanFactory   atc.PlanFactory
+			expectedPlanFactory atc.PlanFactory
+		)
+
+		BeforeEach(func() {
+			actualPlanFactory = atc.NewPlanFactory(123)
+			expectedPlanFactory = atc.NewPlanFactory(123)
+			buildFactory = factory.NewBuildFactory(actualPlanFactory)
+
+			input = atc.JobConfig{
+				Plan: atc.PlanSequence{
+					{
+						LoadVar:        "some-var",
+						TaskConfigPath: "some-input/version",
+						Format:         "trim",
+						Reveal:         true,
+					},
+				},
+			}
+		})
+
+		It("returns the correct plan", func() {
+			actual, err := buildFactory.Create(input, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected := expectedPlanFactory.NewPlan(atc.LoadVarPlan{
+				Name:   "some-var",
+				File:   "some-input/version",
+				Format: "trim",
+				Reveal: true,
+			})
+			Expect(actual).To(testhelpers.MatchPlan(expected))
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package scheduler
 
 import (
+	"sort"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -28,6 +29,10 @@ func (s *Scheduler) Schedule(
 	for _, job := range jobs {
 		jStart := time.Now()
 		err := s.ensurePendingBuildExists(logger, versions, job, resources)
+		if err == nil {
+			err = s.ensureScheduledBuildExists(logger, job)
+		}
+
 		jobSchedulingTime[job.Name()] = time.Since(jStart)
 
 		if err != nil {
@@ -41,7 +46,7 @@ func (s *Scheduler) Schedule(
 		return jobSchedulingTime, err
 	}
 
-	for _, job := range jobs {
+	for _, job := range byPendingPriority(jobs, nextPendingBuilds) {
 		jStart := time.Now()
 		nextPendingBuildsForJob, ok := nextPendingBuilds[job.Name()]
 		if !ok {
@@ -78,7 +83,11 @@ func (s *Scheduler) ensurePendingBuildExists(
 		if ok && inputVersion.FirstOccurrence {
 			hasNewInputs = true
 			if inputConfig.Trigger {
-				err := job.EnsurePendingBuildExists()
+				err := job.EnsurePendingBuildExists(atc.BuildCreator{
+					Type:     atc.BuildCreatorResource,
+					Resource: inputConfig.Resource,
+					Input:    inputConfig.Name,
+				})
 				if err != nil {
 					logger.Error("failed-to-ensure-pending-build-exists", err)
 					return err
@@ -97,3 +106,60 @@ func (s *Scheduler) ensurePendingBuildExists(
 
 	return nil
 }
+
+// ensureScheduledBuildExists creates a pending build for the job if its
+// schedule has triggered since it last did. Ticks missed while the scheduler
+// wasn't running result in a single build.
+func (s *Scheduler) ensureScheduledBuildExists(logger lager.Logger, job db.Job) error {
+	schedule := job.Config().Schedule
+	if schedule == nil || job.ScheduleTime().IsZero() {
+		return nil
+	}
+
+	next, err := schedule.Next(job.ScheduleTime())
+	if err != nil {
+		// the pipeline config was validated when it was set
+		logger.Error("failed-to-evaluate-schedule", err, lager.Data{"job": job.Name()})
+		return nil
+	}
+
+	now := time.Now()
+	if next.IsZero() || next.After(now) {
+		return nil
+	}
+
+	created, err := job.EnsureScheduledBuildExists(job.ScheduleTime(), now)
+	if err != nil {
+		logger.Error("failed-to-ensure-scheduled-build-exists", err, lager.Data{"job": job.Name()})
+		return err
+	}
+
+	if created {
+		logger.Debug("scheduled-build", lager.Data{"job": job.Name(), "scheduled-for": next})
+	}
+
+	return nil
+}
+
+// byPendingPriority orders the jobs so that those with the highest-priority
+// pending build are started first. Pending builds are already ordered by
+// priority, so each job's first pending build has its highest priority.
+func byPendingPriority(jobs []db.Job, pendingBuilds map[string][]db.Build) []db.Job {
+	priority := func(job db.Job) int {
+		builds := pendingBuilds[job.Name()]
+		if len(builds) == 0 {
+			return 0
+		}
+
+		return builds[0].Priority()
+	}
+
+	sorted := make([]db.Job, len(jobs))
+	copy(sorted, jobs)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return priority(sorted[i]) > priority(sorted[j])
+	})
+
+	return sorted
+}
@@ -2,6 +2,7 @@ package scheduler_test
 
 import (
 	"errors"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc"
@@ -164,6 +165,31 @@ var _ = Describe("Scheduler", func() {
 					})
 				})
 
+				Context("when a later job has a higher-priority pending build", func() {
+					BeforeEach(func() {
+						highPriorityBuild := new(dbfakes.FakeBuild)
+						highPriorityBuild.PriorityReturns(10)
+						nextPendingBuildsJob2 = []db.Build{highPriorityBuild}
+
+						fakePipeline.GetAllPendingBuildsReturns(map[string][]db.Build{
+							"some-job-1": nextPendingBuildsJob1,
+							"some-job-2": nextPendingBuildsJob2,
+						}, nil)
+					})
+
+					It("starts the pending builds of the higher-priority job first", func() {
+						Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(2))
+
+						_, actualJob, _, _, actualPendingBuilds := fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(0)
+						Expect(actualJob.Name()).To(Equal(fakeJob2.Name()))
+						Expect(actualPendingBuilds).To(Equal(nextPendingBuildsJob2))
+
+						_, actualJob, _, _, actualPendingBuilds = fakeBuildStarter.TryStartPendingBuildsForJobArgsForCall(1)
+						Expect(actualJob.Name()).To(Equal(fakeJob.Name()))
+						Expect(actualPendingBuilds).To(Equal(nextPendingBuildsJob1))
+					})
+				})
+
 				It("didn't mark the job as having new inputs", func() {
 					Expect(fakeJob.SetHasNewInputsCallCount()).To(BeZero())
 				})
@@ -280,6 +306,14 @@ var _ = Describe("Scheduler", func() {
 					It("created a pending build for the right job", func() {
 						Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(Equal(1))
 					})
+
+					It("records the resource whose new version triggered the build", func() {
+						Expect(fakeJob.EnsurePendingBuildExistsArgsForCall(0)).To(Equal(atc.BuildCreator{
+							Type:     atc.BuildCreatorResource,
+							Resource: "a",
+							Input:    "a",
+						}))
+					})
 				})
 
 				Context("when creating a pending build succeeds", func() {
@@ -324,5 +358,73 @@ var _ = Describe("Scheduler", func() {
 				})
 			})
 		})
+
+		Context("when the job has a schedule", func() {
+			var lastScheduleTime time.Time
+
+			BeforeEach(func() {
+				fakeJob = new(dbfakes.FakeJob)
+				fakeJob.NameReturns("some-job")
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Name:     "some-job",
+					Schedule: &atc.ScheduleConfig{Cron: "0 * * * *"},
+				})
+
+				fakeJobs = []db.Job{fakeJob}
+
+				fakeInputMapper.SaveNextInputMappingReturns(algorithm.InputMapping{}, nil)
+			})
+
+			Context("when the schedule has not triggered since it last did", func() {
+				BeforeEach(func() {
+					lastScheduleTime = time.Now()
+					fakeJob.ScheduleTimeReturns(lastScheduleTime)
+				})
+
+				It("does not create a scheduled build", func() {
+					Expect(fakeJob.EnsureScheduledBuildExistsCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the schedule has triggered since it last did", func() {
+				BeforeEach(func() {
+					lastScheduleTime = time.Now().Add(-3 * time.Hour)
+					fakeJob.ScheduleTimeReturns(lastScheduleTime)
+				})
+
+				It("creates a single scheduled build for all of the missed ticks", func() {
+					Expect(fakeJob.EnsureScheduledBuildExistsCallCount()).To(Equal(1))
+
+					from, to := fakeJob.EnsureScheduledBuildExistsArgsForCall(0)
+					Expect(from).To(Equal(lastScheduleTime))
+					Expect(to).To(BeTemporally("~", time.Now(), time.Minute))
+				})
+
+				It("starts the pending builds", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeBuildStarter.TryStartPendingBuildsForJobCallCount()).To(Equal(1))
+				})
+
+				Context("when creating the scheduled build fails", func() {
+					BeforeEach(func() {
+						fakeJob.EnsureScheduledBuildExistsReturns(false, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(scheduleErr).To(Equal(disaster))
+					})
+				})
+			})
+
+			Context("when the schedule was never initialized", func() {
+				BeforeEach(func() {
+					fakeJob.ScheduleTimeReturns(time.Time{})
+				})
+
+				It("does not create a scheduled build", func() {
+					Expect(fakeJob.EnsureScheduledBuildExistsCallCount()).To(Equal(0))
+				})
+			})
+		})
 	})
 })
@@ -20,7 +20,7 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	plaintext, err := encryption.NewKey(command.Key.AEAD).Decrypt(command.Ciphertext, &command.Nonce)
+	plaintext, err := encryption.NewKeyRing(encryption.NewKey(command.Key.AEAD)).Decrypt(command.Ciphertext, &command.Nonce)
 	if err != nil {
 		panic(err)
 	}
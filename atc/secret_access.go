@@ -0,0 +1,39 @@
+package atc
+
+const (
+	SecretAccessQueryTeam     = "team"
+	SecretAccessQueryPipeline = "pipeline"
+	SecretAccessQueryJob      = "job"
+	SecretAccessQueryBuildID  = "build_id"
+	SecretAccessQueryVariable = "variable"
+	SecretAccessQueryManager  = "manager"
+	SecretAccessQuerySince    = "since"
+	SecretAccessQueryUntil    = "until"
+	SecretAccessQueryLimit    = "limit"
+)
+
+// SecretAccess records a single lookup of a secret in a credential manager.
+// The secret's value is never recorded.
+type SecretAccess struct {
+	ID   int   `json:"id,omitempty"`
+	Time int64 `json:"time"`
+
+	TeamName     string `json:"team_name,omitempty"`
+	PipelineName string `json:"pipeline_name,omitempty"`
+	JobName      string `json:"job_name,omitempty"`
+	BuildID      int    `json:"build_id,omitempty"`
+
+	// Variable is the name of the ((var)) being looked up, and Path the
+	// secret path it was looked up at.
+	Variable string `json:"variable"`
+	Path     string `json:"path"`
+
+	// Manager is the type of the credential manager the secret was looked up
+	// in, and Source the name of the pipeline's var source, if any.
+	Manager string `json:"manager"`
+	Source  string `json:"source,omitempty"`
+
+	CacheHit bool   `json:"cache_hit"`
+	Found    bool   `json:"found"`
+	Error    string `json:"error,omitempty"`
+}
@@ -0,0 +1,15 @@
+package atc
+
+// Semaphore is a team-wide lock declared by jobs, which limits how many of
+// their builds run at once across the team's pipelines.
+type Semaphore struct {
+	Name     string `json:"name"`
+	Capacity int    `json:"capacity"`
+
+	// Holders are the builds currently holding the semaphore.
+	Holders []Build `json:"holders"`
+
+	// Waiting are the pending builds of the jobs declaring the semaphore, in
+	// the order they are scheduled.
+	Waiting []Build `json:"waiting"`
+}
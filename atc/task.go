@@ -79,6 +79,7 @@ func (config TaskConfig) Validate() error {
 
 	messages = append(messages, config.validateInputContainsNames()...)
 	messages = append(messages, config.validateOutputContainsNames()...)
+	messages = append(messages, config.validateOutputReports()...)
 
 	if len(messages) > 0 {
 		return fmt.Errorf("invalid task configuration:\n%s", strings.Join(messages, "\n"))
@@ -99,6 +100,24 @@ func (config TaskConfig) validateOutputContainsNames() []string {
 	return messages
 }
 
+func (config TaskConfig) validateOutputReports() []string {
+	messages := []string{}
+
+	for _, output := range config.Outputs {
+		for i, report := range output.Reports {
+			if report.Path == "" {
+				messages = append(messages, fmt.Sprintf("  report in position %d of output '%s' is missing a path", i, output.Name))
+			}
+
+			if report.Format != "" && report.Format != TaskReportFormatJUnit {
+				messages = append(messages, fmt.Sprintf("  report '%s' of output '%s' has unknown format '%s'", report.Path, output.Name, report.Format))
+			}
+		}
+	}
+
+	return messages
+}
+
 func (config TaskConfig) validateInputContainsNames() []string {
 	messages := []string{}
 
@@ -129,6 +148,22 @@ type TaskInputConfig struct {
 type TaskOutputConfig struct {
 	Name string `json:"name"`
 	Path string `json:"path,omitempty"`
+
+	// persist the output to the artifact store once the task has run
+	Archive bool `json:"archive,omitempty"`
+
+	// test reports written to the output, collected once the task has run
+	Reports []TaskReportConfig `json:"reports,omitempty"`
+}
+
+const TaskReportFormatJUnit = "junit"
+
+type TaskReportConfig struct {
+	// path to the report file, relative to the output
+	Path string `json:"path"`
+
+	// format of the report; defaults to JUnit XML
+	Format string `json:"format,omitempty"`
 }
 
 type TaskCacheConfig struct {
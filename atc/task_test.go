@@ -384,6 +384,48 @@ run: {path: a/file}
 					Expect(err).To(MatchError(ContainSubstring("  output in position 2 is missing a name")))
 				})
 			})
+
+			Context("when an output has test reports", func() {
+				BeforeEach(func() {
+					validConfig.Outputs = append(validConfig.Outputs, TaskOutputConfig{
+						Name: "test-results",
+						Reports: []TaskReportConfig{
+							{Path: "unit.xml"},
+							{Path: "integration.xml", Format: TaskReportFormatJUnit},
+						},
+					})
+				})
+
+				It("is valid", func() {
+					Expect(validConfig.Validate()).ToNot(HaveOccurred())
+				})
+			})
+
+			Context("when a report is missing a path", func() {
+				BeforeEach(func() {
+					invalidConfig.Outputs = append(invalidConfig.Outputs, TaskOutputConfig{
+						Name:    "test-results",
+						Reports: []TaskReportConfig{{Format: TaskReportFormatJUnit}},
+					})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  report in position 0 of output 'test-results' is missing a path")))
+				})
+			})
+
+			Context("when a report has an unknown format", func() {
+				BeforeEach(func() {
+					invalidConfig.Outputs = append(invalidConfig.Outputs, TaskOutputConfig{
+						Name:    "test-results",
+						Reports: []TaskReportConfig{{Path: "report.tap", Format: "tap"}},
+					})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("  report 'report.tap' of output 'test-results' has unknown format 'tap'")))
+				})
+			})
 		})
 
 		Context("when run is missing", func() {
@@ -0,0 +1,106 @@
+package atc
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	TestStatusPassed  = "passed"
+	TestStatusFailed  = "failed"
+	TestStatusErrored = "errored"
+	TestStatusSkipped = "skipped"
+)
+
+// TestResult is the outcome of a single test case, as reported by a task.
+type TestResult struct {
+	Suite    string  `json:"suite"`
+	Name     string  `json:"name"`
+	Status   string  `json:"status"`
+	Duration float64 `json:"duration"`
+	Message  string  `json:"message,omitempty"`
+}
+
+type TestSummary struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Errored int `json:"errored"`
+	Skipped int `json:"skipped"`
+}
+
+func SummarizeTests(results []TestResult) TestSummary {
+	summary := TestSummary{Total: len(results)}
+
+	for _, result := range results {
+		switch result.Status {
+		case TestStatusPassed:
+			summary.Passed++
+		case TestStatusFailed:
+			summary.Failed++
+		case TestStatusErrored:
+			summary.Errored++
+		case TestStatusSkipped:
+			summary.Skipped++
+		}
+	}
+
+	return summary
+}
+
+// String describes the summary briefly, e.g. "10 passed, 2 failed", omitting
+// statuses no test has.
+func (summary TestSummary) String() string {
+	parts := []string{}
+
+	for _, count := range []struct {
+		n      int
+		status string
+	}{
+		{summary.Passed, TestStatusPassed},
+		{summary.Failed, TestStatusFailed},
+		{summary.Errored, TestStatusErrored},
+		{summary.Skipped, TestStatusSkipped},
+	} {
+		if count.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count.n, count.status))
+		}
+	}
+
+	if len(parts) == 0 {
+		return "no tests"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+type BuildTestResults struct {
+	Summary TestSummary  `json:"summary"`
+	Tests   []TestResult `json:"tests"`
+}
+
+// TestHistoryQueryBuilds is the query parameter limiting how many of a job's
+// recent builds the test history covers.
+const TestHistoryQueryBuilds = "builds"
+
+// TestHistory is the outcome of a test across a job's recent builds, most
+// recent first.
+type TestHistory struct {
+	Suite    string `json:"suite"`
+	Name     string `json:"name"`
+	Runs     int    `json:"runs"`
+	Failures int    `json:"failures"`
+
+	// FlakeRate is the fraction of consecutive runs in which the test went
+	// from passing to failing or vice versa.
+	FlakeRate float64 `json:"flake_rate"`
+
+	Builds []TestHistoryBuild `json:"builds"`
+}
+
+type TestHistoryBuild struct {
+	BuildID   int     `json:"build_id"`
+	BuildName string  `json:"build_name"`
+	Status    string  `json:"status"`
+	Duration  float64 `json:"duration"`
+}
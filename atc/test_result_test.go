@@ -0,0 +1,39 @@
+package atc_test
+
+import (
+	. "github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TestSummary", func() {
+	Describe("SummarizeTests", func() {
+		It("counts the tests by status", func() {
+			summary := SummarizeTests([]TestResult{
+				{Name: "a", Status: TestStatusPassed},
+				{Name: "b", Status: TestStatusPassed},
+				{Name: "c", Status: TestStatusFailed},
+				{Name: "d", Status: TestStatusErrored},
+				{Name: "e", Status: TestStatusSkipped},
+			})
+
+			Expect(summary).To(Equal(TestSummary{
+				Total:   5,
+				Passed:  2,
+				Failed:  1,
+				Errored: 1,
+				Skipped: 1,
+			}))
+		})
+	})
+
+	Describe("String", func() {
+		It("omits statuses without tests", func() {
+			Expect(TestSummary{Total: 12, Passed: 10, Failed: 2}.String()).To(Equal("10 passed, 2 failed"))
+		})
+
+		It("says when there are no tests", func() {
+			Expect(TestSummary{}.String()).To(Equal("no tests"))
+		})
+	})
+})
@@ -0,0 +1,123 @@
+// Package testreport parses the test reports written by tasks into test
+// results.
+package testreport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+)
+
+// Parse reads a report of the given format, as configured on a task output.
+func Parse(format string, report io.Reader) ([]atc.TestResult, error) {
+	switch format {
+	case "", atc.TaskReportFormatJUnit:
+		return ParseJUnit(report)
+	default:
+		return nil, fmt.Errorf("unknown report format '%s'", format)
+	}
+}
+
+type junitSuite struct {
+	XMLName xml.Name
+	Name    string       `xml:"name,attr"`
+	Suites  []junitSuite `xml:"testsuite"`
+	Cases   []junitCase  `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure"`
+	Error     *junitMessage `xml:"error"`
+	Skipped   *junitMessage `xml:"skipped"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (message junitMessage) String() string {
+	if message.Message != "" {
+		return message.Message
+	}
+
+	return strings.TrimSpace(message.Text)
+}
+
+// ParseJUnit reads a JUnit XML report, whose root is either a <testsuites>
+// or a single <testsuite> element. Nested suites are flattened.
+func ParseJUnit(report io.Reader) ([]atc.TestResult, error) {
+	var root junitSuite
+	err := xml.NewDecoder(report).Decode(&root)
+	if err != nil {
+		return nil, fmt.Errorf("malformed JUnit report: %s", err)
+	}
+
+	results := []atc.TestResult{}
+
+	switch root.XMLName.Local {
+	case "testsuites":
+		for _, suite := range root.Suites {
+			results = appendSuite(results, suite)
+		}
+	case "testsuite":
+		results = appendSuite(results, root)
+	default:
+		return nil, fmt.Errorf("malformed JUnit report: unexpected root element <%s>", root.XMLName.Local)
+	}
+
+	return results, nil
+}
+
+func appendSuite(results []atc.TestResult, suite junitSuite) []atc.TestResult {
+	for _, testCase := range suite.Cases {
+		result := atc.TestResult{
+			Suite:    suite.Name,
+			Name:     testCase.Name,
+			Status:   atc.TestStatusPassed,
+			Duration: parseDuration(testCase.Time),
+		}
+
+		if result.Suite == "" {
+			result.Suite = testCase.ClassName
+		}
+
+		switch {
+		case testCase.Error != nil:
+			result.Status = atc.TestStatusErrored
+			result.Message = testCase.Error.String()
+		case testCase.Failure != nil:
+			result.Status = atc.TestStatusFailed
+			result.Message = testCase.Failure.String()
+		case testCase.Skipped != nil:
+			result.Status = atc.TestStatusSkipped
+			result.Message = testCase.Skipped.String()
+		}
+
+		results = append(results, result)
+	}
+
+	for _, nested := range suite.Suites {
+		results = appendSuite(results, nested)
+	}
+
+	return results
+}
+
+// parseDuration parses a duration in seconds, tolerating thousands
+// separators as written by some tools. Malformed durations are treated as 0.
+func parseDuration(seconds string) float64 {
+	duration, err := strconv.ParseFloat(strings.Replace(seconds, ",", "", -1), 64)
+	if err != nil {
+		return 0
+	}
+
+	return duration
+}
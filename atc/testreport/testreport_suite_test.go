@@ -0,0 +1,13 @@
+package testreport_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestTestreport(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Testreport Suite")
+}
@@ -0,0 +1,100 @@
+package testreport_test
+
+import (
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/testreport"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseJUnit", func() {
+	var (
+		report  string
+		results []atc.TestResult
+		err     error
+	)
+
+	JustBeforeEach(func() {
+		results, err = testreport.ParseJUnit(strings.NewReader(report))
+	})
+
+	Context("with a <testsuites> root", func() {
+		BeforeEach(func() {
+			report = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="math">
+    <testcase classname="math" name="adds" time="0.25"/>
+    <testcase classname="math" name="divides" time="1,000.5">
+      <failure message="expected 2, got 3">stack trace</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="io">
+    <testcase classname="io" name="reads">
+      <error>
+        boom
+      </error>
+    </testcase>
+    <testcase classname="io" name="writes">
+      <skipped message="not on windows"/>
+    </testcase>
+    <testsuite name="io/nested">
+      <testcase name="nested"/>
+    </testsuite>
+  </testsuite>
+</testsuites>`
+		})
+
+		It("returns a result for every test case", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(Equal([]atc.TestResult{
+				{Suite: "math", Name: "adds", Status: atc.TestStatusPassed, Duration: 0.25},
+				{Suite: "math", Name: "divides", Status: atc.TestStatusFailed, Duration: 1000.5, Message: "expected 2, got 3"},
+				{Suite: "io", Name: "reads", Status: atc.TestStatusErrored, Message: "boom"},
+				{Suite: "io", Name: "writes", Status: atc.TestStatusSkipped, Message: "not on windows"},
+				{Suite: "io/nested", Name: "nested", Status: atc.TestStatusPassed},
+			}))
+		})
+	})
+
+	Context("with a <testsuite> root", func() {
+		BeforeEach(func() {
+			report = `<testsuite><testcase classname="pkg.Class" name="works" time="3"/></testsuite>`
+		})
+
+		It("falls back to the class name for the suite", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(results).To(Equal([]atc.TestResult{
+				{Suite: "pkg.Class", Name: "works", Status: atc.TestStatusPassed, Duration: 3},
+			}))
+		})
+	})
+
+	Context("with some other root", func() {
+		BeforeEach(func() {
+			report = `<html></html>`
+		})
+
+		It("errors", func() {
+			Expect(err).To(MatchError("malformed JUnit report: unexpected root element <html>"))
+		})
+	})
+
+	Context("with malformed XML", func() {
+		BeforeEach(func() {
+			report = `<testsuite`
+		})
+
+		It("errors", func() {
+			Expect(err).To(MatchError(ContainSubstring("malformed JUnit report")))
+		})
+	})
+})
+
+var _ = Describe("Parse", func() {
+	It("errors for unknown formats", func() {
+		_, err := testreport.Parse("tap", strings.NewReader(""))
+		Expect(err).To(MatchError("unknown report format 'tap'"))
+	})
+})
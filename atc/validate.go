@@ -3,9 +3,12 @@ package atc
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/gorhill/cronexpr"
 )
 
 func formatErr(groupName string, err error) string {
@@ -43,6 +46,11 @@ func (c Config) Validate() ([]ConfigWarning, []string) {
 		errorMessages = append(errorMessages, formatErr("resource types", resourceTypesErr))
 	}
 
+	varSourcesErr := validateVarSources(c)
+	if varSourcesErr != nil {
+		errorMessages = append(errorMessages, formatErr("var sources", varSourcesErr))
+	}
+
 	jobWarnings, jobsErr := validateJobs(c)
 	if jobsErr != nil {
 		errorMessages = append(errorMessages, formatErr("jobs", jobsErr))
@@ -178,6 +186,45 @@ func validateResourceTypes(c Config) error {
 	return compositeErr(errorMessages)
 }
 
+var varSourceNameRegex = regexp.MustCompile(`^[-\w\pL]+$`)
+
+func validateVarSources(c Config) error {
+	errorMessages := []string{}
+
+	names := map[string]int{}
+
+	for i, varSource := range c.VarSources {
+		var identifier string
+		if varSource.Name == "" {
+			identifier = fmt.Sprintf("var_sources[%d]", i)
+		} else {
+			identifier = fmt.Sprintf("var_sources.%s", varSource.Name)
+		}
+
+		if other, exists := names[varSource.Name]; exists {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"var_sources[%d] and var_sources[%d] have the same name ('%s')",
+					other, i, varSource.Name))
+		} else if varSource.Name != "" {
+			names[varSource.Name] = i
+		}
+
+		if varSource.Name == "" {
+			errorMessages = append(errorMessages, identifier+" has no name")
+		} else if !varSourceNameRegex.MatchString(varSource.Name) {
+			// the name must be usable as the source of a ((source:path)) var
+			errorMessages = append(errorMessages, identifier+" has an invalid name; it may only contain letters, numbers, '-' and '_'")
+		}
+
+		if varSource.Type == "" {
+			errorMessages = append(errorMessages, identifier+" has no type")
+		}
+	}
+
+	return compositeErr(errorMessages)
+}
+
 func validateResourcesUnused(c Config) []string {
 	usedResources := usedResources(c)
 
@@ -261,6 +308,31 @@ func validateJobs(c Config) ([]ConfigWarning, error) {
 			}
 		}
 
+		semaphores := map[string]bool{}
+		for j, semaphore := range job.Semaphores {
+			if semaphore.Name == "" {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(".semaphores[%d] has no name", j))
+			} else if semaphores[semaphore.Name] {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(" declares semaphore '%s' more than once", semaphore.Name))
+			}
+
+			semaphores[semaphore.Name] = true
+
+			if semaphore.RawCapacity < 0 {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(".semaphores[%d] has negative capacity: %d", j, semaphore.RawCapacity))
+			}
+		}
+
+		if job.Schedule != nil {
+			if _, err := cronexpr.Parse(job.Schedule.Cron); err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(".schedule has invalid cron expression '%s': %s", job.Schedule.Cron, err))
+			}
+
+			if _, err := job.Schedule.location(); err != nil {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(".schedule has unknown location '%s'", job.Schedule.Location))
+			}
+		}
+
 		planWarnings, planErrMessages := validatePlan(c, identifier+".plan", PlanConfig{Do: &job.Plan})
 		warnings = append(warnings, planWarnings...)
 		errorMessages = append(errorMessages, planErrMessages...)
@@ -363,6 +435,18 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 		foundTypes.Find("task")
 	}
 
+	if plan.SetPipeline != "" {
+		foundTypes.Find("set_pipeline")
+	}
+
+	if plan.LoadVar != "" {
+		foundTypes.Find("load_var")
+	}
+
+	if plan.Approval != "" {
+		foundTypes.Find("approval")
+	}
+
 	if plan.Do != nil {
 		foundTypes.Find("do")
 	}
@@ -419,7 +503,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 		identifier = fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"privileged", "config", "file"},
+			[]string{"privileged", "config", "file", "allowed_roles"},
 			plan, identifier)...,
 		)
 
@@ -494,7 +578,7 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 		identifier = fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "trigger", "privileged", "config", "file", "allowed_roles"},
 			plan, identifier)...,
 		)
 
@@ -551,7 +635,52 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 		}
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"resource", "passed", "trigger"},
+			[]string{"resource", "passed", "trigger", "allowed_roles"},
+			plan, identifier)...,
+		)
+
+	case plan.SetPipeline != "":
+		identifier = fmt.Sprintf("%s.set_pipeline.%s", identifier, plan.SetPipeline)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify any pipeline configuration")
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config", "allowed_roles"},
+			plan, identifier)...,
+		)
+
+	case plan.LoadVar != "":
+		identifier = fmt.Sprintf("%s.load_var.%s", identifier, plan.LoadVar)
+
+		if plan.TaskConfigPath == "" {
+			errorMessages = append(errorMessages, identifier+" does not specify any file")
+		}
+
+		switch plan.Format {
+		case "", LoadVarFormatRaw, LoadVarFormatTrim, LoadVarFormatJSON, LoadVarFormatYAML:
+		default:
+			errorMessages = append(errorMessages, identifier+fmt.Sprintf(" has an unknown format ('%s')", plan.Format))
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config", "allowed_roles"},
+			plan, identifier)...,
+		)
+
+	case plan.Approval != "":
+		identifier = fmt.Sprintf("%s.approval.%s", identifier, plan.Approval)
+
+		if plan.Timeout != "" {
+			timeout, err := time.ParseDuration(plan.Timeout)
+			if err == nil && timeout <= 0 {
+				errorMessages = append(errorMessages, identifier+fmt.Sprintf(".timeout must be a positive duration ('%s')", plan.Timeout))
+			}
+		}
+
+		errorMessages = append(errorMessages, validateInapplicableFields(
+			[]string{"resource", "passed", "trigger", "privileged", "config", "file"},
 			plan, identifier)...,
 		)
 
@@ -610,9 +739,42 @@ func validatePlan(c Config, identifier string, plan PlanConfig) ([]ConfigWarning
 		errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid number of attempts (%d)", plan.Attempts))
 	}
 
+	if plan.Across != nil {
+		errorMessages = append(errorMessages, validateAcross(identifier, plan.Across)...)
+	} else if plan.FailFast {
+		errorMessages = append(errorMessages, identifier+" specifies fail_fast without across")
+	}
+
 	return warnings, errorMessages
 }
 
+func validateAcross(identifier string, across []AcrossVarConfig) []string {
+	errorMessages := []string{}
+	seen := map[string]bool{}
+
+	for i, acrossVar := range across {
+		subIdentifier := fmt.Sprintf("%s.across[%d]", identifier, i)
+
+		if acrossVar.Var == "" {
+			errorMessages = append(errorMessages, subIdentifier+" has no var name")
+		} else if seen[acrossVar.Var] {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" repeats var '%s'", acrossVar.Var))
+		}
+
+		seen[acrossVar.Var] = true
+
+		if len(acrossVar.Values) == 0 {
+			errorMessages = append(errorMessages, subIdentifier+" has no values")
+		}
+
+		if acrossVar.MaxInFlight < 0 {
+			errorMessages = append(errorMessages, subIdentifier+fmt.Sprintf(" has an invalid max_in_flight (%d)", acrossVar.MaxInFlight))
+		}
+	}
+
+	return errorMessages
+}
+
 func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, identifier string) []string {
 	errorMessages := []string{}
 	foundInapplicableFields := []string{}
@@ -643,6 +805,10 @@ func validateInapplicableFields(inapplicableFields []string, plan PlanConfig, id
 			if plan.TaskConfigPath != "" {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "allowed_roles":
+			if len(plan.AllowedRoles) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		}
 	}
 
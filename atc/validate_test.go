@@ -484,6 +484,59 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("invalid var sources", func() {
+		BeforeEach(func() {
+			config.VarSources = VarSourceConfigs{
+				{
+					Name:   "some-var-source",
+					Type:   "vault",
+					Config: map[string]interface{}{"url": "https://vault.example.com"},
+				},
+			}
+		})
+
+		It("does not return an error", func() {
+			Expect(errorMessages).To(HaveLen(0))
+		})
+
+		Context("when a var source has no name or type", func() {
+			BeforeEach(func() {
+				config.VarSources = append(config.VarSources, VarSourceConfig{})
+			})
+
+			It("returns an error describing both errors", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid var sources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("var_sources[1] has no name"))
+				Expect(errorMessages[0]).To(ContainSubstring("var_sources[1] has no type"))
+			})
+		})
+
+		Context("when a var source's name cannot be used in a var", func() {
+			BeforeEach(func() {
+				config.VarSources[0].Name = "some:var.source"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid var sources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("var_sources.some:var.source has an invalid name"))
+			})
+		})
+
+		Context("when two var sources have the same name", func() {
+			BeforeEach(func() {
+				config.VarSources = append(config.VarSources, config.VarSources...)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid var sources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("var_sources[0] and var_sources[1] have the same name ('some-var-source')"))
+			})
+		})
+	})
+
 	Describe("validating a job", func() {
 		var job JobConfig
 
@@ -742,6 +795,157 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a set_pipeline plan has no config file", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetPipeline: "some-pipeline",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline does not specify any pipeline configuration"))
+				})
+			})
+
+			Context("when a set_pipeline plan has invalid fields specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						SetPipeline:    "some-pipeline",
+						TaskConfigPath: "some-input/pipeline.yml",
+						Privileged:     true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].set_pipeline.some-pipeline has invalid fields specified (privileged)"))
+				})
+			})
+
+			Context("when a load_var plan has no file", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						LoadVar: "some-var",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var does not specify any file"))
+				})
+			})
+
+			Context("when a load_var plan has an unknown format", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						LoadVar:        "some-var",
+						TaskConfigPath: "some-input/version",
+						Format:         "toml",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var has an unknown format ('toml')"))
+				})
+			})
+
+			Context("when a load_var plan has invalid fields specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						LoadVar:        "some-var",
+						TaskConfigPath: "some-input/version",
+						Privileged:     true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].load_var.some-var has invalid fields specified (privileged)"))
+				})
+			})
+
+			Context("when an approval plan has invalid fields specified", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Approval:       "deploy-to-prod",
+						TaskConfigPath: "some-input/approval.yml",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].approval.deploy-to-prod has invalid fields specified (file)"))
+				})
+			})
+
+			Context("when an approval plan has an unparseable timeout", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Approval: "deploy-to-prod",
+						Timeout:  "a day",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].approval.deploy-to-prod.timeout refers to a duration that could not be parsed ('a day')"))
+				})
+			})
+
+			Context("when an approval plan has a non-positive timeout", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Approval: "deploy-to-prod",
+						Timeout:  "0s",
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].approval.deploy-to-prod.timeout must be a positive duration ('0s')"))
+				})
+			})
+
+			Context("when a non-approval plan specifies allowed_roles", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:          "some-resource",
+						AllowedRoles: []string{"owner"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource has invalid fields specified (allowed_roles)"))
+				})
+			})
+
 			Context("when a task plan has config path and config specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1206,6 +1410,66 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when an across step is valid", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						Across: []AcrossVarConfig{
+							{Var: "var1", Values: []interface{}{"a", "b"}},
+							{Var: "var2", Values: []interface{}{1, 2}, MaxInFlight: 2},
+						},
+						FailFast: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when an across step has invalid vars", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put: "some-resource",
+						Across: []AcrossVarConfig{
+							{Var: "var1", Values: []interface{}{"a"}},
+							{Var: "var1", Values: []interface{}{"b"}},
+							{Values: []interface{}{"c"}},
+							{Var: "var2", Values: []interface{}{"d"}, MaxInFlight: -1},
+							{Var: "var3", Values: []interface{}{}},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across[1] repeats var 'var1'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across[2] has no var name"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across[3] has an invalid max_in_flight (-1)"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource.across[4] has no values"))
+				})
+			})
+
+			Context("when a step specifies fail_fast without across", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, PlanConfig{
+						Put:      "some-resource",
+						FailFast: true,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does return an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan[0].put.some-resource specifies fail_fast without across"))
+				})
+			})
+
 			Context("when a put plan has a custom name but refers to a resource that does not exist", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, PlanConfig{
@@ -1343,6 +1607,87 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job declares semaphores", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Semaphores = []SemaphoreConfig{
+					{Name: "staging", RawCapacity: 2},
+					{Name: "deploy"},
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+
+			Context("when a semaphore has no name", func() {
+				BeforeEach(func() {
+					config.Jobs[0].Semaphores[1].Name = ""
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.semaphores[1] has no name"))
+				})
+			})
+
+			Context("when a semaphore is declared twice", func() {
+				BeforeEach(func() {
+					config.Jobs[0].Semaphores[1].Name = "staging"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job declares semaphore 'staging' more than once"))
+				})
+			})
+
+			Context("when a semaphore has a negative capacity", func() {
+				BeforeEach(func() {
+					config.Jobs[0].Semaphores[0].RawCapacity = -1
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.semaphores[0] has negative capacity: -1"))
+				})
+			})
+		})
+
+		Context("when a job has a schedule", func() {
+			BeforeEach(func() {
+				config.Jobs[0].Schedule = &ScheduleConfig{
+					Cron:     "0 2 * * 1-5",
+					Location: "America/Toronto",
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+
+			Context("when the cron expression is invalid", func() {
+				BeforeEach(func() {
+					config.Jobs[0].Schedule.Cron = "every tuesday"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.schedule has invalid cron expression 'every tuesday'"))
+				})
+			})
+
+			Context("when the location is unknown", func() {
+				BeforeEach(func() {
+					config.Jobs[0].Schedule.Location = "Mars/Olympus_Mons"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-job.schedule has unknown location 'Mars/Olympus_Mons'"))
+				})
+			})
+		})
+
 		Context("when a job has negative build_log_retention values", func() {
 			BeforeEach(func() {
 				config.Jobs[0].BuildLogRetention = &BuildLogRetention{
@@ -30,8 +30,17 @@ type Worker struct {
 	StartTime int64    `json:"start_time"`
 	Ephemeral bool     `json:"ephemeral"`
 	State     string   `json:"state"`
+
+	// Runtime is the runtime used to run containers and volumes on the
+	// worker. It is empty for Garden workers.
+	Runtime string `json:"runtime,omitempty"`
 }
 
+const (
+	WorkerRuntimeGarden     = ""
+	WorkerRuntimeKubernetes = "kubernetes"
+)
+
 var ErrInvalidWorkerVersion = errors.New("invalid worker version, only numeric characters are allowed")
 var ErrMissingWorkerGardenAddress = errors.New("missing garden address")
 var ErrNoWorkers = errors.New("no workers available for checking")
@@ -41,7 +50,7 @@ func (w Worker) Validate() error {
 		return ErrInvalidWorkerVersion
 	}
 
-	if len(w.GardenAddr) == 0 {
+	if w.Runtime == WorkerRuntimeGarden && len(w.GardenAddr) == 0 {
 		return ErrMissingWorkerGardenAddress
 	}
 
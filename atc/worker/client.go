@@ -14,6 +14,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/tracing"
 	multierror "github.com/hashicorp/go-multierror"
 )
 
@@ -43,14 +44,16 @@ type Client interface {
 
 func NewClient(pool Pool, provider WorkerProvider) *client {
 	return &client{
-		pool:     pool,
-		provider: provider,
+		pool:         pool,
+		provider:     provider,
+		waitingTasks: newWaitingTasks(),
 	}
 }
 
 type client struct {
-	pool     Pool
-	provider WorkerProvider
+	pool         Pool
+	provider     WorkerProvider
+	waitingTasks *waitingTasks
 }
 
 type TaskResult struct {
@@ -257,14 +260,26 @@ func (client *client) chooseTaskWorker(
 	workerSpec WorkerSpec,
 	outputWriter io.Writer,
 ) (Worker, error) {
+	ctx, span := tracing.StartSpan(ctx, "worker.choose-task-worker", tracing.Attrs{
+		"team": strconv.Itoa(containerSpec.TeamID),
+	})
+	defer span.End()
+
 	var (
 		chosenWorker      Worker
 		activeTasksLock   lock.Lock
 		elapsed           time.Duration
 		err               error
 		existingContainer bool
+		waiting           *waitingTask
 	)
 
+	defer func() {
+		if waiting != nil {
+			client.waitingTasks.remove(waiting)
+		}
+	}()
+
 	for {
 		if strategy.ModifiesActiveTasks() {
 			var acquired bool
@@ -299,6 +314,17 @@ func (client *client) chooseTaskWorker(
 			return nil, err
 		}
 
+		if strategy.ModifiesActiveTasks() && !existingContainer && chosenWorker != nil {
+			worker := chosenWorker
+			if client.waitingTasks.outrank(containerSpec.Priority, func(spec WorkerSpec) bool {
+				return worker.Satisfies(logger, spec)
+			}) {
+				// leave the free worker to a higher-priority task waiting for one
+				// which it could run on
+				chosenWorker = nil
+			}
+		}
+
 		if strategy.ModifiesActiveTasks() {
 			waitWorker := time.Duration(5 * time.Second) // Workers polling frequency
 
@@ -314,6 +340,10 @@ func (client *client) chooseTaskWorker(
 			}
 
 			if chosenWorker == nil {
+				if waiting == nil {
+					waiting = client.waitingTasks.add(containerSpec.Priority, workerSpec)
+				}
+
 				err = activeTasksLock.Release()
 				if err != nil {
 					return nil, err
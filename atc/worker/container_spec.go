@@ -44,6 +44,11 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Priority of the build the container is for. With the limit-active-tasks
+	// placement strategy, higher-priority tasks are placed on free workers
+	// first.
+	Priority int
 }
 
 //go:generate counterfeiter . InputSource
@@ -30,6 +30,7 @@ type dbWorkerProvider struct {
 	dbWorkerFactory                   db.WorkerFactory
 	workerVersion                     version.Version
 	baggageclaimResponseHeaderTimeout time.Duration
+	runtimes                          map[string]Runtime
 }
 
 func NewDBWorkerProvider(
@@ -46,6 +47,7 @@ func NewDBWorkerProvider(
 	workerFactory db.WorkerFactory,
 	workerVersion version.Version,
 	baggageclaimResponseHeaderTimeout time.Duration,
+	runtimes map[string]Runtime,
 ) WorkerProvider {
 	return &dbWorkerProvider{
 		lockFactory:                       lockFactory,
@@ -61,6 +63,7 @@ func NewDBWorkerProvider(
 		dbWorkerFactory:                   workerFactory,
 		workerVersion:                     workerVersion,
 		baggageclaimResponseHeaderTimeout: baggageclaimResponseHeaderTimeout,
+		runtimes:                          runtimes,
 	}
 }
 
@@ -85,7 +88,7 @@ func (provider *dbWorkerProvider) RunningWorkers(logger lager.Logger) ([]Worker,
 		}
 
 		workerLog := logger.Session("running-worker")
-		worker := provider.NewGardenWorker(
+		worker := provider.newWorker(
 			workerLog,
 			tikTok,
 			savedWorker,
@@ -113,7 +116,7 @@ func (provider *dbWorkerProvider) FindWorkersForContainerByOwner(
 
 	var workers []Worker
 	for _, w := range dbWorkers {
-		worker := provider.NewGardenWorker(logger, clock.NewClock(), w, 0)
+		worker := provider.newWorker(logger, clock.NewClock(), w, 0)
 		if worker.IsVersionCompatible(logger, provider.workerVersion) {
 			workers = append(workers, worker)
 		}
@@ -139,7 +142,7 @@ func (provider *dbWorkerProvider) FindWorkerForContainer(
 		return nil, false, nil
 	}
 
-	worker := provider.NewGardenWorker(logger, clock.NewClock(), dbWorker, 0)
+	worker := provider.newWorker(logger, clock.NewClock(), dbWorker, 0)
 	if !worker.IsVersionCompatible(logger, provider.workerVersion) {
 		return nil, false, nil
 	}
@@ -163,13 +166,23 @@ func (provider *dbWorkerProvider) FindWorkerForVolume(
 		return nil, false, nil
 	}
 
-	worker := provider.NewGardenWorker(logger, clock.NewClock(), dbWorker, 0)
+	worker := provider.newWorker(logger, clock.NewClock(), dbWorker, 0)
 	if !worker.IsVersionCompatible(logger, provider.workerVersion) {
 		return nil, false, nil
 	}
 	return worker, true, err
 }
 
+// newWorker constructs a Worker using the Runtime the worker registered with,
+// falling back to Garden for workers which did not specify one.
+func (provider *dbWorkerProvider) newWorker(logger lager.Logger, tikTok clock.Clock, savedWorker db.Worker, buildContainersCount int) Worker {
+	if runtime, found := provider.runtimes[savedWorker.Runtime()]; found {
+		return runtime.NewWorker(logger, savedWorker, buildContainersCount)
+	}
+
+	return provider.NewGardenWorker(logger, tikTok, savedWorker, buildContainersCount)
+}
+
 func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, tikTok clock.Clock, savedWorker db.Worker, buildContainersCount int) Worker {
 	gcf := gclient.NewGardenClientFactory(
 		provider.dbWorkerFactory,
@@ -64,6 +64,8 @@ var _ = Describe("DBProvider", func() {
 
 		fakeWorker1 *dbfakes.FakeWorker
 		fakeWorker2 *dbfakes.FakeWorker
+
+		fakeRuntime *workerfakes.FakeRuntime
 	)
 
 	BeforeEach(func() {
@@ -159,6 +161,8 @@ var _ = Describe("DBProvider", func() {
 
 		fakeDBWorkerFactory = new(dbfakes.FakeWorkerFactory)
 
+		fakeRuntime = new(workerfakes.FakeRuntime)
+
 		wantWorkerVersion, err = version.NewVersionFromString("1.1.0")
 		Expect(err).ToNot(HaveOccurred())
 
@@ -176,6 +180,7 @@ var _ = Describe("DBProvider", func() {
 			fakeDBWorkerFactory,
 			wantWorkerVersion,
 			baggageclaimResponseHeaderTimeout,
+			map[string]Runtime{"some-runtime": fakeRuntime},
 		)
 		baggageclaimURL = baggageclaimServer.URL()
 	})
@@ -255,6 +260,28 @@ var _ = Describe("DBProvider", func() {
 				})
 			})
 
+			Context("when a worker registered with a known runtime", func() {
+				var runtimeWorker *workerfakes.FakeWorker
+
+				BeforeEach(func() {
+					fakeWorker2.RuntimeReturns("some-runtime")
+
+					runtimeWorker = new(workerfakes.FakeWorker)
+					runtimeWorker.IsVersionCompatibleReturns(true)
+					fakeRuntime.NewWorkerReturns(runtimeWorker)
+				})
+
+				It("constructs the worker using the runtime", func() {
+					Expect(workers).To(HaveLen(2))
+					Expect(workers).To(ContainElement(runtimeWorker))
+
+					Expect(fakeRuntime.NewWorkerCallCount()).To(Equal(1))
+					_, dbWorker, buildContainers := fakeRuntime.NewWorkerArgsForCall(0)
+					Expect(dbWorker).To(Equal(fakeWorker2))
+					Expect(buildContainers).To(Equal(68))
+				})
+			})
+
 			Context("when a worker's major version is higher or lower than the atc worker version", func() {
 				BeforeEach(func() {
 					worker1 := new(dbfakes.FakeWorker)
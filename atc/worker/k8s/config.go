@@ -0,0 +1,69 @@
+package k8s
+
+import (
+	"errors"
+	"time"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type Config struct {
+	InClusterConfig bool   `long:"in-cluster"  description:"Enables the in-cluster client."`
+	ConfigPath      string `long:"config-path" description:"Path to Kubernetes config when running ATC outside Kubernetes."`
+
+	Namespace string `long:"namespace" default:"concourse-workloads" description:"Namespace in which to create pods for containers."`
+	Name      string `long:"name"      default:"kubernetes"          description:"Name to register the worker as."`
+
+	StreamingImage  string        `long:"streaming-image"   default:"busybox" description:"Image to run the sidecar used for streaming volumes in and out of pods. Must provide sh and tar."`
+	PodStartTimeout time.Duration `long:"pod-start-timeout" default:"5m"      description:"How long to wait for a pod to be scheduled and start running."`
+
+	ResourceTypes map[string]string `long:"resource" description:"A resource type to advertise for the worker, and the image to run its containers with. Can be specified multiple times." value-name:"TYPE:IMAGE"`
+	Tags          []string          `long:"tag"      description:"A tag to advertise for the worker. Can be specified multiple times."`
+}
+
+func (config Config) IsConfigured() bool {
+	return config.InClusterConfig || config.ConfigPath != ""
+}
+
+func (config Config) Validate() error {
+	if config.InClusterConfig && config.ConfigPath != "" {
+		return errors.New("Either in-cluster or config-path can be used, not both.")
+	}
+
+	if config.Namespace == "" {
+		return errors.New("namespace must be specified")
+	}
+
+	if config.Name == "" {
+		return errors.New("name must be specified")
+	}
+
+	return nil
+}
+
+// RESTConfig builds the configuration for connecting to the cluster.
+func (config Config) RESTConfig() (*rest.Config, error) {
+	if config.InClusterConfig {
+		return rest.InClusterConfig()
+	}
+
+	return clientcmd.BuildConfigFromFlags("", config.ConfigPath)
+}
+
+// NewClientset connects to the cluster, returning both the clientset and the
+// configuration used, which is needed for executing commands in pods.
+func (config Config) NewClientset() (kubernetes.Interface, *rest.Config, error) {
+	restConfig, err := config.RESTConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientset, restConfig, nil
+}
@@ -0,0 +1,392 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+var ErrContainerOperationNotSupported = errors.New("operation is not supported for containers in the kubernetes runtime")
+
+// runScript changes to the directory given as its first argument before
+// running the remaining arguments as a command.
+const runScript = `cd "$0" && exec "$@"`
+
+// stopScript signals every process in the container other than the
+// keep-alive process. Processes which do not exit within 10 seconds of being
+// terminated are killed, as with Garden.
+const stopScript = `kill -$0 -1 2>/dev/null
+[ "$0" = KILL ] && exit 0
+for i in 1 2 3 4 5 6 7 8 9 10; do
+  kill -0 -1 2>/dev/null || exit 0
+  sleep 1
+done
+kill -KILL -1 2>/dev/null
+exit 0`
+
+// kubernetesContainer runs processes in the main container of a pod.
+type kubernetesContainer struct {
+	clientset kubernetes.Interface
+	executor  Executor
+	namespace string
+
+	pod          *corev1.Pod
+	dbContainer  db.CreatedContainer
+	volumeMounts []worker.VolumeMount
+	workerName   string
+}
+
+func newContainer(
+	clientset kubernetes.Interface,
+	executor Executor,
+	namespace string,
+	pod *corev1.Pod,
+	dbContainer db.CreatedContainer,
+	workerName string,
+) worker.Container {
+	container := &kubernetesContainer{
+		clientset:   clientset,
+		executor:    executor,
+		namespace:   namespace,
+		pod:         pod,
+		dbContainer: dbContainer,
+		workerName:  workerName,
+	}
+
+	for _, c := range pod.Spec.Containers {
+		if c.Name != mainContainerName {
+			continue
+		}
+
+		for _, mount := range c.VolumeMounts {
+			container.volumeMounts = append(container.volumeMounts, worker.VolumeMount{
+				Volume:    newVolume(executor, pod.Name, mount.Name, mount.MountPath, workerName),
+				MountPath: mount.MountPath,
+			})
+		}
+	}
+
+	return container
+}
+
+func (container *kubernetesContainer) Handle() string {
+	return container.pod.Name
+}
+
+func (container *kubernetesContainer) WorkerName() string {
+	return container.workerName
+}
+
+func (container *kubernetesContainer) VolumeMounts() []worker.VolumeMount {
+	return container.volumeMounts
+}
+
+func (container *kubernetesContainer) MarkAsHijacked() error {
+	return container.dbContainer.MarkAsHijacked()
+}
+
+func (container *kubernetesContainer) Destroy() error {
+	err := container.pods().Delete(container.Handle(), &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (container *kubernetesContainer) Run(ctx context.Context, spec garden.ProcessSpec, processIO garden.ProcessIO) (garden.Process, error) {
+	dir := spec.Dir
+	if dir == "" {
+		dir = "/"
+	}
+
+	command := []string{"/bin/sh", "-c", runScript, dir}
+	if len(spec.Env) > 0 {
+		command = append(command, "env")
+		command = append(command, spec.Env...)
+	}
+
+	command = append(command, spec.Path)
+	command = append(command, spec.Args...)
+
+	process := &process{
+		id:        spec.ID,
+		container: container,
+		exited:    make(chan struct{}),
+	}
+
+	go func() {
+		defer close(process.exited)
+
+		process.status, process.err = container.executor.Exec(
+			ctx,
+			container.Handle(),
+			mainContainerName,
+			command,
+			processIO.Stdin,
+			processIO.Stdout,
+			processIO.Stderr,
+		)
+	}()
+
+	return process, nil
+}
+
+// Attach always fails, as the executor's connection to a process can't be
+// re-established, e.g. once the ATC which ran it has restarted. The step then
+// runs its process again, so any process left over from before is killed
+// first rather than left running alongside it.
+func (container *kubernetesContainer) Attach(ctx context.Context, processID string, processIO garden.ProcessIO) (garden.Process, error) {
+	err := container.Stop(true)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, garden.ProcessNotFoundError{ProcessID: processID}
+}
+
+func (container *kubernetesContainer) Stop(kill bool) error {
+	signal := "TERM"
+	if kill {
+		signal = "KILL"
+	}
+
+	_, err := container.executor.Exec(
+		context.Background(),
+		container.Handle(),
+		mainContainerName,
+		[]string{"/bin/sh", "-c", stopScript, signal},
+		nil,
+		nil,
+		nil,
+	)
+
+	return err
+}
+
+func (container *kubernetesContainer) Info() (garden.ContainerInfo, error) {
+	pod, err := container.pods().Get(container.Handle(), metav1.GetOptions{})
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	properties, err := podProperties(pod)
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	state := "active"
+	if pod.Status.Phase != corev1.PodRunning {
+		state = "stopped"
+	}
+
+	return garden.ContainerInfo{
+		State:       state,
+		HostIP:      pod.Status.HostIP,
+		ContainerIP: pod.Status.PodIP,
+		Properties:  properties,
+	}, nil
+}
+
+// StreamIn extracts the uncompressed tar stream into the main container.
+func (container *kubernetesContainer) StreamIn(spec garden.StreamInSpec) error {
+	stderr := new(bytes.Buffer)
+
+	status, err := container.executor.Exec(
+		context.Background(),
+		container.Handle(),
+		mainContainerName,
+		[]string{"/bin/sh", "-c", streamInScript, spec.Path},
+		spec.TarStream,
+		nil,
+		stderr,
+	)
+	if err != nil {
+		return err
+	}
+
+	if status != 0 {
+		return fmt.Errorf("failed to stream in to container (exit status %d): %s", status, stderr.String())
+	}
+
+	return nil
+}
+
+// StreamOut returns an uncompressed tar stream of the path in the main
+// container.
+func (container *kubernetesContainer) StreamOut(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+	reader, writer := io.Pipe()
+
+	go func() {
+		stderr := new(bytes.Buffer)
+
+		status, err := container.executor.Exec(
+			context.Background(),
+			container.Handle(),
+			mainContainerName,
+			[]string{"/bin/sh", "-c", streamOutScript, path.Clean(spec.Path)},
+			nil,
+			writer,
+			stderr,
+		)
+		if err == nil && status != 0 {
+			err = fmt.Errorf("failed to stream out of container (exit status %d): %s", status, stderr.String())
+		}
+
+		writer.CloseWithError(err)
+	}()
+
+	return reader, nil
+}
+
+func (container *kubernetesContainer) CurrentBandwidthLimits() (garden.BandwidthLimits, error) {
+	return garden.BandwidthLimits{}, nil
+}
+
+func (container *kubernetesContainer) CurrentCPULimits() (garden.CPULimits, error) {
+	return garden.CPULimits{}, nil
+}
+
+func (container *kubernetesContainer) CurrentDiskLimits() (garden.DiskLimits, error) {
+	return garden.DiskLimits{}, nil
+}
+
+func (container *kubernetesContainer) CurrentMemoryLimits() (garden.MemoryLimits, error) {
+	return garden.MemoryLimits{}, nil
+}
+
+func (container *kubernetesContainer) NetIn(hostPort, containerPort uint32) (uint32, uint32, error) {
+	return 0, 0, ErrContainerOperationNotSupported
+}
+
+func (container *kubernetesContainer) NetOut(netOutRule garden.NetOutRule) error {
+	return ErrContainerOperationNotSupported
+}
+
+func (container *kubernetesContainer) BulkNetOut(netOutRules []garden.NetOutRule) error {
+	return ErrContainerOperationNotSupported
+}
+
+func (container *kubernetesContainer) Metrics() (garden.Metrics, error) {
+	return garden.Metrics{}, nil
+}
+
+// SetGraceTime is a no-op, as pods are only deleted once their container has
+// been marked for destruction.
+func (container *kubernetesContainer) SetGraceTime(graceTime time.Duration) error {
+	return nil
+}
+
+func (container *kubernetesContainer) Properties() (garden.Properties, error) {
+	pod, err := container.pods().Get(container.Handle(), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return podProperties(pod)
+}
+
+func (container *kubernetesContainer) Property(name string) (string, error) {
+	properties, err := container.Properties()
+	if err != nil {
+		return "", err
+	}
+
+	value, found := properties[name]
+	if !found {
+		return "", fmt.Errorf("property does not exist: %s", name)
+	}
+
+	return value, nil
+}
+
+func (container *kubernetesContainer) SetProperty(name string, value string) error {
+	return container.updateProperties(func(properties map[string]string) {
+		properties[name] = value
+	})
+}
+
+func (container *kubernetesContainer) RemoveProperty(name string) error {
+	return container.updateProperties(func(properties map[string]string) {
+		delete(properties, name)
+	})
+}
+
+// updateProperties applies the change to the properties stored in the pod's
+// annotations, retrying if the pod is modified concurrently.
+func (container *kubernetesContainer) updateProperties(update func(map[string]string)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		pod, err := container.pods().Get(container.Handle(), metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		properties, err := podProperties(pod)
+		if err != nil {
+			return err
+		}
+
+		update(properties)
+
+		payload, err := json.Marshal(properties)
+		if err != nil {
+			return err
+		}
+
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+
+		pod.Annotations[propertiesAnnotation] = string(payload)
+
+		_, err = container.pods().Update(pod)
+		return err
+	})
+}
+
+func (container *kubernetesContainer) pods() typedcorev1.PodInterface {
+	return container.clientset.CoreV1().Pods(container.namespace)
+}
+
+type process struct {
+	id        string
+	container *kubernetesContainer
+
+	exited chan struct{}
+	status int
+	err    error
+}
+
+func (process *process) ID() string {
+	return process.id
+}
+
+func (process *process) Wait() (int, error) {
+	<-process.exited
+	return process.status, process.err
+}
+
+func (process *process) SetTTY(garden.TTYSpec) error {
+	return nil
+}
+
+// Signal stops every process in the container, as processes run via the
+// executor cannot be signalled individually.
+func (process *process) Signal(signal garden.Signal) error {
+	return process.container.Stop(signal == garden.SignalKill)
+}
@@ -0,0 +1,99 @@
+package k8s
+
+import (
+	"context"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+//go:generate counterfeiter . Executor
+
+// Executor runs commands in the containers of a pod, as with `kubectl exec`.
+type Executor interface {
+	// Exec runs the command to completion, returning its exit status. Any of
+	// stdin, stdout and stderr may be nil.
+	Exec(
+		ctx context.Context,
+		pod string,
+		container string,
+		command []string,
+		stdin io.Reader,
+		stdout io.Writer,
+		stderr io.Writer,
+	) (int, error)
+}
+
+type spdyExecutor struct {
+	config    *rest.Config
+	client    rest.Interface
+	namespace string
+}
+
+// NewExecutor constructs an Executor which runs commands in pods in the given
+// namespace via the exec subresource.
+func NewExecutor(config *rest.Config, clientset kubernetes.Interface, namespace string) Executor {
+	return &spdyExecutor{
+		config:    config,
+		client:    clientset.CoreV1().RESTClient(),
+		namespace: namespace,
+	}
+}
+
+func (executor *spdyExecutor) Exec(
+	ctx context.Context,
+	pod string,
+	container string,
+	command []string,
+	stdin io.Reader,
+	stdout io.Writer,
+	stderr io.Writer,
+) (int, error) {
+	req := executor.client.Post().
+		Resource("pods").
+		Namespace(executor.namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    stdout != nil,
+			Stderr:    stderr != nil,
+		}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(executor.config, "POST", req.URL())
+	if err != nil {
+		return -1, err
+	}
+
+	streamErr := make(chan error, 1)
+	go func() {
+		streamErr <- exec.Stream(remotecommand.StreamOptions{
+			Stdin:  stdin,
+			Stdout: stdout,
+			Stderr: stderr,
+		})
+	}()
+
+	select {
+	case err = <-streamErr:
+	case <-ctx.Done():
+		return -1, ctx.Err()
+	}
+
+	if err != nil {
+		if exitErr, ok := err.(utilexec.ExitError); ok && exitErr.Exited() {
+			return exitErr.ExitStatus(), nil
+		}
+
+		return -1, err
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,329 @@
+package k8s
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	mainContainerName   = "main"
+	streamContainerName = "stream"
+
+	// streamVolumesDir is where the streaming sidecar mounts each of the pod's
+	// volumes, by name.
+	streamVolumesDir = "/concourse/volumes"
+
+	handleLabel          = "concourse-ci.org/handle"
+	typeLabel            = "concourse-ci.org/type"
+	teamIDLabel          = "concourse-ci.org/team-id"
+	workerAnnotation     = "concourse-ci.org/worker"
+	propertiesAnnotation = "concourse-ci.org/properties"
+
+	scratchPath = "/scratch"
+)
+
+// keepAliveCommand keeps a container running so that processes can be run in
+// it via Executor, exiting promptly when the pod is deleted.
+var keepAliveCommand = []string{"/bin/sh", "-c", "trap 'exit 0' TERM; sleep 2147483647 & wait"}
+
+var ErrImageArtifactNotSupported = errors.New("image artifacts are not supported by the kubernetes runtime")
+
+type UnsupportedImageError struct {
+	Image string
+}
+
+func (err UnsupportedImageError) Error() string {
+	return fmt.Sprintf("image '%s' is not supported by the kubernetes runtime; only docker images can be used", err.Image)
+}
+
+// newPod translates the ContainerSpec into a pod named after the container's
+// handle.
+//
+// The pod runs the image in its main container, with an emptyDir volume for
+// each of the scratch dir, working dir, inputs and outputs. A sidecar mounts
+// each volume under streamVolumesDir so that they can be streamed in and out
+// regardless of the tools available in the image. Volumes only live as long
+// as the pod, so caches are not persisted between builds.
+func newPod(
+	handle string,
+	workerName string,
+	image string,
+	streamingImage string,
+	metadata db.ContainerMetadata,
+	spec worker.ContainerSpec,
+) *corev1.Pod {
+	volumes := []corev1.Volume{}
+	mainMounts := []corev1.VolumeMount{}
+	streamMounts := []corev1.VolumeMount{}
+
+	for i, mountPath := range mountPaths(spec) {
+		name := fmt.Sprintf("volume-%d", i)
+
+		volumes = append(volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+
+		mainMounts = append(mainMounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: mountPath,
+		})
+
+		streamMounts = append(streamMounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: path.Join(streamVolumesDir, name),
+		})
+	}
+
+	automountToken := false
+	privileged := spec.ImageSpec.Privileged
+
+	main := corev1.Container{
+		Name:         mainContainerName,
+		Image:        image,
+		Command:      keepAliveCommand,
+		Env:          envVars(spec.Env),
+		WorkingDir:   spec.Dir,
+		VolumeMounts: mainMounts,
+		Resources:    resourceRequirements(spec.Limits),
+		SecurityContext: &corev1.SecurityContext{
+			Privileged: &privileged,
+			RunAsUser:  runAsUser(spec.User),
+		},
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: handle,
+			Labels: map[string]string{
+				handleLabel: handle,
+				typeLabel:   string(metadata.Type),
+				teamIDLabel: strconv.Itoa(spec.TeamID),
+			},
+			Annotations: map[string]string{
+				workerAnnotation:     workerName,
+				propertiesAnnotation: "{}",
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:                corev1.RestartPolicyNever,
+			AutomountServiceAccountToken: &automountToken,
+			Volumes:                      volumes,
+			Containers: []corev1.Container{
+				main,
+				{
+					Name:         streamContainerName,
+					Image:        streamingImage,
+					Command:      keepAliveCommand,
+					VolumeMounts: streamMounts,
+				},
+			},
+		},
+	}
+}
+
+// mountPaths returns the paths at which a volume should be mounted in the
+// main container, sorted so that parent directories are mounted first.
+func mountPaths(spec worker.ContainerSpec) []string {
+	paths := map[string]bool{scratchPath: true}
+
+	if spec.Dir != "" {
+		paths[filepath.Clean(spec.Dir)] = true
+	}
+
+	for _, input := range spec.Inputs {
+		paths[filepath.Clean(input.DestinationPath())] = true
+	}
+
+	for _, outputPath := range spec.Outputs {
+		paths[filepath.Clean(outputPath)] = true
+	}
+
+	sorted := []string{}
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+func envVars(env []string) []corev1.EnvVar {
+	vars := []corev1.EnvVar{}
+	for _, e := range env {
+		segs := strings.SplitN(e, "=", 2)
+		if len(segs) != 2 {
+			continue
+		}
+
+		vars = append(vars, corev1.EnvVar{Name: segs[0], Value: segs[1]})
+	}
+
+	return vars
+}
+
+// resourceRequirements converts the limits to their Kubernetes equivalents.
+// CPU limits are given in shares, where 1024 shares are equivalent to one
+// CPU.
+func resourceRequirements(limits worker.ContainerLimits) corev1.ResourceRequirements {
+	resources := corev1.ResourceList{}
+
+	if limits.CPU != nil && *limits.CPU > 0 {
+		millicores := int64(*limits.CPU * 1000 / 1024)
+		if millicores > 0 {
+			resources[corev1.ResourceCPU] = *resource.NewMilliQuantity(millicores, resource.DecimalSI)
+		}
+	}
+
+	if limits.Memory != nil && *limits.Memory > 0 {
+		resources[corev1.ResourceMemory] = *resource.NewQuantity(int64(*limits.Memory), resource.BinarySI)
+	}
+
+	if len(resources) == 0 {
+		return corev1.ResourceRequirements{}
+	}
+
+	return corev1.ResourceRequirements{Limits: resources}
+}
+
+// runAsUser converts the user to a UID. Users can only be given by name if
+// they are root, as the image's /etc/passwd is not available; otherwise the
+// image's default user is used.
+func runAsUser(user string) *int64 {
+	if user == "root" {
+		uid := int64(0)
+		return &uid
+	}
+
+	uid, err := strconv.ParseInt(user, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &uid
+}
+
+// imageFor determines the image reference to run the main container with.
+//
+// Only images which can be pulled by the kubelet are supported, i.e. docker
+// image URLs, image resources of type registry-image or docker-image, and
+// resource types whose image is one of the two.
+func imageFor(
+	spec worker.ImageSpec,
+	workerResourceTypes []atc.WorkerResourceType,
+	resourceTypes atc.VersionedResourceTypes,
+) (string, error) {
+	if spec.ImageArtifactSource != nil {
+		return "", ErrImageArtifactNotSupported
+	}
+
+	if spec.ImageResource != nil {
+		var version atc.Version
+		if spec.ImageResource.Version != nil {
+			version = *spec.ImageResource.Version
+		}
+
+		return imageResourceRef(spec.ImageResource.Type, spec.ImageResource.Source, version)
+	}
+
+	if spec.ImageURL != "" {
+		return imageURLRef(spec.ImageURL)
+	}
+
+	if spec.ResourceType != "" {
+		for _, resourceType := range resourceTypes {
+			if resourceType.Name == spec.ResourceType {
+				return imageResourceRef(resourceType.Type, resourceType.Source, resourceType.Version)
+			}
+		}
+
+		for _, resourceType := range workerResourceTypes {
+			if resourceType.Type == spec.ResourceType {
+				return resourceType.Image, nil
+			}
+		}
+
+		return "", fmt.Errorf("unknown resource type '%s'", spec.ResourceType)
+	}
+
+	return "", errors.New("no image specified")
+}
+
+func imageResourceRef(resourceType string, source atc.Source, version atc.Version) (string, error) {
+	if resourceType != "registry-image" && resourceType != "docker-image" {
+		return "", UnsupportedImageError{Image: resourceType}
+	}
+
+	repository, _ := source["repository"].(string)
+	if repository == "" {
+		return "", errors.New("image resource source must specify a repository")
+	}
+
+	if digest := version["digest"]; digest != "" {
+		return repository + "@" + digest, nil
+	}
+
+	tag := "latest"
+	if sourceTag, ok := source["tag"].(string); ok && sourceTag != "" {
+		tag = sourceTag
+	}
+
+	return repository + ":" + tag, nil
+}
+
+// imageURLRef converts an image URL of the form docker:///repository#tag, as
+// accepted by Garden, into an image reference.
+func imageURLRef(imageURL string) (string, error) {
+	u, err := url.Parse(imageURL)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme != "docker" {
+		return "", UnsupportedImageError{Image: imageURL}
+	}
+
+	repository := strings.TrimPrefix(u.Path, "/")
+	if u.Host != "" {
+		repository = u.Host + "/" + repository
+	}
+
+	if u.Fragment == "" {
+		return repository, nil
+	}
+
+	return repository + ":" + u.Fragment, nil
+}
+
+func podProperties(pod *corev1.Pod) (map[string]string, error) {
+	properties := map[string]string{}
+
+	payload, found := pod.Annotations[propertiesAnnotation]
+	if !found {
+		return properties, nil
+	}
+
+	err := json.Unmarshal([]byte(payload), &properties)
+	if err != nil {
+		return nil, err
+	}
+
+	return properties, nil
+}
@@ -0,0 +1,118 @@
+package k8s
+
+import (
+	"os"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/ifrit"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	registrarInterval = 10 * time.Second
+	registrarTTL      = 30 * time.Second
+)
+
+// NewRegistrar keeps the worker registered with the Kubernetes runtime and
+// deletes the pods of containers which have been marked for destruction,
+// standing in for the worker process of a Garden worker.
+func NewRegistrar(
+	logger lager.Logger,
+	clock clock.Clock,
+	workerFactory db.WorkerFactory,
+	containerRepository db.ContainerRepository,
+	clientset kubernetes.Interface,
+	config Config,
+) ifrit.RunFunc {
+	return func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		workerInfo := WorkerInfo(config)
+
+		_, err := workerFactory.SaveWorker(workerInfo, registrarTTL)
+		if err != nil {
+			logger.Error("could-not-save-kubernetes-worker", err)
+			return err
+		}
+
+		ticker := clock.NewTicker(registrarInterval)
+		defer ticker.Stop()
+
+		close(ready)
+
+		for {
+			select {
+			case <-ticker.C():
+				_, err = workerFactory.SaveWorker(workerInfo, registrarTTL)
+				if err != nil {
+					logger.Error("could-not-save-kubernetes-worker", err)
+				}
+
+				destroyPods(logger.Session("destroy-pods"), containerRepository, clientset, config.Namespace, workerInfo.Name)
+			case <-signals:
+				return nil
+			}
+		}
+	}
+}
+
+// WorkerInfo describes the worker to register for the configured runtime.
+func WorkerInfo(config Config) atc.Worker {
+	resourceTypes := []atc.WorkerResourceType{}
+	for t, image := range config.ResourceTypes {
+		resourceTypes = append(resourceTypes, atc.WorkerResourceType{
+			Type:  t,
+			Image: image,
+		})
+	}
+
+	sort.Slice(resourceTypes, func(i, j int) bool {
+		return resourceTypes[i].Type < resourceTypes[j].Type
+	})
+
+	tags := config.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return atc.Worker{
+		Name:          config.Name,
+		Runtime:       atc.WorkerRuntimeKubernetes,
+		Platform:      "linux",
+		Tags:          tags,
+		ResourceTypes: resourceTypes,
+	}
+}
+
+func destroyPods(
+	logger lager.Logger,
+	containerRepository db.ContainerRepository,
+	clientset kubernetes.Interface,
+	namespace string,
+	workerName string,
+) {
+	handles, err := containerRepository.FindDestroyingContainers(workerName)
+	if err != nil {
+		logger.Error("failed-to-find-destroying-containers", err)
+		return
+	}
+
+	failed := []string{}
+	for _, handle := range handles {
+		err := clientset.CoreV1().Pods(namespace).Delete(handle, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			logger.Error("failed-to-delete-pod", err, lager.Data{"handle": handle})
+			failed = append(failed, handle)
+		}
+	}
+
+	_, err = containerRepository.RemoveDestroyingContainers(workerName, failed)
+	if err != nil {
+		logger.Error("failed-to-remove-destroying-containers", err)
+	}
+}
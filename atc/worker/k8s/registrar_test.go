@@ -0,0 +1,122 @@
+package k8s_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/worker/k8s"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/ginkgomon"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var _ = Describe("Registrar", func() {
+	var (
+		fakeWorkerFactory       *dbfakes.FakeWorkerFactory
+		fakeContainerRepository *dbfakes.FakeContainerRepository
+		fakeClientset           *fake.Clientset
+		fakeClock               *fakeclock.FakeClock
+
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+		fakeContainerRepository = new(dbfakes.FakeContainerRepository)
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+
+		fakeClientset = fake.NewSimpleClientset(
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "destroying-handle", Namespace: "some-namespace"}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "undeletable-handle", Namespace: "some-namespace"}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "created-handle", Namespace: "some-namespace"}},
+		)
+		fakeClientset.PrependReactor("delete", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if action.(k8stesting.DeleteAction).GetName() == "undeletable-handle" {
+				return true, nil, errors.New("nope")
+			}
+
+			return false, nil, nil
+		})
+
+		fakeContainerRepository.FindDestroyingContainersReturns([]string{
+			"destroying-handle",
+			"undeletable-handle",
+			"missing-handle",
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		process = ginkgomon.Invoke(k8s.NewRegistrar(
+			lagertest.NewTestLogger("test"),
+			fakeClock,
+			fakeWorkerFactory,
+			fakeContainerRepository,
+			fakeClientset,
+			k8s.Config{
+				Name:          "some-worker",
+				Namespace:     "some-namespace",
+				ResourceTypes: map[string]string{"git": "concourse/git-resource"},
+				Tags:          []string{"some-tag"},
+			},
+		))
+	})
+
+	AfterEach(func() {
+		ginkgomon.Interrupt(process)
+	})
+
+	It("registers the worker with the kubernetes runtime and keeps registering it on an interval", func() {
+		expectedWorker := atc.Worker{
+			Name:     "some-worker",
+			Runtime:  "kubernetes",
+			Platform: "linux",
+			Tags:     []string{"some-tag"},
+			ResourceTypes: []atc.WorkerResourceType{
+				{Type: "git", Image: "concourse/git-resource"},
+			},
+		}
+
+		Expect(fakeWorkerFactory.SaveWorkerCallCount()).To(Equal(1))
+		workerInfo, ttl := fakeWorkerFactory.SaveWorkerArgsForCall(0)
+		Expect(workerInfo).To(Equal(expectedWorker))
+		Expect(ttl).To(Equal(30 * time.Second))
+
+		fakeClock.Increment(11 * time.Second)
+
+		Eventually(fakeWorkerFactory.SaveWorkerCallCount).Should(Equal(2))
+		workerInfo, _ = fakeWorkerFactory.SaveWorkerArgsForCall(1)
+		Expect(workerInfo).To(Equal(expectedWorker))
+	})
+
+	It("deletes the pods of destroying containers", func() {
+		fakeClock.Increment(11 * time.Second)
+
+		Eventually(fakeContainerRepository.RemoveDestroyingContainersCallCount).Should(Equal(1))
+
+		Expect(fakeContainerRepository.FindDestroyingContainersArgsForCall(0)).To(Equal("some-worker"))
+
+		pods, err := fakeClientset.CoreV1().Pods("some-namespace").List(metav1.ListOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		names := []string{}
+		for _, pod := range pods.Items {
+			names = append(names, pod.Name)
+		}
+
+		Expect(names).To(ConsistOf("undeletable-handle", "created-handle"))
+
+		workerName, handlesToKeep := fakeContainerRepository.RemoveDestroyingContainersArgsForCall(0)
+		Expect(workerName).To(Equal("some-worker"))
+		Expect(handlesToKeep).To(Equal([]string{"undeletable-handle"}))
+	})
+})
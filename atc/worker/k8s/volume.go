@@ -0,0 +1,172 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/DataDog/zstd"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+var ErrVolumeOperationNotSupported = errors.New("operation is not supported for volumes in the kubernetes runtime")
+
+// streamInScript extracts a tar stream from stdin into the directory given as
+// its first argument.
+const streamInScript = `mkdir -p "$0" && tar -x -C "$0"`
+
+// streamOutScript writes a tar stream of the path given as its first
+// argument to stdout. Directories are streamed as their contents, while files
+// are streamed on their own, matching Baggageclaim.
+const streamOutScript = `if [ -d "$0" ]; then tar -c -C "$0" .; else tar -c -C "$(dirname "$0")" "$(basename "$0")"; fi`
+
+// kubernetesVolume is an emptyDir volume of a pod. Data is streamed in and
+// out via the pod's streaming sidecar, in the same compressed format as
+// Baggageclaim volumes so that they can be streamed to and from volumes on
+// other workers.
+type kubernetesVolume struct {
+	executor   Executor
+	pod        string
+	name       string
+	mountPath  string
+	workerName string
+}
+
+func newVolume(executor Executor, pod string, name string, mountPath string, workerName string) worker.Volume {
+	return &kubernetesVolume{
+		executor:   executor,
+		pod:        pod,
+		name:       name,
+		mountPath:  mountPath,
+		workerName: workerName,
+	}
+}
+
+// volumeHandle identifies a volume by its pod and name within the pod.
+func volumeHandle(pod string, name string) string {
+	return pod + "/" + name
+}
+
+func parseVolumeHandle(handle string) (string, string, bool) {
+	segs := strings.SplitN(handle, "/", 2)
+	if len(segs) != 2 {
+		return "", "", false
+	}
+
+	return segs[0], segs[1], true
+}
+
+func (v *kubernetesVolume) Handle() string { return volumeHandle(v.pod, v.name) }
+
+func (v *kubernetesVolume) Path() string { return v.mountPath }
+
+func (v *kubernetesVolume) WorkerName() string { return v.workerName }
+
+func (v *kubernetesVolume) SetProperty(key string, value string) error {
+	return nil
+}
+
+func (v *kubernetesVolume) Properties() (baggageclaim.VolumeProperties, error) {
+	return baggageclaim.VolumeProperties{}, nil
+}
+
+func (v *kubernetesVolume) SetPrivileged(bool) error {
+	return nil
+}
+
+func (v *kubernetesVolume) StreamIn(ctx context.Context, dest string, tarStream io.Reader) error {
+	zstdReader := zstd.NewReader(tarStream)
+	defer zstdReader.Close()
+
+	stderr := new(bytes.Buffer)
+
+	status, err := v.executor.Exec(
+		ctx,
+		v.pod,
+		streamContainerName,
+		[]string{"/bin/sh", "-c", streamInScript, v.streamPath(dest)},
+		zstdReader,
+		nil,
+		stderr,
+	)
+	if err != nil {
+		return err
+	}
+
+	if status != 0 {
+		return fmt.Errorf("failed to stream in to volume (exit status %d): %s", status, stderr.String())
+	}
+
+	return nil
+}
+
+func (v *kubernetesVolume) StreamOut(ctx context.Context, src string) (io.ReadCloser, error) {
+	reader, writer := io.Pipe()
+
+	go func() {
+		zstdWriter := zstd.NewWriter(writer)
+		stderr := new(bytes.Buffer)
+
+		status, err := v.executor.Exec(
+			ctx,
+			v.pod,
+			streamContainerName,
+			[]string{"/bin/sh", "-c", streamOutScript, v.streamPath(src)},
+			nil,
+			zstdWriter,
+			stderr,
+		)
+		if err == nil && status != 0 {
+			err = fmt.Errorf("failed to stream out of volume (exit status %d): %s", status, stderr.String())
+		}
+
+		closeErr := zstdWriter.Close()
+		if err == nil {
+			err = closeErr
+		}
+
+		writer.CloseWithError(err)
+	}()
+
+	return reader, nil
+}
+
+func (v *kubernetesVolume) streamPath(p string) string {
+	return path.Join(streamVolumesDir, v.name, p)
+}
+
+func (v *kubernetesVolume) COWStrategy() baggageclaim.COWStrategy {
+	return baggageclaim.COWStrategy{}
+}
+
+// InitializeResourceCache is a no-op, as the volume does not outlive its pod
+// and so cannot be reused as a cache.
+func (v *kubernetesVolume) InitializeResourceCache(db.UsedResourceCache) error {
+	return nil
+}
+
+// InitializeTaskCache is a no-op, as the volume does not outlive its pod and
+// so cannot be reused as a cache.
+func (v *kubernetesVolume) InitializeTaskCache(logger lager.Logger, jobID int, stepName string, path string, privileged bool) error {
+	return nil
+}
+
+func (v *kubernetesVolume) InitializeArtifact(name string, buildID int) (db.WorkerArtifact, error) {
+	return nil, ErrVolumeOperationNotSupported
+}
+
+func (v *kubernetesVolume) CreateChildForContainer(db.CreatingContainer, string) (db.CreatingVolume, error) {
+	return nil, ErrVolumeOperationNotSupported
+}
+
+// Destroy is a no-op, as the volume is destroyed along with its pod.
+func (v *kubernetesVolume) Destroy() error {
+	return nil
+}
@@ -0,0 +1,429 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/gclient"
+	"github.com/cppforlife/go-semi-semantic/version"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+var ErrCreateVolumeNotSupported = errors.New("volumes cannot be created outside of a container in the kubernetes runtime")
+
+type PodFailedError struct {
+	Pod    string
+	Reason string
+}
+
+func (err PodFailedError) Error() string {
+	return fmt.Sprintf("pod '%s' failed to start: %s", err.Pod, err.Reason)
+}
+
+type PodStartTimeoutError struct {
+	Pod     string
+	Timeout time.Duration
+}
+
+func (err PodStartTimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s waiting for pod '%s' to start", err.Timeout, err.Pod)
+}
+
+type kubernetesRuntime struct {
+	clientset     kubernetes.Interface
+	executor      Executor
+	dbTeamFactory db.TeamFactory
+	config        Config
+}
+
+// NewRuntime constructs a worker.Runtime which runs containers as pods in the
+// configured namespace.
+func NewRuntime(
+	clientset kubernetes.Interface,
+	executor Executor,
+	dbTeamFactory db.TeamFactory,
+	config Config,
+) worker.Runtime {
+	return &kubernetesRuntime{
+		clientset:     clientset,
+		executor:      executor,
+		dbTeamFactory: dbTeamFactory,
+		config:        config,
+	}
+}
+
+func (runtime *kubernetesRuntime) NewWorker(logger lager.Logger, dbWorker db.Worker, buildContainers int) worker.Worker {
+	return &kubernetesWorker{
+		clientset:       runtime.clientset,
+		executor:        runtime.executor,
+		dbTeamFactory:   runtime.dbTeamFactory,
+		namespace:       runtime.config.Namespace,
+		streamingImage:  runtime.config.StreamingImage,
+		podStartTimeout: runtime.config.PodStartTimeout,
+		dbWorker:        dbWorker,
+		buildContainers: buildContainers,
+	}
+}
+
+type kubernetesWorker struct {
+	clientset       kubernetes.Interface
+	executor        Executor
+	dbTeamFactory   db.TeamFactory
+	namespace       string
+	streamingImage  string
+	podStartTimeout time.Duration
+
+	dbWorker        db.Worker
+	buildContainers int
+}
+
+func (w *kubernetesWorker) Name() string {
+	return w.dbWorker.Name()
+}
+
+func (w *kubernetesWorker) Description() string {
+	return worker.DescribeWorker(w.dbWorker)
+}
+
+func (w *kubernetesWorker) ResourceTypes() []atc.WorkerResourceType {
+	return w.dbWorker.ResourceTypes()
+}
+
+func (w *kubernetesWorker) Tags() atc.Tags {
+	return w.dbWorker.Tags()
+}
+
+func (w *kubernetesWorker) Uptime() time.Duration {
+	return time.Since(w.dbWorker.StartTime())
+}
+
+func (w *kubernetesWorker) IsOwnedByTeam() bool {
+	return w.dbWorker.TeamID() != 0
+}
+
+func (w *kubernetesWorker) Ephemeral() bool {
+	return w.dbWorker.Ephemeral()
+}
+
+func (w *kubernetesWorker) BuildContainers() int {
+	return w.buildContainers
+}
+
+// IsVersionCompatible always returns true, as the worker is registered by the
+// ATC itself rather than by a separately versioned worker process.
+func (w *kubernetesWorker) IsVersionCompatible(lager.Logger, version.Version) bool {
+	return true
+}
+
+func (w *kubernetesWorker) Satisfies(logger lager.Logger, spec worker.WorkerSpec) bool {
+	return worker.SatisfiesSpec(w.dbWorker, spec)
+}
+
+// GardenClient returns nil, as containers are not run via Garden.
+func (w *kubernetesWorker) GardenClient() gclient.Client {
+	return nil
+}
+
+func (w *kubernetesWorker) ActiveTasks() (int, error) {
+	return w.dbWorker.ActiveTasks()
+}
+
+func (w *kubernetesWorker) IncreaseActiveTasks() error {
+	return w.dbWorker.IncreaseActiveTasks()
+}
+
+func (w *kubernetesWorker) DecreaseActiveTasks() error {
+	return w.dbWorker.DecreaseActiveTasks()
+}
+
+func (w *kubernetesWorker) FindOrCreateContainer(
+	ctx context.Context,
+	logger lager.Logger,
+	delegate worker.ImageFetchingDelegate,
+	owner db.ContainerOwner,
+	metadata db.ContainerMetadata,
+	containerSpec worker.ContainerSpec,
+	resourceTypes atc.VersionedResourceTypes,
+) (worker.Container, error) {
+	creatingContainer, createdContainer, err := w.dbWorker.FindContainer(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	if createdContainer != nil {
+		logger = logger.WithData(lager.Data{"container": createdContainer.Handle()})
+		logger.Debug("found-created-container-in-db")
+
+		pod, found, err := w.findPod(createdContainer.Handle())
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return nil, garden.ContainerNotFoundError{Handle: createdContainer.Handle()}
+		}
+
+		return w.newContainer(pod, createdContainer), nil
+	}
+
+	if creatingContainer == nil {
+		logger.Debug("creating-container-in-db")
+
+		creatingContainer, err = w.dbWorker.CreateContainer(owner, metadata)
+		if err != nil {
+			logger.Error("failed-to-create-container-in-db", err)
+			if _, ok := err.(db.ContainerOwnerDisappearedError); ok {
+				return nil, worker.ResourceConfigCheckSessionExpiredError
+			}
+
+			return nil, err
+		}
+	}
+
+	handle := creatingContainer.Handle()
+	logger = logger.WithData(lager.Data{"container": handle})
+
+	pod, found, err := w.findPod(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		pod, err = w.createPod(logger, handle, metadata, containerSpec, resourceTypes)
+		if err != nil {
+			w.failContainer(logger, creatingContainer)
+			return nil, err
+		}
+	}
+
+	pod, err = w.waitForPod(ctx, pod)
+	if err != nil {
+		logger.Error("failed-to-start-pod", err)
+		w.failContainer(logger, creatingContainer)
+		return nil, err
+	}
+
+	err = w.streamInputs(ctx, logger, pod, containerSpec)
+	if err != nil {
+		logger.Error("failed-to-stream-inputs", err)
+		w.failContainer(logger, creatingContainer)
+		return nil, err
+	}
+
+	metric.ContainersCreated.Inc()
+
+	createdContainer, err = creatingContainer.Created()
+	if err != nil {
+		logger.Error("failed-to-mark-container-as-created", err)
+
+		_ = w.pods().Delete(handle, &metav1.DeleteOptions{})
+
+		return nil, err
+	}
+
+	logger.Debug("created-container-in-db")
+
+	return w.newContainer(pod, createdContainer), nil
+}
+
+// failContainer marks the container as failed and deletes its pod, if it was
+// created, so that neither is left behind.
+func (w *kubernetesWorker) failContainer(logger lager.Logger, creatingContainer db.CreatingContainer) {
+	_, err := creatingContainer.Failed()
+	if err != nil {
+		logger.Error("failed-to-mark-container-as-failed", err)
+	}
+
+	err = w.pods().Delete(creatingContainer.Handle(), &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		logger.Error("failed-to-delete-pod", err)
+	}
+
+	metric.FailedContainers.Inc()
+}
+
+func (w *kubernetesWorker) createPod(
+	logger lager.Logger,
+	handle string,
+	metadata db.ContainerMetadata,
+	containerSpec worker.ContainerSpec,
+	resourceTypes atc.VersionedResourceTypes,
+) (*corev1.Pod, error) {
+	image, err := imageFor(containerSpec.ImageSpec, w.dbWorker.ResourceTypes(), resourceTypes)
+	if err != nil {
+		logger.Error("failed-to-determine-image", err)
+		return nil, err
+	}
+
+	logger.Debug("creating-pod", lager.Data{"image": image})
+
+	pod, err := w.pods().Create(newPod(handle, w.Name(), image, w.streamingImage, metadata, containerSpec))
+	if err != nil {
+		logger.Error("failed-to-create-pod", err)
+		return nil, err
+	}
+
+	return pod, nil
+}
+
+// waitForPod waits for the pod to be scheduled and for its containers to
+// start, failing if the pod terminates or does not start in time.
+func (w *kubernetesWorker) waitForPod(ctx context.Context, pod *corev1.Pod) (*corev1.Pod, error) {
+	if pod.Status.Phase == corev1.PodRunning {
+		return pod, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.podStartTimeout)
+	defer cancel()
+
+	err := wait.PollImmediateUntil(time.Second, func() (bool, error) {
+		var err error
+		pod, err = w.pods().Get(pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			return true, nil
+		case corev1.PodFailed, corev1.PodSucceeded:
+			return false, PodFailedError{Pod: pod.Name, Reason: pod.Status.Message}
+		default:
+			return false, nil
+		}
+	}, ctx.Done())
+	if err == wait.ErrWaitTimeout {
+		return nil, PodStartTimeoutError{Pod: pod.Name, Timeout: w.podStartTimeout}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pod, nil
+}
+
+// streamInputs streams each input into the pod's volume at its destination
+// path. Inputs are always streamed, as volumes cannot be shared between pods.
+func (w *kubernetesWorker) streamInputs(ctx context.Context, logger lager.Logger, pod *corev1.Pod, containerSpec worker.ContainerSpec) error {
+	volumes := map[string]worker.Volume{}
+	for _, mount := range w.newContainer(pod, nil).VolumeMounts() {
+		volumes[filepath.Clean(mount.MountPath)] = mount.Volume
+	}
+
+	for _, input := range containerSpec.Inputs {
+		volume, found := volumes[filepath.Clean(input.DestinationPath())]
+		if !found {
+			return fmt.Errorf("no volume mounted at %s", input.DestinationPath())
+		}
+
+		err := input.Source().StreamTo(ctx, logger.Session("stream-input"), volume)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *kubernetesWorker) FindContainerByHandle(logger lager.Logger, teamID int, handle string) (worker.Container, bool, error) {
+	pod, found, err := w.findPod(handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-pod", err)
+		return nil, false, err
+	}
+
+	if !found {
+		logger.Info("container-not-found")
+		return nil, false, nil
+	}
+
+	createdContainer, found, err := w.dbTeamFactory.GetByID(teamID).FindCreatedContainerByHandle(handle)
+	if err != nil {
+		logger.Error("failed-to-lookup-in-db", err)
+		return nil, false, err
+	}
+
+	if !found {
+		return nil, false, nil
+	}
+
+	return w.newContainer(pod, createdContainer), true, nil
+}
+
+func (w *kubernetesWorker) LookupVolume(logger lager.Logger, handle string) (worker.Volume, bool, error) {
+	podName, volumeName, ok := parseVolumeHandle(handle)
+	if !ok {
+		return nil, false, nil
+	}
+
+	pod, found, err := w.findPod(podName)
+	if err != nil || !found {
+		return nil, false, err
+	}
+
+	for _, mount := range w.newContainer(pod, nil).VolumeMounts() {
+		if mount.Volume.Handle() == volumeHandle(podName, volumeName) {
+			return mount.Volume, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+func (w *kubernetesWorker) CreateVolume(logger lager.Logger, spec worker.VolumeSpec, teamID int, volumeType db.VolumeType) (worker.Volume, error) {
+	return nil, ErrCreateVolumeNotSupported
+}
+
+// FindVolumeForResourceCache never finds a volume, as volumes do not outlive
+// their pod.
+func (w *kubernetesWorker) FindVolumeForResourceCache(logger lager.Logger, resourceCache db.UsedResourceCache) (worker.Volume, bool, error) {
+	return nil, false, nil
+}
+
+// FindVolumeForTaskCache never finds a volume, as volumes do not outlive their
+// pod.
+func (w *kubernetesWorker) FindVolumeForTaskCache(logger lager.Logger, teamID int, jobID int, stepName string, path string) (worker.Volume, bool, error) {
+	return nil, false, nil
+}
+
+// CertsVolume never finds a volume; images are expected to provide their own
+// certificates.
+func (w *kubernetesWorker) CertsVolume(logger lager.Logger) (worker.Volume, bool, error) {
+	return nil, false, nil
+}
+
+func (w *kubernetesWorker) findPod(name string) (*corev1.Pod, bool, error) {
+	pod, err := w.pods().Get(name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return pod, true, nil
+}
+
+func (w *kubernetesWorker) newContainer(pod *corev1.Pod, dbContainer db.CreatedContainer) worker.Container {
+	return newContainer(w.clientset, w.executor, w.namespace, pod, dbContainer, w.Name())
+}
+
+func (w *kubernetesWorker) pods() typedcorev1.PodInterface {
+	return w.clientset.CoreV1().Pods(w.namespace)
+}
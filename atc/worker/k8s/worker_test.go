@@ -0,0 +1,527 @@
+package k8s_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/atc/worker/k8s"
+	"github.com/concourse/concourse/atc/worker/k8s/k8sfakes"
+	"github.com/concourse/concourse/atc/worker/workerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var _ = Describe("Kubernetes Worker", func() {
+	var (
+		logger        *lagertest.TestLogger
+		fakeClientset *fake.Clientset
+		fakeExecutor  *k8sfakes.FakeExecutor
+		fakeDBWorker  *dbfakes.FakeWorker
+		fakeTeam      *dbfakes.FakeTeam
+
+		fakeCreatingContainer *dbfakes.FakeCreatingContainer
+		fakeCreatedContainer  *dbfakes.FakeCreatedContainer
+		fakeInputSource       *workerfakes.FakeInputSource
+		fakeArtifactSource    *workerfakes.FakeArtifactSource
+
+		podPhase      corev1.PodPhase
+		resourceTypes atc.VersionedResourceTypes
+		containerSpec worker.ContainerSpec
+
+		kubernetesWorker worker.Worker
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		podPhase = corev1.PodRunning
+		fakeClientset = fake.NewSimpleClientset()
+		fakeClientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+			pod.Status.Phase = podPhase
+			return false, nil, nil
+		})
+
+		fakeExecutor = new(k8sfakes.FakeExecutor)
+
+		fakeDBWorker = new(dbfakes.FakeWorker)
+		fakeDBWorker.NameReturns("some-worker")
+		fakeDBWorker.PlatformReturns("linux")
+		fakeDBWorker.ResourceTypesReturns([]atc.WorkerResourceType{
+			{Type: "git", Image: "concourse/git-resource"},
+		})
+
+		fakeCreatingContainer = new(dbfakes.FakeCreatingContainer)
+		fakeCreatingContainer.HandleReturns("some-handle")
+		fakeDBWorker.CreateContainerReturns(fakeCreatingContainer, nil)
+
+		fakeCreatedContainer = new(dbfakes.FakeCreatedContainer)
+		fakeCreatedContainer.HandleReturns("some-handle")
+		fakeCreatingContainer.CreatedReturns(fakeCreatedContainer, nil)
+
+		fakeTeam = new(dbfakes.FakeTeam)
+		fakeTeamFactory := new(dbfakes.FakeTeamFactory)
+		fakeTeamFactory.GetByIDReturns(fakeTeam)
+
+		fakeArtifactSource = new(workerfakes.FakeArtifactSource)
+		fakeInputSource = new(workerfakes.FakeInputSource)
+		fakeInputSource.SourceReturns(fakeArtifactSource)
+		fakeInputSource.DestinationPathReturns("/tmp/build/some-dir/some-input")
+
+		cpu := uint64(512)
+		memory := uint64(1024 * 1024 * 1024)
+
+		resourceTypes = atc.VersionedResourceTypes{}
+		containerSpec = worker.ContainerSpec{
+			TeamID: 1,
+			ImageSpec: worker.ImageSpec{
+				ImageURL: "docker:///some/image#some-tag",
+			},
+			Env:    []string{"SOME=env", "OTHER=env=with=equals"},
+			Dir:    "/tmp/build/some-dir",
+			Inputs: []worker.InputSource{fakeInputSource},
+			Outputs: worker.OutputPaths{
+				"some-output": "/tmp/build/some-dir/some-output/",
+			},
+			Limits: worker.ContainerLimits{
+				CPU:    &cpu,
+				Memory: &memory,
+			},
+			User: "1000",
+		}
+
+		kubernetesWorker = k8s.NewRuntime(
+			fakeClientset,
+			fakeExecutor,
+			fakeTeamFactory,
+			k8s.Config{
+				Namespace:       "some-namespace",
+				StreamingImage:  "some-streaming-image",
+				PodStartTimeout: time.Second,
+			},
+		).NewWorker(logger, fakeDBWorker, 0)
+	})
+
+	getPod := func() *corev1.Pod {
+		pod, err := fakeClientset.CoreV1().Pods("some-namespace").Get("some-handle", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		return pod
+	}
+
+	Describe("FindOrCreateContainer", func() {
+		var (
+			container worker.Container
+			createErr error
+		)
+
+		itFailsTheContainer := func() {
+			It("marks the container as failed", func() {
+				Expect(fakeCreatingContainer.FailedCallCount()).To(Equal(1))
+			})
+
+			It("deletes the pod", func() {
+				_, err := fakeClientset.CoreV1().Pods("some-namespace").Get("some-handle", metav1.GetOptions{})
+				Expect(err).To(HaveOccurred())
+			})
+		}
+
+		JustBeforeEach(func() {
+			container, createErr = kubernetesWorker.FindOrCreateContainer(
+				context.Background(),
+				logger,
+				new(workerfakes.FakeImageFetchingDelegate),
+				db.NewBuildStepContainerOwner(1, atc.PlanID("some-plan"), 1),
+				db.ContainerMetadata{Type: db.ContainerTypeTask},
+				containerSpec,
+				resourceTypes,
+			)
+		})
+
+		Context("when the container does not exist", func() {
+			It("creates a pod for the container in the namespace", func() {
+				Expect(createErr).ToNot(HaveOccurred())
+
+				pod := getPod()
+				Expect(pod.Labels).To(HaveKeyWithValue("concourse-ci.org/handle", "some-handle"))
+				Expect(pod.Labels).To(HaveKeyWithValue("concourse-ci.org/type", "task"))
+				Expect(pod.Spec.RestartPolicy).To(Equal(corev1.RestartPolicyNever))
+				Expect(pod.Spec.Containers).To(HaveLen(2))
+			})
+
+			It("runs the image in the main container", func() {
+				main := getPod().Spec.Containers[0]
+				Expect(main.Name).To(Equal("main"))
+				Expect(main.Image).To(Equal("some/image:some-tag"))
+				Expect(main.WorkingDir).To(Equal("/tmp/build/some-dir"))
+				Expect(main.Env).To(Equal([]corev1.EnvVar{
+					{Name: "SOME", Value: "env"},
+					{Name: "OTHER", Value: "env=with=equals"},
+				}))
+				Expect(*main.SecurityContext.Privileged).To(BeFalse())
+				Expect(*main.SecurityContext.RunAsUser).To(Equal(int64(1000)))
+			})
+
+			It("converts the limits", func() {
+				limits := getPod().Spec.Containers[0].Resources.Limits
+				Expect(limits.Cpu().Cmp(resource.MustParse("500m"))).To(Equal(0))
+				Expect(limits.Memory().Cmp(resource.MustParse("1Gi"))).To(Equal(0))
+			})
+
+			It("mounts an emptyDir for each volume in both the main container and the streaming sidecar", func() {
+				pod := getPod()
+				Expect(pod.Spec.Volumes).To(HaveLen(4))
+				for _, volume := range pod.Spec.Volumes {
+					Expect(volume.EmptyDir).ToNot(BeNil())
+				}
+
+				Expect(pod.Spec.Containers[0].VolumeMounts).To(Equal([]corev1.VolumeMount{
+					{Name: "volume-0", MountPath: "/scratch"},
+					{Name: "volume-1", MountPath: "/tmp/build/some-dir"},
+					{Name: "volume-2", MountPath: "/tmp/build/some-dir/some-input"},
+					{Name: "volume-3", MountPath: "/tmp/build/some-dir/some-output"},
+				}))
+
+				sidecar := pod.Spec.Containers[1]
+				Expect(sidecar.Name).To(Equal("stream"))
+				Expect(sidecar.Image).To(Equal("some-streaming-image"))
+				Expect(sidecar.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+					Name:      "volume-2",
+					MountPath: "/concourse/volumes/volume-2",
+				}))
+			})
+
+			It("streams the inputs into their volumes", func() {
+				Expect(fakeArtifactSource.StreamToCallCount()).To(Equal(1))
+				_, _, dest := fakeArtifactSource.StreamToArgsForCall(0)
+
+				volume := dest.(worker.Volume)
+				Expect(volume.Handle()).To(Equal("some-handle/volume-2"))
+				Expect(volume.Path()).To(Equal("/tmp/build/some-dir/some-input"))
+				Expect(volume.WorkerName()).To(Equal("some-worker"))
+			})
+
+			It("marks the container as created", func() {
+				Expect(fakeCreatingContainer.CreatedCallCount()).To(Equal(1))
+			})
+
+			It("returns a container with the volumes mounted", func() {
+				Expect(container.Handle()).To(Equal("some-handle"))
+				Expect(container.WorkerName()).To(Equal("some-worker"))
+
+				mountPaths := []string{}
+				for _, mount := range container.VolumeMounts() {
+					mountPaths = append(mountPaths, mount.MountPath)
+				}
+
+				Expect(mountPaths).To(ContainElement("/tmp/build/some-dir/some-output"))
+			})
+
+			Context("when the pod fails to start", func() {
+				BeforeEach(func() {
+					podPhase = corev1.PodFailed
+				})
+
+				It("returns an error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(k8s.PodFailedError{}))
+				})
+
+				It("does not mark the container as created", func() {
+					Expect(fakeCreatingContainer.CreatedCallCount()).To(BeZero())
+				})
+
+				itFailsTheContainer()
+			})
+
+			Context("when the pod does not start in time", func() {
+				BeforeEach(func() {
+					podPhase = corev1.PodPending
+				})
+
+				It("returns an error", func() {
+					Expect(createErr).To(Equal(k8s.PodStartTimeoutError{Pod: "some-handle", Timeout: time.Second}))
+				})
+
+				itFailsTheContainer()
+			})
+
+			Context("when streaming an input fails", func() {
+				BeforeEach(func() {
+					fakeArtifactSource.StreamToReturns(errors.New("nope"))
+				})
+
+				It("returns the error", func() {
+					Expect(createErr).To(MatchError("nope"))
+				})
+
+				itFailsTheContainer()
+			})
+
+			Context("when the image cannot be run by kubernetes", func() {
+				BeforeEach(func() {
+					containerSpec.ImageSpec = worker.ImageSpec{
+						ImageArtifactSource: new(workerfakes.FakeArtifactSource),
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(createErr).To(Equal(k8s.ErrImageArtifactNotSupported))
+				})
+
+				It("marks the container as failed", func() {
+					Expect(fakeCreatingContainer.FailedCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when the container has already been created", func() {
+			BeforeEach(func() {
+				fakeDBWorker.FindContainerReturns(nil, fakeCreatedContainer, nil)
+			})
+
+			Context("when the pod exists", func() {
+				BeforeEach(func() {
+					_, err := fakeClientset.CoreV1().Pods("some-namespace").Create(&corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "some-handle"},
+					})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("returns the container without creating it", func() {
+					Expect(createErr).ToNot(HaveOccurred())
+					Expect(container.Handle()).To(Equal("some-handle"))
+					Expect(fakeDBWorker.CreateContainerCallCount()).To(BeZero())
+					Expect(fakeArtifactSource.StreamToCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the pod does not exist", func() {
+				It("returns an error", func() {
+					Expect(createErr).To(HaveOccurred())
+				})
+			})
+		})
+	})
+
+	DescribeTable("mapping images",
+		func(imageSpec worker.ImageSpec, resourceTypes atc.VersionedResourceTypes, expectedImage string) {
+			containerSpec.ImageSpec = imageSpec
+
+			_, err := kubernetesWorker.FindOrCreateContainer(
+				context.Background(),
+				logger,
+				new(workerfakes.FakeImageFetchingDelegate),
+				db.NewBuildStepContainerOwner(1, atc.PlanID("some-plan"), 1),
+				db.ContainerMetadata{},
+				containerSpec,
+				resourceTypes,
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(getPod().Spec.Containers[0].Image).To(Equal(expectedImage))
+		},
+		Entry("image URL without a tag",
+			worker.ImageSpec{ImageURL: "docker:///busybox"},
+			nil,
+			"busybox",
+		),
+		Entry("image URL with a registry",
+			worker.ImageSpec{ImageURL: "docker://registry:5000/some/image#some-tag"},
+			nil,
+			"registry:5000/some/image:some-tag",
+		),
+		Entry("registry-image resource",
+			worker.ImageSpec{ImageResource: &worker.ImageResource{
+				Type:   "registry-image",
+				Source: atc.Source{"repository": "some/image"},
+			}},
+			nil,
+			"some/image:latest",
+		),
+		Entry("docker-image resource with a digest",
+			worker.ImageSpec{ImageResource: &worker.ImageResource{
+				Type:    "docker-image",
+				Source:  atc.Source{"repository": "some/image", "tag": "some-tag"},
+				Version: &atc.Version{"digest": "sha256:some-digest"},
+			}},
+			nil,
+			"some/image@sha256:some-digest",
+		),
+		Entry("base resource type",
+			worker.ImageSpec{ResourceType: "git"},
+			nil,
+			"concourse/git-resource",
+		),
+		Entry("custom resource type",
+			worker.ImageSpec{ResourceType: "some-custom-type"},
+			atc.VersionedResourceTypes{{
+				ResourceType: atc.ResourceType{
+					Name:   "some-custom-type",
+					Type:   "registry-image",
+					Source: atc.Source{"repository": "some/resource", "tag": "1.0"},
+				},
+			}},
+			"some/resource:1.0",
+		),
+	)
+
+	Describe("LookupVolume", func() {
+		BeforeEach(func() {
+			_, err := fakeClientset.CoreV1().Pods("some-namespace").Create(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-handle"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "main",
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "volume-0", MountPath: "/some/path"},
+						},
+					}},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("finds volumes mounted in the pod", func() {
+			volume, found, err := kubernetesWorker.LookupVolume(logger, "some-handle/volume-0")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(volume.Path()).To(Equal("/some/path"))
+		})
+
+		It("does not find other volumes", func() {
+			_, found, err := kubernetesWorker.LookupVolume(logger, "some-handle/volume-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, found, err = kubernetesWorker.LookupVolume(logger, "some-other-handle/volume-0")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Describe("streaming out of the volume", func() {
+			It("tars up the path in the streaming sidecar", func() {
+				fakeExecutor.ExecStub = func(ctx context.Context, pod string, container string, command []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) (int, error) {
+					_, err := stdout.Write([]byte("some-tar"))
+					return 0, err
+				}
+
+				volume, _, err := kubernetesWorker.LookupVolume(logger, "some-handle/volume-0")
+				Expect(err).ToNot(HaveOccurred())
+
+				out, err := volume.StreamOut(context.Background(), "some/file")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = ioutil.ReadAll(out)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeExecutor.ExecCallCount()).To(Equal(1))
+				_, pod, container, command, _, _, _ := fakeExecutor.ExecArgsForCall(0)
+				Expect(pod).To(Equal("some-handle"))
+				Expect(container).To(Equal("stream"))
+				Expect(command[len(command)-1]).To(Equal("/concourse/volumes/volume-0/some/file"))
+			})
+
+			It("fails when tar fails", func() {
+				fakeExecutor.ExecReturns(1, nil)
+
+				volume, _, err := kubernetesWorker.LookupVolume(logger, "some-handle/volume-0")
+				Expect(err).ToNot(HaveOccurred())
+
+				out, err := volume.StreamOut(context.Background(), ".")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = ioutil.ReadAll(out)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("running processes", func() {
+		var container worker.Container
+
+		BeforeEach(func() {
+			fakeDBWorker.FindContainerReturns(nil, fakeCreatedContainer, nil)
+
+			_, err := fakeClientset.CoreV1().Pods("some-namespace").Create(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-handle"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			container, err = kubernetesWorker.FindOrCreateContainer(
+				context.Background(),
+				logger,
+				new(workerfakes.FakeImageFetchingDelegate),
+				db.NewBuildStepContainerOwner(1, atc.PlanID("some-plan"), 1),
+				db.ContainerMetadata{},
+				containerSpec,
+				resourceTypes,
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			fakeExecutor.ExecReturns(42, nil)
+		})
+
+		It("executes the process in the main container and returns its exit status", func() {
+			process, err := container.Run(context.Background(), garden.ProcessSpec{
+				ID:   "some-id",
+				Path: "some-path",
+				Args: []string{"some", "args"},
+				Dir:  "/some/dir",
+			}, garden.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(process.ID()).To(Equal("some-id"))
+
+			status, err := process.Wait()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status).To(Equal(42))
+
+			_, pod, c, command, _, _, _ := fakeExecutor.ExecArgsForCall(0)
+			Expect(pod).To(Equal("some-handle"))
+			Expect(c).To(Equal("main"))
+			Expect(command[3:]).To(Equal([]string{"/some/dir", "some-path", "some", "args"}))
+		})
+
+		It("cannot attach to a process, killing any left over so that it can be run again", func() {
+			_, err := container.Attach(context.Background(), "some-id", garden.ProcessIO{})
+			Expect(err).To(Equal(garden.ProcessNotFoundError{ProcessID: "some-id"}))
+
+			Expect(fakeExecutor.ExecCallCount()).To(Equal(1))
+			_, pod, c, command, _, _, _ := fakeExecutor.ExecArgsForCall(0)
+			Expect(pod).To(Equal("some-handle"))
+			Expect(c).To(Equal("main"))
+			Expect(command[len(command)-1]).To(Equal("KILL"))
+		})
+
+		It("stores properties on the pod", func() {
+			Expect(container.SetProperty("some-property", "some-value")).To(Succeed())
+
+			value, err := container.Property("some-property")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal("some-value"))
+
+			Expect(getPod().Annotations).To(HaveKeyWithValue("concourse-ci.org/properties", `{"some-property":"some-value"}`))
+		})
+
+		It("deletes the pod when destroyed", func() {
+			Expect(container.Destroy()).To(Succeed())
+
+			_, err := fakeClientset.CoreV1().Pods("some-namespace").Get("some-handle", metav1.GetOptions{})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,15 @@
+package worker
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+//go:generate counterfeiter . Runtime
+
+// Runtime constructs Workers for workers which do not run containers via
+// Garden and Baggageclaim, keyed by the runtime the worker registered with
+// (see atc.Worker.Runtime).
+type Runtime interface {
+	NewWorker(logger lager.Logger, dbWorker db.Worker, buildContainers int) Worker
+}
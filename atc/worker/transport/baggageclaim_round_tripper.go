@@ -3,6 +3,8 @@ package transport
 import (
 	"net/http"
 	"net/url"
+
+	"github.com/concourse/concourse/tracing"
 )
 
 type baggageclaimRoundTripper struct {
@@ -54,7 +56,9 @@ func (c *baggageclaimRoundTripper) RoundTrip(request *http.Request) (*http.Respo
 	updatedRequest := *request
 	updatedRequest.URL = &updatedURL
 
-	response, err := c.innerRoundTripper.RoundTrip(&updatedRequest)
+	response, err := tracedRoundTrip(c.innerRoundTripper, &updatedRequest, tracing.Attrs{
+		"worker": c.workerName,
+	})
 	if err != nil {
 		c.cachedBaggageclaimURL = nil
 	}
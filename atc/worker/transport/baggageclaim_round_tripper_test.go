@@ -12,8 +12,11 @@ import (
 	"github.com/concourse/retryhttp/retryhttpfakes"
 
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/tracing"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/api/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 var _ = Describe("BaggageclaimRoundTripper #RoundTrip", func() {
@@ -58,6 +61,28 @@ var _ = Describe("BaggageclaimRoundTripper #RoundTrip", func() {
 		Expect(actualRequest.URL.Path).To(Equal("/something"))
 	})
 
+	Context("when tracing is configured", func() {
+		BeforeEach(func() {
+			provider, err := sdktrace.NewProvider()
+			Expect(err).ToNot(HaveOccurred())
+
+			tracing.ConfigureTraceProvider(provider)
+		})
+
+		AfterEach(func() {
+			tracing.ConfigureTraceProvider(trace.NoopProvider{})
+		})
+
+		It("propagates the trace context in the request headers", func() {
+			actualRequest := fakeRoundTripper.RoundTripArgsForCall(0)
+			Expect(actualRequest.Header.Get("traceparent")).To(MatchRegexp("^00-[0-9a-f]{32}-[0-9a-f]{16}-01$"))
+		})
+
+		It("does not modify the original request's headers", func() {
+			Expect(request.Header).To(BeEmpty())
+		})
+	})
+
 	It("reuses the request cached host on subsequent calls", func() {
 		Expect(fakeDB.GetWorkerCallCount()).To(Equal(0))
 		_, err := roundTripper.RoundTrip(&request)
@@ -0,0 +1,54 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/concourse/concourse/tracing"
+	"go.opentelemetry.io/otel/api/trace"
+)
+
+// tracedRoundTrip performs the request within a span, propagating the span
+// context to the worker through the request headers. The span ends once the
+// response body is closed so that it covers the time spent streaming.
+func tracedRoundTrip(roundTripper http.RoundTripper, request *http.Request, attrs tracing.Attrs) (*http.Response, error) {
+	attrs["method"] = request.Method
+	attrs["path"] = request.URL.Path
+
+	ctx, span := tracing.StartSpan(request.Context(), "worker.transport", attrs)
+
+	tracedRequest := request.WithContext(ctx)
+	tracedRequest.Header = request.Header.Clone()
+	if tracedRequest.Header == nil {
+		tracedRequest.Header = http.Header{}
+	}
+
+	tracing.Inject(ctx, tracedRequest.Header)
+
+	response, err := roundTripper.RoundTrip(tracedRequest)
+	if err != nil {
+		tracing.End(span, err)
+		return nil, err
+	}
+
+	if response.Body == nil {
+		tracing.End(span, nil)
+		return response, nil
+	}
+
+	response.Body = spanEndingBody{ReadCloser: response.Body, span: span}
+
+	return response, nil
+}
+
+type spanEndingBody struct {
+	io.ReadCloser
+
+	span trace.Span
+}
+
+func (body spanEndingBody) Close() error {
+	err := body.ReadCloser.Close()
+	tracing.End(body.span, nil)
+	return err
+}
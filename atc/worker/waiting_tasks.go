@@ -0,0 +1,60 @@
+package worker
+
+import "sync"
+
+// waitingTasks tracks the tasks on this ATC which are waiting for a worker to
+// free up with the limit-active-tasks placement strategy, so that a
+// lower-priority task doesn't take a free worker from a higher-priority one
+// which could run on it.
+type waitingTasks struct {
+	lock  sync.Mutex
+	tasks map[*waitingTask]struct{}
+}
+
+type waitingTask struct {
+	priority   int
+	workerSpec WorkerSpec
+}
+
+func newWaitingTasks() *waitingTasks {
+	return &waitingTasks{
+		tasks: map[*waitingTask]struct{}{},
+	}
+}
+
+func (tasks *waitingTasks) add(priority int, workerSpec WorkerSpec) *waitingTask {
+	tasks.lock.Lock()
+	defer tasks.lock.Unlock()
+
+	task := &waitingTask{
+		priority:   priority,
+		workerSpec: workerSpec,
+	}
+
+	tasks.tasks[task] = struct{}{}
+
+	return task
+}
+
+func (tasks *waitingTasks) remove(task *waitingTask) {
+	tasks.lock.Lock()
+	defer tasks.lock.Unlock()
+
+	delete(tasks.tasks, task)
+}
+
+// outrank returns whether any task with a higher priority than the given one
+// is waiting for a worker satisfying the given predicate, i.e. one which it
+// could run on.
+func (tasks *waitingTasks) outrank(priority int, satisfies func(WorkerSpec) bool) bool {
+	tasks.lock.Lock()
+	defer tasks.lock.Unlock()
+
+	for task := range tasks.tasks {
+		if task.priority > priority && satisfies(task.workerSpec) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,71 @@
+package worker
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("waitingTasks", func() {
+	var tasks *waitingTasks
+
+	anyWorker := func(WorkerSpec) bool { return true }
+
+	BeforeEach(func() {
+		tasks = newWaitingTasks()
+	})
+
+	It("is not outranked when no tasks are waiting", func() {
+		Expect(tasks.outrank(0, anyWorker)).To(BeFalse())
+	})
+
+	Context("when a task is waiting", func() {
+		var task *waitingTask
+
+		BeforeEach(func() {
+			task = tasks.add(10, WorkerSpec{Platform: "linux", Tags: []string{"gpu"}})
+		})
+
+		It("outranks lower-priority tasks", func() {
+			Expect(tasks.outrank(0, anyWorker)).To(BeTrue())
+			Expect(tasks.outrank(-5, anyWorker)).To(BeTrue())
+		})
+
+		It("does not outrank tasks with the same or a higher priority", func() {
+			Expect(tasks.outrank(10, anyWorker)).To(BeFalse())
+			Expect(tasks.outrank(20, anyWorker)).To(BeFalse())
+		})
+
+		It("only outranks tasks for workers it could run on", func() {
+			Expect(tasks.outrank(0, func(spec WorkerSpec) bool {
+				Expect(spec.Tags).To(Equal([]string{"gpu"}))
+				return false
+			})).To(BeFalse())
+		})
+
+		Context("when it is removed", func() {
+			BeforeEach(func() {
+				tasks.remove(task)
+			})
+
+			It("no longer outranks any task", func() {
+				Expect(tasks.outrank(0, anyWorker)).To(BeFalse())
+			})
+		})
+
+		Context("when another task with the same priority is waiting", func() {
+			var otherTask *waitingTask
+
+			BeforeEach(func() {
+				otherTask = tasks.add(10, WorkerSpec{Platform: "linux"})
+				tasks.remove(task)
+			})
+
+			It("still outranks lower-priority tasks until both are removed", func() {
+				Expect(tasks.outrank(0, anyWorker)).To(BeTrue())
+
+				tasks.remove(otherTask)
+				Expect(tasks.outrank(0, anyWorker)).To(BeFalse())
+			})
+		})
+	})
+})
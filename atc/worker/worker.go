@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/worker/gclient"
+	"github.com/concourse/concourse/tracing"
 	"golang.org/x/sync/errgroup"
 
 	"code.cloudfoundry.org/garden"
@@ -181,6 +183,11 @@ func (worker *gardenWorker) FindOrCreateContainer(
 	containerSpec ContainerSpec,
 	resourceTypes atc.VersionedResourceTypes,
 ) (Container, error) {
+	ctx, span := tracing.StartSpan(ctx, "worker.find-or-create-container", tracing.Attrs{
+		"worker": worker.Name(),
+		"team":   strconv.Itoa(containerSpec.TeamID),
+	})
+	defer span.End()
 
 	var (
 		gardenContainer   gclient.Container
@@ -343,6 +350,11 @@ func (worker *gardenWorker) fetchImageForContainer(
 	resourceTypes atc.VersionedResourceTypes,
 	creatingContainer db.CreatingContainer,
 ) (FetchedImage, error) {
+	ctx, span := tracing.StartSpan(ctx, "worker.fetch-image", tracing.Attrs{
+		"worker": worker.Name(),
+	})
+	defer span.End()
+
 	image, err := worker.imageFactory.GetImage(
 		logger,
 		worker,
@@ -382,6 +394,11 @@ func (worker *gardenWorker) createVolumes(
 	creatingContainer db.CreatingContainer,
 	spec ContainerSpec,
 ) ([]VolumeMount, error) {
+	ctx, span := tracing.StartSpan(ctx, "worker.create-volumes", tracing.Attrs{
+		"worker": worker.Name(),
+	})
+	defer span.End()
+
 	var volumeMounts []VolumeMount
 	var ioVolumeMounts []VolumeMount
 
@@ -583,7 +600,14 @@ func (worker *gardenWorker) cloneRemoteVolumes(
 		}
 
 		g.Go(func() error {
-			err = nonLocalInput.desiredArtifact.StreamTo(groupCtx, logger.Session("stream-to", destData), inputVolume)
+			ctx, span := tracing.StartSpan(groupCtx, "worker.stream-volume", tracing.Attrs{
+				"dest-volume": inputVolume.Handle(),
+				"dest-worker": inputVolume.WorkerName(),
+				"mount-path":  nonLocalInput.desiredMountPath,
+			})
+
+			err := nonLocalInput.desiredArtifact.StreamTo(ctx, logger.Session("stream-to", destData), inputVolume)
+			tracing.End(span, err)
 			if err != nil {
 				return err
 			}
@@ -654,8 +678,15 @@ func (worker *gardenWorker) BuildContainers() int {
 }
 
 func (worker *gardenWorker) Satisfies(logger lager.Logger, spec WorkerSpec) bool {
-	workerTeamID := worker.dbWorker.TeamID()
-	workerResourceTypes := worker.dbWorker.ResourceTypes()
+	return SatisfiesSpec(worker.dbWorker, spec)
+}
+
+// SatisfiesSpec determines whether the given worker can run containers
+// matching the WorkerSpec, based on its team, resource types, platform and
+// tags. It is shared by each Runtime's Worker implementation.
+func SatisfiesSpec(dbWorker db.Worker, spec WorkerSpec) bool {
+	workerTeamID := dbWorker.TeamID()
+	workerResourceTypes := dbWorker.ResourceTypes()
 
 	if spec.TeamID != workerTeamID && workerTeamID != 0 {
 		return false
@@ -678,12 +709,12 @@ func (worker *gardenWorker) Satisfies(logger lager.Logger, spec WorkerSpec) bool
 	}
 
 	if spec.Platform != "" {
-		if spec.Platform != worker.dbWorker.Platform() {
+		if spec.Platform != dbWorker.Platform() {
 			return false
 		}
 	}
 
-	if !worker.tagsMatch(spec.Tags) {
+	if !tagsMatch(dbWorker.Tags(), spec.Tags) {
 		return false
 	}
 
@@ -706,11 +737,17 @@ func determineUnderlyingTypeName(typeName string, resourceTypes atc.VersionedRes
 }
 
 func (worker *gardenWorker) Description() string {
+	return DescribeWorker(worker.dbWorker)
+}
+
+// DescribeWorker summarizes the attributes of the given worker which are
+// considered by SatisfiesSpec.
+func DescribeWorker(dbWorker db.Worker) string {
 	messages := []string{
-		fmt.Sprintf("platform '%s'", worker.dbWorker.Platform()),
+		fmt.Sprintf("platform '%s'", dbWorker.Platform()),
 	}
 
-	for _, tag := range worker.dbWorker.Tags() {
+	for _, tag := range dbWorker.Tags() {
 		messages = append(messages, fmt.Sprintf("tag '%s'", tag))
 	}
 
@@ -725,8 +762,7 @@ func (worker *gardenWorker) Uptime() time.Duration {
 	return time.Since(worker.dbWorker.StartTime())
 }
 
-func (worker *gardenWorker) tagsMatch(tags []string) bool {
-	workerTags := worker.dbWorker.Tags()
+func tagsMatch(workerTags []string, tags []string) bool {
 	if len(workerTags) > 0 && len(tags) == 0 {
 		return false
 	}
@@ -59,6 +59,16 @@ var _ = Describe("Worker", func() {
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("missing garden address"))
 			})
+
+			Context("when the worker does not use the Garden runtime", func() {
+				BeforeEach(func() {
+					worker.Runtime = atc.WorkerRuntimeKubernetes
+				})
+
+				It("returns no errors", func() {
+					Expect(worker.Validate()).To(Succeed())
+				})
+			})
 		})
 	})
 })
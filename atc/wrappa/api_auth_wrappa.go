@@ -45,11 +45,17 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetBuildPreparation,
 			atc.BuildEvents,
 			atc.GetBuildPlan,
-			atc.ListBuildArtifacts:
+			atc.ListBuildArtifacts,
+			atc.ListArchivedArtifacts,
+			atc.GetArchivedArtifact,
+			atc.ListBuildTestResults,
+			atc.ListBuildApprovals:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 			// resource belongs to authorized team
-		case atc.AbortBuild:
+		case atc.AbortBuild,
+			atc.ApproveBuild,
+			atc.RejectBuild:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
@@ -70,6 +76,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListJobs,
 			atc.GetJob,
 			atc.ListJobBuilds,
+			atc.ListJobTestHistory,
+			atc.GetJobStats,
 			atc.ListPipelineBuilds,
 			atc.GetResource,
 			atc.ListBuildsWithVersionAsInput,
@@ -95,7 +103,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListTeamBuilds,
 			atc.RenameTeam,
 			atc.DestroyTeam,
-			atc.ListVolumes:
+			atc.ListVolumes,
+			atc.GetInfoRoles:
 			newHandler = auth.CheckAuthenticationHandler(handler, rejector)
 
 		// unauthenticated / delegating to handler (validate token if provided)
@@ -109,11 +118,14 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ListAllJobs,
 			atc.ListAllResources,
 			atc.ListBuilds,
-			atc.MainJobBadge:
+			atc.MainJobBadge,
+			atc.SearchBuildLogs:
 			newHandler = auth.CheckAuthenticationIfProvidedHandler(handler, rejector)
 
 		case atc.GetLogLevel,
 			atc.ListActiveUsersSince,
+			atc.ListSecretAccesses,
+			atc.GetEncryptionStatus,
 			atc.SetLogLevel,
 			atc.GetInfoCreds:
 			newHandler = auth.CheckAdminHandler(handler, rejector)
@@ -122,6 +134,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.CheckResource,
 			atc.CheckResourceType,
 			atc.CreateJobBuild,
+			atc.RerunJobBuild,
 			atc.CreatePipelineBuild,
 			atc.DeletePipeline,
 			atc.DisableResourceVersion,
@@ -144,7 +157,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.SaveConfig,
 			atc.ClearTaskCache,
 			atc.CreateArtifact,
-			atc.GetArtifact:
+			atc.GetArtifact,
+			atc.ListSemaphores:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
 		// think about it!
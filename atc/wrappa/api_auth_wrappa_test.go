@@ -148,13 +148,19 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.BuildResources: doesNotCheckIfPrivateJob(inputHandlers[atc.BuildResources]),
 
 				// authorized or public pipeline and public job
-				atc.BuildEvents:         checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
-				atc.ListBuildArtifacts:  checksIfPrivateJob(inputHandlers[atc.ListBuildArtifacts]),
-				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
-				atc.GetBuildPlan:        checksIfPrivateJob(inputHandlers[atc.GetBuildPlan]),
+				atc.BuildEvents:           checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
+				atc.ListBuildArtifacts:    checksIfPrivateJob(inputHandlers[atc.ListBuildArtifacts]),
+				atc.ListArchivedArtifacts: checksIfPrivateJob(inputHandlers[atc.ListArchivedArtifacts]),
+				atc.ListBuildTestResults:  checksIfPrivateJob(inputHandlers[atc.ListBuildTestResults]),
+				atc.ListBuildApprovals:    checksIfPrivateJob(inputHandlers[atc.ListBuildApprovals]),
+				atc.GetArchivedArtifact:   checksIfPrivateJob(inputHandlers[atc.GetArchivedArtifact]),
+				atc.GetBuildPreparation:   checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
+				atc.GetBuildPlan:          checksIfPrivateJob(inputHandlers[atc.GetBuildPlan]),
 
 				// resource belongs to authorized team
-				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.AbortBuild:   checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.ApproveBuild: checkWritePermissionForBuild(inputHandlers[atc.ApproveBuild]),
+				atc.RejectBuild:  checkWritePermissionForBuild(inputHandlers[atc.RejectBuild]),
 
 				// resource belongs to authorized team
 				atc.PruneWorker:              checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
@@ -173,6 +179,8 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListJobs:                      openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobs]),
 				atc.GetJob:                        openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJob]),
 				atc.ListJobBuilds:                 openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobBuilds]),
+				atc.ListJobTestHistory:            openForPublicPipelineOrAuthorized(inputHandlers[atc.ListJobTestHistory]),
+				atc.GetJobStats:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetJobStats]),
 				atc.ListPipelineBuilds:            openForPublicPipelineOrAuthorized(inputHandlers[atc.ListPipelineBuilds]),
 				atc.GetResource:                   openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResource]),
 				atc.ListBuildsWithVersionAsInput:  openForPublicPipelineOrAuthorized(inputHandlers[atc.ListBuildsWithVersionAsInput]),
@@ -189,6 +197,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.HijackContainer: authenticated(inputHandlers[atc.HijackContainer]),
 				atc.ListContainers:  authenticated(inputHandlers[atc.ListContainers]),
 				atc.ListVolumes:     authenticated(inputHandlers[atc.ListVolumes]),
+				atc.GetInfoRoles:    authenticated(inputHandlers[atc.GetInfoRoles]),
 				atc.ListTeamBuilds:  authenticated(inputHandlers[atc.ListTeamBuilds]),
 				atc.ListWorkers:     authenticated(inputHandlers[atc.ListWorkers]),
 				atc.RegisterWorker:  authenticated(inputHandlers[atc.RegisterWorker]),
@@ -211,17 +220,21 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ListAllResources:     authenticateIfTokenProvided(inputHandlers[atc.ListAllResources]),
 				atc.ListTeams:            authenticateIfTokenProvided(inputHandlers[atc.ListTeams]),
 				atc.MainJobBadge:         authenticateIfTokenProvided(inputHandlers[atc.MainJobBadge]),
+				atc.SearchBuildLogs:      authenticateIfTokenProvided(inputHandlers[atc.SearchBuildLogs]),
 
 				// authenticated and is admin
 				atc.GetLogLevel:          authenticatedAndAdmin(inputHandlers[atc.GetLogLevel]),
 				atc.SetLogLevel:          authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
 				atc.GetInfoCreds:         authenticatedAndAdmin(inputHandlers[atc.GetInfoCreds]),
 				atc.ListActiveUsersSince: authenticatedAndAdmin(inputHandlers[atc.ListActiveUsersSince]),
+				atc.ListSecretAccesses:   authenticatedAndAdmin(inputHandlers[atc.ListSecretAccesses]),
+				atc.GetEncryptionStatus:  authenticatedAndAdmin(inputHandlers[atc.GetEncryptionStatus]),
 
 				// authorized (requested team matches resource team)
 				atc.CheckResource:           authorized(inputHandlers[atc.CheckResource]),
 				atc.CheckResourceType:       authorized(inputHandlers[atc.CheckResourceType]),
 				atc.CreateJobBuild:          authorized(inputHandlers[atc.CreateJobBuild]),
+				atc.RerunJobBuild:           authorized(inputHandlers[atc.RerunJobBuild]),
 				atc.DeletePipeline:          authorized(inputHandlers[atc.DeletePipeline]),
 				atc.DisableResourceVersion:  authorized(inputHandlers[atc.DisableResourceVersion]),
 				atc.EnableResourceVersion:   authorized(inputHandlers[atc.EnableResourceVersion]),
@@ -245,6 +258,7 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ClearTaskCache:          authorized(inputHandlers[atc.ClearTaskCache]),
 				atc.CreateArtifact:          authorized(inputHandlers[atc.CreateArtifact]),
 				atc.GetArtifact:             authorized(inputHandlers[atc.GetArtifact]),
+				atc.ListSemaphores:          authorized(inputHandlers[atc.ListSemaphores]),
 			}
 		})
 
@@ -0,0 +1,75 @@
+package wrappa_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/policychecker"
+	"github.com/concourse/concourse/atc/api/policychecker/policycheckerfakes"
+	"github.com/concourse/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PolicyCheckWrappa", func() {
+	var (
+		logger            *lagertest.TestLogger
+		fakePolicyChecker *policycheckerfakes.FakePolicyChecker
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+		fakePolicyChecker = new(policycheckerfakes.FakePolicyChecker)
+	})
+
+	checked := func(handler http.Handler, action string) http.Handler {
+		return policychecker.NewHandler(logger, handler, action, fakePolicyChecker)
+	}
+
+	Describe("Wrap", func() {
+		var (
+			inputHandlers rata.Handlers
+
+			expectedHandlers rata.Handlers
+
+			wrappedHandlers rata.Handlers
+		)
+
+		BeforeEach(func() {
+			inputHandlers = rata.Handlers{}
+
+			for _, route := range atc.Routes {
+				inputHandlers[route.Name] = &stupidHandler{}
+			}
+
+			expectedHandlers = rata.Handlers{}
+
+			// wrap everything; the checker decides which actions to check
+			for route, handler := range inputHandlers {
+				expectedHandlers[route] = checked(handler, route)
+			}
+		})
+
+		JustBeforeEach(func() {
+			wrappedHandlers = wrappa.NewPolicyCheckWrappa(
+				logger,
+				fakePolicyChecker,
+			).Wrap(inputHandlers)
+		})
+
+		It("wraps every single handler with a policy checking handler", func() {
+			for name, _ := range inputHandlers {
+				Expect(descriptiveRoute{
+					route:   name,
+					handler: wrappedHandlers[name],
+				}).To(Equal(descriptiveRoute{
+					route:   name,
+					handler: expectedHandlers[name],
+				}))
+			}
+		})
+	})
+})
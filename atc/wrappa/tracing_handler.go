@@ -0,0 +1,31 @@
+package wrappa
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/tracing"
+)
+
+// TracingHandler serves each request within a span named after its route,
+// continuing any trace propagated by the client.
+type TracingHandler struct {
+	Route   string
+	Handler http.Handler
+}
+
+func (handler TracingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := tracing.Extract(r.Context(), r.Header)
+
+	// route params are added to the query by rata; reading them from the URL
+	// avoids parsing the request body
+	params := r.URL.Query()
+
+	ctx, span := tracing.StartSpan(ctx, handler.Route, tracing.Attrs{
+		"http.method": r.Method,
+		"team":        params.Get(":team_name"),
+		"pipeline":    params.Get(":pipeline_name"),
+	})
+	defer span.End()
+
+	handler.Handler.ServeHTTP(w, r.WithContext(ctx))
+}
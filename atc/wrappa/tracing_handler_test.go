@@ -0,0 +1,88 @@
+package wrappa_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/concourse/concourse/atc/wrappa"
+	"github.com/concourse/concourse/tracing"
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type spanRecorder struct {
+	ended []*export.SpanData
+}
+
+func (recorder *spanRecorder) ExportSpan(ctx context.Context, span *export.SpanData) {
+	recorder.ended = append(recorder.ended, span)
+}
+
+var _ = Describe("TracingHandler", func() {
+	var (
+		recorder *spanRecorder
+
+		innerSpan trace.SpanContext
+		request   *http.Request
+	)
+
+	BeforeEach(func() {
+		recorder = &spanRecorder{}
+
+		provider, err := sdktrace.NewProvider(sdktrace.WithSyncer(recorder))
+		Expect(err).ToNot(HaveOccurred())
+
+		tracing.ConfigureTraceProvider(provider)
+
+		request, err = http.NewRequest("PUT", "/api/v1/teams/some-team/pipelines/some-pipeline/config?:team_name=some-team&:pipeline_name=some-pipeline", nil)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		tracing.ConfigureTraceProvider(trace.NoopProvider{})
+	})
+
+	JustBeforeEach(func() {
+		wrappa.TracingHandler{
+			Route: "SaveConfig",
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				innerSpan = trace.SpanFromContext(r.Context()).SpanContext()
+			}),
+		}.ServeHTTP(httptest.NewRecorder(), request)
+	})
+
+	It("serves the request within a span named after the route", func() {
+		spans := recorder.ended
+		Expect(spans).To(HaveLen(1))
+		Expect(spans[0].Name).To(Equal("SaveConfig"))
+		Expect(spans[0].Attributes).To(ConsistOf(
+			kv.String("http.method", "PUT"),
+			kv.String("team", "some-team"),
+			kv.String("pipeline", "some-pipeline"),
+		))
+		Expect(innerSpan).To(Equal(spans[0].SpanContext))
+	})
+
+	Context("when the client propagates a trace", func() {
+		var clientSpan trace.Span
+
+		BeforeEach(func() {
+			var ctx context.Context
+			ctx, clientSpan = tracing.StartSpan(context.Background(), "client", nil)
+			tracing.Inject(ctx, request.Header)
+		})
+
+		It("continues the trace", func() {
+			spans := recorder.ended
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].SpanContext.TraceID).To(Equal(clientSpan.SpanContext().TraceID))
+			Expect(spans[0].ParentSpanID).To(Equal(clientSpan.SpanContext().SpanID))
+		})
+	})
+})
@@ -0,0 +1,22 @@
+package wrappa
+
+import "github.com/tedsuo/rata"
+
+type TracingWrappa struct{}
+
+func NewTracingWrappa() Wrappa {
+	return TracingWrappa{}
+}
+
+func (wrappa TracingWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
+	wrapped := rata.Handlers{}
+
+	for name, handler := range handlers {
+		wrapped[name] = TracingHandler{
+			Route:   name,
+			Handler: handler,
+		}
+	}
+
+	return wrapped
+}
@@ -0,0 +1,56 @@
+package wrappa_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/wrappa"
+	"github.com/tedsuo/rata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TracingWrappa", func() {
+	Describe("Wrap", func() {
+		var (
+			inputHandlers rata.Handlers
+
+			expectedHandlers rata.Handlers
+
+			wrappedHandlers rata.Handlers
+		)
+
+		BeforeEach(func() {
+			inputHandlers = rata.Handlers{}
+
+			for _, route := range atc.Routes {
+				inputHandlers[route.Name] = &stupidHandler{}
+			}
+
+			expectedHandlers = rata.Handlers{}
+
+			// wrap everything
+			for route, handler := range inputHandlers {
+				expectedHandlers[route] = wrappa.TracingHandler{
+					Route:   route,
+					Handler: handler,
+				}
+			}
+		})
+
+		JustBeforeEach(func() {
+			wrappedHandlers = wrappa.NewTracingWrappa().Wrap(inputHandlers)
+		})
+
+		It("wraps every single handler with a tracing handler", func() {
+			for name, _ := range inputHandlers {
+				Expect(descriptiveRoute{
+					route:   name,
+					handler: wrappedHandlers[name],
+				}).To(Equal(descriptiveRoute{
+					route:   name,
+					handler: expectedHandlers[name],
+				}))
+			}
+		})
+	})
+})
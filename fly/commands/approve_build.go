@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type ApproveBuildCommand struct {
+	Job     flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of a job to approve"`
+	Build   string              `short:"b" long:"build" required:"true" description:"If job is specified: build number to approve. If job not specified: build id"`
+	Step    string              `short:"s" long:"step" description:"Name of the approval step to approve, if the build is waiting for more than one"`
+	Comment string              `short:"c" long:"comment" description:"Comment explaining the approval"`
+}
+
+func (command *ApproveBuildCommand) Execute([]string) error {
+	err := decideApproval(command.Job, command.Build, atc.ApprovalDecision{
+		Name:    command.Step,
+		Comment: command.Comment,
+	}, true)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("build successfully approved")
+	return nil
+}
+
+func decideApproval(job flaghelpers.JobFlag, buildName string, decision atc.ApprovalDecision, approve bool) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var build atc.Build
+	var exists bool
+	if job.PipelineRef.Name == "" && job.JobName == "" {
+		build, exists, err = target.Client().Build(buildName)
+	} else {
+		build, exists, err = target.Team().JobBuild(job.PipelineRef, job.JobName, buildName)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("build does not exist")
+	}
+
+	if approve {
+		exists, err = target.Client().ApproveBuild(strconv.Itoa(build.ID), decision)
+	} else {
+		exists, err = target.Client().RejectBuild(strconv.Itoa(build.ID), decision)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("build is not waiting for approval")
+	}
+
+	return nil
+}
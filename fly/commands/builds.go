@@ -99,7 +99,7 @@ func (command *BuildsCommand) Execute([]string) error {
 
 		var found bool
 		builds, _, found, err = currentTeam.PipelineBuilds(
-			string(command.Pipeline),
+			command.Pipeline.Ref(),
 			page,
 		)
 		if err != nil {
@@ -112,7 +112,7 @@ func (command *BuildsCommand) Execute([]string) error {
 	} else if command.jobFlag() {
 		var found bool
 		builds, _, found, err = currentTeam.JobBuilds(
-			command.Job.PipelineName,
+			command.Job.PipelineRef,
 			command.Job.JobName,
 			page,
 		)
@@ -184,6 +184,36 @@ func (command *BuildsCommand) Execute([]string) error {
 		rangeUntil = len(builds)
 	}
 
+	// only show priorities, test results and creators when a build has any,
+	// so that the table stays narrow for pipelines which don't use them and
+	// for builds from before they were recorded
+	showPriority, showTests, showCreatedBy := false, false, false
+	for _, b := range builds[:rangeUntil] {
+		if b.Priority != 0 {
+			showPriority = true
+		}
+
+		if b.TestSummary != nil {
+			showTests = true
+		}
+
+		if b.CreatedBy != nil {
+			showCreatedBy = true
+		}
+	}
+
+	if showPriority {
+		table.Headers = append(table.Headers, ui.TableCell{Contents: "priority", Color: color.New(color.Bold)})
+	}
+
+	if showTests {
+		table.Headers = append(table.Headers, ui.TableCell{Contents: "tests", Color: color.New(color.Bold)})
+	}
+
+	if showCreatedBy {
+		table.Headers = append(table.Headers, ui.TableCell{Contents: "created by", Color: color.New(color.Bold)})
+	}
+
 	for _, b := range builds[:rangeUntil] {
 		startTimeCell, endTimeCell, durationCell := populateTimeCells(time.Unix(b.StartTime, 0), time.Unix(b.EndTime, 0))
 
@@ -216,7 +246,7 @@ func (command *BuildsCommand) Execute([]string) error {
 			statusCell.Color = ui.PausedColor
 		}
 
-		table.Data = append(table.Data, []ui.TableCell{
+		row := []ui.TableCell{
 			{Contents: strconv.Itoa(b.ID)},
 			pipelineJobCell,
 			buildCell,
@@ -225,12 +255,43 @@ func (command *BuildsCommand) Execute([]string) error {
 			endTimeCell,
 			durationCell,
 			{Contents: b.TeamName},
-		})
+		}
+
+		if showPriority {
+			row = append(row, ui.TableCell{Contents: strconv.Itoa(b.Priority)})
+		}
+
+		if showTests {
+			row = append(row, testSummaryCell(b.TestSummary))
+		}
+
+		if showCreatedBy {
+			if b.CreatedBy != nil {
+				row = append(row, ui.TableCell{Contents: b.CreatedBy.String()})
+			} else {
+				row = append(row, ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)})
+			}
+		}
+
+		table.Data = append(table.Data, row)
 	}
 
 	return table.Render(os.Stdout, Fly.PrintTableHeaders)
 }
 
+func testSummaryCell(summary *atc.TestSummary) ui.TableCell {
+	if summary == nil {
+		return ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+	}
+
+	cell := ui.TableCell{Contents: summary.String()}
+	if summary.Failed > 0 || summary.Errored > 0 {
+		cell.Color = ui.FailedColor
+	}
+
+	return cell
+}
+
 func populateTimeCells(startTime time.Time, endTime time.Time) (ui.TableCell, ui.TableCell, ui.TableCell) {
 	var startTimeCell ui.TableCell
 	var endTimeCell ui.TableCell
@@ -266,7 +327,7 @@ func roundSecondsOffDuration(d time.Duration) time.Duration {
 }
 
 func (command *BuildsCommand) jobFlag() bool {
-	return command.Job.PipelineName != "" && command.Job.JobName != ""
+	return command.Job.PipelineRef.Name != "" && command.Job.JobName != ""
 }
 
 func (command *BuildsCommand) pipelineFlag() bool {
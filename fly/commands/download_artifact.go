@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/DataDog/zstd"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/go-archive/tarfs"
+)
+
+type DownloadArtifactCommand struct {
+	Job      flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of the job whose build produced the artifacts"`
+	Build    string              `short:"b" long:"build" required:"true" description:"If job is specified: build number. If job not specified: build id"`
+	Artifact []string            `short:"a" long:"artifact" description:"Name of an archived artifact to download. Can be specified multiple times. Defaults to all of the build's artifacts."`
+	Output   string              `short:"o" long:"output" default:"." description:"Directory into which each artifact is extracted, under its own name. Artifacts archived under the same name by several steps, e.g. across vars, are extracted under their plan ID and name."`
+}
+
+func (command *DownloadArtifactCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var build atc.Build
+	var exists bool
+	if command.Job.PipelineRef.Name == "" && command.Job.JobName == "" {
+		build, exists, err = target.Client().Build(command.Build)
+	} else {
+		build, exists, err = target.Team().JobBuild(command.Job.PipelineRef, command.Job.JobName, command.Build)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("build does not exist")
+	}
+
+	buildID := strconv.Itoa(build.ID)
+
+	artifacts, err := target.Client().ListArchivedArtifacts(buildID)
+	if err != nil {
+		return err
+	}
+
+	if len(artifacts) == 0 {
+		return fmt.Errorf("build has no archived artifacts")
+	}
+
+	archived := map[string]int{}
+	for _, artifact := range artifacts {
+		archived[artifact.Name]++
+	}
+
+	requested := map[string]bool{}
+	for _, name := range command.Artifact {
+		if archived[name] == 0 {
+			return fmt.Errorf("build has no archived artifact named '%s'", name)
+		}
+
+		requested[name] = true
+	}
+
+	for _, artifact := range artifacts {
+		if len(requested) != 0 && !requested[artifact.Name] {
+			continue
+		}
+
+		dest := filepath.Join(command.Output, artifact.Name)
+		if archived[artifact.Name] > 1 {
+			dest = filepath.Join(command.Output, string(artifact.PlanID), artifact.Name)
+		}
+
+		fmt.Printf("downloading %s to %s\n", artifact.Name, dest)
+
+		err = command.download(target, buildID, artifact, dest)
+		if err != nil {
+			return fmt.Errorf("failed to download artifact '%s': %s", artifact.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (command *DownloadArtifactCommand) download(target rc.Target, buildID string, artifact atc.ArchivedArtifact, dest string) error {
+	out, err := target.Client().GetArchivedArtifact(buildID, artifact.PlanID, artifact.Name)
+	if err != nil {
+		return err
+	}
+
+	defer out.Close()
+
+	return tarfs.Extract(zstd.NewReader(out), dest)
+}
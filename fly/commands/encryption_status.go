@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type EncryptionStatusCommand struct {
+	Json bool `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *EncryptionStatusCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	status, err := target.Client().EncryptionStatus()
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(status)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if status.KeyID == "" {
+		fmt.Println("encryption is disabled")
+	} else {
+		fmt.Printf("encryption key: %s\n", status.KeyID)
+
+		if len(status.OldKeyIDs) > 0 {
+			fmt.Printf("re-encrypting from: %s\n", strings.Join(status.OldKeyIDs, ", "))
+		}
+	}
+
+	fmt.Println()
+
+	headers := ui.TableRow{
+		{Contents: "table", Color: color.New(color.Bold)},
+		{Contents: "plaintext", Color: color.New(color.Bold)},
+		{Contents: "legacy", Color: color.New(color.Bold)},
+		{Contents: "current key", Color: color.New(color.Bold)},
+		{Contents: "other keys", Color: color.New(color.Bold)},
+		{Contents: "undecryptable", Color: color.New(color.Bold)},
+		{Contents: "remaining", Color: color.New(color.Bold)},
+	}
+
+	table := ui.Table{Headers: headers}
+
+	for _, t := range status.Tables {
+		otherKeys := 0
+		for id, count := range t.Keys {
+			if id != status.KeyID {
+				otherKeys += count
+			}
+		}
+
+		remainingCell := ui.TableCell{Contents: strconv.Itoa(t.Remaining)}
+		if t.Remaining == 0 {
+			remainingCell.Color = color.New(color.FgGreen)
+		} else {
+			remainingCell.Color = color.New(color.FgYellow)
+		}
+
+		undecryptableCell := ui.TableCell{Contents: strconv.Itoa(t.Undecryptable)}
+		if t.Undecryptable != 0 {
+			undecryptableCell.Color = color.New(color.FgRed)
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: t.Table + "." + t.Column},
+			{Contents: strconv.Itoa(t.Plaintext)},
+			{Contents: strconv.Itoa(t.Legacy)},
+			{Contents: strconv.Itoa(t.Keys[status.KeyID])},
+			{Contents: strconv.Itoa(otherKeys)},
+			undecryptableCell,
+			remainingCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
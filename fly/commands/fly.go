@@ -21,8 +21,10 @@ type FlyCommand struct {
 	Status StatusCommand `command:"status" description:"Login status"`
 	Sync   SyncCommand   `command:"sync"  alias:"s" description:"Download and replace the current fly from the target"`
 
-	ActiveUsers ActiveUsersCommand `command:"active-users" alias:"au" description:"List the active users since a date or for the past 2 months"`
-	Userinfo    UserinfoCommand    `command:"userinfo" description:"User information"`
+	ActiveUsers      ActiveUsersCommand      `command:"active-users" alias:"au" description:"List the active users since a date or for the past 2 months"`
+	Userinfo         UserinfoCommand         `command:"userinfo" description:"User information"`
+	SecretAccesses   SecretAccessesCommand   `command:"secret-accesses" alias:"sas" description:"List the most recent lookups of secrets in credential managers"`
+	EncryptionStatus EncryptionStatusCommand `command:"encryption-status" alias:"encs" description:"Show the progress of re-encrypting data with the current encryption key"`
 
 	Teams       TeamsCommand       `command:"teams" alias:"t" description:"List the configured teams"`
 	GetTeam     GetTeamCommand     `command:"get-team"  alias:"gt" description:"Show team configuration"`
@@ -41,6 +43,7 @@ type FlyCommand struct {
 	Jobs       JobsCommand       `command:"jobs"      alias:"js" description:"List the jobs in the pipelines"`
 	PauseJob   PauseJobCommand   `command:"pause-job" alias:"pj" description:"Pause a job"`
 	UnpauseJob UnpauseJobCommand `command:"unpause-job" alias:"uj" description:"Unpause a job"`
+	JobStats   JobStatsCommand   `command:"job-stats" alias:"jst" description:"Show build time and failure statistics of a job"`
 
 	Pipelines        PipelinesCommand        `command:"pipelines"           alias:"ps"   description:"List the configured pipelines"`
 	DestroyPipeline  DestroyPipelineCommand  `command:"destroy-pipeline"    alias:"dp"   description:"Destroy a pipeline"`
@@ -68,7 +71,17 @@ type FlyCommand struct {
 	Builds     BuildsCommand     `command:"builds"      alias:"bs" description:"List builds data"`
 	AbortBuild AbortBuildCommand `command:"abort-build" alias:"ab" description:"Abort a build"`
 
+	ApproveBuild ApproveBuildCommand `command:"approve-build" alias:"apb" description:"Approve a build waiting for approval"`
+	RejectBuild  RejectBuildCommand  `command:"reject-build" alias:"rjb" description:"Reject a build waiting for approval"`
+
+	DownloadArtifact DownloadArtifactCommand `command:"download-artifact" alias:"da" description:"Download the archived artifacts of a build"`
+
+	SearchLogs SearchLogsCommand `command:"search-logs" alias:"sl" description:"Search the logs of the team's builds"`
+
+	Semaphores SemaphoresCommand `command:"semaphores" alias:"sems" description:"List the team's semaphores and the builds holding and waiting for them"`
+
 	TriggerJob TriggerJobCommand `command:"trigger-job" alias:"tj" description:"Start a job in a pipeline"`
+	RerunBuild RerunBuildCommand `command:"rerun-build" alias:"rb" description:"Rerun a build of a job with the same inputs"`
 
 	Volumes VolumesCommand `command:"volumes" alias:"vs" description:"List the active volumes"`
 
@@ -22,7 +22,7 @@ func (command *HidePipelineCommand) Execute(args []string) error {
 		return err
 	}
 
-	pipelineName := string(command.Pipeline)
+	pipelineRef := command.Pipeline.Ref()
 
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
@@ -34,15 +34,15 @@ func (command *HidePipelineCommand) Execute(args []string) error {
 		return err
 	}
 
-	found, err := target.Team().HidePipeline(pipelineName)
+	found, err := target.Team().HidePipeline(pipelineRef)
 	if err != nil {
 		return err
 	}
 
 	if found {
-		fmt.Printf("hid '%s'\n", pipelineName)
+		fmt.Printf("hid '%s'\n", pipelineRef)
 	} else {
-		displayhelpers.Failf("pipeline '%s' not found\n", pipelineName)
+		displayhelpers.Failf("pipeline '%s' not found\n", pipelineRef)
 	}
 
 	return nil
@@ -248,7 +248,7 @@ func (command *HijackCommand) getContainerFingerprintFromUrl(target rc.Target, u
 	}
 
 	fingerprint := &containerFingerprint{
-		pipelineName:  urlMap["pipelines"],
+		pipelineRef:   atc.PipelineRef{Name: urlMap["pipelines"]},
 		jobName:       urlMap["jobs"],
 		buildNameOrID: urlMap["builds"],
 		checkName:     urlMap["resources"],
@@ -268,16 +268,18 @@ func (command *HijackCommand) getContainerFingerprint(target rc.Target) (*contai
 		}
 	}
 
-	pipelineName := command.Check.PipelineName
-	if command.Job.PipelineName != "" {
-		pipelineName = command.Job.PipelineName
+	if command.Check.PipelineRef.Name != "" {
+		fingerprint.pipelineRef = command.Check.PipelineRef
+	}
+
+	if command.Job.PipelineRef.Name != "" {
+		fingerprint.pipelineRef = command.Job.PipelineRef
 	}
 
 	for _, field := range []struct {
 		fp  *string
 		cmd string
 	}{
-		{fp: &fingerprint.pipelineName, cmd: pipelineName},
 		{fp: &fingerprint.buildNameOrID, cmd: command.Build},
 		{fp: &fingerprint.stepName, cmd: command.StepName},
 		{fp: &fingerprint.stepType, cmd: command.StepType},
@@ -349,7 +351,7 @@ func (locator stepContainerLocator) locate(fingerprint *containerFingerprint) (m
 	}
 
 	if fingerprint.jobName != "" {
-		reqValues["pipeline_name"] = fingerprint.pipelineName
+		fingerprint.addPipelineRef(reqValues)
 		reqValues["job_name"] = fingerprint.jobName
 		if fingerprint.buildNameOrID != "" {
 			reqValues["build_name"] = fingerprint.buildNameOrID
@@ -357,7 +359,7 @@ func (locator stepContainerLocator) locate(fingerprint *containerFingerprint) (m
 	} else if fingerprint.buildNameOrID != "" {
 		reqValues["build_id"] = fingerprint.buildNameOrID
 	} else {
-		build, err := GetBuild(locator.client, nil, "", "", "")
+		build, err := GetBuild(locator.client, nil, "", "", atc.PipelineRef{})
 		if err != nil {
 			return reqValues, err
 		}
@@ -376,15 +378,15 @@ func (locator checkContainerLocator) locate(fingerprint *containerFingerprint) (
 	if fingerprint.checkName != "" {
 		reqValues["resource_name"] = fingerprint.checkName
 	}
-	if fingerprint.pipelineName != "" {
-		reqValues["pipeline_name"] = fingerprint.pipelineName
+	if fingerprint.pipelineRef.Name != "" {
+		fingerprint.addPipelineRef(reqValues)
 	}
 
 	return reqValues, nil
 }
 
 type containerFingerprint struct {
-	pipelineName  string
+	pipelineRef   atc.PipelineRef
 	jobName       string
 	buildNameOrID string
 
@@ -395,6 +397,14 @@ type containerFingerprint struct {
 	attempt   string
 }
 
+func (fingerprint *containerFingerprint) addPipelineRef(reqValues map[string]string) {
+	reqValues["pipeline_name"] = fingerprint.pipelineRef.Name
+
+	for key, values := range fingerprint.pipelineRef.QueryParams() {
+		reqValues[key] = values[0]
+	}
+}
+
 func locateContainer(client concourse.Client, fingerprint *containerFingerprint) (map[string]string, error) {
 	var locator containerLocator
 
@@ -7,23 +7,33 @@ import (
 
 	"github.com/jessevdk/go-flags"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/rc"
 )
 
+// JobFlag identifies a job of a pipeline, optionally scoped to one of the
+// pipeline's instances, e.g. release/branch:master/unit.
 type JobFlag struct {
-	PipelineName string
-	JobName      string
+	PipelineRef atc.PipelineRef
+	JobName     string
 }
 
 func (job *JobFlag) UnmarshalFlag(value string) error {
-	vs := strings.SplitN(value, "/", -1)
-
-	if len(vs) != 2 {
+	sep := strings.LastIndex(value, "/")
+	if sep == -1 {
 		return errors.New("argument format should be <pipeline>/<job>")
 	}
 
-	job.PipelineName = vs[0]
-	job.JobName = vs[1]
+	ref, err := parsePipelineRef(value[:sep])
+	if err == errInstanceVarsFormat {
+		return errors.New("argument format should be <pipeline>/<job> or <pipeline>/<key:value>/<job>")
+	}
+	if err != nil {
+		return err
+	}
+
+	job.PipelineRef = ref
+	job.JobName = value[sep+1:]
 
 	return nil
 }
@@ -43,29 +53,28 @@ func (flag *JobFlag) Complete(match string) []flags.Completion {
 
 	team := target.Team()
 	comps := []flags.Completion{}
-	vs := strings.SplitN(match, "/", 2)
 
-	if len(vs) == 1 {
-		pipelines, err := team.ListPipelines()
-		if err != nil {
-			return comps
-		}
+	pipelines, err := team.ListPipelines()
+	if err != nil {
+		return comps
+	}
+
+	for _, pipeline := range pipelines {
+		ref := pipeline.Ref().String()
 
-		for _, pipeline := range pipelines {
-			if strings.HasPrefix(pipeline.Name, vs[0]) {
-				comps = append(comps, flags.Completion{Item: pipeline.Name + "/"})
+		if prefix := ref + "/"; strings.HasPrefix(match, prefix) {
+			jobs, err := team.ListJobs(pipeline.Ref())
+			if err != nil {
+				return comps
 			}
-		}
-	} else if len(vs) == 2 {
-		jobs, err := team.ListJobs(vs[0])
-		if err != nil {
-			return comps
-		}
 
-		for _, job := range jobs {
-			if strings.HasPrefix(job.Name, vs[1]) {
-				comps = append(comps, flags.Completion{Item: fmt.Sprintf("%s/%s", vs[0], job.Name)})
+			for _, job := range jobs {
+				if strings.HasPrefix(job.Name, match[len(prefix):]) {
+					comps = append(comps, flags.Completion{Item: fmt.Sprintf("%s/%s", ref, job.Name)})
+				}
 			}
+		} else if strings.HasPrefix(ref, match) {
+			comps = append(comps, flags.Completion{Item: ref + "/"})
 		}
 	}
 
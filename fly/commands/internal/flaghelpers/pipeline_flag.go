@@ -2,22 +2,45 @@ package flaghelpers
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/jessevdk/go-flags"
+	"sigs.k8s.io/yaml"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/rc"
 )
 
+// PipelineFlag identifies a pipeline, optionally followed by the instance
+// vars of one of its instances, e.g. release/version:5.1,branch:master.
 type PipelineFlag string
 
 func (flag *PipelineFlag) Validate() error {
+	_, err := flag.parse()
+	return err
+}
+
+// ValidateName validates a flag which may only contain a pipeline name, such
+// as the new name when renaming a pipeline.
+func (flag *PipelineFlag) ValidateName() error {
 	if strings.Contains(string(*flag), "/") {
 		return errors.New("pipeline name cannot contain '/'")
 	}
 	return nil
 }
 
+// Ref returns the pipeline the flag refers to. The flag is expected to have
+// been validated already.
+func (flag *PipelineFlag) Ref() atc.PipelineRef {
+	ref, _ := flag.parse()
+	return ref
+}
+
+func (flag *PipelineFlag) parse() (atc.PipelineRef, error) {
+	return parsePipelineRef(string(*flag))
+}
+
 func (flag *PipelineFlag) Complete(match string) []flags.Completion {
 	fly := parseFlags()
 
@@ -38,10 +61,74 @@ func (flag *PipelineFlag) Complete(match string) []flags.Completion {
 
 	comps := []flags.Completion{}
 	for _, pipeline := range pipelines {
-		if strings.HasPrefix(pipeline.Name, match) {
-			comps = append(comps, flags.Completion{Item: pipeline.Name})
+		ref := pipeline.Ref().String()
+		if strings.HasPrefix(ref, match) {
+			comps = append(comps, flags.Completion{Item: ref})
 		}
 	}
 
 	return comps
 }
+
+var errInstanceVarsFormat = errors.New("argument format should be <pipeline>/<key:value>")
+
+// parsePipelineRef parses a pipeline name optionally followed by instance
+// vars, e.g. release/version:5.1,branch:master. Each value is parsed as YAML,
+// so version:5.1 is a number while version:"5.1" is a string.
+func parsePipelineRef(value string) (atc.PipelineRef, error) {
+	vs := strings.SplitN(value, "/", 2)
+
+	ref := atc.PipelineRef{Name: vs[0]}
+	if len(vs) == 1 {
+		return ref, nil
+	}
+
+	ref.InstanceVars = atc.InstanceVars{}
+	for _, pair := range splitInstanceVars(vs[1]) {
+		kv := strings.SplitN(pair, ":", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return atc.PipelineRef{}, errInstanceVarsFormat
+		}
+
+		var val interface{}
+		err := yaml.Unmarshal([]byte(kv[1]), &val)
+		if err != nil {
+			return atc.PipelineRef{}, fmt.Errorf("invalid value for instance var '%s': %s", kv[0], err)
+		}
+
+		ref.InstanceVars[kv[0]] = val
+	}
+
+	return ref, nil
+}
+
+// splitInstanceVars splits key:value pairs on the commas which are not
+// within a quoted string, a list or a map, so that values may contain commas.
+func splitInstanceVars(value string) []string {
+	var (
+		pairs []string
+		depth int
+		quote rune
+		start int
+	)
+
+	for i, c := range value {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		case c == ',' && depth == 0:
+			pairs = append(pairs, value[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(pairs, value[start:])
+}
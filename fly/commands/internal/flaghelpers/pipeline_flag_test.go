@@ -0,0 +1,83 @@
+package flaghelpers_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PipelineFlag", func() {
+	Context("when only a name is specified", func() {
+		It("refers to the pipeline without instance vars", func() {
+			flag := PipelineFlag("some-pipeline")
+
+			Expect(flag.Validate()).To(Succeed())
+			Expect(flag.Ref()).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+		})
+	})
+
+	Context("when instance vars are specified", func() {
+		It("refers to the pipeline instance", func() {
+			flag := PipelineFlag("some-pipeline/branch:master,version:5.1")
+
+			Expect(flag.Validate()).To(Succeed())
+			Expect(flag.Ref()).To(Equal(atc.PipelineRef{
+				Name: "some-pipeline",
+				InstanceVars: atc.InstanceVars{
+					"branch":  "master",
+					"version": 5.1,
+				},
+			}))
+		})
+
+		It("parses each value as YAML", func() {
+			flag := PipelineFlag(`some-pipeline/version:"5.1",enabled:true,tags:[a, b],path:feature/foo,opts:{k: v}`)
+
+			Expect(flag.Validate()).To(Succeed())
+			Expect(flag.Ref()).To(Equal(atc.PipelineRef{
+				Name: "some-pipeline",
+				InstanceVars: atc.InstanceVars{
+					"version": "5.1",
+					"enabled": true,
+					"tags":    []interface{}{"a", "b"},
+					"path":    "feature/foo",
+					"opts":    map[string]interface{}{"k": "v"},
+				},
+			}))
+		})
+
+		It("parses the formatted ref back into the same ref", func() {
+			ref := atc.PipelineRef{
+				Name: "some-pipeline",
+				InstanceVars: atc.InstanceVars{
+					"version": "5.1",
+					"count":   1.0,
+					"tags":    []interface{}{"a", "b"},
+				},
+			}
+
+			flag := PipelineFlag(ref.String())
+
+			Expect(flag.Validate()).To(Succeed())
+			Expect(flag.Ref()).To(Equal(ref))
+		})
+	})
+
+	Context("when the instance vars are malformed", func() {
+		It("displays an error message", func() {
+			flag := PipelineFlag("some-pipeline/master")
+
+			Expect(flag.Validate()).To(MatchError("argument format should be <pipeline>/<key:value>"))
+		})
+	})
+
+	Describe("ValidateName", func() {
+		It("does not allow instance vars", func() {
+			flag := PipelineFlag("some-pipeline/branch:master")
+
+			Expect(flag.ValidateName()).To(MatchError("pipeline name cannot contain '/'"))
+		})
+	})
+})
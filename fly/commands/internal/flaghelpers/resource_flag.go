@@ -3,22 +3,33 @@ package flaghelpers
 import (
 	"errors"
 	"strings"
+
+	"github.com/concourse/concourse/atc"
 )
 
+// ResourceFlag identifies a resource of a pipeline, optionally scoped to one
+// of the pipeline's instances, e.g. release/branch:master/repo.
 type ResourceFlag struct {
-	PipelineName string
+	PipelineRef  atc.PipelineRef
 	ResourceName string
 }
 
 func (resource *ResourceFlag) UnmarshalFlag(value string) error {
-	vs := strings.SplitN(value, "/", 2)
-
-	if len(vs) != 2 {
+	sep := strings.LastIndex(value, "/")
+	if sep == -1 {
 		return errors.New("argument format should be <pipeline>/<resource>")
 	}
 
-	resource.PipelineName = vs[0]
-	resource.ResourceName = vs[1]
+	ref, err := parsePipelineRef(value[:sep])
+	if err == errInstanceVarsFormat {
+		return errors.New("argument format should be <pipeline>/<resource> or <pipeline>/<key:value>/<resource>")
+	}
+	if err != nil {
+		return err
+	}
+
+	resource.PipelineRef = ref
+	resource.ResourceName = value[sep+1:]
 
 	return nil
 }
@@ -1,6 +1,7 @@
 package flaghelpers_test
 
 import (
+	"github.com/concourse/concourse/atc"
 	. "github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 
 	. "github.com/onsi/ginkgo"
@@ -16,4 +17,27 @@ var _ = Describe("ResourceFlag", func() {
 			Expect(err).To(MatchError("argument format should be <pipeline>/<resource>"))
 		})
 	})
+
+	Context("when instance vars are specified", func() {
+		It("refers to the resource of the pipeline instance", func() {
+			resourceFlag := &ResourceFlag{}
+
+			err := resourceFlag.UnmarshalFlag("some-pipeline/branch:master/some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resourceFlag.PipelineRef).To(Equal(atc.PipelineRef{
+				Name:         "some-pipeline",
+				InstanceVars: atc.InstanceVars{"branch": "master"},
+			}))
+			Expect(resourceFlag.ResourceName).To(Equal("some-resource"))
+		})
+	})
+
+	Context("when the instance vars are malformed", func() {
+		It("displays an error message", func() {
+			resourceFlag := &ResourceFlag{}
+
+			err := resourceFlag.UnmarshalFlag("some-pipeline/invalid/some-resource")
+			Expect(err).To(MatchError("argument format should be <pipeline>/<resource> or <pipeline>/<key:value>/<resource>"))
+		})
+	})
 })
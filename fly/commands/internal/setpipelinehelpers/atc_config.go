@@ -10,16 +10,16 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/configdiff"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
 	"github.com/concourse/concourse/fly/commands/internal/templatehelpers"
 	"github.com/concourse/concourse/fly/ui"
 	"github.com/concourse/concourse/go-concourse/concourse"
-	"github.com/onsi/gomega/gexec"
 	"github.com/vito/go-interact/interact"
 )
 
 type ATCConfig struct {
-	PipelineName     string
+	PipelineRef      atc.PipelineRef
 	Team             concourse.Team
 	TargetName       rc.TargetName
 	Target           string
@@ -47,7 +47,7 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 		return err
 	}
 
-	existingConfig, existingConfigVersion, _, err := atcConfig.Team.PipelineConfig(atcConfig.PipelineName)
+	existingConfig, existingConfigVersion, _, err := atcConfig.Team.PipelineConfig(atcConfig.PipelineRef)
 	if err != nil {
 		return err
 	}
@@ -58,7 +58,9 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 		return err
 	}
 
-	diffExists := diff(existingConfig, newConfig)
+	stdout, _ := ui.ForTTY(os.Stdout)
+
+	diffExists := configdiff.Render(stdout, existingConfig, newConfig)
 
 	if !diffExists {
 		fmt.Println("no changes to apply")
@@ -71,7 +73,7 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 	}
 
 	created, updated, warnings, err := atcConfig.Team.CreateOrUpdatePipelineConfig(
-		atcConfig.PipelineName,
+		atcConfig.PipelineRef,
 		existingConfigVersion,
 		evaluatedTemplate,
 		atcConfig.CheckCredentials,
@@ -89,7 +91,7 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 }
 
 func (atcConfig ATCConfig) UnpausePipelineCommand() string {
-	return fmt.Sprintf("%s -t %s unpause-pipeline -p %s", os.Args[0], atcConfig.TargetName, atcConfig.PipelineName)
+	return fmt.Sprintf("%s -t %s unpause-pipeline -p %s", os.Args[0], atcConfig.TargetName, atcConfig.PipelineRef)
 }
 
 func (atcConfig ATCConfig) showPipelineUpdateResult(created bool, updated bool) {
@@ -102,11 +104,13 @@ func (atcConfig ATCConfig) showPipelineUpdateResult(created bool, updated bool)
 			fmt.Println("Could not parse targetURL")
 		}
 
-		pipelineURL, err := url.Parse("/teams/" + atcConfig.Team.Name() + "/pipelines/" + atcConfig.PipelineName)
+		pipelineURL, err := url.Parse("/teams/" + atcConfig.Team.Name() + "/pipelines/" + atcConfig.PipelineRef.Name)
 		if err != nil {
 			fmt.Println("Could not parse pipelineURL")
 		}
 
+		pipelineURL.RawQuery = atcConfig.PipelineRef.QueryParams().Encode()
+
 		fmt.Println("pipeline created!")
 		fmt.Printf("you can view your pipeline here: %s\n", targetURL.ResolveReference(pipelineURL))
 		fmt.Println("")
@@ -118,52 +122,3 @@ func (atcConfig ATCConfig) showPipelineUpdateResult(created bool, updated bool)
 		panic("Something really went wrong!")
 	}
 }
-
-func diff(existingConfig atc.Config, newConfig atc.Config) bool {
-	var diffExists bool
-
-	stdout, _ := ui.ForTTY(os.Stdout)
-
-	indent := gexec.NewPrefixedWriter("  ", stdout)
-
-	groupDiffs := groupDiffIndices(GroupIndex(existingConfig.Groups), GroupIndex(newConfig.Groups))
-	if len(groupDiffs) > 0 {
-		diffExists = true
-		fmt.Println("groups:")
-
-		for _, diff := range groupDiffs {
-			diff.Render(indent, "group")
-		}
-	}
-
-	resourceDiffs := diffIndices(ResourceIndex(existingConfig.Resources), ResourceIndex(newConfig.Resources))
-	if len(resourceDiffs) > 0 {
-		diffExists = true
-		fmt.Println("resources:")
-
-		for _, diff := range resourceDiffs {
-			diff.Render(indent, "resource")
-		}
-	}
-
-	resourceTypeDiffs := diffIndices(ResourceTypeIndex(existingConfig.ResourceTypes), ResourceTypeIndex(newConfig.ResourceTypes))
-	if len(resourceTypeDiffs) > 0 {
-		diffExists = true
-		fmt.Println("resource types:")
-
-		for _, diff := range resourceTypeDiffs {
-			diff.Render(indent, "resource type")
-		}
-	}
-
-	jobDiffs := diffIndices(JobIndex(existingConfig.Jobs), JobIndex(newConfig.Jobs))
-	if len(jobDiffs) > 0 {
-		diffExists = true
-		fmt.Println("jobs:")
-
-		for _, diff := range jobDiffs {
-			diff.Render(indent, "job")
-		}
-	}
-	return diffExists
-}
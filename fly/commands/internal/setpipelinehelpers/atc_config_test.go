@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/concourse/concourse/atc"
 	. "github.com/concourse/concourse/fly/commands/internal/setpipelinehelpers"
 
 	. "github.com/onsi/ginkgo"
@@ -31,10 +32,22 @@ var _ = Describe("ATC Config", func() {
 var _ = Describe("UnpausePipelineCommand", func() {
 	It("uses the right target and pipeline name", func() {
 		atcConfig := ATCConfig{
-			TargetName:   "my-target",
-			PipelineName: "my-pipeline",
+			TargetName:  "my-target",
+			PipelineRef: atc.PipelineRef{Name: "my-pipeline"},
 		}
 		expected := fmt.Sprintf("%s -t my-target unpause-pipeline -p my-pipeline", os.Args[0])
 		Expect(atcConfig.UnpausePipelineCommand()).To(Equal(expected))
 	})
+
+	It("includes the instance vars of a pipeline instance", func() {
+		atcConfig := ATCConfig{
+			TargetName: "my-target",
+			PipelineRef: atc.PipelineRef{
+				Name:         "my-pipeline",
+				InstanceVars: atc.InstanceVars{"branch": "master"},
+			},
+		}
+		expected := fmt.Sprintf("%s -t my-target unpause-pipeline -p my-pipeline/branch:master", os.Args[0])
+		Expect(atcConfig.UnpausePipelineCommand()).To(Equal(expected))
+	})
 })
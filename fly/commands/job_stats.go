@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type JobStatsCommand struct {
+	Job   flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to show statistics for"`
+	Since string              `long:"since" description:"Only consider builds created at or after this time (default: 30 days before --until)"`
+	Until string              `long:"until" description:"Only consider builds created before this time (default: now)"`
+	Json  bool                `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *JobStatsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var since, until time.Time
+
+	if command.Since != "" {
+		since, err = time.ParseInLocation(inputTimeLayout, command.Since, time.Now().Location())
+		if err != nil {
+			return errors.New("Since time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if command.Until != "" {
+		until, err = time.ParseInLocation(inputTimeLayout, command.Until, time.Now().Location())
+		if err != nil {
+			return errors.New("Until time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if command.Since != "" && command.Until != "" && since.After(until) {
+		return errors.New("Cannot have --since after --until")
+	}
+
+	stats, found, err := target.Team().JobStats(command.Job.PipelineRef, command.Job.JobName, since, until)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("%s/%s not found", command.Job.PipelineRef, command.Job.JobName)
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(stats)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	fmt.Printf("builds since %s: %d (%d succeeded, %d failed, %d errored, %d aborted)\n",
+		time.Unix(stats.Since, 0).Local().Format(timeDateLayout),
+		stats.Builds,
+		stats.Succeeded,
+		stats.Failed,
+		stats.Errored,
+		stats.Aborted,
+	)
+
+	fmt.Printf("success rate: %.1f%%\n", stats.SuccessRate*100)
+	fmt.Println()
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "count", Color: color.New(color.Bold)},
+			{Contents: "p50", Color: color.New(color.Bold)},
+			{Contents: "p95", Color: color.New(color.Bold)},
+			{Contents: "mean", Color: color.New(color.Bold)},
+			{Contents: "max", Color: color.New(color.Bold)},
+			{Contents: "init p50", Color: color.New(color.Bold)},
+		},
+	}
+
+	table.Data = append(table.Data,
+		durationStatsRow("build", stats.Duration, nil),
+		durationStatsRow("pending", stats.QueueTime, nil),
+	)
+
+	for _, step := range stats.Steps {
+		initialization := step.Initialization
+		table.Data = append(table.Data, durationStatsRow(step.Type+" "+step.Name, step.Duration, &initialization))
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func durationStatsRow(name string, stats atc.DurationStats, initialization *atc.DurationStats) ui.TableRow {
+	row := ui.TableRow{
+		{Contents: name},
+		{Contents: strconv.Itoa(stats.Count)},
+		durationCell(stats.Count, stats.P50),
+		durationCell(stats.Count, stats.P95),
+		durationCell(stats.Count, stats.Mean),
+		durationCell(stats.Count, stats.Max),
+	}
+
+	if initialization == nil {
+		row = append(row, durationCell(0, 0))
+	} else {
+		row = append(row, durationCell(initialization.Count, initialization.P50))
+	}
+
+	return row
+}
+
+func durationCell(count int, seconds float64) ui.TableCell {
+	if count == 0 {
+		return ui.TableCell{Contents: "n/a", Color: color.New(color.Faint)}
+	}
+
+	return ui.TableCell{Contents: time.Duration(seconds * float64(time.Second)).Round(time.Second).String()}
+}
@@ -21,7 +21,7 @@ func (command *OrderPipelinesCommand) Validate() ([]string, error) {
 	var pipelines []string
 
 	for _, p := range command.Pipelines {
-		err := p.Validate()
+		err := p.ValidateName()
 		if err != nil {
 			return nil, err
 		}
@@ -54,7 +54,14 @@ func (command *OrderPipelinesCommand) Execute(args []string) error {
 			return err
 		}
 
+		seen := map[string]bool{}
 		for _, p := range ps {
+			// instances of a pipeline are ordered together by name
+			if seen[p.Name] {
+				continue
+			}
+
+			seen[p.Name] = true
 			pipelines = append(pipelines, p.Name)
 		}
 		sort.Strings(pipelines)
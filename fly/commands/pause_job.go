@@ -22,13 +22,13 @@ func (command *PauseJobCommand) Execute(args []string) error {
 		return err
 	}
 
-	found, err := target.Team().PauseJob(command.Job.PipelineName, command.Job.JobName)
+	found, err := target.Team().PauseJob(command.Job.PipelineRef, command.Job.JobName)
 	if err != nil {
 		return err
 	}
 
 	if !found {
-		return fmt.Errorf("%s/%s not found\n", command.Job.PipelineName, command.Job.JobName)
+		return fmt.Errorf("%s/%s not found\n", command.Job.PipelineRef, command.Job.JobName)
 	}
 
 	fmt.Printf("paused '%s'\n", command.Job.JobName)
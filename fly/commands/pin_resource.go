@@ -31,7 +31,7 @@ func (command *PinResourceCommand) Execute([]string) error {
 	team := target.Team()
 
 	if command.Version != nil {
-		versions, _, found, err := team.ResourceVersions(command.Resource.PipelineName, command.Resource.ResourceName, concourse.Page{}, *command.Version)
+		versions, _, found, err := team.ResourceVersions(command.Resource.PipelineRef, command.Resource.ResourceName, concourse.Page{}, *command.Version)
 
 		if err != nil {
 			return err
@@ -47,7 +47,7 @@ func (command *PinResourceCommand) Execute([]string) error {
 		}
 
 		latestResourceVer := versions[0]
-		pinned, err := team.PinResourceVersion(command.Resource.PipelineName, command.Resource.ResourceName, latestResourceVer.ID)
+		pinned, err := team.PinResourceVersion(command.Resource.PipelineRef, command.Resource.ResourceName, latestResourceVer.ID)
 
 		if err != nil {
 			return err
@@ -59,14 +59,14 @@ func (command *PinResourceCommand) Execute([]string) error {
 				return err
 			}
 
-			fmt.Printf("pinned '%s/%s' with version %s\n", command.Resource.PipelineName, command.Resource.ResourceName, string(versionBytes))
+			fmt.Printf("pinned '%s/%s' with version %s\n", command.Resource.PipelineRef, command.Resource.ResourceName, string(versionBytes))
 		} else {
-			displayhelpers.Failf("could not pin '%s/%s', make sure the resource exists\n", command.Resource.PipelineName, command.Resource.ResourceName)
+			displayhelpers.Failf("could not pin '%s/%s', make sure the resource exists\n", command.Resource.PipelineRef, command.Resource.ResourceName)
 		}
 	}
 
 	if command.Comment != "" {
-		saved, err := team.SetPinComment(command.Resource.PipelineName, command.Resource.ResourceName, command.Comment)
+		saved, err := team.SetPinComment(command.Resource.PipelineRef, command.Resource.ResourceName, command.Comment)
 
 		if err != nil {
 			return err
@@ -75,7 +75,7 @@ func (command *PinResourceCommand) Execute([]string) error {
 		if saved {
 			fmt.Printf("pin comment '%s' is saved\n", command.Comment)
 		} else {
-			displayhelpers.Failf("could not save comment, make sure '%s/%s' is pinned\n", command.Resource.PipelineName, command.Resource.ResourceName)
+			displayhelpers.Failf("could not save comment, make sure '%s/%s' is pinned\n", command.Resource.PipelineRef, command.Resource.ResourceName)
 		}
 	}
 
@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+)
+
+type RejectBuildCommand struct {
+	Job     flaghelpers.JobFlag `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of a job to reject"`
+	Build   string              `short:"b" long:"build" required:"true" description:"If job is specified: build number to reject. If job not specified: build id"`
+	Step    string              `short:"s" long:"step" description:"Name of the approval step to reject, if the build is waiting for more than one"`
+	Comment string              `short:"c" long:"comment" description:"Comment explaining the rejection"`
+}
+
+func (command *RejectBuildCommand) Execute([]string) error {
+	err := decideApproval(command.Job, command.Build, atc.ApprovalDecision{
+		Name:    command.Step,
+		Comment: command.Comment,
+	}, false)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("build successfully rejected")
+	return nil
+}
@@ -19,7 +19,7 @@ func (command *RenamePipelineCommand) Validate() error {
 		return err
 	}
 
-	return command.Name.Validate()
+	return command.Name.ValidateName()
 }
 
 func (command *RenamePipelineCommand) Execute([]string) error {
@@ -38,16 +38,16 @@ func (command *RenamePipelineCommand) Execute([]string) error {
 		return err
 	}
 
-	oldName := string(command.Pipeline)
+	oldRef := command.Pipeline.Ref()
 	newName := string(command.Name)
 
-	found, err := target.Team().RenamePipeline(oldName, newName)
+	found, err := target.Team().RenamePipeline(oldRef, newName)
 	if err != nil {
 		return err
 	}
 
 	if !found {
-		displayhelpers.Failf("pipeline '%s' not found\n", oldName)
+		displayhelpers.Failf("pipeline '%s' not found\n", oldRef)
 		return nil
 	}
 
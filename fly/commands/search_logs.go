@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type SearchLogsCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Only search builds of this pipeline"`
+	Job      flaghelpers.JobFlag      `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Only search builds of this job"`
+	Status   []string                 `short:"s" long:"status" description:"Only search builds with this status. Can be specified multiple times."`
+	Since    string                   `long:"since" description:"Only search builds started at or after this time"`
+	Until    string                   `long:"until" description:"Only search builds started at or before this time"`
+	Count    int                      `short:"c" long:"count" default:"100" description:"Maximum number of matching log chunks to return"`
+	Json     bool                     `long:"json" description:"Print command result as JSON"`
+
+	Args struct {
+		Query string `positional-arg-name:"QUERY" required:"true" description:"Words to search the build logs for"`
+	} `positional-args:"yes"`
+}
+
+func (command *SearchLogsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	if command.Pipeline != "" && command.Job.JobName != "" {
+		return errors.New("Cannot specify both --pipeline and --job")
+	}
+
+	if command.Pipeline != "" {
+		err = command.Pipeline.ValidateName()
+		if err != nil {
+			return err
+		}
+	}
+
+	search := concourse.BuildLogSearch{
+		Query:        command.Args.Query,
+		PipelineName: string(command.Pipeline),
+		Statuses:     command.Status,
+		Limit:        command.Count,
+	}
+
+	if command.Job.JobName != "" {
+		search.PipelineName = command.Job.PipelineRef.Name
+		search.JobName = command.Job.JobName
+	}
+
+	if command.Since != "" {
+		search.From, err = time.ParseInLocation(inputTimeLayout, command.Since, time.Now().Location())
+		if err != nil {
+			return errors.New("Since time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if command.Until != "" {
+		search.To, err = time.ParseInLocation(inputTimeLayout, command.Until, time.Now().Location())
+		if err != nil {
+			return errors.New("Until time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if command.Since != "" && command.Until != "" && search.From.After(search.To) {
+		return errors.New("Cannot have --since after --until")
+	}
+
+	results, err := target.Team().SearchBuildLogs(search)
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(results)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "id", Color: color.New(color.Bold)},
+			{Contents: "pipeline/job", Color: color.New(color.Bold)},
+			{Contents: "build", Color: color.New(color.Bold)},
+			{Contents: "status", Color: color.New(color.Bold)},
+			{Contents: "step", Color: color.New(color.Bold)},
+			{Contents: "line", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, result := range results {
+		b := result.Build
+
+		var pipelineJobCell, buildCell ui.TableCell
+		if b.PipelineName == "" {
+			pipelineJobCell.Contents = "one-off"
+			buildCell.Contents = "n/a"
+		} else {
+			pipelineJobCell.Contents = fmt.Sprintf("%s/%s", b.PipelineName, b.JobName)
+			buildCell.Contents = b.Name
+		}
+
+		for _, line := range result.Lines {
+			table.Data = append(table.Data, []ui.TableCell{
+				{Contents: strconv.Itoa(b.ID)},
+				pipelineJobCell,
+				buildCell,
+				{Contents: b.Status},
+				{Contents: line.Origin},
+				{Contents: line.Line},
+			})
+		}
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type SecretAccessesCommand struct {
+	Team     string `long:"team" description:"Only list lookups made for this team"`
+	Pipeline string `long:"pipeline" description:"Only list lookups made for this pipeline"`
+	Job      string `long:"job" description:"Only list lookups made for this job"`
+	BuildID  int    `long:"build-id" description:"Only list lookups made for the build with this ID"`
+	Variable string `long:"variable" description:"Only list lookups of this variable"`
+	Manager  string `long:"manager" description:"Only list lookups in this type of credential manager"`
+	Since    string `long:"since" description:"Only list lookups made at or after this time"`
+	Until    string `long:"until" description:"Only list lookups made before this time"`
+	Count    int    `short:"c" long:"count" default:"50" description:"Number of lookups you want to limit the return to"`
+	Json     bool   `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *SecretAccessesCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	filter := concourse.SecretAccessFilter{
+		Team:     command.Team,
+		Pipeline: command.Pipeline,
+		Job:      command.Job,
+		BuildID:  command.BuildID,
+		Variable: command.Variable,
+		Manager:  command.Manager,
+		Limit:    command.Count,
+	}
+
+	if command.Since != "" {
+		filter.Since, err = time.ParseInLocation(inputTimeLayout, command.Since, time.Now().Location())
+		if err != nil {
+			return errors.New("Since time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if command.Until != "" {
+		filter.Until, err = time.ParseInLocation(inputTimeLayout, command.Until, time.Now().Location())
+		if err != nil {
+			return errors.New("Until time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if command.Since != "" && command.Until != "" && filter.Since.After(filter.Until) {
+		return errors.New("Cannot have --since after --until")
+	}
+
+	accesses, err := target.Client().ListSecretAccesses(filter)
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(accesses)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	headers := ui.TableRow{
+		{Contents: "time", Color: color.New(color.Bold)},
+		{Contents: "team", Color: color.New(color.Bold)},
+		{Contents: "pipeline/job", Color: color.New(color.Bold)},
+		{Contents: "build id", Color: color.New(color.Bold)},
+		{Contents: "variable", Color: color.New(color.Bold)},
+		{Contents: "manager", Color: color.New(color.Bold)},
+		{Contents: "cached", Color: color.New(color.Bold)},
+		{Contents: "result", Color: color.New(color.Bold)},
+	}
+
+	table := ui.Table{Headers: headers}
+
+	for _, access := range accesses {
+		var pipelineJobCell, buildIDCell ui.TableCell
+		if access.PipelineName == "" {
+			pipelineJobCell.Contents = "none"
+			pipelineJobCell.Color = color.New(color.Faint)
+		} else if access.JobName == "" {
+			pipelineJobCell.Contents = access.PipelineName
+		} else {
+			pipelineJobCell.Contents = access.PipelineName + "/" + access.JobName
+		}
+
+		if access.BuildID == 0 {
+			buildIDCell.Contents = "none"
+			buildIDCell.Color = color.New(color.Faint)
+		} else {
+			buildIDCell.Contents = strconv.Itoa(access.BuildID)
+		}
+
+		manager := access.Manager
+		if access.Source != "" {
+			manager += " (" + access.Source + ")"
+		}
+
+		var resultCell ui.TableCell
+		switch {
+		case access.Error != "":
+			resultCell.Contents = "error"
+			resultCell.Color = color.New(color.FgRed)
+		case access.Found:
+			resultCell.Contents = "found"
+			resultCell.Color = color.New(color.FgGreen)
+		default:
+			resultCell.Contents = "not found"
+			resultCell.Color = color.New(color.FgYellow)
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: time.Unix(access.Time, 0).Local().Format(timeDateLayout)},
+			{Contents: access.TeamName},
+			pipelineJobCell,
+			buildIDCell,
+			{Contents: access.Variable},
+			{Contents: manager},
+			{Contents: strconv.FormatBool(access.CacheHit)},
+			resultCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type SemaphoresCommand struct {
+	Json bool `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *SemaphoresCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	semaphores, err := target.Team().Semaphores()
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(semaphores)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "capacity", Color: color.New(color.Bold)},
+			{Contents: "holders", Color: color.New(color.Bold)},
+			{Contents: "waiting", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, semaphore := range semaphores {
+		table.Data = append(table.Data, []ui.TableCell{
+			{Contents: semaphore.Name},
+			{Contents: strconv.Itoa(semaphore.Capacity)},
+			semaphoreBuildsCell(semaphore.Holders),
+			semaphoreBuildsCell(semaphore.Waiting),
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func semaphoreBuildsCell(builds []atc.Build) ui.TableCell {
+	if len(builds) == 0 {
+		return ui.TableCell{Contents: "none", Color: color.New(color.Faint)}
+	}
+
+	names := []string{}
+	for _, b := range builds {
+		names = append(names, fmt.Sprintf("%s/%s #%s", b.PipelineName, b.JobName, b.Name))
+	}
+
+	return ui.TableCell{Contents: strings.Join(names, ", ")}
+}
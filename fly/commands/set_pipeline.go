@@ -35,7 +35,7 @@ func (command *SetPipelineCommand) Execute(args []string) error {
 	}
 	configPath := command.Config
 	templateVariablesFiles := command.VarsFrom
-	pipelineName := string(command.Pipeline)
+	pipelineRef := command.Pipeline.Ref()
 
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
@@ -51,7 +51,7 @@ func (command *SetPipelineCommand) Execute(args []string) error {
 
 	atcConfig := setpipelinehelpers.ATCConfig{
 		Team:             target.Team(),
-		PipelineName:     pipelineName,
+		PipelineRef:      pipelineRef,
 		TargetName:       Fly.Target,
 		Target:           target.Client().URL(),
 		SkipInteraction:  command.SkipInteractive,
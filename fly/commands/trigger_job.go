@@ -6,6 +6,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/eventstream"
 	"github.com/concourse/concourse/fly/rc"
@@ -13,12 +14,13 @@ import (
 )
 
 type TriggerJobCommand struct {
-	Job   flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to trigger"`
-	Watch bool                `short:"w" long:"watch" description:"Start watching the build output"`
+	Job      flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to trigger"`
+	Watch    bool                `short:"w" long:"watch" description:"Start watching the build output"`
+	Priority *int                `long:"priority" description:"Priority of the build in the queue, overriding the job's configured priority"`
 }
 
 func (command *TriggerJobCommand) Execute(args []string) error {
-	pipelineName, jobName := command.Job.PipelineName, command.Job.JobName
+	pipelineRef, jobName := command.Job.PipelineRef, command.Job.JobName
 
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
@@ -30,11 +32,16 @@ func (command *TriggerJobCommand) Execute(args []string) error {
 		return err
 	}
 
-	build, err := target.Team().CreateJobBuild(pipelineName, jobName)
+	var build atc.Build
+	if command.Priority != nil {
+		build, err = target.Team().CreateJobBuildWithPriority(pipelineRef, jobName, *command.Priority)
+	} else {
+		build, err = target.Team().CreateJobBuild(pipelineRef, jobName)
+	}
 	if err != nil {
 		return err
 	}
-	fmt.Printf("started %s/%s #%s\n", pipelineName, jobName, build.Name)
+	fmt.Printf("started %s/%s #%s\n", pipelineRef, jobName, build.Name)
 
 	if command.Watch {
 		terminate := make(chan os.Signal, 1)
@@ -43,7 +50,7 @@ func (command *TriggerJobCommand) Execute(args []string) error {
 			<-terminate
 			fmt.Fprintf(ui.Stderr, "\ndetached, build is still running...\n")
 			fmt.Fprintf(ui.Stderr, "re-attach to it with:\n\n")
-			fmt.Fprintf(ui.Stderr, "    "+ui.Embolden(fmt.Sprintf("fly -t %s watch -j %s/%s -b %s\n\n", Fly.Target, pipelineName, jobName, build.Name)))
+			fmt.Fprintf(ui.Stderr, "    "+ui.Embolden(fmt.Sprintf("fly -t %s watch -j %s/%s -b %s\n\n", Fly.Target, pipelineRef, jobName, build.Name)))
 			os.Exit(2)
 		}(terminate)
 
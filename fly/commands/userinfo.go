@@ -12,7 +12,8 @@ import (
 )
 
 type UserinfoCommand struct {
-	Json bool `long:"json" description:"Print command result as JSON"`
+	Json        bool `long:"json" description:"Print command result as JSON"`
+	Permissions bool `short:"p" long:"permissions" description:"Print the actions permitted by each of the user's team roles"`
 }
 
 func (command *UserinfoCommand) Execute([]string) error {
@@ -31,6 +32,10 @@ func (command *UserinfoCommand) Execute([]string) error {
 		return err
 	}
 
+	if command.Permissions {
+		return command.printPermissions(target, userinfo)
+	}
+
 	if command.Json {
 		err = displayhelpers.JsonPrint(userinfo)
 		if err != nil {
@@ -65,3 +70,56 @@ func (command *UserinfoCommand) Execute([]string) error {
 
 	return table.Render(os.Stdout, Fly.PrintTableHeaders)
 }
+
+func (command *UserinfoCommand) printPermissions(target rc.Target, userinfo map[string]interface{}) error {
+	roleActions, err := target.Client().RoleActions()
+	if err != nil {
+		return err
+	}
+
+	permissions := map[string]map[string][]string{}
+
+	teams := userinfo["teams"].(map[string]interface{})
+	for team, roles := range teams {
+		permissions[team] = map[string][]string{}
+
+		for _, role := range roles.([]interface{}) {
+			actions, found := roleActions[role.(string)]
+			if !found {
+				actions = []string{}
+			}
+
+			permissions[team][role.(string)] = actions
+		}
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(permissions)
+	}
+
+	headers := ui.TableRow{
+		{Contents: "team/role", Color: color.New(color.Bold)},
+		{Contents: "actions", Color: color.New(color.Bold)},
+	}
+
+	table := ui.Table{Headers: headers}
+
+	for team, roles := range permissions {
+		for role, actions := range roles {
+			actionsCell := ui.TableCell{Contents: strings.Join(actions, ",")}
+			if len(actions) == 0 {
+				actionsCell.Contents = "none"
+				actionsCell.Color = color.New(color.Faint)
+			}
+
+			table.Data = append(table.Data, ui.TableRow{
+				{Contents: team + "/" + role},
+				actionsCell,
+			})
+		}
+	}
+
+	sort.Sort(table.Data)
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
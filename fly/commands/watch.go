@@ -30,7 +30,7 @@ func (command *WatchCommand) Execute(args []string) error {
 	var buildId int
 	client := target.Client()
 	if command.Job.JobName != "" || command.Build == "" {
-		build, err := GetBuild(client, target.Team(), command.Job.JobName, command.Build, command.Job.PipelineName)
+		build, err := GetBuild(client, target.Team(), command.Job.JobName, command.Build, command.Job.PipelineRef)
 		if err != nil {
 			return err
 		}
@@ -54,6 +54,13 @@ func (command *WatchCommand) Execute(args []string) error {
 
 	eventSource.Close()
 
+	// the build has finished by now; summarize any tests it reported, but
+	// don't let failing to do so affect the exit status
+	results, found, err := client.BuildTestResults(fmt.Sprintf("%d", buildId))
+	if err == nil && found && results.Summary.Total > 0 {
+		fmt.Fprintf(os.Stdout, "tests: %s\n", results.Summary)
+	}
+
 	os.Exit(exitCode)
 
 	return nil
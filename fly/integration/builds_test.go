@@ -439,6 +439,176 @@ var _ = Describe("Fly CLI", func() {
 				})
 			})
 
+			Context("when builds have reported test results", func() {
+				BeforeEach(func() {
+					returnedBuilds = []atc.Build{
+						{
+							ID:           4,
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							Name:         "64",
+							Status:       "failed",
+							StartTime:    succeededBuildStartTime.Unix(),
+							EndTime:      succeededBuildEndTime.Unix(),
+							TestSummary:  &atc.TestSummary{Total: 3, Passed: 2, Failed: 1},
+						},
+						{
+							ID:           3,
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							Name:         "63",
+							Status:       "succeeded",
+							StartTime:    succeededBuildStartTime.Unix(),
+							EndTime:      succeededBuildEndTime.Unix(),
+						},
+					}
+				})
+
+				It("shows a summary of each build's tests", func() {
+					Eventually(session.Out).Should(PrintTable(ui.Table{
+						Headers: append(expectedHeaders, ui.TableCell{Contents: "tests", Color: color.New(color.Bold)}),
+						Data: []ui.TableRow{
+							{
+								{Contents: "4"},
+								{Contents: "some-pipeline/some-job"},
+								{Contents: "64"},
+								{Contents: "failed"},
+								{Contents: succeededBuildStartTime.Local().Format(timeDateLayout)},
+								{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
+								{Contents: "1h15m0s"},
+								{Contents: ""},
+								{Contents: "2 passed, 1 failed"},
+							},
+							{
+								{Contents: "3"},
+								{Contents: "some-pipeline/some-job"},
+								{Contents: "63"},
+								{Contents: "succeeded"},
+								{Contents: succeededBuildStartTime.Local().Format(timeDateLayout)},
+								{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
+								{Contents: "1h15m0s"},
+								{Contents: ""},
+								{Contents: "n/a"},
+							},
+						},
+					}))
+					Eventually(session).Should(gexec.Exit(0))
+				})
+			})
+
+			Context("when builds have a priority", func() {
+				BeforeEach(func() {
+					returnedBuilds = []atc.Build{
+						{
+							ID:           4,
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							Name:         "64",
+							Status:       "pending",
+							Priority:     10,
+						},
+						{
+							ID:           3,
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							Name:         "63",
+							Status:       "succeeded",
+							StartTime:    succeededBuildStartTime.Unix(),
+							EndTime:      succeededBuildEndTime.Unix(),
+						},
+					}
+				})
+
+				It("shows the priority of each build", func() {
+					Eventually(session.Out).Should(PrintTable(ui.Table{
+						Headers: append(expectedHeaders, ui.TableCell{Contents: "priority", Color: color.New(color.Bold)}),
+						Data: []ui.TableRow{
+							{
+								{Contents: "4"},
+								{Contents: "some-pipeline/some-job"},
+								{Contents: "64"},
+								{Contents: "pending"},
+								{Contents: "n/a"},
+								{Contents: "n/a"},
+								{Contents: "n/a"},
+								{Contents: ""},
+								{Contents: "10"},
+							},
+							{
+								{Contents: "3"},
+								{Contents: "some-pipeline/some-job"},
+								{Contents: "63"},
+								{Contents: "succeeded"},
+								{Contents: succeededBuildStartTime.Local().Format(timeDateLayout)},
+								{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
+								{Contents: "1h15m0s"},
+								{Contents: ""},
+								{Contents: "0"},
+							},
+						},
+					}))
+					Eventually(session).Should(gexec.Exit(0))
+				})
+			})
+
+			Context("when builds record why they were created", func() {
+				BeforeEach(func() {
+					returnedBuilds = []atc.Build{
+						{
+							ID:           4,
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							Name:         "64",
+							Status:       "pending",
+							CreatedBy: &atc.BuildCreator{
+								Type: atc.BuildCreatorManual,
+								User: "some-user",
+							},
+						},
+						{
+							ID:           3,
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							Name:         "63",
+							Status:       "succeeded",
+							StartTime:    succeededBuildStartTime.Unix(),
+							EndTime:      succeededBuildEndTime.Unix(),
+						},
+					}
+				})
+
+				It("shows what created each build", func() {
+					Eventually(session.Out).Should(PrintTable(ui.Table{
+						Headers: append(expectedHeaders, ui.TableCell{Contents: "created by", Color: color.New(color.Bold)}),
+						Data: []ui.TableRow{
+							{
+								{Contents: "4"},
+								{Contents: "some-pipeline/some-job"},
+								{Contents: "64"},
+								{Contents: "pending"},
+								{Contents: "n/a"},
+								{Contents: "n/a"},
+								{Contents: "n/a"},
+								{Contents: ""},
+								{Contents: "manual by some-user"},
+							},
+							{
+								{Contents: "3"},
+								{Contents: "some-pipeline/some-job"},
+								{Contents: "63"},
+								{Contents: "succeeded"},
+								{Contents: succeededBuildStartTime.Local().Format(timeDateLayout)},
+								{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
+								{Contents: "1h15m0s"},
+								{Contents: ""},
+								{Contents: "n/a"},
+							},
+						},
+					}))
+					Eventually(session).Should(gexec.Exit(0))
+				})
+			})
+
 			Context("and time range", func() {
 				BeforeEach(func() {
 					since := time.Date(2020, 11, 1, 0, 0, 0, 0, time.Now().Location())
@@ -0,0 +1,183 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("DownloadArtifact", func() {
+	var (
+		outputDir string
+		flyCmd    *exec.Cmd
+	)
+
+	BeforeEach(func() {
+		var err error
+		outputDir, err = ioutil.TempDir("", "fly-download-artifact")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(outputDir)
+	})
+
+	Context("when the build has archived artifacts", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 23, Name: "42"}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23/archived-artifacts"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.ArchivedArtifact{
+						{PlanID: "some-plan", Name: "some-artifact", Size: 1024},
+						{PlanID: "some-other-plan", Name: "some-other-artifact", Size: 2048},
+					}),
+				),
+			)
+		})
+
+		Context("when no artifact is specified", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/23/archived-artifacts/some-plan/some-artifact"),
+						tarHandler,
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/23/archived-artifacts/some-other-plan/some-other-artifact"),
+						tarHandler,
+					),
+				)
+
+				flyCmd = exec.Command(flyPath, "-t", targetName, "download-artifact", "-b", "23", "-o", outputDir)
+			})
+
+			It("extracts every artifact into the output directory", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("downloading some-artifact"))
+				Expect(sess.Out).To(gbytes.Say("downloading some-other-artifact"))
+
+				contents, err := ioutil.ReadFile(filepath.Join(outputDir, "some-artifact", "some-file"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal("tar-contents"))
+
+				contents, err = ioutil.ReadFile(filepath.Join(outputDir, "some-other-artifact", "some-file"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal("tar-contents"))
+			})
+		})
+
+		Context("when an artifact is specified", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/23/archived-artifacts/some-other-plan/some-other-artifact"),
+						tarHandler,
+					),
+				)
+
+				flyCmd = exec.Command(flyPath, "-t", targetName, "download-artifact", "-b", "23", "-a", "some-other-artifact", "-o", outputDir)
+			})
+
+			It("extracts only that artifact", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(filepath.Join(outputDir, "some-other-artifact", "some-file")).To(BeAnExistingFile())
+				Expect(filepath.Join(outputDir, "some-artifact")).ToNot(BeAnExistingFile())
+			})
+		})
+
+		Context("when the specified artifact was not archived", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "download-artifact", "-b", "23", "-a", "bogus-artifact", "-o", outputDir)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("build has no archived artifact named 'bogus-artifact'"))
+			})
+		})
+	})
+
+	Context("when several steps archived an artifact under the same name", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 23, Name: "42"}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23/archived-artifacts"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.ArchivedArtifact{
+						{PlanID: "some-plan", Name: "some-artifact", Size: 1024},
+						{PlanID: "some-other-plan", Name: "some-artifact", Size: 2048},
+					}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23/archived-artifacts/some-plan/some-artifact"),
+					tarHandler,
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23/archived-artifacts/some-other-plan/some-artifact"),
+					tarHandler,
+				),
+			)
+
+			flyCmd = exec.Command(flyPath, "-t", targetName, "download-artifact", "-b", "23", "-a", "some-artifact", "-o", outputDir)
+		})
+
+		It("extracts each of them under its plan ID", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(filepath.Join(outputDir, "some-plan", "some-artifact", "some-file")).To(BeAnExistingFile())
+			Expect(filepath.Join(outputDir, "some-other-plan", "some-artifact", "some-file")).To(BeAnExistingFile())
+		})
+	})
+
+	Context("when the build does not exist", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/42"),
+					ghttp.RespondWith(http.StatusNotFound, ""),
+				),
+			)
+
+			flyCmd = exec.Command(flyPath, "-t", targetName, "download-artifact", "-b", "42")
+		})
+
+		It("errors", func() {
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+			Expect(sess.Err).To(gbytes.Say("build does not exist"))
+		})
+	})
+})
@@ -0,0 +1,141 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("encryption-status", func() {
+		var (
+			flyCmd      *exec.Cmd
+			expectedURL string
+		)
+
+		BeforeEach(func() {
+			expectedURL = "/api/v1/encryption"
+
+			flyCmd = exec.Command(flyPath, "-t", targetName, "encryption-status")
+		})
+
+		Context("when data is being re-encrypted", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.EncryptionStatus{
+							KeyID:     "new-key",
+							OldKeyIDs: []string{"old-key"},
+							Tables: []atc.EncryptedTableStatus{
+								{
+									Table:         "jobs",
+									Column:        "config",
+									Legacy:        1,
+									Keys:          map[string]int{"new-key": 2, "old-key": 3},
+									Undecryptable: 1,
+									Remaining:     3,
+								},
+								{
+									Table:  "resources",
+									Column: "config",
+									Keys:   map[string]int{"new-key": 5},
+								},
+							},
+						}),
+					),
+				)
+			})
+
+			It("prints the keys and the progress of each table", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say("encryption key: new-key"))
+				Expect(sess.Out).To(gbytes.Say("re-encrypting from: old-key"))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "table", Color: color.New(color.Bold)},
+						{Contents: "plaintext", Color: color.New(color.Bold)},
+						{Contents: "legacy", Color: color.New(color.Bold)},
+						{Contents: "current key", Color: color.New(color.Bold)},
+						{Contents: "other keys", Color: color.New(color.Bold)},
+						{Contents: "undecryptable", Color: color.New(color.Bold)},
+						{Contents: "remaining", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "jobs.config"},
+							{Contents: "0"},
+							{Contents: "1"},
+							{Contents: "2"},
+							{Contents: "3"},
+							{Contents: "1", Color: color.New(color.FgRed)},
+							{Contents: "3", Color: color.New(color.FgYellow)},
+						},
+						{
+							{Contents: "resources.config"},
+							{Contents: "0"},
+							{Contents: "0"},
+							{Contents: "5"},
+							{Contents: "0"},
+							{Contents: "0"},
+							{Contents: "0", Color: color.New(color.FgGreen)},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when encryption is disabled", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.EncryptionStatus{
+							Tables: []atc.EncryptedTableStatus{},
+						}),
+					),
+				)
+			})
+
+			It("says so", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("encryption is disabled"))
+			})
+		})
+
+		Context("when the user is not an admin", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusForbidden, nil),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("forbidden"))
+			})
+		})
+	})
+})
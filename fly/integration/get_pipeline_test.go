@@ -100,9 +100,9 @@ var _ = Describe("Fly CLI", func() {
 				})
 			})
 
-			Context("when specifying a pipeline name with a '/' character in it", func() {
-				It("fails and says '/' characters are not allowed", func() {
-					flyCmd := exec.Command(flyPath, "-t", targetName, "get-pipeline", "-p", "forbidden/pipelinename")
+			Context("when specifying malformed instance vars", func() {
+				It("fails and says the instance vars are malformed", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "get-pipeline", "-p", "some-pipeline/forbidden")
 
 					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
 					Expect(err).NotTo(HaveOccurred())
@@ -110,7 +110,7 @@ var _ = Describe("Fly CLI", func() {
 					<-sess.Exited
 					Expect(sess.ExitCode()).To(Equal(1))
 
-					Expect(sess.Err).To(gbytes.Say("error: pipeline name cannot contain '/'"))
+					Expect(sess.Err).To(gbytes.Say("error: argument format should be <pipeline>/<key:value>"))
 				})
 			})
 
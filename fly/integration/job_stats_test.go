@@ -0,0 +1,189 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"os/exec"
+	"regexp"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("job-stats", func() {
+		var (
+			flyCmd      *exec.Cmd
+			expectedURL string
+			since       time.Time
+		)
+
+		BeforeEach(func() {
+			expectedURL = "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/stats"
+			since = time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)
+
+			flyCmd = exec.Command(flyPath, "-t", targetName, "job-stats", "-j", "some-pipeline/some-job")
+		})
+
+		Context("when the job exists", func() {
+			var stats atc.JobStats
+
+			BeforeEach(func() {
+				stats = atc.JobStats{
+					Since:       since.Unix(),
+					Until:       since.Add(30 * 24 * time.Hour).Unix(),
+					Builds:      4,
+					Succeeded:   2,
+					Failed:      1,
+					Aborted:     1,
+					SuccessRate: 2.0 / 3.0,
+					Duration:    atc.DurationStats{Count: 3, Mean: 200, P50: 200, P95: 300, Max: 300},
+					QueueTime:   atc.DurationStats{Count: 3, Mean: 20, P50: 20, P95: 30, Max: 30},
+					Steps: []atc.StepStats{
+						{
+							Type:           "task",
+							Name:           "unit",
+							Initialization: atc.DurationStats{Count: 3, Mean: 10, P50: 10, P95: 10, Max: 10},
+							Duration:       atc.DurationStats{Count: 3, Mean: 120, P50: 120, P95: 180, Max: 180},
+						},
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, stats),
+					),
+				)
+			})
+
+			It("prints the job's stats", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say(fmt.Sprintf(
+					`builds since %s: 4 \(2 succeeded, 1 failed, 0 errored, 1 aborted\)`,
+					regexp.QuoteMeta(since.Local().Format(timeDateLayout)),
+				)))
+				Expect(sess.Out).To(gbytes.Say(`success rate: 66.7%`))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "name", Color: color.New(color.Bold)},
+						{Contents: "count", Color: color.New(color.Bold)},
+						{Contents: "p50", Color: color.New(color.Bold)},
+						{Contents: "p95", Color: color.New(color.Bold)},
+						{Contents: "mean", Color: color.New(color.Bold)},
+						{Contents: "max", Color: color.New(color.Bold)},
+						{Contents: "init p50", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "build"}, {Contents: "3"}, {Contents: "3m20s"}, {Contents: "5m0s"}, {Contents: "3m20s"}, {Contents: "5m0s"}, {Contents: "n/a"}},
+						{{Contents: "pending"}, {Contents: "3"}, {Contents: "20s"}, {Contents: "30s"}, {Contents: "20s"}, {Contents: "30s"}, {Contents: "n/a"}},
+						{{Contents: "task unit"}, {Contents: "3"}, {Contents: "2m0s"}, {Contents: "3m0s"}, {Contents: "2m0s"}, {Contents: "3m0s"}, {Contents: "10s"}},
+					},
+				}))
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints the stats as JSON", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`{
+						"since": ` + fmt.Sprint(stats.Since) + `,
+						"until": ` + fmt.Sprint(stats.Until) + `,
+						"builds": 4,
+						"succeeded": 2,
+						"failed": 1,
+						"errored": 0,
+						"aborted": 1,
+						"success_rate": 0.6666666666666666,
+						"duration": {"count": 3, "mean": 200, "p50": 200, "p95": 300, "max": 300},
+						"queue_time": {"count": 3, "mean": 20, "p50": 20, "p95": 30, "max": 30},
+						"steps": [
+							{
+								"type": "task",
+								"name": "unit",
+								"initialization": {"count": 3, "mean": 10, "p50": 10, "p95": 10, "max": 10},
+								"duration": {"count": 3, "mean": 120, "p50": 120, "p95": 180, "max": 180}
+							}
+						]
+					}`))
+				})
+			})
+		})
+
+		Context("when a time range is given", func() {
+			BeforeEach(func() {
+				until := since.Add(24 * time.Hour)
+
+				flyCmd.Args = append(flyCmd.Args,
+					"--since", since.Local().Format(timeLayout),
+					"--until", until.Local().Format(timeLayout),
+				)
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, fmt.Sprintf("since=%d&until=%d", since.Unix(), until.Unix())),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.JobStats{Since: since.Unix(), Until: until.Unix()}),
+					),
+				)
+			})
+
+			It("requests the stats for that time range", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when the job does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("some-pipeline/some-job not found"))
+			})
+		})
+
+		Context("when the time range is malformed", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--since", "yesterday")
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("Since time should be in the format: 2006-01-02 15:04:05"))
+			})
+		})
+	})
+})
@@ -0,0 +1,151 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("search-logs", func() {
+		var (
+			flyCmd  *exec.Cmd
+			results []atc.BuildLogSearchResult
+		)
+
+		BeforeEach(func() {
+			results = []atc.BuildLogSearchResult{
+				{
+					Build: atc.Build{
+						ID:           42,
+						Name:         "3",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						TeamName:     "main",
+						Status:       "failed",
+					},
+					Lines: []atc.BuildLogLine{
+						{EventID: 2, Origin: "some-task-id", Line: "Error: connection refused"},
+						{EventID: 5, Origin: "some-task-id", Line: "retrying: connection refused"},
+					},
+				},
+				{
+					Build: atc.Build{
+						ID:       43,
+						TeamName: "main",
+						Status:   "errored",
+					},
+					Lines: []atc.BuildLogLine{
+						{EventID: 1, Origin: "some-other-task-id", Line: "connection refused"},
+					},
+				},
+			}
+		})
+
+		Context("when only a query is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/logs/search", "q=connection+refused&limit=100"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, results),
+					),
+				)
+
+				flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "connection refused")
+			})
+
+			It("prints each matching line", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "id", Color: color.New(color.Bold)},
+						{Contents: "pipeline/job", Color: color.New(color.Bold)},
+						{Contents: "build", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+						{Contents: "step", Color: color.New(color.Bold)},
+						{Contents: "line", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "42"}, {Contents: "some-pipeline/some-job"}, {Contents: "3"}, {Contents: "failed"}, {Contents: "some-task-id"}, {Contents: "Error: connection refused"}},
+						{{Contents: "42"}, {Contents: "some-pipeline/some-job"}, {Contents: "3"}, {Contents: "failed"}, {Contents: "some-task-id"}, {Contents: "retrying: connection refused"}},
+						{{Contents: "43"}, {Contents: "one-off"}, {Contents: "n/a"}, {Contents: "errored"}, {Contents: "some-other-task-id"}, {Contents: "connection refused"}},
+					},
+				}))
+			})
+		})
+
+		Context("when filtering by job and status", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/logs/search"),
+						ghttp.VerifyForm(map[string][]string{
+							"q":             {"refused"},
+							"pipeline_name": {"some-pipeline"},
+							"job_name":      {"some-job"},
+							"status":        {"failed", "errored"},
+							"limit":         {"10"},
+						}),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, results),
+					),
+				)
+
+				flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "-j", "some-pipeline/some-job", "-s", "failed", "-s", "errored", "-c", "10", "refused")
+			})
+
+			It("passes the filters to the search", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(gbytes.Say("some-pipeline/some-job"))
+			})
+		})
+
+		Context("when both --pipeline and --job are given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "-p", "some-pipeline", "-j", "some-pipeline/some-job", "refused")
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("Cannot specify both --pipeline and --job"))
+			})
+		})
+
+		Context("when the search fails", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/logs/search"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+
+				flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "refused")
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+			})
+		})
+	})
+})
@@ -0,0 +1,149 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("secret-accesses", func() {
+		var (
+			flyCmd      *exec.Cmd
+			expectedURL string
+			accessTime  time.Time
+		)
+
+		BeforeEach(func() {
+			expectedURL = "/api/v1/secret_accesses"
+			accessTime = time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)
+
+			flyCmd = exec.Command(flyPath, "-t", targetName, "secret-accesses")
+		})
+
+		Context("when secrets have been looked up", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "limit=50"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.SecretAccess{
+							{
+								ID:           2,
+								Time:         accessTime.Unix(),
+								TeamName:     "main",
+								PipelineName: "some-pipeline",
+								JobName:      "some-job",
+								BuildID:      42,
+								Variable:     "foo",
+								Path:         "/concourse/main/foo",
+								Manager:      "vault",
+								CacheHit:     true,
+								Found:        true,
+							},
+							{
+								ID:       1,
+								Time:     accessTime.Unix(),
+								TeamName: "main",
+								Variable: "bar",
+								Path:     "bar",
+								Manager:  "some-type",
+								Source:   "some-source",
+								Error:    "some-error",
+							},
+						}),
+					),
+				)
+			})
+
+			It("prints the secret accesses", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "time", Color: color.New(color.Bold)},
+						{Contents: "team", Color: color.New(color.Bold)},
+						{Contents: "pipeline/job", Color: color.New(color.Bold)},
+						{Contents: "build id", Color: color.New(color.Bold)},
+						{Contents: "variable", Color: color.New(color.Bold)},
+						{Contents: "manager", Color: color.New(color.Bold)},
+						{Contents: "cached", Color: color.New(color.Bold)},
+						{Contents: "result", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: accessTime.Local().Format(timeDateLayout)},
+							{Contents: "main"},
+							{Contents: "some-pipeline/some-job"},
+							{Contents: "42"},
+							{Contents: "foo"},
+							{Contents: "vault"},
+							{Contents: "true"},
+							{Contents: "found", Color: color.New(color.FgGreen)},
+						},
+						{
+							{Contents: accessTime.Local().Format(timeDateLayout)},
+							{Contents: "main"},
+							{Contents: "none", Color: color.New(color.Faint)},
+							{Contents: "none", Color: color.New(color.Faint)},
+							{Contents: "bar"},
+							{Contents: "some-type (some-source)"},
+							{Contents: "false"},
+							{Contents: "error", Color: color.New(color.FgRed)},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when filters are given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--team", "main", "--variable", "foo", "--count", "5")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "limit=5&team=main&variable=foo"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.SecretAccess{}),
+					),
+				)
+			})
+
+			It("filters the secret accesses", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when the user is not an admin", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusForbidden, nil),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("forbidden"))
+			})
+		})
+	})
+})
@@ -0,0 +1,103 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("semaphores", func() {
+		var flyCmd *exec.Cmd
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "semaphores")
+		})
+
+		Context("when semaphores are returned from the API", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/semaphores"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.Semaphore{
+							{
+								Name:     "production",
+								Capacity: 1,
+								Holders:  []atc.Build{},
+								Waiting:  []atc.Build{},
+							},
+							{
+								Name:     "staging",
+								Capacity: 2,
+								Holders: []atc.Build{
+									{ID: 1, Name: "4", PipelineName: "some-pipeline", JobName: "deploy", Status: "started"},
+								},
+								Waiting: []atc.Build{
+									{ID: 2, Name: "7", PipelineName: "some-other-pipeline", JobName: "deploy", Status: "pending"},
+									{ID: 3, Name: "8", PipelineName: "some-other-pipeline", JobName: "deploy", Status: "pending"},
+								},
+							},
+						}),
+					),
+				)
+			})
+
+			It("lists the semaphores with their holders and waiting builds", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "name", Color: color.New(color.Bold)},
+						{Contents: "capacity", Color: color.New(color.Bold)},
+						{Contents: "holders", Color: color.New(color.Bold)},
+						{Contents: "waiting", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "production"},
+							{Contents: "1"},
+							{Contents: "none", Color: color.New(color.Faint)},
+							{Contents: "none", Color: color.New(color.Faint)},
+						},
+						{
+							{Contents: "staging"},
+							{Contents: "2"},
+							{Contents: "some-pipeline/deploy #4"},
+							{Contents: "some-other-pipeline/deploy #7, some-other-pipeline/deploy #8"},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when the API returns an error", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/semaphores"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("Unexpected Response"))
+			})
+		})
+	})
+})
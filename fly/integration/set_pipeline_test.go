@@ -741,9 +741,9 @@ this is super secure
 				})
 			})
 
-			Context("when specifying a pipeline name with a '/' character in it", func() {
-				It("fails and says '/' characters are not allowed", func() {
-					flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-p", "forbidden/pipelinename", "-c", configFile.Name())
+			Context("when specifying malformed instance vars", func() {
+				It("fails and says the instance vars are malformed", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-p", "some-pipeline/forbidden", "-c", configFile.Name())
 
 					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
 					Expect(err).NotTo(HaveOccurred())
@@ -751,7 +751,7 @@ this is super secure
 					<-sess.Exited
 					Expect(sess.ExitCode()).To(Equal(1))
 
-					Expect(sess.Err).To(gbytes.Say("error: pipeline name cannot contain '/'"))
+					Expect(sess.Err).To(gbytes.Say("error: argument format should be <pipeline>/<key:value>"))
 				})
 			})
 
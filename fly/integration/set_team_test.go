@@ -375,6 +375,59 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Describe("sending custom roles", func() {
+			BeforeEach(func() {
+				cmdParams = []string{"-c", "fixtures/team_config_with_custom_role.yml"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/venture"),
+						ghttp.VerifyJSON(`{
+							"auth": {
+								"owner":{
+									"users": [
+										"local:some-owner"
+									],
+									"groups": []
+								},
+								"pipeline-pinner":{
+									"users": [
+										"local:some-pinner"
+									],
+									"groups": []
+								}
+							}
+						}`),
+						ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.Team{
+							Name: "venture",
+							ID:   8,
+						}),
+					),
+				)
+			})
+
+			It("shows and sends the custom role", func() {
+				stdin, err := flyCmd.StdinPipe()
+				Expect(err).NotTo(HaveOccurred())
+
+				sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(sess.Out).Should(gbytes.Say("role owner:"))
+				Eventually(sess.Out).Should(gbytes.Say("- local:some-owner"))
+
+				Eventually(sess.Out).Should(gbytes.Say("role pipeline-pinner:"))
+				Eventually(sess.Out).Should(gbytes.Say("- local:some-pinner"))
+
+				Eventually(sess).Should(gbytes.Say(`apply team configuration\? \[yN\]: `))
+				yes(stdin)
+
+				Eventually(sess.Out).Should(gbytes.Say("team created"))
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
 		Describe("handling server response", func() {
 			BeforeEach(func() {
 				cmdParams = []string{"-c", "fixtures/team_config_mixed.yml"}
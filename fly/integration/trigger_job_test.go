@@ -130,6 +130,29 @@ var _ = Describe("Fly CLI", func() {
 				})
 			})
 
+			Context("when a priority is given", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("POST", path, "priority=10"),
+							ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 57, Name: "42", Priority: 10}),
+						),
+					)
+				})
+
+				It("starts the build with the priority", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "trigger-job", "-j", "awesome-pipeline/awesome-job", "--priority", "10")
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gbytes.Say(`started awesome-pipeline/awesome-job #42`))
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(0))
+				})
+			})
+
 			Context("when the pipeline/job doesn't exist", func() {
 				BeforeEach(func() {
 					atcServer.AppendHandlers(
@@ -204,6 +227,12 @@ var _ = Describe("Fly CLI", func() {
 
 			It("returns all matching jobs", func() {
 				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines"),
+						ghttp.RespondWithJSONEncoded(200, []atc.Pipeline{
+							{Name: "some-pipeline", Paused: false, Public: false},
+						}),
+					),
 					ghttp.CombineHandlers(
 						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/jobs"),
 						ghttp.RespondWithJSONEncoded(200, []atc.Job{
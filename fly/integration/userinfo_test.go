@@ -75,6 +75,73 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Context("when --permissions is given", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "--permissions")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/sky/userinfo"),
+						ghttp.RespondWithJSONEncoded(200, map[string]interface{}{
+							"user_name": "test_user",
+							"teams": map[string][]string{
+								"other_team": {"pipeline-pinner"},
+								"test_team":  {"viewer", "unknown-role"},
+							},
+						}),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/info/roles"),
+						ghttp.RespondWithJSONEncoded(200, map[string][]string{
+							"owner":           {"GetPipeline", "SetTeam"},
+							"viewer":          {"GetPipeline"},
+							"pipeline-pinner": {"CreateJobBuild", "PinResourceVersion"},
+						}),
+					),
+				)
+			})
+
+			It("shows the actions permitted by each team role", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "team/role", Color: color.New(color.Bold)},
+						{Contents: "actions", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "other_team/pipeline-pinner"}, {Contents: "CreateJobBuild,PinResourceVersion"}},
+						{{Contents: "test_team/unknown-role"}, {Contents: "none", Color: color.New(color.Faint)}},
+						{{Contents: "test_team/viewer"}, {Contents: "GetPipeline"}},
+					},
+				}))
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints the permissions in json as stdout", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`{
+							"other_team": {
+								"pipeline-pinner": ["CreateJobBuild", "PinResourceVersion"]
+							},
+							"test_team": {
+								"unknown-role": [],
+								"viewer": ["GetPipeline"]
+							}
+					}`))
+				})
+			})
+		})
+
 		Context("and the api returns an internal server error", func() {
 			BeforeEach(func() {
 				atcServer.AppendHandlers(
@@ -70,6 +70,13 @@ var _ = Describe("Watching", func() {
 		)
 	}
 
+	testResultsHandler := func(results atc.BuildTestResults) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest("GET", "/api/v1/builds/3/tests"),
+			ghttp.RespondWithJSONEncoded(200, results),
+		)
+	}
+
 	watch := func(args ...string) {
 		watchWithArgs := append([]string{"watch"}, args...)
 
@@ -103,6 +110,7 @@ var _ = Describe("Watching", func() {
 					}),
 				),
 				eventsHandler(),
+				testResultsHandler(atc.BuildTestResults{}),
 			)
 		})
 
@@ -115,6 +123,7 @@ var _ = Describe("Watching", func() {
 		BeforeEach(func() {
 			atcServer.AppendHandlers(
 				eventsHandler(),
+				testResultsHandler(atc.BuildTestResults{}),
 			)
 		})
 
@@ -170,6 +179,7 @@ var _ = Describe("Watching", func() {
 						}),
 					),
 					eventsHandler(),
+					testResultsHandler(atc.BuildTestResults{}),
 				)
 			})
 
@@ -194,6 +204,7 @@ var _ = Describe("Watching", func() {
 						}),
 					),
 					eventsHandler(),
+					testResultsHandler(atc.BuildTestResults{}),
 				)
 			})
 
@@ -215,6 +226,7 @@ var _ = Describe("Watching", func() {
 						}),
 					),
 					eventsHandler(),
+					testResultsHandler(atc.BuildTestResults{}),
 				)
 			})
 
@@ -223,4 +235,34 @@ var _ = Describe("Watching", func() {
 			})
 		})
 	})
+
+	Context("when the build reported test results", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				eventsHandler(),
+				testResultsHandler(atc.BuildTestResults{
+					Summary: atc.TestSummary{Total: 3, Passed: 2, Failed: 1},
+				}),
+			)
+		})
+
+		It("prints a summary of the tests after the build's output", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "watch", "--build", "3")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(streaming).Should(BeClosed())
+
+			events <- event.Log{Payload: "sup"}
+
+			Eventually(sess.Out).Should(gbytes.Say("sup"))
+
+			close(events)
+
+			<-sess.Exited
+			Expect(sess.Out).To(gbytes.Say("tests: 2 passed, 1 failed"))
+			Expect(sess.ExitCode()).To(Equal(0))
+		})
+	})
 })
@@ -0,0 +1,67 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) BuildApprovals(buildID string) ([]atc.BuildApproval, bool, error) {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	var approvals []atc.BuildApproval
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListBuildApprovals,
+		Params:      params,
+	}, &internal.Response{
+		Result: &approvals,
+	})
+	switch err.(type) {
+	case nil:
+		return approvals, true, nil
+	case internal.ResourceNotFoundError:
+		return approvals, false, nil
+	default:
+		return approvals, false, err
+	}
+}
+
+func (client *client) ApproveBuild(buildID string, decision atc.ApprovalDecision) (bool, error) {
+	return client.decideApproval(atc.ApproveBuild, buildID, decision)
+}
+
+func (client *client) RejectBuild(buildID string, decision atc.ApprovalDecision) (bool, error) {
+	return client.decideApproval(atc.RejectBuild, buildID, decision)
+}
+
+func (client *client) decideApproval(requestName string, buildID string, decision atc.ApprovalDecision) (bool, error) {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	jsonBytes, err := json.Marshal(decision)
+	if err != nil {
+		return false, err
+	}
+
+	err = client.connection.Send(internal.Request{
+		RequestName: requestName,
+		Params:      params,
+		Body:        bytes.NewBuffer(jsonBytes),
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
+	}, nil)
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
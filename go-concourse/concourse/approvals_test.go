@@ -0,0 +1,127 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Approvals", func() {
+	Describe("BuildApprovals", func() {
+		Context("when the build exists", func() {
+			var expectedApprovals []atc.BuildApproval
+
+			BeforeEach(func() {
+				expectedApprovals = []atc.BuildApproval{
+					{BuildID: 42, PlanID: "some-plan", Name: "deploy", Status: atc.ApprovalStatusPending},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/42/approvals"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedApprovals),
+					),
+				)
+			})
+
+			It("returns the approvals", func() {
+				approvals, found, err := client.BuildApprovals("42")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(approvals).To(Equal(expectedApprovals))
+			})
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/42/approvals"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := client.BuildApprovals("42")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("ApproveBuild", func() {
+		Context("when the build is waiting for approval", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/builds/42/approve"),
+						ghttp.VerifyJSONRepresenting(atc.ApprovalDecision{Name: "deploy", Comment: "ship it"}),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("approves the build", func() {
+				found, err := client.ApproveBuild("42", atc.ApprovalDecision{Name: "deploy", Comment: "ship it"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("when the build is not waiting for approval", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/builds/42/approve"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				found, err := client.ApproveBuild("42", atc.ApprovalDecision{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the user may not decide", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/builds/42/approve"),
+						ghttp.RespondWith(http.StatusForbidden, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				_, err := client.ApproveBuild("42", atc.ApprovalDecision{})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("RejectBuild", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/42/reject"),
+					ghttp.VerifyJSONRepresenting(atc.ApprovalDecision{Comment: "not today"}),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("rejects the build", func() {
+			found, err := client.RejectBuild("42", atc.ApprovalDecision{Comment: "not today"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,47 @@
+package concourse
+
+import (
+	"io"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) ListArchivedArtifacts(buildID string) ([]atc.ArchivedArtifact, error) {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	var artifacts []atc.ArchivedArtifact
+
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListArchivedArtifacts,
+		Params:      params,
+	}, &internal.Response{
+		Result: &artifacts,
+	})
+
+	return artifacts, err
+}
+
+func (client *client) GetArchivedArtifact(buildID string, planID atc.PlanID, name string) (io.ReadCloser, error) {
+	params := rata.Params{
+		"build_id":      buildID,
+		"plan_id":       string(planID),
+		"artifact_name": name,
+	}
+
+	response := internal.Response{}
+	err := client.connection.Send(internal.Request{
+		RequestName:        atc.GetArchivedArtifact,
+		Params:             params,
+		ReturnResponseBody: true,
+	}, &response)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Result.(io.ReadCloser), nil
+}
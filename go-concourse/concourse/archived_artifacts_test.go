@@ -0,0 +1,87 @@
+package concourse_test
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Archived Artifacts", func() {
+	Describe("ListArchivedArtifacts", func() {
+		Context("when the build has archived artifacts", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/42/archived-artifacts"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.ArchivedArtifact{
+							{Name: "some-artifact", Size: 1024, CreatedAt: 1},
+						}),
+					),
+				)
+			})
+
+			It("returns the artifacts", func() {
+				artifacts, err := client.ListArchivedArtifacts("42")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(artifacts).To(Equal([]atc.ArchivedArtifact{
+					{Name: "some-artifact", Size: 1024, CreatedAt: 1},
+				}))
+			})
+		})
+
+		Context("when listing the artifacts fails", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/42/archived-artifacts"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				_, err := client.ListArchivedArtifacts("42")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("GetArchivedArtifact", func() {
+		Context("when the artifact exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/42/archived-artifacts/some-plan/some-artifact"),
+						ghttp.RespondWith(http.StatusOK, "some-contents"),
+					),
+				)
+			})
+
+			It("returns the contents", func() {
+				contents, err := client.GetArchivedArtifact("42", "some-plan", "some-artifact")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.ReadAll(contents)).To(Equal([]byte("some-contents")))
+			})
+		})
+
+		Context("when the artifact does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/42/archived-artifacts/some-plan/some-artifact"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				_, err := client.GetArchivedArtifact("42", "some-plan", "some-artifact")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
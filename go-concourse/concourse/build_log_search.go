@@ -0,0 +1,68 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+type BuildLogSearch struct {
+	Query        string
+	PipelineName string
+	JobName      string
+	Statuses     []string
+	From         time.Time
+	To           time.Time
+	Limit        int
+}
+
+func (search BuildLogSearch) QueryParams() url.Values {
+	queryParams := url.Values{}
+	queryParams.Add(atc.BuildLogSearchQuery, search.Query)
+
+	if search.PipelineName != "" {
+		queryParams.Add(atc.BuildLogSearchPipeline, search.PipelineName)
+	}
+
+	if search.JobName != "" {
+		queryParams.Add(atc.BuildLogSearchJob, search.JobName)
+	}
+
+	for _, status := range search.Statuses {
+		queryParams.Add(atc.BuildLogSearchStatus, status)
+	}
+
+	if !search.From.IsZero() {
+		queryParams.Add(atc.BuildLogSearchFrom, strconv.FormatInt(search.From.Unix(), 10))
+	}
+
+	if !search.To.IsZero() {
+		queryParams.Add(atc.BuildLogSearchTo, strconv.FormatInt(search.To.Unix(), 10))
+	}
+
+	if search.Limit > 0 {
+		queryParams.Add(atc.BuildLogSearchLimit, strconv.Itoa(search.Limit))
+	}
+
+	return queryParams
+}
+
+func (team *team) SearchBuildLogs(search BuildLogSearch) ([]atc.BuildLogSearchResult, error) {
+	var results []atc.BuildLogSearchResult
+
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.SearchBuildLogs,
+		Params: rata.Params{
+			"team_name": team.name,
+		},
+		Query: search.QueryParams(),
+	}, &internal.Response{
+		Result: &results,
+	})
+
+	return results, err
+}
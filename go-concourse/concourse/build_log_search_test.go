@@ -0,0 +1,114 @@
+package concourse_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Log Search", func() {
+	Describe("team.SearchBuildLogs", func() {
+		expectedURL := "/api/v1/teams/some-team/logs/search"
+
+		var (
+			search          concourse.BuildLogSearch
+			expectedResults []atc.BuildLogSearchResult
+
+			results   []atc.BuildLogSearchResult
+			searchErr error
+		)
+
+		BeforeEach(func() {
+			search = concourse.BuildLogSearch{Query: "connection refused"}
+
+			expectedResults = []atc.BuildLogSearchResult{
+				{
+					Build: atc.Build{
+						ID:       123,
+						Name:     "1",
+						TeamName: "some-team",
+						Status:   "failed",
+						JobName:  "some-job",
+						APIURL:   "api/v1/builds/123",
+					},
+					Lines: []atc.BuildLogLine{
+						{EventID: 2, Origin: "some-task-id", Line: "Error: connection refused"},
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			results, searchErr = team.SearchBuildLogs(search)
+		})
+
+		Context("when only the query is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "q=connection+refused"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedResults),
+					),
+				)
+			})
+
+			It("returns the results", func() {
+				Expect(searchErr).NotTo(HaveOccurred())
+				Expect(results).To(Equal(expectedResults))
+			})
+		})
+
+		Context("when filters are given", func() {
+			BeforeEach(func() {
+				search.PipelineName = "some-pipeline"
+				search.JobName = "some-job"
+				search.Statuses = []string{"failed", "errored"}
+				search.From = time.Unix(10, 0)
+				search.To = time.Unix(20, 0)
+				search.Limit = 5
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.VerifyForm(map[string][]string{
+							"q":             {"connection refused"},
+							"pipeline_name": {"some-pipeline"},
+							"job_name":      {"some-job"},
+							"status":        {"failed", "errored"},
+							"from":          {"10"},
+							"to":            {"20"},
+							"limit":         {"5"},
+						}),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedResults),
+					),
+				)
+			})
+
+			It("passes them as query params", func() {
+				Expect(searchErr).NotTo(HaveOccurred())
+				Expect(results).To(Equal(expectedResults))
+			})
+		})
+
+		Context("when the server returns an error", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("returns the error", func() {
+				Expect(searchErr).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse/internal"
@@ -35,17 +37,52 @@ func (team *team) CreateBuild(plan atc.Plan) (atc.Build, error) {
 	return build, err
 }
 
-func (team *team) CreateJobBuild(pipelineName string, jobName string) (atc.Build, error) {
+func (team *team) CreateJobBuild(pipelineRef atc.PipelineRef, jobName string) (atc.Build, error) {
+	return team.createJobBuild(pipelineRef, jobName, url.Values{})
+}
+
+func (team *team) CreateJobBuildWithPriority(pipelineRef atc.PipelineRef, jobName string, priority int) (atc.Build, error) {
+	return team.createJobBuild(pipelineRef, jobName, url.Values{
+		atc.CreateJobBuildQueryPriority: {strconv.Itoa(priority)},
+	})
+}
+
+func (team *team) createJobBuild(pipelineRef atc.PipelineRef, jobName string, query url.Values) (atc.Build, error) {
 	params := rata.Params{
 		"job_name":      jobName,
-		"pipeline_name": pipelineName,
+		"pipeline_name": pipelineRef.Name,
 		"team_name":     team.name,
 	}
 
+	for key, values := range pipelineRef.QueryParams() {
+		query[key] = values
+	}
+
 	var build atc.Build
 	err := team.connection.Send(internal.Request{
 		RequestName: atc.CreateJobBuild,
 		Params:      params,
+		Query:       query,
+	}, &internal.Response{
+		Result: &build,
+	})
+
+	return build, err
+}
+
+func (team *team) RerunJobBuild(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, error) {
+	params := rata.Params{
+		"build_name":    buildName,
+		"job_name":      jobName,
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.name,
+	}
+
+	var build atc.Build
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.RerunJobBuild,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
 	}, &internal.Response{
 		Result: &build,
 	})
@@ -53,11 +90,11 @@ func (team *team) CreateJobBuild(pipelineName string, jobName string) (atc.Build
 	return build, err
 }
 
-func (team *team) JobBuild(pipelineName, jobName, buildName string) (atc.Build, bool, error) {
+func (team *team) JobBuild(pipelineRef atc.PipelineRef, jobName, buildName string) (atc.Build, bool, error) {
 	params := rata.Params{
 		"job_name":      jobName,
 		"build_name":    buildName,
-		"pipeline_name": pipelineName,
+		"pipeline_name": pipelineRef.Name,
 		"team_name":     team.name,
 	}
 
@@ -65,6 +102,7 @@ func (team *team) JobBuild(pipelineName, jobName, buildName string) (atc.Build,
 	err := team.connection.Send(internal.Request{
 		RequestName: atc.GetJobBuild,
 		Params:      params,
+		Query:       pipelineRef.QueryParams(),
 	}, &internal.Response{
 		Result: &build,
 	})
@@ -90,7 +90,97 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 
 		It("takes a pipeline and a job and creates the build", func() {
-			build, err := team.CreateJobBuild(pipelineName, jobName)
+			build, err := team.CreateJobBuild(atc.PipelineRef{Name: pipelineName}, jobName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build).To(Equal(expectedBuild))
+		})
+	})
+
+	Describe("RerunJobBuild", func() {
+		var expectedBuild atc.Build
+
+		BeforeEach(func() {
+			expectedBuild = atc.Build{
+				ID:      124,
+				Name:    "3.1",
+				Status:  "pending",
+				JobName: "myjob",
+				APIURL:  "api/v1/builds/124",
+				CreatedBy: &atc.BuildCreator{
+					Type:      atc.BuildCreatorRerun,
+					BuildID:   123,
+					BuildName: "3",
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/builds/3"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+				),
+			)
+		})
+
+		It("reruns the build and returns the new build", func() {
+			build, err := team.RerunJobBuild(atc.PipelineRef{Name: "mypipeline"}, "myjob", "3")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build).To(Equal(expectedBuild))
+		})
+	})
+
+	Describe("CreateJobBuildWithPriority", func() {
+		var expectedBuild atc.Build
+
+		BeforeEach(func() {
+			expectedBuild = atc.Build{
+				ID:       123,
+				Name:     "mybuild",
+				Status:   "pending",
+				JobName:  "myjob",
+				APIURL:   "api/v1/builds/123",
+				Priority: 10,
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/builds", "priority=10"),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, expectedBuild),
+				),
+			)
+		})
+
+		It("creates the build with the given priority", func() {
+			build, err := team.CreateJobBuildWithPriority(atc.PipelineRef{Name: "mypipeline"}, "myjob", 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build).To(Equal(expectedBuild))
+		})
+	})
+
+	Describe("CreateJobBuild for a pipeline instance", func() {
+		var expectedBuild atc.Build
+
+		BeforeEach(func() {
+			expectedBuild = atc.Build{
+				ID:      123,
+				Name:    "mybuild",
+				Status:  "pending",
+				JobName: "myjob",
+				APIURL:  "api/v1/builds/123",
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/builds", "instance_vars=%7B%22branch%22%3A%22master%22%7D&priority=10"),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, expectedBuild),
+				),
+			)
+		})
+
+		It("scopes the request to the instance", func() {
+			build, err := team.CreateJobBuildWithPriority(atc.PipelineRef{
+				Name:         "mypipeline",
+				InstanceVars: atc.InstanceVars{"branch": "master"},
+			}, "myjob", 10)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(build).To(Equal(expectedBuild))
 		})
@@ -123,7 +213,7 @@ var _ = Describe("ATC Handler Builds", func() {
 			})
 
 			It("returns the given build", func() {
-				build, found, err := team.JobBuild("mypipeline", "myjob", "mybuild")
+				build, found, err := team.JobBuild(atc.PipelineRef{Name: "mypipeline"}, "myjob", "mybuild")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(build).To(Equal(expectedBuild))
@@ -143,7 +233,7 @@ var _ = Describe("ATC Handler Builds", func() {
 			})
 
 			It("return false and no error", func() {
-				_, found, err := team.JobBuild("mypipeline", "myjob", "mybuild")
+				_, found, err := team.JobBuild(atc.PipelineRef{Name: "mypipeline"}, "myjob", "mybuild")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeFalse())
 			})
@@ -19,19 +19,28 @@ type Client interface {
 	BuildEvents(buildID string) (Events, error)
 	BuildResources(buildID int) (atc.BuildInputsOutputs, bool, error)
 	ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact, error)
+	ListArchivedArtifacts(buildID string) ([]atc.ArchivedArtifact, error)
+	GetArchivedArtifact(buildID string, planID atc.PlanID, name string) (io.ReadCloser, error)
+	BuildTestResults(buildID string) (atc.BuildTestResults, bool, error)
 	AbortBuild(buildID string) error
+	BuildApprovals(buildID string) ([]atc.BuildApproval, bool, error)
+	ApproveBuild(buildID string, decision atc.ApprovalDecision) (bool, error)
+	RejectBuild(buildID string, decision atc.ApprovalDecision) (bool, error)
 	BuildPlan(buildID int) (atc.PublicBuildPlan, bool, error)
 	SaveWorker(atc.Worker, *time.Duration) (*atc.Worker, error)
 	ListWorkers() ([]atc.Worker, error)
 	PruneWorker(workerName string) error
 	LandWorker(workerName string) error
 	GetInfo() (atc.Info, error)
+	RoleActions() (map[string][]string, error)
 	GetCLIReader(arch, platform string) (io.ReadCloser, http.Header, error)
 	ListPipelines() ([]atc.Pipeline, error)
 	ListTeams() ([]atc.Team, error)
 	Team(teamName string) Team
 	UserInfo() (map[string]interface{}, error)
 	ListActiveUsersSince(since time.Time) ([]atc.User, error)
+	ListSecretAccesses(filter SecretAccessFilter) ([]atc.SecretAccess, error)
+	EncryptionStatus() (atc.EncryptionStatus, error)
 	Check(checkID string) (atc.Check, bool, error)
 }
 
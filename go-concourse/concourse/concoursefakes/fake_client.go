@@ -23,6 +23,20 @@ type FakeClient struct {
 	abortBuildReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ApproveBuildStub        func(string, atc.ApprovalDecision) (bool, error)
+	approveBuildMutex       sync.RWMutex
+	approveBuildArgsForCall []struct {
+		arg1 string
+		arg2 atc.ApprovalDecision
+	}
+	approveBuildReturns struct {
+		result1 bool
+		result2 error
+	}
+	approveBuildReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	BuildStub        func(string) (atc.Build, bool, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
@@ -38,6 +52,21 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	BuildApprovalsStub        func(string) ([]atc.BuildApproval, bool, error)
+	buildApprovalsMutex       sync.RWMutex
+	buildApprovalsArgsForCall []struct {
+		arg1 string
+	}
+	buildApprovalsReturns struct {
+		result1 []atc.BuildApproval
+		result2 bool
+		result3 error
+	}
+	buildApprovalsReturnsOnCall map[int]struct {
+		result1 []atc.BuildApproval
+		result2 bool
+		result3 error
+	}
 	BuildEventsStub        func(string) (concourse.Events, error)
 	buildEventsMutex       sync.RWMutex
 	buildEventsArgsForCall []struct {
@@ -81,6 +110,21 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	BuildTestResultsStub        func(string) (atc.BuildTestResults, bool, error)
+	buildTestResultsMutex       sync.RWMutex
+	buildTestResultsArgsForCall []struct {
+		arg1 string
+	}
+	buildTestResultsReturns struct {
+		result1 atc.BuildTestResults
+		result2 bool
+		result3 error
+	}
+	buildTestResultsReturnsOnCall map[int]struct {
+		result1 atc.BuildTestResults
+		result2 bool
+		result3 error
+	}
 	BuildsStub        func(concourse.Page) ([]atc.Build, concourse.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -111,6 +155,33 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	EncryptionStatusStub        func() (atc.EncryptionStatus, error)
+	encryptionStatusMutex       sync.RWMutex
+	encryptionStatusArgsForCall []struct {
+	}
+	encryptionStatusReturns struct {
+		result1 atc.EncryptionStatus
+		result2 error
+	}
+	encryptionStatusReturnsOnCall map[int]struct {
+		result1 atc.EncryptionStatus
+		result2 error
+	}
+	GetArchivedArtifactStub        func(string, atc.PlanID, string) (io.ReadCloser, error)
+	getArchivedArtifactMutex       sync.RWMutex
+	getArchivedArtifactArgsForCall []struct {
+		arg1 string
+		arg2 atc.PlanID
+		arg3 string
+	}
+	getArchivedArtifactReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	getArchivedArtifactReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
 	GetCLIReaderStub        func(string, string) (io.ReadCloser, http.Header, error)
 	getCLIReaderMutex       sync.RWMutex
 	getCLIReaderArgsForCall []struct {
@@ -173,6 +244,19 @@ type FakeClient struct {
 		result1 []atc.User
 		result2 error
 	}
+	ListArchivedArtifactsStub        func(string) ([]atc.ArchivedArtifact, error)
+	listArchivedArtifactsMutex       sync.RWMutex
+	listArchivedArtifactsArgsForCall []struct {
+		arg1 string
+	}
+	listArchivedArtifactsReturns struct {
+		result1 []atc.ArchivedArtifact
+		result2 error
+	}
+	listArchivedArtifactsReturnsOnCall map[int]struct {
+		result1 []atc.ArchivedArtifact
+		result2 error
+	}
 	ListBuildArtifactsStub        func(string) ([]atc.WorkerArtifact, error)
 	listBuildArtifactsMutex       sync.RWMutex
 	listBuildArtifactsArgsForCall []struct {
@@ -198,6 +282,19 @@ type FakeClient struct {
 		result1 []atc.Pipeline
 		result2 error
 	}
+	ListSecretAccessesStub        func(concourse.SecretAccessFilter) ([]atc.SecretAccess, error)
+	listSecretAccessesMutex       sync.RWMutex
+	listSecretAccessesArgsForCall []struct {
+		arg1 concourse.SecretAccessFilter
+	}
+	listSecretAccessesReturns struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}
+	listSecretAccessesReturnsOnCall map[int]struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}
 	ListTeamsStub        func() ([]atc.Team, error)
 	listTeamsMutex       sync.RWMutex
 	listTeamsArgsForCall []struct {
@@ -233,6 +330,32 @@ type FakeClient struct {
 	pruneWorkerReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RejectBuildStub        func(string, atc.ApprovalDecision) (bool, error)
+	rejectBuildMutex       sync.RWMutex
+	rejectBuildArgsForCall []struct {
+		arg1 string
+		arg2 atc.ApprovalDecision
+	}
+	rejectBuildReturns struct {
+		result1 bool
+		result2 error
+	}
+	rejectBuildReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	RoleActionsStub        func() (map[string][]string, error)
+	roleActionsMutex       sync.RWMutex
+	roleActionsArgsForCall []struct {
+	}
+	roleActionsReturns struct {
+		result1 map[string][]string
+		result2 error
+	}
+	roleActionsReturnsOnCall map[int]struct {
+		result1 map[string][]string
+		result2 error
+	}
 	SaveWorkerStub        func(atc.Worker, *time.Duration) (*atc.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -344,6 +467,70 @@ func (fake *FakeClient) AbortBuildReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) ApproveBuild(arg1 string, arg2 atc.ApprovalDecision) (bool, error) {
+	fake.approveBuildMutex.Lock()
+	ret, specificReturn := fake.approveBuildReturnsOnCall[len(fake.approveBuildArgsForCall)]
+	fake.approveBuildArgsForCall = append(fake.approveBuildArgsForCall, struct {
+		arg1 string
+		arg2 atc.ApprovalDecision
+	}{arg1, arg2})
+	fake.recordInvocation("ApproveBuild", []interface{}{arg1, arg2})
+	fake.approveBuildMutex.Unlock()
+	if fake.ApproveBuildStub != nil {
+		return fake.ApproveBuildStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.approveBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ApproveBuildCallCount() int {
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	return len(fake.approveBuildArgsForCall)
+}
+
+func (fake *FakeClient) ApproveBuildCalls(stub func(string, atc.ApprovalDecision) (bool, error)) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = stub
+}
+
+func (fake *FakeClient) ApproveBuildArgsForCall(i int) (string, atc.ApprovalDecision) {
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	argsForCall := fake.approveBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) ApproveBuildReturns(result1 bool, result2 error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = nil
+	fake.approveBuildReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ApproveBuildReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = nil
+	if fake.approveBuildReturnsOnCall == nil {
+		fake.approveBuildReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.approveBuildReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) Build(arg1 string) (atc.Build, bool, error) {
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
@@ -410,6 +597,72 @@ func (fake *FakeClient) BuildReturnsOnCall(i int, result1 atc.Build, result2 boo
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) BuildApprovals(arg1 string) ([]atc.BuildApproval, bool, error) {
+	fake.buildApprovalsMutex.Lock()
+	ret, specificReturn := fake.buildApprovalsReturnsOnCall[len(fake.buildApprovalsArgsForCall)]
+	fake.buildApprovalsArgsForCall = append(fake.buildApprovalsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("BuildApprovals", []interface{}{arg1})
+	fake.buildApprovalsMutex.Unlock()
+	if fake.BuildApprovalsStub != nil {
+		return fake.BuildApprovalsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.buildApprovalsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildApprovalsCallCount() int {
+	fake.buildApprovalsMutex.RLock()
+	defer fake.buildApprovalsMutex.RUnlock()
+	return len(fake.buildApprovalsArgsForCall)
+}
+
+func (fake *FakeClient) BuildApprovalsCalls(stub func(string) ([]atc.BuildApproval, bool, error)) {
+	fake.buildApprovalsMutex.Lock()
+	defer fake.buildApprovalsMutex.Unlock()
+	fake.BuildApprovalsStub = stub
+}
+
+func (fake *FakeClient) BuildApprovalsArgsForCall(i int) string {
+	fake.buildApprovalsMutex.RLock()
+	defer fake.buildApprovalsMutex.RUnlock()
+	argsForCall := fake.buildApprovalsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) BuildApprovalsReturns(result1 []atc.BuildApproval, result2 bool, result3 error) {
+	fake.buildApprovalsMutex.Lock()
+	defer fake.buildApprovalsMutex.Unlock()
+	fake.BuildApprovalsStub = nil
+	fake.buildApprovalsReturns = struct {
+		result1 []atc.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildApprovalsReturnsOnCall(i int, result1 []atc.BuildApproval, result2 bool, result3 error) {
+	fake.buildApprovalsMutex.Lock()
+	defer fake.buildApprovalsMutex.Unlock()
+	fake.BuildApprovalsStub = nil
+	if fake.buildApprovalsReturnsOnCall == nil {
+		fake.buildApprovalsReturnsOnCall = make(map[int]struct {
+			result1 []atc.BuildApproval
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildApprovalsReturnsOnCall[i] = struct {
+		result1 []atc.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) BuildEvents(arg1 string) (concourse.Events, error) {
 	fake.buildEventsMutex.Lock()
 	ret, specificReturn := fake.buildEventsReturnsOnCall[len(fake.buildEventsArgsForCall)]
@@ -605,6 +858,72 @@ func (fake *FakeClient) BuildResourcesReturnsOnCall(i int, result1 atc.BuildInpu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) BuildTestResults(arg1 string) (atc.BuildTestResults, bool, error) {
+	fake.buildTestResultsMutex.Lock()
+	ret, specificReturn := fake.buildTestResultsReturnsOnCall[len(fake.buildTestResultsArgsForCall)]
+	fake.buildTestResultsArgsForCall = append(fake.buildTestResultsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("BuildTestResults", []interface{}{arg1})
+	fake.buildTestResultsMutex.Unlock()
+	if fake.BuildTestResultsStub != nil {
+		return fake.BuildTestResultsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.buildTestResultsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildTestResultsCallCount() int {
+	fake.buildTestResultsMutex.RLock()
+	defer fake.buildTestResultsMutex.RUnlock()
+	return len(fake.buildTestResultsArgsForCall)
+}
+
+func (fake *FakeClient) BuildTestResultsCalls(stub func(string) (atc.BuildTestResults, bool, error)) {
+	fake.buildTestResultsMutex.Lock()
+	defer fake.buildTestResultsMutex.Unlock()
+	fake.BuildTestResultsStub = stub
+}
+
+func (fake *FakeClient) BuildTestResultsArgsForCall(i int) string {
+	fake.buildTestResultsMutex.RLock()
+	defer fake.buildTestResultsMutex.RUnlock()
+	argsForCall := fake.buildTestResultsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) BuildTestResultsReturns(result1 atc.BuildTestResults, result2 bool, result3 error) {
+	fake.buildTestResultsMutex.Lock()
+	defer fake.buildTestResultsMutex.Unlock()
+	fake.BuildTestResultsStub = nil
+	fake.buildTestResultsReturns = struct {
+		result1 atc.BuildTestResults
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildTestResultsReturnsOnCall(i int, result1 atc.BuildTestResults, result2 bool, result3 error) {
+	fake.buildTestResultsMutex.Lock()
+	defer fake.buildTestResultsMutex.Unlock()
+	fake.BuildTestResultsStub = nil
+	if fake.buildTestResultsReturnsOnCall == nil {
+		fake.buildTestResultsReturnsOnCall = make(map[int]struct {
+			result1 atc.BuildTestResults
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildTestResultsReturnsOnCall[i] = struct {
+		result1 atc.BuildTestResults
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) Builds(arg1 concourse.Page) ([]atc.Build, concourse.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -737,6 +1056,126 @@ func (fake *FakeClient) CheckReturnsOnCall(i int, result1 atc.Check, result2 boo
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) EncryptionStatus() (atc.EncryptionStatus, error) {
+	fake.encryptionStatusMutex.Lock()
+	ret, specificReturn := fake.encryptionStatusReturnsOnCall[len(fake.encryptionStatusArgsForCall)]
+	fake.encryptionStatusArgsForCall = append(fake.encryptionStatusArgsForCall, struct {
+	}{})
+	fake.recordInvocation("EncryptionStatus", []interface{}{})
+	fake.encryptionStatusMutex.Unlock()
+	if fake.EncryptionStatusStub != nil {
+		return fake.EncryptionStatusStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.encryptionStatusReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) EncryptionStatusCallCount() int {
+	fake.encryptionStatusMutex.RLock()
+	defer fake.encryptionStatusMutex.RUnlock()
+	return len(fake.encryptionStatusArgsForCall)
+}
+
+func (fake *FakeClient) EncryptionStatusCalls(stub func() (atc.EncryptionStatus, error)) {
+	fake.encryptionStatusMutex.Lock()
+	defer fake.encryptionStatusMutex.Unlock()
+	fake.EncryptionStatusStub = stub
+}
+
+func (fake *FakeClient) EncryptionStatusReturns(result1 atc.EncryptionStatus, result2 error) {
+	fake.encryptionStatusMutex.Lock()
+	defer fake.encryptionStatusMutex.Unlock()
+	fake.EncryptionStatusStub = nil
+	fake.encryptionStatusReturns = struct {
+		result1 atc.EncryptionStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) EncryptionStatusReturnsOnCall(i int, result1 atc.EncryptionStatus, result2 error) {
+	fake.encryptionStatusMutex.Lock()
+	defer fake.encryptionStatusMutex.Unlock()
+	fake.EncryptionStatusStub = nil
+	if fake.encryptionStatusReturnsOnCall == nil {
+		fake.encryptionStatusReturnsOnCall = make(map[int]struct {
+			result1 atc.EncryptionStatus
+			result2 error
+		})
+	}
+	fake.encryptionStatusReturnsOnCall[i] = struct {
+		result1 atc.EncryptionStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetArchivedArtifact(arg1 string, arg2 atc.PlanID, arg3 string) (io.ReadCloser, error) {
+	fake.getArchivedArtifactMutex.Lock()
+	ret, specificReturn := fake.getArchivedArtifactReturnsOnCall[len(fake.getArchivedArtifactArgsForCall)]
+	fake.getArchivedArtifactArgsForCall = append(fake.getArchivedArtifactArgsForCall, struct {
+		arg1 string
+		arg2 atc.PlanID
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetArchivedArtifact", []interface{}{arg1, arg2, arg3})
+	fake.getArchivedArtifactMutex.Unlock()
+	if fake.GetArchivedArtifactStub != nil {
+		return fake.GetArchivedArtifactStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getArchivedArtifactReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetArchivedArtifactCallCount() int {
+	fake.getArchivedArtifactMutex.RLock()
+	defer fake.getArchivedArtifactMutex.RUnlock()
+	return len(fake.getArchivedArtifactArgsForCall)
+}
+
+func (fake *FakeClient) GetArchivedArtifactCalls(stub func(string, atc.PlanID, string) (io.ReadCloser, error)) {
+	fake.getArchivedArtifactMutex.Lock()
+	defer fake.getArchivedArtifactMutex.Unlock()
+	fake.GetArchivedArtifactStub = stub
+}
+
+func (fake *FakeClient) GetArchivedArtifactArgsForCall(i int) (string, atc.PlanID, string) {
+	fake.getArchivedArtifactMutex.RLock()
+	defer fake.getArchivedArtifactMutex.RUnlock()
+	argsForCall := fake.getArchivedArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) GetArchivedArtifactReturns(result1 io.ReadCloser, result2 error) {
+	fake.getArchivedArtifactMutex.Lock()
+	defer fake.getArchivedArtifactMutex.Unlock()
+	fake.GetArchivedArtifactStub = nil
+	fake.getArchivedArtifactReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetArchivedArtifactReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.getArchivedArtifactMutex.Lock()
+	defer fake.getArchivedArtifactMutex.Unlock()
+	fake.GetArchivedArtifactStub = nil
+	if fake.getArchivedArtifactReturnsOnCall == nil {
+		fake.getArchivedArtifactReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.getArchivedArtifactReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) GetCLIReader(arg1 string, arg2 string) (io.ReadCloser, http.Header, error) {
 	fake.getCLIReaderMutex.Lock()
 	ret, specificReturn := fake.getCLIReaderReturnsOnCall[len(fake.getCLIReaderArgsForCall)]
@@ -1034,6 +1473,69 @@ func (fake *FakeClient) ListActiveUsersSinceReturnsOnCall(i int, result1 []atc.U
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListArchivedArtifacts(arg1 string) ([]atc.ArchivedArtifact, error) {
+	fake.listArchivedArtifactsMutex.Lock()
+	ret, specificReturn := fake.listArchivedArtifactsReturnsOnCall[len(fake.listArchivedArtifactsArgsForCall)]
+	fake.listArchivedArtifactsArgsForCall = append(fake.listArchivedArtifactsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ListArchivedArtifacts", []interface{}{arg1})
+	fake.listArchivedArtifactsMutex.Unlock()
+	if fake.ListArchivedArtifactsStub != nil {
+		return fake.ListArchivedArtifactsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listArchivedArtifactsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListArchivedArtifactsCallCount() int {
+	fake.listArchivedArtifactsMutex.RLock()
+	defer fake.listArchivedArtifactsMutex.RUnlock()
+	return len(fake.listArchivedArtifactsArgsForCall)
+}
+
+func (fake *FakeClient) ListArchivedArtifactsCalls(stub func(string) ([]atc.ArchivedArtifact, error)) {
+	fake.listArchivedArtifactsMutex.Lock()
+	defer fake.listArchivedArtifactsMutex.Unlock()
+	fake.ListArchivedArtifactsStub = stub
+}
+
+func (fake *FakeClient) ListArchivedArtifactsArgsForCall(i int) string {
+	fake.listArchivedArtifactsMutex.RLock()
+	defer fake.listArchivedArtifactsMutex.RUnlock()
+	argsForCall := fake.listArchivedArtifactsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ListArchivedArtifactsReturns(result1 []atc.ArchivedArtifact, result2 error) {
+	fake.listArchivedArtifactsMutex.Lock()
+	defer fake.listArchivedArtifactsMutex.Unlock()
+	fake.ListArchivedArtifactsStub = nil
+	fake.listArchivedArtifactsReturns = struct {
+		result1 []atc.ArchivedArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListArchivedArtifactsReturnsOnCall(i int, result1 []atc.ArchivedArtifact, result2 error) {
+	fake.listArchivedArtifactsMutex.Lock()
+	defer fake.listArchivedArtifactsMutex.Unlock()
+	fake.ListArchivedArtifactsStub = nil
+	if fake.listArchivedArtifactsReturnsOnCall == nil {
+		fake.listArchivedArtifactsReturnsOnCall = make(map[int]struct {
+			result1 []atc.ArchivedArtifact
+			result2 error
+		})
+	}
+	fake.listArchivedArtifactsReturnsOnCall[i] = struct {
+		result1 []atc.ArchivedArtifact
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListBuildArtifacts(arg1 string) ([]atc.WorkerArtifact, error) {
 	fake.listBuildArtifactsMutex.Lock()
 	ret, specificReturn := fake.listBuildArtifactsReturnsOnCall[len(fake.listBuildArtifactsArgsForCall)]
@@ -1152,6 +1654,69 @@ func (fake *FakeClient) ListPipelinesReturnsOnCall(i int, result1 []atc.Pipeline
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListSecretAccesses(arg1 concourse.SecretAccessFilter) ([]atc.SecretAccess, error) {
+	fake.listSecretAccessesMutex.Lock()
+	ret, specificReturn := fake.listSecretAccessesReturnsOnCall[len(fake.listSecretAccessesArgsForCall)]
+	fake.listSecretAccessesArgsForCall = append(fake.listSecretAccessesArgsForCall, struct {
+		arg1 concourse.SecretAccessFilter
+	}{arg1})
+	fake.recordInvocation("ListSecretAccesses", []interface{}{arg1})
+	fake.listSecretAccessesMutex.Unlock()
+	if fake.ListSecretAccessesStub != nil {
+		return fake.ListSecretAccessesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listSecretAccessesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListSecretAccessesCallCount() int {
+	fake.listSecretAccessesMutex.RLock()
+	defer fake.listSecretAccessesMutex.RUnlock()
+	return len(fake.listSecretAccessesArgsForCall)
+}
+
+func (fake *FakeClient) ListSecretAccessesCalls(stub func(concourse.SecretAccessFilter) ([]atc.SecretAccess, error)) {
+	fake.listSecretAccessesMutex.Lock()
+	defer fake.listSecretAccessesMutex.Unlock()
+	fake.ListSecretAccessesStub = stub
+}
+
+func (fake *FakeClient) ListSecretAccessesArgsForCall(i int) concourse.SecretAccessFilter {
+	fake.listSecretAccessesMutex.RLock()
+	defer fake.listSecretAccessesMutex.RUnlock()
+	argsForCall := fake.listSecretAccessesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ListSecretAccessesReturns(result1 []atc.SecretAccess, result2 error) {
+	fake.listSecretAccessesMutex.Lock()
+	defer fake.listSecretAccessesMutex.Unlock()
+	fake.ListSecretAccessesStub = nil
+	fake.listSecretAccessesReturns = struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListSecretAccessesReturnsOnCall(i int, result1 []atc.SecretAccess, result2 error) {
+	fake.listSecretAccessesMutex.Lock()
+	defer fake.listSecretAccessesMutex.Unlock()
+	fake.ListSecretAccessesStub = nil
+	if fake.listSecretAccessesReturnsOnCall == nil {
+		fake.listSecretAccessesReturnsOnCall = make(map[int]struct {
+			result1 []atc.SecretAccess
+			result2 error
+		})
+	}
+	fake.listSecretAccessesReturnsOnCall[i] = struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListTeams() ([]atc.Team, error) {
 	fake.listTeamsMutex.Lock()
 	ret, specificReturn := fake.listTeamsReturnsOnCall[len(fake.listTeamsArgsForCall)]
@@ -1322,6 +1887,125 @@ func (fake *FakeClient) PruneWorkerReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) RejectBuild(arg1 string, arg2 atc.ApprovalDecision) (bool, error) {
+	fake.rejectBuildMutex.Lock()
+	ret, specificReturn := fake.rejectBuildReturnsOnCall[len(fake.rejectBuildArgsForCall)]
+	fake.rejectBuildArgsForCall = append(fake.rejectBuildArgsForCall, struct {
+		arg1 string
+		arg2 atc.ApprovalDecision
+	}{arg1, arg2})
+	fake.recordInvocation("RejectBuild", []interface{}{arg1, arg2})
+	fake.rejectBuildMutex.Unlock()
+	if fake.RejectBuildStub != nil {
+		return fake.RejectBuildStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.rejectBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RejectBuildCallCount() int {
+	fake.rejectBuildMutex.RLock()
+	defer fake.rejectBuildMutex.RUnlock()
+	return len(fake.rejectBuildArgsForCall)
+}
+
+func (fake *FakeClient) RejectBuildCalls(stub func(string, atc.ApprovalDecision) (bool, error)) {
+	fake.rejectBuildMutex.Lock()
+	defer fake.rejectBuildMutex.Unlock()
+	fake.RejectBuildStub = stub
+}
+
+func (fake *FakeClient) RejectBuildArgsForCall(i int) (string, atc.ApprovalDecision) {
+	fake.rejectBuildMutex.RLock()
+	defer fake.rejectBuildMutex.RUnlock()
+	argsForCall := fake.rejectBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) RejectBuildReturns(result1 bool, result2 error) {
+	fake.rejectBuildMutex.Lock()
+	defer fake.rejectBuildMutex.Unlock()
+	fake.RejectBuildStub = nil
+	fake.rejectBuildReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RejectBuildReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.rejectBuildMutex.Lock()
+	defer fake.rejectBuildMutex.Unlock()
+	fake.RejectBuildStub = nil
+	if fake.rejectBuildReturnsOnCall == nil {
+		fake.rejectBuildReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.rejectBuildReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RoleActions() (map[string][]string, error) {
+	fake.roleActionsMutex.Lock()
+	ret, specificReturn := fake.roleActionsReturnsOnCall[len(fake.roleActionsArgsForCall)]
+	fake.roleActionsArgsForCall = append(fake.roleActionsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("RoleActions", []interface{}{})
+	fake.roleActionsMutex.Unlock()
+	if fake.RoleActionsStub != nil {
+		return fake.RoleActionsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.roleActionsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RoleActionsCallCount() int {
+	fake.roleActionsMutex.RLock()
+	defer fake.roleActionsMutex.RUnlock()
+	return len(fake.roleActionsArgsForCall)
+}
+
+func (fake *FakeClient) RoleActionsCalls(stub func() (map[string][]string, error)) {
+	fake.roleActionsMutex.Lock()
+	defer fake.roleActionsMutex.Unlock()
+	fake.RoleActionsStub = stub
+}
+
+func (fake *FakeClient) RoleActionsReturns(result1 map[string][]string, result2 error) {
+	fake.roleActionsMutex.Lock()
+	defer fake.roleActionsMutex.Unlock()
+	fake.RoleActionsStub = nil
+	fake.roleActionsReturns = struct {
+		result1 map[string][]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RoleActionsReturnsOnCall(i int, result1 map[string][]string, result2 error) {
+	fake.roleActionsMutex.Lock()
+	defer fake.roleActionsMutex.Unlock()
+	fake.RoleActionsStub = nil
+	if fake.roleActionsReturnsOnCall == nil {
+		fake.roleActionsReturnsOnCall = make(map[int]struct {
+			result1 map[string][]string
+			result2 error
+		})
+	}
+	fake.roleActionsReturnsOnCall[i] = struct {
+		result1 map[string][]string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) SaveWorker(arg1 atc.Worker, arg2 *time.Duration) (*atc.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -1558,18 +2242,28 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.abortBuildMutex.RLock()
 	defer fake.abortBuildMutex.RUnlock()
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
+	fake.buildApprovalsMutex.RLock()
+	defer fake.buildApprovalsMutex.RUnlock()
 	fake.buildEventsMutex.RLock()
 	defer fake.buildEventsMutex.RUnlock()
 	fake.buildPlanMutex.RLock()
 	defer fake.buildPlanMutex.RUnlock()
 	fake.buildResourcesMutex.RLock()
 	defer fake.buildResourcesMutex.RUnlock()
+	fake.buildTestResultsMutex.RLock()
+	defer fake.buildTestResultsMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
 	fake.checkMutex.RLock()
 	defer fake.checkMutex.RUnlock()
+	fake.encryptionStatusMutex.RLock()
+	defer fake.encryptionStatusMutex.RUnlock()
+	fake.getArchivedArtifactMutex.RLock()
+	defer fake.getArchivedArtifactMutex.RUnlock()
 	fake.getCLIReaderMutex.RLock()
 	defer fake.getCLIReaderMutex.RUnlock()
 	fake.getInfoMutex.RLock()
@@ -1580,16 +2274,24 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.landWorkerMutex.RUnlock()
 	fake.listActiveUsersSinceMutex.RLock()
 	defer fake.listActiveUsersSinceMutex.RUnlock()
+	fake.listArchivedArtifactsMutex.RLock()
+	defer fake.listArchivedArtifactsMutex.RUnlock()
 	fake.listBuildArtifactsMutex.RLock()
 	defer fake.listBuildArtifactsMutex.RUnlock()
 	fake.listPipelinesMutex.RLock()
 	defer fake.listPipelinesMutex.RUnlock()
+	fake.listSecretAccessesMutex.RLock()
+	defer fake.listSecretAccessesMutex.RUnlock()
 	fake.listTeamsMutex.RLock()
 	defer fake.listTeamsMutex.RUnlock()
 	fake.listWorkersMutex.RLock()
 	defer fake.listWorkersMutex.RUnlock()
 	fake.pruneWorkerMutex.RLock()
 	defer fake.pruneWorkerMutex.RUnlock()
+	fake.rejectBuildMutex.RLock()
+	defer fake.rejectBuildMutex.RUnlock()
+	fake.roleActionsMutex.RLock()
+	defer fake.roleActionsMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
 	fake.teamMutex.RLock()
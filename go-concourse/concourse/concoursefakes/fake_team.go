@@ -4,16 +4,17 @@ package concoursefakes
 import (
 	"io"
 	"sync"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse"
 )
 
 type FakeTeam struct {
-	BuildInputsForJobStub        func(string, string) ([]atc.BuildInput, bool, error)
+	BuildInputsForJobStub        func(atc.PipelineRef, string) ([]atc.BuildInput, bool, error)
 	buildInputsForJobMutex       sync.RWMutex
 	buildInputsForJobArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}
 	buildInputsForJobReturns struct {
@@ -41,10 +42,10 @@ type FakeTeam struct {
 		result2 concourse.Pagination
 		result3 error
 	}
-	BuildsWithVersionAsInputStub        func(string, string, int) ([]atc.Build, bool, error)
+	BuildsWithVersionAsInputStub        func(atc.PipelineRef, string, int) ([]atc.Build, bool, error)
 	buildsWithVersionAsInputMutex       sync.RWMutex
 	buildsWithVersionAsInputArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
 	}
@@ -58,10 +59,10 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
-	BuildsWithVersionAsOutputStub        func(string, string, int) ([]atc.Build, bool, error)
+	BuildsWithVersionAsOutputStub        func(atc.PipelineRef, string, int) ([]atc.Build, bool, error)
 	buildsWithVersionAsOutputMutex       sync.RWMutex
 	buildsWithVersionAsOutputArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
 	}
@@ -75,10 +76,10 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
-	CheckResourceStub        func(string, string, atc.Version) (atc.Check, bool, error)
+	CheckResourceStub        func(atc.PipelineRef, string, atc.Version) (atc.Check, bool, error)
 	checkResourceMutex       sync.RWMutex
 	checkResourceArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 atc.Version
 	}
@@ -92,10 +93,10 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
-	CheckResourceTypeStub        func(string, string, atc.Version) (atc.Check, bool, error)
+	CheckResourceTypeStub        func(atc.PipelineRef, string, atc.Version) (atc.Check, bool, error)
 	checkResourceTypeMutex       sync.RWMutex
 	checkResourceTypeArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 atc.Version
 	}
@@ -109,10 +110,10 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
-	ClearTaskCacheStub        func(string, string, string, string) (int64, error)
+	ClearTaskCacheStub        func(atc.PipelineRef, string, string, string) (int64, error)
 	clearTaskCacheMutex       sync.RWMutex
 	clearTaskCacheArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 string
 		arg4 string
@@ -152,10 +153,10 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
-	CreateJobBuildStub        func(string, string) (atc.Build, error)
+	CreateJobBuildStub        func(atc.PipelineRef, string) (atc.Build, error)
 	createJobBuildMutex       sync.RWMutex
 	createJobBuildArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}
 	createJobBuildReturns struct {
@@ -166,6 +167,21 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
+	CreateJobBuildWithPriorityStub        func(atc.PipelineRef, string, int) (atc.Build, error)
+	createJobBuildWithPriorityMutex       sync.RWMutex
+	createJobBuildWithPriorityArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+	}
+	createJobBuildWithPriorityReturns struct {
+		result1 atc.Build
+		result2 error
+	}
+	createJobBuildWithPriorityReturnsOnCall map[int]struct {
+		result1 atc.Build
+		result2 error
+	}
 	CreateOrUpdateStub        func(atc.Team) (atc.Team, bool, bool, error)
 	createOrUpdateMutex       sync.RWMutex
 	createOrUpdateArgsForCall []struct {
@@ -183,10 +199,10 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
-	CreateOrUpdatePipelineConfigStub        func(string, string, []byte, bool) (bool, bool, []concourse.ConfigWarning, error)
+	CreateOrUpdatePipelineConfigStub        func(atc.PipelineRef, string, []byte, bool) (bool, bool, []concourse.ConfigWarning, error)
 	createOrUpdatePipelineConfigMutex       sync.RWMutex
 	createOrUpdatePipelineConfigArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 []byte
 		arg4 bool
@@ -203,10 +219,10 @@ type FakeTeam struct {
 		result3 []concourse.ConfigWarning
 		result4 error
 	}
-	CreatePipelineBuildStub        func(string, atc.Plan) (atc.Build, error)
+	CreatePipelineBuildStub        func(atc.PipelineRef, atc.Plan) (atc.Build, error)
 	createPipelineBuildMutex       sync.RWMutex
 	createPipelineBuildArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 atc.Plan
 	}
 	createPipelineBuildReturns struct {
@@ -217,10 +233,10 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
-	DeletePipelineStub        func(string) (bool, error)
+	DeletePipelineStub        func(atc.PipelineRef) (bool, error)
 	deletePipelineMutex       sync.RWMutex
 	deletePipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	deletePipelineReturns struct {
 		result1 bool
@@ -241,10 +257,10 @@ type FakeTeam struct {
 	destroyTeamReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DisableResourceVersionStub        func(string, string, int) (bool, error)
+	DisableResourceVersionStub        func(atc.PipelineRef, string, int) (bool, error)
 	disableResourceVersionMutex       sync.RWMutex
 	disableResourceVersionArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
 	}
@@ -256,10 +272,10 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	EnableResourceVersionStub        func(string, string, int) (bool, error)
+	EnableResourceVersionStub        func(atc.PipelineRef, string, int) (bool, error)
 	enableResourceVersionMutex       sync.RWMutex
 	enableResourceVersionArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
 	}
@@ -271,10 +287,10 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	ExposePipelineStub        func(string) (bool, error)
+	ExposePipelineStub        func(atc.PipelineRef) (bool, error)
 	exposePipelineMutex       sync.RWMutex
 	exposePipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	exposePipelineReturns struct {
 		result1 bool
@@ -310,10 +326,10 @@ type FakeTeam struct {
 		result1 atc.Container
 		result2 error
 	}
-	HidePipelineStub        func(string) (bool, error)
+	HidePipelineStub        func(atc.PipelineRef) (bool, error)
 	hidePipelineMutex       sync.RWMutex
 	hidePipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	hidePipelineReturns struct {
 		result1 bool
@@ -323,10 +339,10 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	JobStub        func(string, string) (atc.Job, bool, error)
+	JobStub        func(atc.PipelineRef, string) (atc.Job, bool, error)
 	jobMutex       sync.RWMutex
 	jobArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}
 	jobReturns struct {
@@ -339,10 +355,10 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
-	JobBuildStub        func(string, string, string) (atc.Build, bool, error)
+	JobBuildStub        func(atc.PipelineRef, string, string) (atc.Build, bool, error)
 	jobBuildMutex       sync.RWMutex
 	jobBuildArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 string
 	}
@@ -356,10 +372,10 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
-	JobBuildsStub        func(string, string, concourse.Page) ([]atc.Build, concourse.Pagination, bool, error)
+	JobBuildsStub        func(atc.PipelineRef, string, concourse.Page) ([]atc.Build, concourse.Pagination, bool, error)
 	jobBuildsMutex       sync.RWMutex
 	jobBuildsArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 concourse.Page
 	}
@@ -375,6 +391,41 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	JobStatsStub        func(atc.PipelineRef, string, time.Time, time.Time) (atc.JobStats, bool, error)
+	jobStatsMutex       sync.RWMutex
+	jobStatsArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 time.Time
+		arg4 time.Time
+	}
+	jobStatsReturns struct {
+		result1 atc.JobStats
+		result2 bool
+		result3 error
+	}
+	jobStatsReturnsOnCall map[int]struct {
+		result1 atc.JobStats
+		result2 bool
+		result3 error
+	}
+	JobTestHistoryStub        func(atc.PipelineRef, string, int) ([]atc.TestHistory, bool, error)
+	jobTestHistoryMutex       sync.RWMutex
+	jobTestHistoryArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+	}
+	jobTestHistoryReturns struct {
+		result1 []atc.TestHistory
+		result2 bool
+		result3 error
+	}
+	jobTestHistoryReturnsOnCall map[int]struct {
+		result1 []atc.TestHistory
+		result2 bool
+		result3 error
+	}
 	ListContainersStub        func(map[string]string) ([]atc.Container, error)
 	listContainersMutex       sync.RWMutex
 	listContainersArgsForCall []struct {
@@ -388,10 +439,10 @@ type FakeTeam struct {
 		result1 []atc.Container
 		result2 error
 	}
-	ListJobsStub        func(string) ([]atc.Job, error)
+	ListJobsStub        func(atc.PipelineRef) ([]atc.Job, error)
 	listJobsMutex       sync.RWMutex
 	listJobsArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	listJobsReturns struct {
 		result1 []atc.Job
@@ -413,10 +464,10 @@ type FakeTeam struct {
 		result1 []atc.Pipeline
 		result2 error
 	}
-	ListResourcesStub        func(string) ([]atc.Resource, error)
+	ListResourcesStub        func(atc.PipelineRef) ([]atc.Resource, error)
 	listResourcesMutex       sync.RWMutex
 	listResourcesArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	listResourcesReturns struct {
 		result1 []atc.Resource
@@ -459,10 +510,10 @@ type FakeTeam struct {
 	orderingPipelinesReturnsOnCall map[int]struct {
 		result1 error
 	}
-	PauseJobStub        func(string, string) (bool, error)
+	PauseJobStub        func(atc.PipelineRef, string) (bool, error)
 	pauseJobMutex       sync.RWMutex
 	pauseJobArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}
 	pauseJobReturns struct {
@@ -473,10 +524,10 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	PausePipelineStub        func(string) (bool, error)
+	PausePipelineStub        func(atc.PipelineRef) (bool, error)
 	pausePipelineMutex       sync.RWMutex
 	pausePipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	pausePipelineReturns struct {
 		result1 bool
@@ -486,10 +537,10 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	PinResourceVersionStub        func(string, string, int) (bool, error)
+	PinResourceVersionStub        func(atc.PipelineRef, string, int) (bool, error)
 	pinResourceVersionMutex       sync.RWMutex
 	pinResourceVersionArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
 	}
@@ -501,10 +552,10 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	PipelineStub        func(string) (atc.Pipeline, bool, error)
+	PipelineStub        func(atc.PipelineRef) (atc.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	pipelineReturns struct {
 		result1 atc.Pipeline
@@ -516,10 +567,10 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
-	PipelineBuildsStub        func(string, concourse.Page) ([]atc.Build, concourse.Pagination, bool, error)
+	PipelineBuildsStub        func(atc.PipelineRef, concourse.Page) ([]atc.Build, concourse.Pagination, bool, error)
 	pipelineBuildsMutex       sync.RWMutex
 	pipelineBuildsArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 concourse.Page
 	}
 	pipelineBuildsReturns struct {
@@ -534,10 +585,10 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
-	PipelineConfigStub        func(string) (atc.Config, string, bool, error)
+	PipelineConfigStub        func(atc.PipelineRef) (atc.Config, string, bool, error)
 	pipelineConfigMutex       sync.RWMutex
 	pipelineConfigArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	pipelineConfigReturns struct {
 		result1 atc.Config
@@ -551,10 +602,10 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
-	RenamePipelineStub        func(string, string) (bool, error)
+	RenamePipelineStub        func(atc.PipelineRef, string) (bool, error)
 	renamePipelineMutex       sync.RWMutex
 	renamePipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}
 	renamePipelineReturns struct {
@@ -579,10 +630,25 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	ResourceStub        func(string, string) (atc.Resource, bool, error)
+	RerunJobBuildStub        func(atc.PipelineRef, string, string) (atc.Build, error)
+	rerunJobBuildMutex       sync.RWMutex
+	rerunJobBuildArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 string
+	}
+	rerunJobBuildReturns struct {
+		result1 atc.Build
+		result2 error
+	}
+	rerunJobBuildReturnsOnCall map[int]struct {
+		result1 atc.Build
+		result2 error
+	}
+	ResourceStub        func(atc.PipelineRef, string) (atc.Resource, bool, error)
 	resourceMutex       sync.RWMutex
 	resourceArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}
 	resourceReturns struct {
@@ -595,10 +661,10 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
-	ResourceVersionsStub        func(string, string, concourse.Page, atc.Version) ([]atc.ResourceVersion, concourse.Pagination, bool, error)
+	ResourceVersionsStub        func(atc.PipelineRef, string, concourse.Page, atc.Version) ([]atc.ResourceVersion, concourse.Pagination, bool, error)
 	resourceVersionsMutex       sync.RWMutex
 	resourceVersionsArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 concourse.Page
 		arg4 atc.Version
@@ -615,10 +681,35 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
-	SetPinCommentStub        func(string, string, string) (bool, error)
+	SearchBuildLogsStub        func(concourse.BuildLogSearch) ([]atc.BuildLogSearchResult, error)
+	searchBuildLogsMutex       sync.RWMutex
+	searchBuildLogsArgsForCall []struct {
+		arg1 concourse.BuildLogSearch
+	}
+	searchBuildLogsReturns struct {
+		result1 []atc.BuildLogSearchResult
+		result2 error
+	}
+	searchBuildLogsReturnsOnCall map[int]struct {
+		result1 []atc.BuildLogSearchResult
+		result2 error
+	}
+	SemaphoresStub        func() ([]atc.Semaphore, error)
+	semaphoresMutex       sync.RWMutex
+	semaphoresArgsForCall []struct {
+	}
+	semaphoresReturns struct {
+		result1 []atc.Semaphore
+		result2 error
+	}
+	semaphoresReturnsOnCall map[int]struct {
+		result1 []atc.Semaphore
+		result2 error
+	}
+	SetPinCommentStub        func(atc.PipelineRef, string, string) (bool, error)
 	setPinCommentMutex       sync.RWMutex
 	setPinCommentArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 string
 	}
@@ -645,10 +736,10 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
-	UnpauseJobStub        func(string, string) (bool, error)
+	UnpauseJobStub        func(atc.PipelineRef, string) (bool, error)
 	unpauseJobMutex       sync.RWMutex
 	unpauseJobArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}
 	unpauseJobReturns struct {
@@ -659,10 +750,10 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	UnpausePipelineStub        func(string) (bool, error)
+	UnpausePipelineStub        func(atc.PipelineRef) (bool, error)
 	unpausePipelineMutex       sync.RWMutex
 	unpausePipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	unpausePipelineReturns struct {
 		result1 bool
@@ -672,10 +763,10 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	UnpinResourceStub        func(string, string) (bool, error)
+	UnpinResourceStub        func(atc.PipelineRef, string) (bool, error)
 	unpinResourceMutex       sync.RWMutex
 	unpinResourceArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}
 	unpinResourceReturns struct {
@@ -686,10 +777,10 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
-	VersionedResourceTypesStub        func(string) (atc.VersionedResourceTypes, bool, error)
+	VersionedResourceTypesStub        func(atc.PipelineRef) (atc.VersionedResourceTypes, bool, error)
 	versionedResourceTypesMutex       sync.RWMutex
 	versionedResourceTypesArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	versionedResourceTypesReturns struct {
 		result1 atc.VersionedResourceTypes
@@ -705,11 +796,11 @@ type FakeTeam struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeTeam) BuildInputsForJob(arg1 string, arg2 string) ([]atc.BuildInput, bool, error) {
+func (fake *FakeTeam) BuildInputsForJob(arg1 atc.PipelineRef, arg2 string) ([]atc.BuildInput, bool, error) {
 	fake.buildInputsForJobMutex.Lock()
 	ret, specificReturn := fake.buildInputsForJobReturnsOnCall[len(fake.buildInputsForJobArgsForCall)]
 	fake.buildInputsForJobArgsForCall = append(fake.buildInputsForJobArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}{arg1, arg2})
 	fake.recordInvocation("BuildInputsForJob", []interface{}{arg1, arg2})
@@ -730,13 +821,13 @@ func (fake *FakeTeam) BuildInputsForJobCallCount() int {
 	return len(fake.buildInputsForJobArgsForCall)
 }
 
-func (fake *FakeTeam) BuildInputsForJobCalls(stub func(string, string) ([]atc.BuildInput, bool, error)) {
+func (fake *FakeTeam) BuildInputsForJobCalls(stub func(atc.PipelineRef, string) ([]atc.BuildInput, bool, error)) {
 	fake.buildInputsForJobMutex.Lock()
 	defer fake.buildInputsForJobMutex.Unlock()
 	fake.BuildInputsForJobStub = stub
 }
 
-func (fake *FakeTeam) BuildInputsForJobArgsForCall(i int) (string, string) {
+func (fake *FakeTeam) BuildInputsForJobArgsForCall(i int) (atc.PipelineRef, string) {
 	fake.buildInputsForJobMutex.RLock()
 	defer fake.buildInputsForJobMutex.RUnlock()
 	argsForCall := fake.buildInputsForJobArgsForCall[i]
@@ -838,11 +929,11 @@ func (fake *FakeTeam) BuildsReturnsOnCall(i int, result1 []atc.Build, result2 co
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTeam) BuildsWithVersionAsInput(arg1 string, arg2 string, arg3 int) ([]atc.Build, bool, error) {
+func (fake *FakeTeam) BuildsWithVersionAsInput(arg1 atc.PipelineRef, arg2 string, arg3 int) ([]atc.Build, bool, error) {
 	fake.buildsWithVersionAsInputMutex.Lock()
 	ret, specificReturn := fake.buildsWithVersionAsInputReturnsOnCall[len(fake.buildsWithVersionAsInputArgsForCall)]
 	fake.buildsWithVersionAsInputArgsForCall = append(fake.buildsWithVersionAsInputArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
 	}{arg1, arg2, arg3})
@@ -864,13 +955,13 @@ func (fake *FakeTeam) BuildsWithVersionAsInputCallCount() int {
 	return len(fake.buildsWithVersionAsInputArgsForCall)
 }
 
-func (fake *FakeTeam) BuildsWithVersionAsInputCalls(stub func(string, string, int) ([]atc.Build, bool, error)) {
+func (fake *FakeTeam) BuildsWithVersionAsInputCalls(stub func(atc.PipelineRef, string, int) ([]atc.Build, bool, error)) {
 	fake.buildsWithVersionAsInputMutex.Lock()
 	defer fake.buildsWithVersionAsInputMutex.Unlock()
 	fake.BuildsWithVersionAsInputStub = stub
 }
 
-func (fake *FakeTeam) BuildsWithVersionAsInputArgsForCall(i int) (string, string, int) {
+func (fake *FakeTeam) BuildsWithVersionAsInputArgsForCall(i int) (atc.PipelineRef, string, int) {
 	fake.buildsWithVersionAsInputMutex.RLock()
 	defer fake.buildsWithVersionAsInputMutex.RUnlock()
 	argsForCall := fake.buildsWithVersionAsInputArgsForCall[i]
@@ -906,11 +997,11 @@ func (fake *FakeTeam) BuildsWithVersionAsInputReturnsOnCall(i int, result1 []atc
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTeam) BuildsWithVersionAsOutput(arg1 string, arg2 string, arg3 int) ([]atc.Build, bool, error) {
+func (fake *FakeTeam) BuildsWithVersionAsOutput(arg1 atc.PipelineRef, arg2 string, arg3 int) ([]atc.Build, bool, error) {
 	fake.buildsWithVersionAsOutputMutex.Lock()
 	ret, specificReturn := fake.buildsWithVersionAsOutputReturnsOnCall[len(fake.buildsWithVersionAsOutputArgsForCall)]
 	fake.buildsWithVersionAsOutputArgsForCall = append(fake.buildsWithVersionAsOutputArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
 	}{arg1, arg2, arg3})
@@ -932,13 +1023,13 @@ func (fake *FakeTeam) BuildsWithVersionAsOutputCallCount() int {
 	return len(fake.buildsWithVersionAsOutputArgsForCall)
 }
 
-func (fake *FakeTeam) BuildsWithVersionAsOutputCalls(stub func(string, string, int) ([]atc.Build, bool, error)) {
+func (fake *FakeTeam) BuildsWithVersionAsOutputCalls(stub func(atc.PipelineRef, string, int) ([]atc.Build, bool, error)) {
 	fake.buildsWithVersionAsOutputMutex.Lock()
 	defer fake.buildsWithVersionAsOutputMutex.Unlock()
 	fake.BuildsWithVersionAsOutputStub = stub
 }
 
-func (fake *FakeTeam) BuildsWithVersionAsOutputArgsForCall(i int) (string, string, int) {
+func (fake *FakeTeam) BuildsWithVersionAsOutputArgsForCall(i int) (atc.PipelineRef, string, int) {
 	fake.buildsWithVersionAsOutputMutex.RLock()
 	defer fake.buildsWithVersionAsOutputMutex.RUnlock()
 	argsForCall := fake.buildsWithVersionAsOutputArgsForCall[i]
@@ -974,11 +1065,11 @@ func (fake *FakeTeam) BuildsWithVersionAsOutputReturnsOnCall(i int, result1 []at
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTeam) CheckResource(arg1 string, arg2 string, arg3 atc.Version) (atc.Check, bool, error) {
+func (fake *FakeTeam) CheckResource(arg1 atc.PipelineRef, arg2 string, arg3 atc.Version) (atc.Check, bool, error) {
 	fake.checkResourceMutex.Lock()
 	ret, specificReturn := fake.checkResourceReturnsOnCall[len(fake.checkResourceArgsForCall)]
 	fake.checkResourceArgsForCall = append(fake.checkResourceArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 atc.Version
 	}{arg1, arg2, arg3})
@@ -1000,13 +1091,13 @@ func (fake *FakeTeam) CheckResourceCallCount() int {
 	return len(fake.checkResourceArgsForCall)
 }
 
-func (fake *FakeTeam) CheckResourceCalls(stub func(string, string, atc.Version) (atc.Check, bool, error)) {
+func (fake *FakeTeam) CheckResourceCalls(stub func(atc.PipelineRef, string, atc.Version) (atc.Check, bool, error)) {
 	fake.checkResourceMutex.Lock()
 	defer fake.checkResourceMutex.Unlock()
 	fake.CheckResourceStub = stub
 }
 
-func (fake *FakeTeam) CheckResourceArgsForCall(i int) (string, string, atc.Version) {
+func (fake *FakeTeam) CheckResourceArgsForCall(i int) (atc.PipelineRef, string, atc.Version) {
 	fake.checkResourceMutex.RLock()
 	defer fake.checkResourceMutex.RUnlock()
 	argsForCall := fake.checkResourceArgsForCall[i]
@@ -1042,11 +1133,11 @@ func (fake *FakeTeam) CheckResourceReturnsOnCall(i int, result1 atc.Check, resul
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTeam) CheckResourceType(arg1 string, arg2 string, arg3 atc.Version) (atc.Check, bool, error) {
+func (fake *FakeTeam) CheckResourceType(arg1 atc.PipelineRef, arg2 string, arg3 atc.Version) (atc.Check, bool, error) {
 	fake.checkResourceTypeMutex.Lock()
 	ret, specificReturn := fake.checkResourceTypeReturnsOnCall[len(fake.checkResourceTypeArgsForCall)]
 	fake.checkResourceTypeArgsForCall = append(fake.checkResourceTypeArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 atc.Version
 	}{arg1, arg2, arg3})
@@ -1068,13 +1159,13 @@ func (fake *FakeTeam) CheckResourceTypeCallCount() int {
 	return len(fake.checkResourceTypeArgsForCall)
 }
 
-func (fake *FakeTeam) CheckResourceTypeCalls(stub func(string, string, atc.Version) (atc.Check, bool, error)) {
+func (fake *FakeTeam) CheckResourceTypeCalls(stub func(atc.PipelineRef, string, atc.Version) (atc.Check, bool, error)) {
 	fake.checkResourceTypeMutex.Lock()
 	defer fake.checkResourceTypeMutex.Unlock()
 	fake.CheckResourceTypeStub = stub
 }
 
-func (fake *FakeTeam) CheckResourceTypeArgsForCall(i int) (string, string, atc.Version) {
+func (fake *FakeTeam) CheckResourceTypeArgsForCall(i int) (atc.PipelineRef, string, atc.Version) {
 	fake.checkResourceTypeMutex.RLock()
 	defer fake.checkResourceTypeMutex.RUnlock()
 	argsForCall := fake.checkResourceTypeArgsForCall[i]
@@ -1110,11 +1201,11 @@ func (fake *FakeTeam) CheckResourceTypeReturnsOnCall(i int, result1 atc.Check, r
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTeam) ClearTaskCache(arg1 string, arg2 string, arg3 string, arg4 string) (int64, error) {
+func (fake *FakeTeam) ClearTaskCache(arg1 atc.PipelineRef, arg2 string, arg3 string, arg4 string) (int64, error) {
 	fake.clearTaskCacheMutex.Lock()
 	ret, specificReturn := fake.clearTaskCacheReturnsOnCall[len(fake.clearTaskCacheArgsForCall)]
 	fake.clearTaskCacheArgsForCall = append(fake.clearTaskCacheArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 string
 		arg4 string
@@ -1137,13 +1228,13 @@ func (fake *FakeTeam) ClearTaskCacheCallCount() int {
 	return len(fake.clearTaskCacheArgsForCall)
 }
 
-func (fake *FakeTeam) ClearTaskCacheCalls(stub func(string, string, string, string) (int64, error)) {
+func (fake *FakeTeam) ClearTaskCacheCalls(stub func(atc.PipelineRef, string, string, string) (int64, error)) {
 	fake.clearTaskCacheMutex.Lock()
 	defer fake.clearTaskCacheMutex.Unlock()
 	fake.ClearTaskCacheStub = stub
 }
 
-func (fake *FakeTeam) ClearTaskCacheArgsForCall(i int) (string, string, string, string) {
+func (fake *FakeTeam) ClearTaskCacheArgsForCall(i int) (atc.PipelineRef, string, string, string) {
 	fake.clearTaskCacheMutex.RLock()
 	defer fake.clearTaskCacheMutex.RUnlock()
 	argsForCall := fake.clearTaskCacheArgsForCall[i]
@@ -1303,11 +1394,11 @@ func (fake *FakeTeam) CreateBuildReturnsOnCall(i int, result1 atc.Build, result2
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) CreateJobBuild(arg1 string, arg2 string) (atc.Build, error) {
+func (fake *FakeTeam) CreateJobBuild(arg1 atc.PipelineRef, arg2 string) (atc.Build, error) {
 	fake.createJobBuildMutex.Lock()
 	ret, specificReturn := fake.createJobBuildReturnsOnCall[len(fake.createJobBuildArgsForCall)]
 	fake.createJobBuildArgsForCall = append(fake.createJobBuildArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}{arg1, arg2})
 	fake.recordInvocation("CreateJobBuild", []interface{}{arg1, arg2})
@@ -1328,13 +1419,13 @@ func (fake *FakeTeam) CreateJobBuildCallCount() int {
 	return len(fake.createJobBuildArgsForCall)
 }
 
-func (fake *FakeTeam) CreateJobBuildCalls(stub func(string, string) (atc.Build, error)) {
+func (fake *FakeTeam) CreateJobBuildCalls(stub func(atc.PipelineRef, string) (atc.Build, error)) {
 	fake.createJobBuildMutex.Lock()
 	defer fake.createJobBuildMutex.Unlock()
 	fake.CreateJobBuildStub = stub
 }
 
-func (fake *FakeTeam) CreateJobBuildArgsForCall(i int) (string, string) {
+func (fake *FakeTeam) CreateJobBuildArgsForCall(i int) (atc.PipelineRef, string) {
 	fake.createJobBuildMutex.RLock()
 	defer fake.createJobBuildMutex.RUnlock()
 	argsForCall := fake.createJobBuildArgsForCall[i]
@@ -1367,6 +1458,71 @@ func (fake *FakeTeam) CreateJobBuildReturnsOnCall(i int, result1 atc.Build, resu
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateJobBuildWithPriority(arg1 atc.PipelineRef, arg2 string, arg3 int) (atc.Build, error) {
+	fake.createJobBuildWithPriorityMutex.Lock()
+	ret, specificReturn := fake.createJobBuildWithPriorityReturnsOnCall[len(fake.createJobBuildWithPriorityArgsForCall)]
+	fake.createJobBuildWithPriorityArgsForCall = append(fake.createJobBuildWithPriorityArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("CreateJobBuildWithPriority", []interface{}{arg1, arg2, arg3})
+	fake.createJobBuildWithPriorityMutex.Unlock()
+	if fake.CreateJobBuildWithPriorityStub != nil {
+		return fake.CreateJobBuildWithPriorityStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createJobBuildWithPriorityReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateJobBuildWithPriorityCallCount() int {
+	fake.createJobBuildWithPriorityMutex.RLock()
+	defer fake.createJobBuildWithPriorityMutex.RUnlock()
+	return len(fake.createJobBuildWithPriorityArgsForCall)
+}
+
+func (fake *FakeTeam) CreateJobBuildWithPriorityCalls(stub func(atc.PipelineRef, string, int) (atc.Build, error)) {
+	fake.createJobBuildWithPriorityMutex.Lock()
+	defer fake.createJobBuildWithPriorityMutex.Unlock()
+	fake.CreateJobBuildWithPriorityStub = stub
+}
+
+func (fake *FakeTeam) CreateJobBuildWithPriorityArgsForCall(i int) (atc.PipelineRef, string, int) {
+	fake.createJobBuildWithPriorityMutex.RLock()
+	defer fake.createJobBuildWithPriorityMutex.RUnlock()
+	argsForCall := fake.createJobBuildWithPriorityArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) CreateJobBuildWithPriorityReturns(result1 atc.Build, result2 error) {
+	fake.createJobBuildWithPriorityMutex.Lock()
+	defer fake.createJobBuildWithPriorityMutex.Unlock()
+	fake.CreateJobBuildWithPriorityStub = nil
+	fake.createJobBuildWithPriorityReturns = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateJobBuildWithPriorityReturnsOnCall(i int, result1 atc.Build, result2 error) {
+	fake.createJobBuildWithPriorityMutex.Lock()
+	defer fake.createJobBuildWithPriorityMutex.Unlock()
+	fake.CreateJobBuildWithPriorityStub = nil
+	if fake.createJobBuildWithPriorityReturnsOnCall == nil {
+		fake.createJobBuildWithPriorityReturnsOnCall = make(map[int]struct {
+			result1 atc.Build
+			result2 error
+		})
+	}
+	fake.createJobBuildWithPriorityReturnsOnCall[i] = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) CreateOrUpdate(arg1 atc.Team) (atc.Team, bool, bool, error) {
 	fake.createOrUpdateMutex.Lock()
 	ret, specificReturn := fake.createOrUpdateReturnsOnCall[len(fake.createOrUpdateArgsForCall)]
@@ -1436,7 +1592,7 @@ func (fake *FakeTeam) CreateOrUpdateReturnsOnCall(i int, result1 atc.Team, resul
 	}{result1, result2, result3, result4}
 }
 
-func (fake *FakeTeam) CreateOrUpdatePipelineConfig(arg1 string, arg2 string, arg3 []byte, arg4 bool) (bool, bool, []concourse.ConfigWarning, error) {
+func (fake *FakeTeam) CreateOrUpdatePipelineConfig(arg1 atc.PipelineRef, arg2 string, arg3 []byte, arg4 bool) (bool, bool, []concourse.ConfigWarning, error) {
 	var arg3Copy []byte
 	if arg3 != nil {
 		arg3Copy = make([]byte, len(arg3))
@@ -1445,7 +1601,7 @@ func (fake *FakeTeam) CreateOrUpdatePipelineConfig(arg1 string, arg2 string, arg
 	fake.createOrUpdatePipelineConfigMutex.Lock()
 	ret, specificReturn := fake.createOrUpdatePipelineConfigReturnsOnCall[len(fake.createOrUpdatePipelineConfigArgsForCall)]
 	fake.createOrUpdatePipelineConfigArgsForCall = append(fake.createOrUpdatePipelineConfigArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 []byte
 		arg4 bool
@@ -1468,13 +1624,13 @@ func (fake *FakeTeam) CreateOrUpdatePipelineConfigCallCount() int {
 	return len(fake.createOrUpdatePipelineConfigArgsForCall)
 }
 
-func (fake *FakeTeam) CreateOrUpdatePipelineConfigCalls(stub func(string, string, []byte, bool) (bool, bool, []concourse.ConfigWarning, error)) {
+func (fake *FakeTeam) CreateOrUpdatePipelineConfigCalls(stub func(atc.PipelineRef, string, []byte, bool) (bool, bool, []concourse.ConfigWarning, error)) {
 	fake.createOrUpdatePipelineConfigMutex.Lock()
 	defer fake.createOrUpdatePipelineConfigMutex.Unlock()
 	fake.CreateOrUpdatePipelineConfigStub = stub
 }
 
-func (fake *FakeTeam) CreateOrUpdatePipelineConfigArgsForCall(i int) (string, string, []byte, bool) {
+func (fake *FakeTeam) CreateOrUpdatePipelineConfigArgsForCall(i int) (atc.PipelineRef, string, []byte, bool) {
 	fake.createOrUpdatePipelineConfigMutex.RLock()
 	defer fake.createOrUpdatePipelineConfigMutex.RUnlock()
 	argsForCall := fake.createOrUpdatePipelineConfigArgsForCall[i]
@@ -1513,11 +1669,11 @@ func (fake *FakeTeam) CreateOrUpdatePipelineConfigReturnsOnCall(i int, result1 b
 	}{result1, result2, result3, result4}
 }
 
-func (fake *FakeTeam) CreatePipelineBuild(arg1 string, arg2 atc.Plan) (atc.Build, error) {
+func (fake *FakeTeam) CreatePipelineBuild(arg1 atc.PipelineRef, arg2 atc.Plan) (atc.Build, error) {
 	fake.createPipelineBuildMutex.Lock()
 	ret, specificReturn := fake.createPipelineBuildReturnsOnCall[len(fake.createPipelineBuildArgsForCall)]
 	fake.createPipelineBuildArgsForCall = append(fake.createPipelineBuildArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 atc.Plan
 	}{arg1, arg2})
 	fake.recordInvocation("CreatePipelineBuild", []interface{}{arg1, arg2})
@@ -1538,13 +1694,13 @@ func (fake *FakeTeam) CreatePipelineBuildCallCount() int {
 	return len(fake.createPipelineBuildArgsForCall)
 }
 
-func (fake *FakeTeam) CreatePipelineBuildCalls(stub func(string, atc.Plan) (atc.Build, error)) {
+func (fake *FakeTeam) CreatePipelineBuildCalls(stub func(atc.PipelineRef, atc.Plan) (atc.Build, error)) {
 	fake.createPipelineBuildMutex.Lock()
 	defer fake.createPipelineBuildMutex.Unlock()
 	fake.CreatePipelineBuildStub = stub
 }
 
-func (fake *FakeTeam) CreatePipelineBuildArgsForCall(i int) (string, atc.Plan) {
+func (fake *FakeTeam) CreatePipelineBuildArgsForCall(i int) (atc.PipelineRef, atc.Plan) {
 	fake.createPipelineBuildMutex.RLock()
 	defer fake.createPipelineBuildMutex.RUnlock()
 	argsForCall := fake.createPipelineBuildArgsForCall[i]
@@ -1577,11 +1733,11 @@ func (fake *FakeTeam) CreatePipelineBuildReturnsOnCall(i int, result1 atc.Build,
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) DeletePipeline(arg1 string) (bool, error) {
+func (fake *FakeTeam) DeletePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.deletePipelineMutex.Lock()
 	ret, specificReturn := fake.deletePipelineReturnsOnCall[len(fake.deletePipelineArgsForCall)]
 	fake.deletePipelineArgsForCall = append(fake.deletePipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("DeletePipeline", []interface{}{arg1})
 	fake.deletePipelineMutex.Unlock()
@@ -1601,13 +1757,13 @@ func (fake *FakeTeam) DeletePipelineCallCount() int {
 	return len(fake.deletePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) DeletePipelineCalls(stub func(string) (bool, error)) {
+func (fake *FakeTeam) DeletePipelineCalls(stub func(atc.PipelineRef) (bool, error)) {
 	fake.deletePipelineMutex.Lock()
 	defer fake.deletePipelineMutex.Unlock()
 	fake.DeletePipelineStub = stub
 }
 
-func (fake *FakeTeam) DeletePipelineArgsForCall(i int) string {
+func (fake *FakeTeam) DeletePipelineArgsForCall(i int) atc.PipelineRef {
 	fake.deletePipelineMutex.RLock()
 	defer fake.deletePipelineMutex.RUnlock()
 	argsForCall := fake.deletePipelineArgsForCall[i]
@@ -1700,11 +1856,11 @@ func (fake *FakeTeam) DestroyTeamReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTeam) DisableResourceVersion(arg1 string, arg2 string, arg3 int) (bool, error) {
+func (fake *FakeTeam) DisableResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int) (bool, error) {
 	fake.disableResourceVersionMutex.Lock()
 	ret, specificReturn := fake.disableResourceVersionReturnsOnCall[len(fake.disableResourceVersionArgsForCall)]
 	fake.disableResourceVersionArgsForCall = append(fake.disableResourceVersionArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
 	}{arg1, arg2, arg3})
@@ -1726,13 +1882,13 @@ func (fake *FakeTeam) DisableResourceVersionCallCount() int {
 	return len(fake.disableResourceVersionArgsForCall)
 }
 
-func (fake *FakeTeam) DisableResourceVersionCalls(stub func(string, string, int) (bool, error)) {
+func (fake *FakeTeam) DisableResourceVersionCalls(stub func(atc.PipelineRef, string, int) (bool, error)) {
 	fake.disableResourceVersionMutex.Lock()
 	defer fake.disableResourceVersionMutex.Unlock()
 	fake.DisableResourceVersionStub = stub
 }
 
-func (fake *FakeTeam) DisableResourceVersionArgsForCall(i int) (string, string, int) {
+func (fake *FakeTeam) DisableResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int) {
 	fake.disableResourceVersionMutex.RLock()
 	defer fake.disableResourceVersionMutex.RUnlock()
 	argsForCall := fake.disableResourceVersionArgsForCall[i]
@@ -1765,11 +1921,11 @@ func (fake *FakeTeam) DisableResourceVersionReturnsOnCall(i int, result1 bool, r
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) EnableResourceVersion(arg1 string, arg2 string, arg3 int) (bool, error) {
+func (fake *FakeTeam) EnableResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int) (bool, error) {
 	fake.enableResourceVersionMutex.Lock()
 	ret, specificReturn := fake.enableResourceVersionReturnsOnCall[len(fake.enableResourceVersionArgsForCall)]
 	fake.enableResourceVersionArgsForCall = append(fake.enableResourceVersionArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
 	}{arg1, arg2, arg3})
@@ -1791,13 +1947,13 @@ func (fake *FakeTeam) EnableResourceVersionCallCount() int {
 	return len(fake.enableResourceVersionArgsForCall)
 }
 
-func (fake *FakeTeam) EnableResourceVersionCalls(stub func(string, string, int) (bool, error)) {
+func (fake *FakeTeam) EnableResourceVersionCalls(stub func(atc.PipelineRef, string, int) (bool, error)) {
 	fake.enableResourceVersionMutex.Lock()
 	defer fake.enableResourceVersionMutex.Unlock()
 	fake.EnableResourceVersionStub = stub
 }
 
-func (fake *FakeTeam) EnableResourceVersionArgsForCall(i int) (string, string, int) {
+func (fake *FakeTeam) EnableResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int) {
 	fake.enableResourceVersionMutex.RLock()
 	defer fake.enableResourceVersionMutex.RUnlock()
 	argsForCall := fake.enableResourceVersionArgsForCall[i]
@@ -1830,11 +1986,11 @@ func (fake *FakeTeam) EnableResourceVersionReturnsOnCall(i int, result1 bool, re
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) ExposePipeline(arg1 string) (bool, error) {
+func (fake *FakeTeam) ExposePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.exposePipelineMutex.Lock()
 	ret, specificReturn := fake.exposePipelineReturnsOnCall[len(fake.exposePipelineArgsForCall)]
 	fake.exposePipelineArgsForCall = append(fake.exposePipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("ExposePipeline", []interface{}{arg1})
 	fake.exposePipelineMutex.Unlock()
@@ -1854,13 +2010,13 @@ func (fake *FakeTeam) ExposePipelineCallCount() int {
 	return len(fake.exposePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) ExposePipelineCalls(stub func(string) (bool, error)) {
+func (fake *FakeTeam) ExposePipelineCalls(stub func(atc.PipelineRef) (bool, error)) {
 	fake.exposePipelineMutex.Lock()
 	defer fake.exposePipelineMutex.Unlock()
 	fake.ExposePipelineStub = stub
 }
 
-func (fake *FakeTeam) ExposePipelineArgsForCall(i int) string {
+func (fake *FakeTeam) ExposePipelineArgsForCall(i int) atc.PipelineRef {
 	fake.exposePipelineMutex.RLock()
 	defer fake.exposePipelineMutex.RUnlock()
 	argsForCall := fake.exposePipelineArgsForCall[i]
@@ -2019,11 +2175,11 @@ func (fake *FakeTeam) GetContainerReturnsOnCall(i int, result1 atc.Container, re
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) HidePipeline(arg1 string) (bool, error) {
+func (fake *FakeTeam) HidePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.hidePipelineMutex.Lock()
 	ret, specificReturn := fake.hidePipelineReturnsOnCall[len(fake.hidePipelineArgsForCall)]
 	fake.hidePipelineArgsForCall = append(fake.hidePipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("HidePipeline", []interface{}{arg1})
 	fake.hidePipelineMutex.Unlock()
@@ -2043,13 +2199,13 @@ func (fake *FakeTeam) HidePipelineCallCount() int {
 	return len(fake.hidePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) HidePipelineCalls(stub func(string) (bool, error)) {
+func (fake *FakeTeam) HidePipelineCalls(stub func(atc.PipelineRef) (bool, error)) {
 	fake.hidePipelineMutex.Lock()
 	defer fake.hidePipelineMutex.Unlock()
 	fake.HidePipelineStub = stub
 }
 
-func (fake *FakeTeam) HidePipelineArgsForCall(i int) string {
+func (fake *FakeTeam) HidePipelineArgsForCall(i int) atc.PipelineRef {
 	fake.hidePipelineMutex.RLock()
 	defer fake.hidePipelineMutex.RUnlock()
 	argsForCall := fake.hidePipelineArgsForCall[i]
@@ -2082,11 +2238,11 @@ func (fake *FakeTeam) HidePipelineReturnsOnCall(i int, result1 bool, result2 err
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) Job(arg1 string, arg2 string) (atc.Job, bool, error) {
+func (fake *FakeTeam) Job(arg1 atc.PipelineRef, arg2 string) (atc.Job, bool, error) {
 	fake.jobMutex.Lock()
 	ret, specificReturn := fake.jobReturnsOnCall[len(fake.jobArgsForCall)]
 	fake.jobArgsForCall = append(fake.jobArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}{arg1, arg2})
 	fake.recordInvocation("Job", []interface{}{arg1, arg2})
@@ -2107,13 +2263,13 @@ func (fake *FakeTeam) JobCallCount() int {
 	return len(fake.jobArgsForCall)
 }
 
-func (fake *FakeTeam) JobCalls(stub func(string, string) (atc.Job, bool, error)) {
+func (fake *FakeTeam) JobCalls(stub func(atc.PipelineRef, string) (atc.Job, bool, error)) {
 	fake.jobMutex.Lock()
 	defer fake.jobMutex.Unlock()
 	fake.JobStub = stub
 }
 
-func (fake *FakeTeam) JobArgsForCall(i int) (string, string) {
+func (fake *FakeTeam) JobArgsForCall(i int) (atc.PipelineRef, string) {
 	fake.jobMutex.RLock()
 	defer fake.jobMutex.RUnlock()
 	argsForCall := fake.jobArgsForCall[i]
@@ -2149,11 +2305,11 @@ func (fake *FakeTeam) JobReturnsOnCall(i int, result1 atc.Job, result2 bool, res
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTeam) JobBuild(arg1 string, arg2 string, arg3 string) (atc.Build, bool, error) {
+func (fake *FakeTeam) JobBuild(arg1 atc.PipelineRef, arg2 string, arg3 string) (atc.Build, bool, error) {
 	fake.jobBuildMutex.Lock()
 	ret, specificReturn := fake.jobBuildReturnsOnCall[len(fake.jobBuildArgsForCall)]
 	fake.jobBuildArgsForCall = append(fake.jobBuildArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 string
 	}{arg1, arg2, arg3})
@@ -2175,13 +2331,13 @@ func (fake *FakeTeam) JobBuildCallCount() int {
 	return len(fake.jobBuildArgsForCall)
 }
 
-func (fake *FakeTeam) JobBuildCalls(stub func(string, string, string) (atc.Build, bool, error)) {
+func (fake *FakeTeam) JobBuildCalls(stub func(atc.PipelineRef, string, string) (atc.Build, bool, error)) {
 	fake.jobBuildMutex.Lock()
 	defer fake.jobBuildMutex.Unlock()
 	fake.JobBuildStub = stub
 }
 
-func (fake *FakeTeam) JobBuildArgsForCall(i int) (string, string, string) {
+func (fake *FakeTeam) JobBuildArgsForCall(i int) (atc.PipelineRef, string, string) {
 	fake.jobBuildMutex.RLock()
 	defer fake.jobBuildMutex.RUnlock()
 	argsForCall := fake.jobBuildArgsForCall[i]
@@ -2217,11 +2373,11 @@ func (fake *FakeTeam) JobBuildReturnsOnCall(i int, result1 atc.Build, result2 bo
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTeam) JobBuilds(arg1 string, arg2 string, arg3 concourse.Page) ([]atc.Build, concourse.Pagination, bool, error) {
+func (fake *FakeTeam) JobBuilds(arg1 atc.PipelineRef, arg2 string, arg3 concourse.Page) ([]atc.Build, concourse.Pagination, bool, error) {
 	fake.jobBuildsMutex.Lock()
 	ret, specificReturn := fake.jobBuildsReturnsOnCall[len(fake.jobBuildsArgsForCall)]
 	fake.jobBuildsArgsForCall = append(fake.jobBuildsArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 concourse.Page
 	}{arg1, arg2, arg3})
@@ -2243,13 +2399,13 @@ func (fake *FakeTeam) JobBuildsCallCount() int {
 	return len(fake.jobBuildsArgsForCall)
 }
 
-func (fake *FakeTeam) JobBuildsCalls(stub func(string, string, concourse.Page) ([]atc.Build, concourse.Pagination, bool, error)) {
+func (fake *FakeTeam) JobBuildsCalls(stub func(atc.PipelineRef, string, concourse.Page) ([]atc.Build, concourse.Pagination, bool, error)) {
 	fake.jobBuildsMutex.Lock()
 	defer fake.jobBuildsMutex.Unlock()
 	fake.JobBuildsStub = stub
 }
 
-func (fake *FakeTeam) JobBuildsArgsForCall(i int) (string, string, concourse.Page) {
+func (fake *FakeTeam) JobBuildsArgsForCall(i int) (atc.PipelineRef, string, concourse.Page) {
 	fake.jobBuildsMutex.RLock()
 	defer fake.jobBuildsMutex.RUnlock()
 	argsForCall := fake.jobBuildsArgsForCall[i]
@@ -2288,6 +2444,143 @@ func (fake *FakeTeam) JobBuildsReturnsOnCall(i int, result1 []atc.Build, result2
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) JobStats(arg1 atc.PipelineRef, arg2 string, arg3 time.Time, arg4 time.Time) (atc.JobStats, bool, error) {
+	fake.jobStatsMutex.Lock()
+	ret, specificReturn := fake.jobStatsReturnsOnCall[len(fake.jobStatsArgsForCall)]
+	fake.jobStatsArgsForCall = append(fake.jobStatsArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 time.Time
+		arg4 time.Time
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("JobStats", []interface{}{arg1, arg2, arg3, arg4})
+	fake.jobStatsMutex.Unlock()
+	if fake.JobStatsStub != nil {
+		return fake.JobStatsStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.jobStatsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) JobStatsCallCount() int {
+	fake.jobStatsMutex.RLock()
+	defer fake.jobStatsMutex.RUnlock()
+	return len(fake.jobStatsArgsForCall)
+}
+
+func (fake *FakeTeam) JobStatsCalls(stub func(atc.PipelineRef, string, time.Time, time.Time) (atc.JobStats, bool, error)) {
+	fake.jobStatsMutex.Lock()
+	defer fake.jobStatsMutex.Unlock()
+	fake.JobStatsStub = stub
+}
+
+func (fake *FakeTeam) JobStatsArgsForCall(i int) (atc.PipelineRef, string, time.Time, time.Time) {
+	fake.jobStatsMutex.RLock()
+	defer fake.jobStatsMutex.RUnlock()
+	argsForCall := fake.jobStatsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTeam) JobStatsReturns(result1 atc.JobStats, result2 bool, result3 error) {
+	fake.jobStatsMutex.Lock()
+	defer fake.jobStatsMutex.Unlock()
+	fake.JobStatsStub = nil
+	fake.jobStatsReturns = struct {
+		result1 atc.JobStats
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) JobStatsReturnsOnCall(i int, result1 atc.JobStats, result2 bool, result3 error) {
+	fake.jobStatsMutex.Lock()
+	defer fake.jobStatsMutex.Unlock()
+	fake.JobStatsStub = nil
+	if fake.jobStatsReturnsOnCall == nil {
+		fake.jobStatsReturnsOnCall = make(map[int]struct {
+			result1 atc.JobStats
+			result2 bool
+			result3 error
+		})
+	}
+	fake.jobStatsReturnsOnCall[i] = struct {
+		result1 atc.JobStats
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) JobTestHistory(arg1 atc.PipelineRef, arg2 string, arg3 int) ([]atc.TestHistory, bool, error) {
+	fake.jobTestHistoryMutex.Lock()
+	ret, specificReturn := fake.jobTestHistoryReturnsOnCall[len(fake.jobTestHistoryArgsForCall)]
+	fake.jobTestHistoryArgsForCall = append(fake.jobTestHistoryArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("JobTestHistory", []interface{}{arg1, arg2, arg3})
+	fake.jobTestHistoryMutex.Unlock()
+	if fake.JobTestHistoryStub != nil {
+		return fake.JobTestHistoryStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.jobTestHistoryReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) JobTestHistoryCallCount() int {
+	fake.jobTestHistoryMutex.RLock()
+	defer fake.jobTestHistoryMutex.RUnlock()
+	return len(fake.jobTestHistoryArgsForCall)
+}
+
+func (fake *FakeTeam) JobTestHistoryCalls(stub func(atc.PipelineRef, string, int) ([]atc.TestHistory, bool, error)) {
+	fake.jobTestHistoryMutex.Lock()
+	defer fake.jobTestHistoryMutex.Unlock()
+	fake.JobTestHistoryStub = stub
+}
+
+func (fake *FakeTeam) JobTestHistoryArgsForCall(i int) (atc.PipelineRef, string, int) {
+	fake.jobTestHistoryMutex.RLock()
+	defer fake.jobTestHistoryMutex.RUnlock()
+	argsForCall := fake.jobTestHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) JobTestHistoryReturns(result1 []atc.TestHistory, result2 bool, result3 error) {
+	fake.jobTestHistoryMutex.Lock()
+	defer fake.jobTestHistoryMutex.Unlock()
+	fake.JobTestHistoryStub = nil
+	fake.jobTestHistoryReturns = struct {
+		result1 []atc.TestHistory
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) JobTestHistoryReturnsOnCall(i int, result1 []atc.TestHistory, result2 bool, result3 error) {
+	fake.jobTestHistoryMutex.Lock()
+	defer fake.jobTestHistoryMutex.Unlock()
+	fake.JobTestHistoryStub = nil
+	if fake.jobTestHistoryReturnsOnCall == nil {
+		fake.jobTestHistoryReturnsOnCall = make(map[int]struct {
+			result1 []atc.TestHistory
+			result2 bool
+			result3 error
+		})
+	}
+	fake.jobTestHistoryReturnsOnCall[i] = struct {
+		result1 []atc.TestHistory
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) ListContainers(arg1 map[string]string) ([]atc.Container, error) {
 	fake.listContainersMutex.Lock()
 	ret, specificReturn := fake.listContainersReturnsOnCall[len(fake.listContainersArgsForCall)]
@@ -2351,11 +2644,11 @@ func (fake *FakeTeam) ListContainersReturnsOnCall(i int, result1 []atc.Container
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) ListJobs(arg1 string) ([]atc.Job, error) {
+func (fake *FakeTeam) ListJobs(arg1 atc.PipelineRef) ([]atc.Job, error) {
 	fake.listJobsMutex.Lock()
 	ret, specificReturn := fake.listJobsReturnsOnCall[len(fake.listJobsArgsForCall)]
 	fake.listJobsArgsForCall = append(fake.listJobsArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("ListJobs", []interface{}{arg1})
 	fake.listJobsMutex.Unlock()
@@ -2375,13 +2668,13 @@ func (fake *FakeTeam) ListJobsCallCount() int {
 	return len(fake.listJobsArgsForCall)
 }
 
-func (fake *FakeTeam) ListJobsCalls(stub func(string) ([]atc.Job, error)) {
+func (fake *FakeTeam) ListJobsCalls(stub func(atc.PipelineRef) ([]atc.Job, error)) {
 	fake.listJobsMutex.Lock()
 	defer fake.listJobsMutex.Unlock()
 	fake.ListJobsStub = stub
 }
 
-func (fake *FakeTeam) ListJobsArgsForCall(i int) string {
+func (fake *FakeTeam) ListJobsArgsForCall(i int) atc.PipelineRef {
 	fake.listJobsMutex.RLock()
 	defer fake.listJobsMutex.RUnlock()
 	argsForCall := fake.listJobsArgsForCall[i]
@@ -2469,11 +2762,11 @@ func (fake *FakeTeam) ListPipelinesReturnsOnCall(i int, result1 []atc.Pipeline,
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) ListResources(arg1 string) ([]atc.Resource, error) {
+func (fake *FakeTeam) ListResources(arg1 atc.PipelineRef) ([]atc.Resource, error) {
 	fake.listResourcesMutex.Lock()
 	ret, specificReturn := fake.listResourcesReturnsOnCall[len(fake.listResourcesArgsForCall)]
 	fake.listResourcesArgsForCall = append(fake.listResourcesArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("ListResources", []interface{}{arg1})
 	fake.listResourcesMutex.Unlock()
@@ -2493,13 +2786,13 @@ func (fake *FakeTeam) ListResourcesCallCount() int {
 	return len(fake.listResourcesArgsForCall)
 }
 
-func (fake *FakeTeam) ListResourcesCalls(stub func(string) ([]atc.Resource, error)) {
+func (fake *FakeTeam) ListResourcesCalls(stub func(atc.PipelineRef) ([]atc.Resource, error)) {
 	fake.listResourcesMutex.Lock()
 	defer fake.listResourcesMutex.Unlock()
 	fake.ListResourcesStub = stub
 }
 
-func (fake *FakeTeam) ListResourcesArgsForCall(i int) string {
+func (fake *FakeTeam) ListResourcesArgsForCall(i int) atc.PipelineRef {
 	fake.listResourcesMutex.RLock()
 	defer fake.listResourcesMutex.RUnlock()
 	argsForCall := fake.listResourcesArgsForCall[i]
@@ -2704,11 +2997,11 @@ func (fake *FakeTeam) OrderingPipelinesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTeam) PauseJob(arg1 string, arg2 string) (bool, error) {
+func (fake *FakeTeam) PauseJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.pauseJobMutex.Lock()
 	ret, specificReturn := fake.pauseJobReturnsOnCall[len(fake.pauseJobArgsForCall)]
 	fake.pauseJobArgsForCall = append(fake.pauseJobArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}{arg1, arg2})
 	fake.recordInvocation("PauseJob", []interface{}{arg1, arg2})
@@ -2729,13 +3022,13 @@ func (fake *FakeTeam) PauseJobCallCount() int {
 	return len(fake.pauseJobArgsForCall)
 }
 
-func (fake *FakeTeam) PauseJobCalls(stub func(string, string) (bool, error)) {
+func (fake *FakeTeam) PauseJobCalls(stub func(atc.PipelineRef, string) (bool, error)) {
 	fake.pauseJobMutex.Lock()
 	defer fake.pauseJobMutex.Unlock()
 	fake.PauseJobStub = stub
 }
 
-func (fake *FakeTeam) PauseJobArgsForCall(i int) (string, string) {
+func (fake *FakeTeam) PauseJobArgsForCall(i int) (atc.PipelineRef, string) {
 	fake.pauseJobMutex.RLock()
 	defer fake.pauseJobMutex.RUnlock()
 	argsForCall := fake.pauseJobArgsForCall[i]
@@ -2768,11 +3061,11 @@ func (fake *FakeTeam) PauseJobReturnsOnCall(i int, result1 bool, result2 error)
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) PausePipeline(arg1 string) (bool, error) {
+func (fake *FakeTeam) PausePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.pausePipelineMutex.Lock()
 	ret, specificReturn := fake.pausePipelineReturnsOnCall[len(fake.pausePipelineArgsForCall)]
 	fake.pausePipelineArgsForCall = append(fake.pausePipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("PausePipeline", []interface{}{arg1})
 	fake.pausePipelineMutex.Unlock()
@@ -2792,13 +3085,13 @@ func (fake *FakeTeam) PausePipelineCallCount() int {
 	return len(fake.pausePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) PausePipelineCalls(stub func(string) (bool, error)) {
+func (fake *FakeTeam) PausePipelineCalls(stub func(atc.PipelineRef) (bool, error)) {
 	fake.pausePipelineMutex.Lock()
 	defer fake.pausePipelineMutex.Unlock()
 	fake.PausePipelineStub = stub
 }
 
-func (fake *FakeTeam) PausePipelineArgsForCall(i int) string {
+func (fake *FakeTeam) PausePipelineArgsForCall(i int) atc.PipelineRef {
 	fake.pausePipelineMutex.RLock()
 	defer fake.pausePipelineMutex.RUnlock()
 	argsForCall := fake.pausePipelineArgsForCall[i]
@@ -2831,11 +3124,11 @@ func (fake *FakeTeam) PausePipelineReturnsOnCall(i int, result1 bool, result2 er
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) PinResourceVersion(arg1 string, arg2 string, arg3 int) (bool, error) {
+func (fake *FakeTeam) PinResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int) (bool, error) {
 	fake.pinResourceVersionMutex.Lock()
 	ret, specificReturn := fake.pinResourceVersionReturnsOnCall[len(fake.pinResourceVersionArgsForCall)]
 	fake.pinResourceVersionArgsForCall = append(fake.pinResourceVersionArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 int
 	}{arg1, arg2, arg3})
@@ -2857,13 +3150,13 @@ func (fake *FakeTeam) PinResourceVersionCallCount() int {
 	return len(fake.pinResourceVersionArgsForCall)
 }
 
-func (fake *FakeTeam) PinResourceVersionCalls(stub func(string, string, int) (bool, error)) {
+func (fake *FakeTeam) PinResourceVersionCalls(stub func(atc.PipelineRef, string, int) (bool, error)) {
 	fake.pinResourceVersionMutex.Lock()
 	defer fake.pinResourceVersionMutex.Unlock()
 	fake.PinResourceVersionStub = stub
 }
 
-func (fake *FakeTeam) PinResourceVersionArgsForCall(i int) (string, string, int) {
+func (fake *FakeTeam) PinResourceVersionArgsForCall(i int) (atc.PipelineRef, string, int) {
 	fake.pinResourceVersionMutex.RLock()
 	defer fake.pinResourceVersionMutex.RUnlock()
 	argsForCall := fake.pinResourceVersionArgsForCall[i]
@@ -2896,11 +3189,11 @@ func (fake *FakeTeam) PinResourceVersionReturnsOnCall(i int, result1 bool, resul
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) Pipeline(arg1 string) (atc.Pipeline, bool, error) {
+func (fake *FakeTeam) Pipeline(arg1 atc.PipelineRef) (atc.Pipeline, bool, error) {
 	fake.pipelineMutex.Lock()
 	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
 	fake.pipelineArgsForCall = append(fake.pipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("Pipeline", []interface{}{arg1})
 	fake.pipelineMutex.Unlock()
@@ -2920,13 +3213,13 @@ func (fake *FakeTeam) PipelineCallCount() int {
 	return len(fake.pipelineArgsForCall)
 }
 
-func (fake *FakeTeam) PipelineCalls(stub func(string) (atc.Pipeline, bool, error)) {
+func (fake *FakeTeam) PipelineCalls(stub func(atc.PipelineRef) (atc.Pipeline, bool, error)) {
 	fake.pipelineMutex.Lock()
 	defer fake.pipelineMutex.Unlock()
 	fake.PipelineStub = stub
 }
 
-func (fake *FakeTeam) PipelineArgsForCall(i int) string {
+func (fake *FakeTeam) PipelineArgsForCall(i int) atc.PipelineRef {
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	argsForCall := fake.pipelineArgsForCall[i]
@@ -2962,11 +3255,11 @@ func (fake *FakeTeam) PipelineReturnsOnCall(i int, result1 atc.Pipeline, result2
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTeam) PipelineBuilds(arg1 string, arg2 concourse.Page) ([]atc.Build, concourse.Pagination, bool, error) {
+func (fake *FakeTeam) PipelineBuilds(arg1 atc.PipelineRef, arg2 concourse.Page) ([]atc.Build, concourse.Pagination, bool, error) {
 	fake.pipelineBuildsMutex.Lock()
 	ret, specificReturn := fake.pipelineBuildsReturnsOnCall[len(fake.pipelineBuildsArgsForCall)]
 	fake.pipelineBuildsArgsForCall = append(fake.pipelineBuildsArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 concourse.Page
 	}{arg1, arg2})
 	fake.recordInvocation("PipelineBuilds", []interface{}{arg1, arg2})
@@ -2987,13 +3280,13 @@ func (fake *FakeTeam) PipelineBuildsCallCount() int {
 	return len(fake.pipelineBuildsArgsForCall)
 }
 
-func (fake *FakeTeam) PipelineBuildsCalls(stub func(string, concourse.Page) ([]atc.Build, concourse.Pagination, bool, error)) {
+func (fake *FakeTeam) PipelineBuildsCalls(stub func(atc.PipelineRef, concourse.Page) ([]atc.Build, concourse.Pagination, bool, error)) {
 	fake.pipelineBuildsMutex.Lock()
 	defer fake.pipelineBuildsMutex.Unlock()
 	fake.PipelineBuildsStub = stub
 }
 
-func (fake *FakeTeam) PipelineBuildsArgsForCall(i int) (string, concourse.Page) {
+func (fake *FakeTeam) PipelineBuildsArgsForCall(i int) (atc.PipelineRef, concourse.Page) {
 	fake.pipelineBuildsMutex.RLock()
 	defer fake.pipelineBuildsMutex.RUnlock()
 	argsForCall := fake.pipelineBuildsArgsForCall[i]
@@ -3032,11 +3325,11 @@ func (fake *FakeTeam) PipelineBuildsReturnsOnCall(i int, result1 []atc.Build, re
 	}{result1, result2, result3, result4}
 }
 
-func (fake *FakeTeam) PipelineConfig(arg1 string) (atc.Config, string, bool, error) {
+func (fake *FakeTeam) PipelineConfig(arg1 atc.PipelineRef) (atc.Config, string, bool, error) {
 	fake.pipelineConfigMutex.Lock()
 	ret, specificReturn := fake.pipelineConfigReturnsOnCall[len(fake.pipelineConfigArgsForCall)]
 	fake.pipelineConfigArgsForCall = append(fake.pipelineConfigArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("PipelineConfig", []interface{}{arg1})
 	fake.pipelineConfigMutex.Unlock()
@@ -3056,13 +3349,13 @@ func (fake *FakeTeam) PipelineConfigCallCount() int {
 	return len(fake.pipelineConfigArgsForCall)
 }
 
-func (fake *FakeTeam) PipelineConfigCalls(stub func(string) (atc.Config, string, bool, error)) {
+func (fake *FakeTeam) PipelineConfigCalls(stub func(atc.PipelineRef) (atc.Config, string, bool, error)) {
 	fake.pipelineConfigMutex.Lock()
 	defer fake.pipelineConfigMutex.Unlock()
 	fake.PipelineConfigStub = stub
 }
 
-func (fake *FakeTeam) PipelineConfigArgsForCall(i int) string {
+func (fake *FakeTeam) PipelineConfigArgsForCall(i int) atc.PipelineRef {
 	fake.pipelineConfigMutex.RLock()
 	defer fake.pipelineConfigMutex.RUnlock()
 	argsForCall := fake.pipelineConfigArgsForCall[i]
@@ -3101,11 +3394,11 @@ func (fake *FakeTeam) PipelineConfigReturnsOnCall(i int, result1 atc.Config, res
 	}{result1, result2, result3, result4}
 }
 
-func (fake *FakeTeam) RenamePipeline(arg1 string, arg2 string) (bool, error) {
+func (fake *FakeTeam) RenamePipeline(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.renamePipelineMutex.Lock()
 	ret, specificReturn := fake.renamePipelineReturnsOnCall[len(fake.renamePipelineArgsForCall)]
 	fake.renamePipelineArgsForCall = append(fake.renamePipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}{arg1, arg2})
 	fake.recordInvocation("RenamePipeline", []interface{}{arg1, arg2})
@@ -3126,13 +3419,13 @@ func (fake *FakeTeam) RenamePipelineCallCount() int {
 	return len(fake.renamePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) RenamePipelineCalls(stub func(string, string) (bool, error)) {
+func (fake *FakeTeam) RenamePipelineCalls(stub func(atc.PipelineRef, string) (bool, error)) {
 	fake.renamePipelineMutex.Lock()
 	defer fake.renamePipelineMutex.Unlock()
 	fake.RenamePipelineStub = stub
 }
 
-func (fake *FakeTeam) RenamePipelineArgsForCall(i int) (string, string) {
+func (fake *FakeTeam) RenamePipelineArgsForCall(i int) (atc.PipelineRef, string) {
 	fake.renamePipelineMutex.RLock()
 	defer fake.renamePipelineMutex.RUnlock()
 	argsForCall := fake.renamePipelineArgsForCall[i]
@@ -3229,11 +3522,76 @@ func (fake *FakeTeam) RenameTeamReturnsOnCall(i int, result1 bool, result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) Resource(arg1 string, arg2 string) (atc.Resource, bool, error) {
+func (fake *FakeTeam) RerunJobBuild(arg1 atc.PipelineRef, arg2 string, arg3 string) (atc.Build, error) {
+	fake.rerunJobBuildMutex.Lock()
+	ret, specificReturn := fake.rerunJobBuildReturnsOnCall[len(fake.rerunJobBuildArgsForCall)]
+	fake.rerunJobBuildArgsForCall = append(fake.rerunJobBuildArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("RerunJobBuild", []interface{}{arg1, arg2, arg3})
+	fake.rerunJobBuildMutex.Unlock()
+	if fake.RerunJobBuildStub != nil {
+		return fake.RerunJobBuildStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.rerunJobBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) RerunJobBuildCallCount() int {
+	fake.rerunJobBuildMutex.RLock()
+	defer fake.rerunJobBuildMutex.RUnlock()
+	return len(fake.rerunJobBuildArgsForCall)
+}
+
+func (fake *FakeTeam) RerunJobBuildCalls(stub func(atc.PipelineRef, string, string) (atc.Build, error)) {
+	fake.rerunJobBuildMutex.Lock()
+	defer fake.rerunJobBuildMutex.Unlock()
+	fake.RerunJobBuildStub = stub
+}
+
+func (fake *FakeTeam) RerunJobBuildArgsForCall(i int) (atc.PipelineRef, string, string) {
+	fake.rerunJobBuildMutex.RLock()
+	defer fake.rerunJobBuildMutex.RUnlock()
+	argsForCall := fake.rerunJobBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) RerunJobBuildReturns(result1 atc.Build, result2 error) {
+	fake.rerunJobBuildMutex.Lock()
+	defer fake.rerunJobBuildMutex.Unlock()
+	fake.RerunJobBuildStub = nil
+	fake.rerunJobBuildReturns = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) RerunJobBuildReturnsOnCall(i int, result1 atc.Build, result2 error) {
+	fake.rerunJobBuildMutex.Lock()
+	defer fake.rerunJobBuildMutex.Unlock()
+	fake.RerunJobBuildStub = nil
+	if fake.rerunJobBuildReturnsOnCall == nil {
+		fake.rerunJobBuildReturnsOnCall = make(map[int]struct {
+			result1 atc.Build
+			result2 error
+		})
+	}
+	fake.rerunJobBuildReturnsOnCall[i] = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) Resource(arg1 atc.PipelineRef, arg2 string) (atc.Resource, bool, error) {
 	fake.resourceMutex.Lock()
 	ret, specificReturn := fake.resourceReturnsOnCall[len(fake.resourceArgsForCall)]
 	fake.resourceArgsForCall = append(fake.resourceArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}{arg1, arg2})
 	fake.recordInvocation("Resource", []interface{}{arg1, arg2})
@@ -3254,13 +3612,13 @@ func (fake *FakeTeam) ResourceCallCount() int {
 	return len(fake.resourceArgsForCall)
 }
 
-func (fake *FakeTeam) ResourceCalls(stub func(string, string) (atc.Resource, bool, error)) {
+func (fake *FakeTeam) ResourceCalls(stub func(atc.PipelineRef, string) (atc.Resource, bool, error)) {
 	fake.resourceMutex.Lock()
 	defer fake.resourceMutex.Unlock()
 	fake.ResourceStub = stub
 }
 
-func (fake *FakeTeam) ResourceArgsForCall(i int) (string, string) {
+func (fake *FakeTeam) ResourceArgsForCall(i int) (atc.PipelineRef, string) {
 	fake.resourceMutex.RLock()
 	defer fake.resourceMutex.RUnlock()
 	argsForCall := fake.resourceArgsForCall[i]
@@ -3296,11 +3654,11 @@ func (fake *FakeTeam) ResourceReturnsOnCall(i int, result1 atc.Resource, result2
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTeam) ResourceVersions(arg1 string, arg2 string, arg3 concourse.Page, arg4 atc.Version) ([]atc.ResourceVersion, concourse.Pagination, bool, error) {
+func (fake *FakeTeam) ResourceVersions(arg1 atc.PipelineRef, arg2 string, arg3 concourse.Page, arg4 atc.Version) ([]atc.ResourceVersion, concourse.Pagination, bool, error) {
 	fake.resourceVersionsMutex.Lock()
 	ret, specificReturn := fake.resourceVersionsReturnsOnCall[len(fake.resourceVersionsArgsForCall)]
 	fake.resourceVersionsArgsForCall = append(fake.resourceVersionsArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 concourse.Page
 		arg4 atc.Version
@@ -3323,13 +3681,13 @@ func (fake *FakeTeam) ResourceVersionsCallCount() int {
 	return len(fake.resourceVersionsArgsForCall)
 }
 
-func (fake *FakeTeam) ResourceVersionsCalls(stub func(string, string, concourse.Page, atc.Version) ([]atc.ResourceVersion, concourse.Pagination, bool, error)) {
+func (fake *FakeTeam) ResourceVersionsCalls(stub func(atc.PipelineRef, string, concourse.Page, atc.Version) ([]atc.ResourceVersion, concourse.Pagination, bool, error)) {
 	fake.resourceVersionsMutex.Lock()
 	defer fake.resourceVersionsMutex.Unlock()
 	fake.ResourceVersionsStub = stub
 }
 
-func (fake *FakeTeam) ResourceVersionsArgsForCall(i int) (string, string, concourse.Page, atc.Version) {
+func (fake *FakeTeam) ResourceVersionsArgsForCall(i int) (atc.PipelineRef, string, concourse.Page, atc.Version) {
 	fake.resourceVersionsMutex.RLock()
 	defer fake.resourceVersionsMutex.RUnlock()
 	argsForCall := fake.resourceVersionsArgsForCall[i]
@@ -3368,11 +3726,129 @@ func (fake *FakeTeam) ResourceVersionsReturnsOnCall(i int, result1 []atc.Resourc
 	}{result1, result2, result3, result4}
 }
 
-func (fake *FakeTeam) SetPinComment(arg1 string, arg2 string, arg3 string) (bool, error) {
+func (fake *FakeTeam) SearchBuildLogs(arg1 concourse.BuildLogSearch) ([]atc.BuildLogSearchResult, error) {
+	fake.searchBuildLogsMutex.Lock()
+	ret, specificReturn := fake.searchBuildLogsReturnsOnCall[len(fake.searchBuildLogsArgsForCall)]
+	fake.searchBuildLogsArgsForCall = append(fake.searchBuildLogsArgsForCall, struct {
+		arg1 concourse.BuildLogSearch
+	}{arg1})
+	fake.recordInvocation("SearchBuildLogs", []interface{}{arg1})
+	fake.searchBuildLogsMutex.Unlock()
+	if fake.SearchBuildLogsStub != nil {
+		return fake.SearchBuildLogsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.searchBuildLogsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SearchBuildLogsCallCount() int {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	return len(fake.searchBuildLogsArgsForCall)
+}
+
+func (fake *FakeTeam) SearchBuildLogsCalls(stub func(concourse.BuildLogSearch) ([]atc.BuildLogSearchResult, error)) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = stub
+}
+
+func (fake *FakeTeam) SearchBuildLogsArgsForCall(i int) concourse.BuildLogSearch {
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	argsForCall := fake.searchBuildLogsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturns(result1 []atc.BuildLogSearchResult, result2 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	fake.searchBuildLogsReturns = struct {
+		result1 []atc.BuildLogSearchResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SearchBuildLogsReturnsOnCall(i int, result1 []atc.BuildLogSearchResult, result2 error) {
+	fake.searchBuildLogsMutex.Lock()
+	defer fake.searchBuildLogsMutex.Unlock()
+	fake.SearchBuildLogsStub = nil
+	if fake.searchBuildLogsReturnsOnCall == nil {
+		fake.searchBuildLogsReturnsOnCall = make(map[int]struct {
+			result1 []atc.BuildLogSearchResult
+			result2 error
+		})
+	}
+	fake.searchBuildLogsReturnsOnCall[i] = struct {
+		result1 []atc.BuildLogSearchResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) Semaphores() ([]atc.Semaphore, error) {
+	fake.semaphoresMutex.Lock()
+	ret, specificReturn := fake.semaphoresReturnsOnCall[len(fake.semaphoresArgsForCall)]
+	fake.semaphoresArgsForCall = append(fake.semaphoresArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Semaphores", []interface{}{})
+	fake.semaphoresMutex.Unlock()
+	if fake.SemaphoresStub != nil {
+		return fake.SemaphoresStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.semaphoresReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) SemaphoresCallCount() int {
+	fake.semaphoresMutex.RLock()
+	defer fake.semaphoresMutex.RUnlock()
+	return len(fake.semaphoresArgsForCall)
+}
+
+func (fake *FakeTeam) SemaphoresCalls(stub func() ([]atc.Semaphore, error)) {
+	fake.semaphoresMutex.Lock()
+	defer fake.semaphoresMutex.Unlock()
+	fake.SemaphoresStub = stub
+}
+
+func (fake *FakeTeam) SemaphoresReturns(result1 []atc.Semaphore, result2 error) {
+	fake.semaphoresMutex.Lock()
+	defer fake.semaphoresMutex.Unlock()
+	fake.SemaphoresStub = nil
+	fake.semaphoresReturns = struct {
+		result1 []atc.Semaphore
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SemaphoresReturnsOnCall(i int, result1 []atc.Semaphore, result2 error) {
+	fake.semaphoresMutex.Lock()
+	defer fake.semaphoresMutex.Unlock()
+	fake.SemaphoresStub = nil
+	if fake.semaphoresReturnsOnCall == nil {
+		fake.semaphoresReturnsOnCall = make(map[int]struct {
+			result1 []atc.Semaphore
+			result2 error
+		})
+	}
+	fake.semaphoresReturnsOnCall[i] = struct {
+		result1 []atc.Semaphore
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) SetPinComment(arg1 atc.PipelineRef, arg2 string, arg3 string) (bool, error) {
 	fake.setPinCommentMutex.Lock()
 	ret, specificReturn := fake.setPinCommentReturnsOnCall[len(fake.setPinCommentArgsForCall)]
 	fake.setPinCommentArgsForCall = append(fake.setPinCommentArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 		arg3 string
 	}{arg1, arg2, arg3})
@@ -3394,13 +3870,13 @@ func (fake *FakeTeam) SetPinCommentCallCount() int {
 	return len(fake.setPinCommentArgsForCall)
 }
 
-func (fake *FakeTeam) SetPinCommentCalls(stub func(string, string, string) (bool, error)) {
+func (fake *FakeTeam) SetPinCommentCalls(stub func(atc.PipelineRef, string, string) (bool, error)) {
 	fake.setPinCommentMutex.Lock()
 	defer fake.setPinCommentMutex.Unlock()
 	fake.SetPinCommentStub = stub
 }
 
-func (fake *FakeTeam) SetPinCommentArgsForCall(i int) (string, string, string) {
+func (fake *FakeTeam) SetPinCommentArgsForCall(i int) (atc.PipelineRef, string, string) {
 	fake.setPinCommentMutex.RLock()
 	defer fake.setPinCommentMutex.RUnlock()
 	argsForCall := fake.setPinCommentArgsForCall[i]
@@ -3499,11 +3975,11 @@ func (fake *FakeTeam) TeamReturnsOnCall(i int, result1 atc.Team, result2 bool, r
 	}{result1, result2, result3}
 }
 
-func (fake *FakeTeam) UnpauseJob(arg1 string, arg2 string) (bool, error) {
+func (fake *FakeTeam) UnpauseJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.unpauseJobMutex.Lock()
 	ret, specificReturn := fake.unpauseJobReturnsOnCall[len(fake.unpauseJobArgsForCall)]
 	fake.unpauseJobArgsForCall = append(fake.unpauseJobArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}{arg1, arg2})
 	fake.recordInvocation("UnpauseJob", []interface{}{arg1, arg2})
@@ -3524,13 +4000,13 @@ func (fake *FakeTeam) UnpauseJobCallCount() int {
 	return len(fake.unpauseJobArgsForCall)
 }
 
-func (fake *FakeTeam) UnpauseJobCalls(stub func(string, string) (bool, error)) {
+func (fake *FakeTeam) UnpauseJobCalls(stub func(atc.PipelineRef, string) (bool, error)) {
 	fake.unpauseJobMutex.Lock()
 	defer fake.unpauseJobMutex.Unlock()
 	fake.UnpauseJobStub = stub
 }
 
-func (fake *FakeTeam) UnpauseJobArgsForCall(i int) (string, string) {
+func (fake *FakeTeam) UnpauseJobArgsForCall(i int) (atc.PipelineRef, string) {
 	fake.unpauseJobMutex.RLock()
 	defer fake.unpauseJobMutex.RUnlock()
 	argsForCall := fake.unpauseJobArgsForCall[i]
@@ -3563,11 +4039,11 @@ func (fake *FakeTeam) UnpauseJobReturnsOnCall(i int, result1 bool, result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) UnpausePipeline(arg1 string) (bool, error) {
+func (fake *FakeTeam) UnpausePipeline(arg1 atc.PipelineRef) (bool, error) {
 	fake.unpausePipelineMutex.Lock()
 	ret, specificReturn := fake.unpausePipelineReturnsOnCall[len(fake.unpausePipelineArgsForCall)]
 	fake.unpausePipelineArgsForCall = append(fake.unpausePipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("UnpausePipeline", []interface{}{arg1})
 	fake.unpausePipelineMutex.Unlock()
@@ -3587,13 +4063,13 @@ func (fake *FakeTeam) UnpausePipelineCallCount() int {
 	return len(fake.unpausePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) UnpausePipelineCalls(stub func(string) (bool, error)) {
+func (fake *FakeTeam) UnpausePipelineCalls(stub func(atc.PipelineRef) (bool, error)) {
 	fake.unpausePipelineMutex.Lock()
 	defer fake.unpausePipelineMutex.Unlock()
 	fake.UnpausePipelineStub = stub
 }
 
-func (fake *FakeTeam) UnpausePipelineArgsForCall(i int) string {
+func (fake *FakeTeam) UnpausePipelineArgsForCall(i int) atc.PipelineRef {
 	fake.unpausePipelineMutex.RLock()
 	defer fake.unpausePipelineMutex.RUnlock()
 	argsForCall := fake.unpausePipelineArgsForCall[i]
@@ -3626,11 +4102,11 @@ func (fake *FakeTeam) UnpausePipelineReturnsOnCall(i int, result1 bool, result2
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) UnpinResource(arg1 string, arg2 string) (bool, error) {
+func (fake *FakeTeam) UnpinResource(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.unpinResourceMutex.Lock()
 	ret, specificReturn := fake.unpinResourceReturnsOnCall[len(fake.unpinResourceArgsForCall)]
 	fake.unpinResourceArgsForCall = append(fake.unpinResourceArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 string
 	}{arg1, arg2})
 	fake.recordInvocation("UnpinResource", []interface{}{arg1, arg2})
@@ -3651,13 +4127,13 @@ func (fake *FakeTeam) UnpinResourceCallCount() int {
 	return len(fake.unpinResourceArgsForCall)
 }
 
-func (fake *FakeTeam) UnpinResourceCalls(stub func(string, string) (bool, error)) {
+func (fake *FakeTeam) UnpinResourceCalls(stub func(atc.PipelineRef, string) (bool, error)) {
 	fake.unpinResourceMutex.Lock()
 	defer fake.unpinResourceMutex.Unlock()
 	fake.UnpinResourceStub = stub
 }
 
-func (fake *FakeTeam) UnpinResourceArgsForCall(i int) (string, string) {
+func (fake *FakeTeam) UnpinResourceArgsForCall(i int) (atc.PipelineRef, string) {
 	fake.unpinResourceMutex.RLock()
 	defer fake.unpinResourceMutex.RUnlock()
 	argsForCall := fake.unpinResourceArgsForCall[i]
@@ -3690,11 +4166,11 @@ func (fake *FakeTeam) UnpinResourceReturnsOnCall(i int, result1 bool, result2 er
 	}{result1, result2}
 }
 
-func (fake *FakeTeam) VersionedResourceTypes(arg1 string) (atc.VersionedResourceTypes, bool, error) {
+func (fake *FakeTeam) VersionedResourceTypes(arg1 atc.PipelineRef) (atc.VersionedResourceTypes, bool, error) {
 	fake.versionedResourceTypesMutex.Lock()
 	ret, specificReturn := fake.versionedResourceTypesReturnsOnCall[len(fake.versionedResourceTypesArgsForCall)]
 	fake.versionedResourceTypesArgsForCall = append(fake.versionedResourceTypesArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("VersionedResourceTypes", []interface{}{arg1})
 	fake.versionedResourceTypesMutex.Unlock()
@@ -3714,13 +4190,13 @@ func (fake *FakeTeam) VersionedResourceTypesCallCount() int {
 	return len(fake.versionedResourceTypesArgsForCall)
 }
 
-func (fake *FakeTeam) VersionedResourceTypesCalls(stub func(string) (atc.VersionedResourceTypes, bool, error)) {
+func (fake *FakeTeam) VersionedResourceTypesCalls(stub func(atc.PipelineRef) (atc.VersionedResourceTypes, bool, error)) {
 	fake.versionedResourceTypesMutex.Lock()
 	defer fake.versionedResourceTypesMutex.Unlock()
 	fake.VersionedResourceTypesStub = stub
 }
 
-func (fake *FakeTeam) VersionedResourceTypesArgsForCall(i int) string {
+func (fake *FakeTeam) VersionedResourceTypesArgsForCall(i int) atc.PipelineRef {
 	fake.versionedResourceTypesMutex.RLock()
 	defer fake.versionedResourceTypesMutex.RUnlock()
 	argsForCall := fake.versionedResourceTypesArgsForCall[i]
@@ -3779,6 +4255,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createBuildMutex.RUnlock()
 	fake.createJobBuildMutex.RLock()
 	defer fake.createJobBuildMutex.RUnlock()
+	fake.createJobBuildWithPriorityMutex.RLock()
+	defer fake.createJobBuildWithPriorityMutex.RUnlock()
 	fake.createOrUpdateMutex.RLock()
 	defer fake.createOrUpdateMutex.RUnlock()
 	fake.createOrUpdatePipelineConfigMutex.RLock()
@@ -3807,6 +4285,10 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.jobBuildMutex.RUnlock()
 	fake.jobBuildsMutex.RLock()
 	defer fake.jobBuildsMutex.RUnlock()
+	fake.jobStatsMutex.RLock()
+	defer fake.jobStatsMutex.RUnlock()
+	fake.jobTestHistoryMutex.RLock()
+	defer fake.jobTestHistoryMutex.RUnlock()
 	fake.listContainersMutex.RLock()
 	defer fake.listContainersMutex.RUnlock()
 	fake.listJobsMutex.RLock()
@@ -3837,10 +4319,16 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.renamePipelineMutex.RUnlock()
 	fake.renameTeamMutex.RLock()
 	defer fake.renameTeamMutex.RUnlock()
+	fake.rerunJobBuildMutex.RLock()
+	defer fake.rerunJobBuildMutex.RUnlock()
 	fake.resourceMutex.RLock()
 	defer fake.resourceMutex.RUnlock()
 	fake.resourceVersionsMutex.RLock()
 	defer fake.resourceVersionsMutex.RUnlock()
+	fake.searchBuildLogsMutex.RLock()
+	defer fake.searchBuildLogsMutex.RUnlock()
+	fake.semaphoresMutex.RLock()
+	defer fake.semaphoresMutex.RUnlock()
 	fake.setPinCommentMutex.RLock()
 	defer fake.setPinCommentMutex.RUnlock()
 	fake.teamMutex.RLock()
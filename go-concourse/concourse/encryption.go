@@ -0,0 +1,19 @@
+package concourse
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+)
+
+// EncryptionStatus reports the progress of re-encrypting the data in the
+// database with the current encryption key. Only admins may see it.
+func (client *client) EncryptionStatus() (atc.EncryptionStatus, error) {
+	var status atc.EncryptionStatus
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.GetEncryptionStatus,
+	}, &internal.Response{
+		Result: &status,
+	})
+
+	return status, err
+}
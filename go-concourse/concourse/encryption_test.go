@@ -0,0 +1,62 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Encryption Handler", func() {
+	Describe("EncryptionStatus", func() {
+		expectedURL := "/api/v1/encryption"
+
+		Context("when the status is returned", func() {
+			expectedStatus := atc.EncryptionStatus{
+				KeyID:     "new-key",
+				OldKeyIDs: []string{"old-key"},
+				Tables: []atc.EncryptedTableStatus{
+					{
+						Table:     "jobs",
+						Column:    "config",
+						Keys:      map[string]int{"new-key": 1, "old-key": 2},
+						Remaining: 2,
+					},
+				},
+			}
+
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedStatus),
+					),
+				)
+			})
+
+			It("returns the encryption status", func() {
+				status, err := client.EncryptionStatus()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status).To(Equal(expectedStatus))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusForbidden, nil),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := client.EncryptionStatus()
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -16,3 +16,15 @@ func (client *client) GetInfo() (atc.Info, error) {
 
 	return info, err
 }
+
+func (client *client) RoleActions() (map[string][]string, error) {
+	var roleActions map[string][]string
+
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.GetInfoRoles,
+	}, &internal.Response{
+		Result: &roleActions,
+	})
+
+	return roleActions, err
+}
@@ -31,4 +31,28 @@ var _ = Describe("ATC Info", func() {
 			Expect(info.Version).To(Equal("12.3.4"))
 		})
 	})
+
+	Describe("RoleActions", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/info/roles"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, map[string][]string{
+						"owner":           {"GetPipeline", "SetTeam"},
+						"pipeline-pinner": {"PinResourceVersion"},
+					}),
+				),
+			)
+		})
+
+		It("returns the actions permitted to each role", func() {
+			roleActions, err := client.RoleActions()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(roleActions).To(Equal(map[string][]string{
+				"owner":           {"GetPipeline", "SetTeam"},
+				"pipeline-pinner": {"PinResourceVersion"},
+			}))
+		})
+	})
 })
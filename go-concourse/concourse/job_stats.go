@@ -0,0 +1,52 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+// JobStats fetches the statistics of the job's builds created within the
+// given time range. Zero times leave the respective end of the range up to
+// the server, which defaults to the last 30 days.
+func (team *team) JobStats(pipelineRef atc.PipelineRef, jobName string, since time.Time, until time.Time) (atc.JobStats, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"job_name":      jobName,
+		"team_name":     team.name,
+	}
+
+	query := url.Values{}
+	if !since.IsZero() {
+		query.Add(atc.JobStatsQuerySince, strconv.FormatInt(since.Unix(), 10))
+	}
+
+	if !until.IsZero() {
+		query.Add(atc.JobStatsQueryUntil, strconv.FormatInt(until.Unix(), 10))
+	}
+
+	for key, values := range pipelineRef.QueryParams() {
+		query[key] = values
+	}
+
+	var stats atc.JobStats
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.GetJobStats,
+		Params:      params,
+		Query:       query,
+	}, &internal.Response{
+		Result: &stats,
+	})
+	switch err.(type) {
+	case nil:
+		return stats, true, nil
+	case internal.ResourceNotFoundError:
+		return stats, false, nil
+	default:
+		return stats, false, err
+	}
+}
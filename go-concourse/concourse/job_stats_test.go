@@ -0,0 +1,98 @@
+package concourse_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Job Stats", func() {
+	Describe("team.JobStats", func() {
+		var expectedURL = "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/stats"
+
+		Context("when the job exists", func() {
+			var expectedStats atc.JobStats
+
+			BeforeEach(func() {
+				expectedStats = atc.JobStats{
+					Since:       100,
+					Until:       200,
+					Builds:      2,
+					Succeeded:   1,
+					Failed:      1,
+					SuccessRate: 0.5,
+					Duration:    atc.DurationStats{Count: 2, Mean: 15, P50: 10, P95: 20, Max: 20},
+					Steps:       []atc.StepStats{},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "since=100&until=200"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedStats),
+					),
+				)
+			})
+
+			It("returns the job's stats for the time range", func() {
+				stats, found, err := team.JobStats(atc.PipelineRef{Name: "some-pipeline"}, "some-job", time.Unix(100, 0), time.Unix(200, 0))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(stats).To(Equal(expectedStats))
+			})
+		})
+
+		Context("when no time range is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.JobStats{}),
+					),
+				)
+			})
+
+			It("leaves it to the server", func() {
+				_, found, err := team.JobStats(atc.PipelineRef{Name: "some-pipeline"}, "some-job", time.Time{}, time.Time{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the job does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := team.JobStats(atc.PipelineRef{Name: "some-pipeline"}, "some-job", time.Time{}, time.Time{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when fetching the stats fails", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				_, _, err := team.JobStats(atc.PipelineRef{Name: "some-pipeline"}, "some-job", time.Time{}, time.Time{})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -9,9 +9,9 @@ import (
 	"github.com/tedsuo/rata"
 )
 
-func (team *team) ListJobs(pipelineName string) ([]atc.Job, error) {
+func (team *team) ListJobs(pipelineRef atc.PipelineRef) ([]atc.Job, error) {
 	params := rata.Params{
-		"pipeline_name": pipelineName,
+		"pipeline_name": pipelineRef.Name,
 		"team_name":     team.name,
 	}
 
@@ -19,6 +19,7 @@ func (team *team) ListJobs(pipelineName string) ([]atc.Job, error) {
 	err := team.connection.Send(internal.Request{
 		RequestName: atc.ListJobs,
 		Params:      params,
+		Query:       pipelineRef.QueryParams(),
 	}, &internal.Response{
 		Result: &jobs,
 	})
@@ -26,9 +27,9 @@ func (team *team) ListJobs(pipelineName string) ([]atc.Job, error) {
 	return jobs, err
 }
 
-func (team *team) Job(pipelineName, jobName string) (atc.Job, bool, error) {
+func (team *team) Job(pipelineRef atc.PipelineRef, jobName string) (atc.Job, bool, error) {
 	params := rata.Params{
-		"pipeline_name": pipelineName,
+		"pipeline_name": pipelineRef.Name,
 		"job_name":      jobName,
 		"team_name":     team.name,
 	}
@@ -37,6 +38,7 @@ func (team *team) Job(pipelineName, jobName string) (atc.Job, bool, error) {
 	err := team.connection.Send(internal.Request{
 		RequestName: atc.GetJob,
 		Params:      params,
+		Query:       pipelineRef.QueryParams(),
 	}, &internal.Response{
 		Result: &job,
 	})
@@ -50,20 +52,25 @@ func (team *team) Job(pipelineName, jobName string) (atc.Job, bool, error) {
 	}
 }
 
-func (team *team) JobBuilds(pipelineName string, jobName string, page Page) ([]atc.Build, Pagination, bool, error) {
+func (team *team) JobBuilds(pipelineRef atc.PipelineRef, jobName string, page Page) ([]atc.Build, Pagination, bool, error) {
 	params := rata.Params{
-		"pipeline_name": pipelineName,
+		"pipeline_name": pipelineRef.Name,
 		"job_name":      jobName,
 		"team_name":     team.name,
 	}
 
+	query := page.QueryParams()
+	for key, values := range pipelineRef.QueryParams() {
+		query[key] = values
+	}
+
 	var builds []atc.Build
 
 	headers := http.Header{}
 	err := team.connection.Send(internal.Request{
 		RequestName: atc.ListJobBuilds,
 		Params:      params,
-		Query:       page.QueryParams(),
+		Query:       query,
 	}, &internal.Response{
 		Result:  &builds,
 		Headers: &headers,
@@ -83,9 +90,9 @@ func (team *team) JobBuilds(pipelineName string, jobName string, page Page) ([]a
 	}
 }
 
-func (team *team) PauseJob(pipelineName string, jobName string) (bool, error) {
+func (team *team) PauseJob(pipelineRef atc.PipelineRef, jobName string) (bool, error) {
 	params := rata.Params{
-		"pipeline_name": pipelineName,
+		"pipeline_name": pipelineRef.Name,
 		"job_name":      jobName,
 		"team_name":     team.name,
 	}
@@ -93,6 +100,7 @@ func (team *team) PauseJob(pipelineName string, jobName string) (bool, error) {
 	err := team.connection.Send(internal.Request{
 		RequestName: atc.PauseJob,
 		Params:      params,
+		Query:       pipelineRef.QueryParams(),
 	}, &internal.Response{})
 
 	switch err.(type) {
@@ -105,9 +113,9 @@ func (team *team) PauseJob(pipelineName string, jobName string) (bool, error) {
 	}
 }
 
-func (team *team) UnpauseJob(pipelineName string, jobName string) (bool, error) {
+func (team *team) UnpauseJob(pipelineRef atc.PipelineRef, jobName string) (bool, error) {
 	params := rata.Params{
-		"pipeline_name": pipelineName,
+		"pipeline_name": pipelineRef.Name,
 		"job_name":      jobName,
 		"team_name":     team.name,
 	}
@@ -115,6 +123,7 @@ func (team *team) UnpauseJob(pipelineName string, jobName string) (bool, error)
 	err := team.connection.Send(internal.Request{
 		RequestName: atc.UnpauseJob,
 		Params:      params,
+		Query:       pipelineRef.QueryParams(),
 	}, &internal.Response{})
 
 	switch err.(type) {
@@ -127,10 +136,10 @@ func (team *team) UnpauseJob(pipelineName string, jobName string) (bool, error)
 	}
 }
 
-func (team *team) ClearTaskCache(pipelineName string, jobName string, stepName string, cachePath string) (int64, error) {
+func (team *team) ClearTaskCache(pipelineRef atc.PipelineRef, jobName string, stepName string, cachePath string) (int64, error) {
 	params := rata.Params{
 		"team_name":     team.name,
-		"pipeline_name": pipelineName,
+		"pipeline_name": pipelineRef.Name,
 		"job_name":      jobName,
 		"step_name":     stepName,
 	}
@@ -140,6 +149,10 @@ func (team *team) ClearTaskCache(pipelineName string, jobName string, stepName s
 		queryParams.Add(atc.ClearTaskCacheQueryPath, cachePath)
 	}
 
+	for key, values := range pipelineRef.QueryParams() {
+		queryParams[key] = values
+	}
+
 	var ctcResponse atc.ClearTaskCacheResponse
 	responseHeaders := http.Header{}
 	response := internal.Response{
@@ -25,7 +25,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("return true and no error", func() {
-				found, err := team.PausePipeline("mypipeline")
+				found, err := team.PausePipeline(atc.PipelineRef{Name: "mypipeline"})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 			})
@@ -42,7 +42,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 				)
 			})
 			It("returns false and no error", func() {
-				found, err := team.PausePipeline("mypipeline")
+				found, err := team.PausePipeline(atc.PipelineRef{Name: "mypipeline"})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeFalse())
 			})
@@ -62,7 +62,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("return true and no error", func() {
-				found, err := team.UnpausePipeline("mypipeline")
+				found, err := team.UnpausePipeline(atc.PipelineRef{Name: "mypipeline"})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 			})
@@ -79,7 +79,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 				)
 			})
 			It("returns false and no error", func() {
-				found, err := team.UnpausePipeline("mypipeline")
+				found, err := team.UnpausePipeline(atc.PipelineRef{Name: "mypipeline"})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeFalse())
 			})
@@ -99,7 +99,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("return true and no error", func() {
-				found, err := team.ExposePipeline("mypipeline")
+				found, err := team.ExposePipeline(atc.PipelineRef{Name: "mypipeline"})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 			})
@@ -116,7 +116,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 				)
 			})
 			It("returns false and no error", func() {
-				found, err := team.ExposePipeline("mypipeline")
+				found, err := team.ExposePipeline(atc.PipelineRef{Name: "mypipeline"})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeFalse())
 			})
@@ -136,7 +136,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("return true and no error", func() {
-				found, err := team.HidePipeline("mypipeline")
+				found, err := team.HidePipeline(atc.PipelineRef{Name: "mypipeline"})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 			})
@@ -153,7 +153,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 				)
 			})
 			It("returns false and no error", func() {
-				found, err := team.HidePipeline("mypipeline")
+				found, err := team.HidePipeline(atc.PipelineRef{Name: "mypipeline"})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeFalse())
 			})
@@ -241,7 +241,30 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("returns the requested pipeline", func() {
-				pipeline, found, err := team.Pipeline(pipelineName)
+				pipeline, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(pipeline).To(Equal(expectedPipeline))
+			})
+		})
+
+		Context("when requesting a pipeline instance", func() {
+			BeforeEach(func() {
+				expectedPipeline.InstanceVars = atc.InstanceVars{"branch": "master"}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "instance_vars=%7B%22branch%22%3A%22master%22%7D"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedPipeline),
+					),
+				)
+			})
+
+			It("scopes the request to the instance", func() {
+				pipeline, found, err := team.Pipeline(atc.PipelineRef{
+					Name:         pipelineName,
+					InstanceVars: atc.InstanceVars{"branch": "master"},
+				})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(pipeline).To(Equal(expectedPipeline))
@@ -259,7 +282,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("returns false", func() {
-				_, found, err := team.Pipeline(pipelineName)
+				_, found, err := team.Pipeline(atc.PipelineRef{Name: pipelineName})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeFalse())
 			})
@@ -373,7 +396,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 
 			It("deletes the pipeline when called", func() {
 				Expect(func() {
-					found, err := team.DeletePipeline("mypipeline")
+					found, err := team.DeletePipeline(atc.PipelineRef{Name: "mypipeline"})
 					Expect(err).NotTo(HaveOccurred())
 					Expect(found).To(BeTrue())
 				}).To(Change(func() int {
@@ -393,7 +416,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("returns false and no error", func() {
-				found, err := team.DeletePipeline("mypipeline")
+				found, err := team.DeletePipeline(atc.PipelineRef{Name: "mypipeline"})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeFalse())
 			})
@@ -415,7 +438,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("renames the pipeline when called", func() {
-				renamed, err := team.RenamePipeline("mypipeline", "newpipelinename")
+				renamed, err := team.RenamePipeline(atc.PipelineRef{Name: "mypipeline"}, "newpipelinename")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(renamed).To(BeTrue())
 			})
@@ -429,7 +452,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("returns false and no error", func() {
-				renamed, err := team.RenamePipeline("mypipeline", "newpipelinename")
+				renamed, err := team.RenamePipeline(atc.PipelineRef{Name: "mypipeline"}, "newpipelinename")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(renamed).To(BeFalse())
 			})
@@ -443,7 +466,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("returns an error", func() {
-				renamed, err := team.RenamePipeline("mypipeline", "newpipelinename")
+				renamed, err := team.RenamePipeline(atc.PipelineRef{Name: "mypipeline"}, "newpipelinename")
 				Expect(err).To(MatchError(ContainSubstring("418 I'm a teapot")))
 				Expect(renamed).To(BeFalse())
 			})
@@ -491,7 +514,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("returns the build and no error", func() {
-				build, err := team.CreatePipelineBuild("mypipeline", plan)
+				build, err := team.CreatePipelineBuild(atc.PipelineRef{Name: "mypipeline"}, plan)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(build).To(Equal(expectedBuild))
 			})
@@ -532,7 +555,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("calls to get all builds", func() {
-				builds, _, found, err := team.PipelineBuilds("mypipeline", concourse.Page{})
+				builds, _, found, err := team.PipelineBuilds(atc.PipelineRef{Name: "mypipeline"}, concourse.Page{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(builds).To(Equal(expectedBuilds))
@@ -545,7 +568,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("calls to get all builds since that id", func() {
-				builds, _, found, err := team.PipelineBuilds("mypipeline", concourse.Page{Since: 24})
+				builds, _, found, err := team.PipelineBuilds(atc.PipelineRef{Name: "mypipeline"}, concourse.Page{Since: 24})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(builds).To(Equal(expectedBuilds))
@@ -557,7 +580,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 				})
 
 				It("appends limit to the url", func() {
-					builds, _, found, err := team.PipelineBuilds("mypipeline", concourse.Page{Since: 24, Limit: 5})
+					builds, _, found, err := team.PipelineBuilds(atc.PipelineRef{Name: "mypipeline"}, concourse.Page{Since: 24, Limit: 5})
 					Expect(err).NotTo(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(builds).To(Equal(expectedBuilds))
@@ -571,7 +594,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("calls to get all builds until that id", func() {
-				builds, _, found, err := team.PipelineBuilds("mypipeline", concourse.Page{Until: 26})
+				builds, _, found, err := team.PipelineBuilds(atc.PipelineRef{Name: "mypipeline"}, concourse.Page{Until: 26})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(builds).To(Equal(expectedBuilds))
@@ -583,7 +606,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 				})
 
 				It("appends limit to the url", func() {
-					builds, _, found, err := team.PipelineBuilds("mypipeline", concourse.Page{Until: 26, Limit: 15})
+					builds, _, found, err := team.PipelineBuilds(atc.PipelineRef{Name: "mypipeline"}, concourse.Page{Until: 26, Limit: 15})
 					Expect(err).NotTo(HaveOccurred())
 					Expect(found).To(BeTrue())
 					Expect(builds).To(Equal(expectedBuilds))
@@ -597,7 +620,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("sends both since and until", func() {
-				builds, _, found, err := team.PipelineBuilds("mypipeline", concourse.Page{Since: 24, Until: 26})
+				builds, _, found, err := team.PipelineBuilds(atc.PipelineRef{Name: "mypipeline"}, concourse.Page{Since: 24, Until: 26})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(builds).To(Equal(expectedBuilds))
@@ -615,7 +638,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("returns false and an error", func() {
-				_, _, found, err := team.PipelineBuilds("mypipeline", concourse.Page{})
+				_, _, found, err := team.PipelineBuilds(atc.PipelineRef{Name: "mypipeline"}, concourse.Page{})
 				Expect(err).To(HaveOccurred())
 				Expect(found).To(BeFalse())
 			})
@@ -632,7 +655,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("returns false and no error", func() {
-				_, _, found, err := team.PipelineBuilds("mypipeline", concourse.Page{})
+				_, _, found, err := team.PipelineBuilds(atc.PipelineRef{Name: "mypipeline"}, concourse.Page{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeFalse())
 			})
@@ -655,7 +678,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 				})
 
 				It("returns the pagination data from the header", func() {
-					_, pagination, _, err := team.PipelineBuilds("mypipeline", concourse.Page{})
+					_, pagination, _, err := team.PipelineBuilds(atc.PipelineRef{Name: "mypipeline"}, concourse.Page{})
 					Expect(err).ToNot(HaveOccurred())
 
 					Expect(pagination.Previous).To(Equal(&concourse.Page{Since: 452, Limit: 123}))
@@ -675,7 +698,7 @@ var _ = Describe("ATC Handler Pipelines", func() {
 			})
 
 			It("returns pagination data with nil pages", func() {
-				_, pagination, _, err := team.PipelineBuilds("mypipeline", concourse.Page{})
+				_, pagination, _, err := team.PipelineBuilds(atc.PipelineRef{Name: "mypipeline"}, concourse.Page{})
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(pagination.Previous).To(BeNil())
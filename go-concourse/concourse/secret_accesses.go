@@ -0,0 +1,73 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+)
+
+// SecretAccessFilter narrows down the secret accesses listed by
+// ListSecretAccesses. Zero fields are left out of the query.
+type SecretAccessFilter struct {
+	Team     string
+	Pipeline string
+	Job      string
+	BuildID  int
+	Variable string
+	Manager  string
+
+	Since time.Time
+	Until time.Time
+
+	Limit int
+}
+
+// ListSecretAccesses lists the most recent lookups of secrets matching the
+// filter. Only admins may list them.
+func (client *client) ListSecretAccesses(filter SecretAccessFilter) ([]atc.SecretAccess, error) {
+	query := url.Values{}
+
+	for param, value := range map[string]string{
+		atc.SecretAccessQueryTeam:     filter.Team,
+		atc.SecretAccessQueryPipeline: filter.Pipeline,
+		atc.SecretAccessQueryJob:      filter.Job,
+		atc.SecretAccessQueryVariable: filter.Variable,
+		atc.SecretAccessQueryManager:  filter.Manager,
+	} {
+		if value != "" {
+			query.Add(param, value)
+		}
+	}
+
+	if filter.BuildID != 0 {
+		query.Add(atc.SecretAccessQueryBuildID, strconv.Itoa(filter.BuildID))
+	}
+
+	if !filter.Since.IsZero() {
+		query.Add(atc.SecretAccessQuerySince, strconv.FormatInt(filter.Since.Unix(), 10))
+	}
+
+	if !filter.Until.IsZero() {
+		query.Add(atc.SecretAccessQueryUntil, strconv.FormatInt(filter.Until.Unix(), 10))
+	}
+
+	if filter.Limit != 0 {
+		query.Add(atc.SecretAccessQueryLimit, strconv.Itoa(filter.Limit))
+	}
+
+	var accesses []atc.SecretAccess
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListSecretAccesses,
+		Query:       query,
+	}, &internal.Response{
+		Result: &accesses,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return accesses, nil
+}
@@ -0,0 +1,86 @@
+package concourse_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Secret Accesses Handler", func() {
+	Describe("ListSecretAccesses", func() {
+		expectedURL := "/api/v1/secret_accesses"
+		expectedAccesses := []atc.SecretAccess{
+			{
+				ID:       1,
+				Time:     10,
+				TeamName: "some-team",
+				BuildID:  42,
+				Variable: "foo",
+				Path:     "/concourse/some-team/foo",
+				Manager:  "vault",
+				Found:    true,
+			},
+		}
+
+		Context("without a filter", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedAccesses),
+					),
+				)
+			})
+
+			It("returns the secret accesses", func() {
+				accesses, err := client.ListSecretAccesses(concourse.SecretAccessFilter{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(accesses).To(Equal(expectedAccesses))
+			})
+		})
+
+		Context("with a filter", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "build_id=42&limit=5&since=10&team=some-team&variable=foo"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedAccesses),
+					),
+				)
+			})
+
+			It("sends the filter as query params", func() {
+				accesses, err := client.ListSecretAccesses(concourse.SecretAccessFilter{
+					Team:     "some-team",
+					BuildID:  42,
+					Variable: "foo",
+					Since:    time.Unix(10, 0),
+					Limit:    5,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(accesses).To(Equal(expectedAccesses))
+			})
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusForbidden, nil),
+					),
+				)
+			})
+
+			It("errors", func() {
+				_, err := client.ListSecretAccesses(concourse.SecretAccessFilter{})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -0,0 +1,22 @@
+package concourse
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) Semaphores() ([]atc.Semaphore, error) {
+	var semaphores []atc.Semaphore
+
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListSemaphores,
+		Params: rata.Params{
+			"team_name": team.name,
+		},
+	}, &internal.Response{
+		Result: &semaphores,
+	})
+
+	return semaphores, err
+}
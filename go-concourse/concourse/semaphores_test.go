@@ -0,0 +1,65 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Semaphores", func() {
+	Describe("team.Semaphores", func() {
+		var expectedSemaphores []atc.Semaphore
+
+		BeforeEach(func() {
+			expectedSemaphores = []atc.Semaphore{
+				{
+					Name:     "staging",
+					Capacity: 1,
+					Holders: []atc.Build{
+						{ID: 1, Name: "4", JobName: "deploy", PipelineName: "some-pipeline", Status: "started"},
+					},
+					Waiting: []atc.Build{
+						{ID: 2, Name: "7", JobName: "deploy", PipelineName: "some-other-pipeline", Status: "pending"},
+					},
+				},
+			}
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/semaphores"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedSemaphores),
+					),
+				)
+			})
+
+			It("returns the team's semaphores", func() {
+				semaphores, err := team.Semaphores()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(semaphores).To(Equal(expectedSemaphores))
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/semaphores"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := team.Semaphores()
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
@@ -2,6 +2,7 @@ package concourse
 
 import (
 	"io"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse/internal"
@@ -17,54 +18,60 @@ type Team interface {
 	RenameTeam(teamName, name string) (bool, error)
 	DestroyTeam(teamName string) error
 
-	Pipeline(name string) (atc.Pipeline, bool, error)
-	PipelineBuilds(pipelineName string, page Page) ([]atc.Build, Pagination, bool, error)
-	DeletePipeline(pipelineName string) (bool, error)
-	PausePipeline(pipelineName string) (bool, error)
-	UnpausePipeline(pipelineName string) (bool, error)
-	ExposePipeline(pipelineName string) (bool, error)
-	HidePipeline(pipelineName string) (bool, error)
-	RenamePipeline(pipelineName, name string) (bool, error)
+	Pipeline(pipelineRef atc.PipelineRef) (atc.Pipeline, bool, error)
+	PipelineBuilds(pipelineRef atc.PipelineRef, page Page) ([]atc.Build, Pagination, bool, error)
+	DeletePipeline(pipelineRef atc.PipelineRef) (bool, error)
+	PausePipeline(pipelineRef atc.PipelineRef) (bool, error)
+	UnpausePipeline(pipelineRef atc.PipelineRef) (bool, error)
+	ExposePipeline(pipelineRef atc.PipelineRef) (bool, error)
+	HidePipeline(pipelineRef atc.PipelineRef) (bool, error)
+	RenamePipeline(pipelineRef atc.PipelineRef, name string) (bool, error)
 	ListPipelines() ([]atc.Pipeline, error)
-	PipelineConfig(pipelineName string) (atc.Config, string, bool, error)
-	CreateOrUpdatePipelineConfig(pipelineName string, configVersion string, passedConfig []byte, checkCredentials bool) (bool, bool, []ConfigWarning, error)
+	PipelineConfig(pipelineRef atc.PipelineRef) (atc.Config, string, bool, error)
+	CreateOrUpdatePipelineConfig(pipelineRef atc.PipelineRef, configVersion string, passedConfig []byte, checkCredentials bool) (bool, bool, []ConfigWarning, error)
 
-	CreatePipelineBuild(pipelineName string, plan atc.Plan) (atc.Build, error)
+	CreatePipelineBuild(pipelineRef atc.PipelineRef, plan atc.Plan) (atc.Build, error)
 
-	BuildInputsForJob(pipelineName string, jobName string) ([]atc.BuildInput, bool, error)
+	BuildInputsForJob(pipelineRef atc.PipelineRef, jobName string) ([]atc.BuildInput, bool, error)
 
-	Job(pipelineName, jobName string) (atc.Job, bool, error)
-	JobBuild(pipelineName, jobName, buildName string) (atc.Build, bool, error)
-	JobBuilds(pipelineName string, jobName string, page Page) ([]atc.Build, Pagination, bool, error)
-	CreateJobBuild(pipelineName string, jobName string) (atc.Build, error)
-	ListJobs(pipelineName string) ([]atc.Job, error)
+	Job(pipelineRef atc.PipelineRef, jobName string) (atc.Job, bool, error)
+	JobBuild(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, bool, error)
+	JobBuilds(pipelineRef atc.PipelineRef, jobName string, page Page) ([]atc.Build, Pagination, bool, error)
+	JobTestHistory(pipelineRef atc.PipelineRef, jobName string, builds int) ([]atc.TestHistory, bool, error)
+	JobStats(pipelineRef atc.PipelineRef, jobName string, since time.Time, until time.Time) (atc.JobStats, bool, error)
+	CreateJobBuild(pipelineRef atc.PipelineRef, jobName string) (atc.Build, error)
+	CreateJobBuildWithPriority(pipelineRef atc.PipelineRef, jobName string, priority int) (atc.Build, error)
+	RerunJobBuild(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, error)
+	ListJobs(pipelineRef atc.PipelineRef) ([]atc.Job, error)
 
-	PauseJob(pipelineName string, jobName string) (bool, error)
-	UnpauseJob(pipelineName string, jobName string) (bool, error)
+	PauseJob(pipelineRef atc.PipelineRef, jobName string) (bool, error)
+	UnpauseJob(pipelineRef atc.PipelineRef, jobName string) (bool, error)
 
-	ClearTaskCache(pipelineName string, jobName string, stepName string, cachePath string) (int64, error)
+	ClearTaskCache(pipelineRef atc.PipelineRef, jobName string, stepName string, cachePath string) (int64, error)
 
-	Resource(pipelineName string, resourceName string) (atc.Resource, bool, error)
-	ListResources(pipelineName string) ([]atc.Resource, error)
-	VersionedResourceTypes(pipelineName string) (atc.VersionedResourceTypes, bool, error)
-	ResourceVersions(pipelineName string, resourceName string, page Page, filter atc.Version) ([]atc.ResourceVersion, Pagination, bool, error)
-	CheckResource(pipelineName string, resourceName string, version atc.Version) (atc.Check, bool, error)
-	CheckResourceType(pipelineName string, resourceTypeName string, version atc.Version) (atc.Check, bool, error)
-	DisableResourceVersion(pipelineName string, resourceName string, resourceVersionID int) (bool, error)
-	EnableResourceVersion(pipelineName string, resourceName string, resourceVersionID int) (bool, error)
+	Resource(pipelineRef atc.PipelineRef, resourceName string) (atc.Resource, bool, error)
+	ListResources(pipelineRef atc.PipelineRef) ([]atc.Resource, error)
+	VersionedResourceTypes(pipelineRef atc.PipelineRef) (atc.VersionedResourceTypes, bool, error)
+	ResourceVersions(pipelineRef atc.PipelineRef, resourceName string, page Page, filter atc.Version) ([]atc.ResourceVersion, Pagination, bool, error)
+	CheckResource(pipelineRef atc.PipelineRef, resourceName string, version atc.Version) (atc.Check, bool, error)
+	CheckResourceType(pipelineRef atc.PipelineRef, resourceTypeName string, version atc.Version) (atc.Check, bool, error)
+	DisableResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error)
+	EnableResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error)
 
-	PinResourceVersion(pipelineName string, resourceName string, resourceVersionID int) (bool, error)
-	UnpinResource(pipelineName string, resourceName string) (bool, error)
-	SetPinComment(pipelineName string, resourceName string, comment string) (bool, error)
+	PinResourceVersion(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) (bool, error)
+	UnpinResource(pipelineRef atc.PipelineRef, resourceName string) (bool, error)
+	SetPinComment(pipelineRef atc.PipelineRef, resourceName string, comment string) (bool, error)
 
-	BuildsWithVersionAsInput(pipelineName string, resourceName string, resourceVersionID int) ([]atc.Build, bool, error)
-	BuildsWithVersionAsOutput(pipelineName string, resourceName string, resourceVersionID int) ([]atc.Build, bool, error)
+	BuildsWithVersionAsInput(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) ([]atc.Build, bool, error)
+	BuildsWithVersionAsOutput(pipelineRef atc.PipelineRef, resourceName string, resourceVersionID int) ([]atc.Build, bool, error)
 
 	ListContainers(queryList map[string]string) ([]atc.Container, error)
 	GetContainer(id string) (atc.Container, error)
 	ListVolumes() ([]atc.Volume, error)
 	CreateBuild(plan atc.Plan) (atc.Build, error)
 	Builds(page Page) ([]atc.Build, Pagination, error)
+	SearchBuildLogs(search BuildLogSearch) ([]atc.BuildLogSearchResult, error)
+	Semaphores() ([]atc.Semaphore, error)
 	OrderingPipelines(pipelineNames []string) error
 
 	CreateArtifact(io.Reader, string) (atc.WorkerArtifact, error)
@@ -0,0 +1,66 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) BuildTestResults(buildID string) (atc.BuildTestResults, bool, error) {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	var results atc.BuildTestResults
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListBuildTestResults,
+		Params:      params,
+	}, &internal.Response{
+		Result: &results,
+	})
+	switch err.(type) {
+	case nil:
+		return results, true, nil
+	case internal.ResourceNotFoundError:
+		return results, false, nil
+	default:
+		return results, false, err
+	}
+}
+
+func (team *team) JobTestHistory(pipelineRef atc.PipelineRef, jobName string, builds int) ([]atc.TestHistory, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"job_name":      jobName,
+		"team_name":     team.name,
+	}
+
+	query := pipelineRef.QueryParams()
+	if builds > 0 {
+		if query == nil {
+			query = url.Values{}
+		}
+
+		query.Set(atc.TestHistoryQueryBuilds, strconv.Itoa(builds))
+	}
+
+	var history []atc.TestHistory
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListJobTestHistory,
+		Params:      params,
+		Query:       query,
+	}, &internal.Response{
+		Result: &history,
+	})
+	switch err.(type) {
+	case nil:
+		return history, true, nil
+	case internal.ResourceNotFoundError:
+		return history, false, nil
+	default:
+		return history, false, err
+	}
+}
@@ -0,0 +1,146 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Test Results", func() {
+	Describe("BuildTestResults", func() {
+		Context("when the build exists", func() {
+			var expectedResults atc.BuildTestResults
+
+			BeforeEach(func() {
+				expectedResults = atc.BuildTestResults{
+					Summary: atc.TestSummary{Total: 1, Failed: 1},
+					Tests: []atc.TestResult{
+						{Suite: "some-suite", Name: "some-test", Status: "failed", Duration: 1.5, Message: "boom"},
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/42/tests"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedResults),
+					),
+				)
+			})
+
+			It("returns the test results", func() {
+				results, found, err := client.BuildTestResults("42")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(results).To(Equal(expectedResults))
+			})
+		})
+
+		Context("when the build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/42/tests"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := client.BuildTestResults("42")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when fetching the test results fails", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/42/tests"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				_, _, err := client.BuildTestResults("42")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("team.JobTestHistory", func() {
+		var expectedURL = "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/tests"
+
+		Context("when the job exists", func() {
+			var expectedHistory []atc.TestHistory
+
+			BeforeEach(func() {
+				expectedHistory = []atc.TestHistory{
+					{
+						Suite:     "some-suite",
+						Name:      "some-test",
+						Runs:      2,
+						Failures:  1,
+						FlakeRate: 1,
+						Builds: []atc.TestHistoryBuild{
+							{BuildID: 2, BuildName: "2", Status: "failed", Duration: 1},
+							{BuildID: 1, BuildName: "1", Status: "passed", Duration: 1},
+						},
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "builds=10"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedHistory),
+					),
+				)
+			})
+
+			It("returns the job's test history", func() {
+				history, found, err := team.JobTestHistory(atc.PipelineRef{Name: "some-pipeline"}, "some-job", 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(history).To(Equal(expectedHistory))
+			})
+		})
+
+		Context("when the number of builds is not given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.TestHistory{}),
+					),
+				)
+			})
+
+			It("leaves it to the server", func() {
+				_, found, err := team.JobTestHistory(atc.PipelineRef{Name: "some-pipeline"}, "some-job", 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the job does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := team.JobTestHistory(atc.PipelineRef{Name: "some-pipeline"}, "some-job", 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
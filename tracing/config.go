@@ -0,0 +1,128 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/trace/jaeger"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type Config struct {
+	ServiceName string            `long:"service-name" default:"concourse-web" description:"Service name to attach to traces as metadata."`
+	Attributes  map[string]string `long:"attribute" description:"Attribute to attach to traces as metadata. Can be specified multiple times." value-name:"NAME:VALUE"`
+
+	Jaeger Jaeger
+	OTLP   OTLP
+}
+
+type Jaeger struct {
+	Endpoint string `long:"jaeger-endpoint" description:"Jaeger HTTP thrift collector endpoint, e.g. http://jaeger:14268/api/traces."`
+}
+
+func (j Jaeger) IsConfigured() bool {
+	return j.Endpoint != ""
+}
+
+func (j Jaeger) Exporter(serviceName string) (export.SpanSyncer, error) {
+	return jaeger.NewRawExporter(
+		jaeger.WithCollectorEndpoint(j.Endpoint),
+		jaeger.WithProcess(jaeger.Process{ServiceName: serviceName}),
+	)
+}
+
+type OTLP struct {
+	Address string            `long:"otlp-address" description:"OTLP gRPC collector address, e.g. otel-collector:4317."`
+	Headers map[string]string `long:"otlp-header" description:"Header to attach to requests made to the OTLP collector. Can be specified multiple times." value-name:"NAME:VALUE"`
+	UseTLS  bool              `long:"otlp-use-tls" description:"Whether to use TLS when connecting to the OTLP collector."`
+}
+
+func (o OTLP) IsConfigured() bool {
+	return o.Address != ""
+}
+
+func (o OTLP) Exporter() (export.SpanBatcher, error) {
+	options := []otlp.ExporterOption{
+		otlp.WithAddress(o.Address),
+		otlp.WithHeaders(o.Headers),
+	}
+
+	if !o.UseTLS {
+		options = append(options, otlp.WithInsecure())
+	}
+
+	return otlp.NewExporter(options...)
+}
+
+// Prepare configures the global trace provider to export spans to the
+// configured exporter. If no exporter is configured, tracing stays disabled.
+func (c Config) Prepare() error {
+	if c.Jaeger.IsConfigured() && c.OTLP.IsConfigured() {
+		return errors.New("multiple tracing exporters configured: Jaeger, OTLP")
+	}
+
+	var option sdktrace.ProviderOption
+
+	switch {
+	case c.Jaeger.IsConfigured():
+		exporter, err := c.Jaeger.Exporter(c.ServiceName)
+		if err != nil {
+			return err
+		}
+
+		// the Jaeger exporter buffers spans itself
+		option = sdktrace.WithSyncer(exporter)
+	case c.OTLP.IsConfigured():
+		exporter, err := c.OTLP.Exporter()
+		if err != nil {
+			return err
+		}
+
+		option = sdktrace.WithBatcher(exporter)
+	default:
+		return nil
+	}
+
+	provider, err := sdktrace.NewProvider(
+		option,
+		sdktrace.WithResource(c.resource()),
+	)
+	if err != nil {
+		return err
+	}
+
+	ConfigureTraceProvider(provider)
+
+	return nil
+}
+
+func (c Config) resource() *resource.Resource {
+	attrs := []kv.KeyValue{
+		standard.ServiceNameKey.String(c.ServiceName),
+	}
+
+	for key, value := range c.Attributes {
+		attrs = append(attrs, kv.String(key, value))
+	}
+
+	return resource.New(attrs...)
+}
+
+// ConfigureTraceProvider sets the global trace provider and propagates span
+// contexts using the W3C Trace Context format.
+func ConfigureTraceProvider(tp trace.Provider) {
+	global.SetTraceProvider(tp)
+	global.SetPropagators(propagation.New(
+		propagation.WithInjectors(trace.TraceContext{}),
+		propagation.WithExtractors(trace.TraceContext{}),
+	))
+
+	Configured = true
+}
@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/trace"
+	"google.golang.org/grpc/codes"
+)
+
+// Configured indicates whether a trace exporter has been configured. Until
+// then, spans are no-ops.
+var Configured bool
+
+// Attrs are attached to spans as string attributes.
+type Attrs map[string]string
+
+// StartSpan creates a span for the given component as a child of any span
+// already present in ctx, returning a context carrying the new span.
+func StartSpan(ctx context.Context, component string, attrs Attrs) (context.Context, trace.Span) {
+	return global.Tracer("concourse").Start(
+		ctx,
+		component,
+		trace.WithAttributes(keyValueSlice(attrs)...),
+	)
+}
+
+// Detach returns a context carrying the span of ctx, but which is not
+// cancelled along with ctx, e.g. for work which outlives the request that
+// kicked it off.
+func Detach(ctx context.Context) context.Context {
+	return trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ctx))
+}
+
+// End ends the span, recording err as its status if it is non-nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(context.Background(), err)
+		span.SetStatus(codes.Unknown, err.Error())
+	}
+
+	span.End()
+}
+
+// Inject propagates the span context of ctx to the supplier, e.g. the headers
+// of an outgoing request.
+func Inject(ctx context.Context, supplier propagation.HTTPSupplier) {
+	propagation.InjectHTTP(ctx, global.Propagators(), supplier)
+}
+
+// Extract returns a context carrying the span context propagated through the
+// supplier, e.g. the headers of an incoming request.
+func Extract(ctx context.Context, supplier propagation.HTTPSupplier) context.Context {
+	return propagation.ExtractHTTP(ctx, global.Propagators(), supplier)
+}
+
+// Env returns the span context of ctx as environment variables (e.g.
+// TRACEPARENT), so that processes run in containers can continue the trace.
+func Env(ctx context.Context) []string {
+	carrier := envCarrier{}
+	Inject(ctx, carrier)
+
+	var env []string
+	for _, key := range carrier.Keys() {
+		env = append(env, key+"="+carrier[key])
+	}
+
+	return env
+}
+
+type envCarrier map[string]string
+
+func (c envCarrier) Get(key string) string {
+	return c[strings.ToUpper(key)]
+}
+
+func (c envCarrier) Set(key string, value string) {
+	c[strings.ToUpper(key)] = value
+}
+
+func (c envCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func keyValueSlice(attrs Attrs) []kv.KeyValue {
+	var res []kv.KeyValue
+	for key, value := range attrs {
+		res = append(res, kv.String(key, value))
+	}
+
+	return res
+}
@@ -0,0 +1,109 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/tracing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/api/kv"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/codes"
+)
+
+type spanRecorder struct {
+	ended []*export.SpanData
+}
+
+func (recorder *spanRecorder) ExportSpan(ctx context.Context, span *export.SpanData) {
+	recorder.ended = append(recorder.ended, span)
+}
+
+var _ = Describe("Tracer", func() {
+	var recorder *spanRecorder
+
+	BeforeEach(func() {
+		recorder = &spanRecorder{}
+
+		provider, err := sdktrace.NewProvider(sdktrace.WithSyncer(recorder))
+		Expect(err).ToNot(HaveOccurred())
+
+		tracing.ConfigureTraceProvider(provider)
+	})
+
+	Describe("StartSpan", func() {
+		It("creates a span with the given attributes", func() {
+			_, span := tracing.StartSpan(context.Background(), "some-component", tracing.Attrs{
+				"team": "some-team",
+			})
+			tracing.End(span, nil)
+
+			spans := recorder.ended
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("some-component"))
+			Expect(spans[0].Attributes).To(ConsistOf(kv.String("team", "some-team")))
+			Expect(spans[0].StatusCode).To(Equal(codes.OK))
+		})
+
+		It("creates child spans of the span in the context", func() {
+			ctx, parent := tracing.StartSpan(context.Background(), "parent", nil)
+			_, child := tracing.StartSpan(ctx, "child", nil)
+			tracing.End(child, nil)
+			tracing.End(parent, nil)
+
+			spans := recorder.ended
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].ParentSpanID).To(Equal(parent.SpanContext().SpanID))
+		})
+	})
+
+	Describe("Detach", func() {
+		It("carries the span of the context over", func() {
+			ctx, parent := tracing.StartSpan(context.Background(), "parent", nil)
+			_, child := tracing.StartSpan(tracing.Detach(ctx), "child", nil)
+			tracing.End(child, nil)
+			tracing.End(parent, nil)
+
+			spans := recorder.ended
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].ParentSpanID).To(Equal(parent.SpanContext().SpanID))
+		})
+
+		It("is not cancelled along with the context", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			detached := tracing.Detach(ctx)
+			cancel()
+
+			Expect(detached.Err()).ToNot(HaveOccurred())
+		})
+	})
+
+	Describe("End", func() {
+		It("records errors", func() {
+			_, span := tracing.StartSpan(context.Background(), "some-component", nil)
+			tracing.End(span, errors.New("nope"))
+
+			spans := recorder.ended
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].StatusCode).To(Equal(codes.Unknown))
+			Expect(spans[0].StatusMessage).To(Equal("nope"))
+		})
+	})
+
+	Describe("Env", func() {
+		It("propagates the span context as environment variables", func() {
+			ctx, span := tracing.StartSpan(context.Background(), "some-component", nil)
+			defer tracing.End(span, nil)
+
+			env := tracing.Env(ctx)
+			Expect(env).To(HaveLen(1))
+			Expect(env[0]).To(HavePrefix("TRACEPARENT=00-" + span.SpanContext().TraceID.String()))
+		})
+
+		It("returns nothing when there is no span", func() {
+			Expect(tracing.Env(context.Background())).To(BeEmpty())
+		})
+	})
+})
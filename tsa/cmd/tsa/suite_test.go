@@ -144,7 +144,7 @@ var _ = BeforeEach(func() {
 	signingKey, err := jwt.ParseRSAPrivateKeyFromPEM(rsaKeyBlob)
 	Expect(err).NotTo(HaveOccurred())
 
-	accessFactory = accessor.NewAccessFactory(&signingKey.PublicKey)
+	accessFactory = accessor.NewAccessFactory(&signingKey.PublicKey, accessor.DefaultRoleActionMap())
 
 	tsaCommand := exec.Command(
 		tsaPath,
@@ -58,7 +58,7 @@ func (t Template) interpolateRoot(obj interface{}, tracker varsTracker) (interfa
 type interpolator struct{}
 
 var (
-	interpolationRegex         = regexp.MustCompile(`\(\((!?[-/\.\w\pL]+)\)\)`)
+	interpolationRegex         = regexp.MustCompile(`\(\((!?(?:(?:\.|[-\w\pL]+):)?[-/\.@\w\pL]+)\)\)`)
 	interpolationAnchoredRegex = regexp.MustCompile("\\A" + interpolationRegex.String() + "\\z")
 )
 
@@ -138,8 +138,15 @@ type varsLookup struct {
 
 var ErrEmptyVar = errors.New("empty var")
 
+// Get looks up a var by name, which may be prefixed with the var's source,
+// e.g. '.:some-var', and followed by fields to access, e.g. 'some-var.field'.
 func (l varsLookup) Get(name string) (interface{}, bool, error) {
-	splitName := strings.Split(name, ".")
+	source, path := "", name
+	if i := strings.Index(name, ":"); i != -1 {
+		source, path = name[:i], name[i+1:]
+	}
+
+	splitName := strings.Split(path, ".")
 
 	// this should be impossible since interpolationRegex only matches non-empty
 	// vars, but better to error than to panic
@@ -147,7 +154,7 @@ func (l varsLookup) Get(name string) (interface{}, bool, error) {
 		return nil, false, ErrEmptyVar
 	}
 
-	val, found, err := l.varsTracker.Get(splitName[0])
+	val, found, err := l.varsTracker.Get(source, splitName[0])
 	if !found || err != nil {
 		return val, found, err
 	}
@@ -206,12 +213,17 @@ func newVarsTracker(vars Variables, expectAllFound, expectAllUsed bool) varsTrac
 	}
 }
 
-func (t varsTracker) Get(name string) (interface{}, bool, error) {
-	t.visitedAll[name] = struct{}{}
+func (t varsTracker) Get(source, name string) (interface{}, bool, error) {
+	key := name
+	if source != "" {
+		key = source + ":" + name
+	}
+
+	t.visitedAll[key] = struct{}{}
 
-	val, found, err := t.vars.Get(VariableDefinition{Name: name})
+	val, found, err := t.vars.Get(VariableDefinition{Source: source, Name: name})
 	if !found {
-		t.missing[name] = struct{}{}
+		t.missing[key] = struct{}{}
 	}
 
 	return val, found, err
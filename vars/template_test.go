@@ -28,6 +28,25 @@ var _ = Describe("Template", func() {
 		Expect(result).To(Equal([]byte("foo\n")))
 	})
 
+	It("can interpolate values from a var source", func() {
+		template := NewTemplate([]byte("((.:key)): ((.:value.field)) ((key))"))
+		vars := NewCredVarsTracker(StaticVariables{"key": "static"}, false)
+		vars.AddLocalVar("key", "foo", false)
+		vars.AddLocalVar("value", map[string]interface{}{"field": "bar"}, false)
+
+		result, err := template.Evaluate(vars, EvaluateOpts{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal([]byte("foo: bar static\n")))
+	})
+
+	It("does not find values from a var source in static vars", func() {
+		template := NewTemplate([]byte("((.:key))"))
+		vars := StaticVariables{"key": "foo"}
+
+		_, err := template.Evaluate(vars, EvaluateOpts{ExpectAllKeys: true})
+		Expect(err).To(Equal(UndefinedVarsError{Vars: []string{".:key"}}))
+	})
+
 	It("can interpolate multiple values into a byte slice", func() {
 		template := NewTemplate([]byte("((key)): ((value))"))
 		vars := StaticVariables{
@@ -319,6 +338,17 @@ dup-key: ((key3))
 		Expect(result).To(Equal([]byte("e\n")))
 	})
 
+	It("allows to access sub key of a value with a version selector via dot syntax", func() {
+		template := NewTemplate([]byte("((key@2.subkey))"))
+		vars := StaticVariables{
+			"key@2": map[interface{}]interface{}{"subkey": "e"},
+		}
+
+		result, err := template.Evaluate(vars, EvaluateOpts{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal([]byte("e\n")))
+	})
+
 	It("returns an error if variable is not found and is being used with a sub key", func() {
 		template := NewTemplate([]byte("((key.subkey_not_found))"))
 		vars := StaticVariables{}
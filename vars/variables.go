@@ -5,7 +5,14 @@ type Variables interface {
 	List() ([]VariableDefinition, error)
 }
 
+// LocalVarSource is the source of vars set during a build, e.g. by an across
+// step or a load_var step. They are referenced as ((.:name)).
+const LocalVarSource = "."
+
 type VariableDefinition struct {
+	// Source is the name of the source to fetch the var from. It is empty for
+	// vars which are given statically or fetched from the credential manager.
+	Source  string
 	Name    string
 	Type    string
 	Options interface{}
@@ -16,33 +16,85 @@ type CredVarsTrackerIterator interface {
 type CredVarsTracker interface {
 	Variables
 	IterateInterpolatedCreds(iter CredVarsTrackerIterator)
+
+	// NewLocalScope returns a tracker which shares the creds, tracked values
+	// and local vars of this tracker. Local vars added to the new scope are
+	// not visible to this tracker.
+	NewLocalScope() CredVarsTracker
+
+	// AddLocalVar sets a var which is fetched with the LocalVarSource. If
+	// redact is true, the value is tracked like an interpolated cred.
+	AddLocalVar(name string, val interface{}, redact bool)
 }
 
 func NewCredVarsTracker(credVars Variables, on bool) CredVarsTracker {
 	if on {
 		return credVarsTracker{
 			credVars:          credVars,
+			localVars:         newLocalVars(nil),
 			interpolatedCreds: map[string]string{},
-			lock:              sync.RWMutex{},
+			lock:              &sync.RWMutex{},
 		}
 	} else {
-		return dummyCredVarsTracker{credVars: credVars}
+		return dummyCredVarsTracker{
+			credVars:  credVars,
+			localVars: newLocalVars(nil),
+			lock:      &sync.RWMutex{},
+		}
+	}
+}
+
+type localVars struct {
+	vars   map[string]interface{}
+	parent *localVars
+}
+
+func newLocalVars(parent *localVars) *localVars {
+	return &localVars{
+		vars:   map[string]interface{}{},
+		parent: parent,
 	}
 }
 
+func (l *localVars) get(name string) (interface{}, bool) {
+	for scope := l; scope != nil; scope = scope.parent {
+		if val, found := scope.vars[name]; found {
+			return val, true
+		}
+	}
+
+	return nil, false
+}
+
 type credVarsTracker struct {
 	credVars          Variables
+	localVars         *localVars
 	interpolatedCreds map[string]string
 
-	// Considering in-parallel steps, a lock is need.
-	lock sync.RWMutex
+	// Considering in-parallel steps, a lock is need. It is shared by all
+	// local scopes, as they share interpolatedCreds and local vars may be
+	// added while other steps are running.
+	lock *sync.RWMutex
 }
 
 func (t credVarsTracker) Get(varDef VariableDefinition) (interface{}, bool, error) {
+	if varDef.Source == LocalVarSource {
+		t.lock.RLock()
+		val, found := t.localVars.get(varDef.Name)
+		t.lock.RUnlock()
+
+		return val, found, nil
+	}
+
 	val, found, err := t.credVars.Get(varDef)
 	if found {
+		name := varDef.Name
+		if varDef.Source != "" {
+			name = varDef.Source + ":" + name
+		}
+
 		t.lock.Lock()
-		t.track(varDef.Name, val)
+		t.track(name, val)
 		t.lock.Unlock()
 	}
 
@@ -80,13 +132,39 @@ func (t credVarsTracker) IterateInterpolatedCreds(iter CredVarsTrackerIterator)
 	t.lock.RUnlock()
 }
 
+func (t credVarsTracker) NewLocalScope() CredVarsTracker {
+	t.localVars = newLocalVars(t.localVars)
+	return t
+}
+
+func (t credVarsTracker) AddLocalVar(name string, val interface{}, redact bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.localVars.vars[name] = val
+
+	if redact {
+		t.track(LocalVarSource+":"+name, val)
+	}
+}
+
 // DummyCredVarsTracker do nothing,
 
 type dummyCredVarsTracker struct {
-	credVars Variables
+	credVars  Variables
+	localVars *localVars
+	lock      *sync.RWMutex
 }
 
 func (t dummyCredVarsTracker) Get(varDef VariableDefinition) (interface{}, bool, error) {
+	if varDef.Source == LocalVarSource {
+		t.lock.RLock()
+		val, found := t.localVars.get(varDef.Name)
+		t.lock.RUnlock()
+
+		return val, found, nil
+	}
+
 	return t.credVars.Get(varDef)
 }
 
@@ -98,6 +176,17 @@ func (t dummyCredVarsTracker) IterateInterpolatedCreds(iter CredVarsTrackerItera
 	// do nothing
 }
 
+func (t dummyCredVarsTracker) NewLocalScope() CredVarsTracker {
+	t.localVars = newLocalVars(t.localVars)
+	return t
+}
+
+func (t dummyCredVarsTracker) AddLocalVar(name string, val interface{}, redact bool) {
+	t.lock.Lock()
+	t.localVars.vars[name] = val
+	t.lock.Unlock()
+}
+
 // MapCredVarsTrackerIterator implements a simple CredVarsTrackerIterator which just
 // populate interpolated secrets into a map. This could be useful in unit test.
 
@@ -47,6 +47,60 @@ var _ = Describe("vars_tracker", func() {
 				Expect(err).ToNot(HaveOccurred())
 			})
 		})
+
+		Describe("local vars", func() {
+			var scope CredVarsTracker
+
+			BeforeEach(func() {
+				scope = tracker.NewLocalScope()
+				scope.AddLocalVar("k1", "local-v1", false)
+				scope.AddLocalVar("k4", "local-v4", true)
+			})
+
+			It("are fetched with the local var source", func() {
+				val, found, err := scope.Get(VariableDefinition{Source: LocalVarSource, Name: "k1"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(val).To(Equal("local-v1"))
+
+				val, found, err = scope.Get(VariableDefinition{Name: "k1"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(val).To(Equal("v1"))
+			})
+
+			It("are not visible to the parent scope", func() {
+				_, found, err := tracker.Get(VariableDefinition{Source: LocalVarSource, Name: "k1"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("are visible to nested scopes, including vars added later", func() {
+				nested := scope.NewLocalScope()
+				scope.AddLocalVar("k5", "local-v5", false)
+
+				val, found, err := nested.Get(VariableDefinition{Source: LocalVarSource, Name: "k4"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(val).To(Equal("local-v4"))
+
+				val, found, err = nested.Get(VariableDefinition{Source: LocalVarSource, Name: "k5"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(val).To(Equal("local-v5"))
+			})
+
+			It("tracks redacted local vars along with the creds", func() {
+				scope.Get(VariableDefinition{Name: "k2"})
+
+				mapit := NewMapCredVarsTrackerIterator()
+				tracker.IterateInterpolatedCreds(mapit)
+				Expect(mapit.Data).To(Equal(map[string]interface{}{
+					"k2":   "v2",
+					".:k4": "local-v4",
+				}))
+			})
+		})
 	})
 
 	Describe("turn off track", func() {
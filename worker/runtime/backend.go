@@ -0,0 +1,368 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/errdefs"
+)
+
+//go:generate counterfeiter github.com/containerd/containerd.Container
+//go:generate counterfeiter github.com/containerd/containerd.Task
+//go:generate counterfeiter github.com/containerd/containerd.Process
+//go:generate counterfeiter github.com/containerd/containerd/cio.IO
+
+const (
+	// Namespace is the containerd namespace in which all containers are
+	// created.
+	Namespace = "concourse"
+
+	graceTimeLabel = "concourse.garden.grace-time"
+)
+
+// BackendConfig configures the containerd Garden backend.
+type BackendConfig struct {
+	// Address is the path to containerd's socket.
+	Address string
+
+	// RequestTimeout bounds each request made to containerd.
+	RequestTimeout time.Duration
+
+	// MaxContainers is reported as the backend's capacity.
+	MaxContainers int
+
+	Spec SpecOptions
+}
+
+//go:generate counterfeiter . Client
+
+// Client is the subset of the containerd client used by the backend.
+type Client interface {
+	NewContainer(ctx context.Context, id string, opts ...containerd.NewContainerOpts) (containerd.Container, error)
+	LoadContainer(ctx context.Context, id string) (containerd.Container, error)
+	Containers(ctx context.Context, filters ...string) ([]containerd.Container, error)
+	Version(ctx context.Context) (containerd.Version, error)
+	Close() error
+}
+
+var _ Client = &containerd.Client{}
+
+// GardenBackend implements a Garden backend on top of containerd, allowing
+// the worker to run containers without Guardian.
+//
+// Containers are created with their Garden properties as labels and run the
+// init binary as their task; processes are run and attached to as execs
+// within the task.
+type GardenBackend struct {
+	logger  lager.Logger
+	config  BackendConfig
+	network Network
+
+	client Client
+}
+
+var _ garden.Backend = &GardenBackend{}
+
+// GardenBackendOpt configures optional behaviour of the backend.
+type GardenBackendOpt func(*GardenBackend)
+
+// WithClient configures the backend to use the given client rather than
+// connecting to containerd on start.
+func WithClient(client Client) GardenBackendOpt {
+	return func(backend *GardenBackend) {
+		backend.client = client
+	}
+}
+
+func NewGardenBackend(logger lager.Logger, config BackendConfig, network Network, opts ...GardenBackendOpt) *GardenBackend {
+	backend := &GardenBackend{
+		logger:  logger,
+		config:  config,
+		network: network,
+	}
+
+	for _, opt := range opts {
+		opt(backend)
+	}
+
+	return backend
+}
+
+// Start connects to containerd, unless a client was given. It must be called
+// before the backend is served.
+func (backend *GardenBackend) Start() error {
+	if backend.client != nil {
+		return nil
+	}
+
+	client, err := containerd.New(
+		backend.config.Address,
+		containerd.WithDefaultNamespace(Namespace),
+	)
+	if err != nil {
+		return fmt.Errorf("connect to containerd: %s", err)
+	}
+
+	backend.client = client
+
+	return nil
+}
+
+func (backend *GardenBackend) Stop() {
+	if backend.client == nil {
+		return
+	}
+
+	err := backend.client.Close()
+	if err != nil {
+		backend.logger.Error("failed-to-close-client", err)
+	}
+}
+
+func (backend *GardenBackend) GraceTime(container garden.Container) time.Duration {
+	return container.(*Container).graceTime()
+}
+
+func (backend *GardenBackend) Ping() error {
+	ctx, cancel := backend.context()
+	defer cancel()
+
+	_, err := backend.client.Version(ctx)
+	if err != nil {
+		return garden.NewServiceUnavailableError(err.Error())
+	}
+
+	return nil
+}
+
+func (backend *GardenBackend) Capacity() (garden.Capacity, error) {
+	return garden.Capacity{
+		MaxContainers: uint64(backend.config.MaxContainers),
+	}, nil
+}
+
+func (backend *GardenBackend) Create(gdnSpec garden.ContainerSpec) (garden.Container, error) {
+	logger := backend.logger.Session("create", lager.Data{"handle": gdnSpec.Handle})
+
+	ctx, cancel := backend.context()
+	defer cancel()
+
+	spec, err := OciSpec(gdnSpec, backend.config.Spec)
+	if err != nil {
+		logger.Error("failed-to-generate-spec", err)
+		return nil, err
+	}
+
+	labels := map[string]string{}
+	for name, value := range gdnSpec.Properties {
+		labels[name] = value
+	}
+
+	if gdnSpec.GraceTime != 0 {
+		labels[graceTimeLabel] = gdnSpec.GraceTime.String()
+	}
+
+	cdContainer, err := backend.client.NewContainer(ctx, gdnSpec.Handle,
+		containerd.WithSpec(spec),
+		containerd.WithContainerLabels(labels),
+	)
+	if err != nil {
+		logger.Error("failed-to-create-container", err)
+		return nil, fmt.Errorf("create container: %s", err)
+	}
+
+	task, err := cdContainer.NewTask(ctx, cio.NullIO)
+	if err != nil {
+		logger.Error("failed-to-create-task", err)
+		backend.cleanup(logger, cdContainer)
+		return nil, fmt.Errorf("create task: %s", err)
+	}
+
+	err = backend.network.Add(ctx, gdnSpec.Handle, task.Pid())
+	if err != nil {
+		logger.Error("failed-to-add-network", err)
+		backend.cleanup(logger, cdContainer)
+		return nil, err
+	}
+
+	err = task.Start(ctx)
+	if err != nil {
+		logger.Error("failed-to-start-task", err)
+		backend.cleanup(logger, cdContainer)
+		return nil, fmt.Errorf("start task: %s", err)
+	}
+
+	return backend.newContainer(cdContainer), nil
+}
+
+func (backend *GardenBackend) Destroy(handle string) error {
+	logger := backend.logger.Session("destroy", lager.Data{"handle": handle})
+
+	ctx, cancel := backend.context()
+	defer cancel()
+
+	cdContainer, err := backend.client.LoadContainer(ctx, handle)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return garden.ContainerNotFoundError{Handle: handle}
+		}
+
+		logger.Error("failed-to-load-container", err)
+		return err
+	}
+
+	return backend.destroy(ctx, logger, cdContainer)
+}
+
+func (backend *GardenBackend) Containers(properties garden.Properties) ([]garden.Container, error) {
+	ctx, cancel := backend.context()
+	defer cancel()
+
+	filters := []string{}
+	for name, value := range properties {
+		filters = append(filters, fmt.Sprintf("labels.%q==%q", name, value))
+	}
+
+	var filter []string
+	if len(filters) > 0 {
+		filter = []string{strings.Join(filters, ",")}
+	}
+
+	cdContainers, err := backend.client.Containers(ctx, filter...)
+	if err != nil {
+		return nil, fmt.Errorf("list containers: %s", err)
+	}
+
+	containers := []garden.Container{}
+	for _, cdContainer := range cdContainers {
+		containers = append(containers, backend.newContainer(cdContainer))
+	}
+
+	return containers, nil
+}
+
+func (backend *GardenBackend) BulkInfo(handles []string) (map[string]garden.ContainerInfoEntry, error) {
+	infos := map[string]garden.ContainerInfoEntry{}
+
+	for _, handle := range handles {
+		container, err := backend.Lookup(handle)
+		if err != nil {
+			infos[handle] = garden.ContainerInfoEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		info, err := container.Info()
+		if err != nil {
+			infos[handle] = garden.ContainerInfoEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		infos[handle] = garden.ContainerInfoEntry{Info: info}
+	}
+
+	return infos, nil
+}
+
+func (backend *GardenBackend) BulkMetrics(handles []string) (map[string]garden.ContainerMetricsEntry, error) {
+	metrics := map[string]garden.ContainerMetricsEntry{}
+
+	for _, handle := range handles {
+		metrics[handle] = garden.ContainerMetricsEntry{Err: garden.NewError(ErrNotImplemented.Error())}
+	}
+
+	return metrics, nil
+}
+
+func (backend *GardenBackend) Lookup(handle string) (garden.Container, error) {
+	ctx, cancel := backend.context()
+	defer cancel()
+
+	cdContainer, err := backend.client.LoadContainer(ctx, handle)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, garden.ContainerNotFoundError{Handle: handle}
+		}
+
+		return nil, err
+	}
+
+	return backend.newContainer(cdContainer), nil
+}
+
+func (backend *GardenBackend) newContainer(cdContainer containerd.Container) *Container {
+	return &Container{
+		container: cdContainer,
+		timeout:   backend.config.RequestTimeout,
+	}
+}
+
+// destroy kills the container's task, disconnects it from the network and
+// deletes the container.
+func (backend *GardenBackend) destroy(ctx context.Context, logger lager.Logger, cdContainer containerd.Container) error {
+	task, err := cdContainer.Task(ctx, nil)
+	if err != nil && !errdefs.IsNotFound(err) {
+		logger.Error("failed-to-load-task", err)
+		return err
+	}
+
+	var pid uint32
+	if task != nil {
+		pid = task.Pid()
+	}
+
+	err = backend.network.Remove(ctx, cdContainer.ID(), pid)
+	if err != nil {
+		logger.Error("failed-to-remove-network", err)
+		return err
+	}
+
+	if task != nil {
+		_, err = task.Delete(ctx, containerd.WithProcessKill)
+		if err != nil && !errdefs.IsNotFound(err) {
+			logger.Error("failed-to-delete-task", err)
+			return err
+		}
+	}
+
+	err = cdContainer.Delete(ctx)
+	if err != nil && !errdefs.IsNotFound(err) {
+		logger.Error("failed-to-delete-container", err)
+		return err
+	}
+
+	return nil
+}
+
+func (backend *GardenBackend) cleanup(logger lager.Logger, cdContainer containerd.Container) {
+	ctx, cancel := backend.context()
+	defer cancel()
+
+	err := backend.destroy(ctx, logger, cdContainer)
+	if err != nil {
+		logger.Error("failed-to-clean-up-container", err)
+	}
+}
+
+func (backend *GardenBackend) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), backend.config.RequestTimeout)
+}
+
+func parseGraceTime(labels map[string]string) time.Duration {
+	value, found := labels[graceTimeLabel]
+	if !found {
+		return 0
+	}
+
+	graceTime, err := time.ParseDuration(value)
+	if err != nil {
+		return 0
+	}
+
+	return graceTime
+}
@@ -0,0 +1,353 @@
+package runtime_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/errdefs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+var _ = Describe("GardenBackend", func() {
+	var (
+		fakeClient    *runtimefakes.FakeClient
+		fakeNetwork   *runtimefakes.FakeNetwork
+		fakeContainer *runtimefakes.FakeContainer
+		fakeTask      *runtimefakes.FakeTask
+
+		backend *runtime.GardenBackend
+	)
+
+	BeforeEach(func() {
+		fakeClient = new(runtimefakes.FakeClient)
+		fakeNetwork = new(runtimefakes.FakeNetwork)
+		fakeContainer = new(runtimefakes.FakeContainer)
+		fakeTask = new(runtimefakes.FakeTask)
+
+		fakeContainer.IDReturns("some-handle")
+		fakeTask.PidReturns(1234)
+
+		backend = runtime.NewGardenBackend(
+			lagertest.NewTestLogger("test"),
+			runtime.BackendConfig{
+				RequestTimeout: time.Minute,
+				MaxContainers:  250,
+				Spec: runtime.SpecOptions{
+					InitBinPath:    "/some/init",
+					ResolvConfPath: "/some/resolv.conf",
+					MaxUID:         4294967294,
+					MaxGID:         4294967294,
+				},
+			},
+			fakeNetwork,
+			runtime.WithClient(fakeClient),
+		)
+
+		Expect(backend.Start()).To(Succeed())
+	})
+
+	Describe("Start", func() {
+		It("does not replace the given client", func() {
+			Expect(backend.Ping()).To(Succeed())
+			Expect(fakeClient.VersionCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("Stop", func() {
+		It("closes the client", func() {
+			backend.Stop()
+			Expect(fakeClient.CloseCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("Ping", func() {
+		Context("when containerd cannot be reached", func() {
+			BeforeEach(func() {
+				fakeClient.VersionReturns(containerd.Version{}, errors.New("nope"))
+			})
+
+			It("returns a service unavailable error", func() {
+				err := backend.Ping()
+				Expect(err).To(Equal(garden.NewServiceUnavailableError("nope")))
+			})
+		})
+	})
+
+	Describe("Capacity", func() {
+		It("reports the configured max containers", func() {
+			capacity, err := backend.Capacity()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(capacity.MaxContainers).To(Equal(uint64(250)))
+		})
+	})
+
+	Describe("Create", func() {
+		var (
+			gdnSpec garden.ContainerSpec
+
+			container garden.Container
+			createErr error
+		)
+
+		BeforeEach(func() {
+			gdnSpec = garden.ContainerSpec{
+				Handle:     "some-handle",
+				RootFSPath: "raw:///some/rootfs",
+				GraceTime:  time.Hour,
+				Properties: garden.Properties{"some": "property"},
+				Limits: garden.Limits{
+					CPU:    garden.CPULimits{LimitInShares: 512},
+					Memory: garden.MemoryLimits{LimitInBytes: 1024},
+				},
+			}
+
+			fakeClient.NewContainerReturns(fakeContainer, nil)
+			fakeContainer.NewTaskReturns(fakeTask, nil)
+		})
+
+		JustBeforeEach(func() {
+			container, createErr = backend.Create(gdnSpec)
+		})
+
+		createdContainer := func() containers.Container {
+			Expect(fakeClient.NewContainerCallCount()).To(Equal(1))
+
+			_, id, opts := fakeClient.NewContainerArgsForCall(0)
+			Expect(id).To(Equal("some-handle"))
+
+			record := containers.Container{ID: id}
+			for _, opt := range opts {
+				Expect(opt(context.Background(), nil, &record)).To(Succeed())
+			}
+
+			return record
+		}
+
+		It("creates a container with the properties and grace time as labels", func() {
+			Expect(createErr).ToNot(HaveOccurred())
+			Expect(container.Handle()).To(Equal("some-handle"))
+
+			Expect(createdContainer().Labels).To(Equal(map[string]string{
+				"some":                        "property",
+				"concourse.garden.grace-time": "1h0m0s",
+			}))
+		})
+
+		It("creates the container with the limits in its spec", func() {
+			var spec specs.Spec
+			Expect(json.Unmarshal(createdContainer().Spec.Value, &spec)).To(Succeed())
+
+			Expect(spec.Root.Path).To(Equal("/some/rootfs"))
+			Expect(*spec.Linux.Resources.CPU.Shares).To(Equal(uint64(512)))
+			Expect(*spec.Linux.Resources.Memory.Limit).To(Equal(int64(1024)))
+		})
+
+		Context("when the task is started", func() {
+			var addedBeforeStart bool
+
+			BeforeEach(func() {
+				fakeTask.StartStub = func(context.Context) error {
+					addedBeforeStart = fakeNetwork.AddCallCount() == 1
+					return nil
+				}
+			})
+
+			It("has already added the task to the network", func() {
+				Expect(fakeTask.StartCallCount()).To(Equal(1))
+				Expect(addedBeforeStart).To(BeTrue())
+
+				_, handle, pid := fakeNetwork.AddArgsForCall(0)
+				Expect(handle).To(Equal("some-handle"))
+				Expect(pid).To(Equal(uint32(1234)))
+			})
+		})
+
+		Context("when the spec is invalid", func() {
+			BeforeEach(func() {
+				gdnSpec.RootFSPath = "docker:///some/image"
+			})
+
+			It("errors without creating a container", func() {
+				Expect(createErr).To(HaveOccurred())
+				Expect(fakeClient.NewContainerCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when creating the container fails", func() {
+			BeforeEach(func() {
+				fakeClient.NewContainerReturns(nil, errors.New("nope"))
+			})
+
+			It("errors", func() {
+				Expect(createErr).To(MatchError("create container: nope"))
+			})
+		})
+
+		Context("when adding the network fails", func() {
+			BeforeEach(func() {
+				fakeNetwork.AddReturns(errors.New("nope"))
+				fakeContainer.TaskReturns(fakeTask, nil)
+			})
+
+			It("errors", func() {
+				Expect(createErr).To(MatchError("nope"))
+			})
+
+			It("does not start the task", func() {
+				Expect(fakeTask.StartCallCount()).To(Equal(0))
+			})
+
+			It("cleans up the container", func() {
+				Expect(fakeNetwork.RemoveCallCount()).To(Equal(1))
+				Expect(fakeTask.DeleteCallCount()).To(Equal(1))
+				Expect(fakeContainer.DeleteCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when starting the task fails", func() {
+			BeforeEach(func() {
+				fakeTask.StartReturns(errors.New("nope"))
+				fakeContainer.TaskReturns(fakeTask, nil)
+			})
+
+			It("errors", func() {
+				Expect(createErr).To(MatchError("start task: nope"))
+			})
+
+			It("cleans up the container", func() {
+				Expect(fakeNetwork.RemoveCallCount()).To(Equal(1))
+				Expect(fakeTask.DeleteCallCount()).To(Equal(1))
+				Expect(fakeContainer.DeleteCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("Destroy", func() {
+		var destroyErr error
+
+		BeforeEach(func() {
+			fakeClient.LoadContainerReturns(fakeContainer, nil)
+			fakeContainer.TaskReturns(fakeTask, nil)
+		})
+
+		JustBeforeEach(func() {
+			destroyErr = backend.Destroy("some-handle")
+		})
+
+		It("removes the task from the network", func() {
+			Expect(destroyErr).ToNot(HaveOccurred())
+
+			Expect(fakeNetwork.RemoveCallCount()).To(Equal(1))
+			_, handle, pid := fakeNetwork.RemoveArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(pid).To(Equal(uint32(1234)))
+		})
+
+		It("kills and deletes the task", func() {
+			Expect(fakeTask.DeleteCallCount()).To(Equal(1))
+			_, opts := fakeTask.DeleteArgsForCall(0)
+			Expect(opts).To(HaveLen(1))
+		})
+
+		It("deletes the container", func() {
+			Expect(fakeContainer.DeleteCallCount()).To(Equal(1))
+		})
+
+		Context("when the container does not exist", func() {
+			BeforeEach(func() {
+				fakeClient.LoadContainerReturns(nil, errdefs.ErrNotFound)
+			})
+
+			It("returns a container not found error", func() {
+				Expect(destroyErr).To(Equal(garden.ContainerNotFoundError{Handle: "some-handle"}))
+			})
+		})
+
+		Context("when the task has already gone away", func() {
+			BeforeEach(func() {
+				fakeContainer.TaskReturns(nil, errdefs.ErrNotFound)
+			})
+
+			It("removes the network without a pid", func() {
+				Expect(destroyErr).ToNot(HaveOccurred())
+
+				Expect(fakeNetwork.RemoveCallCount()).To(Equal(1))
+				_, _, pid := fakeNetwork.RemoveArgsForCall(0)
+				Expect(pid).To(BeZero())
+			})
+
+			It("deletes the container", func() {
+				Expect(fakeTask.DeleteCallCount()).To(Equal(0))
+				Expect(fakeContainer.DeleteCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when removing the network fails", func() {
+			BeforeEach(func() {
+				fakeNetwork.RemoveReturns(errors.New("nope"))
+			})
+
+			It("errors without deleting the container", func() {
+				Expect(destroyErr).To(MatchError("nope"))
+				Expect(fakeContainer.DeleteCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the container has already been deleted", func() {
+			BeforeEach(func() {
+				fakeContainer.DeleteReturns(errdefs.ErrNotFound)
+			})
+
+			It("succeeds", func() {
+				Expect(destroyErr).ToNot(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Containers", func() {
+		BeforeEach(func() {
+			fakeClient.ContainersReturns([]containerd.Container{fakeContainer}, nil)
+		})
+
+		It("filters containers by their labels", func() {
+			containers, err := backend.Containers(garden.Properties{"some": "property"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(containers).To(HaveLen(1))
+			Expect(containers[0].Handle()).To(Equal("some-handle"))
+
+			_, filters := fakeClient.ContainersArgsForCall(0)
+			Expect(filters).To(Equal([]string{`labels."some"=="property"`}))
+		})
+
+		It("lists all containers when no properties are given", func() {
+			_, err := backend.Containers(nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, filters := fakeClient.ContainersArgsForCall(0)
+			Expect(filters).To(BeEmpty())
+		})
+	})
+
+	Describe("Lookup", func() {
+		Context("when the container does not exist", func() {
+			BeforeEach(func() {
+				fakeClient.LoadContainerReturns(nil, errdefs.ErrNotFound)
+			})
+
+			It("returns a container not found error", func() {
+				_, err := backend.Lookup("some-handle")
+				Expect(err).To(Equal(garden.ContainerNotFoundError{Handle: "some-handle"}))
+			})
+		})
+	})
+})
@@ -0,0 +1,401 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/errdefs"
+	uuid "github.com/nu7hatch/gouuid"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// ErrNotImplemented is returned for Garden operations which Concourse does
+// not use and so are not supported by the containerd backend.
+var ErrNotImplemented = errors.New("not implemented")
+
+// stopGracePeriod is how long processes are given to exit after being
+// terminated before they are killed.
+const stopGracePeriod = 10 * time.Second
+
+// Container is a Garden container backed by a containerd container and its
+// init task.
+type Container struct {
+	container containerd.Container
+	timeout   time.Duration
+}
+
+var _ garden.Container = &Container{}
+
+func (container *Container) Handle() string {
+	return container.container.ID()
+}
+
+// Stop signals every process in the container to terminate, killing them if
+// they have not exited after a grace period. If kill is true they are killed
+// immediately.
+func (container *Container) Stop(kill bool) error {
+	ctx, cancel := container.context()
+	defer cancel()
+
+	task, err := container.container.Task(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("load task: %s", err)
+	}
+
+	exited, err := task.Wait(ctx)
+	if err != nil {
+		return fmt.Errorf("wait for task: %s", err)
+	}
+
+	if !kill {
+		err = task.Kill(ctx, syscall.SIGTERM, containerd.WithKillAll)
+		if err != nil {
+			return fmt.Errorf("terminate task: %s", err)
+		}
+
+		select {
+		case <-exited:
+			return nil
+		case <-time.After(stopGracePeriod):
+		}
+	}
+
+	err = task.Kill(ctx, syscall.SIGKILL, containerd.WithKillAll)
+	if err != nil {
+		return fmt.Errorf("kill task: %s", err)
+	}
+
+	select {
+	case <-exited:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (container *Container) Info() (garden.ContainerInfo, error) {
+	ctx, cancel := container.context()
+	defer cancel()
+
+	state := "active"
+
+	task, err := container.container.Task(ctx, nil)
+	if err != nil {
+		if !errdefs.IsNotFound(err) {
+			return garden.ContainerInfo{}, err
+		}
+
+		state = "stopped"
+	} else {
+		status, err := task.Status(ctx)
+		if err != nil {
+			return garden.ContainerInfo{}, err
+		}
+
+		if status.Status != containerd.Running {
+			state = "stopped"
+		}
+	}
+
+	properties, err := container.Properties()
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	return garden.ContainerInfo{
+		State:      state,
+		Properties: properties,
+	}, nil
+}
+
+// Run executes a process in the container's task. The process inherits the
+// container's environment, and runs as the given user as resolved from the
+// container's /etc/passwd.
+func (container *Container) Run(gdnSpec garden.ProcessSpec, processIO garden.ProcessIO) (garden.Process, error) {
+	ctx, cancel := container.context()
+	defer cancel()
+
+	containerSpec, err := container.container.Spec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load spec: %s", err)
+	}
+
+	task, err := container.container.Task(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("load task: %s", err)
+	}
+
+	user, err := LookupUser(containerSpec.Root.Path, gdnSpec.User)
+	if err != nil {
+		return nil, err
+	}
+
+	procSpec := *containerSpec.Process
+	procSpec.Args = append([]string{gdnSpec.Path}, gdnSpec.Args...)
+	procSpec.Env = processEnv(append(append([]string{}, containerSpec.Process.Env...), gdnSpec.Env...))
+	procSpec.User = specs.User{UID: user.UID, GID: user.GID}
+	procSpec.Terminal = gdnSpec.TTY != nil
+
+	procSpec.Cwd = gdnSpec.Dir
+	if procSpec.Cwd == "" {
+		procSpec.Cwd = user.Home
+	}
+
+	id := gdnSpec.ID
+	if id == "" {
+		guid, err := uuid.NewV4()
+		if err != nil {
+			return nil, err
+		}
+
+		id = guid.String()
+	}
+
+	stdin := &stdinCloser{started: make(chan struct{})}
+	if processIO.Stdin != nil {
+		stdin.reader = processIO.Stdin
+	} else {
+		stdin.reader = strings.NewReader("")
+	}
+
+	streamOpts := []cio.Opt{cio.WithStreams(stdin, processIO.Stdout, processIO.Stderr)}
+	if procSpec.Terminal {
+		streamOpts = append(streamOpts, cio.WithTerminal)
+	}
+
+	proc, err := task.Exec(ctx, id, &procSpec, cio.NewCreator(streamOpts...))
+	if err != nil {
+		return nil, fmt.Errorf("exec process: %s", err)
+	}
+
+	// wait before starting so that the exit status cannot be missed
+	exited, err := proc.Wait(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("wait for process: %s", err)
+	}
+
+	err = proc.Start(ctx)
+	if err != nil {
+		_, _ = proc.Delete(ctx)
+		return nil, fmt.Errorf("start process: %s", err)
+	}
+
+	stdin.start(proc)
+
+	process := newProcess(proc, exited, container.timeout)
+
+	if gdnSpec.TTY != nil {
+		err = process.SetTTY(*gdnSpec.TTY)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return process, nil
+}
+
+// Attach re-attaches to a running process, e.g. when hijacking or after the
+// ATC restarts.
+func (container *Container) Attach(processID string, processIO garden.ProcessIO) (garden.Process, error) {
+	ctx, cancel := container.context()
+	defer cancel()
+
+	task, err := container.container.Task(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("load task: %s", err)
+	}
+
+	proc, err := task.LoadProcess(ctx, processID, cio.NewAttach(cio.WithStreams(processIO.Stdin, processIO.Stdout, processIO.Stderr)))
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, garden.ProcessNotFoundError{ProcessID: processID}
+		}
+
+		return nil, fmt.Errorf("load process: %s", err)
+	}
+
+	exited, err := proc.Wait(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("wait for process: %s", err)
+	}
+
+	return newProcess(proc, exited, container.timeout), nil
+}
+
+func (container *Container) Properties() (garden.Properties, error) {
+	ctx, cancel := container.context()
+	defer cancel()
+
+	labels, err := container.container.Labels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := garden.Properties{}
+	for name, value := range labels {
+		if name == graceTimeLabel {
+			continue
+		}
+
+		properties[name] = value
+	}
+
+	return properties, nil
+}
+
+func (container *Container) Property(name string) (string, error) {
+	properties, err := container.Properties()
+	if err != nil {
+		return "", err
+	}
+
+	value, found := properties[name]
+	if !found {
+		return "", fmt.Errorf("property does not exist: %s", name)
+	}
+
+	return value, nil
+}
+
+func (container *Container) SetProperty(name string, value string) error {
+	ctx, cancel := container.context()
+	defer cancel()
+
+	_, err := container.container.SetLabels(ctx, map[string]string{name: value})
+	return err
+}
+
+func (container *Container) RemoveProperty(name string) error {
+	ctx, cancel := container.context()
+	defer cancel()
+
+	// setting a label to an empty value removes it
+	_, err := container.container.SetLabels(ctx, map[string]string{name: ""})
+	return err
+}
+
+func (container *Container) SetGraceTime(graceTime time.Duration) error {
+	ctx, cancel := container.context()
+	defer cancel()
+
+	_, err := container.container.SetLabels(ctx, map[string]string{graceTimeLabel: graceTime.String()})
+	return err
+}
+
+func (container *Container) graceTime() time.Duration {
+	ctx, cancel := container.context()
+	defer cancel()
+
+	labels, err := container.container.Labels(ctx)
+	if err != nil {
+		return 0
+	}
+
+	return parseGraceTime(labels)
+}
+
+func (container *Container) StreamIn(garden.StreamInSpec) error {
+	return ErrNotImplemented
+}
+
+func (container *Container) StreamOut(garden.StreamOutSpec) (io.ReadCloser, error) {
+	return nil, ErrNotImplemented
+}
+
+func (container *Container) CurrentBandwidthLimits() (garden.BandwidthLimits, error) {
+	return garden.BandwidthLimits{}, ErrNotImplemented
+}
+
+func (container *Container) CurrentCPULimits() (garden.CPULimits, error) {
+	ctx, cancel := container.context()
+	defer cancel()
+
+	spec, err := container.container.Spec(ctx)
+	if err != nil {
+		return garden.CPULimits{}, err
+	}
+
+	limits := garden.CPULimits{}
+	if spec.Linux.Resources != nil && spec.Linux.Resources.CPU != nil && spec.Linux.Resources.CPU.Shares != nil {
+		limits.LimitInShares = *spec.Linux.Resources.CPU.Shares
+	}
+
+	return limits, nil
+}
+
+func (container *Container) CurrentDiskLimits() (garden.DiskLimits, error) {
+	return garden.DiskLimits{}, ErrNotImplemented
+}
+
+func (container *Container) CurrentMemoryLimits() (garden.MemoryLimits, error) {
+	ctx, cancel := container.context()
+	defer cancel()
+
+	spec, err := container.container.Spec(ctx)
+	if err != nil {
+		return garden.MemoryLimits{}, err
+	}
+
+	limits := garden.MemoryLimits{}
+	if spec.Linux.Resources != nil && spec.Linux.Resources.Memory != nil && spec.Linux.Resources.Memory.Limit != nil {
+		limits.LimitInBytes = uint64(*spec.Linux.Resources.Memory.Limit)
+	}
+
+	return limits, nil
+}
+
+func (container *Container) NetIn(hostPort, containerPort uint32) (uint32, uint32, error) {
+	return 0, 0, ErrNotImplemented
+}
+
+func (container *Container) NetOut(garden.NetOutRule) error {
+	return ErrNotImplemented
+}
+
+func (container *Container) BulkNetOut([]garden.NetOutRule) error {
+	return ErrNotImplemented
+}
+
+func (container *Container) Metrics() (garden.Metrics, error) {
+	return garden.Metrics{}, ErrNotImplemented
+}
+
+func (container *Container) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), container.timeout)
+}
+
+// stdinCloser closes the process's stdin once the given reader is exhausted,
+// as containerd does not propagate EOF on its own.
+type stdinCloser struct {
+	reader io.Reader
+
+	started chan struct{}
+	proc    containerd.Process
+	once    sync.Once
+}
+
+func (stdin *stdinCloser) Read(p []byte) (int, error) {
+	n, err := stdin.reader.Read(p)
+	if err == io.EOF {
+		stdin.once.Do(func() {
+			<-stdin.started
+			_ = stdin.proc.CloseIO(context.Background(), containerd.WithStdinCloser)
+		})
+	}
+
+	return n, err
+}
+
+func (stdin *stdinCloser) start(proc containerd.Process) {
+	stdin.proc = proc
+	close(stdin.started)
+}
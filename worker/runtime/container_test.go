@@ -0,0 +1,365 @@
+package runtime_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/errdefs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+var _ = Describe("Container", func() {
+	var (
+		tmpdir string
+
+		fakeClient    *runtimefakes.FakeClient
+		fakeContainer *runtimefakes.FakeContainer
+		fakeTask      *runtimefakes.FakeTask
+		fakeProcess   *runtimefakes.FakeProcess
+
+		spec *specs.Spec
+
+		container garden.Container
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpdir, err = ioutil.TempDir("", "runtime-container")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(os.MkdirAll(filepath.Join(tmpdir, "etc"), 0755)).To(Succeed())
+
+		err = ioutil.WriteFile(filepath.Join(tmpdir, "etc", "passwd"), []byte(
+			"root:x:0:0:root:/root:/bin/sh\n"+
+				"some-user:x:1000:1001:Some User:/home/some-user:/bin/sh\n",
+		), 0644)
+		Expect(err).ToNot(HaveOccurred())
+
+		fakeClient = new(runtimefakes.FakeClient)
+		fakeContainer = new(runtimefakes.FakeContainer)
+		fakeTask = new(runtimefakes.FakeTask)
+		fakeProcess = new(runtimefakes.FakeProcess)
+
+		spec = &specs.Spec{
+			Root: &specs.Root{Path: tmpdir},
+			Process: &specs.Process{
+				Env: []string{"PATH=/some/path", "FOO=bar"},
+			},
+			Linux: &specs.Linux{},
+		}
+
+		fakeContainer.IDReturns("some-handle")
+		fakeContainer.SpecReturns(spec, nil)
+		fakeContainer.TaskReturns(fakeTask, nil)
+		fakeClient.LoadContainerReturns(fakeContainer, nil)
+
+		fakeProcess.IDReturns("some-process")
+		fakeTask.ExecReturns(fakeProcess, nil)
+		fakeTask.LoadProcessReturns(fakeProcess, nil)
+
+		backend := runtime.NewGardenBackend(
+			lagertest.NewTestLogger("test"),
+			runtime.BackendConfig{RequestTimeout: time.Minute},
+			new(runtimefakes.FakeNetwork),
+			runtime.WithClient(fakeClient),
+		)
+
+		container, err = backend.Lookup("some-handle")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpdir)).To(Succeed())
+	})
+
+	Describe("Run", func() {
+		var (
+			processSpec garden.ProcessSpec
+			processIO   garden.ProcessIO
+
+			process garden.Process
+			runErr  error
+		)
+
+		BeforeEach(func() {
+			processSpec = garden.ProcessSpec{
+				ID:   "some-id",
+				Path: "/some/bin",
+				Args: []string{"some", "args"},
+				Env:  []string{"FOO=baz"},
+				User: "some-user",
+			}
+
+			processIO = garden.ProcessIO{
+				Stdin:  bytes.NewBufferString("some-input"),
+				Stdout: new(bytes.Buffer),
+				Stderr: new(bytes.Buffer),
+			}
+		})
+
+		JustBeforeEach(func() {
+			process, runErr = container.Run(processSpec, processIO)
+		})
+
+		It("execs the process in the container's task", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(process.ID()).To(Equal("some-process"))
+
+			Expect(fakeTask.ExecCallCount()).To(Equal(1))
+			_, id, procSpec, creator := fakeTask.ExecArgsForCall(0)
+			Expect(id).To(Equal("some-id"))
+			Expect(creator).ToNot(BeNil())
+
+			Expect(procSpec.Args).To(Equal([]string{"/some/bin", "some", "args"}))
+			Expect(procSpec.Env).To(Equal([]string{"PATH=/some/path", "FOO=bar", "FOO=baz"}))
+			Expect(procSpec.User).To(Equal(specs.User{UID: 1000, GID: 1001}))
+			Expect(procSpec.Cwd).To(Equal("/home/some-user"))
+			Expect(procSpec.Terminal).To(BeFalse())
+		})
+
+		It("does not modify the container's spec", func() {
+			Expect(spec.Process.Env).To(Equal([]string{"PATH=/some/path", "FOO=bar"}))
+		})
+
+		Context("when the process is started", func() {
+			var waitedBeforeStart bool
+
+			BeforeEach(func() {
+				fakeProcess.StartStub = func(context.Context) error {
+					waitedBeforeStart = fakeProcess.WaitCallCount() == 1
+					return nil
+				}
+			})
+
+			It("is already being waited on, so its exit cannot be missed", func() {
+				Expect(fakeProcess.StartCallCount()).To(Equal(1))
+				Expect(waitedBeforeStart).To(BeTrue())
+			})
+		})
+
+		Context("when a working directory is given", func() {
+			BeforeEach(func() {
+				processSpec.Dir = "/some/dir"
+			})
+
+			It("runs the process in it", func() {
+				_, _, procSpec, _ := fakeTask.ExecArgsForCall(0)
+				Expect(procSpec.Cwd).To(Equal("/some/dir"))
+			})
+		})
+
+		Context("when no id is given", func() {
+			BeforeEach(func() {
+				processSpec.ID = ""
+			})
+
+			It("generates one", func() {
+				_, id, _, _ := fakeTask.ExecArgsForCall(0)
+				Expect(id).ToNot(BeEmpty())
+			})
+		})
+
+		Context("when a tty is requested", func() {
+			BeforeEach(func() {
+				processSpec.TTY = &garden.TTYSpec{
+					WindowSize: &garden.WindowSize{Columns: 80, Rows: 24},
+				}
+			})
+
+			It("runs the process with a terminal of the given size", func() {
+				_, _, procSpec, _ := fakeTask.ExecArgsForCall(0)
+				Expect(procSpec.Terminal).To(BeTrue())
+
+				Expect(fakeProcess.ResizeCallCount()).To(Equal(1))
+				_, width, height := fakeProcess.ResizeArgsForCall(0)
+				Expect(width).To(Equal(uint32(80)))
+				Expect(height).To(Equal(uint32(24)))
+			})
+		})
+
+		Context("when the user does not exist", func() {
+			BeforeEach(func() {
+				processSpec.User = "bogus-user"
+			})
+
+			It("errors without execing", func() {
+				Expect(runErr).To(HaveOccurred())
+				Expect(fakeTask.ExecCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when starting the process fails", func() {
+			BeforeEach(func() {
+				fakeProcess.StartReturns(errors.New("nope"))
+			})
+
+			It("deletes the process", func() {
+				Expect(runErr).To(MatchError("start process: nope"))
+				Expect(fakeProcess.DeleteCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("Attach", func() {
+		It("loads the process from the container's task", func() {
+			process, err := container.Attach("some-process", garden.ProcessIO{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(process.ID()).To(Equal("some-process"))
+
+			_, id, attach := fakeTask.LoadProcessArgsForCall(0)
+			Expect(id).To(Equal("some-process"))
+			Expect(attach).ToNot(BeNil())
+
+			Expect(fakeProcess.WaitCallCount()).To(Equal(1))
+		})
+
+		Context("when the process does not exist", func() {
+			BeforeEach(func() {
+				fakeTask.LoadProcessReturns(nil, errdefs.ErrNotFound)
+			})
+
+			It("returns a process not found error", func() {
+				_, err := container.Attach("some-process", garden.ProcessIO{})
+				Expect(err).To(Equal(garden.ProcessNotFoundError{ProcessID: "some-process"}))
+			})
+		})
+	})
+
+	Describe("Stop", func() {
+		var exited chan containerd.ExitStatus
+
+		BeforeEach(func() {
+			exited = make(chan containerd.ExitStatus)
+			fakeTask.WaitReturns(exited, nil)
+		})
+
+		Context("when killing", func() {
+			It("kills every process in the task", func() {
+				fakeTask.KillStub = func(ctx context.Context, signal syscall.Signal, opts ...containerd.KillOpts) error {
+					close(exited)
+					return nil
+				}
+
+				Expect(container.Stop(true)).To(Succeed())
+
+				Expect(fakeTask.KillCallCount()).To(Equal(1))
+				_, signal, opts := fakeTask.KillArgsForCall(0)
+				Expect(signal).To(Equal(syscall.SIGKILL))
+				Expect(opts).To(HaveLen(1))
+			})
+		})
+
+		Context("when not killing", func() {
+			It("terminates every process in the task", func() {
+				fakeTask.KillStub = func(ctx context.Context, signal syscall.Signal, opts ...containerd.KillOpts) error {
+					close(exited)
+					return nil
+				}
+
+				Expect(container.Stop(false)).To(Succeed())
+
+				Expect(fakeTask.KillCallCount()).To(Equal(1))
+				_, signal, _ := fakeTask.KillArgsForCall(0)
+				Expect(signal).To(Equal(syscall.SIGTERM))
+			})
+		})
+	})
+
+	Describe("Info", func() {
+		BeforeEach(func() {
+			fakeContainer.LabelsReturns(map[string]string{
+				"some":                        "property",
+				"concourse.garden.grace-time": "1h0m0s",
+			}, nil)
+		})
+
+		It("reports running containers as active, hiding the grace time", func() {
+			fakeTask.StatusReturns(containerd.Status{Status: containerd.Running}, nil)
+
+			info, err := container.Info()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.State).To(Equal("active"))
+			Expect(info.Properties).To(Equal(garden.Properties{"some": "property"}))
+		})
+
+		It("reports containers without a task as stopped", func() {
+			fakeContainer.TaskReturns(nil, errdefs.ErrNotFound)
+
+			info, err := container.Info()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.State).To(Equal("stopped"))
+		})
+	})
+
+	Describe("properties", func() {
+		It("removes properties by clearing their label", func() {
+			Expect(container.RemoveProperty("some")).To(Succeed())
+
+			_, labels := fakeContainer.SetLabelsArgsForCall(0)
+			Expect(labels).To(Equal(map[string]string{"some": ""}))
+		})
+
+		It("sets the grace time as a label", func() {
+			Expect(container.SetGraceTime(time.Minute)).To(Succeed())
+
+			_, labels := fakeContainer.SetLabelsArgsForCall(0)
+			Expect(labels).To(Equal(map[string]string{"concourse.garden.grace-time": "1m0s"}))
+		})
+	})
+
+	Describe("limits", func() {
+		Context("when the spec has limits", func() {
+			BeforeEach(func() {
+				shares := uint64(512)
+				memory := int64(1024)
+
+				spec.Linux.Resources = &specs.LinuxResources{
+					CPU:    &specs.LinuxCPU{Shares: &shares},
+					Memory: &specs.LinuxMemory{Limit: &memory},
+				}
+			})
+
+			It("reports the cpu limit", func() {
+				limits, err := container.CurrentCPULimits()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(limits).To(Equal(garden.CPULimits{LimitInShares: 512}))
+			})
+
+			It("reports the memory limit", func() {
+				limits, err := container.CurrentMemoryLimits()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(limits).To(Equal(garden.MemoryLimits{LimitInBytes: 1024}))
+			})
+		})
+
+		Context("when the spec has no limits", func() {
+			It("reports no limits", func() {
+				cpu, err := container.CurrentCPULimits()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cpu).To(BeZero())
+
+				memory, err := container.CurrentMemoryLimits()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(memory).To(BeZero())
+			})
+		})
+
+		It("does not support disk limits", func() {
+			_, err := container.CurrentDiskLimits()
+			Expect(err).To(Equal(runtime.ErrNotImplemented))
+		})
+	})
+})
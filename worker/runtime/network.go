@@ -0,0 +1,110 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/containernetworking/cni/libcni"
+)
+
+//go:generate counterfeiter . Network
+
+// Network connects containers' network namespaces to the worker's container
+// network.
+type Network interface {
+	// Add connects the network namespace of the process with the given pid.
+	Add(ctx context.Context, handle string, pid uint32) error
+
+	// Remove releases the container's address. The pid may be 0 if the
+	// container's task has already exited.
+	Remove(ctx context.Context, handle string, pid uint32) error
+}
+
+const (
+	networkName   = "concourse"
+	interfaceName = "eth0"
+)
+
+// CNINetworkConfig configures the default CNI network, which places each
+// container on a bridge with an address from the given pool. Outbound traffic
+// is masqueraded.
+type CNINetworkConfig struct {
+	BridgeName string
+	Subnet     string
+	DataDir    string
+}
+
+func (config CNINetworkConfig) confList() string {
+	return fmt.Sprintf(`{
+  "cniVersion": "0.4.0",
+  "name": %q,
+  "plugins": [
+    {
+      "type": "bridge",
+      "bridge": %q,
+      "isGateway": true,
+      "ipMasq": true,
+      "ipam": {
+        "type": "host-local",
+        "subnet": %q,
+        "dataDir": %q,
+        "routes": [{"dst": "0.0.0.0/0"}]
+      }
+    },
+    {
+      "type": "firewall"
+    }
+  ]
+}`, networkName, config.BridgeName, config.Subnet, config.DataDir)
+}
+
+type cniNetwork struct {
+	cni  libcni.CNI
+	list *libcni.NetworkConfigList
+}
+
+// NewCNINetwork constructs a Network from the CNI plugins in the given
+// directory.
+func NewCNINetwork(pluginsDir string, config CNINetworkConfig) (Network, error) {
+	list, err := libcni.ConfListFromBytes([]byte(config.confList()))
+	if err != nil {
+		return nil, fmt.Errorf("parse cni config: %s", err)
+	}
+
+	return &cniNetwork{
+		cni:  libcni.NewCNIConfig([]string{pluginsDir}, nil),
+		list: list,
+	}, nil
+}
+
+func (network *cniNetwork) Add(ctx context.Context, handle string, pid uint32) error {
+	_, err := network.cni.AddNetworkList(ctx, network.list, network.runtimeConf(handle, pid))
+	if err != nil {
+		return fmt.Errorf("add network: %s", err)
+	}
+
+	return nil
+}
+
+func (network *cniNetwork) Remove(ctx context.Context, handle string, pid uint32) error {
+	err := network.cni.DelNetworkList(ctx, network.list, network.runtimeConf(handle, pid))
+	if err != nil {
+		return fmt.Errorf("remove network: %s", err)
+	}
+
+	return nil
+}
+
+func (network *cniNetwork) runtimeConf(handle string, pid uint32) *libcni.RuntimeConf {
+	netNS := ""
+	if pid != 0 {
+		netNS = filepath.Join("/proc", fmt.Sprint(pid), "ns", "net")
+	}
+
+	return &libcni.RuntimeConf{
+		ContainerID: handle,
+		NetNS:       netNS,
+		IfName:      interfaceName,
+	}
+}
@@ -0,0 +1,94 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"syscall"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/containerd/containerd"
+)
+
+// Process is a Garden process backed by a containerd exec process.
+type Process struct {
+	process containerd.Process
+	exited  <-chan containerd.ExitStatus
+	timeout time.Duration
+
+	waitOnce   sync.Once
+	exitStatus int
+	waitErr    error
+}
+
+var _ garden.Process = &Process{}
+
+func newProcess(process containerd.Process, exited <-chan containerd.ExitStatus, timeout time.Duration) *Process {
+	return &Process{
+		process: process,
+		exited:  exited,
+		timeout: timeout,
+	}
+}
+
+func (process *Process) ID() string {
+	return process.process.ID()
+}
+
+// Wait blocks until the process exits, and then deletes it so that its ID
+// may be reused.
+func (process *Process) Wait() (int, error) {
+	process.waitOnce.Do(func() {
+		status := <-process.exited
+
+		code, _, err := status.Result()
+		if err != nil {
+			process.waitErr = fmt.Errorf("process exit status: %s", err)
+			return
+		}
+
+		process.exitStatus = int(code)
+
+		// the process's IO must be drained before it is deleted, otherwise
+		// trailing output may be lost
+		process.process.IO().Wait()
+
+		ctx, cancel := process.context()
+		defer cancel()
+
+		_, err = process.process.Delete(ctx)
+		if err != nil {
+			process.waitErr = fmt.Errorf("delete process: %s", err)
+		}
+	})
+
+	return process.exitStatus, process.waitErr
+}
+
+func (process *Process) SetTTY(spec garden.TTYSpec) error {
+	if spec.WindowSize == nil {
+		return nil
+	}
+
+	ctx, cancel := process.context()
+	defer cancel()
+
+	return process.process.Resize(ctx, uint32(spec.WindowSize.Columns), uint32(spec.WindowSize.Rows))
+}
+
+func (process *Process) Signal(signal garden.Signal) error {
+	ctx, cancel := process.context()
+	defer cancel()
+
+	sig := syscall.SIGTERM
+	if signal == garden.SignalKill {
+		sig = syscall.SIGKILL
+	}
+
+	return process.process.Kill(ctx, sig)
+}
+
+func (process *Process) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), process.timeout)
+}
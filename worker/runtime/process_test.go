@@ -0,0 +1,152 @@
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	"github.com/containerd/containerd"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+var _ = Describe("Process", func() {
+	var (
+		fakeProcess *runtimefakes.FakeProcess
+		fakeIO      *runtimefakes.FakeIO
+
+		exited chan containerd.ExitStatus
+
+		process garden.Process
+	)
+
+	BeforeEach(func() {
+		fakeClient := new(runtimefakes.FakeClient)
+		fakeContainer := new(runtimefakes.FakeContainer)
+		fakeTask := new(runtimefakes.FakeTask)
+
+		fakeProcess = new(runtimefakes.FakeProcess)
+		fakeIO = new(runtimefakes.FakeIO)
+
+		exited = make(chan containerd.ExitStatus, 1)
+
+		fakeClient.LoadContainerReturns(fakeContainer, nil)
+		fakeContainer.SpecReturns(&specs.Spec{}, nil)
+		fakeContainer.TaskReturns(fakeTask, nil)
+		fakeTask.LoadProcessReturns(fakeProcess, nil)
+		fakeProcess.WaitReturns(exited, nil)
+		fakeProcess.IOReturns(fakeIO)
+
+		backend := runtime.NewGardenBackend(
+			lagertest.NewTestLogger("test"),
+			runtime.BackendConfig{RequestTimeout: time.Minute},
+			new(runtimefakes.FakeNetwork),
+			runtime.WithClient(fakeClient),
+		)
+
+		container, err := backend.Lookup("some-handle")
+		Expect(err).ToNot(HaveOccurred())
+
+		process, err = container.Attach("some-process", garden.ProcessIO{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("Wait", func() {
+		Context("when the process exits", func() {
+			BeforeEach(func() {
+				exited <- *containerd.NewExitStatus(42, time.Now(), nil)
+			})
+
+			It("returns its exit status", func() {
+				status, err := process.Wait()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(status).To(Equal(42))
+			})
+
+			It("drains its output before deleting it", func() {
+				fakeProcess.DeleteStub = func(context.Context, ...containerd.ProcessDeleteOpts) (*containerd.ExitStatus, error) {
+					Expect(fakeIO.WaitCallCount()).To(Equal(1))
+					return nil, nil
+				}
+
+				_, err := process.Wait()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeProcess.DeleteCallCount()).To(Equal(1))
+			})
+
+			It("only deletes it once when waited on again", func() {
+				_, err := process.Wait()
+				Expect(err).ToNot(HaveOccurred())
+
+				status, err := process.Wait()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(status).To(Equal(42))
+
+				Expect(fakeProcess.DeleteCallCount()).To(Equal(1))
+			})
+
+			Context("when deleting the process fails", func() {
+				BeforeEach(func() {
+					fakeProcess.DeleteReturns(nil, errors.New("nope"))
+				})
+
+				It("errors", func() {
+					_, err := process.Wait()
+					Expect(err).To(MatchError("delete process: nope"))
+				})
+			})
+		})
+
+		Context("when the exit status could not be determined", func() {
+			BeforeEach(func() {
+				exited <- *containerd.NewExitStatus(containerd.UnknownExitStatus, time.Time{}, errors.New("nope"))
+			})
+
+			It("errors without deleting the process", func() {
+				_, err := process.Wait()
+				Expect(err).To(MatchError("process exit status: nope"))
+				Expect(fakeProcess.DeleteCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Signal", func() {
+		It("sends SIGTERM to terminate the process", func() {
+			Expect(process.Signal(garden.SignalTerminate)).To(Succeed())
+
+			_, signal, _ := fakeProcess.KillArgsForCall(0)
+			Expect(signal).To(Equal(syscall.SIGTERM))
+		})
+
+		It("sends SIGKILL to kill the process", func() {
+			Expect(process.Signal(garden.SignalKill)).To(Succeed())
+
+			_, signal, _ := fakeProcess.KillArgsForCall(0)
+			Expect(signal).To(Equal(syscall.SIGKILL))
+		})
+	})
+
+	Describe("SetTTY", func() {
+		It("resizes the process's terminal", func() {
+			err := process.SetTTY(garden.TTYSpec{
+				WindowSize: &garden.WindowSize{Columns: 80, Rows: 24},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, width, height := fakeProcess.ResizeArgsForCall(0)
+			Expect(width).To(Equal(uint32(80)))
+			Expect(height).To(Equal(uint32(24)))
+		})
+
+		It("does nothing without a window size", func() {
+			Expect(process.SetTTY(garden.TTYSpec{})).To(Succeed())
+			Expect(fakeProcess.ResizeCallCount()).To(Equal(0))
+		})
+	})
+})
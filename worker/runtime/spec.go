@@ -0,0 +1,345 @@
+package runtime
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"code.cloudfoundry.org/garden"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	// InitMountPath is where the init binary is mounted in each container. It
+	// is run as the container's first process, keeping the container alive
+	// so that processes can be run in it.
+	InitMountPath = "/tmp/gdn-init"
+
+	defaultPath = "PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+)
+
+// SpecOptions configures the parts of the OCI spec which are shared by every
+// container.
+type SpecOptions struct {
+	// InitBinPath is the path on the host to the binary run as each
+	// container's init process.
+	InitBinPath string
+
+	// ResolvConfPath is the path on the host to the resolv.conf to mount into
+	// each container.
+	ResolvConfPath string
+
+	// MaxUID and MaxGID are the highest IDs on the host, which container root
+	// is mapped to in unprivileged containers. This matches the mapping used
+	// by Baggageclaim for unprivileged volumes.
+	MaxUID uint32
+	MaxGID uint32
+}
+
+// OciSpec converts a Garden container spec into an OCI runtime spec.
+//
+// The container's rootfs must be given as a raw:// URL, as it is with
+// Baggageclaim volumes. Unprivileged containers run in a user namespace with
+// a restricted set of capabilities.
+func OciSpec(gdn garden.ContainerSpec, opts SpecOptions) (*specs.Spec, error) {
+	if gdn.Handle == "" {
+		return nil, fmt.Errorf("handle must be specified")
+	}
+
+	rootfs, err := rootfsPath(gdn)
+	if err != nil {
+		return nil, err
+	}
+
+	mounts, err := bindMounts(gdn.BindMounts)
+	if err != nil {
+		return nil, err
+	}
+
+	mounts = append(mounts,
+		specs.Mount{
+			Source:      opts.InitBinPath,
+			Destination: InitMountPath,
+			Type:        "bind",
+			Options:     []string{"bind", "ro"},
+		},
+	)
+
+	if opts.ResolvConfPath != "" {
+		mounts = append(mounts, specs.Mount{
+			Source:      opts.ResolvConfPath,
+			Destination: "/etc/resolv.conf",
+			Type:        "bind",
+			Options:     []string{"bind", "ro"},
+		})
+	}
+
+	spec := &specs.Spec{
+		Version:  specs.Version,
+		Hostname: gdn.Handle,
+		Root: &specs.Root{
+			Path: rootfs,
+		},
+		Process: &specs.Process{
+			Args: []string{InitMountPath},
+			Cwd:  "/",
+			Env:  processEnv(gdn.Env),
+		},
+		Mounts: append(defaultMounts(gdn.Privileged), mounts...),
+		Linux: &specs.Linux{
+			Namespaces: namespaces(gdn.Privileged),
+			Resources:  resources(gdn.Limits, gdn.Privileged),
+		},
+	}
+
+	if gdn.Privileged {
+		spec.Process.Capabilities = capabilities(privilegedCapabilities)
+	} else {
+		spec.Process.Capabilities = capabilities(unprivilegedCapabilities)
+		spec.Linux.MaskedPaths = maskedPaths
+		spec.Linux.ReadonlyPaths = readonlyPaths
+		spec.Linux.UIDMappings = idMappings(opts.MaxUID)
+		spec.Linux.GIDMappings = idMappings(opts.MaxGID)
+	}
+
+	return spec, nil
+}
+
+func rootfsPath(gdn garden.ContainerSpec) (string, error) {
+	rootfsURL := gdn.Image.URI
+	if rootfsURL == "" {
+		rootfsURL = gdn.RootFSPath
+	}
+
+	u, err := url.Parse(rootfsURL)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Scheme != "raw" {
+		return "", fmt.Errorf("unsupported rootfs '%s': only raw:// rootfs URLs are supported", rootfsURL)
+	}
+
+	return u.Path, nil
+}
+
+func bindMounts(gdnMounts []garden.BindMount) ([]specs.Mount, error) {
+	mounts := []specs.Mount{}
+
+	for _, m := range gdnMounts {
+		if m.SrcPath == "" || m.DstPath == "" {
+			return nil, fmt.Errorf("bind mounts must specify both a source and destination path")
+		}
+
+		if !filepath.IsAbs(m.DstPath) {
+			return nil, fmt.Errorf("bind mount destination '%s' must be an absolute path", m.DstPath)
+		}
+
+		mode := "ro"
+		if m.Mode == garden.BindMountModeRW {
+			mode = "rw"
+		}
+
+		mounts = append(mounts, specs.Mount{
+			Source:      m.SrcPath,
+			Destination: m.DstPath,
+			Type:        "bind",
+			Options:     []string{"bind", mode},
+		})
+	}
+
+	return mounts, nil
+}
+
+func processEnv(env []string) []string {
+	for _, e := range env {
+		if len(e) > 5 && e[:5] == "PATH=" {
+			return env
+		}
+	}
+
+	return append([]string{defaultPath}, env...)
+}
+
+// resources converts the Garden limits. CPU limits are given in shares and
+// memory limits in bytes, both of which map directly onto cgroup settings.
+//
+// Unprivileged containers may only access the default set of devices.
+func resources(limits garden.Limits, privileged bool) *specs.LinuxResources {
+	resources := &specs.LinuxResources{
+		Devices: []specs.LinuxDeviceCgroup{
+			{Allow: privileged, Access: "rwm"},
+		},
+	}
+
+	if !privileged {
+		resources.Devices = append(resources.Devices, allowedDevices...)
+	}
+
+	if limits.CPU.LimitInShares > 0 {
+		shares := limits.CPU.LimitInShares
+		resources.CPU = &specs.LinuxCPU{Shares: &shares}
+	}
+
+	if limits.Memory.LimitInBytes > 0 {
+		memory := int64(limits.Memory.LimitInBytes)
+		resources.Memory = &specs.LinuxMemory{Limit: &memory, Swap: &memory}
+	}
+
+	if limits.Pid.Max > 0 {
+		resources.Pids = &specs.LinuxPids{Limit: int64(limits.Pid.Max)}
+	}
+
+	return resources
+}
+
+// namespaces isolates every container in its own namespaces, including
+// network. Unprivileged containers additionally get a user namespace.
+func namespaces(privileged bool) []specs.LinuxNamespace {
+	namespaces := []specs.LinuxNamespace{
+		{Type: specs.PIDNamespace},
+		{Type: specs.IPCNamespace},
+		{Type: specs.UTSNamespace},
+		{Type: specs.MountNamespace},
+		{Type: specs.NetworkNamespace},
+	}
+
+	if !privileged {
+		namespaces = append(namespaces, specs.LinuxNamespace{Type: specs.UserNamespace})
+	}
+
+	return namespaces
+}
+
+// idMappings maps container root to the highest ID on the host, leaving
+// every other ID as-is.
+func idMappings(maxID uint32) []specs.LinuxIDMapping {
+	return []specs.LinuxIDMapping{
+		{ContainerID: 0, HostID: maxID, Size: 1},
+		{ContainerID: 1, HostID: 1, Size: maxID - 1},
+	}
+}
+
+func capabilities(caps []string) *specs.LinuxCapabilities {
+	return &specs.LinuxCapabilities{
+		Bounding:    caps,
+		Effective:   caps,
+		Inheritable: caps,
+		Permitted:   caps,
+	}
+}
+
+func defaultMounts(privileged bool) []specs.Mount {
+	mounts := []specs.Mount{
+		{Destination: "/proc", Type: "proc", Source: "proc", Options: []string{"nosuid", "noexec", "nodev"}},
+		{Destination: "/dev", Type: "tmpfs", Source: "tmpfs", Options: []string{"nosuid", "strictatime", "mode=755", "size=65536k"}},
+		{Destination: "/dev/pts", Type: "devpts", Source: "devpts", Options: []string{"nosuid", "noexec", "newinstance", "ptmxmode=0666", "mode=0620", "gid=5"}},
+		{Destination: "/dev/shm", Type: "tmpfs", Source: "shm", Options: []string{"nosuid", "noexec", "nodev", "mode=1777", "size=65536k"}},
+		{Destination: "/dev/mqueue", Type: "mqueue", Source: "mqueue", Options: []string{"nosuid", "noexec", "nodev"}},
+		{Destination: "/run", Type: "tmpfs", Source: "tmpfs", Options: []string{"nosuid", "strictatime", "mode=755", "size=65536k"}},
+	}
+
+	if privileged {
+		return append(mounts,
+			specs.Mount{Destination: "/sys", Type: "sysfs", Source: "sysfs", Options: []string{"nosuid", "noexec", "nodev"}},
+			specs.Mount{Destination: "/sys/fs/cgroup", Type: "cgroup", Source: "cgroup", Options: []string{"nosuid", "noexec", "nodev"}},
+		)
+	}
+
+	return append(mounts,
+		specs.Mount{Destination: "/sys", Type: "sysfs", Source: "sysfs", Options: []string{"nosuid", "noexec", "nodev", "ro"}},
+	)
+}
+
+func int64Ptr(i int64) *int64 { return &i }
+
+var allowedDevices = []specs.LinuxDeviceCgroup{
+	{Allow: true, Type: "c", Major: int64Ptr(1), Minor: int64Ptr(3), Access: "rwm"},    // null
+	{Allow: true, Type: "c", Major: int64Ptr(1), Minor: int64Ptr(5), Access: "rwm"},    // zero
+	{Allow: true, Type: "c", Major: int64Ptr(1), Minor: int64Ptr(7), Access: "rwm"},    // full
+	{Allow: true, Type: "c", Major: int64Ptr(1), Minor: int64Ptr(8), Access: "rwm"},    // random
+	{Allow: true, Type: "c", Major: int64Ptr(1), Minor: int64Ptr(9), Access: "rwm"},    // urandom
+	{Allow: true, Type: "c", Major: int64Ptr(5), Minor: int64Ptr(0), Access: "rwm"},    // tty
+	{Allow: true, Type: "c", Major: int64Ptr(5), Minor: int64Ptr(2), Access: "rwm"},    // ptmx
+	{Allow: true, Type: "c", Major: int64Ptr(136), Minor: nil, Access: "rwm"},          // pts
+	{Allow: true, Type: "c", Major: int64Ptr(10), Minor: int64Ptr(200), Access: "rwm"}, // tun
+}
+
+var maskedPaths = []string{
+	"/proc/acpi",
+	"/proc/kcore",
+	"/proc/keys",
+	"/proc/latency_stats",
+	"/proc/timer_list",
+	"/proc/timer_stats",
+	"/proc/sched_debug",
+	"/proc/scsi",
+	"/sys/firmware",
+}
+
+var readonlyPaths = []string{
+	"/proc/asound",
+	"/proc/bus",
+	"/proc/fs",
+	"/proc/irq",
+	"/proc/sys",
+	"/proc/sysrq-trigger",
+}
+
+var unprivilegedCapabilities = []string{
+	"CAP_AUDIT_WRITE",
+	"CAP_CHOWN",
+	"CAP_DAC_OVERRIDE",
+	"CAP_FOWNER",
+	"CAP_FSETID",
+	"CAP_KILL",
+	"CAP_MKNOD",
+	"CAP_NET_BIND_SERVICE",
+	"CAP_NET_RAW",
+	"CAP_SETFCAP",
+	"CAP_SETGID",
+	"CAP_SETPCAP",
+	"CAP_SETUID",
+	"CAP_SYS_CHROOT",
+}
+
+var privilegedCapabilities = []string{
+	"CAP_AUDIT_CONTROL",
+	"CAP_AUDIT_READ",
+	"CAP_AUDIT_WRITE",
+	"CAP_BLOCK_SUSPEND",
+	"CAP_CHOWN",
+	"CAP_DAC_OVERRIDE",
+	"CAP_DAC_READ_SEARCH",
+	"CAP_FOWNER",
+	"CAP_FSETID",
+	"CAP_IPC_LOCK",
+	"CAP_IPC_OWNER",
+	"CAP_KILL",
+	"CAP_LEASE",
+	"CAP_LINUX_IMMUTABLE",
+	"CAP_MAC_ADMIN",
+	"CAP_MAC_OVERRIDE",
+	"CAP_MKNOD",
+	"CAP_NET_ADMIN",
+	"CAP_NET_BIND_SERVICE",
+	"CAP_NET_BROADCAST",
+	"CAP_NET_RAW",
+	"CAP_SETFCAP",
+	"CAP_SETGID",
+	"CAP_SETPCAP",
+	"CAP_SETUID",
+	"CAP_SYSLOG",
+	"CAP_SYS_ADMIN",
+	"CAP_SYS_BOOT",
+	"CAP_SYS_CHROOT",
+	"CAP_SYS_MODULE",
+	"CAP_SYS_NICE",
+	"CAP_SYS_PACCT",
+	"CAP_SYS_PTRACE",
+	"CAP_SYS_RAWIO",
+	"CAP_SYS_RESOURCE",
+	"CAP_SYS_TIME",
+	"CAP_SYS_TTY_CONFIG",
+	"CAP_WAKE_ALARM",
+}
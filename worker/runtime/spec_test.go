@@ -0,0 +1,206 @@
+package runtime_test
+
+import (
+	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/worker/runtime"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+var _ = Describe("OciSpec", func() {
+	var (
+		gdnSpec garden.ContainerSpec
+		opts    runtime.SpecOptions
+
+		spec    *specs.Spec
+		specErr error
+	)
+
+	BeforeEach(func() {
+		gdnSpec = garden.ContainerSpec{
+			Handle:     "some-handle",
+			RootFSPath: "raw:///some/rootfs",
+			Env:        []string{"FOO=bar"},
+		}
+
+		opts = runtime.SpecOptions{
+			InitBinPath:    "/some/init",
+			ResolvConfPath: "/some/resolv.conf",
+			MaxUID:         4294967294,
+			MaxGID:         4294967294,
+		}
+	})
+
+	JustBeforeEach(func() {
+		spec, specErr = runtime.OciSpec(gdnSpec, opts)
+	})
+
+	It("uses the raw rootfs path as the root", func() {
+		Expect(specErr).ToNot(HaveOccurred())
+		Expect(spec.Root.Path).To(Equal("/some/rootfs"))
+	})
+
+	It("uses the handle as the hostname", func() {
+		Expect(spec.Hostname).To(Equal("some-handle"))
+	})
+
+	It("runs the init binary", func() {
+		Expect(spec.Process.Args).To(Equal([]string{runtime.InitMountPath}))
+		Expect(spec.Mounts).To(ContainElement(specs.Mount{
+			Source:      "/some/init",
+			Destination: runtime.InitMountPath,
+			Type:        "bind",
+			Options:     []string{"bind", "ro"},
+		}))
+	})
+
+	It("mounts the resolv.conf", func() {
+		Expect(spec.Mounts).To(ContainElement(specs.Mount{
+			Source:      "/some/resolv.conf",
+			Destination: "/etc/resolv.conf",
+			Type:        "bind",
+			Options:     []string{"bind", "ro"},
+		}))
+	})
+
+	It("includes a default PATH in the environment", func() {
+		Expect(spec.Process.Env).To(ConsistOf(
+			"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
+			"FOO=bar",
+		))
+	})
+
+	It("isolates the container's network", func() {
+		Expect(spec.Linux.Namespaces).To(ContainElement(specs.LinuxNamespace{Type: specs.NetworkNamespace}))
+	})
+
+	Context("when the rootfs is given as an image URI", func() {
+		BeforeEach(func() {
+			gdnSpec.RootFSPath = ""
+			gdnSpec.Image = garden.ImageRef{URI: "raw:///some/image/rootfs"}
+		})
+
+		It("uses it as the root", func() {
+			Expect(specErr).ToNot(HaveOccurred())
+			Expect(spec.Root.Path).To(Equal("/some/image/rootfs"))
+		})
+	})
+
+	Context("when the rootfs is not raw", func() {
+		BeforeEach(func() {
+			gdnSpec.RootFSPath = "docker:///busybox"
+		})
+
+		It("errors", func() {
+			Expect(specErr).To(MatchError("unsupported rootfs 'docker:///busybox': only raw:// rootfs URLs are supported"))
+		})
+	})
+
+	Context("when no handle is given", func() {
+		BeforeEach(func() {
+			gdnSpec.Handle = ""
+		})
+
+		It("errors", func() {
+			Expect(specErr).To(HaveOccurred())
+		})
+	})
+
+	Context("with bind mounts", func() {
+		BeforeEach(func() {
+			gdnSpec.BindMounts = []garden.BindMount{
+				{SrcPath: "/some/volume", DstPath: "/tmp/build/input", Mode: garden.BindMountModeRW},
+				{SrcPath: "/some/certs", DstPath: "/etc/ssl/certs", Mode: garden.BindMountModeRO},
+			}
+		})
+
+		It("mounts them with the requested mode", func() {
+			Expect(spec.Mounts).To(ContainElement(specs.Mount{
+				Source:      "/some/volume",
+				Destination: "/tmp/build/input",
+				Type:        "bind",
+				Options:     []string{"bind", "rw"},
+			}))
+
+			Expect(spec.Mounts).To(ContainElement(specs.Mount{
+				Source:      "/some/certs",
+				Destination: "/etc/ssl/certs",
+				Type:        "bind",
+				Options:     []string{"bind", "ro"},
+			}))
+		})
+
+		Context("when a destination is relative", func() {
+			BeforeEach(func() {
+				gdnSpec.BindMounts = []garden.BindMount{
+					{SrcPath: "/some/volume", DstPath: "some/path"},
+				}
+			})
+
+			It("errors", func() {
+				Expect(specErr).To(MatchError("bind mount destination 'some/path' must be an absolute path"))
+			})
+		})
+	})
+
+	Context("with limits", func() {
+		BeforeEach(func() {
+			gdnSpec.Limits = garden.Limits{
+				CPU:    garden.CPULimits{LimitInShares: 512},
+				Memory: garden.MemoryLimits{LimitInBytes: 1024 * 1024},
+			}
+		})
+
+		It("configures the cgroup resources", func() {
+			Expect(*spec.Linux.Resources.CPU.Shares).To(Equal(uint64(512)))
+			Expect(*spec.Linux.Resources.Memory.Limit).To(Equal(int64(1024 * 1024)))
+		})
+	})
+
+	Context("without limits", func() {
+		It("does not limit cpu or memory", func() {
+			Expect(spec.Linux.Resources.CPU).To(BeNil())
+			Expect(spec.Linux.Resources.Memory).To(BeNil())
+		})
+	})
+
+	Context("when unprivileged", func() {
+		It("runs in a user namespace mapping root to the max id", func() {
+			Expect(spec.Linux.Namespaces).To(ContainElement(specs.LinuxNamespace{Type: specs.UserNamespace}))
+			Expect(spec.Linux.UIDMappings).To(Equal([]specs.LinuxIDMapping{
+				{ContainerID: 0, HostID: 4294967294, Size: 1},
+				{ContainerID: 1, HostID: 1, Size: 4294967293},
+			}))
+		})
+
+		It("does not grant admin capabilities", func() {
+			Expect(spec.Process.Capabilities.Bounding).ToNot(ContainElement("CAP_SYS_ADMIN"))
+		})
+
+		It("masks sensitive paths", func() {
+			Expect(spec.Linux.MaskedPaths).To(ContainElement("/proc/kcore"))
+		})
+	})
+
+	Context("when privileged", func() {
+		BeforeEach(func() {
+			gdnSpec.Privileged = true
+		})
+
+		It("does not run in a user namespace", func() {
+			Expect(spec.Linux.Namespaces).ToNot(ContainElement(specs.LinuxNamespace{Type: specs.UserNamespace}))
+			Expect(spec.Linux.UIDMappings).To(BeEmpty())
+		})
+
+		It("grants all capabilities", func() {
+			Expect(spec.Process.Capabilities.Bounding).To(ContainElement("CAP_SYS_ADMIN"))
+		})
+
+		It("permits access to all devices", func() {
+			Expect(spec.Linux.Resources.Devices).To(Equal([]specs.LinuxDeviceCgroup{
+				{Allow: true, Access: "rwm"},
+			}))
+		})
+	})
+})
@@ -0,0 +1,134 @@
+package runtime
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// User is the user and group a process is run as.
+type User struct {
+	UID  uint32
+	GID  uint32
+	Home string
+}
+
+var rootUser = User{UID: 0, GID: 0, Home: "/root"}
+
+// LookupUser resolves a username (or numeric UID) against the /etc/passwd
+// file in the given rootfs. An empty username resolves to root.
+func LookupUser(rootfs string, username string) (User, error) {
+	if username == "" || username == "root" {
+		return rootUser, nil
+	}
+
+	passwd, err := os.Open(filepath.Join(rootfs, "etc", "passwd"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return numericUser(username)
+		}
+
+		return User{}, err
+	}
+
+	defer passwd.Close()
+
+	user, found, err := parsePasswd(passwd, username)
+	if err != nil {
+		return User{}, err
+	}
+
+	if !found {
+		return numericUser(username)
+	}
+
+	return user, nil
+}
+
+func numericUser(username string) (User, error) {
+	uid, err := strconv.ParseUint(username, 10, 32)
+	if err != nil {
+		return User{}, fmt.Errorf("unknown user '%s'", username)
+	}
+
+	return User{UID: uint32(uid), GID: uint32(uid), Home: "/"}, nil
+}
+
+func parsePasswd(r io.Reader, username string) (User, bool, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ":")
+		if len(fields) < 6 || fields[0] != username {
+			continue
+		}
+
+		uid, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return User{}, false, fmt.Errorf("invalid uid for user '%s': %s", username, err)
+		}
+
+		gid, err := strconv.ParseUint(fields[3], 10, 32)
+		if err != nil {
+			return User{}, false, fmt.Errorf("invalid gid for user '%s': %s", username, err)
+		}
+
+		return User{UID: uint32(uid), GID: uint32(gid), Home: fields[5]}, true, nil
+	}
+
+	return User{}, false, scanner.Err()
+}
+
+// MaxID returns the highest ID mapped by the given /proc/self/uid_map or
+// /proc/self/gid_map file.
+func MaxID(path string) (uint32, error) {
+	idMap, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+
+	defer idMap.Close()
+
+	return parseMaxID(idMap)
+}
+
+func parseMaxID(r io.Reader) (uint32, error) {
+	var maxID uint64
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			continue
+		}
+
+		start, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil {
+			return 0, err
+		}
+
+		size, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return 0, err
+		}
+
+		if start+size-1 > maxID {
+			maxID = start + size - 1
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	// the full range would overflow the ID mappings, so leave room for the
+	// rest of the IDs to be mapped 1:1
+	if maxID >= 1<<32-1 {
+		maxID = 1<<32 - 2
+	}
+
+	return uint32(maxID), nil
+}
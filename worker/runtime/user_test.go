@@ -0,0 +1,80 @@
+package runtime_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/concourse/worker/runtime"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Users", func() {
+	var tmpdir string
+
+	BeforeEach(func() {
+		var err error
+		tmpdir, err = ioutil.TempDir("", "runtime-users")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpdir)).To(Succeed())
+	})
+
+	Describe("LookupUser", func() {
+		BeforeEach(func() {
+			Expect(os.MkdirAll(filepath.Join(tmpdir, "etc"), 0755)).To(Succeed())
+
+			err := ioutil.WriteFile(filepath.Join(tmpdir, "etc", "passwd"), []byte(
+				"root:x:0:0:root:/root:/bin/sh\n"+
+					"some-user:x:1000:1001:Some User:/home/some-user:/bin/sh\n",
+			), 0644)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("resolves users from the rootfs's /etc/passwd", func() {
+			user, err := runtime.LookupUser(tmpdir, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(user).To(Equal(runtime.User{UID: 1000, GID: 1001, Home: "/home/some-user"}))
+		})
+
+		It("defaults to root", func() {
+			user, err := runtime.LookupUser(tmpdir, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(user).To(Equal(runtime.User{UID: 0, GID: 0, Home: "/root"}))
+		})
+
+		It("accepts numeric ids", func() {
+			user, err := runtime.LookupUser(tmpdir, "1234")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(user).To(Equal(runtime.User{UID: 1234, GID: 1234, Home: "/"}))
+		})
+
+		It("errors for unknown users", func() {
+			_, err := runtime.LookupUser(tmpdir, "bogus")
+			Expect(err).To(MatchError("unknown user 'bogus'"))
+		})
+	})
+
+	Describe("MaxID", func() {
+		It("returns the highest mapped id", func() {
+			path := filepath.Join(tmpdir, "uid_map")
+			Expect(ioutil.WriteFile(path, []byte("         0          0      65536\n"), 0644)).To(Succeed())
+
+			maxID, err := runtime.MaxID(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(maxID).To(Equal(uint32(65535)))
+		})
+
+		It("leaves room to map the remaining ids when the full range is mapped", func() {
+			path := filepath.Join(tmpdir, "uid_map")
+			Expect(ioutil.WriteFile(path, []byte("         0          0 4294967295\n"), 0644)).To(Succeed())
+
+			maxID, err := runtime.MaxID(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(maxID).To(Equal(uint32(4294967294)))
+		})
+	})
+})
@@ -0,0 +1,221 @@
+package workercmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"code.cloudfoundry.org/garden/server"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/localip"
+	concourseCmd "github.com/concourse/concourse/cmd"
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/concourse/flag"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/grouper"
+)
+
+type ContainerdRuntime struct {
+	Bin     string    `long:"bin"      default:"containerd" description:"Path to 'containerd' executable (or leave as 'containerd' to find it in $PATH)."`
+	InitBin string    `long:"init-bin"                      description:"Path to the executable run as each container's init process. Defaults to 'init' in the packaged 'bin' directory."`
+	Config  flag.File `long:"config"                        description:"Path to a config file to use for containerd."`
+
+	CNIPluginsDir string   `long:"cni-plugins-dir" description:"Path to the directory containing the CNI plugins. Defaults to the packaged 'bin' directory."`
+	NetworkPool   string   `long:"network-pool" default:"10.80.0.0/16" description:"Network range from which container addresses are allocated."`
+	DNSServers    []string `long:"dns-server" description:"DNS server to configure in containers. Can be specified multiple times. Defaults to the non-loopback servers in the host's /etc/resolv.conf."`
+
+	RequestTimeout time.Duration `long:"request-timeout" default:"5m"  description:"How long to wait for requests to containerd to complete."`
+	MaxContainers  int           `long:"max-containers"  default:"250" description:"Maximum number of containers which may be run at once."`
+}
+
+// containerdRunner runs containerd alongside a Garden server backed by it,
+// allowing containers to be run without Guardian.
+func (cmd *WorkerCommand) containerdRunner(logger lager.Logger) (ifrit.Runner, error) {
+	binDir := concourseCmd.DiscoverAsset("bin")
+	if binDir != "" {
+		// ensure packaged 'containerd' executable is available in $PATH
+		err := os.Setenv("PATH", binDir+":"+os.Getenv("PATH"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	initBin := cmd.Containerd.InitBin
+	if initBin == "" {
+		initBin = filepath.Join(binDir, "init")
+	}
+
+	pluginsDir := cmd.Containerd.CNIPluginsDir
+	if pluginsDir == "" {
+		pluginsDir = binDir
+	}
+
+	containerdDir := filepath.Join(cmd.WorkDir.Path(), "containerd")
+
+	err := os.MkdirAll(containerdDir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create containerd dir: %s", err)
+	}
+
+	members := grouper.Members{}
+
+	dnsServers := cmd.Containerd.DNSServers
+	if cmd.Garden.DNS.Enable {
+		dnsProxyRunner, err := cmd.dnsProxyRunner(logger.Session("dns-proxy"))
+		if err != nil {
+			return nil, err
+		}
+
+		lip, err := localip.LocalIP()
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, grouper.Member{
+			Name: "dns-proxy",
+			Runner: concourseCmd.NewLoggingRunner(
+				logger.Session("dns-proxy-runner"),
+				dnsProxyRunner,
+			),
+		})
+
+		dnsServers = []string{lip}
+	}
+
+	resolvConfPath := filepath.Join(containerdDir, "resolv.conf")
+
+	err = writeResolvConf(resolvConfPath, dnsServers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write resolv.conf: %s", err)
+	}
+
+	maxUID, err := runtime.MaxID("/proc/self/uid_map")
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine max uid: %s", err)
+	}
+
+	maxGID, err := runtime.MaxID("/proc/self/gid_map")
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine max gid: %s", err)
+	}
+
+	network, err := runtime.NewCNINetwork(pluginsDir, runtime.CNINetworkConfig{
+		BridgeName: "concourse0",
+		Subnet:     cmd.Containerd.NetworkPool,
+		DataDir:    filepath.Join(containerdDir, "networks"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sock := filepath.Join(containerdDir, "containerd.sock")
+
+	containerdArgs := []string{
+		"--address", sock,
+		"--root", filepath.Join(containerdDir, "root"),
+		"--state", filepath.Join(containerdDir, "state"),
+	}
+
+	if cmd.Containerd.Config.Path() != "" {
+		containerdArgs = append(containerdArgs, "--config", cmd.Containerd.Config.Path())
+	}
+
+	containerdCmd := exec.Command(cmd.Containerd.Bin, containerdArgs...)
+	containerdCmd.Stdout = os.Stdout
+	containerdCmd.Stderr = os.Stderr
+	containerdCmd.SysProcAttr = &syscall.SysProcAttr{
+		Pdeathsig: syscall.SIGKILL,
+	}
+
+	backend := runtime.NewGardenBackend(
+		logger.Session("containerd-backend"),
+		runtime.BackendConfig{
+			Address:        sock,
+			RequestTimeout: cmd.Containerd.RequestTimeout,
+			MaxContainers:  cmd.Containerd.MaxContainers,
+			Spec: runtime.SpecOptions{
+				InitBinPath:    initBin,
+				ResolvConfPath: resolvConfPath,
+				MaxUID:         maxUID,
+				MaxGID:         maxGID,
+			},
+		},
+		network,
+	)
+
+	gardenServer := server.New(
+		"tcp",
+		cmd.bindAddr(),
+		0,
+		backend,
+		logger,
+	)
+
+	members = append(members,
+		grouper.Member{
+			Name: "containerd",
+			Runner: concourseCmd.NewLoggingRunner(
+				logger.Session("containerd-runner"),
+				cmdRunner{containerdCmd},
+			),
+		},
+		grouper.Member{
+			Name:   "containerd-garden-server",
+			Runner: gardenServerRunner{logger.Session("containerd-garden-server"), gardenServer},
+		},
+	)
+
+	// the garden server must start after containerd, as it connects to it on
+	// start
+	return grouper.NewOrdered(os.Interrupt, members), nil
+}
+
+// writeResolvConf writes the resolv.conf mounted into each container. If no
+// servers are given, the host's servers are used, skipping any loopback
+// addresses as they are unreachable from the container's network namespace.
+func writeResolvConf(path string, servers []string) error {
+	if len(servers) == 0 {
+		hostResolvConf, err := ioutil.ReadFile("/etc/resolv.conf")
+		if err != nil {
+			return err
+		}
+
+		servers = nonLoopbackNameservers(hostResolvConf)
+	}
+
+	buf := new(bytes.Buffer)
+	for _, server := range servers {
+		fmt.Fprintf(buf, "nameserver %s\n", server)
+	}
+
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
+
+func nonLoopbackNameservers(resolvConf []byte) []string {
+	servers := []string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(resolvConf))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "nameserver" {
+			continue
+		}
+
+		ip := net.ParseIP(fields[1])
+		if ip == nil || ip.IsLoopback() {
+			continue
+		}
+
+		servers = append(servers, fields[1])
+	}
+
+	return servers
+}
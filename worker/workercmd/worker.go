@@ -22,6 +22,11 @@ import (
 	"github.com/tedsuo/ifrit/sigmon"
 )
 
+const (
+	containerdRuntime = "containerd"
+	houdiniRuntime    = "houdini"
+)
+
 type WorkerCommand struct {
 	Worker WorkerConfig
 
@@ -49,8 +54,12 @@ type WorkerCommand struct {
 
 	ConnectionDrainTimeout time.Duration `long:"connection-drain-timeout" default:"1h" description:"Duration after which a worker should give up draining forwarded connections on shutdown."`
 
+	Runtime string `long:"runtime" default:"guardian" choice:"guardian" choice:"containerd" choice:"houdini" description:"Runtime to use for running containers. The containerd runtime is only available on Linux."`
+
 	Garden GardenBackend `group:"Garden Configuration" namespace:"garden"`
 
+	Containerd ContainerdRuntime `group:"Containerd Configuration" namespace:"containerd"`
+
 	ExternalGardenURL flag.URL `long:"external-garden-url" description:"API endpoint of an externally managed Garden server to use instead of running the embedded Garden server."`
 
 	Baggageclaim baggageclaimcmd.BaggageclaimCommand `group:"Baggageclaim Configuration" namespace:"baggageclaim"`
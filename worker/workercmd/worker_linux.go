@@ -40,7 +40,13 @@ func (cmd WorkerCommand) LessenRequirements(prefix string, command *flags.Comman
 	command.FindOptionByLongName(prefix + "baggageclaim-volumes").Required = false
 }
 
+var ErrHoudiniWithContainerd = errors.New("--garden-use-houdini cannot be used with --runtime containerd")
+
 func (cmd *WorkerCommand) gardenRunner(logger lager.Logger) (atc.Worker, ifrit.Runner, error) {
+	if cmd.Garden.UseHoudini && cmd.Runtime == containerdRuntime {
+		return atc.Worker{}, nil, ErrHoudiniWithContainerd
+	}
+
 	err := cmd.checkRoot()
 	if err != nil {
 		return atc.Worker{}, nil, err
@@ -64,9 +70,12 @@ func (cmd *WorkerCommand) gardenRunner(logger lager.Logger) (atc.Worker, ifrit.R
 	}
 
 	var runner ifrit.Runner
-	if cmd.Garden.UseHoudini {
+	switch {
+	case cmd.Garden.UseHoudini || cmd.Runtime == houdiniRuntime:
 		runner, err = cmd.houdiniRunner(logger)
-	} else {
+	case cmd.Runtime == containerdRuntime:
+		runner, err = cmd.containerdRunner(logger)
+	default:
 		runner, err = cmd.gdnRunner(logger)
 	}
 	if err != nil {
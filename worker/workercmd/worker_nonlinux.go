@@ -3,6 +3,7 @@
 package workercmd
 
 import (
+	"fmt"
 	"runtime"
 
 	"code.cloudfoundry.org/lager"
@@ -13,6 +14,8 @@ import (
 
 type GardenBackend struct{}
 
+type ContainerdRuntime struct{}
+
 type Certs struct{}
 
 func (cmd WorkerCommand) LessenRequirements(prefix string, command *flags.Command) {
@@ -23,6 +26,11 @@ func (cmd WorkerCommand) LessenRequirements(prefix string, command *flags.Comman
 func (cmd *WorkerCommand) gardenRunner(logger lager.Logger) (atc.Worker, ifrit.Runner, error) {
 	worker := cmd.Worker.Worker()
 	worker.Platform = runtime.GOOS
+
+	if cmd.Runtime == containerdRuntime {
+		return atc.Worker{}, nil, fmt.Errorf("the containerd runtime is not supported on %s", runtime.GOOS)
+	}
+
 	var err error
 	worker.Name, err = cmd.workerName()
 	if err != nil {